@@ -0,0 +1,270 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/roles"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var grantTests = []struct {
+	email         string
+	role          string
+	ttl           string
+	expectGranted bool
+	expectErr     bool
+}{
+	{"user@example.com", "admin", "4h", true, false},
+	{"User@Example.com", "Admin", "30m", true, false},
+	{"user@example.com", "member", "4h", false, true},
+	{"user@example.com", "owner", "4h", false, true},
+	{"unknown@example.com", "admin", "4h", false, true},
+	{"user@example.com", "admin", "48h", false, true},
+	{"user@example.com", "admin", "30s", false, true},
+	{"user@example.com", "admin", "soon", false, true},
+}
+
+func TestGrant(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	granted := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":5,"name":"member"}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com","roleID":5}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/access-grants",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			var grant models.AccessGrant
+			json.Unmarshal(b, &grant)
+			test.AssertEquals(t, grant.UsersID, "1")
+			if grant.TTL <= 0 {
+				t.Errorf("Expected a positive TTL but got %d", grant.TTL)
+			}
+			granted = true
+			grant.ID = "grant1"
+			grant.PreviousRoleID = 5
+			b, _ = json.Marshal(grant)
+			w.Write(b)
+		},
+	)
+
+	for _, data := range grantTests {
+		t.Logf("Data: %+v", data)
+		granted = false
+
+		// test
+		err := CmdGrant(data.email, data.role, data.ttl, "INC-1234", New(settings), users.New(settings), invites.New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectGranted), fmt.Sprintf("%t", granted))
+	}
+}
+
+var grantsListTests = []struct {
+	grants     string
+	expectRows []string
+}{
+	{`[{"id":"grant1","usersID":"1","email":"user@example.com","roleID":1,"previousRoleID":5,"grantedBy":"lead@example.com","expiresAt":"2026-10-17T12:00:00Z","reason":"INC-1234"}]`, []string{"PREVIOUS ROLE", "grant1", "user@example.com", "admin", "member", "lead@example.com", "INC-1234"}},
+	{`[{"id":"grant2","usersID":"1","email":"user@example.com","roleID":9,"previousRoleID":5,"expiresAt":"2026-10-17T12:00:00Z"}]`, []string{"grant2", "9", "member"}},
+	{`[]`, []string{"No access grants are active"}},
+}
+
+func TestGrantsList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	grants := ""
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":5,"name":"member"}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/access-grants",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, grants)
+		},
+	)
+
+	for _, data := range grantsListTests {
+		t.Logf("Data: %+v", data)
+		grants = data.grants
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdGrantsList(New(settings), invites.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the output to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+var revokeTests = []struct {
+	grantID   string
+	expectErr bool
+}{
+	{"grant1", false},
+	{"unknown", true},
+}
+
+func TestRevoke(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	revoked := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/access-grants/grant1",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			revoked = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range revokeTests {
+		t.Logf("Data: %+v", data)
+		revoked = false
+
+		// test
+		err := CmdRevoke(data.grantID, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", revoked))
+	}
+}
+
+var adviseTests = []struct {
+	days       int
+	jsonOutput bool
+	csvOutput  bool
+	expectRows []string
+	expectErr  bool
+}{
+	{90, false, false, []string{"Access review of the operations from", "USED PERMISSIONS", "user@example.com", "member", "logs"}, false},
+	{90, true, false, []string{`"email": "user@example.com"`, `"role": "member"`, `"operations": 1`}, false},
+	{30, false, true, []string{"email,role,operations", "user@example.com,member,1,logs"}, false},
+	{90, true, true, nil, true},
+	{0, false, false, nil, true},
+}
+
+func TestAdvise(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":5,"name":"member"}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com","roleID":5}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/audit",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","timestamp":"2026-10-01T12:00:00Z","actorEmail":"User@example.com","action":"logs view","target":"code-1"}]`)
+		},
+	)
+
+	for _, data := range adviseTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdAdvise(data.days, data.jsonOutput, data.csvOutput, audit.New(settings), users.New(settings), invites.New(settings))
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the output to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+var adviseRoles = []models.Role{
+	{ID: 1, Name: "admin"},
+	{ID: 7, Name: "deploy-only", Custom: true, Permissions: []string{"deploy"}},
+	{ID: 8, Name: "observer", Custom: true, Permissions: []string{"logs", "metrics"}},
+}
+
+var adviseRoleTests = []struct {
+	role      models.Role
+	actions   []string
+	used      []string
+	suggested string
+}{
+	{adviseRoles[0], []string{"logs view", "metrics cpu"}, []string{"logs", "metrics"}, "observer"},
+	{adviseRoles[0], []string{"deploy", "redeploy app01"}, []string{"deploy"}, "deploy-only"},
+	{adviseRoles[0], []string{"deploy", "vars set"}, []string{"deploy", "vars"}, ""},
+	{adviseRoles[0], []string{}, []string{}, ""},
+	{adviseRoles[0], []string{"something new"}, roles.Permissions, ""},
+	{adviseRoles[1], []string{"deploy"}, []string{"deploy"}, ""},
+}
+
+func TestAdviseRole(t *testing.T) {
+	for _, data := range adviseRoleTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		a := advise("user@example.com", &data.role, data.actions, adviseRoles, 90)
+
+		// assert
+		if !reflect.DeepEqual(a.Used, data.used) {
+			t.Errorf("Expected used permissions %v but got %v", data.used, a.Used)
+		}
+		if a.SuggestedRole != data.suggested {
+			t.Errorf("Expected the suggested role \"%s\" but got \"%s\"", data.suggested, a.SuggestedRole)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package access
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/roles"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// auditPageSize is the number of audit events requested at a time
+const auditPageSize = 500
+
+// actionPermissions maps the first word of an audit event's action to the
+// permission needed to perform it. Actions that are not listed are assumed to
+// need every permission, so that an unknown action never leads to a
+// downgrade.
+var actionPermissions = map[string]string{
+	"billing":      "billing",
+	"cert":         "certs",
+	"certs":        "certs",
+	"ssl":          "certs",
+	"sites":        "certs",
+	"domain":       "certs",
+	"console":      "console",
+	"run":          "console",
+	"rake":         "console",
+	"deploy":       "deploy",
+	"redeploy":     "deploy",
+	"rollback":     "deploy",
+	"releases":     "deploy",
+	"builds":       "deploy",
+	"git":          "deploy",
+	"worker":       "deploy",
+	"cron":         "deploy",
+	"environment":  "environments",
+	"environments": "environments",
+	"logs":         "logs",
+	"metrics":      "metrics",
+	"service":      "services",
+	"services":     "services",
+	"db":           "services",
+	"volumes":      "services",
+	"maintenance":  "services",
+	"invite":       "users",
+	"invites":      "users",
+	"users":        "users",
+	"roles":        "users",
+	"access":       "users",
+	"vars":         "vars",
+}
+
+// readPermissions only allow looking at an environment, not changing it
+var readPermissions = map[string]bool{"logs": true, "metrics": true}
+
+// advice is the least-privilege review of a single user
+type advice struct {
+	Email          string   `json:"email"`
+	Role           string   `json:"role"`
+	Operations     int      `json:"operations"`
+	Used           []string `json:"usedPermissions"`
+	Unused         []string `json:"unusedPermissions"`
+	SuggestedRole  string   `json:"suggestedRole,omitempty"`
+	Recommendation string   `json:"recommendation"`
+}
+
+func CmdAdvise(days int, jsonOutput, csvOutput bool, ia audit.IAudit, iu users.IUsers, ii invites.IInvites) error {
+	if jsonOutput && csvOutput {
+		return errors.New("Only one of --json and --csv can be given")
+	}
+	if days < 1 {
+		return fmt.Errorf("--days must be greater than 0")
+	}
+	until := time.Now()
+	since := until.AddDate(0, 0, -days)
+	orgUsers, err := iu.List()
+	if err != nil {
+		return err
+	}
+	orgRoles, err := ii.ListRoles()
+	if err != nil {
+		return err
+	}
+	events, err := auditEvents(since, until, ia)
+	if err != nil {
+		return err
+	}
+	operations := map[string][]string{}
+	for _, e := range events {
+		email := strings.ToLower(e.ActorEmail)
+		operations[email] = append(operations[email], e.Action)
+	}
+	advices := []advice{}
+	for _, u := range *orgUsers {
+		var role *models.Role
+		for i := range *orgRoles {
+			if (*orgRoles)[i].ID == u.RoleID {
+				role = &(*orgRoles)[i]
+				break
+			}
+		}
+		if role == nil {
+			config.Warn(config.WarningSkipped, "Skipping %s since their role %d was not found", u.Email, u.RoleID)
+			continue
+		}
+		advices = append(advices, advise(u.Email, role, operations[strings.ToLower(u.Email)], *orgRoles, days))
+	}
+	sort.Sort(sortedAdvice(advices))
+
+	if jsonOutput {
+		b, _ := json.MarshalIndent(advices, "", "    ")
+		logrus.Println(string(b))
+		return nil
+	}
+	if csvOutput {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"email", "role", "operations", "used_permissions", "unused_permissions", "suggested_role", "recommendation"})
+		for _, a := range advices {
+			w.Write([]string{a.Email, a.Role, strconv.Itoa(a.Operations), strings.Join(a.Used, " "), strings.Join(a.Unused, " "), a.SuggestedRole, a.Recommendation})
+		}
+		w.Flush()
+		logrus.Print(strings.TrimSuffix(buf.String(), "\n"))
+		return nil
+	}
+
+	logrus.Printf("Access review of the operations from %s to %s", config.FormatTimestamp(since), config.FormatTimestamp(until))
+	if len(advices) == 0 {
+		logrus.Println("No users found")
+		return nil
+	}
+	data := [][]string{{"USER", "ROLE", "OPERATIONS", "USED PERMISSIONS", "SUGGESTED ROLE", "RECOMMENDATION"}}
+	for _, a := range advices {
+		used := strings.Join(a.Used, ", ")
+		if used == "" {
+			used = "-"
+		}
+		suggested := a.SuggestedRole
+		if suggested == "" {
+			suggested = "-"
+		}
+		data = append(data, []string{a.Email, a.Role, strconv.Itoa(a.Operations), used, suggested, a.Recommendation})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// auditEvents retrieves every audit event between the given times
+func auditEvents(since, until time.Time, ia audit.IAudit) ([]models.AuditEvent, error) {
+	events := []models.AuditEvent{}
+	for page := 1; ; page++ {
+		pageEvents, err := ia.List(since, until, page, auditPageSize)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *pageEvents...)
+		if len(*pageEvents) < auditPageSize {
+			return events, nil
+		}
+	}
+}
+
+// advise compares the permissions of the user's role with the permissions
+// needed for the given actions and suggests the role with the fewest
+// permissions that still allows all of them
+func advise(email string, role *models.Role, actions []string, orgRoles []models.Role, days int) advice {
+	has := roles.RolePermissions(role)
+	usedSet := map[string]bool{}
+	for _, action := range actions {
+		for _, p := range actionPermission(action) {
+			usedSet[p] = true
+		}
+	}
+	a := advice{
+		Email:      email,
+		Role:       role.Name,
+		Operations: len(actions),
+		Used:       setToList(usedSet),
+		Unused:     []string{},
+	}
+	for _, p := range has {
+		if !usedSet[p] {
+			a.Unused = append(a.Unused, p)
+		}
+	}
+	if len(actions) == 0 {
+		a.Recommendation = fmt.Sprintf("No operations in %d days, consider removing their access", days)
+		return a
+	}
+	if len(a.Unused) == 0 {
+		a.Recommendation = "Keep, every permission of the role was used"
+		return a
+	}
+	var best *models.Role
+	bestCount := len(has)
+	for i, r := range orgRoles {
+		permissions := roles.RolePermissions(&r)
+		if len(permissions) >= bestCount || !covers(permissions, usedSet) {
+			continue
+		}
+		best = &orgRoles[i]
+		bestCount = len(permissions)
+	}
+	readOnly := true
+	for p := range usedSet {
+		if !readPermissions[p] {
+			readOnly = false
+			break
+		}
+	}
+	summary := fmt.Sprintf("%s but only used %s", role.Name, strings.Join(a.Used, ", "))
+	if readOnly {
+		summary = fmt.Sprintf("%s but only ran read operations", role.Name)
+	}
+	if best == nil {
+		a.Recommendation = fmt.Sprintf("%s, consider a custom role with only %s", summary, strings.Join(a.Used, ", "))
+		return a
+	}
+	a.SuggestedRole = best.Name
+	a.Recommendation = fmt.Sprintf("%s, consider downgrading to %s", summary, best.Name)
+	return a
+}
+
+// actionPermission returns the permissions needed to perform the action
+func actionPermission(action string) []string {
+	fields := strings.Fields(strings.ToLower(action))
+	if len(fields) > 0 {
+		if p, ok := actionPermissions[fields[0]]; ok {
+			return []string{p}
+		}
+	}
+	return roles.Permissions
+}
+
+// covers returns whether the permissions include every permission in the set
+func covers(permissions []string, set map[string]bool) bool {
+	has := map[string]bool{}
+	for _, p := range permissions {
+		has[p] = true
+	}
+	for p := range set {
+		if !has[p] {
+			return false
+		}
+	}
+	return true
+}
+
+// setToList returns the sorted members of the set
+func setToList(set map[string]bool) []string {
+	list := []string{}
+	for p := range set {
+		list = append(list, p)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// sortedAdvice is a wrapper for advice in order to sort it by the number of
+// unused permissions, most first, and then by email
+type sortedAdvice []advice
+
+func (as sortedAdvice) Len() int {
+	return len(as)
+}
+
+func (as sortedAdvice) Swap(i, j int) {
+	as[i], as[j] = as[j], as[i]
+}
+
+func (as sortedAdvice) Less(i, j int) bool {
+	if len(as[i].Unused) != len(as[j].Unused) {
+		return len(as[i].Unused) > len(as[j].Unused)
+	}
+	return as[i].Email < as[j].Email
+}
@@ -0,0 +1,181 @@
+package access
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "access",
+	ShortHelp: "Grant users of the given organization a role for a limited time",
+	LongHelp: "The `access` command allows you to give a user of your environment's organization more access for a limited time, such as to troubleshoot production, instead of changing their role for good. " +
+		"When the time is up the user's previous role is restored automatically, even if no one runs the CLI. " +
+		"It can also review whether each user's role fits what they actually do, see [access advise](#access-advise). " +
+		"The access command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(AdviseSubCmd.Name, AdviseSubCmd.ShortHelp, help.Render(AdviseSubCmd.LongHelp), AdviseSubCmd.CmdFunc(settings))
+			cmd.CommandLong(GrantSubCmd.Name, GrantSubCmd.ShortHelp, help.Render(GrantSubCmd.LongHelp), GrantSubCmd.CmdFunc(settings))
+			cmd.CommandLong(GrantsSubCmd.Name, GrantsSubCmd.ShortHelp, help.Render(GrantsSubCmd.LongHelp), GrantsSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RevokeSubCmd.Name, RevokeSubCmd.ShortHelp, help.Render(RevokeSubCmd.LongHelp), RevokeSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var AdviseSubCmd = models.Command{
+	Name:      "advise",
+	ShortHelp: "Suggest role downgrades based on what users actually did",
+	LongHelp: "`access advise` compares the role of each user of your environment's organization with the operations they performed according to the [audit](#audit) trail, by default over the last 90 days, and suggests a role with fewer permissions where one would have been enough. " +
+		"For example, an admin who only viewed logs and metrics is reported as having only run read operations. " +
+		"Users who performed no operations at all are reported as well, since they may no longer need access. " +
+		"Built-in roles are treated as having every permission, and operations the CLI does not recognize are treated as needing every permission so that they never lead to a downgrade. " +
+		"No roles are changed, use [users update](#users-update) or [roles grant](#roles-grant) to act on a suggestion. " +
+		"Use `--json` or `--csv` to export the review, such as for a periodic access review. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" access advise\n" +
+		"datica -E \"<your_env_alias>\" access advise --days 30 --csv > access-review.csv\n```",
+	JSONOutput: []advice{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			days := subCmd.IntOpt("days", 90, "The number of days of the audit trail to review")
+			jsonOutput := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the review as JSON"))
+			csvOutput := subCmd.BoolOpt("csv", false, "Output the review as CSV")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdAdvise(*days, *jsonOutput, *csvOutput, audit.New(settings), users.New(settings), invites.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--days] [--json | --csv]"
+		}
+	},
+}
+
+var GrantSubCmd = models.Command{
+	Name:      "grant",
+	ShortHelp: "Grant a user a role for a limited time",
+	LongHelp: "`access grant` gives a user of your environment's organization the role given by `--role` for the time given by `--ttl`, such as `30m` or `4h`, up to " + maxTTLString + ". " +
+		"Afterwards the user's previous role is restored automatically. " +
+		"The role can be any built-in or custom role shown by [roles list](#roles-list). " +
+		"Give the reason for the access with `--reason` so that it shows up in [access grants list](#access-grants-list) and the [audit](#audit) trail. " +
+		"You will be asked to confirm before access is granted, use the global `--yes` flag to skip the confirmation. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" access grant user@example.com --role admin --ttl 4h\n" +
+		"datica -E \"<your_env_alias>\" access grant user@example.com --role admin --ttl 30m --reason \"INC-1234 database failover\"\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			email := subCmd.StringArg("EMAIL", "", "The email address of the user to grant the role to")
+			role := subCmd.StringOpt("r role", "", "The name of the role to grant")
+			ttl := subCmd.StringOpt("t ttl", "", "How long the user has the role for, i.e. '4h'")
+			reason := subCmd.StringOpt("reason", "", "Why the access is needed")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdGrant(*email, *role, *ttl, *reason, New(settings), users.New(settings), invites.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "EMAIL --role --ttl [--reason]"
+		}
+	},
+}
+
+var GrantsSubCmd = models.Command{
+	Name:      "grants",
+	ShortHelp: "Manage the active access grants of the given organization",
+	LongHelp: "`access grants` shows the users of your environment's organization who have been granted a role for a limited time. " +
+		"The grants command can not be run directly but has sub commands.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(GrantsListSubCmd.Name, GrantsListSubCmd.ShortHelp, help.Render(GrantsListSubCmd.LongHelp), GrantsListSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var GrantsListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the active access grants",
+	LongHelp: "`access grants list` lists the access grants of your environment's organization that have not expired or been revoked, along with who granted them, why, and when each one expires. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" access grants list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdGrantsList(New(settings), invites.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RevokeSubCmd = models.Command{
+	Name:      "revoke",
+	ShortHelp: "End an access grant early",
+	LongHelp: "`access revoke` ends an access grant before it expires and restores the user's previous role right away. " +
+		"The ID of each grant is shown by [access grants list](#access-grants-list). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" access revoke 8f5b9f2e-6a7c-4c1e-9d0b-2b1f0c3e4a5d\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			grantID := subCmd.StringArg("GRANT_ID", "", "The ID of the access grant to end")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRevoke(*grantID, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "GRANT_ID"
+		}
+	},
+}
+
+// IAccess
+type IAccess interface {
+	Grant(grant *models.AccessGrant) (*models.AccessGrant, error)
+	List() (*[]models.AccessGrant, error)
+	Revoke(grantID string) error
+}
+
+// SAccess is a concrete implementation of IAccess
+type SAccess struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IAccess
+func New(settings *models.Settings) IAccess {
+	return &SAccess{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,93 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// maxTTL is the longest time a role can be granted for. Longer access should
+// be given by changing the user's role.
+const maxTTL = 24 * time.Hour
+
+const maxTTLString = "24h"
+
+func CmdGrant(email, roleName, ttl, reason string, ia IAccess, iu users.IUsers, ii invites.IInvites, ip prompts.IPrompts) error {
+	d, err := time.ParseDuration(ttl)
+	if err != nil || d < time.Minute {
+		return fmt.Errorf("Invalid TTL \"%s\". TTLs must be a duration of at least a minute such as 30m or 4h", ttl)
+	}
+	if d > maxTTL {
+		return fmt.Errorf("Access can be granted for at most %s. To give %s the role for longer, run \"datica users update %s --role %s\"", maxTTLString, email, email, roleName)
+	}
+	orgUsers, err := iu.List()
+	if err != nil {
+		return err
+	}
+	var user *models.OrgUser
+	for i := range *orgUsers {
+		if strings.EqualFold((*orgUsers)[i].Email, email) {
+			user = &(*orgUsers)[i]
+			break
+		}
+	}
+	if user == nil {
+		return fmt.Errorf("A user with email %s was not found", email)
+	}
+	role, err := invites.FindRole(roleName, ii)
+	if err != nil {
+		return err
+	}
+	if user.RoleID == role.ID {
+		return fmt.Errorf("%s already has the %s role", email, role.Name)
+	}
+	if reason == "" {
+		logrus.Warnln("No reason was given. Give one with --reason so that others know why the access was granted.")
+	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to grant %s the %s role for %s? (y/n) ", email, role.Name, d))
+	if err != nil {
+		return err
+	}
+	grant, err := ia.Grant(&models.AccessGrant{
+		UsersID: user.ID,
+		RoleID:  role.ID,
+		TTL:     int64(d / time.Second),
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
+	}
+	expires := config.FormatTimestamp(time.Now().Add(d))
+	if grant.ExpiresAt != "" {
+		expires = config.FormatTimestampString(grant.ExpiresAt)
+	}
+	logrus.Printf("Granted %s the %s role until %s (grant ID = %s). Their previous role is restored automatically afterwards, or end it early with \"datica access revoke %s\"", email, role.Name, expires, grant.ID, grant.ID)
+	return nil
+}
+
+// Grant gives the user the role of the grant until its TTL runs out
+func (a *SAccess) Grant(grant *models.AccessGrant) (*models.AccessGrant, error) {
+	b, err := json.Marshal(grant)
+	if err != nil {
+		return nil, err
+	}
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/orgs/%s/access-grants", a.Settings.AuthHost, a.Settings.AuthHostVersion, a.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var created models.AccessGrant
+	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
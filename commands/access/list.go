@@ -0,0 +1,69 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdGrantsList(ia IAccess, ii invites.IInvites) error {
+	grants, err := ia.List()
+	if err != nil {
+		return err
+	}
+	if grants == nil || len(*grants) == 0 {
+		logrus.Println("No access grants are active")
+		return nil
+	}
+	roles, err := ii.ListRoles()
+	if err != nil {
+		return err
+	}
+	roleNames := map[int]string{}
+	for _, r := range *roles {
+		roleNames[r.ID] = r.Name
+	}
+	data := [][]string{{"ID", "USER", "ROLE", "PREVIOUS ROLE", "GRANTED BY", "EXPIRES", "REASON"}}
+	for _, g := range *grants {
+		data = append(data, []string{g.ID, g.Email, roleName(g.RoleID, roleNames), roleName(g.PreviousRoleID, roleNames), g.GrantedBy, config.FormatTimestampString(g.ExpiresAt), g.Reason})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// roleName returns the name of the role with the given ID, or the ID itself
+// if the role no longer exists
+func roleName(roleID int, roleNames map[int]string) string {
+	if name, ok := roleNames[roleID]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", roleID)
+}
+
+// List lists the access grants of the organization that are still active
+func (a *SAccess) List() (*[]models.AccessGrant, error) {
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/access-grants", a.Settings.AuthHost, a.Settings.AuthHostVersion, a.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var grants []models.AccessGrant
+	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, &grants)
+	if err != nil {
+		return nil, err
+	}
+	return &grants, nil
+}
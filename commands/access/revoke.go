@@ -0,0 +1,26 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+)
+
+func CmdRevoke(grantID string, ia IAccess) error {
+	err := ia.Revoke(grantID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Ended access grant %s. The user's previous role has been restored", grantID)
+	return nil
+}
+
+// Revoke ends the access grant and restores the user's previous role
+func (a *SAccess) Revoke(grantID string) error {
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/access-grants/%s", a.Settings.AuthHost, a.Settings.AuthHostVersion, a.Settings.OrgID, grantID), headers)
+	if err != nil {
+		return err
+	}
+	return a.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,154 @@
+package admin
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// activitySummary is the number of operations of a single class run by a
+// single user
+type activitySummary struct {
+	Email string
+	Class string
+	Count int
+	Last  time.Time
+}
+
+func CmdActivity(days int, email string, ia IAdmin, iu users.IUsers) error {
+	if days <= 0 {
+		return fmt.Errorf("The number of days must be greater than 0")
+	}
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -days)
+	events, err := ia.Activity(since, until)
+	if err != nil {
+		return err
+	}
+	orgUsers, err := iu.List()
+	if err != nil {
+		return err
+	}
+	if email != "" {
+		found := false
+		for _, u := range *orgUsers {
+			if strings.EqualFold(u.Email, email) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Could not find a user with the email \"%s\" in this organization. You can list users with the \"datica users list\" command.", email)
+		}
+	}
+
+	summaries := map[string]*activitySummary{}
+	active := map[string]bool{}
+	for _, e := range *events {
+		if email != "" && !strings.EqualFold(e.Email, email) {
+			continue
+		}
+		key := e.Email + "\x00" + e.Class
+		s, ok := summaries[key]
+		if !ok {
+			s = &activitySummary{Email: e.Email, Class: e.Class}
+			summaries[key] = s
+		}
+		s.Count++
+		if t, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil && t.After(s.Last) {
+			s.Last = t
+		}
+		active[strings.ToLower(e.Email)] = true
+	}
+
+	logrus.Printf("Activity from %s to %s", config.FormatTimestamp(since), config.FormatTimestamp(until))
+	if len(summaries) == 0 {
+		logrus.Println("No activity found")
+	} else {
+		sorted := []activitySummary{}
+		for _, s := range summaries {
+			sorted = append(sorted, *s)
+		}
+		sort.Sort(SortedActivity(sorted))
+		data := [][]string{{"USER", "CLASS", "OPERATIONS", "LAST ACTIVITY"}}
+		for _, s := range sorted {
+			last := "-"
+			if !s.Last.IsZero() {
+				last = config.FormatTimestamp(s.Last)
+			}
+			data = append(data, []string{s.Email, s.Class, fmt.Sprintf("%d", s.Count), last})
+		}
+		table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+		table.SetBorder(false)
+		table.SetRowLine(false)
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("")
+		table.SetRowSeparator("")
+		table.AppendBulk(data)
+		table.Render()
+	}
+
+	dormant := []string{}
+	for _, u := range *orgUsers {
+		if email != "" && !strings.EqualFold(u.Email, email) {
+			continue
+		}
+		if !active[strings.ToLower(u.Email)] {
+			dormant = append(dormant, u.Email)
+		}
+	}
+	if len(dormant) > 0 {
+		sort.Strings(dormant)
+		logrus.Printf("\nDormant users (no activity in the last %d days):", days)
+		for _, d := range dormant {
+			logrus.Printf("  %s", d)
+		}
+	}
+	return nil
+}
+
+// SortedActivity is a wrapper for activitySummary array in order to sort them
+// by user and then class
+type SortedActivity []activitySummary
+
+func (a SortedActivity) Len() int {
+	return len(a)
+}
+
+func (a SortedActivity) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func (a SortedActivity) Less(i, j int) bool {
+	if a[i].Email != a[j].Email {
+		return a[i].Email < a[j].Email
+	}
+	return a[i].Class < a[j].Class
+}
+
+// Activity retrieves the CLI operations run by users in the organization
+// between the given times from the audit log
+func (a *SAdmin) Activity(since, until time.Time) (*[]models.ActivityEvent, error) {
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	params := url.Values{}
+	params.Set("since", since.Format(time.RFC3339))
+	params.Set("until", until.Format(time.RFC3339))
+	resp, statusCode, err := a.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/audit/activity?%s", a.Settings.AuthHost, a.Settings.AuthHostVersion, a.Settings.OrgID, params.Encode()), headers)
+	if err != nil {
+		return nil, err
+	}
+	var events []models.ActivityEvent
+	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, &events)
+	if err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
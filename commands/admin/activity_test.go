@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/test"
+)
+
+var adminActivityTests = []struct {
+	days      int
+	email     string
+	expectErr bool
+}{
+	{30, "", false},
+	{90, "user@example.com", false},
+	{30, "dormant@example.com", false},
+	{30, "unknown@example.com", true},
+	{0, "", true},
+}
+
+func TestAdminActivity(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs/"+test.OrgID+"/audit/activity",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			if r.URL.Query().Get("since") == "" || r.URL.Query().Get("until") == "" {
+				t.Errorf("Expected a since and until time")
+			}
+			fmt.Fprint(w, `[{"usersId":"1","email":"user@example.com","operation":"db backup","class":"write","timestamp":"2017-03-01T12:00:00Z"},{"usersId":"1","email":"user@example.com","operation":"logs","class":"read","timestamp":"2017-03-02T12:00:00Z"},{"usersId":"1","email":"user@example.com","operation":"vars list","class":"read","timestamp":"2017-03-03T12:00:00Z"}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com"},{"id":"2","email":"dormant@example.com"}]`)
+		},
+	)
+
+	for _, data := range adminActivityTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdActivity(data.days, data.email, New(settings), users.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "admin",
+	ShortHelp: "Administrative reports for organization admins",
+	LongHelp: "The `admin` command provides reports for the admins of your environment's organization. " +
+		"You must be an admin of the organization to run these commands. " +
+		"The admin command cannot be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ActivitySubCmd.Name, ActivitySubCmd.ShortHelp, help.Render(ActivitySubCmd.LongHelp), ActivitySubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ActivitySubCmd = models.Command{
+	Name:      "activity",
+	ShortHelp: "Summarize the CLI operations run by each user in the organization",
+	LongHelp: "`admin activity` summarizes which users in your environment's organization ran which classes of CLI operations over a period of time, using the organization's audit log. " +
+		"For each user and class of operation, the number of operations and the time of the most recent one are shown. " +
+		"Users in the organization who did not run any operations during the period are listed as dormant. " +
+		"This is useful when reviewing whether users still need their access and whether their access follows least privilege. " +
+		"The period defaults to the last 30 days and can be changed with `--days`. " +
+		"Use `--user` to only show the activity of a single user. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" admin activity\n" +
+		"datica -E \"<your_env_alias>\" admin activity --days 90 --user user@example.com\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			days := subCmd.IntOpt("days", 30, "The number of days of activity to summarize")
+			email := subCmd.StringOpt("user", "", "The email address of a single user to summarize activity for")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdActivity(*days, *email, New(settings), users.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--days] [--user]"
+		}
+	},
+}
+
+// IAdmin
+type IAdmin interface {
+	Activity(since, until time.Time) (*[]models.ActivityEvent, error)
+}
+
+// SAdmin is a concrete implementation of IAdmin
+type SAdmin struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IAdmin
+func New(settings *models.Settings) IAdmin {
+	return &SAdmin{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,189 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	libalerts "github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const ruleID = "rule1"
+
+var createTests = []struct {
+	svcName     string
+	when        string
+	forDuration string
+	event       string
+	target      string
+	emails      []string
+	webhooks    []string
+	expected    models.AlertRule
+	expectErr   bool
+}{
+	{test.SvcLabel, "cpu > 90", "5m", "", "", []string{"ops@example.com"}, []string{}, models.AlertRule{Metric: "cpu", Operator: ">", Threshold: 90, Duration: 300, ServiceID: test.SvcID, Notify: []models.AlertTarget{{Type: libalerts.TargetEmail, Address: "ops@example.com"}}}, false},
+	{test.SvcLabel, "DISK>=80.5%", "", "", "", []string{}, []string{"https://hooks.example.com/alerts"}, models.AlertRule{Metric: "disk", Operator: ">=", Threshold: 80.5, ServiceID: test.SvcID, Notify: []models.AlertTarget{{Type: libalerts.TargetWebhook, Address: "https://hooks.example.com/alerts"}}}, false},
+	{test.SvcLabel, "", "", "scale-mismatch", "worker", []string{}, []string{}, models.AlertRule{Event: libalerts.EventScaleMismatch, ServiceID: test.SvcID, Target: "worker"}, false},
+	{test.SvcLabel, "", "", "oom", "", []string{}, []string{}, models.AlertRule{Event: libalerts.EventOOM, ServiceID: test.SvcID}, false},
+	{test.SvcLabel, "cpu above 90", "", "", "", []string{}, []string{}, models.AlertRule{}, true},
+	{test.SvcLabel, "load > 90", "", "", "", []string{}, []string{}, models.AlertRule{}, true},
+	{test.SvcLabel, "memory > 120", "", "", "", []string{}, []string{}, models.AlertRule{}, true},
+	{test.SvcLabel, "cpu > 90", "soon", "", "", []string{}, []string{}, models.AlertRule{}, true},
+	{test.SvcLabel, "", "", "restart", "", []string{}, []string{}, models.AlertRule{}, true},
+	{test.SvcLabel, "cpu > 90", "", "", "", []string{"ops"}, []string{}, models.AlertRule{}, true},
+	{test.SvcLabel, "cpu > 90", "", "", "", []string{}, []string{"hooks.example.com"}, models.AlertRule{}, true},
+	{"invalid-svc", "cpu > 90", "", "", "", []string{}, []string{}, models.AlertRule{}, true},
+}
+
+const rulesList = `[{"id":"` + ruleID + `","metric":"cpu","operator":">","threshold":90,"duration":300,"service":"` + test.SvcID + `","notify":[{"type":"email","address":"ops@example.com"}],"created_at":"2017-06-01T00:00:00Z"},{"id":"rule2","event":"oom","service":"` + test.SvcIDAlt + `"}]`
+
+func TestCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	var created models.AlertRule
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/alerts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(b, &created)
+			rule := created
+			rule.ID = ruleID
+			b, _ = json.Marshal(rule)
+			w.Write(b)
+		},
+	)
+
+	for _, data := range createTests {
+		t.Logf("Data: %+v", data)
+		created = models.AlertRule{}
+
+		// test
+		err := CmdCreate(data.svcName, data.when, data.forDuration, data.event, data.target, data.emails, data.webhooks, libalerts.New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		expected, _ := json.Marshal(data.expected)
+		actual, _ := json.Marshal(created)
+		if string(expected) != string(actual) {
+			t.Errorf("Expected the rule %s but got %s", expected, actual)
+		}
+	}
+}
+
+var listTests = []struct {
+	rules      string
+	jsonOutput bool
+	expectRows []string
+}{
+	{rulesList, false, []string{"ID", ruleID, test.SvcLabel, "cpu > 90% for", "the inbox, ops@example.com", "rule2", test.SvcIDAlt, "oom happens"}},
+	{rulesList, true, []string{`"id": "` + ruleID + `"`, `"metric": "cpu"`, `"id": "rule2"`, `"event": "oom"`}},
+	{`[]`, false, []string{"No alert rules have been created"}},
+}
+
+func TestList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	rules := ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/alerts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rules)
+		},
+	)
+
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+		rules = data.rules
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdList(data.jsonOutput, libalerts.New(settings), services.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+var rmTests = []struct {
+	ruleID    string
+	expectErr bool
+}{
+	{ruleID, false},
+	{"invalid-rule", true},
+}
+
+func TestRm(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	deleted := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/alerts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rulesList)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/alerts/"+ruleID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			deleted = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range rmTests {
+		t.Logf("Data: %+v", data)
+		deleted = false
+
+		// test
+		err := CmdRm(data.ruleID, libalerts.New(settings), services.New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if deleted == data.expectErr {
+			t.Errorf("Expected the rule to be deleted: %t, but it was deleted: %t", !data.expectErr, deleted)
+		}
+	}
+}
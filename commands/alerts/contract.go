@@ -0,0 +1,125 @@
+package alerts
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libalerts "github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "alerts",
+	ShortHelp: "Manage the alert rules of an environment",
+	LongHelp: "The `alerts` command manages the rules the platform uses to notify you about problems with your services, so that alerting can be set up from scripts along with the rest of an environment. " +
+		"A rule either watches a metric of a service, such as CPU usage above 90% for 5 minutes, or an event, such as a job running out of memory or a worker target running fewer jobs than it is scaled to. " +
+		"Alerts are always sent to the inbox of the members of the environment, see [inbox](#inbox), and can also be sent to email addresses and webhooks. " +
+		"The alerts command cannot be run directly but has sub commands.",
+	Category: models.CategoryObservability,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Create an alert rule for a service",
+	LongHelp: "`alerts create` creates a rule that sends an alert about the given service. " +
+		"Give `--when` with a condition such as `cpu > 90` to alert when a metric crosses a threshold, where the metric is one of `cpu`, `memory`, or `disk`, the operator is one of `>`, `>=`, `<`, or `<=`, and the threshold is a percentage of the size of the service. " +
+		"Use `--for` to only alert once the condition has held for a duration such as `5m`. " +
+		"Alternatively give `--event` to alert when `oom`, a job of the service running out of memory, or `scale_mismatch`, a worker target running fewer jobs than it is scaled to, happens. " +
+		"Event rules can be limited to a single worker target with `--target`. " +
+		"Give `--email` and `--webhook` once for each address and URL to send the alert to in addition to the inbox of the members of the environment. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" alerts create code-1 --when \"cpu > 90\" --for 5m --email ops@example.com\n" +
+		"datica -E \"<your_env_alias>\" alerts create db01 --when \"disk >= 80\" --webhook https://hooks.example.com/alerts\n" +
+		"datica -E \"<your_env_alias>\" alerts create code-1 --event scale_mismatch --target worker\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to alert about")
+			when := subCmd.StringOpt("when", "", "The condition of a metric to alert on (i.e. 'cpu > 90')")
+			forDuration := subCmd.StringOpt("for", "", "How long the condition must hold before alerting (i.e. '5m')")
+			event := subCmd.StringOpt("event", "", "The event to alert on, either 'oom' or 'scale_mismatch'")
+			target := subCmd.StringOpt("target", "", "The worker target to limit an event rule to")
+			emails := subCmd.StringsOpt("email", []string{}, "An email address to send alerts to. Can be given more than once.")
+			webhooks := subCmd.StringsOpt("webhook", []string{}, "A URL to post alerts to. Can be given more than once.")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCreate(*serviceName, *when, *forDuration, *event, *target, *emails, *webhooks, libalerts.New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME (--when [--for] | --event [--target]) [--email...] [--webhook...]"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the alert rules of an environment",
+	LongHelp: "`alerts list` lists the alert rules of the environment along with the service each is for, its condition or event, and where alerts are sent. " +
+		"Use `--json` to print the rules in a format that can be saved and reviewed alongside your scripts. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" alerts list\n" +
+		"datica -E \"<your_env_alias>\" alerts list --json\n```",
+	JSONOutput: []models.AlertRule{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			jsonOutput := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the alert rules as json"))
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(*jsonOutput, libalerts.New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--json]"
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove an alert rule",
+	LongHelp: "`alerts rm` removes an alert rule so that no more alerts are sent for it. " +
+		"You will be asked to confirm before the rule is removed, use the global `--yes` flag to skip the confirmation. " +
+		"The ID of each rule is shown by [alerts list](#alerts-list). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" alerts rm 5d8e1f0a-6d0b-4d7e-9a57-36f4e0d8a2b1\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			ruleID := subCmd.StringArg("RULE_ID", "", "The ID of the alert rule to remove")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRm(*ruleID, libalerts.New(settings), services.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "RULE_ID"
+		}
+	},
+}
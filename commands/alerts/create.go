@@ -0,0 +1,124 @@
+package alerts
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libalerts "github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/models"
+)
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// conditionRegex matches a condition such as "cpu > 90" or "disk>=80%"
+var conditionRegex = regexp.MustCompile(`^\s*([a-zA-Z]+)\s*(>=|<=|>|<)\s*([0-9]+(?:\.[0-9]+)?)\s*%?\s*$`)
+
+func CmdCreate(svcName, when, forDuration, event, target string, emails, webhooks []string, ia libalerts.IAlerts, is services.IServices) error {
+	rule := &models.AlertRule{}
+	if when != "" {
+		if err := parseCondition(when, rule); err != nil {
+			return err
+		}
+		if forDuration != "" {
+			d, err := time.ParseDuration(forDuration)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("Invalid duration \"%s\". The duration must be positive such as 5m or 90s", forDuration)
+			}
+			rule.Duration = int(d.Seconds())
+		}
+	} else {
+		rule.Event = strings.Replace(strings.ToLower(event), "-", "_", -1)
+		if !contains(libalerts.Events, rule.Event) {
+			return fmt.Errorf("Invalid event \"%s\". Please specify one of %s.", event, strings.Join(libalerts.Events, ", "))
+		}
+		rule.Target = target
+	}
+	for _, email := range emails {
+		if !emailRegex.MatchString(email) {
+			return fmt.Errorf("Invalid email address \"%s\"", email)
+		}
+		rule.Notify = append(rule.Notify, models.AlertTarget{Type: libalerts.TargetEmail, Address: email})
+	}
+	for _, webhook := range webhooks {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("Invalid webhook \"%s\". The webhook must be a full URL such as https://hooks.example.com/alerts", webhook)
+		}
+		rule.Notify = append(rule.Notify, models.AlertTarget{Type: libalerts.TargetWebhook, Address: webhook})
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	rule.ServiceID = service.ID
+	created, err := ia.CreateRule(rule)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Created alert rule %s: %s will be notified when %s on %s", created.ID, describeNotify(rule.Notify), describeRule(rule), service.Label)
+	return nil
+}
+
+// parseCondition sets the metric, operator, and threshold of the rule from a
+// condition such as "cpu > 90"
+func parseCondition(when string, rule *models.AlertRule) error {
+	matches := conditionRegex.FindStringSubmatch(when)
+	if matches == nil {
+		return fmt.Errorf("Invalid condition \"%s\". The condition must be a metric, an operator, and a percentage such as \"cpu > 90\"", when)
+	}
+	metric := strings.ToLower(matches[1])
+	if !contains(libalerts.Metrics, metric) {
+		return fmt.Errorf("Invalid metric \"%s\". Please specify one of %s.", matches[1], strings.Join(libalerts.Metrics, ", "))
+	}
+	threshold, _ := strconv.ParseFloat(matches[3], 64)
+	if threshold > 100 {
+		return fmt.Errorf("Invalid threshold %s%%. The threshold is a percentage of the size of the service and cannot be greater than 100", matches[3])
+	}
+	rule.Metric = metric
+	rule.Operator = matches[2]
+	rule.Threshold = threshold
+	return nil
+}
+
+// describeRule returns the condition or event of the rule, such as
+// "cpu > 90% for 5m"
+func describeRule(rule *models.AlertRule) string {
+	if rule.Metric == "" {
+		if rule.Target != "" {
+			return fmt.Sprintf("%s happens to %s", rule.Event, rule.Target)
+		}
+		return fmt.Sprintf("%s happens", rule.Event)
+	}
+	s := fmt.Sprintf("%s %s %s%%", rule.Metric, rule.Operator, strconv.FormatFloat(rule.Threshold, 'f', -1, 64))
+	if rule.Duration > 0 {
+		s = fmt.Sprintf("%s for %s", s, config.FormatDuration(time.Duration(rule.Duration)*time.Second))
+	}
+	return s
+}
+
+// describeNotify returns where alerts of a rule are sent
+func describeNotify(notify []models.AlertTarget) string {
+	targets := []string{"the inbox"}
+	for _, t := range notify {
+		targets = append(targets, t.Address)
+	}
+	return strings.Join(targets, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package alerts
+
+import (
+	"encoding/json"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libalerts "github.com/daticahealth/cli/lib/alerts"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdList(jsonOutput bool, ia libalerts.IAlerts, is services.IServices) error {
+	rules, err := ia.ListRules()
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		b, _ := json.MarshalIndent(rules, "", "    ")
+		logrus.Println(string(b))
+		return nil
+	}
+	if rules == nil || len(*rules) == 0 {
+		logrus.Println("No alert rules have been created for this environment.")
+		return nil
+	}
+	svcs, err := is.List()
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{}
+	for _, s := range *svcs {
+		labels[s.ID] = s.Label
+	}
+	data := [][]string{{"ID", "SERVICE", "RULE", "NOTIFY", "CREATED"}}
+	for i := range *rules {
+		rule := &(*rules)[i]
+		label, ok := labels[rule.ServiceID]
+		if !ok {
+			label = rule.ServiceID
+		}
+		data = append(data, []string{rule.ID, label, describeRule(rule), describeNotify(rule.Notify), config.FormatTimestampString(rule.CreatedAt)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
@@ -0,0 +1,41 @@
+package alerts
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	libalerts "github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdRm(ruleID string, ia libalerts.IAlerts, is services.IServices, ip prompts.IPrompts) error {
+	rules, err := ia.ListRules()
+	if err != nil {
+		return err
+	}
+	var rule *models.AlertRule
+	for i := range *rules {
+		if (*rules)[i].ID == ruleID {
+			rule = &(*rules)[i]
+			break
+		}
+	}
+	if rule == nil {
+		return fmt.Errorf("Could not find an alert rule with the ID \"%s\". You can list alert rules with the \"datica alerts list\" command.", ruleID)
+	}
+	label := rule.ServiceID
+	if service, err := is.Retrieve(rule.ServiceID); err == nil && service != nil {
+		label = service.Label
+	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to stop alerting when %s on %s? (y/n) ", describeRule(rule), label))
+	if err != nil {
+		return err
+	}
+	if err = ia.DeleteRule(rule.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Removed the alert rule %s", rule.ID)
+	return nil
+}
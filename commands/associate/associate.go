@@ -5,18 +5,26 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/environments"
 	"github.com/daticahealth/cli/commands/git"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 )
 
-func CmdAssociate(envLabel, svcLabel, alias, remote string, defaultEnv bool, ia IAssociate, ig git.IGit, ie environments.IEnvironments, is services.IServices) error {
+// CmdAssociate associates the environment and code service with the given
+// names with the local git repo. If the environment or service is not given,
+// it is chosen from a menu instead.
+func CmdAssociate(envLabel, svcLabel, alias, remote string, defaultEnv bool, ia IAssociate, ig git.IGit, ie environments.IEnvironments, is services.IServices, ip prompts.IPrompts) error {
 	if defaultEnv {
-		logrus.Warnln("The \"--default\" flag has been deprecated! It will be removed in a future version.")
+		if err := config.Deprecated("The \"--default\" flag has been deprecated! It will be removed in a future version."); err != nil {
+			return err
+		}
 	}
 	if !ig.Exists() {
 		return errors.New("No git repo found in the current directory")
@@ -28,6 +36,22 @@ func CmdAssociate(envLabel, svcLabel, alias, remote string, defaultEnv bool, ia
 			logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
 		}
 	}
+	interactive := envLabel == "" || svcLabel == ""
+	if envLabel == "" {
+		if len(*envs) == 0 {
+			return errors.New("No environments found. Ensure you have the correct permissions from your organization owner.")
+		}
+		sort.Sort(environments.SortedEnvironments(*envs))
+		options := []string{}
+		for _, env := range *envs {
+			options = append(options, fmt.Sprintf("%s (%s)", env.Name, env.Pod))
+		}
+		i, err := ip.Select("Which environment would you like to associate?", options)
+		if err != nil {
+			return err
+		}
+		envLabel = (*envs)[i].Name
+	}
 	var e *models.Environment
 	var svcs *[]models.Service
 	var err error
@@ -48,6 +72,28 @@ func CmdAssociate(envLabel, svcLabel, alias, remote string, defaultEnv bool, ia
 		return fmt.Errorf("No services found for environment with name \"%s\"", envLabel)
 	}
 
+	if svcLabel == "" {
+		codeServices := []string{}
+		for _, service := range *svcs {
+			if service.Type == "code" {
+				codeServices = append(codeServices, service.Label)
+			}
+		}
+		sort.Strings(codeServices)
+		switch len(codeServices) {
+		case 0:
+			return fmt.Errorf("No code services found for environment with name \"%s\"", envLabel)
+		case 1:
+			svcLabel = codeServices[0]
+		default:
+			i, err := ip.Select("Which code service would you like to associate?", codeServices)
+			if err != nil {
+				return err
+			}
+			svcLabel = codeServices[i]
+		}
+	}
+
 	var chosenService *models.Service
 	availableCodeServices := []string{}
 	for _, service := range *svcs {
@@ -94,6 +140,9 @@ func CmdAssociate(envLabel, svcLabel, alias, remote string, defaultEnv bool, ia
 	}
 	logrus.Printf("Your git repository \"%s\" and \"catalyze\" have been associated with code service \"%s\" and environment \"%s\"", remote, svcLabel, name)
 	logrus.Println("After associating to an environment, you need to add a cert with the \"datica certs create\" command, if you have not done so already")
+	if interactive {
+		logrus.Printf("To associate this environment again without the menu, run \"datica associate \"%s\" %s\"", envLabel, svcLabel)
+	}
 	return nil
 }
 
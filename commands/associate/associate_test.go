@@ -25,6 +25,9 @@ var associateTests = []struct {
 	{test.EnvName, test.SvcLabel, test.Alias, "datica", false},
 	{test.EnvName, "bad-svc", "", "datica", true},
 	{"bad-env", test.SvcLabel, "", "datica", true},
+	{test.EnvName, "", "", "datica", false},
+	{"", "", "", "datica", false},
+	{"", "", test.Alias, "datica", false},
 }
 
 func TestAssociate(t *testing.T) {
@@ -64,7 +67,7 @@ func TestAssociate(t *testing.T) {
 		settings.Environments = map[string]models.AssociatedEnv{}
 
 		// test
-		err := CmdAssociate(data.envName, data.svcName, data.alias, data.remote, false, New(settings), git.New(), environments.New(settings), services.New(settings))
+		err := CmdAssociate(data.envName, data.svcName, data.alias, data.remote, false, New(settings), git.New(), environments.New(settings), services.New(settings), &test.FakePrompts{})
 
 		// assertions
 		if err != nil != data.expectErr {
@@ -75,7 +78,7 @@ func TestAssociate(t *testing.T) {
 		if !data.expectErr {
 			name := data.alias
 			if name == "" {
-				name = data.envName
+				name = test.EnvName
 			}
 			expectedEnvs[name] = models.AssociatedEnv{
 				Name:          test.EnvName,
@@ -122,7 +125,7 @@ func TestAssociateWithPodErrors(t *testing.T) {
 	)
 
 	// test
-	err := CmdAssociate(test.EnvName, test.SvcLabel, "", "datica", false, New(settings), git.New(), environments.New(settings), services.New(settings))
+	err := CmdAssociate(test.EnvName, test.SvcLabel, "", "datica", false, New(settings), git.New(), environments.New(settings), services.New(settings), &test.FakePrompts{})
 
 	// assert
 	if err != nil {
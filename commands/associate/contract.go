@@ -17,12 +17,16 @@ var Cmd = models.Command{
 	Name:      "associate",
 	ShortHelp: "Associates an environment",
 	LongHelp: "`associate` is the entry point of the cli. You need to associate an environment before you can run most other commands. " +
-		"Check out [scope](#global-scope) and [aliases](#environment-aliases) for more info on the value of the alias and default options. Here is a sample command\n\n" +
-		"```\ndatica associate My-Production-Environment app01 -a prod\n```",
+		"If the environment or code service is not given, you choose it from a menu of the environments you have access to and their code services. " +
+		"Type part of a name in the menu to narrow it down, the letters only need to appear in order. " +
+		"Check out [scope](#global-scope) and [aliases](#environment-aliases) for more info on the value of the alias and default options. Here are some sample commands\n\n" +
+		"```\ndatica associate\n" +
+		"datica associate My-Production-Environment app01 -a prod\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			envName := cmd.StringArg("ENV_NAME", "", "The name of your environment")
-			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the primary code service to associate with this environment (i.e. 'app01')")
+			envName := cmd.StringArg("ENV_NAME", "", "The name of your environment. Chosen from a menu if not given")
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the primary code service to associate with this environment (i.e. 'app01'). Chosen from a menu if not given")
 			alias := cmd.StringOpt("a alias", "", "A shorter name to reference your environment by for local commands")
 			remote := cmd.StringOpt("r remote", "datica", "The name of the remote")
 			defaultEnv := cmd.BoolOpt("d default", false, "[DEPRECATED] Specifies whether or not the associated environment will be the default")
@@ -30,12 +34,12 @@ var Cmd = models.Command{
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdAssociate(*envName, *serviceName, *alias, *remote, *defaultEnv, New(settings), git.New(), environments.New(settings), services.New(settings))
+				err := CmdAssociate(*envName, *serviceName, *alias, *remote, *defaultEnv, New(settings), git.New(), environments.New(settings), services.New(settings), prompts.New())
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			cmd.Spec = "ENV_NAME SERVICE_NAME [-a] [-r] [-d]"
+			cmd.Spec = "[ENV_NAME [SERVICE_NAME]] [-a] [-r] [-d]"
 		}
 	},
 }
@@ -0,0 +1,91 @@
+package associate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/pmylund/sortutil"
+)
+
+// CmdAssociateOrg offers to associate one of the environments of the given
+// organization, such as right after joining it. Nothing is associated when
+// the user has no access to its environments yet or chooses not to.
+func CmdAssociateOrg(orgID string, ia IAssociate, ie environments.IEnvironments, is services.IServices, ip prompts.IPrompts) error {
+	envs, errs := ie.List()
+	for pod, err := range errs {
+		logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
+	}
+	orgEnvs := []models.Environment{}
+	for _, env := range *envs {
+		if env.OrgID == orgID {
+			orgEnvs = append(orgEnvs, env)
+		}
+	}
+	if len(orgEnvs) == 0 {
+		logrus.Println("You do not have access to any environments of this organization yet. Once you do, run \"datica associate\" to associate one.")
+		return nil
+	}
+	if prompts.NonInteractive() {
+		logrus.Println("Run \"datica associate\" to associate one of the environments of this organization")
+		return nil
+	}
+	sort.Sort(environments.SortedEnvironments(orgEnvs))
+	options := []string{}
+	for _, env := range orgEnvs {
+		options = append(options, fmt.Sprintf("%s (%s)", env.Name, env.Pod))
+	}
+	options = append(options, "None, I will associate one later")
+	i, err := ip.Select("Which environment would you like to associate?", options)
+	if err != nil {
+		return err
+	}
+	if i == len(orgEnvs) {
+		logrus.Println("Run \"datica associate\" to associate one of the environments of this organization")
+		return nil
+	}
+	env := orgEnvs[i]
+
+	svcs, err := is.ListByEnvID(env.ID, env.Pod)
+	if err != nil {
+		return err
+	}
+	codeServices := []models.Service{}
+	for _, service := range *svcs {
+		if service.Type == "code" {
+			codeServices = append(codeServices, service)
+		}
+	}
+	sortutil.AscByField(codeServices, "Label")
+	// environments without code services can still be associated, commands
+	// that need a code service ask for one
+	chosenService := &models.Service{}
+	switch len(codeServices) {
+	case 0:
+	case 1:
+		chosenService = &codeServices[0]
+	default:
+		labels := []string{}
+		for _, service := range codeServices {
+			labels = append(labels, service.Label)
+		}
+		i, err := ip.Select("Which code service would you like to associate?", labels)
+		if err != nil {
+			return err
+		}
+		chosenService = &codeServices[i]
+	}
+
+	if err = ia.Associate(env.Name, "", false, &env, chosenService); err != nil {
+		return err
+	}
+	logrus.Printf("Environment \"%s\" has been associated. Run commands against it with \"datica -E \"%s\" <command>\"", env.Name, env.Name)
+	if chosenService.Label != "" {
+		logrus.Printf("To push code to %s, run \"datica -E \"%s\" git-remote add %s\" in your git repo", chosenService.Label, env.Name, chosenService.Label)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package associate
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var associateOrgTests = []struct {
+	orgID     string
+	expectEnv string
+	expectSvc string
+}{
+	{test.OrgID, test.EnvName, test.SvcID},
+	{test.OrgIDAlt, test.EnvNameAlt, ""},
+	{"other-org", "", ""},
+}
+
+func TestAssociateOrg(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			if r.Header.Get("X-Pod-ID") == test.Pod {
+				fmt.Fprintf(w, `[{"id":"%s","name":"%s","namespace":"%s","organizationId":"%s"}]`, test.EnvID, test.EnvName, test.Namespace, test.OrgID)
+			} else {
+				fmt.Fprintf(w, `[{"id":"%s","name":"%s","namespace":"%s","organizationId":"%s"}]`, test.EnvIDAlt, test.EnvNameAlt, test.NamespaceAlt, test.OrgIDAlt)
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","type":"code","label":"%s"},{"id":"db","type":"database","label":"db01"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvIDAlt+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"db","type":"database","label":"db01"}]`)
+		},
+	)
+
+	for _, data := range associateOrgTests {
+		t.Logf("Data: %+v", data)
+
+		// reset
+		settings.Environments = map[string]models.AssociatedEnv{}
+
+		// test
+		err := CmdAssociateOrg(data.orgID, New(settings), environments.New(settings), services.New(settings), &test.FakePrompts{})
+
+		// assertions
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectEnv == "" {
+			test.AssertEquals(t, "0", fmt.Sprintf("%d", len(settings.Environments)))
+			continue
+		}
+		env, ok := settings.Environments[data.expectEnv]
+		if !ok {
+			t.Errorf("Expected %s to be associated, got %+v", data.expectEnv, settings.Environments)
+			continue
+		}
+		test.AssertEquals(t, data.expectSvc, env.ServiceID)
+	}
+}
@@ -14,6 +14,7 @@ var Cmd = models.Command{
 	LongHelp: "`associated` outputs information about all previously associated environments on your local machine. " +
 		"The information that is printed out includes the alias, environment ID, actual environment name, service ID, and the git repo directory. Here is a sample command\n\n" +
 		"```\ndatica associated\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			cmd.Action = func() {
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdAudit(since, until time.Time, page, pageSize int, all, jsonOutput bool, ia IAudit) error {
+	if !since.Before(until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+	if page < 1 {
+		return fmt.Errorf("--page must be greater than 0")
+	}
+	if pageSize < 1 {
+		return fmt.Errorf("--page-size must be greater than 0")
+	}
+	events := []models.AuditEvent{}
+	for {
+		pageEvents, err := ia.List(since, until, page, pageSize)
+		if err != nil {
+			return err
+		}
+		events = append(events, *pageEvents...)
+		if !all || len(*pageEvents) < pageSize {
+			break
+		}
+		page++
+	}
+
+	if jsonOutput {
+		b, err := json.MarshalIndent(events, "", "    ")
+		if err != nil {
+			return err
+		}
+		logrus.Println(string(b))
+		return nil
+	}
+
+	logrus.Printf("Audit trail from %s to %s", config.FormatTimestamp(since), config.FormatTimestamp(until))
+	if len(events) == 0 {
+		if page == 1 {
+			logrus.Println("No events found")
+		} else {
+			logrus.Printf("No events found on page %d", page)
+		}
+		return nil
+	}
+	// the break-glass column is only shown when a change was made during a
+	// break-glass session
+	breakGlass := false
+	for _, e := range events {
+		if e.BreakGlassID != "" {
+			breakGlass = true
+			break
+		}
+	}
+	header := []string{"TIME", "ACTOR", "ACTION", "TARGET"}
+	if breakGlass {
+		header = append(header, "BREAK GLASS")
+	}
+	data := [][]string{header}
+	for _, e := range events {
+		row := []string{config.FormatTimestampString(e.Timestamp), e.ActorEmail, e.Action, e.Target}
+		if breakGlass {
+			row = append(row, e.BreakGlassID)
+		}
+		data = append(data, row)
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	if !all && len(events) == pageSize {
+		logrus.Printf("(for older events, try with --page %d or --all)", page+1)
+	}
+	return nil
+}
+
+// List retrieves a single page of the organization's audit trail between the
+// given times, newest first
+func (a *SAudit) List(since, until time.Time, page, pageSize int) (*[]models.AuditEvent, error) {
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	params := url.Values{}
+	params.Set("since", since.UTC().Format(time.RFC3339))
+	params.Set("until", until.UTC().Format(time.RFC3339))
+	params.Set("pageNumber", strconv.Itoa(page))
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	resp, statusCode, err := a.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/audit?%s", a.Settings.AuthHost, a.Settings.AuthHostVersion, a.Settings.OrgID, params.Encode()), headers)
+	if err != nil {
+		return nil, err
+	}
+	var events []models.AuditEvent
+	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, &events)
+	if err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
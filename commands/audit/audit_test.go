@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/test"
+)
+
+var auditTests = []struct {
+	sinceDays int
+	page      int
+	pageSize  int
+	all       bool
+	json      bool
+	expectErr bool
+}{
+	{30, 1, 2, false, false, false},
+	{30, 1, 2, true, false, false},
+	{30, 1, 2, true, true, false},
+	{30, 3, 2, false, false, false},
+	{0, 1, 2, false, false, true},
+	{30, 0, 2, false, false, true},
+	{30, 1, 0, false, false, true},
+}
+
+func TestAudit(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs/"+test.OrgID+"/audit",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			if r.URL.Query().Get("since") == "" || r.URL.Query().Get("until") == "" {
+				t.Errorf("Expected a since and until time")
+			}
+			switch r.URL.Query().Get("pageNumber") {
+			case "1":
+				fmt.Fprint(w, `[{"id":"3","timestamp":"2017-03-03T12:00:00Z","actorEmail":"user@example.com","action":"deploy","target":"code-1"},{"id":"2","timestamp":"2017-03-02T12:00:00Z","actorEmail":"user@example.com","action":"vars set","target":"code-1"}]`)
+			case "2":
+				fmt.Fprint(w, `[{"id":"1","timestamp":"2017-03-01T12:00:00Z","actorEmail":"admin@example.com","action":"invite","target":"user@example.com"}]`)
+			default:
+				fmt.Fprint(w, `[]`)
+			}
+		},
+	)
+
+	for _, data := range auditTests {
+		t.Logf("Data: %+v", data)
+		until := time.Now()
+		since := until.AddDate(0, 0, -data.sinceDays)
+
+		// test
+		err := CmdAudit(since, until, data.page, data.pageSize, data.all, data.json, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+	}
+}
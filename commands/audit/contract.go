@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "audit",
+	ShortHelp: "View the audit trail of the given organization",
+	LongHelp: "`audit` shows the audit trail of your environment's organization: who deployed, who changed environment variables, who invited whom, and every other change made in the organization. " +
+		"Events are shown newest first. " +
+		"Changes made during a [break-glass](#breakglass) session are marked with the ID of the session. " +
+		"The period defaults to the last 30 days and can be changed with `--since` and `--until`, which accept a date such as `2017-06-01`, a date and time such as `2017-06-01 13:30`, or an RFC3339 timestamp. " +
+		"Dates and times without a zone are in the timezone set by `--timezone`. " +
+		"Events are retrieved one page at a time. Use `--page` and `--page-size` to choose the page, or `--all` to retrieve every page. " +
+		"Use `--json` to print the events as JSON instead of a table, for example to feed them into another tool. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" audit\n" +
+		"datica -E \"<your_env_alias>\" audit --since 2017-06-01 --until 2017-07-01 --all --json\n```",
+	Category:   models.CategoryAccess,
+	JSONOutput: []models.AuditEvent{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			since := cmd.StringOpt("since", "", "Only show events at or after this time. Defaults to 30 days ago")
+			until := cmd.StringOpt("until", "", "Only show events before this time. Defaults to now")
+			page := cmd.IntOpt("p page", 1, "The page to view")
+			pageSize := cmd.IntOpt("n page-size", 50, "The number of events to show per page")
+			all := cmd.BoolOpt("all", false, "Retrieve every page of events")
+			jsonOutput := config.JSONOpt(cmd.BoolOpt("json", false, "Output the events as JSON"))
+			cmd.Action = func() {
+				sinceTime, untilTime, err := parseRange(*since, *until)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdAudit(sinceTime, untilTime, *page, *pageSize, *all, *jsonOutput, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[--since] [--until] [-p] [-n] [--all] [--json]"
+		}
+	},
+}
+
+// parseRange parses the --since and --until flags, defaulting to the last 30
+// days
+func parseRange(since, until string) (time.Time, time.Time, error) {
+	untilTime := time.Now()
+	if until != "" {
+		t, err := config.ParseTimestamp(until)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		untilTime = t
+	}
+	sinceTime := untilTime.AddDate(0, 0, -30)
+	if since != "" {
+		t, err := config.ParseTimestamp(since)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		sinceTime = t
+	}
+	return sinceTime, untilTime, nil
+}
+
+// IAudit
+type IAudit interface {
+	List(since, until time.Time, page, pageSize int) (*[]models.AuditEvent, error)
+}
+
+// SAudit is a concrete implementation of IAudit
+type SAudit struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IAudit
+func New(settings *models.Settings) IAudit {
+	return &SAudit{
+		Settings: settings,
+	}
+}
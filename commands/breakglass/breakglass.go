@@ -0,0 +1,140 @@
+package breakglass
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/inbox"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// maxTTL is the longest a break-glass session can last. A longer emergency
+// needs a new session and a new reason.
+const maxTTL = 4 * time.Hour
+
+const maxTTLString = "4h"
+
+// adminRole is the role of the users notified when a session starts
+const adminRole = "admin"
+
+func CmdStart(reason, ttl string, settings *models.Settings, ib IBreakGlass, ii inbox.IInbox, ip prompts.IPrompts) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("A reason is required to start a break-glass session. Give one with --reason")
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil || d < time.Minute {
+		return fmt.Errorf("Invalid TTL \"%s\". TTLs must be a duration of at least a minute such as 30m or 2h", ttl)
+	}
+	if d > maxTTL {
+		return fmt.Errorf("A break-glass session can last at most %s", maxTTLString)
+	}
+	if config.BreakGlassActive(settings) {
+		return fmt.Errorf("Break-glass session %s is already active until %s. Run \"datica breakglass end\" to end it first", settings.BreakGlass.ID, config.FormatTimestampString(settings.BreakGlass.ExpiresAt))
+	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to lift the production guards for the %s environment's organization for %s? The admins of the organization will be notified. (y/n) ", settings.EnvironmentName, d))
+	if err != nil {
+		return err
+	}
+	session, err := ib.Start(&models.BreakGlassSession{
+		OrgID:         settings.OrgID,
+		EnvironmentID: settings.EnvironmentID,
+		Reason:        reason,
+	}, int64(d/time.Second))
+	if err != nil {
+		return err
+	}
+	if session.OrgID == "" {
+		session.OrgID = settings.OrgID
+	}
+	if session.ExpiresAt == "" {
+		session.ExpiresAt = time.Now().Add(d).UTC().Format(time.RFC3339)
+	}
+	settings.BreakGlass = session
+	httpclient.SetBreakGlassSession(session.ID)
+	logrus.Printf("Started break-glass session %s until %s", session.ID, config.FormatTimestampString(session.ExpiresAt))
+
+	err = ii.Send(&models.Notification{
+		Type:           "break-glass",
+		Title:          fmt.Sprintf("Break-glass session started on %s", settings.EnvironmentName),
+		Body:           fmt.Sprintf("A break-glass session (ID = %s) was started on the %s environment until %s. Reason: %s", session.ID, settings.EnvironmentName, session.ExpiresAt, reason),
+		EnvironmentIDs: []string{settings.EnvironmentID},
+		Roles:          []string{adminRole},
+	})
+	if err != nil {
+		logrus.Warnf("Could not notify the admins of your organization: %s. Please let them know about the break-glass session yourself.", err)
+		return nil
+	}
+	logrus.Println("The admins of your organization have been notified")
+	return nil
+}
+
+func CmdEnd(settings *models.Settings, ib IBreakGlass) error {
+	if settings.BreakGlass == nil || config.BreakGlassExpired(settings.BreakGlass) {
+		settings.BreakGlass = nil
+		return errors.New("No break-glass session is active")
+	}
+	session := settings.BreakGlass
+	if err := ib.End(session); err != nil {
+		return err
+	}
+	settings.BreakGlass = nil
+	httpclient.SetBreakGlassSession("")
+	logrus.Printf("Ended break-glass session %s", session.ID)
+	return nil
+}
+
+func CmdStatus(settings *models.Settings) error {
+	s := settings.BreakGlass
+	if s == nil || config.BreakGlassExpired(s) {
+		logrus.Println("No break-glass session is active")
+		return nil
+	}
+	logrus.Printf("Break-glass session %s is active until %s", s.ID, config.FormatTimestampString(s.ExpiresAt))
+	logrus.Printf("Reason: %s", s.Reason)
+	if s.OrgID != settings.OrgID {
+		logrus.Println("The session belongs to another organization than the associated environment, so the production guards are not lifted")
+	}
+	return nil
+}
+
+// Start starts a break-glass session for the organization of the session that
+// lasts for the given number of seconds
+func (b *SBreakGlass) Start(session *models.BreakGlassSession, ttl int64) (*models.BreakGlassSession, error) {
+	body := struct {
+		*models.BreakGlassSession
+		TTL int64 `json:"ttl"`
+	}{session, ttl}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	headers := b.Settings.HTTPManager.GetHeaders(b.Settings.SessionToken, b.Settings.Version, b.Settings.Pod, b.Settings.UsersID)
+	resp, statusCode, err := b.Settings.HTTPManager.Post(data, fmt.Sprintf("%s%s/orgs/%s/break-glass", b.Settings.AuthHost, b.Settings.AuthHostVersion, session.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var started models.BreakGlassSession
+	err = b.Settings.HTTPManager.ConvertResp(resp, statusCode, &started)
+	if err != nil {
+		return nil, err
+	}
+	return &started, nil
+}
+
+// End ends the break-glass session before it expires
+func (b *SBreakGlass) End(session *models.BreakGlassSession) error {
+	headers := b.Settings.HTTPManager.GetHeaders(b.Settings.SessionToken, b.Settings.Version, b.Settings.Pod, b.Settings.UsersID)
+	resp, statusCode, err := b.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/break-glass/%s", b.Settings.AuthHost, b.Settings.AuthHostVersion, session.OrgID, session.ID), headers)
+	if err != nil {
+		return err
+	}
+	return b.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,119 @@
+package breakglass
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/inbox"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const sessionID = "bg1"
+
+var startTests = []struct {
+	reason    string
+	ttl       string
+	active    bool
+	expectErr bool
+}{
+	{"sev1 outage", "1h", false, false},
+	{"sev1 outage", "1h", true, true},
+	{"  ", "1h", false, true},
+	{"sev1 outage", "8h", false, true},
+	{"sev1 outage", "never", false, true},
+}
+
+func TestStart(t *testing.T) {
+	for _, data := range startTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		settings.AuthHost = baseURL.String()
+		notified := 0
+		mux.HandleFunc("/orgs/"+test.OrgID+"/break-glass",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				b, _ := ioutil.ReadAll(r.Body)
+				var body struct {
+					Reason string `json:"reason"`
+					TTL    int64  `json:"ttl"`
+				}
+				json.Unmarshal(b, &body)
+				test.AssertEquals(t, strings.TrimSpace(data.reason), body.Reason)
+				fmt.Fprintf(w, `{"id":"%s","organizationId":"%s","reason":"%s","expiresAt":"%s"}`, sessionID, test.OrgID, body.Reason, time.Now().Add(time.Duration(body.TTL)*time.Second).UTC().Format(time.RFC3339))
+			},
+		)
+		mux.HandleFunc("/orgs/"+test.OrgID+"/notifications",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				b, _ := ioutil.ReadAll(r.Body)
+				var n models.Notification
+				json.Unmarshal(b, &n)
+				if len(n.Roles) != 1 || n.Roles[0] != adminRole {
+					t.Errorf("Expected the notification to go to the %s role but got %v", adminRole, n.Roles)
+				}
+				notified++
+				fmt.Fprint(w, `{}`)
+			},
+		)
+		if data.active {
+			settings.BreakGlass = &models.BreakGlassSession{ID: "other", OrgID: settings.OrgID, ExpiresAt: time.Now().Add(time.Hour).Format(time.RFC3339)}
+		}
+
+		// test
+		err := CmdStart(data.reason, data.ttl, settings, New(settings), inbox.New(settings), &test.FakePrompts{})
+		httpclient.SetBreakGlassSession("")
+		test.Teardown(server)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if data.expectErr {
+			continue
+		}
+		if settings.BreakGlass == nil || settings.BreakGlass.ID != sessionID {
+			t.Errorf("Expected break-glass session %s to be saved but got %+v", sessionID, settings.BreakGlass)
+		}
+		if notified != 1 {
+			t.Errorf("Expected the admins to be notified once but were notified %d times", notified)
+		}
+	}
+}
+
+func TestEnd(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	ended := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/break-glass/"+sessionID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			ended = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	if err := CmdEnd(settings, New(settings)); err == nil {
+		t.Error("Expected an error ending a session that was not started")
+	}
+	settings.BreakGlass = &models.BreakGlassSession{ID: sessionID, OrgID: settings.OrgID, ExpiresAt: time.Now().Add(time.Hour).Format(time.RFC3339)}
+	if err := CmdEnd(settings, New(settings)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !ended {
+		t.Error("Expected the break-glass session to be ended")
+	}
+	if settings.BreakGlass != nil {
+		t.Errorf("Expected the break-glass session to be cleared but got %+v", settings.BreakGlass)
+	}
+}
@@ -0,0 +1,120 @@
+package breakglass
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/inbox"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "breakglass",
+	ShortHelp: "Lift the production guards of the CLI during an emergency",
+	LongHelp: "The `breakglass` command allows you to start an emergency session for the organization of the associated environment that lifts the production guards of the CLI for the environments of the organization. " +
+		"While the session is active, the production branch check of the [git-hooks](#git-hooks) pre-push hook lets a hotfix branch be pushed to a production environment, " +
+		"and [environments transfer](#environments-transfer) and [environments migrate](#environments-migrate) run without asking for confirmation. " +
+		"The guards are checked by the CLI on your machine and the session does not change what the Datica API allows you to do, so your role still needs the permissions for every change you make. " +
+		"Starting a session requires a reason and notifies the admins of the organization in their [inbox](#inbox). " +
+		"Every request made while the session is active is tagged with its ID, which shows up in the [audit](#audit) trail so that the changes made during the emergency can be reviewed afterwards. " +
+		"A banner is printed before every command while the session is active. " +
+		"The breakglass command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(EndSubCmd.Name, EndSubCmd.ShortHelp, help.Render(EndSubCmd.LongHelp), EndSubCmd.CmdFunc(settings))
+			cmd.CommandLong(StartSubCmd.Name, StartSubCmd.ShortHelp, help.Render(StartSubCmd.LongHelp), StartSubCmd.CmdFunc(settings))
+			cmd.CommandLong(StatusSubCmd.Name, StatusSubCmd.ShortHelp, help.Render(StatusSubCmd.LongHelp), StatusSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var EndSubCmd = models.Command{
+	Name:      "end",
+	ShortHelp: "End the break-glass session",
+	LongHelp: "`breakglass end` ends the active break-glass session so that the production guards apply again and requests are no longer tagged with its ID. " +
+		"Sessions end on their own once they expire. Here is a sample command\n\n" +
+		"```\ndatica breakglass end\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdEnd(settings, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var StartSubCmd = models.Command{
+	Name:      "start",
+	ShortHelp: "Start a break-glass session",
+	LongHelp: "`breakglass start` starts an emergency session for the organization of the associated environment that lasts for the time given by `--ttl`, at most " + maxTTLString + ". " +
+		"A reason must be given with `--reason`, which is sent to the admins of the organization and recorded with the session. " +
+		"You will be asked to confirm before the session starts, use the global `--yes` flag to skip the confirmation. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" breakglass start --reason \"sev1 outage\"\n" +
+		"datica -E \"<your_env_alias>\" breakglass start --reason \"INC-1234 database failover\" --ttl 2h\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			reason := subCmd.StringOpt("r reason", "", "Why the production guards need to be lifted")
+			ttl := subCmd.StringOpt("t ttl", "1h", "How long the session lasts, i.e. '2h'")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdStart(*reason, *ttl, settings, New(settings), inbox.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "--reason [--ttl]"
+		}
+	},
+}
+
+var StatusSubCmd = models.Command{
+	Name:      "status",
+	ShortHelp: "Show the active break-glass session",
+	LongHelp: "`breakglass status` prints the ID, reason, and expiration of the active break-glass session, if any. Here is a sample command\n\n" +
+		"```\ndatica breakglass status\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				err := CmdStatus(settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+// IBreakGlass
+type IBreakGlass interface {
+	Start(session *models.BreakGlassSession, ttl int64) (*models.BreakGlassSession, error)
+	End(session *models.BreakGlassSession) error
+}
+
+// SBreakGlass is a concrete implementation of IBreakGlass
+type SBreakGlass struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IBreakGlass
+func New(settings *models.Settings) IBreakGlass {
+	return &SBreakGlass{
+		Settings: settings,
+	}
+}
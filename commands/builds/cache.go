@@ -0,0 +1,103 @@
+package builds
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdCacheClear(svcName string, ib IBuilds, is services.IServices) error {
+	if svcName == "" {
+		return errors.New("You must specify the name of the code service whose build cache to clear")
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if service.Type != "code" {
+		return fmt.Errorf("The \"%s\" service is not a code service and does not have a build cache", svcName)
+	}
+	if err = ib.ClearCache(service.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Cleared the build cache of %s. The next build will download every dependency again.", svcName)
+	return nil
+}
+
+func CmdCacheInfo(svcName string, ib IBuilds, is services.IServices) error {
+	svcs, err := is.List()
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{}
+	svcID := ""
+	for _, s := range *svcs {
+		labels[s.ID] = s.Label
+		if s.Label == svcName {
+			svcID = s.ID
+		}
+	}
+	if svcName != "" && svcID == "" {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	caches, err := ib.ListCaches()
+	if err != nil {
+		return err
+	}
+	data := [][]string{{"Service", "Size", "Buildpack", "Created At", "Last Used"}}
+	for _, c := range *caches {
+		if svcID != "" && c.ServiceID != svcID {
+			continue
+		}
+		label, ok := labels[c.ServiceID]
+		if !ok {
+			label = c.ServiceID
+		}
+		data = append(data, []string{label, config.FormatBytes(float64(c.Size)), c.Buildpack, config.FormatTimestampString(c.CreatedAt), config.FormatTimestampString(c.LastUsed)})
+	}
+	if len(data) == 1 {
+		logrus.Println("No build caches found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.Render()
+	return nil
+}
+
+func (b *SBuilds) ClearCache(svcID string) error {
+	headers := b.Settings.HTTPManager.GetHeaders(b.Settings.SessionToken, b.Settings.Version, b.Settings.Pod, b.Settings.UsersID)
+	resp, statusCode, err := b.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/build-cache", b.Settings.PaasHost, b.Settings.PaasHostVersion, b.Settings.EnvironmentID, svcID), headers)
+	if err != nil {
+		return err
+	}
+	return b.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
+
+func (b *SBuilds) ListCaches() (*[]models.BuildCache, error) {
+	headers := b.Settings.HTTPManager.GetHeaders(b.Settings.SessionToken, b.Settings.Version, b.Settings.Pod, b.Settings.UsersID)
+	resp, statusCode, err := b.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/build-cache", b.Settings.PaasHost, b.Settings.PaasHostVersion, b.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var caches []models.BuildCache
+	err = b.Settings.HTTPManager.ConvertResp(resp, statusCode, &caches)
+	if err != nil {
+		return nil, err
+	}
+	return &caches, nil
+}
@@ -0,0 +1,89 @@
+package builds
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+var cacheClearTests = []struct {
+	svcName   string
+	expectErr bool
+}{
+	{test.SvcLabel, false},
+	{test.SvcLabelAlt, true},
+	{"invalid-svc", true},
+	{"", true},
+}
+
+func TestCacheClear(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"%s","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/build-cache",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			w.WriteHeader(204)
+		},
+	)
+
+	for _, data := range cacheClearTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdCacheClear(data.svcName, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	}
+}
+
+var cacheInfoTests = []struct {
+	svcName   string
+	expectErr bool
+}{
+	{"", false},
+	{test.SvcLabel, false},
+	{"invalid-svc", true},
+}
+
+func TestCacheInfo(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"%s","type":"code"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/build-cache",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"service":"%s","size":52428800,"buildpack":"ruby","created_at":"2017-03-01T12:00:00Z","last_used":"2017-03-02T12:00:00Z"},{"service":"%s","size":1024}]`, test.SvcID, test.SvcIDAlt)
+		},
+	)
+
+	for _, data := range cacheInfoTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdCacheInfo(data.svcName, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	}
+}
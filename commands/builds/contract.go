@@ -0,0 +1,114 @@
+package builds
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "builds",
+	ShortHelp: "Manage the builds of your code services",
+	LongHelp: "The `builds` command allows you to manage how your code services are built when you push code. " +
+		"The builds command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CacheSubCmd.Name, CacheSubCmd.ShortHelp, help.Render(CacheSubCmd.LongHelp), CacheSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CacheSubCmd = models.Command{
+	Name:      "cache",
+	ShortHelp: "Manage the build cache of your code services",
+	LongHelp: "`builds cache` manages the build cache of your code services. " +
+		"Each code service keeps a cache of the dependencies its buildpack downloaded so that later builds are faster. " +
+		"When a build behaves differently than it does locally, a stale build cache is a common cause. " +
+		"The cache command can not be run directly but has sub commands.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.CommandLong(CacheClearSubCmd.Name, CacheClearSubCmd.ShortHelp, help.Render(CacheClearSubCmd.LongHelp), CacheClearSubCmd.CmdFunc(settings))
+			subCmd.CommandLong(CacheInfoSubCmd.Name, CacheInfoSubCmd.ShortHelp, help.Render(CacheInfoSubCmd.LongHelp), CacheInfoSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CacheClearSubCmd = models.Command{
+	Name:      "clear",
+	ShortHelp: "Clear the build cache of a code service",
+	LongHelp: "`builds cache clear` removes the build cache of a code service so that its next build downloads every dependency again. " +
+		"The running service is not affected. " +
+		"To clear the cache as part of a deploy, use `deploy --no-cache`. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" builds cache clear app01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service whose build cache to clear. Defaults to the service pinned by the workspace file.")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCacheClear(config.ServiceName(*serviceName, settings), New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME]"
+		}
+	},
+}
+
+var CacheInfoSubCmd = models.Command{
+	Name:      "info",
+	ShortHelp: "Show the build cache of your code services",
+	LongHelp: "`builds cache info` prints the size, buildpack, and age of the build cache of every code service in the environment, or only the given service. " +
+		"A cache that was created long ago or with a different buildpack than the service currently uses is a good candidate for [builds cache clear](#builds-cache-clear). Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" builds cache info\n" +
+		"datica -E \"<your_env_alias>\" builds cache info app01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of a code service to show the build cache of")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCacheInfo(*serviceName, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME]"
+		}
+	},
+}
+
+// IBuilds
+type IBuilds interface {
+	ClearCache(svcID string) error
+	ListCaches() (*[]models.BuildCache, error)
+}
+
+// SBuilds is a concrete implementation of IBuilds
+type SBuilds struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IBuilds
+func New(settings *models.Settings) IBuilds {
+	return &SBuilds{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/cache"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdLs(ic cache.ICache) error {
+	entries, err := ic.List()
+	if err != nil {
+		return err
+	}
+	if len(*entries) == 0 {
+		logrus.Println("The cache is empty")
+		return nil
+	}
+	var total int64
+	data := [][]string{{"DIGEST", "SIZE", "LAST USED", "REFS"}}
+	for _, e := range *entries {
+		data = append(data, []string{e.Digest[:12], transfer.ByteSize(e.Size).String(), config.FormatTimestampString(e.LastUsed), strings.Join(e.Refs, ", ")})
+		total += e.Size
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	logrus.Printf("\n%d artifacts, %s total", len(*entries), transfer.ByteSize(total))
+	return nil
+}
+
+func CmdPrune(maxSize int64, ic cache.ICache) error {
+	removed, freed, err := ic.Prune(maxSize)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		logrus.Println("Nothing to prune")
+		return nil
+	}
+	logrus.Printf("Removed %d artifacts, freeing %s", removed, transfer.ByteSize(freed))
+	return nil
+}
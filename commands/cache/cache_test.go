@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/lib/cache"
+)
+
+var pruneTests = []struct {
+	maxSize         int64
+	expectArtifacts int
+}{
+	{100, 3},
+	{12, 2},
+	{0, 0},
+}
+
+func TestCachePrune(t *testing.T) {
+	for _, data := range pruneTests {
+		t.Logf("Data: %+v", data)
+		dir, err := ioutil.TempDir("", "cache")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ic := &cache.SCache{Dir: dir}
+		for i, s := range []string{"first", "second", "third"} {
+			digest, err := ic.Put(strings.NewReader(s))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = ic.Link("backup-"+s, digest); err != nil {
+				t.Fatal(err)
+			}
+			path, _ := ic.Get(digest)
+			used := time.Now().Add(time.Duration(i-3) * time.Hour)
+			os.Chtimes(path, used, used)
+		}
+		if err = CmdLs(ic); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+
+		// test
+		err = CmdPrune(data.maxSize, ic)
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		entries, _ := ic.List()
+		if len(*entries) != data.expectArtifacts {
+			t.Errorf("Unexpected number of artifacts. Expected: %d, actual: %d", data.expectArtifacts, len(*entries))
+		}
+		if _, ok := ic.Resolve("backup-first"); ok && data.expectArtifacts < 3 {
+			t.Errorf("Expected the least recently used artifact to be pruned")
+		}
+		os.RemoveAll(dir)
+	}
+}
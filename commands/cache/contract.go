@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/cache"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "cache",
+	ShortHelp: "Manage the local cache of downloaded artifacts",
+	LongHelp: "The `cache` command manages the local cache of downloaded artifacts, such as backups downloaded with `db download --cache` and the parts of backups exported with `db export --incremental --cache`. " +
+		"Artifacts are stored by the digest of their contents so the same artifact is never downloaded or stored twice. " +
+		"The cache holds decrypted data, which could contain PHI. " +
+		"The cache command can not be run directly but has sub commands.",
+	Category: models.CategoryData,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(LsSubCmd.Name, LsSubCmd.ShortHelp, help.Render(LsSubCmd.LongHelp), LsSubCmd.CmdFunc(settings))
+			cmd.CommandLong(PruneSubCmd.Name, PruneSubCmd.ShortHelp, help.Render(PruneSubCmd.LongHelp), PruneSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var LsSubCmd = models.Command{
+	Name:      "ls",
+	ShortHelp: "List the artifacts in the local cache",
+	LongHelp: "`cache ls` lists every artifact in the local cache, most recently used first, along with its size and the backups it was downloaded from. Here is a sample command\n\n" +
+		"```\ndatica cache ls\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				err := CmdLs(cache.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var PruneSubCmd = models.Command{
+	Name:      "prune",
+	ShortHelp: "Remove artifacts from the local cache",
+	LongHelp: "`cache prune` removes the least recently used artifacts from the local cache until it is no larger than `--max-size`, such as `500M` or `20G`. " +
+		"Without `--max-size` every artifact is removed. Here are some sample commands\n\n" +
+		"```\ndatica cache prune --max-size 20G\n" +
+		"datica cache prune\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			maxSize := subCmd.StringOpt("max-size", "0", "The size to shrink the cache to, such as 500M or 20G")
+			subCmd.Action = func() {
+				size, err := transfer.ParseSize(*maxSize)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdPrune(int64(size), cache.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--max-size]"
+		}
+	},
+}
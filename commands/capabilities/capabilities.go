@@ -0,0 +1,196 @@
+package capabilities
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/roles"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+	"github.com/olekukonko/tablewriter"
+)
+
+type capabilities struct {
+	Version       string      `json:"version"`
+	Pod           *models.Pod `json:"pod"`
+	Role          string      `json:"role"`
+	GlobalOptions []string    `json:"globalOptions"`
+	Commands      []command   `json:"commands"`
+}
+
+type command struct {
+	Name       string   `json:"name"`
+	Permission string   `json:"permission,omitempty"`
+	Options    []string `json:"options"`
+	Args       []string `json:"args"`
+}
+
+func CmdCapabilities(root *cli.Cmd, podName, usersID string, jsonOutput bool, ip pods.IPods, ir roles.IRoles, iu users.IUsers) error {
+	c, err := gather(root, podName, usersID, ip, ir, iu)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		b, _ := json.MarshalIndent(c, "", "    ")
+		logrus.Println(string(b))
+		return nil
+	}
+
+	logrus.Printf("Version: %s", c.Version)
+	if c.Pod != nil {
+		logrus.Printf("Pod: %s", c.Pod.Name)
+		logrus.Printf("Pod features: %s", strings.Join(podFeatures(c.Pod), ", "))
+	} else {
+		logrus.Printf("Pod: %s (not listed by the platform)", podName)
+	}
+	if c.Role != "" {
+		logrus.Printf("Role: %s", c.Role)
+	} else {
+		logrus.Println("Role: unknown")
+	}
+	logrus.Printf("Global options: %s\n", strings.Join(c.GlobalOptions, ", "))
+
+	data := [][]string{{"COMMAND", "OPTIONS", "ARGS"}}
+	for _, cmd := range c.Commands {
+		data = append(data, []string{cmd.Name, strings.Join(cmd.Options, ", "), strings.Join(cmd.Args, " ")})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// gather collects the capabilities of the CLI, the given pod, and the user's
+// role. Commands that need a permission the role does not have are left out.
+// Failing to look up the role is not fatal since listing the users of an
+// organization may itself require a role the user does not have, in which
+// case every command is listed.
+func gather(root *cli.Cmd, podName, usersID string, ip pods.IPods, ir roles.IRoles, iu users.IUsers) (*capabilities, error) {
+	c := &capabilities{
+		Version:  config.VERSION,
+		Commands: []command{},
+	}
+	podList, err := ip.List()
+	if err != nil {
+		return nil, err
+	}
+	if podList != nil {
+		for i := range *podList {
+			if (*podList)[i].Name == podName {
+				c.Pod = &(*podList)[i]
+				break
+			}
+		}
+	}
+	var permissions map[string]bool
+	role, err := userRole(usersID, ir, iu)
+	if err != nil {
+		logrus.Debugf("Error looking up your role: %s", err)
+	} else if role != nil {
+		c.Role = role.Name
+		permissions = map[string]bool{}
+		for _, p := range roles.RolePermissions(role) {
+			permissions[p] = true
+		}
+	}
+
+	if !root.Initialized() {
+		if err = root.DoInit(); err != nil {
+			return nil, err
+		}
+	}
+	c.GlobalOptions = root.OptionNames()
+	if err = walk(root, []string{}, permissions, &c.Commands); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// walk adds every runnable command below cmd to commands. Commands that only
+// group sub commands are skipped but their sub commands are not. Unless
+// permissions is nil, commands that need a permission not in it are skipped
+// along with their sub commands.
+func walk(cmd *cli.Cmd, path []string, permissions map[string]bool, commands *[]command) error {
+	for _, sub := range cmd.Commands {
+		subPath := append(append([]string{}, path...), sub.Name)
+		permission := roles.CommandPermissions[subPath[0]]
+		if permissions != nil && permission != "" && !permissions[permission] {
+			continue
+		}
+		if !sub.Initialized() {
+			if err := sub.DoInit(); err != nil {
+				return err
+			}
+		}
+		if sub.Action != nil {
+			*commands = append(*commands, command{
+				Name:       strings.Join(subPath, " "),
+				Permission: permission,
+				Options:    sub.OptionNames(),
+				Args:       sub.ArgNames(),
+			})
+		}
+		if err := walk(sub, subPath, permissions, commands); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userRole returns the role the user with the given ID has in the associated
+// environment's organization, or nil if the user or role is not found
+func userRole(usersID string, ir roles.IRoles, iu users.IUsers) (*models.Role, error) {
+	orgUsers, err := iu.List()
+	if err != nil {
+		return nil, err
+	}
+	roleID := -1
+	for _, u := range *orgUsers {
+		if u.ID == usersID {
+			roleID = u.RoleID
+			break
+		}
+	}
+	if roleID == -1 {
+		return nil, nil
+	}
+	orgRoles, err := ir.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range *orgRoles {
+		if (*orgRoles)[i].ID == roleID {
+			return &(*orgRoles)[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// podFeatures describes the features the given pod advertises
+func podFeatures(p *models.Pod) []string {
+	features := []string{}
+	if p.PHISafe {
+		features = append(features, "PHI safe")
+	}
+	if p.Streaming {
+		features = append(features, "streaming")
+	}
+	if p.ImportRequiresLength {
+		features = append(features, "imports require a length")
+	}
+	if len(features) == 0 {
+		features = append(features, "none")
+	}
+	return features
+}
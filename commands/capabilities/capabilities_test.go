@@ -0,0 +1,206 @@
+package capabilities
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/roles"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/test"
+	"github.com/jault3/mow.cli"
+)
+
+var gatherTests = []struct {
+	podName        string
+	usersID        string
+	role           string
+	expectPod      bool
+	expectCommands string
+}{
+	{test.Pod, "1", "admin", true, "logs,worker scale,version"},
+	{test.Pod, "2", "member", true, "logs,worker scale,version"},
+	{test.Pod, "3", "", true, "logs,worker scale,version"},
+	{test.Pod, "4", "log reader", true, "logs,version"},
+	{test.Pod, "5", "deployer", true, "worker scale,version"},
+	{"unknown", "1", "admin", false, "logs,worker scale,version"},
+}
+
+func testRoot() *cli.Cmd {
+	app := cli.App("datica", "")
+	app.String(cli.StringOpt{Name: "E env", Desc: "The local alias of the environment"})
+	app.Command("logs", "", func(cmd *cli.Cmd) {
+		cmd.Action = func() {}
+	})
+	app.Command("worker", "", func(cmd *cli.Cmd) {
+		cmd.Command("scale", "", func(subCmd *cli.Cmd) {
+			subCmd.StringArg("TARGET", "", "")
+			subCmd.StringArg("SCALE", "", "")
+			subCmd.BoolOpt("dry-run", false, "")
+			subCmd.Action = func() {}
+			subCmd.Spec = "TARGET SCALE [--dry-run]"
+		})
+	})
+	app.Command("version", "", func(cmd *cli.Cmd) {
+		cmd.Action = func() {}
+	})
+	return app.Cmd
+}
+
+func TestGather(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/pods",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"pods":[{"name":"%s","phiSafe":true,"streaming":true}]}`, test.Pod))
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":5,"name":"member"},{"id":6,"name":"log reader","custom":true,"permissions":["logs"]},{"id":7,"name":"deployer","custom":true,"permissions":["deploy"]}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"admin@example.com","roleID":1},{"id":"2","email":"user@example.com","roleID":5},{"id":"4","email":"logs@example.com","roleID":6},{"id":"5","email":"deploy@example.com","roleID":7}]`)
+		},
+	)
+
+	for _, data := range gatherTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		c, err := gather(testRoot(), data.podName, data.usersID, pods.New(settings), roles.New(settings), users.New(settings))
+
+		// assert
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if (c.Pod != nil) != data.expectPod {
+			t.Errorf("Expected pod to be found: %t, actual pod: %+v", data.expectPod, c.Pod)
+		}
+		if c.Pod != nil && (!c.Pod.PHISafe || !c.Pod.Streaming) {
+			t.Errorf("Pod features were not returned: %+v", c.Pod)
+		}
+		test.AssertEquals(t, data.role, c.Role)
+		test.AssertEquals(t, "-E --env", strings.Join(c.GlobalOptions, ","))
+		names := []string{}
+		for _, cmd := range c.Commands {
+			names = append(names, cmd.Name)
+			if cmd.Name == "worker scale" {
+				test.AssertEquals(t, "deploy", cmd.Permission)
+				test.AssertEquals(t, "--dry-run", strings.Join(cmd.Options, ","))
+				test.AssertEquals(t, "TARGET SCALE", strings.Join(cmd.Args, " "))
+			}
+		}
+		test.AssertEquals(t, data.expectCommands, strings.Join(names, ","))
+	}
+}
+
+var capabilitiesTests = []struct {
+	usersID      string
+	jsonOutput   bool
+	expectOutput []string
+}{
+	{"1", false, []string{"Pod: " + test.Pod, "Pod features:", "Role: admin", "Global options: -E --env", "COMMAND", "worker scale", "--dry-run", "TARGET SCALE"}},
+	{"4", false, []string{"Role: log reader", "logs", "version"}},
+	{"3", false, []string{"Role: unknown"}},
+	{"1", true, []string{`"role": "admin"`, `"name": "worker scale"`, `"permission": "deploy"`}},
+}
+
+func TestCapabilities(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/pods",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"pods":[{"name":"%s","phiSafe":true,"streaming":true}]}`, test.Pod))
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":5,"name":"member"},{"id":6,"name":"log reader","custom":true,"permissions":["logs"]},{"id":7,"name":"deployer","custom":true,"permissions":["deploy"]}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"admin@example.com","roleID":1},{"id":"2","email":"user@example.com","roleID":5},{"id":"4","email":"logs@example.com","roleID":6},{"id":"5","email":"deploy@example.com","roleID":7}]`)
+		},
+	)
+
+	for _, data := range capabilitiesTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdCapabilities(testRoot(), test.Pod, data.usersID, data.jsonOutput, pods.New(settings), roles.New(settings), users.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for _, expected := range data.expectOutput {
+			if !strings.Contains(output, expected) {
+				t.Errorf("Expected the output to contain %q but got %s", expected, output)
+			}
+		}
+	}
+}
+
+func TestGatherRoleForbidden(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/pods",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`{"pods":[{"name":"%s"}]}`, test.Pod))
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(403)
+			fmt.Fprint(w, `{"title":"Forbidden","description":"You do not have access to this resource","code":403}`)
+		},
+	)
+
+	c, err := gather(testRoot(), test.Pod, "1", pods.New(settings), roles.New(settings), users.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, "", c.Role)
+	if len(c.Commands) != 3 {
+		t.Errorf("Expected every command to be listed when the role is unknown, actual: %+v", c.Commands)
+	}
+}
+
+func TestCapabilitiesPodsUnavailable(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/pods",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+			fmt.Fprint(w, `{"title":"Error","description":"error","code":500}`)
+		},
+	)
+
+	err := CmdCapabilities(testRoot(), test.Pod, "1", true, pods.New(settings), roles.New(settings), users.New(settings))
+	if err == nil {
+		t.Fatal("Expected an error when the pods cannot be listed")
+	}
+}
@@ -0,0 +1,56 @@
+package capabilities
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/roles"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// root is the top level command whose sub commands are listed
+var root *cli.Cmd
+
+// SetRoot sets the top level command whose sub commands are listed as
+// capabilities. This must be called once every command has been registered.
+func SetRoot(cmd *cli.Cmd) {
+	root = cmd
+}
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "capabilities",
+	ShortHelp: "List the commands and platform features available to you",
+	LongHelp: "`capabilities` lists everything this version of the CLI can do against the associated environment so wrapper scripts and tools can check for a feature before using it instead of running a command and parsing its failure. " +
+		"The output includes the CLI version, the features of the environment's pod such as whether it is PHI safe or serves streaming endpoints, the name of your role in the environment's organization, the global options, and every command your role may run with its options, arguments, and the permission it needs. " +
+		"Commands that need a permission your custom role does not have are left out. Built-in roles are treated as having every permission, and every command is listed when your role can't be looked up. " +
+		"Permissions are still enforced by the platform when a command is run. " +
+		"Use `--json` to print the capabilities in a stable machine readable format. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" capabilities\n" +
+		"datica -E \"<your_env_alias>\" capabilities --json\n```",
+	Category:   models.CategoryEnvironment,
+	JSONOutput: capabilities{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			jsonOutput := config.JSONOpt(cmd.BoolOpt("json", false, "Output the capabilities as JSON"))
+			cmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCapabilities(root, settings.Pod, settings.UsersID, *jsonOutput, pods.New(settings), roles.New(settings), users.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[--json]"
+		}
+	},
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/daticahealth/cli/commands/ssl"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -17,12 +18,14 @@ var Cmd = models.Command{
 	Name:      "certs",
 	ShortHelp: "Manage your SSL certificates and domains",
 	LongHelp:  "The `certs` command gives access to certificate and private key management for public facing services. The certs command cannot be run directly but has sub commands.",
+	Category:  models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, CreateSubCmd.LongHelp, CreateSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, RmSubCmd.LongHelp, RmSubCmd.CmdFunc(settings))
-			cmd.CommandLong(UpdateSubCmd.Name, UpdateSubCmd.ShortHelp, UpdateSubCmd.LongHelp, UpdateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(InventorySubCmd.Name, InventorySubCmd.ShortHelp, help.Render(InventorySubCmd.LongHelp), InventorySubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(UpdateSubCmd.Name, UpdateSubCmd.ShortHelp, help.Render(UpdateSubCmd.LongHelp), UpdateSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -63,6 +66,38 @@ var CreateSubCmd = models.Command{
 	},
 }
 
+var InventorySubCmd = models.Command{
+	Name:      "inventory",
+	ShortHelp: "List every cert with its expiration, issuer, and sites",
+	LongHelp: "`certs inventory` lists the certs of your environment along with the date each one expires, who issued it, and the sites using it, soonest to expire first. " +
+		"Use `--all-envs` to list the certs of every associated environment in one table. " +
+		"Environments whose certs can not be retrieved are skipped with a warning. " +
+		"Use `--json` or `--csv` to print the inventory in a machine readable format. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" certs inventory\n" +
+		"datica certs inventory --all-envs --csv\n```",
+	JSONOutput: []inventoryEntry{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			allEnvs := subCmd.BoolOpt("all-envs", false, "List the certs of every associated environment")
+			jsonOutput := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the inventory as JSON"))
+			csvOutput := subCmd.BoolOpt("csv", false, "Output the inventory as CSV")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(!*allEnvs, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdInventory(inventoryEnvs(*allEnvs, settings), *jsonOutput, *csvOutput)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--all-envs] [--json | --csv]"
+		}
+	},
+}
+
 var ListSubCmd = models.Command{
 	Name:      "list",
 	ShortHelp: "List all existing domains that have SSL certificate and private key pairs",
@@ -0,0 +1,200 @@
+package certs
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/sites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// inventoryEnv is an environment whose certs are part of an inventory
+type inventoryEnv struct {
+	name   string
+	ic     ICerts
+	isites sites.ISites
+	is     services.IServices
+}
+
+// inventoryEnvs returns the current environment, or every associated
+// environment if all is true, for an inventory
+func inventoryEnvs(all bool, settings *models.Settings) []inventoryEnv {
+	if !all {
+		return []inventoryEnv{{settings.EnvironmentName, New(settings), sites.New(settings), services.New(settings)}}
+	}
+	aliases := []string{}
+	for alias := range settings.Environments {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	envs := []inventoryEnv{}
+	for _, alias := range aliases {
+		envSettings := config.EnvironmentSettings(alias, settings)
+		envs = append(envs, inventoryEnv{alias, New(envSettings), sites.New(envSettings), services.New(envSettings)})
+	}
+	return envs
+}
+
+type inventoryEntry struct {
+	Environment string     `json:"environment"`
+	Hostname    string     `json:"hostname"`
+	Expires     *time.Time `json:"expires"`
+	Issuer      string     `json:"issuer"`
+	Sites       []string   `json:"sites"`
+}
+
+// SortedEntries is a wrapper for an inventory in order to sort it by
+// expiration, soonest first. Certs whose expiration is unknown are last.
+type SortedEntries []inventoryEntry
+
+func (es SortedEntries) Len() int {
+	return len(es)
+}
+
+func (es SortedEntries) Swap(i, j int) {
+	es[i], es[j] = es[j], es[i]
+}
+
+func (es SortedEntries) Less(i, j int) bool {
+	if es[i].Expires == nil || es[j].Expires == nil {
+		return es[j].Expires == nil && es[i].Expires != nil
+	}
+	return es[i].Expires.Before(*es[j].Expires)
+}
+
+func CmdInventory(envs []inventoryEnv, jsonOutput, csvOutput bool) error {
+	if jsonOutput && csvOutput {
+		return errors.New("Only one of --json and --csv can be given")
+	}
+	entries := []inventoryEntry{}
+	for _, env := range envs {
+		envEntries, err := inventory(env)
+		if err != nil {
+			if len(envs) == 1 {
+				return err
+			}
+			config.Warn(config.WarningSkipped, "Skipping the certs of %s: %s", env.name, err)
+			continue
+		}
+		entries = append(entries, envEntries...)
+	}
+	sort.Stable(SortedEntries(entries))
+
+	if jsonOutput {
+		b, _ := json.MarshalIndent(entries, "", "    ")
+		logrus.Println(string(b))
+		return nil
+	}
+	if csvOutput {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"environment", "hostname", "expires", "issuer", "sites"})
+		for _, e := range entries {
+			expires := ""
+			if e.Expires != nil {
+				expires = e.Expires.Format(time.RFC3339)
+			}
+			w.Write([]string{e.Environment, e.Hostname, expires, e.Issuer, strings.Join(e.Sites, " ")})
+		}
+		w.Flush()
+		logrus.Print(strings.TrimSuffix(buf.String(), "\n"))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		logrus.Println("No certs found")
+		return nil
+	}
+	data := [][]string{{"ENVIRONMENT", "HOSTNAME", "EXPIRES", "ISSUER", "SITES"}}
+	for _, e := range entries {
+		expires := "unknown"
+		if e.Expires != nil {
+			expires = config.FormatTimestamp(*e.Expires)
+			if e.Expires.Before(time.Now()) {
+				expires += " (expired)"
+			}
+		}
+		data = append(data, []string{e.Environment, e.Hostname, expires, e.Issuer, strings.Join(e.Sites, ", ")})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// inventory lists the certs of a single environment along with the sites
+// using each of them
+func inventory(env inventoryEnv) ([]inventoryEntry, error) {
+	service, err := env.is.RetrieveByLabel("service_proxy")
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, errors.New("Could not find the service_proxy service")
+	}
+	certs, err := env.ic.List(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	siteList, err := env.isites.List(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	bound := map[string][]string{}
+	for _, s := range *siteList {
+		bound[s.Cert] = append(bound[s.Cert], s.Name)
+	}
+	entries := []inventoryEntry{}
+	for _, c := range *certs {
+		e := inventoryEntry{
+			Environment: env.name,
+			Hostname:    c.Name,
+			Sites:       bound[c.Name],
+		}
+		if e.Sites == nil {
+			e.Sites = []string{}
+		}
+		if x509Cert := parseCert(c.PubKey); x509Cert != nil {
+			expires := x509Cert.NotAfter
+			e.Expires = &expires
+			e.Issuer = x509Cert.Issuer.CommonName
+			if e.Issuer == "" && len(x509Cert.Issuer.Organization) > 0 {
+				e.Issuer = x509Cert.Issuer.Organization[0]
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// parseCert parses the first certificate of a PEM encoded chain. nil is
+// returned if the chain was not given or can not be parsed.
+func parseCert(chain string) *x509.Certificate {
+	block, _ := pem.Decode([]byte(chain))
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
@@ -0,0 +1,125 @@
+package certs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+// selfSignedPEM returns a PEM encoded certificate for the hostname that
+// expires at the given time
+func selfSignedPEM(t *testing.T, hostname string, expires time.Time) string {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hostname},
+		NotBefore:    expires.Add(-24 * time.Hour),
+		NotAfter:     expires,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestInventory(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.Environments[test.AliasAlt] = models.AssociatedEnv{
+		Name:          test.EnvNameAlt,
+		EnvironmentID: test.EnvIDAlt,
+		ServiceID:     test.SvcIDAlt,
+		Pod:           test.PodAlt,
+		OrgID:         test.OrgIDAlt,
+	}
+	later := time.Now().Add(90 * 24 * time.Hour).UTC().Truncate(time.Second)
+	sooner := time.Now().Add(10 * 24 * time.Hour).UTC().Truncate(time.Second)
+	certs, _ := json.Marshal([]models.Cert{
+		{Name: "later.example.com", PubKey: selfSignedPEM(t, "later.example.com", later)},
+		{Name: "sooner.example.com", PubKey: selfSignedPEM(t, "sooner.example.com", sooner)},
+		{Name: "unknown.example.com"},
+	})
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `[{"id":"%s","label":"service_proxy"}]`, test.SvcID)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/certs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			w.Write(certs)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/sites",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"www.example.com","cert":"later.example.com"},{"id":2,"name":"api.example.com","cert":"later.example.com"}]`)
+		},
+	)
+	// the second environment has no service proxy and is skipped
+	mux.HandleFunc("/environments/"+test.EnvIDAlt+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[]`)
+		},
+	)
+
+	entries, err := inventory(inventoryEnvs(true, settings)[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 certs, actual: %+v", entries)
+	}
+	// self signed certs are issued by their own subject
+	test.AssertEquals(t, "later.example.com", entries[0].Issuer)
+	test.AssertEquals(t, later.String(), entries[0].Expires.String())
+	test.AssertEquals(t, "www.example.com,api.example.com", strings.Join(entries[0].Sites, ","))
+	if entries[2].Expires != nil {
+		t.Errorf("Expected an unknown expiration for a cert without a chain, actual: %s", entries[2].Expires)
+	}
+
+	if _, err = inventory(inventoryEnvs(true, settings)[1]); err == nil {
+		t.Error("Expected an error for an environment without a service proxy")
+	}
+	for _, output := range [][]bool{{false, false}, {true, false}, {false, true}} {
+		if err = CmdInventory(inventoryEnvs(true, settings), output[0], output[1]); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	}
+	if err = CmdInventory(inventoryEnvs(false, settings), false, false); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if err = CmdInventory(inventoryEnvs(true, settings), true, true); err == nil {
+		t.Error("Expected an error when both --json and --csv are given")
+	}
+}
+
+func TestSortedEntries(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	entries := []inventoryEntry{{Hostname: "unknown"}, {Hostname: "later", Expires: &later}, {Hostname: "now", Expires: &now}}
+	sort.Sort(SortedEntries(entries))
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Hostname)
+	}
+	test.AssertEquals(t, "now,later,unknown", strings.Join(names, ","))
+}
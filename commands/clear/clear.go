@@ -8,13 +8,21 @@ import (
 
 func CmdClear(privateKey, session, environments, defaultEnv, pods bool, settings *models.Settings) error {
 	if defaultEnv {
-		logrus.Warnln("The \"--default\" flag has been deprecated! It will be removed in a future version.")
+		if err := config.Deprecated("The \"--default\" flag has been deprecated! It will be removed in a future version."); err != nil {
+			return err
+		}
 	}
 	if privateKey {
+		if settings.PrivateKeyPath != "" {
+			if err := config.DeletePrivateKey(settings.PrivateKeyPath); err != nil {
+				return err
+			}
+		}
 		settings.PrivateKeyPath = ""
 	}
 	if session {
 		settings.SessionToken = ""
+		settings.RefreshToken = ""
 		settings.UsersID = ""
 	}
 	if environments {
@@ -25,6 +33,7 @@ func CmdClear(privateKey, session, environments, defaultEnv, pods bool, settings
 	}
 	if pods {
 		settings.Pods = &[]models.Pod{}
+		settings.Unavailable = nil
 	}
 	config.SaveSettings(settings)
 	if !privateKey && !session && !environments && !defaultEnv && !pods {
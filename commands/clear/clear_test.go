@@ -3,6 +3,8 @@ package clear
 import (
 	"testing"
 
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/probe"
 	"github.com/daticahealth/cli/test"
 )
 
@@ -26,6 +28,7 @@ func TestClear(t *testing.T) {
 	for _, data := range clearTests {
 		t.Logf("Data: %+v", data)
 		settings := test.GetSettings("")
+		settings.Unavailable = map[string]int64{"pod01/" + probe.RunningJobs: 1}
 		err := CmdClear(data.privKey, data.session, data.envs, data.defaultEnv, data.pods, settings)
 		if err != nil {
 			t.Errorf("Unexpected error: %s", err)
@@ -47,5 +50,36 @@ func TestClear(t *testing.T) {
 		if data.pods && settings.Pods != nil && len(*settings.Pods) != 0 {
 			t.Errorf("Pods should have been cleared")
 		}
+		if data.pods && len(settings.Unavailable) != 0 {
+			t.Errorf("Features pods do not serve should have been cleared")
+		}
+	}
+}
+
+var clearStrictTests = []struct {
+	session    bool
+	defaultEnv bool
+	expectErr  bool
+}{
+	{true, false, false},
+	{false, true, true},
+	{true, true, true},
+}
+
+func TestClearStrict(t *testing.T) {
+	config.SetStrict(true)
+	defer config.SetStrict(false)
+	for _, data := range clearStrictTests {
+		t.Logf("Data: %+v", data)
+		settings := test.GetSettings("")
+		settings.Default = test.EnvName
+		err := CmdClear(false, data.session, false, data.defaultEnv, false, settings)
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectErr && (settings.Default == "" || settings.SessionToken == "") {
+			t.Errorf("Nothing should have been cleared when deprecated usage fails")
+		}
 	}
 }
@@ -13,18 +13,21 @@ var Cmd = models.Command{
 	ShortHelp: "Clear out information in the global settings file to fix a misconfigured CLI.",
 	LongHelp: "`clear` allows you to manage your global settings file in case your CLI becomes misconfigured. " +
 		"The global settings file is stored in your home directory at `~/.datica`. " +
+		"Your session token, the refresh token that renews it, and your private key are stored in the keychain of your OS, or in `~/.datica_credentials` when your OS has no keychain, and are removed from there as well. " +
+		"Set the `DATICA_KEYRING` environment variable to `file` to always use `~/.datica_credentials`, such as on headless systems. " +
 		"You can clear out all settings or pick and choose which ones need to be removed. " +
 		"After running the `clear` command, any other CLI command will reset the removed settings to their appropriate values. Here are some sample commands\n\n" +
 		"```\ndatica clear --all\n" +
 		"datica clear --environments # removes your associated environments\n" +
 		"datica clear --session --private-key # removes all session and private key authentication information\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			privateKey := cmd.BoolOpt("private-key", false, "Clear out the saved private key information")
 			session := cmd.BoolOpt("session", false, "Clear out all session information")
 			envs := cmd.BoolOpt("environments", false, "Clear out all associated environments")
 			defaultEnv := cmd.BoolOpt("default", false, "[DEPRECATED] Clear out the saved default environment")
-			pods := cmd.BoolOpt("pods", false, "Clear out all saved pods")
+			pods := cmd.BoolOpt("pods", false, "Clear out all saved pods and the features they were found not to serve")
 			all := cmd.BoolOpt("all", false, "Clear out all settings")
 			cmd.Action = func() {
 				if *all {
@@ -1,22 +1,16 @@
 package console
 
 import (
-	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"os"
-	"os/signal"
-	"runtime"
+	"net/http"
 	"strings"
 
-	"golang.org/x/net/websocket"
-
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/terminal"
 	"github.com/daticahealth/cli/models"
-	"github.com/docker/docker/pkg/term"
 )
 
 func CmdConsole(svcName, command string, ic IConsole, is services.IServices) error {
@@ -36,31 +30,17 @@ func CmdConsole(svcName, command string, ic IConsole, is services.IServices) err
 // is needed - instead, the appropriate command for the database type is run.
 // For example, for a postgres database, psql is run.
 func (c *SConsole) Open(command string, service *models.Service) error {
-	stdin, stdout, _ := term.StdStreams()
-	fdIn, isTermIn := term.GetFdInfo(stdin)
-	if !isTermIn {
-		return errors.New("StdIn is not a terminal")
-	}
-	var size *term.Winsize
-	var err error
-	if runtime.GOOS != "windows" {
-		size, err = term.GetWinsize(fdIn)
-	} else {
-		fdOut, _ := term.GetFdInfo(stdout)
-		size, err = term.GetWinsize(fdOut)
-	}
-
+	t, err := terminal.New()
 	if err != nil {
 		return err
 	}
-	if size.Width != 80 {
-		logrus.Warnln("Your terminal width is not 80 characters. Please resize your terminal to be exactly 80 characters wide to avoid line wrapping issues.")
-	} else {
-		logrus.Warnln("Keep your terminal width at 80 characters. Resizing your terminal will introduce line wrapping issues.")
+	size, err := t.Size()
+	if err != nil {
+		return err
 	}
 
 	logrus.Printf("Opening console to %s (%s)", service.Name, service.ID)
-	job, err := c.Request(command, service)
+	job, err := c.Request(command, size, service)
 	if err != nil {
 		return err
 	}
@@ -91,38 +71,29 @@ func (c *SConsole) Open(command string, service *models.Service) error {
 
 	creds.URL = strings.Replace(creds.URL, "http", "ws", 1)
 	logrus.Println("\nConnecting...")
-
-	// BEGIN websocket impl
-	config, _ := websocket.NewConfig(creds.URL, "ws://localhost:9443/")
-	config.TlsConfig = &tls.Config{
-		MinVersion: tls.VersionTLS12,
-	}
-	config.Header["X-Console-Token"] = []string{creds.Token}
-	ws, err := websocket.DialConfig(config)
+	ws, err := terminal.Dial(creds.URL, http.Header{"X-Console-Token": {creds.Token}}, c.Settings.HTTPManager)
 	if err != nil {
 		return err
 	}
 	defer ws.Close()
 	logrus.Println("Connection opened")
 
-	oldState, err := term.SetRawTerminal(fdIn)
-	if err != nil {
-		return err
+	err = t.Attach(ws)
+	if err != nil && err != io.EOF {
+		logrus.Printf("Connection lost: %s", err)
+		return nil
 	}
-	defer term.RestoreTerminal(fdIn, oldState)
-
-	signal.Notify(make(chan os.Signal, 1), os.Interrupt)
-
-	done := make(chan struct{}, 2)
-	go readWS(ws, stdout, done)
-	go readStdin(stdin, ws, done)
-
-	<-done
+	logrus.Println("Connection closed")
 	return nil
 }
 
-func (c *SConsole) Request(command string, service *models.Service) (*models.Job, error) {
-	console := map[string]string{}
+// Request creates a console job for the service with a PTY of the given size
+func (c *SConsole) Request(command string, size *terminal.Size, service *models.Service) (*models.Job, error) {
+	console := map[string]interface{}{
+		"tty":  true,
+		"cols": size.Cols,
+		"rows": size.Rows,
+	}
 	if command != "" {
 		console["command"] = command
 	}
@@ -160,25 +131,3 @@ func (c *SConsole) RetrieveTokens(jobID string, service *models.Service) (*model
 func (c *SConsole) Destroy(jobID string, service *models.Service) error {
 	return c.Jobs.Delete(jobID, service.ID)
 }
-
-// Reads incoming data from the websocket and forwards it to stdout.
-func readWS(ws *websocket.Conn, t io.Writer, done chan struct{}) {
-	_, err := io.Copy(t, ws)
-	if err == io.EOF {
-		logrus.Println("Connection closed")
-	} else if err != nil {
-		logrus.Printf("Error reading data from server: %s", err)
-	}
-	done <- struct{}{}
-}
-
-// Reads data from stdin and writes it to the websocket.
-func readStdin(t io.ReadCloser, ws *websocket.Conn, done chan struct{}) {
-	_, err := io.Copy(ws, t)
-	if err == io.EOF {
-		logrus.Println("Input closed")
-	} else if err != nil {
-		logrus.Printf("Error writing data to server: %s", err)
-	}
-	done <- struct{}{}
-}
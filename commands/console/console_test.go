@@ -1,12 +1,15 @@
 package console
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/terminal"
+	"github.com/daticahealth/cli/models"
 	"github.com/daticahealth/cli/test"
 )
 
@@ -43,3 +46,31 @@ func TestConsole(t *testing.T) {
 		}
 	}
 }
+
+func TestConsoleRequest(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/console",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			var body struct {
+				Command string `json:"command"`
+				TTY     bool   `json:"tty"`
+				Cols    int    `json:"cols"`
+				Rows    int    `json:"rows"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			test.AssertEquals(t, "rails console", body.Command)
+			test.AssertEquals(t, "true", fmt.Sprintf("%t", body.TTY))
+			test.AssertEquals(t, "120x40", fmt.Sprintf("%dx%d", body.Cols, body.Rows))
+			fmt.Fprint(w, `{"id":"job1","status":"scheduled"}`)
+		},
+	)
+
+	job, err := New(settings, jobs.New(settings)).Request("rails console", &terminal.Size{Cols: 120, Rows: 40}, &models.Service{ID: test.SvcID})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, "job1", job.ID)
+}
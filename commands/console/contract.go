@@ -7,6 +7,7 @@ import (
 	"github.com/daticahealth/cli/lib/auth"
 	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/lib/terminal"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
 )
@@ -20,9 +21,11 @@ var Cmd = models.Command{
 		"For example, if you open up a console to a postgres database, you will be given access to a psql prompt. " +
 		"You can also open up a mysql prompt, mongo cli prompt, rails console, django shell, and much more. " +
 		"When accessing a database service, the `COMMAND` argument is not needed because the appropriate prompt will be given to you. " +
-		"If you are connecting to an application service the `COMMAND` argument is required. Here are some sample commands\n\n" +
+		"If you are connecting to an application service the `COMMAND` argument is required. " +
+		"The console runs in a full terminal that follows the size of your own, so you can resize your terminal at any time, and keys such as ctrl-C are sent to the console instead of closing it. Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" console db01\n" +
 		"datica -E \"<your_env_alias>\" console app01 \"bundle exec rails console\"\n```",
+	Category: models.CategoryData,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the service to open up a console for")
@@ -47,7 +50,7 @@ var Cmd = models.Command{
 // IConsole
 type IConsole interface {
 	Open(command string, service *models.Service) error
-	Request(command string, service *models.Service) (*models.Job, error)
+	Request(command string, size *terminal.Size, service *models.Service) (*models.Job, error)
 	RetrieveTokens(jobID string, service *models.Service) (*models.ConsoleCredentials, error)
 	Destroy(jobID string, service *models.Service) error
 }
@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "cron",
+	ShortHelp: "Manage the scheduled tasks of a service",
+	LongHelp: "The `cron` command allows you to run a worker of a code service on a schedule, such as a nightly cleanup or an hourly report. " +
+		"Each scheduled task starts the given Procfile target as a worker, the same as [worker deploy](#worker-deploy), every time its schedule comes up. " +
+		"Schedules are standard five field cron expressions (minute, hour, day of month, month, day of week) in UTC. " +
+		"The cron command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RunNowSubCmd.Name, RunNowSubCmd.ShortHelp, help.Render(RunNowSubCmd.LongHelp), RunNowSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Schedule a worker of a service",
+	LongHelp: "`cron create` schedules the given Procfile target of a code service to be started as a worker on the schedule given by `--schedule`. " +
+		"The schedule is checked before the task is created and the next time it will run is printed. " +
+		"Fields may be lists, ranges, or `*`, each optionally followed by a step such as `*/15`, and months and days of the week may be given by name. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" cron create code-1 cleanup --schedule \"0 3 * * *\"\n" +
+		"datica -E \"<your_env_alias>\" cron create code-1 report --schedule \"*/30 8-17 * * MON-FRI\"\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service to run the worker of")
+			target := subCmd.StringArg("TARGET", "", "The name of the Procfile target to start as a worker")
+			schedule := subCmd.StringOpt("s schedule", "", "The cron expression describing when to start the worker, in UTC (i.e. '0 3 * * *')")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCreate(*serviceName, *target, *schedule, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME TARGET --schedule"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the scheduled tasks of a service",
+	LongHelp: "`cron list` lists the scheduled tasks of a code service along with their target, schedule, and the next time each will run. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" cron list code-1\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service to list the scheduled tasks of")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(*serviceName, services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME"
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove a scheduled task",
+	LongHelp: "`cron rm` removes a scheduled task so that its worker is no longer started. Workers the task already started keep running. " +
+		"You will be asked to confirm before the task is removed, use the global `--yes` flag to skip the confirmation. " +
+		"The ID of each task is shown by [cron list](#cron-list). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" cron rm code-1 cd2b4bce-2727-42d1-89e0-027bf3f1a203\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service the task belongs to")
+			taskID := subCmd.StringArg("TASK_ID", "", "The ID of the scheduled task to remove")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRm(*serviceName, *taskID, services.New(settings), jobs.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME TASK_ID"
+		}
+	},
+}
+
+var RunNowSubCmd = models.Command{
+	Name:      "run-now",
+	ShortHelp: "Start the worker of a scheduled task right away",
+	LongHelp: "`cron run-now` starts the worker of a scheduled task right away without waiting for its schedule, such as to test a new task. " +
+		"The schedule of the task is not changed. " +
+		"Use `--follow` to wait for the worker to finish, otherwise you can wait for it later with [jobs attach](#jobs-attach). Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" cron run-now code-1 cd2b4bce-2727-42d1-89e0-027bf3f1a203\n" +
+		"datica -E \"<your_env_alias>\" cron run-now code-1 cd2b4bce-2727-42d1-89e0-027bf3f1a203 --follow\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service the task belongs to")
+			taskID := subCmd.StringArg("TASK_ID", "", "The ID of the scheduled task to run")
+			follow := subCmd.BoolOpt("f follow", false, "Wait for the worker to finish")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRunNow(*serviceName, *taskID, *follow, New(settings), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME TASK_ID [-f]"
+		}
+	},
+}
+
+// ICron
+type ICron interface {
+	Create(target, schedule, svcID string) (*models.Job, error)
+	RunNow(taskID, svcID string) (*models.Job, error)
+}
+
+// SCron is a concrete implementation of ICron
+type SCron struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of ICron
+func New(settings *models.Settings) ICron {
+	return &SCron{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libcron "github.com/daticahealth/cli/lib/cron"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdCreate(svcName, target, schedule string, ic ICron, is services.IServices) error {
+	parsed, err := libcron.Parse(schedule)
+	if err != nil {
+		return err
+	}
+	next := parsed.Next(time.Now().UTC())
+	if next.IsZero() {
+		return fmt.Errorf("The cron expression \"%s\" never runs. Please check the day of month and month fields.", schedule)
+	}
+	service, err := codeService(svcName, is)
+	if err != nil {
+		return err
+	}
+	task, err := ic.Create(target, schedule, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Scheduled %s of %s (ID = %s) on \"%s\". It will first run at %s", target, service.Label, task.ID, schedule, config.FormatTimestamp(next))
+	return nil
+}
+
+// Create schedules the target to be started as a worker of the service
+func (c *SCron) Create(target, schedule, svcID string) (*models.Job, error) {
+	b, err := json.Marshal(models.Job{Type: taskType, Target: target, Schedule: schedule})
+	if err != nil {
+		return nil, err
+	}
+	headers := c.Settings.HTTPManager.GetHeaders(c.Settings.SessionToken, c.Settings.Version, c.Settings.Pod, c.Settings.UsersID)
+	resp, statusCode, err := c.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs", c.Settings.PaasHost, c.Settings.PaasHostVersion, c.Settings.EnvironmentID, svcID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var task models.Job
+	err = c.Settings.HTTPManager.ConvertResp(resp, statusCode, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
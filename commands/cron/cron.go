@@ -0,0 +1,39 @@
+package cron
+
+import (
+	"fmt"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+)
+
+// taskType is the job type of scheduled tasks
+const taskType = "cron"
+
+// codeService looks up the code service with the given label
+func codeService(svcName string, is services.IServices) (*models.Service, error) {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if service.Type != "code" {
+		return nil, fmt.Errorf("%s is a %s service. Only code services can have scheduled tasks", service.Label, service.Type)
+	}
+	return service, nil
+}
+
+// findTask retrieves the scheduled task with the given ID
+func findTask(taskID, svcID string, ij jobs.IJobs) (*models.Job, error) {
+	task, err := ij.Retrieve(taskID, svcID, false)
+	if err != nil {
+		return nil, err
+	}
+	if task.Type != taskType {
+		return nil, fmt.Errorf("%s is a %s job, not a scheduled task. You can list scheduled tasks with the \"datica cron list\" command.", taskID, task.Type)
+	}
+	return task, nil
+}
@@ -0,0 +1,240 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const (
+	taskID   = "task1"
+	workerID = "worker1"
+)
+
+var createTests = []struct {
+	svcName   string
+	target    string
+	schedule  string
+	expectErr bool
+}{
+	{test.SvcLabel, "cleanup", "0 3 * * *", false},
+	{test.SvcLabel, "report", "*/30 8-17 * * MON-FRI", false},
+	{test.SvcLabel, "cleanup", "0 3 * *", true},
+	{test.SvcLabel, "cleanup", "0 24 * * *", true},
+	{test.SvcLabel, "cleanup", "0 0 30 2 *", true},
+	{"db01", "cleanup", "0 3 * * *", true},
+	{"invalid-svc", "cleanup", "0 3 * * *", true},
+}
+
+var taskTests = []struct {
+	svcName   string
+	taskID    string
+	expectErr bool
+}{
+	{test.SvcLabel, taskID, false},
+	{test.SvcLabel, workerID, true},
+	{"db01", taskID, true},
+	{"invalid-svc", taskID, true},
+}
+
+const tasksList = `[{"id":"` + taskID + `","type":"cron","target":"cleanup","schedule":"0 3 * * *"},{"id":"task2","type":"cron","target":"report","schedule":"0 0 30 2 *"}]`
+
+func TestCronCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	created := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"db01","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			var task models.Job
+			json.Unmarshal(b, &task)
+			test.AssertEquals(t, task.Type, taskType)
+			created = true
+			task.ID = taskID
+			b, _ = json.Marshal(task)
+			w.Write(b)
+		},
+	)
+
+	for _, data := range createTests {
+		t.Logf("Data: %+v", data)
+		created = false
+
+		// test
+		err := CmdCreate(data.svcName, data.target, data.schedule, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", created))
+	}
+}
+
+var listTests = []struct {
+	tasks      string
+	expectRows []string
+}{
+	{tasksList, []string{"ID", taskID, "cleanup", "0 3 * * *", "task2", "report", "0 0 30 2 *"}},
+	{`[]`, []string{"No tasks have been scheduled for " + test.SvcLabel}},
+}
+
+func TestCronList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	tasks := ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"db01","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			test.AssertEquals(t, r.URL.Query().Get("type"), taskType)
+			fmt.Fprint(w, tasks)
+		},
+	)
+
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+		tasks = data.tasks
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdList(test.SvcLabel, services.New(settings), jobs.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+		for _, line := range strings.Split(output, "\n") {
+			// a schedule that never matches has no next run
+			if strings.Contains(line, "task2") && !strings.HasSuffix(strings.TrimSpace(line), "-") {
+				t.Errorf("Expected task2 to have no next run but got %q", line)
+			}
+		}
+	}
+}
+
+func TestCronRm(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	removed := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"db01","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+taskID,
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "DELETE" {
+				removed = true
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"cron","target":"cleanup","schedule":"0 3 * * *"}`, taskID))
+		},
+	)
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+workerID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"worker","target":"cleanup","status":"finished"}`, workerID))
+		},
+	)
+
+	for _, data := range taskTests {
+		t.Logf("Data: %+v", data)
+		removed = false
+
+		// test
+		err := CmdRm(data.svcName, data.taskID, services.New(settings), jobs.New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", removed))
+	}
+}
+
+func TestCronRunNow(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	removed := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"db01","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+taskID,
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "DELETE" {
+				removed = true
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"cron","target":"cleanup","schedule":"0 3 * * *"}`, taskID))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+taskID+"/run",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"worker","target":"cleanup","status":"scheduled"}`, workerID))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+workerID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"worker","target":"cleanup","status":"finished"}`, workerID))
+		},
+	)
+
+	for _, data := range taskTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdRunNow(data.svcName, data.taskID, true, New(settings), services.New(settings), jobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if removed {
+		t.Error("Expected running a task not to remove it")
+	}
+}
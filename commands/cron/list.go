@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libcron "github.com/daticahealth/cli/lib/cron"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdList(svcName string, is services.IServices, ij jobs.IJobs) error {
+	service, err := codeService(svcName, is)
+	if err != nil {
+		return err
+	}
+	tasks, err := ij.RetrieveAllByType(service.ID, taskType)
+	if err != nil {
+		return err
+	}
+	if tasks == nil || len(*tasks) == 0 {
+		logrus.Printf("No tasks have been scheduled for %s", service.Label)
+		return nil
+	}
+	now := time.Now().UTC()
+	data := [][]string{{"ID", "TARGET", "SCHEDULE", "NEXT RUN"}}
+	for _, t := range *tasks {
+		next := "-"
+		if schedule, err := libcron.Parse(t.Schedule); err == nil {
+			if n := schedule.Next(now); !n.IsZero() {
+				next = config.FormatTimestamp(n)
+			}
+		}
+		data = append(data, []string{t.ID, t.Target, t.Schedule, next})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
@@ -0,0 +1,30 @@
+package cron
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdRm(svcName, taskID string, is services.IServices, ij jobs.IJobs, ip prompts.IPrompts) error {
+	service, err := codeService(svcName, is)
+	if err != nil {
+		return err
+	}
+	task, err := findTask(taskID, service.ID, ij)
+	if err != nil {
+		return err
+	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to stop running %s of %s on \"%s\"? (y/n) ", task.Target, service.Label, task.Schedule))
+	if err != nil {
+		return err
+	}
+	if err = ij.Delete(task.ID, service.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Removed the scheduled task %s", task.ID)
+	return nil
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdRunNow(svcName, taskID string, follow bool, ic ICron, is services.IServices, ij jobs.IJobs) error {
+	service, err := codeService(svcName, is)
+	if err != nil {
+		return err
+	}
+	task, err := findTask(taskID, service.ID, ij)
+	if err != nil {
+		return err
+	}
+	job, err := ic.RunNow(task.ID, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Started %s of %s (job ID = %s)", task.Target, service.Label, job.ID)
+	if !follow {
+		logrus.Printf("Wait for it to finish with \"datica jobs attach %s %s\"", service.Label, job.ID)
+		return nil
+	}
+	status, err := ij.PollForStatus([]string{"finished"}, job.ID, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("\nEnded in status '%s'", status)
+	return nil
+}
+
+// RunNow starts the worker of the scheduled task right away and returns the
+// started worker job
+func (c *SCron) RunNow(taskID, svcID string) (*models.Job, error) {
+	headers := c.Settings.HTTPManager.GetHeaders(c.Settings.SessionToken, c.Settings.Version, c.Settings.Pod, c.Settings.UsersID)
+	resp, statusCode, err := c.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs/%s/run", c.Settings.PaasHost, c.Settings.PaasHostVersion, c.Settings.EnvironmentID, svcID, taskID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var job models.Job
+	err = c.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
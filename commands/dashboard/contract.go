@@ -13,6 +13,7 @@ var Cmd = models.Command{
 	ShortHelp: "Open the Datica Dashboard in your default browser",
 	LongHelp: "`dashboard` opens up the Datica Dashboard homepage in your default web browser. Here is a sample command\n\n" +
 		"```\ndatica dashboard\n```",
+	Category: models.CategoryObservability,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			cmd.Action = func() {
@@ -6,6 +6,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 )
 
@@ -17,6 +18,9 @@ func CmdBackup(databaseName string, skipPoll bool, id IDb, is services.IServices
 	if service == nil {
 		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
 	}
+	if deferred, err := transfer.Deferred("backup"); deferred || err != nil {
+		return err
+	}
 	job, err := id.Backup(service)
 	if err != nil {
 		return err
@@ -46,8 +50,11 @@ func CmdBackup(databaseName string, skipPoll bool, id IDb, is services.IServices
 		if job.Status != "finished" {
 			return fmt.Errorf("Job finished with invalid status %s", job.Status)
 		}
-	} else if isSnapshotBackup {
-		logrus.Printf("This is a snapshot backup, it may be a while before this backup shows up in the \"datica db list %s\" command.", databaseName)
+	} else {
+		if isSnapshotBackup {
+			logrus.Printf("This is a snapshot backup, it may be a while before this backup shows up in the \"datica db list %s\" command.", databaseName)
+		}
+		logrus.Printf("You can wait for the backup to finish with the \"datica jobs attach %s %s\" command", databaseName, job.ID)
 	}
 	logrus.Printf("You can download your backup with the \"datica db download %s %s ./output_file_path\" command", databaseName, job.ID)
 	return nil
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/crypto"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/test"
 )
 
@@ -70,7 +73,7 @@ func TestDbBackup(t *testing.T) {
 		queriedJob = false
 
 		// test
-		err := CmdBackup(data.databaseName, data.skipPoll, New(settings, crypto.New(), jobs.New(settings)), services.New(settings), jobs.New(settings))
+		err := CmdBackup(data.databaseName, data.skipPoll, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings), jobs.New(settings))
 
 		// assert
 		if err != nil != data.expectErr {
@@ -84,3 +87,32 @@ func TestDbBackup(t *testing.T) {
 		}
 	}
 }
+
+func TestDbBackupDeferred(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup",
+		func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Expected the backup not to be started outside of the schedule window")
+		},
+	)
+	// a window that opens in an hour
+	now := time.Now().In(config.Timezone())
+	window, err := transfer.ParseWindow(fmt.Sprintf("%s-%s", now.Add(time.Hour).Format("15:04"), now.Add(2*time.Hour).Format("15:04")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer.SetScheduleWindow(window)
+	defer transfer.SetScheduleWindow(nil)
+
+	err = CmdBackup(dbName, false, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings), jobs.New(settings))
+	if _, ok := err.(*transfer.DeferredError); !ok {
+		t.Errorf("Expected the backup to be deferred but got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"io"
+	"os"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// backupRef is the ref a downloaded backup is given in the artifact cache
+func backupRef(jobID string) string {
+	return "backup-" + jobID
+}
+
+// cacheBackup adds the downloaded backup at filePath to the artifact cache.
+// Failing to cache the backup does not fail the download.
+func (d *SDb) cacheBackup(filePath, jobID string) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		logrus.Debugf("Error adding backup %s to the artifact cache: %s", jobID, err)
+		return
+	}
+	defer f.Close()
+	digest, err := d.Cache.Put(f)
+	if err == nil {
+		err = d.Cache.Link(backupRef(jobID), digest)
+	}
+	if err != nil {
+		logrus.Debugf("Error adding backup %s to the artifact cache: %s", jobID, err)
+	}
+}
+
+// copyFile copies the file at src to a new file at dst
+func copyFile(src, dst string) error {
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if err = appendFile(out, src); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// appendFile writes the contents of the file at src to w
+func appendFile(w io.Writer, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	_, err = io.Copy(w, in)
+	return err
+}
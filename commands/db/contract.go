@@ -2,6 +2,7 @@ package db
 
 import (
 	"io"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/cache"
 	"github.com/daticahealth/cli/lib/crypto"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/lib/transfer"
@@ -24,15 +27,17 @@ import (
 var Cmd = models.Command{
 	Name:      "db",
 	ShortHelp: "Tasks for databases",
-	LongHelp:  "The `db` command gives access to backup, import, and export services for databases. The db command can not be run directly but has sub commands.",
+	LongHelp:  "The `db` command gives access to backup, import, export, and point in time restore services for databases. The db command can not be run directly but has sub commands.",
+	Category:  models.CategoryData,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(BackupSubCmd.Name, BackupSubCmd.ShortHelp, BackupSubCmd.LongHelp, BackupSubCmd.CmdFunc(settings))
-			cmd.CommandLong(DownloadSubCmd.Name, DownloadSubCmd.ShortHelp, DownloadSubCmd.LongHelp, DownloadSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ExportSubCmd.Name, ExportSubCmd.ShortHelp, ExportSubCmd.LongHelp, ExportSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ImportSubCmd.Name, ImportSubCmd.ShortHelp, ImportSubCmd.LongHelp, ImportSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(LogsSubCmd.Name, LogsSubCmd.ShortHelp, LogsSubCmd.LongHelp, LogsSubCmd.CmdFunc(settings))
+			cmd.CommandLong(BackupSubCmd.Name, BackupSubCmd.ShortHelp, help.Render(BackupSubCmd.LongHelp), BackupSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DownloadSubCmd.Name, DownloadSubCmd.ShortHelp, help.Render(DownloadSubCmd.LongHelp), DownloadSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ExportSubCmd.Name, ExportSubCmd.ShortHelp, help.Render(ExportSubCmd.LongHelp), ExportSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ImportSubCmd.Name, ImportSubCmd.ShortHelp, help.Render(ImportSubCmd.LongHelp), ImportSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(LogsSubCmd.Name, LogsSubCmd.ShortHelp, help.Render(LogsSubCmd.LongHelp), LogsSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RestoreSubCmd.Name, RestoreSubCmd.ShortHelp, help.Render(RestoreSubCmd.LongHelp), RestoreSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -43,12 +48,71 @@ var BackupSubCmd = models.Command{
 	LongHelp: "`db backup` creates a new backup for the given database service. " +
 		"The backup is started and unless `-s` is specified, the CLI will poll every few seconds until it finishes. " +
 		"Regardless of a successful backup or not, the logs for the backup will be printed to the console when the backup is finished. " +
-		"If an error occurs and the logs are not printed, you can use the [db logs](#db-logs) command to print out historical backup job logs. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" db backup db01\n```",
+		"If an error occurs and the logs are not printed, you can use the [db logs](#db-logs) command to print out historical backup job logs. " +
+		"Use `--limit-rate` to keep the download from saturating a shared network connection. " +
+		"Use `--schedule-window` to only start the backup during off-hours, such as `22:00-06:00`. Outside of the window nothing is started and the command fails with the time the window opens, so it can be run from a cron job until it succeeds. " +
+		"With `-s`, the backup is instead started by a background process once the window opens, which appends its output to `~/.datica_schedule.log`. The window is in the timezone set by `--timezone`. " +
+		"To have backups created automatically, see [db backup schedule](#db-backup-schedule). Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db backup db01\n" +
+		"datica -E \"<your_env_alias>\" db backup db01 -s --schedule-window 22:00-06:00\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
+			subCmd.CommandLong(ScheduleSubCmd.Name, ScheduleSubCmd.ShortHelp, help.Render(ScheduleSubCmd.LongHelp), ScheduleSubCmd.CmdFunc(settings))
 			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database service to create a backup for (i.e. 'db01')")
 			skipPoll := subCmd.BoolOpt("s skip-poll", false, "Whether or not to wait for the backup to finish")
+			scheduleWindow := subCmd.StringOpt("schedule-window", "", "Only start the backup during this time of day, such as 22:00-06:00")
+			subCmd.Action = func() {
+				// the name is optional in the spec only so that the schedule
+				// sub command can be run without one
+				if *databaseName == "" {
+					logrus.Fatal("Please specify the name of the database service to create a backup for")
+				}
+				window, err := transfer.ParseWindow(*scheduleWindow)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetScheduleWindow(window)
+				transfer.SetDetached(*skipPoll)
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdBackup(*databaseName, *skipPoll, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[DATABASE_NAME] [-s] [--schedule-window]"
+		}
+	},
+}
+
+var ScheduleSubCmd = models.Command{
+	Name:      "schedule",
+	ShortHelp: "Set, show, and remove the automated backup schedule of a database",
+	LongHelp: "`db backup schedule` manages how often a database service is backed up automatically and how long those backups are kept. " +
+		"Automated backups are created by Datica, so no one needs to run the CLI for them to be created, and they are listed by [db list](#db-list) along with the current schedule. " +
+		"The schedule command can not be run directly but has sub commands.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ScheduleRmSubCmd.Name, ScheduleRmSubCmd.ShortHelp, help.Render(ScheduleRmSubCmd.LongHelp), ScheduleRmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ScheduleSetSubCmd.Name, ScheduleSetSubCmd.ShortHelp, help.Render(ScheduleSetSubCmd.LongHelp), ScheduleSetSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ScheduleShowSubCmd.Name, ScheduleShowSubCmd.ShortHelp, help.Render(ScheduleShowSubCmd.LongHelp), ScheduleShowSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ScheduleRmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Stop the automated backups of a database",
+	LongHelp: "`db backup schedule rm` stops the automated backups of the given database service. " +
+		"Backups that were already created are kept until their retention ends. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db backup schedule rm db01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database service to stop automated backups of (i.e. 'db01')")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -56,12 +120,76 @@ var BackupSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdBackup(*databaseName, *skipPoll, New(settings, crypto.New(), jobs.New(settings)), services.New(settings), jobs.New(settings))
+				err := CmdScheduleRm(*databaseName, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "DATABASE_NAME [-s]"
+			subCmd.Spec = "DATABASE_NAME"
+		}
+	},
+}
+
+var ScheduleSetSubCmd = models.Command{
+	Name:      "set",
+	ShortHelp: "Set how often a database is backed up and how long backups are kept",
+	LongHelp: "`db backup schedule set` creates or replaces the automated backup schedule of the given database service. " +
+		"The frequency is one of `hourly`, `daily`, or `weekly`, or a standard five field cron expression (minute, hour, day of month, month, day of week) in UTC. " +
+		"Named frequencies run at the start of the hour, at midnight UTC, and at midnight UTC on Sundays. " +
+		"The retention is the number of days automated backups are kept, up to 365. " +
+		"Use `--schedule-window` with a named frequency to have the backups created during off-hours, such as `22:00-06:00`, instead. " +
+		"Daily and weekly backups are created when the window opens, and hourly backups every hour the window is open. The window is in the timezone set by `--timezone`. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db backup schedule set db01 --frequency daily --retention 30\n" +
+		"datica -E \"<your_env_alias>\" db backup schedule set db01 --frequency hourly --schedule-window 22:00-06:00\n" +
+		"datica -E \"<your_env_alias>\" db backup schedule set db01 --frequency \"0 3 * * *\" --retention 14\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database service to schedule backups of (i.e. 'db01')")
+			frequency := subCmd.StringOpt("f frequency", "daily", "How often to create a backup (hourly, daily, weekly, or a cron expression)")
+			retention := subCmd.IntOpt("r retention", 30, "The number of days to keep automated backups")
+			scheduleWindow := subCmd.StringOpt("schedule-window", "", "Create the backups during this time of day, such as 22:00-06:00")
+			subCmd.Action = func() {
+				window, err := transfer.ParseWindow(*scheduleWindow)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdScheduleSet(*databaseName, *frequency, *retention, window, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "DATABASE_NAME [-f] [-r] [--schedule-window]"
+		}
+	},
+}
+
+var ScheduleShowSubCmd = models.Command{
+	Name:      "show",
+	ShortHelp: "Show the automated backup schedule of a database",
+	LongHelp: "`db backup schedule show` prints how often the given database service is backed up automatically, how long those backups are kept, and when the next one is created. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db backup schedule show db01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database service to show the backup schedule of (i.e. 'db01')")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdScheduleShow(*databaseName, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "DATABASE_NAME"
 		}
 	},
 }
@@ -72,7 +200,14 @@ var DownloadSubCmd = models.Command{
 	LongHelp: "`db download` downloads a previously created backup to your local hard drive. " +
 		"Be careful using this command as it could download PHI. " +
 		"Be sure that all hard drive encryption and necessary precautions have been taken before performing a download. " +
-		"The ID of the backup is found by first running the [db list](#db-list) command. Here is a sample command\n\n" +
+		"The ID of the backup is found by first running the [db list](#db-list) command. " +
+		"If the connection drops, the download is retried automatically and picks up where it left off. " +
+		"If the command itself is interrupted, running it again with the same backup ID and file path resumes the download from the partial file saved next to the file path. " +
+		"Use `--limit-rate` to keep the download from saturating a shared network connection, and `--schedule-window` to only start the download during off-hours, such as `22:00-06:00`. Outside of the window nothing is downloaded and the command fails with the time the window opens. " +
+		"With `--detach`, the download is instead started by a background process once the window opens, which appends its output to `~/.datica_schedule.log`. " +
+		"The window is in the timezone set by `--timezone`. " +
+		"Use `--cache` to keep a copy of the backup in the local artifact cache, so downloading the same backup again is a local copy instead of a download. " +
+		"The cache holds decrypted backups, which could contain PHI, so only use it on machines where that is acceptable. See [cache](#cache) to manage it. Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" db download db01 cd2b4bce-2727-42d1-89e0-027bf3f1a203 ./db.sql\n```\n\n" +
 		"This assumes you are downloading a MySQL or PostgreSQL backup which takes the `.sql` file format. If you are downloading a mongo backup, the command might look like this\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" db download db01 cd2b4bce-2727-42d1-89e0-027bf3f1a203 ./db.tar.gz\n```",
@@ -82,6 +217,10 @@ var DownloadSubCmd = models.Command{
 			backupID := subCmd.StringArg("BACKUP_ID", "", "The ID of the backup to download (found from \"datica backup list\")")
 			filePath := subCmd.StringArg("FILEPATH", "", "The location to save the downloaded backup to. This location must NOT already exist unless -f is specified")
 			force := subCmd.BoolOpt("f force", false, "If a file previously exists at \"filepath\", overwrite it and download the backup")
+			useCache := subCmd.BoolOpt("cache", false, "Copy the backup from the local artifact cache if it was downloaded before, and add it to the cache otherwise")
+			limitRate := subCmd.StringOpt("limit-rate", "", "The maximum transfer rate in bytes per second, such as 500K or 5M")
+			scheduleWindow := subCmd.StringOpt("schedule-window", "", "Only start the transfer during this time of day, such as 22:00-06:00")
+			detach := subCmd.BoolOpt("detach", false, "Start the transfer in a background process once the schedule window opens instead of failing while it is closed")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -89,12 +228,23 @@ var DownloadSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdDownload(*databaseName, *backupID, *filePath, *force, New(settings, crypto.New(), jobs.New(settings)), prompts.New(), services.New(settings))
+				rate, err := transfer.ParseRate(*limitRate)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetRateLimit(rate)
+				window, err := transfer.ParseWindow(*scheduleWindow)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetScheduleWindow(window)
+				transfer.SetDetached(*detach)
+				err = CmdDownload(*databaseName, *backupID, *filePath, *force, New(settings, crypto.New(), jobs.New(settings), artifactCache(*useCache)), prompts.New(), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "DATABASE_NAME BACKUP_ID FILEPATH [-f]"
+			subCmd.Spec = "DATABASE_NAME BACKUP_ID FILEPATH [-f] [--cache] [--limit-rate] [--schedule-window [--detach]]"
 		}
 	},
 }
@@ -105,8 +255,17 @@ var ExportSubCmd = models.Command{
 	LongHelp: "`db export` is a simple wrapper around the `db backup` and `db download` commands. " +
 		"When you request an export, a backup is created that will be added to the list of backups shown when you perform the [db list](#db-list) command. " +
 		"Then that backup is immediately downloaded. Regardless of a successful export or not, the logs for the backup will be printed to the console when the export is finished. " +
-		"If an error occurs and the logs are not printed, you can use the [db logs](#db-logs) command to print out historical backup job logs. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" db export db01 ./dbexport.sql\n```\n\n" +
+		"If an error occurs and the logs are not printed, you can use the [db logs](#db-logs) command to print out historical backup job logs. " +
+		"Use `--limit-rate` to keep the download from saturating a shared network connection, and `--schedule-window` to only create and download the backup during off-hours, such as `22:00-06:00`. Outside of the window nothing is started and the command fails with the time the window opens. " +
+		"With `--detach`, the export is instead started by a background process once the window opens, which appends its output to `~/.datica_schedule.log`. " +
+		"The window is in the timezone set by `--timezone`. " +
+		"Use `--incremental` to only download the parts of the backup that changed since the last incremental export to the same file path, which is much faster for large databases that change slowly. " +
+		"The previous export is updated in place and a manifest of its parts is kept next to it with a `.manifest` extension. " +
+		"Use `--cache` to keep a copy of the export, or with `--incremental` of each of its parts, in the local artifact cache so they are not downloaded again, even when exporting to a different file path. " +
+		"The cache holds decrypted data, which could contain PHI, so only use it on machines where that is acceptable. See [cache](#cache) to manage it. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db export db01 ./dbexport.sql\n" +
+		"datica -E \"<your_env_alias>\" db export db01 ./dbexport.sql --incremental\n" +
+		"datica -E \"<your_env_alias>\" db export db01 ./dbexport.sql --limit-rate 5M --schedule-window 22:00-06:00\n```\n\n" +
 		"This assumes you are exporting a MySQL or PostgreSQL database which takes the `.sql` file format. If you are exporting a mongo database, the command might look like this\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" db export db01 ./dbexport.tar.gz\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
@@ -114,6 +273,11 @@ var ExportSubCmd = models.Command{
 			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database to export data from (i.e. 'db01')")
 			filePath := subCmd.StringArg("FILEPATH", "", "The location to save the exported data. This location must NOT already exist unless -f is specified")
 			force := subCmd.BoolOpt("f force", false, "If a file previously exists at `filepath`, overwrite it and export data")
+			incremental := subCmd.BoolOpt("incremental", false, "Only download the parts of the backup that changed since the last incremental export to `filepath`")
+			useCache := subCmd.BoolOpt("cache", false, "Add the export to the local artifact cache, and with --incremental reuse cached parts")
+			limitRate := subCmd.StringOpt("limit-rate", "", "The maximum transfer rate in bytes per second, such as 500K or 5M")
+			scheduleWindow := subCmd.StringOpt("schedule-window", "", "Only start the transfer during this time of day, such as 22:00-06:00")
+			detach := subCmd.BoolOpt("detach", false, "Start the transfer in a background process once the schedule window opens instead of failing while it is closed")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -121,12 +285,23 @@ var ExportSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdExport(*databaseName, *filePath, *force, New(settings, crypto.New(), jobs.New(settings)), prompts.New(), services.New(settings), jobs.New(settings))
+				rate, err := transfer.ParseRate(*limitRate)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetRateLimit(rate)
+				window, err := transfer.ParseWindow(*scheduleWindow)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetScheduleWindow(window)
+				transfer.SetDetached(*detach)
+				err = CmdExport(*databaseName, *filePath, *force, *incremental, New(settings, crypto.New(), jobs.New(settings), artifactCache(*useCache)), prompts.New(), services.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "DATABASE_NAME FILEPATH [-f]"
+			subCmd.Spec = "DATABASE_NAME FILEPATH [-f] [--incremental] [--cache] [--limit-rate] [--schedule-window [--detach]]"
 		}
 	},
 }
@@ -143,8 +318,12 @@ var ImportSubCmd = models.Command{
 		"and stored it at `./db.sql` you could import this into your database service. " +
 		"When importing data into mongo, you may specify the database and collection to import into using the `-d` and `-c` flags respectively. " +
 		"Regardless of a successful import or not, the logs for the import will be printed to the console when the import is finished. " +
-		"Before an import takes place, your database is backed up automatically in case any issues arise. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" db import db01 ./db.sql\n```",
+		"Before an import takes place, your database is backed up automatically in case any issues arise. " +
+		"The file is encrypted in chunks as it is uploaded so it is never held in memory in full, and files larger than 5 GB are uploaded in parts. " +
+		"Use `--detach` to exit as soon as the import job has been created and its job ID printed, you can then follow it with [jobs attach](#jobs-attach). `--follow` keeps the default and waits for the import to finish. " +
+		"Use `--limit-rate` to keep the upload from saturating a shared network connection. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db import db01 ./db.sql\n" +
+		"datica -E \"<your_env_alias>\" db import db01 ./db.sql --limit-rate 500K\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database to import data to (i.e. 'db01')")
@@ -152,6 +331,9 @@ var ImportSubCmd = models.Command{
 			mongoCollection := subCmd.StringOpt("c mongo-collection", "", "If importing into a mongo service, the name of the collection to import into")
 			mongoDatabase := subCmd.StringOpt("d mongo-database", "", "If importing into a mongo service, the name of the database to import into")
 			skipBackup := subCmd.BoolOpt("s skip-backup", false, "Skip backing up database. Useful for large databases, which can have long backup times.")
+			detach := subCmd.BoolOpt("detach", false, "Exit once the import job has been created instead of waiting for it to finish")
+			subCmd.BoolOpt("follow", false, "Wait for the import to finish before exiting. This is the default")
+			limitRate := subCmd.StringOpt("limit-rate", "", "The maximum transfer rate in bytes per second, such as 500K or 5M")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -159,12 +341,17 @@ var ImportSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdImport(*databaseName, *filePath, *mongoCollection, *mongoDatabase, *skipBackup, New(settings, crypto.New(), jobs.New(settings)), prompts.New(), services.New(settings), jobs.New(settings))
+				rate, err := transfer.ParseRate(*limitRate)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetRateLimit(rate)
+				err = CmdImport(*databaseName, *filePath, *mongoCollection, *mongoDatabase, *skipBackup, *detach, New(settings, crypto.New(), jobs.New(settings), nil), prompts.New(), services.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "DATABASE_NAME FILEPATH [-s][-d [-c]]"
+			subCmd.Spec = "DATABASE_NAME FILEPATH [-s][-d [-c]] [--detach | --follow] [--limit-rate]"
 		}
 	},
 }
@@ -172,14 +359,20 @@ var ImportSubCmd = models.Command{
 var ListSubCmd = models.Command{
 	Name:      "list",
 	ShortHelp: "List created backups",
-	LongHelp: "`db list` lists all previously created backups. " +
-		"After listing backups you can copy the backup ID and use it to [download](#db-download) that backup or [view the logs](#db-logs) from that backup. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" db list db01\n```",
+	LongHelp: "`db list` lists all previously created backups along with their size and whether they were created manually or by the [backup schedule](#db-backup-schedule). " +
+		"The automated backup schedule of the database, if the pod supports one, is printed above the backups. " +
+		"Backups are sorted by `--sort`, which is one of `newest` (the default), `oldest`, or `size` to find the largest backups first. " +
+		"Only the first `--limit` backups are shown, use `--page` to see the ones after them or `--limit 0` to show every backup. " +
+		"After listing backups you can copy the backup ID and use it to [download](#db-download) that backup or [view the logs](#db-logs) from that backup. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db list db01\n" +
+		"datica -E \"<your_env_alias>\" db list db01 --sort size --limit 5\n" +
+		"datica -E \"<your_env_alias>\" db list db01 --sort oldest --page 2\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database service to list backups for (i.e. 'db01')")
-			page := subCmd.IntOpt("p page", 1, "The page to view")
-			pageSize := subCmd.IntOpt("n page-size", 10, "The number of items to show per page")
+			sortBy := subCmd.StringOpt("sort", "newest", "The order to list backups in (newest, oldest, or size)")
+			page := subCmd.IntOpt("p page", 1, "The page of backups to view")
+			limit := subCmd.IntOpt("n limit page-size", 10, "The number of backups to show per page, or 0 to show all of them")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -187,26 +380,64 @@ var ListSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdList(*databaseName, *page, *pageSize, New(settings, crypto.New(), jobs.New(settings)), services.New(settings))
+				err := CmdList(*databaseName, *sortBy, *page, *limit, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "DATABASE_NAME [-p] [-n]"
+			subCmd.Spec = "DATABASE_NAME [--sort] [-p] [-n]"
 		}
 	},
 }
 
 var LogsSubCmd = models.Command{
 	Name:      "logs",
-	ShortHelp: "Print out the logs from a previous database backup job",
-	LongHelp: "`db logs` allows you to view backup logs from historical backup jobs. " +
-		"You can find the backup ID from using the `db list` command. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" db logs db01 cd2b4bce-2727-42d1-89e0-027bf3f1a203\n```",
+	ShortHelp: "Print out the logs from a previous database backup or import job",
+	LongHelp: "`db logs` downloads, decrypts, and prints the logs of a historical backup or import job, such as to find out why an import failed. " +
+		"You can find the backup ID from using the `db list` command, and the ID of an import job is printed when the import is started. " +
+		"The database name can be left out, in which case every database service of the environment is searched for the job. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db logs db01 cd2b4bce-2727-42d1-89e0-027bf3f1a203\n" +
+		"datica -E \"<your_env_alias>\" db logs cd2b4bce-2727-42d1-89e0-027bf3f1a203\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the database service (i.e. 'db01')")
-			backupID := subCmd.StringArg("BACKUP_ID", "", "The ID of the backup to download logs from (found from \"datica backup list\")")
+			jobID := subCmd.StringArg("JOB_ID", "", "The ID of the backup or import job to download logs from (backup IDs are found from \"datica db list\")")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdLogs(*databaseName, *jobID, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[DATABASE_NAME] JOB_ID"
+		}
+	},
+}
+
+var RestoreSubCmd = models.Command{
+	Name:      "restore",
+	ShortHelp: "Restore a PostgreSQL database to a point in time",
+	LongHelp: "`db restore` restores a PostgreSQL database service to the data it had at the moment given by `--at`. " +
+		"The timestamp is in RFC3339 format, such as `2026-10-17T14:30:00Z`, or a local date and time such as `2026-10-17 14:30:00` in the timezone set by `--timezone`, and is rounded down to the second. " +
+		"It must fall within the restore window of the service, which is printed if it does not. " +
+		"By default the service is restored in place and every change made after the restore point is lost. Use `--target` to restore into another PostgreSQL service instead and leave the original untouched. " +
+		"Before the restore starts, the exact restore point and the target service are printed and you are asked to confirm. " +
+		"Unless `--detach` is specified, the CLI will poll every few seconds until the restore finishes. `-s` is kept as an alias of `--detach`, and `--follow` keeps the default. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" db restore db01 --at 2026-10-17T14:30:00Z\n" +
+		"datica -E \"<your_env_alias>\" db restore db01 --at \"2026-10-17 09:30:00\" --target db02 --detach\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			databaseName := subCmd.StringArg("DATABASE_NAME", "", "The name of the PostgreSQL database service to restore (i.e. 'db01')")
+			at := subCmd.StringOpt("at", "", "The moment to restore the data of the database to")
+			target := subCmd.StringOpt("target", "", "The name of another PostgreSQL database service to restore into instead of the database itself")
+			skipPoll := subCmd.BoolOpt("s skip-poll", false, "Whether or not to wait for the restore to finish")
+			detach := subCmd.BoolOpt("detach", false, "Exit once the restore job has been created instead of waiting for it to finish")
+			subCmd.BoolOpt("follow", false, "Wait for the restore to finish before exiting. This is the default")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -214,12 +445,12 @@ var LogsSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdLogs(*databaseName, *backupID, New(settings, crypto.New(), jobs.New(settings)), services.New(settings), jobs.New(settings))
+				err := CmdRestore(*databaseName, *at, *target, *skipPoll || *detach, New(settings, crypto.New(), jobs.New(settings), nil), prompts.New(), services.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "DATABASE_NAME BACKUP_ID"
+			subCmd.Spec = "DATABASE_NAME --at [--target] [-s | --detach | --follow]"
 		}
 	},
 }
@@ -229,8 +460,15 @@ type IDb interface {
 	Backup(service *models.Service) (*models.Job, error)
 	Download(backupID, filePath string, service *models.Service) error
 	Export(filePath string, job *models.Job, service *models.Service) error
+	IncrementalExport(filePath string, job *models.Job, service *models.Service) error
+	BackupManifest(jobID string, service *models.Service) (*models.BackupManifest, error)
 	Import(rt *transfer.ReaderTransfer, key, iv []byte, mongoCollection, mongoDatabase string, service *models.Service) (*models.Job, error)
 	List(page, pageSize int, service *models.Service) (*[]models.Job, error)
+	SetSchedule(schedule *models.BackupSchedule, service *models.Service) (*models.BackupSchedule, error)
+	RetrieveSchedule(service *models.Service) (*models.BackupSchedule, error)
+	RemoveSchedule(service *models.Service) error
+	RestoreWindow(service *models.Service) (*models.RestoreWindow, error)
+	PointInTimeRestore(restorePoint time.Time, target, service *models.Service) (*models.Job, error)
 	TempDownloadURL(jobID string, service *models.Service) (*models.TempURL, error)
 	TempLogsURL(jobID string, serviceID string) (*models.TempURL, error)
 	DumpLogs(taskType string, job *models.Job, service *models.Service) error
@@ -242,17 +480,27 @@ type SDb struct {
 	Settings *models.Settings
 	Crypto   crypto.ICrypto
 	Jobs     jobs.IJobs
+	Cache    cache.ICache // nil when downloads should not use the artifact cache
 }
 
-// New returns an instance of IDb
-func New(settings *models.Settings, crypto crypto.ICrypto, jobs jobs.IJobs) IDb {
+// New returns an instance of IDb. The cache may be nil.
+func New(settings *models.Settings, crypto crypto.ICrypto, jobs jobs.IJobs, cache cache.ICache) IDb {
 	return &SDb{
 		Settings: settings,
 		Crypto:   crypto,
 		Jobs:     jobs,
+		Cache:    cache,
 	}
 }
 
 func (db *SDb) NewEncryptReader(reader io.Reader, key, iv []byte) (*gcm.EncryptReader, error) {
 	return db.Crypto.NewEncryptReader(reader, key, iv)
 }
+
+// artifactCache returns the local artifact cache if it should be used
+func artifactCache(use bool) cache.ICache {
+	if !use {
+		return nil
+	}
+	return cache.New()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 )
 
@@ -16,6 +17,9 @@ func CmdDownload(databaseName, backupID, filePath string, force bool, id IDb, ip
 	if err != nil {
 		return err
 	}
+	if deferred, err := transfer.Deferred("download"); deferred || err != nil {
+		return err
+	}
 	if !force {
 		if _, err := os.Stat(filePath); err == nil {
 			return fmt.Errorf("File already exists at path '%s'. Specify `--force` to overwrite", filePath)
@@ -1,14 +1,17 @@
 package db
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/cache"
 	"github.com/daticahealth/cli/lib/crypto"
 	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/test"
@@ -16,6 +19,8 @@ import (
 
 var downloadFilePath = "db-download.sql"
 
+var encryptedBackup = []byte{186, 194, 51, 73, 71, 71, 38, 3, 182, 216, 210, 144, 156, 237, 120, 227, 95, 91, 197, 59, 19} // gcm encrypted "test"
+
 var dbDownloadTests = []struct {
 	databaseName string
 	backupID     string
@@ -56,7 +61,7 @@ func TestDbDownload(t *testing.T) {
 	mux.HandleFunc("/backup",
 		func(w http.ResponseWriter, r *http.Request) {
 			test.AssertEquals(t, r.Method, "GET")
-			w.Write([]byte{186, 194, 51, 73, 71, 71, 38, 3, 182, 216, 210, 144, 156, 237, 120, 227, 95, 91, 197, 59, 19}) // gcm encrypted "test"
+			http.ServeContent(w, r, "backup", time.Time{}, bytes.NewReader(encryptedBackup))
 		},
 	)
 
@@ -64,7 +69,7 @@ func TestDbDownload(t *testing.T) {
 		t.Logf("Data: %+v", data)
 
 		// test
-		err := CmdDownload(data.databaseName, data.backupID, data.filePath, data.force, New(settings, crypto.New(), jobs.New(settings)), &test.FakePrompts{}, services.New(settings))
+		err := CmdDownload(data.databaseName, data.backupID, data.filePath, data.force, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings))
 
 		// assert
 		if err != nil {
@@ -81,3 +86,106 @@ func TestDbDownload(t *testing.T) {
 	}
 	os.Remove(downloadFilePath)
 }
+
+func TestDbDownloadResume(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","isSnapshotBackup":false,"type":"backup","status":"finished","backup":{"key":"0000000000000000000000000000000000000000000000000000000000000000","iv":"000000000000000000000000"}}`, dbJobID))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-url/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`{"url":"%s/backup"}`, baseURL.String()))
+		},
+	)
+	mux.HandleFunc("/backup",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, "bytes=10-", r.Header.Get("Range"))
+			test.AssertEquals(t, `"backup-etag"`, r.Header.Get("If-Range"))
+			w.Header().Set("ETag", `"backup-etag"`)
+			http.ServeContent(w, r, "backup", time.Time{}, bytes.NewReader(encryptedBackup))
+		},
+	)
+
+	// a previous download was interrupted after the first 10 bytes
+	partPath := fmt.Sprintf("%s.%s.part", downloadFilePath, dbJobID)
+	ioutil.WriteFile(partPath, encryptedBackup[:10], 0600)
+	ioutil.WriteFile(partPath+".etag", []byte(`"backup-etag"`), 0600)
+	defer os.Remove(partPath)
+	defer os.Remove(partPath + ".etag")
+	defer os.Remove(downloadFilePath)
+
+	err := CmdDownload(dbName, dbJobID, downloadFilePath, true, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	b, _ := ioutil.ReadFile(downloadFilePath)
+	if strings.TrimSpace(string(b)) != "test" {
+		t.Errorf("Unexpected file contents. Expected: test, actual: %s", string(b))
+	}
+	if _, err := os.Stat(partPath); !os.IsNotExist(err) {
+		t.Errorf("Expected partial file %s to be removed", partPath)
+	}
+	if _, err := os.Stat(partPath + ".etag"); !os.IsNotExist(err) {
+		t.Errorf("Expected the ETag of partial file %s to be removed", partPath)
+	}
+}
+
+func TestDbDownloadCache(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","isSnapshotBackup":false,"type":"backup","status":"finished","backup":{"key":"0000000000000000000000000000000000000000000000000000000000000000","iv":"000000000000000000000000"}}`, dbJobID))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-url/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, fmt.Sprintf(`{"url":"%s/backup"}`, baseURL.String()))
+		},
+	)
+	downloads := 0
+	mux.HandleFunc("/backup",
+		func(w http.ResponseWriter, r *http.Request) {
+			downloads++
+			http.ServeContent(w, r, "backup", time.Time{}, bytes.NewReader(encryptedBackup))
+		},
+	)
+
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Remove(downloadFilePath)
+
+	for i := 0; i < 2; i++ {
+		err := CmdDownload(dbName, dbJobID, downloadFilePath, true, New(settings, crypto.New(), jobs.New(settings), &cache.SCache{Dir: dir}), &test.FakePrompts{}, services.New(settings))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		b, _ := ioutil.ReadFile(downloadFilePath)
+		if strings.TrimSpace(string(b)) != "test" {
+			t.Errorf("Unexpected file contents. Expected: test, actual: %s", string(b))
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("Expected the backup to be downloaded once, downloaded %d times", downloads)
+	}
+}
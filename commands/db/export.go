@@ -3,31 +3,34 @@ package db
 import (
 	"fmt"
 	"io"
-	"net/http"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 )
 
-func CmdExport(databaseName, filePath string, force bool, id IDb, ip prompts.IPrompts, is services.IServices, ij jobs.IJobs) error {
+func CmdExport(databaseName, filePath string, force, incremental bool, id IDb, ip prompts.IPrompts, is services.IServices, ij jobs.IJobs) error {
 	err := ip.PHI()
 	if err != nil {
 		return err
 	}
-	if !force {
+	if deferred, err := transfer.Deferred("export"); deferred || err != nil {
+		return err
+	}
+	if force {
+		os.Remove(filePath)
+		os.Remove(manifestPath(filePath))
+	} else if !incremental {
 		if _, err := os.Stat(filePath); err == nil {
 			return fmt.Errorf("File already exists at path '%s'. Specify `--force` to overwrite", filePath)
 		}
-	} else {
-		os.Remove(filePath)
 	}
 	service, err := is.RetrieveByLabel(databaseName)
 	if err != nil {
@@ -61,7 +64,11 @@ func CmdExport(databaseName, filePath string, force bool, id IDb, ip prompts.IPr
 		return fmt.Errorf("Job finished with invalid status %s", job.Status)
 	}
 
-	err = id.Export(filePath, job, service)
+	if incremental {
+		err = id.IncrementalExport(filePath, job, service)
+	} else {
+		err = id.Export(filePath, job, service)
+	}
 	if err != nil {
 		return err
 	}
@@ -76,42 +83,69 @@ func CmdExport(databaseName, filePath string, force bool, id IDb, ip prompts.IPr
 // Export dumps all data from a database service and downloads the encrypted
 // data to the local machine. The export is accomplished by first creating a
 // backup. Once finished, the CLI asks where the file can be downloaded from.
-// The file is downloaded, decrypted, and saved locally.
+// The encrypted file is downloaded next to the given file path with HTTP range
+// requests so that dropped connections, or a later run of the same command,
+// resume the download rather than starting over. Once the download is
+// complete, the file is decrypted and saved locally. If the artifact cache is
+// used and already holds the backup, it is copied from the cache instead.
 func (d *SDb) Export(filePath string, job *models.Job, service *models.Service) error {
-	tempURL, err := d.TempDownloadURL(job.ID, service)
-	if err != nil {
-		return err
+	if d.Cache != nil {
+		if digest, ok := d.Cache.Resolve(backupRef(job.ID)); ok {
+			path, _ := d.Cache.Get(digest)
+			logrus.Printf("Copying backup %s from the local artifact cache", job.ID)
+			return copyFile(path, filePath)
+		}
 	}
-	resp, err := http.Get(tempURL.URL)
-	if err != nil {
-		return err
+	partPath := fmt.Sprintf("%s.%s.part", filePath, job.ID)
+	if info, err := os.Stat(partPath); err == nil {
+		logrus.Printf("Resuming previous download of %s from %s", transfer.ByteSize(info.Size()), partPath)
+	}
+	rd := transfer.NewResumableDownload(partPath)
+	var done chan bool
+	var err error
+	for attempt := 1; attempt <= config.TransferRetries; attempt++ {
+		if attempt > 1 {
+			logrus.Printf("\nDownload interrupted: %s. Retrying (attempt %d of %d)", err, attempt, config.TransferRetries)
+			time.Sleep(config.TransferRetryTime * time.Second)
+		}
+		var tempURL *models.TempURL
+		tempURL, err = d.TempDownloadURL(job.ID, service)
+		if err != nil {
+			continue
+		}
+		var body io.ReadCloser
+		body, err = rd.Open(tempURL.URL)
+		if err != nil {
+			continue
+		}
+		if done == nil {
+			done = make(chan bool)
+			go printTransferStatus(true, rd, done)
+		}
+		err = rd.Append(body)
+		if err == nil {
+			break
+		}
 	}
-	defer resp.Body.Close()
-	size, err := strconv.Atoi(resp.Header.Get("Content-Length"))
-	if err != nil {
-		return err
+	if done != nil {
+		done <- err == nil
 	}
-	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
 	if err != nil {
-		return err
+		return fmt.Errorf("%s. Run this command again to resume the download", err)
 	}
-	dfw, err := d.Crypto.NewDecryptWriteCloser(file, job.Backup.Key, job.Backup.IV)
+
+	logrus.Println("Decrypting...")
+	err = d.Crypto.DecryptFile(partPath, job.Backup.Key, job.Backup.IV, filePath)
 	if err != nil {
 		return err
 	}
-
-	wct := transfer.NewWriteCloserTransfer(dfw, size)
-	done := make(chan bool)
-	go printTransferStatus(true, wct, done)
-
-	_, err = io.Copy(wct, resp.Body)
-	if err != nil {
-		done <- false
-		dfw.Close()
+	if err = rd.Remove(); err != nil {
 		return err
 	}
-	done <- true
-	return dfw.Close()
+	if d.Cache != nil {
+		d.cacheBackup(filePath, job.ID)
+	}
+	return nil
 }
 
 func printTransferStatus(isDownload bool, tr transfer.Transfer, done <-chan bool) {
@@ -119,7 +153,7 @@ func printTransferStatus(isDownload bool, tr transfer.Transfer, done <-chan bool
 	final := "Download"
 	status := "Finished"
 	if isDownload {
-		logrus.Println("Decrypting and Downloading...")
+		logrus.Println("Downloading...")
 	} else {
 		logrus.Println("Encrypting and Uploading...")
 		action = "uploaded"
@@ -128,6 +162,8 @@ func printTransferStatus(isDownload bool, tr transfer.Transfer, done <-chan bool
 	lastLen := 0
 	success := true
 	isDone := false
+	start := time.Now()
+	startTransferred := tr.Transferred()
 loop:
 	for i, l := tr.Transferred(), tr.Length(); i < l; i = tr.Transferred() {
 		select {
@@ -136,7 +172,7 @@ loop:
 			break loop
 		case <-time.After(time.Millisecond * 100):
 			percent := uint64(i / l * 100)
-			s := fmt.Sprintf("\r\033[m\t%s of %s (%d%%) %s", i, l, percent, action)
+			s := fmt.Sprintf("\r\033[m\t%s of %s (%d%%) %s%s", i, l, percent, action, eta(i-startTransferred, l-i, time.Since(start)))
 			fmt.Print(s)
 			sLen := len(s)
 			// this clears any dangling characters at the end with empty space
@@ -166,3 +202,15 @@ loop:
 	}
 	logrus.Printf("\n%s %s!\n", final, status)
 }
+
+// eta estimates the time remaining for a transfer based on the average rate
+// since it started. An empty string is returned until there is enough data
+// to make an estimate.
+func eta(transferred, remaining transfer.ByteSize, elapsed time.Duration) string {
+	if transferred <= 0 || elapsed < time.Second {
+		return ""
+	}
+	rate := float64(transferred) / elapsed.Seconds()
+	left := time.Duration(float64(remaining)/rate) * time.Second
+	return fmt.Sprintf(", %s/s, ETA %s", transfer.ByteSize(rate), config.FormatDuration(left))
+}
@@ -80,7 +80,7 @@ func TestDbExport(t *testing.T) {
 		t.Logf("Data: %+v", data)
 
 		// test
-		err := CmdExport(data.databaseName, data.filePath, data.force, New(settings, crypto.New(), jobs.New(settings)), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
+		err := CmdExport(data.databaseName, data.filePath, data.force, false, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
 
 		// assert
 		if err != nil {
@@ -97,3 +97,77 @@ func TestDbExport(t *testing.T) {
 	}
 	os.Remove(exportFilePath)
 }
+
+func TestDbExportIncremental(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","isSnapshotBackup":false,"type":"backup","status":"running","backup":{"key":"0000000000000000000000000000000000000000000000000000000000000000","keyLogs":"0000000000000000000000000000000000000000000000000000000000000000","iv":"000000000000000000000000"}}`, dbJobID))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","isSnapshotBackup":false,"type":"backup","status":"finished","backup":{"key":"0000000000000000000000000000000000000000000000000000000000000000","keyLogs":"0000000000000000000000000000000000000000000000000000000000000000","iv":"000000000000000000000000"}}`, dbJobID))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-manifest/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			segment := fmt.Sprintf(`{"hash":"f2ca1bb6c7e907d06dafe4687e579fce76b37e4e93b7605022da52e6ccc26fd2","size":5,"iv":"000000000000000000000000","url":"%s/segment"}`, baseURL.String())
+			fmt.Fprint(w, fmt.Sprintf(`{"jobId":"%s","segments":[%s,%s]}`, dbJobID, segment, segment))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-restore-logs-url/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"url":"%s/logs"}`, baseURL.String()))
+		},
+	)
+	mux.HandleFunc("/logs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			w.Write([]byte{186, 194, 51, 73, 71, 71, 38, 3, 182, 216, 210, 144, 156, 237, 120, 227, 95, 91, 197, 59, 19}) // gcm encrypted "test"
+		},
+	)
+	downloads := 0
+	mux.HandleFunc("/segment",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			downloads++
+			w.Write([]byte{186, 194, 51, 73, 71, 71, 38, 3, 182, 216, 210, 144, 156, 237, 120, 227, 95, 91, 197, 59, 19}) // gcm encrypted "test"
+		},
+	)
+	defer os.Remove(exportFilePath)
+	defer os.Remove(manifestPath(exportFilePath))
+
+	for run, expectedDownloads := range []int{2, 2, 4} {
+		if run == 2 {
+			// the previous export was modified without changing its size
+			if err := ioutil.WriteFile(exportFilePath, []byte("tesT\ntest\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		err := CmdExport(dbName, exportFilePath, false, true, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
+		if err != nil {
+			t.Fatalf("Unexpected error on run %d: %s", run+1, err)
+		}
+		if downloads != expectedDownloads {
+			t.Errorf("Unexpected number of segment downloads on run %d. Expected: %d, actual: %d", run+1, expectedDownloads, downloads)
+		}
+		b, _ := ioutil.ReadFile(exportFilePath)
+		if string(b) != "test\ntest\n" {
+			t.Errorf("Unexpected file contents. Expected: test\\ntest\\n, actual: %s", string(b))
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -19,7 +20,16 @@ import (
 	"github.com/daticahealth/cli/models"
 )
 
-func CmdImport(databaseName, filePath, mongoCollection, mongoDatabase string, skipBackup bool, id IDb, ip prompts.IPrompts, is services.IServices, ij jobs.IJobs) error {
+var (
+	// singleUploadLimit is the largest file that is uploaded in one request
+	singleUploadLimit = transfer.GB * 5
+	// uploadPartSize is the size of each part of a multipart upload
+	uploadPartSize = transfer.GB
+	// maxUploadParts is the most parts a multipart upload may be split into
+	maxUploadParts = transfer.ByteSize(10000)
+)
+
+func CmdImport(databaseName, filePath, mongoCollection, mongoDatabase string, skipBackup, detach bool, id IDb, ip prompts.IPrompts, is services.IServices, ij jobs.IJobs) error {
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return fmt.Errorf("A file does not exist at path '%s'", filePath)
 	}
@@ -48,9 +58,8 @@ func CmdImport(databaseName, filePath, mongoCollection, mongoDatabase string, sk
 		return err
 	}
 	uploadSize := encryptFileReader.CalculateTotalSize(int(fi.Size()))
-	fiveGB := transfer.GB * 5
-	if transfer.ByteSize(uploadSize) > fiveGB {
-		return fmt.Errorf("the encrypted size of %s exceeds the maximum upload size of %s", filePath, fiveGB)
+	if transfer.ByteSize(uploadSize) > uploadPartSize*maxUploadParts {
+		return fmt.Errorf("the encrypted size of %s exceeds the maximum upload size of %s", filePath, uploadPartSize*maxUploadParts)
 	}
 	rt := transfer.NewReaderTransfer(encryptFileReader, uploadSize)
 	if !skipBackup {
@@ -94,6 +103,11 @@ func CmdImport(databaseName, filePath, mongoCollection, mongoDatabase string, sk
 	if err != nil {
 		return err
 	}
+	if detach {
+		logrus.Printf("Import started (job ID = %s)", job.ID)
+		logrus.Printf("You can wait for the import to finish with the \"datica jobs attach %s %s\" command and view its logs with the \"datica db logs %s %s\" command", databaseName, job.ID, databaseName, job.ID)
+		return nil
+	}
 	// all because logrus treats print, println, and printf the same
 	logrus.StandardLogger().Out.Write([]byte(fmt.Sprintf("Processing import (job ID = %s).", job.ID)))
 
@@ -130,37 +144,24 @@ func (d *SDb) Import(rt *transfer.ReaderTransfer, key, iv []byte, mongoCollectio
 	if mongoDatabase != "" {
 		options["database"] = mongoDatabase
 	}
-	tmpURL, err := d.TempUploadURL(service)
-	if err != nil {
-		return nil, err
-	}
-	u, err := url.Parse(tmpURL.URL)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest("PUT", tmpURL.URL, rt)
-	req.Header.Set("x-amz-server-side-encryption", "AES256")
-	req.ContentLength = int64(rt.Length())
+	var filename string
+	var err error
 	done := make(chan bool)
 	go printTransferStatus(false, rt, done)
-	uploadResp, err := http.DefaultClient.Do(req)
+	if rt.Length() > singleUploadLimit {
+		filename, err = d.uploadMultipart(rt, service)
+	} else {
+		filename, err = d.upload(rt, service)
+	}
+	done <- err == nil
 	if err != nil {
-		done <- false
 		return nil, err
 	}
-	defer uploadResp.Body.Close()
-	if uploadResp.StatusCode != 200 {
-		done <- false
-		b, err := ioutil.ReadAll(uploadResp.Body)
-		logrus.Debugf("Error uploading import file: %d %s %s", uploadResp.StatusCode, string(b), err)
-		return nil, fmt.Errorf("Failed to upload import file - received status code %d", uploadResp.StatusCode)
-	}
-	done <- true
 	importParams := map[string]interface{}{}
 	for key, value := range options {
 		importParams[key] = value
 	}
-	importParams["filename"] = strings.TrimLeft(u.Path, "/")
+	importParams["filename"] = filename
 	importParams["encryptionKey"] = string(d.Crypto.Hex(key, crypto.KeySize*2))
 	importParams["encryptionIV"] = string(d.Crypto.Hex(iv, crypto.IVSize*2))
 	importParams["dropDatabase"] = false
@@ -182,6 +183,118 @@ func (d *SDb) Import(rt *transfer.ReaderTransfer, key, iv []byte, mongoCollectio
 	return &job, nil
 }
 
+// upload streams the encrypted import file to a temporary location in a
+// single request and returns the name of the uploaded file.
+func (d *SDb) upload(rt *transfer.ReaderTransfer, service *models.Service) (string, error) {
+	tmpURL, err := d.TempUploadURL(service)
+	if err != nil {
+		return "", err
+	}
+	err = uploadPart(tmpURL.URL, rt, int64(rt.Length()), nil)
+	if err != nil {
+		return "", err
+	}
+	return filenameFromURL(tmpURL.URL)
+}
+
+// uploadMultipart streams the encrypted import file to a temporary location
+// in parts of uploadPartSize so that files larger than a single request
+// allows can be imported. Each part is encrypted as it is read from disk, so
+// no more than a single encryption chunk is held in memory at a time.
+func (d *SDb) uploadMultipart(rt *transfer.ReaderTransfer, service *models.Service) (string, error) {
+	remaining := int64(rt.Length())
+	parts := int((remaining + int64(uploadPartSize) - 1) / int64(uploadPartSize))
+	mp, err := d.TempMultipartUploadURLs(parts, service)
+	if err != nil {
+		return "", err
+	}
+	if len(mp.URLs) != parts {
+		return "", fmt.Errorf("Expected %d upload locations but received %d", parts, len(mp.URLs))
+	}
+	uploaded := []models.UploadPart{}
+	for i, partURL := range mp.URLs {
+		size := int64(uploadPartSize)
+		if remaining < size {
+			size = remaining
+		}
+		part := models.UploadPart{PartNumber: i + 1}
+		err = uploadPart(partURL, io.LimitReader(rt, size), size, &part)
+		if err != nil {
+			return "", err
+		}
+		uploaded = append(uploaded, part)
+		remaining -= size
+	}
+	err = d.CompleteMultipartUpload(mp.UploadID, uploaded, service)
+	if err != nil {
+		return "", err
+	}
+	return filenameFromURL(mp.URLs[0])
+}
+
+// uploadPart PUTs size bytes from reader to a temporary upload URL. If part
+// is not nil, its ETag is populated from the response.
+func uploadPart(uploadURL string, reader io.Reader, size int64, part *models.UploadPart) error {
+	req, err := http.NewRequest("PUT", uploadURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-amz-server-side-encryption", "AES256")
+	req.ContentLength = size
+	uploadResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer uploadResp.Body.Close()
+	if uploadResp.StatusCode != 200 {
+		b, err := ioutil.ReadAll(uploadResp.Body)
+		logrus.Debugf("Error uploading import file: %d %s %s", uploadResp.StatusCode, string(b), err)
+		return fmt.Errorf("Failed to upload import file - received status code %d", uploadResp.StatusCode)
+	}
+	if part != nil {
+		part.ETag = uploadResp.Header.Get("ETag")
+	}
+	return nil
+}
+
+func filenameFromURL(uploadURL string) (string, error) {
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimLeft(u.Path, "/"), nil
+}
+
+// TempMultipartUploadURLs starts a multipart upload and returns a temporary
+// URL for each part.
+func (d *SDb) TempMultipartUploadURLs(parts int, service *models.Service) (*models.MultipartUpload, error) {
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/restore-url/multipart?parts=%d", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID, parts), headers)
+	if err != nil {
+		return nil, err
+	}
+	var mp models.MultipartUpload
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &mp)
+	if err != nil {
+		return nil, err
+	}
+	return &mp, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into a single file
+func (d *SDb) CompleteMultipartUpload(uploadID string, parts []models.UploadPart, service *models.Service) error {
+	b, err := json.Marshal(map[string]interface{}{"parts": parts})
+	if err != nil {
+		return err
+	}
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/services/%s/restore-url/multipart/%s", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID, uploadID), headers)
+	if err != nil {
+		return err
+	}
+	return d.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
+
 func (d *SDb) TempUploadURL(service *models.Service) (*models.TempURL, error) {
 	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
 	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/restore-url", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID), headers)
@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/daticahealth/cli/commands/services"
@@ -108,7 +110,7 @@ func TestDbImport(t *testing.T) {
 		backedUp = false
 
 		// test
-		err := CmdImport(data.databaseName, data.filePath, data.collection, data.database, data.skipBackup, New(settings, crypto.New(), jobs.New(settings)), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
+		err := CmdImport(data.databaseName, data.filePath, data.collection, data.database, data.skipBackup, false, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
 
 		// assert
 		if err != nil {
@@ -160,7 +162,7 @@ func TestDbImportFailedUpload(t *testing.T) {
 	)
 
 	// test
-	err := CmdImport(dbName, importFilePath, "", "", true, New(settings, crypto.New(), jobs.New(settings)), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
+	err := CmdImport(dbName, importFilePath, "", "", true, false, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
 
 	// assert
 	if err == nil {
@@ -170,3 +172,75 @@ func TestDbImportFailedUpload(t *testing.T) {
 
 	os.Remove(importFilePath)
 }
+
+func TestDbImportMultipart(t *testing.T) {
+	ioutil.WriteFile(importFilePath, []byte("select 1; select 2; select 3;"), 0644)
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	origLimit, origPartSize := singleUploadLimit, uploadPartSize
+	singleUploadLimit, uploadPartSize = 0, 16
+	defer func() {
+		singleUploadLimit, uploadPartSize = origLimit, origPartSize
+	}()
+
+	received := 0
+	completed := ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/restore-url/multipart",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			parts, _ := strconv.Atoi(r.URL.Query().Get("parts"))
+			urls := []string{}
+			for i := 0; i < parts; i++ {
+				urls = append(urls, fmt.Sprintf(`"%s/restore?part=%d"`, baseURL.String(), i+1))
+			}
+			fmt.Fprint(w, fmt.Sprintf(`{"uploadId":"u1","urls":[%s]}`, strings.Join(urls, ",")))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/restore-url/multipart/u1",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			completed = string(b)
+			w.WriteHeader(204)
+		},
+	)
+	mux.HandleFunc("/restore",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			b, _ := ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			if int64(len(b)) > int64(uploadPartSize) {
+				t.Errorf("Part %s was %d bytes, expected at most %s", r.URL.Query().Get("part"), len(b), uploadPartSize)
+			}
+			received += len(b)
+			w.Header().Set("ETag", "e"+r.URL.Query().Get("part"))
+			w.WriteHeader(200)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/import",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"restore","status":"running"}`, dbImportID))
+		},
+	)
+
+	err := CmdImport(dbName, importFilePath, "", "", true, true, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if received == 0 {
+		t.Errorf("No parts were uploaded")
+	}
+	if !strings.Contains(completed, `"etag":"e1"`) {
+		t.Errorf("Multipart upload was not completed with the uploaded parts: %s", completed)
+	}
+	os.Remove(importFilePath)
+}
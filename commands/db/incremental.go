@@ -0,0 +1,245 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/daticahealth/cli/models"
+)
+
+// IncrementalExport downloads a backup one segment at a time using the
+// manifest of the backup. Segments whose hash matches a segment of the
+// previous incremental export to the same file path are copied from that file
+// instead of being downloaded, unless the copy no longer matches the hash. The
+// manifest is saved next to the file path so that the next incremental export
+// can do the same.
+func (d *SDb) IncrementalExport(filePath string, job *models.Job, service *models.Service) error {
+	manifest, err := d.BackupManifest(job.ID, service)
+	if err != nil {
+		return err
+	}
+	if len(manifest.Segments) == 0 {
+		return fmt.Errorf("Backup %s does not have a manifest so it cannot be exported incrementally. Run the export again without --incremental", job.ID)
+	}
+
+	previous := previousSegments(filePath)
+	var base *os.File
+	if len(previous) > 0 {
+		base, err = os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer base.Close()
+	}
+
+	partPath := fmt.Sprintf("%s.%s.part", filePath, job.ID)
+	out, err := os.Create(partPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	var offset int64
+	var reused, downloaded transfer.ByteSize
+	logrus.Printf("Exporting %d segments...", len(manifest.Segments))
+	for i, segment := range manifest.Segments {
+		if baseOffset, ok := previous[segment.Hash]; ok {
+			var valid bool
+			if valid, err = copySegment(out, base, baseOffset, offset, &segment); err != nil {
+				return err
+			}
+			if valid {
+				offset += segment.Size
+				reused += transfer.ByteSize(segment.Size)
+				continue
+			}
+			logrus.Debugf("Segment %d of the previous export changed on disk, downloading it again", i+1)
+		}
+		if d.Cache != nil {
+			if path, ok := d.Cache.Get(segment.Hash); ok {
+				if err = appendFile(out, path); err != nil {
+					return err
+				}
+				offset += segment.Size
+				reused += transfer.ByteSize(segment.Size)
+				continue
+			}
+		}
+		for attempt := 1; attempt <= config.TransferRetries; attempt++ {
+			if attempt > 1 {
+				logrus.Printf("Download of segment %d interrupted: %s. Retrying (attempt %d of %d)", i+1, err, attempt, config.TransferRetries)
+				time.Sleep(config.TransferRetryTime * time.Second)
+				if err = out.Truncate(offset); err != nil {
+					return err
+				}
+				if _, err = out.Seek(offset, io.SeekStart); err != nil {
+					return err
+				}
+				// the segment URLs may have expired, so ask for new ones
+				var fresh *models.BackupManifest
+				fresh, err = d.BackupManifest(job.ID, service)
+				if err != nil {
+					continue
+				}
+				if len(fresh.Segments) != len(manifest.Segments) {
+					return fmt.Errorf("The manifest of backup %s changed during the export", job.ID)
+				}
+				segment = fresh.Segments[i]
+			}
+			err = d.downloadSegment(out, &segment, job)
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if d.Cache != nil {
+			if _, err = d.Cache.Put(io.NewSectionReader(out, offset, segment.Size)); err != nil {
+				logrus.Debugf("Error adding segment %d to the artifact cache: %s", i+1, err)
+			}
+		}
+		offset += segment.Size
+		downloaded += transfer.ByteSize(segment.Size)
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	if base != nil {
+		base.Close()
+	}
+	if err = os.Rename(partPath, filePath); err != nil {
+		return err
+	}
+	logrus.Printf("Downloaded %s and reused %s from previous exports", downloaded, reused)
+	return saveManifest(filePath, manifest)
+}
+
+// copySegment copies the segment at baseOffset of the previous export to
+// offset in out and returns whether it still matches its hash. A segment that
+// does not is removed from out again so that it can be downloaded instead.
+func copySegment(out, base *os.File, baseOffset, offset int64, segment *models.BackupSegment) (bool, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hash), io.NewSectionReader(base, baseOffset, segment.Size)); err != nil {
+		return false, err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) == segment.Hash {
+		return true, nil
+	}
+	if err := out.Truncate(offset); err != nil {
+		return false, err
+	}
+	_, err := out.Seek(offset, io.SeekStart)
+	return false, err
+}
+
+// downloadSegment downloads and decrypts a single segment of a backup to out
+// and verifies its hash
+func (d *SDb) downloadSegment(out io.Writer, segment *models.BackupSegment, job *models.Job) error {
+	resp, err := http.Get(segment.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Unexpected status code %d", resp.StatusCode)
+	}
+	hash := sha256.New()
+	dwc, err := d.Crypto.NewDecryptWriteCloser(nopWriteCloser{io.MultiWriter(out, hash)}, job.Backup.Key, segment.IV)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dwc, transfer.NewReaderTransfer(resp.Body, int(resp.ContentLength))); err != nil {
+		return err
+	}
+	if err = dwc.Close(); err != nil {
+		return err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != segment.Hash {
+		return fmt.Errorf("The downloaded segment does not match its hash")
+	}
+	return nil
+}
+
+// BackupManifest retrieves the manifest of a finished backup, with a
+// temporary download URL for each of its segments
+func (d *SDb) BackupManifest(jobID string, service *models.Service) (*models.BackupManifest, error) {
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/backup-manifest/%s", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID, jobID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var manifest models.BackupManifest
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &manifest)
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// manifestPath is where the manifest of an incremental export to the given
+// file path is saved
+func manifestPath(filePath string) string {
+	return filePath + ".manifest"
+}
+
+// previousSegments returns the offset of each segment in the file of the
+// previous incremental export to the given file path, by hash. Nothing is
+// returned if there was no previous export or the file was modified since.
+func previousSegments(filePath string) map[string]int64 {
+	b, err := ioutil.ReadFile(manifestPath(filePath))
+	if err != nil {
+		return nil
+	}
+	var manifest models.BackupManifest
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		return nil
+	}
+	segments := map[string]int64{}
+	var offset int64
+	for _, s := range manifest.Segments {
+		if _, ok := segments[s.Hash]; !ok {
+			segments[s.Hash] = offset
+		}
+		offset += s.Size
+	}
+	info, err := os.Stat(filePath)
+	if err != nil || info.Size() != offset {
+		return nil
+	}
+	return segments
+}
+
+// saveManifest saves the manifest of an incremental export next to the file
+// path, without the segment URLs which expire
+func saveManifest(filePath string, manifest *models.BackupManifest) error {
+	saved := models.BackupManifest{JobID: manifest.JobID}
+	for _, s := range manifest.Segments {
+		s.URL = ""
+		saved.Segments = append(saved.Segments, s)
+	}
+	b, err := json.MarshalIndent(saved, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manifestPath(filePath), b, 0600)
+}
+
+// nopWriteCloser adds a Close method that does nothing to a Writer
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
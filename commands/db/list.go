@@ -3,13 +3,43 @@ package db
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pmylund/sortutil"
 )
 
-func CmdList(databaseName string, page, pageSize int, id IDb, is services.IServices) error {
+// listPageSize is the number of backups requested at a time
+const listPageSize = 100
+
+// maxListPages keeps listing from requesting pages forever if the API keeps
+// returning full pages
+const maxListPages = 100
+
+var listSorts = []string{"newest", "oldest", "size"}
+
+func CmdList(databaseName, sortBy string, page, limit int, id IDb, is services.IServices) error {
+	sortBy = strings.ToLower(sortBy)
+	valid := false
+	for _, s := range listSorts {
+		if s == sortBy {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("Invalid sort \"%s\". Please specify one of %s.", sortBy, strings.Join(listSorts, ", "))
+	}
+	if page < 1 {
+		return fmt.Errorf("The page must be at least 1 but was %d.", page)
+	}
+	if limit < 0 {
+		return fmt.Errorf("The limit must not be negative but was %d.", limit)
+	}
 	service, err := is.RetrieveByLabel(databaseName)
 	if err != nil {
 		return err
@@ -17,25 +47,86 @@ func CmdList(databaseName string, page, pageSize int, id IDb, is services.IServi
 	if service == nil {
 		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
 	}
-	jobs, err := id.List(page, pageSize, service)
+	jobs, err := listAll(id, service)
 	if err != nil {
 		return err
 	}
-	sort.Sort(SortedJobs(*jobs))
-	for _, job := range *jobs {
-		logrus.Printf("%s %s (status = %s)", job.ID, job.CreatedAt, job.Status)
+	if header := scheduleHeader(databaseName, id, service); header != "" {
+		logrus.Println(header)
 	}
-	if len(*jobs) == pageSize && page == 1 {
-		logrus.Println("(for older backups, try with --page 2 or adjust --page-size)")
-	}
-	if len(*jobs) == 0 && page == 1 {
+	if len(jobs) == 0 {
 		logrus.Println("No backups created yet for this service.")
-	} else if len(*jobs) == 0 {
-		logrus.Println("No backups found with the given parameters.")
+		return nil
+	}
+	switch sortBy {
+	case "newest":
+		sort.Sort(sort.Reverse(SortedJobs(jobs)))
+	case "oldest":
+		sort.Sort(SortedJobs(jobs))
+	case "size":
+		sortutil.DescByField(jobs, "Size")
+	}
+	start, end := 0, len(jobs)
+	if limit > 0 {
+		start = (page - 1) * limit
+		if start+limit < end {
+			end = start + limit
+		}
+	}
+	if start >= len(jobs) {
+		logrus.Printf("No backups found with the given parameters. There are %d backups for this service.", len(jobs))
+		return nil
+	}
+
+	data := [][]string{{"ID", "CREATED", "STATUS", "TYPE", "SIZE"}}
+	for _, job := range jobs[start:end] {
+		backupType := "manual"
+		if job.Scheduled {
+			backupType = "scheduled"
+		}
+		size := "-"
+		if job.Size > 0 {
+			size = config.FormatBytes(float64(job.Size))
+		}
+		data = append(data, []string{job.ID, config.FormatTimestampString(job.CreatedAt), job.Status, backupType, size})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	if end < len(jobs) {
+		logrus.Printf("(showing %d-%d of %d backups, for more try with --page %d or adjust --limit)", start+1, end, len(jobs), page+1)
 	}
 	return nil
 }
 
+// listAll lists every backup of the service, requesting page after page
+// until a page that is not full is returned
+func listAll(id IDb, service *models.Service) ([]models.Job, error) {
+	all := []models.Job{}
+	for page := 1; ; page++ {
+		jobs, err := id.List(page, listPageSize, service)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, *jobs...)
+		if len(*jobs) < listPageSize {
+			break
+		}
+		if page == maxListPages {
+			logrus.Warnf("Only the first %d backups are listed", len(all))
+			break
+		}
+	}
+	return all, nil
+}
+
 // SortedJobs is a wrapper for Jobs array in order to sort them by CreatedAt
 // for the ListBackups command
 type SortedJobs []models.Job
@@ -52,7 +143,7 @@ func (jobs SortedJobs) Less(i, j int) bool {
 	return jobs[i].CreatedAt < jobs[j].CreatedAt
 }
 
-// List lists the created backups for the service sorted from oldest to newest
+// List lists one page of the created backups for the service
 func (d *SDb) List(page, pageSize int, service *models.Service) (*[]models.Job, error) {
 	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
 	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs?type=backup&pageNumber=%d&pageSize=%d", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID, page, pageSize), headers)
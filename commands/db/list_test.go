@@ -13,13 +13,19 @@ import (
 
 var dbListTests = []struct {
 	databaseName string
+	sortBy       string
 	page         int
-	pageSize     int
+	limit        int
 	expectErr    bool
 }{
-	{dbName, 1, 10, false},
-	{dbName, 2, 10, false},
-	{"invalid-svc", 1, 10, true},
+	{dbName, "newest", 1, 10, false},
+	{dbName, "oldest", 2, 1, false},
+	{dbName, "SIZE", 1, 0, false},
+	{dbName, "newest", 5, 10, false},
+	{dbName, "largest", 1, 10, true},
+	{dbName, "newest", 0, 10, true},
+	{dbName, "newest", 1, -1, true},
+	{"invalid-svc", "newest", 1, 10, true},
 }
 
 func TestDbList(t *testing.T) {
@@ -36,7 +42,8 @@ func TestDbList(t *testing.T) {
 	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs",
 		func(w http.ResponseWriter, r *http.Request) {
 			test.AssertEquals(t, r.Method, "GET")
-			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","isSnapshotBackup":false,"type":"backup","status":"finished"}]`, dbJobID))
+			test.AssertEquals(t, r.URL.Query().Get("pageSize"), "100")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","isSnapshotBackup":false,"type":"backup","status":"finished","created_at":"2017-03-01T00:00:00Z","size":1048576,"scheduled":true},{"id":"j2","type":"backup","status":"finished","created_at":"2017-03-02T00:00:00Z","size":2048}]`, dbJobID))
 		},
 	)
 
@@ -44,7 +51,7 @@ func TestDbList(t *testing.T) {
 		t.Logf("Data: %+v", data)
 
 		// test
-		err := CmdList(data.databaseName, data.page, data.pageSize, New(settings, crypto.New(), jobs.New(settings)), services.New(settings))
+		err := CmdList(data.databaseName, data.sortBy, data.page, data.limit, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
 
 		// assert
 		if err != nil != data.expectErr {
@@ -9,23 +9,61 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/httpclient"
 	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/models"
 )
 
-func CmdLogs(databaseName, backupID string, id IDb, is services.IServices, ij jobs.IJobs) error {
-	service, err := is.RetrieveByLabel(databaseName)
-	if err != nil {
-		return err
+func CmdLogs(databaseName, jobID string, id IDb, is services.IServices, ij jobs.IJobs) error {
+	var service *models.Service
+	var job *models.Job
+	var err error
+	if databaseName == "" {
+		service, job, err = findJob(jobID, is, ij)
+		if err != nil {
+			return err
+		}
+	} else {
+		service, err = is.RetrieveByLabel(databaseName)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
+		}
+		job, err = ij.Retrieve(jobID, service.ID, false)
+		if err != nil {
+			return err
+		}
 	}
-	if service == nil {
-		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
+	if job.Type != "backup" && job.Type != "restore" {
+		return fmt.Errorf("Job %s is a %s job. Logs can only be retrieved for backup and import jobs, use \"datica logs\" for the logs of other jobs.", job.ID, job.Type)
 	}
-	job, err := ij.Retrieve(backupID, service.ID, false)
+	return id.DumpLogs(job.Type, job, service)
+}
+
+// findJob looks for the job with the given ID on every database service of
+// the environment
+func findJob(jobID string, is services.IServices, ij jobs.IJobs) (*models.Service, *models.Job, error) {
+	svcs, err := is.List()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	return id.DumpLogs(job.Type, job, service)
+	for i := range *svcs {
+		service := &(*svcs)[i]
+		if !services.IsDatabase(service) {
+			continue
+		}
+		job, err := ij.Retrieve(jobID, service.ID, false)
+		if err != nil {
+			if !httpclient.IsMissingEndpoint(err) {
+				return nil, nil, err
+			}
+			continue
+		}
+		return service, job, nil
+	}
+	return nil, nil, fmt.Errorf("Could not find a job with the ID \"%s\" on any database service. You can list backups with the \"datica db list\" command.", jobID)
 }
 
 // DumpLogs dumps logs from a Backup/Restore/Import/Export job to the console
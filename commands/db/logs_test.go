@@ -19,7 +19,10 @@ var dbLogsTests = []struct {
 	{dbName, dbJobID, false},
 	{dbName, "invalid-job", true},
 	{dbName, dbImportID, true},
+	{dbName, "deploy-job", true},
 	{"invalid-svc", dbJobID, true},
+	{"", dbJobID, false},
+	{"", "invalid-job", true},
 }
 
 func TestDbLogs(t *testing.T) {
@@ -30,7 +33,13 @@ func TestDbLogs(t *testing.T) {
 	mux.HandleFunc("/environments/"+test.EnvID+"/services",
 		func(w http.ResponseWriter, r *http.Request) {
 			test.AssertEquals(t, r.Method, "GET")
-			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"code1","label":"app01","name":"code"},{"id":"%s","label":"%s","name":"postgresql"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs/deploy-job",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"id":"deploy-job","type":"deploy","status":"finished"}`)
 		},
 	)
 	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs/"+dbJobID,
@@ -62,7 +71,7 @@ func TestDbLogs(t *testing.T) {
 		t.Logf("Data: %+v", data)
 
 		// test
-		err := CmdLogs(data.databaseName, data.jobID, New(settings, crypto.New(), jobs.New(settings)), services.New(settings), jobs.New(settings))
+		err := CmdLogs(data.databaseName, data.jobID, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings), jobs.New(settings))
 
 		// assert
 		if err != nil {
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// pitrServiceName is the kind of database service that supports point in
+// time restores
+const pitrServiceName = "postgresql"
+
+// CmdRestore restores a PostgreSQL service to the data it had at the given
+// moment, either in place or into another PostgreSQL service
+func CmdRestore(databaseName, at, targetName string, skipPoll bool, id IDb, ip prompts.IPrompts, is services.IServices, ij jobs.IJobs) error {
+	restorePoint, err := config.ParseTimestamp(at)
+	if err != nil {
+		return err
+	}
+	// restore points are kept to the second, the precision of the window
+	restorePoint = restorePoint.UTC().Truncate(time.Second)
+	service, err := is.RetrieveByLabel(databaseName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
+	}
+	if service.Name != pitrServiceName {
+		return fmt.Errorf("%s is a %s service. Point in time restores are only supported for PostgreSQL services, use \"datica db import\" to restore a backup instead.", databaseName, service.Name)
+	}
+	target := service
+	if targetName != "" && targetName != databaseName {
+		target, err = is.RetrieveByLabel(targetName)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", targetName)
+		}
+		if target.Name != pitrServiceName {
+			return fmt.Errorf("%s is a %s service. Point in time restores can only be made into PostgreSQL services.", targetName, target.Name)
+		}
+	}
+	window, err := id.RestoreWindow(service)
+	if err != nil {
+		return err
+	}
+	earliest, err := time.Parse(time.RFC3339, window.Earliest)
+	if err != nil {
+		return fmt.Errorf("Invalid restore window returned for %s: %s", databaseName, err)
+	}
+	latest, err := time.Parse(time.RFC3339, window.Latest)
+	if err != nil {
+		return fmt.Errorf("Invalid restore window returned for %s: %s", databaseName, err)
+	}
+	if restorePoint.Before(earliest) || restorePoint.After(latest) {
+		return fmt.Errorf("%s can only be restored to a point between %s and %s, but %s was given.", databaseName, config.FormatTimestamp(earliest), config.FormatTimestamp(latest), config.FormatTimestamp(restorePoint))
+	}
+
+	logrus.Printf("Restore point: %s (%s)", config.FormatTimestamp(restorePoint), restorePoint.Format(time.RFC3339))
+	logrus.Printf("Source:        %s (ID = %s)", service.Label, service.ID)
+	logrus.Printf("Target:        %s (ID = %s)", target.Label, target.ID)
+	prompt := fmt.Sprintf("This will replace all data in %s with the data %s had at the restore point. Are you sure you want to restore? (y/n) ", target.Label, service.Label)
+	if target.ID == service.ID {
+		prompt = fmt.Sprintf("This will restore %s in place, and every change made to it after the restore point will be lost. Are you sure you want to restore? (y/n) ", service.Label)
+	}
+	if err = ip.YesNo(prompt); err != nil {
+		return err
+	}
+	job, err := id.PointInTimeRestore(restorePoint, target, service)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Restore started (job ID = %s)", job.ID)
+	if skipPoll {
+		logrus.Printf("You can wait for the restore to finish with the \"datica jobs attach %s %s\" command", target.Label, job.ID)
+		return nil
+	}
+	// all because logrus treats print, println, and printf the same
+	logrus.StandardLogger().Out.Write([]byte("Polling until the restore finishes."))
+	status, err := ij.PollTillFinished(job.ID, target.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("\nEnded in status '%s'", status)
+	if status != "finished" {
+		return fmt.Errorf("Restore finished with invalid status %s. The data of %s was not changed.", status, target.Label)
+	}
+	return nil
+}
+
+// RestoreWindow returns the range of time the service can be restored to a
+// point in
+func (d *SDb) RestoreWindow(service *models.Service) (*models.RestoreWindow, error) {
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/pitr", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var window models.RestoreWindow
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &window)
+	if err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+// PointInTimeRestore restores the data the service had at the restore point
+// into the target service, which may be the service itself
+func (d *SDb) PointInTimeRestore(restorePoint time.Time, target, service *models.Service) (*models.Job, error) {
+	b, err := json.Marshal(map[string]string{
+		"restorePoint":    restorePoint.UTC().Format(time.RFC3339),
+		"targetServiceId": target.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/services/%s/pitr", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var job models.Job
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/crypto"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var dbRestoreTests = []struct {
+	databaseName string
+	at           string
+	target       string
+	skipPoll     bool
+	restorePoint string
+	expectErr    bool
+}{
+	{dbName, "2026-10-17T14:30:00Z", "", false, "2026-10-17T14:30:00Z", false},
+	{dbName, "2026-10-17T14:30:00.750Z", "", true, "2026-10-17T14:30:00Z", false},
+	{dbName, "2026-10-17T09:30:00-05:00", "database2", true, "2026-10-17T14:30:00Z", false},
+	{dbName, "2026-10-17T14:30:00Z", dbName, true, "2026-10-17T14:30:00Z", false},
+	{dbName, "2026-10-01T00:00:00Z", "", true, "", true},
+	{dbName, "2026-10-18T00:00:00Z", "", true, "", true},
+	{dbName, "yesterday", "", true, "", true},
+	{dbName, "2026-10-17T14:30:00Z", "redis1", true, "", true},
+	{dbName, "2026-10-17T14:30:00Z", "invalid-svc", true, "", true},
+	{"redis1", "2026-10-17T14:30:00Z", "", true, "", true},
+	{"invalid-svc", "2026-10-17T14:30:00Z", "", true, "", true},
+}
+
+func TestDbRestore(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	var restored map[string]string
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","name":"postgresql"},{"id":"d2","label":"database2","name":"postgresql"},{"id":"r1","label":"redis1","name":"redis"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/pitr",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				b, _ := ioutil.ReadAll(r.Body)
+				json.Unmarshal(b, &restored)
+				fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"restore","status":"running"}`, dbJobID))
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"earliest":"2026-10-10T00:00:00Z","latest":"2026-10-17T15:00:00Z"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/jobs/"+dbJobID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","status":"finished"}`, dbJobID))
+		},
+	)
+
+	for _, data := range dbRestoreTests {
+		t.Logf("Data: %+v", data)
+		restored = nil
+
+		// test
+		err := CmdRestore(data.databaseName, data.at, data.target, data.skipPoll, New(settings, crypto.New(), jobs.New(settings), nil), &test.FakePrompts{}, services.New(settings), jobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectErr {
+			if restored != nil {
+				t.Errorf("Expected no restore to be started but one was")
+			}
+			continue
+		}
+		if restored["restorePoint"] != data.restorePoint {
+			t.Errorf("Expected restore point %s but got %s", data.restorePoint, restored["restorePoint"])
+		}
+		targetID := dbID
+		if data.target == "database2" {
+			targetID = "d2"
+		}
+		if restored["targetServiceId"] != targetID {
+			t.Errorf("Expected target %s but got %s", targetID, restored["targetServiceId"])
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libcron "github.com/daticahealth/cli/lib/cron"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/daticahealth/cli/models"
+)
+
+// frequencies are the named backup frequencies and the cron expressions they
+// stand for
+var frequencies = map[string]string{
+	"hourly": "0 * * * *",
+	"daily":  "0 0 * * *",
+	"weekly": "0 0 * * SUN",
+}
+
+// maxRetentionDays is the longest automated backups can be kept
+const maxRetentionDays = 365
+
+func CmdScheduleSet(databaseName, frequency string, retentionDays int, window *transfer.Window, id IDb, is services.IServices) error {
+	frequency = strings.TrimSpace(frequency)
+	if _, ok := frequencies[strings.ToLower(frequency)]; ok {
+		frequency = strings.ToLower(frequency)
+	}
+	if _, err := parseFrequency(frequency); err != nil {
+		return err
+	}
+	if window != nil {
+		var err error
+		if frequency, err = windowFrequency(frequency, window, time.Now()); err != nil {
+			return err
+		}
+	}
+	if retentionDays < 1 || retentionDays > maxRetentionDays {
+		return fmt.Errorf("The retention must be between 1 and %d days but was %d.", maxRetentionDays, retentionDays)
+	}
+	service, err := is.RetrieveByLabel(databaseName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
+	}
+	schedule, err := id.SetSchedule(&models.BackupSchedule{Frequency: frequency, RetentionDays: retentionDays}, service)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("%s is now backed up %s", databaseName, describeSchedule(schedule, time.Now()))
+	return nil
+}
+
+func CmdScheduleShow(databaseName string, id IDb, is services.IServices) error {
+	service, err := is.RetrieveByLabel(databaseName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
+	}
+	schedule, err := id.RetrieveSchedule(service)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		logrus.Printf("%s is not backed up automatically. You can schedule backups with the \"datica db backup schedule set %s\" command.", databaseName, databaseName)
+		return nil
+	}
+	logrus.Printf("%s is backed up %s", databaseName, describeSchedule(schedule, time.Now()))
+	return nil
+}
+
+func CmdScheduleRm(databaseName string, id IDb, is services.IServices) error {
+	service, err := is.RetrieveByLabel(databaseName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", databaseName)
+	}
+	err = id.RemoveSchedule(service)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Automated backups of %s stopped. Backups that were already created are kept until their retention ends.", databaseName)
+	return nil
+}
+
+// parseFrequency parses a named frequency or a cron expression
+func parseFrequency(frequency string) (*libcron.Schedule, error) {
+	if expr, ok := frequencies[frequency]; ok {
+		return libcron.Parse(expr)
+	}
+	if len(strings.Fields(frequency)) <= 1 {
+		return nil, fmt.Errorf("Invalid frequency \"%s\". Please specify hourly, daily, weekly, or a cron expression.", frequency)
+	}
+	return libcron.Parse(frequency)
+}
+
+// windowFrequency returns the cron expression in UTC that creates backups at
+// the named frequency inside the given window. Daily and weekly backups are
+// created when the window opens, and hourly backups every hour it is open.
+// The window is converted to UTC with the offset of the configured timezone
+// at the given time.
+func windowFrequency(frequency string, w *transfer.Window, now time.Time) (string, error) {
+	local := now.In(config.Timezone())
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location()).Add(w.Start)
+	switch frequency {
+	case "daily":
+		return fmt.Sprintf("%d %d * * *", start.UTC().Minute(), start.UTC().Hour()), nil
+	case "weekly":
+		for start.Weekday() != time.Sunday {
+			start = start.AddDate(0, 0, 1)
+		}
+		return fmt.Sprintf("%d %d * * %s", start.UTC().Minute(), start.UTC().Hour(), strings.ToUpper(start.UTC().Weekday().String()[:3])), nil
+	case "hourly":
+		hours := []string{}
+		for t := start; w.Contains(t) && len(hours) < 24; t = t.Add(time.Hour) {
+			hours = append(hours, strconv.Itoa(t.UTC().Hour()))
+		}
+		return fmt.Sprintf("%d %s * * *", start.UTC().Minute(), strings.Join(hours, ",")), nil
+	}
+	return "", fmt.Errorf("A schedule window can only be used with the hourly, daily, or weekly frequency. Pick times inside the window in the cron expression \"%s\" instead.", frequency)
+}
+
+// describeSchedule describes how often backups are created, how long they
+// are kept, and when the next one is created after the given time
+func describeSchedule(schedule *models.BackupSchedule, now time.Time) string {
+	desc := schedule.Frequency
+	if _, ok := frequencies[schedule.Frequency]; !ok {
+		desc = fmt.Sprintf("on \"%s\"", schedule.Frequency)
+	}
+	desc = fmt.Sprintf("%s and backups are kept for %d days", desc, schedule.RetentionDays)
+	if s, err := parseFrequency(schedule.Frequency); err == nil {
+		if next := s.Next(now.UTC()); !next.IsZero() {
+			desc = fmt.Sprintf("%s (next backup %s)", desc, next.Format(time.RFC3339))
+		}
+	}
+	return desc
+}
+
+// scheduleHeader returns the line printed above the backups of the service
+// with its automated backup schedule. Listing backups does not fail when the
+// schedule can not be retrieved, and pods that do not serve backup schedules
+// yet get no header.
+func scheduleHeader(databaseName string, id IDb, service *models.Service) string {
+	schedule, err := id.RetrieveSchedule(service)
+	if httpclient.IsMissingEndpoint(err) {
+		return ""
+	}
+	if err != nil {
+		logrus.Warnf("Could not retrieve the backup schedule of %s: %s", databaseName, err)
+		return ""
+	}
+	if schedule == nil {
+		return fmt.Sprintf("Automated backups: none (see \"datica db backup schedule set %s\")", databaseName)
+	}
+	return fmt.Sprintf("Automated backups: %s", describeSchedule(schedule, time.Now()))
+}
+
+// SetSchedule creates or replaces the automated backup schedule of the
+// service
+func (d *SDb) SetSchedule(schedule *models.BackupSchedule, service *models.Service) (*models.BackupSchedule, error) {
+	b, err := json.Marshal(schedule)
+	if err != nil {
+		return nil, err
+	}
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Put(b, fmt.Sprintf("%s%s/environments/%s/services/%s/backup-schedule", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var updated models.BackupSchedule
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// RetrieveSchedule returns the automated backup schedule of the service, or
+// nil if the service is not backed up automatically
+func (d *SDb) RetrieveSchedule(service *models.Service) (*models.BackupSchedule, error) {
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/backup-schedule", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var schedule *models.BackupSchedule
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &schedule)
+	if err != nil {
+		return nil, err
+	}
+	if schedule != nil && schedule.Frequency == "" {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
+// RemoveSchedule stops the automated backups of the service
+func (d *SDb) RemoveSchedule(service *models.Service) error {
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/backup-schedule", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, service.ID), headers)
+	if err != nil {
+		return err
+	}
+	return d.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,215 @@
+package db
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/crypto"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var dbScheduleSetTests = []struct {
+	databaseName  string
+	frequency     string
+	retentionDays int
+	expectErr     bool
+}{
+	{dbName, "daily", 30, false},
+	{dbName, "Weekly", 7, false},
+	{dbName, "0 3 * * *", 14, false},
+	{dbName, "monthly", 30, true},
+	{dbName, "0 3 * *", 30, true},
+	{dbName, "daily", 0, true},
+	{dbName, "daily", 366, true},
+	{"invalid-svc", "daily", 30, true},
+}
+
+func TestDbScheduleSet(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-schedule",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			b, _ := ioutil.ReadAll(r.Body)
+			fmt.Fprint(w, string(b))
+		},
+	)
+
+	for _, data := range dbScheduleSetTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdScheduleSet(data.databaseName, data.frequency, data.retentionDays, nil, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
+
+var windowFrequencyTests = []struct {
+	frequency string
+	window    string
+	timezone  string
+	expected  string
+	expectErr bool
+}{
+	{"daily", "22:00-06:00", "UTC", "0 22 * * *", false},
+	{"daily", "22:30-06:00", "America/Chicago", "30 3 * * *", false},
+	{"weekly", "22:00-06:00", "America/Chicago", "0 3 * * MON", false},
+	{"weekly", "01:00-03:00", "UTC", "0 1 * * SUN", false},
+	{"hourly", "22:00-02:00", "UTC", "0 22,23,0,1 * * *", false},
+	{"hourly", "22:30-01:00", "America/Chicago", "30 3,4,5 * * *", false},
+	{"0 3 * * *", "22:00-06:00", "UTC", "", true},
+}
+
+func TestWindowFrequency(t *testing.T) {
+	defer config.SetTimezone("local")
+	// a Wednesday in daylight saving time
+	now := time.Date(2017, 6, 7, 12, 0, 0, 0, time.UTC)
+	for _, data := range windowFrequencyTests {
+		t.Logf("Data: %+v", data)
+		if err := config.SetTimezone(data.timezone); err != nil {
+			t.Fatal(err)
+		}
+		window, err := transfer.ParseWindow(data.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// test
+		actual, err := windowFrequency(data.frequency, window, now)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		test.AssertEquals(t, data.expected, actual)
+		if _, err := parseFrequency(actual); err != nil && !data.expectErr {
+			t.Errorf("Expected a valid cron expression but got %s", err)
+		}
+	}
+}
+
+var dbScheduleShowTests = []struct {
+	databaseName string
+	expectErr    bool
+}{
+	{dbName, false},
+	{"invalid-svc", true},
+}
+
+func TestDbScheduleShow(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-schedule",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"frequency":"daily","retentionDays":30}`)
+		},
+	)
+
+	for _, data := range dbScheduleShowTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdScheduleShow(data.databaseName, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
+
+var dbScheduleRmTests = []struct {
+	databaseName string
+	expectErr    bool
+}{
+	{dbName, false},
+	{"invalid-svc", true},
+}
+
+func TestDbScheduleRm(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, dbID, dbName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+dbID+"/backup-schedule",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+		},
+	)
+
+	for _, data := range dbScheduleRmTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdScheduleRm(data.databaseName, New(settings, crypto.New(), jobs.New(settings), nil), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
+
+var describeScheduleTests = []struct {
+	schedule models.BackupSchedule
+	contains string
+}{
+	{models.BackupSchedule{Frequency: "daily", RetentionDays: 30}, "daily and backups are kept for 30 days (next backup 2017-03-02T00:00:00Z)"},
+	{models.BackupSchedule{Frequency: "hourly", RetentionDays: 1}, "(next backup 2017-03-01T11:00:00Z)"},
+	{models.BackupSchedule{Frequency: "0 3 * * *", RetentionDays: 14}, "on \"0 3 * * *\" and backups are kept for 14 days (next backup 2017-03-02T03:00:00Z)"},
+}
+
+func TestDescribeSchedule(t *testing.T) {
+	now := time.Date(2017, 3, 1, 10, 30, 0, 0, time.UTC)
+	for _, data := range describeScheduleTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		desc := describeSchedule(&data.schedule, now)
+
+		// assert
+		if !strings.Contains(desc, data.contains) {
+			t.Errorf("Expected \"%s\" to contain \"%s\"", desc, data.contains)
+		}
+	}
+}
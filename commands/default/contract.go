@@ -17,6 +17,7 @@ var Cmd = models.Command{
 		"See [scope](#global-scope) for more information on scope and default environments. " +
 		"When setting a default environment, you must give the alias of the environment if one was set when it was associated and not the real environment name. Here is a sample command\n\n" +
 		"```\ndatica default prod\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			alias := cmd.StringArg("ENV_ALIAS", "", "The alias of an already associated environment to set as the default")
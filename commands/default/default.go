@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
 )
 
 func CmdDefault(alias string, id IDefault) error {
-	logrus.Warnln("The \"default\" command has been deprecated! It will be removed in a future version.")
-	logrus.Warnln("Please specify \"-E\" on all commands instead of using the default.")
+	if err := config.Deprecated("The \"default\" command has been deprecated! It will be removed in a future version. Please specify \"-E\" on all commands instead of using the default."); err != nil {
+		return err
+	}
 	err := id.Set(alias)
 	if err != nil {
 		return err
@@ -0,0 +1,120 @@
+package defaults
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// root is the top level command whose options can be given defaults
+var root *cli.Cmd
+
+// SetRoot sets the top level command used to check that the command and
+// option of a default exist. This must be called once every command has been
+// registered.
+func SetRoot(cmd *cli.Cmd) {
+	root = cmd
+}
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "defaults",
+	ShortHelp: "Set default values for the options of commands",
+	LongHelp: "The `defaults` command allows you to set the value an option has when it is not given on the command line, so that your team can standardize how commands behave without wrapping the CLI in shell aliases. " +
+		"A default is named by the command and the option separated by dots, such as `logs.follow` or `environments.list.all-pods`. " +
+		"A default named by the option alone, such as `json`, applies to every command that has that option, including global options such as `timezone`, unless the command has a default of its own. " +
+		"Defaults are applied before the command line is parsed, so an option given on the command line always wins. " +
+		"Defaults are stored in your local settings. " +
+		"The defaults command can not be run directly but has sub commands.",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, help.Render(SetSubCmd.LongHelp), SetSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the default values of options",
+	LongHelp: "`defaults list` lists every default you have set along with its value. Here is a sample command\n\n" +
+		"```\ndatica defaults list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				err := CmdList(New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove the default value of an option",
+	LongHelp: "`defaults rm` removes the default with the given name, so that the option goes back to its usual default value. Here is a sample command\n\n" +
+		"```\ndatica defaults rm logs.follow\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the default to remove, such as logs.follow")
+			subCmd.Action = func() {
+				err := CmdRm(*name, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+var SetSubCmd = models.Command{
+	Name:      "set",
+	ShortHelp: "Set the default value of an option",
+	LongHelp: "`defaults set` sets the value an option has when it is not given on the command line. " +
+		"`NAME` is the command and the option separated by dots, or the option alone to set it for every command that has it. " +
+		"Use `true` or `false` as the value of options that do not take a value. " +
+		"The command and option must exist, and a value that is not valid for its option is reported and ignored when the command is run. Here are some sample commands\n\n" +
+		"```\ndatica defaults set logs.follow true\n" +
+		"datica defaults set environments.list.all-pods true\n" +
+		"datica defaults set json true\n" +
+		"datica defaults set timezone UTC\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the default, such as logs.follow")
+			value := subCmd.StringArg("VALUE", "", "The default value of the option")
+			subCmd.Action = func() {
+				err := CmdSet(*name, *value, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME VALUE"
+		}
+	},
+}
+
+// IDefaults
+type IDefaults interface {
+	List() map[string]string
+	Rm(name string) error
+	Set(name, value string) error
+}
+
+// SDefaults is a concrete implementation of IDefaults
+type SDefaults struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IDefaults
+func New(settings *models.Settings) IDefaults {
+	return &SDefaults{
+		Settings: settings,
+	}
+}
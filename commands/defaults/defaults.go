@@ -0,0 +1,190 @@
+package defaults
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/jault3/mow.cli"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdSet(name, value string, id IDefaults) error {
+	path, option := split(name)
+	if option == "" {
+		return errors.New("The name of a default must end with the name of an option, such as logs.follow")
+	}
+	if root != nil {
+		if len(path) > 0 {
+			cmd, err := findCommand(path)
+			if err != nil {
+				return err
+			}
+			if !hasOption(cmd, option) {
+				return fmt.Errorf("\"datica %s\" has no --%s option", strings.Join(path, " "), option)
+			}
+		} else {
+			found, err := anyHasOption(root, option)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("No command has a --%s option", option)
+			}
+		}
+	}
+	name = strings.Join(append(path, option), ".")
+	if err := id.Set(name, value); err != nil {
+		return err
+	}
+	if len(path) > 0 {
+		logrus.Printf("\"datica %s\" will run with --%s=%s unless the option is given", strings.Join(path, " "), option, value)
+	} else {
+		logrus.Printf("Every command with a --%s option will run with --%s=%s unless the option is given", option, option, value)
+	}
+	return nil
+}
+
+func CmdList(id IDefaults) error {
+	defaults := id.List()
+	if len(defaults) == 0 {
+		logrus.Println("No defaults have been set. Set one with \"datica defaults set\".")
+		return nil
+	}
+	names := []string{}
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data := [][]string{{"NAME", "VALUE"}}
+	for _, name := range names {
+		data = append(data, []string{name, defaults[name]})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.Render()
+	return nil
+}
+
+func CmdRm(name string, id IDefaults) error {
+	path, option := split(name)
+	name = strings.Join(append(path, option), ".")
+	if err := id.Rm(name); err != nil {
+		return err
+	}
+	logrus.Printf("Removed the default %s", name)
+	return nil
+}
+
+// Resolve returns the defaults that apply to the command at the given path,
+// which does not include the name of the CLI, keyed by option name. A
+// default set for the command wins over a default set for every command.
+func Resolve(defaults map[string]string, path []string) map[string]string {
+	resolved := map[string]string{}
+	specific := map[string]string{}
+	cmdPath := strings.Join(path, " ")
+	for name, value := range defaults {
+		p, option := split(name)
+		if len(p) == 0 {
+			resolved[option] = value
+		} else if strings.Join(p, " ") == cmdPath {
+			specific[option] = value
+		}
+	}
+	for option, value := range specific {
+		resolved[option] = value
+	}
+	return resolved
+}
+
+// split splits the name of a default into the path of its command and the
+// name of its option
+func split(name string) ([]string, string) {
+	parts := strings.Split(strings.TrimLeft(name, "-"), ".")
+	return parts[:len(parts)-1], parts[len(parts)-1]
+}
+
+// findCommand returns the command at the given path below root
+func findCommand(path []string) (*cli.Cmd, error) {
+	cmd := root
+	for i, name := range path {
+		var next *cli.Cmd
+		for _, sub := range cmd.Commands {
+			if sub.Name == name {
+				next = sub
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("There is no \"datica %s\" command", strings.Join(path[:i+1], " "))
+		}
+		if !next.Initialized() {
+			if err := next.DoInit(); err != nil {
+				return nil, err
+			}
+		}
+		cmd = next
+	}
+	return cmd, nil
+}
+
+// anyHasOption reports whether cmd or any command below it has the option
+func anyHasOption(cmd *cli.Cmd, option string) (bool, error) {
+	if hasOption(cmd, option) {
+		return true, nil
+	}
+	for _, sub := range cmd.Commands {
+		if !sub.Initialized() {
+			if err := sub.DoInit(); err != nil {
+				return false, err
+			}
+		}
+		found, err := anyHasOption(sub, option)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// hasOption reports whether the command has an option with the given name
+func hasOption(cmd *cli.Cmd, option string) bool {
+	for _, names := range cmd.OptionNames() {
+		for _, n := range strings.Fields(names) {
+			if strings.TrimLeft(n, "-") == option {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// List returns the defaults keyed by name
+func (d *SDefaults) List() map[string]string {
+	return d.Settings.Defaults
+}
+
+// Rm removes the default with the given name
+func (d *SDefaults) Rm(name string) error {
+	if _, ok := d.Settings.Defaults[name]; !ok {
+		return fmt.Errorf("No default named \"%s\" has been set", name)
+	}
+	delete(d.Settings.Defaults, name)
+	return nil
+}
+
+// Set sets the default with the given name
+func (d *SDefaults) Set(name, value string) error {
+	if d.Settings.Defaults == nil {
+		d.Settings.Defaults = map[string]string{}
+	}
+	d.Settings.Defaults[name] = value
+	return nil
+}
@@ -0,0 +1,130 @@
+package defaults
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+	"github.com/jault3/mow.cli"
+)
+
+// testApp returns an app with a logs command and an environments list command
+// whose options are stored in the given map when they run
+func testApp(got map[string]string) *cli.Cli {
+	app := cli.App("datica", "")
+	timezone := app.StringOpt("timezone", "", "")
+	app.Command("logs", "", func(cmd *cli.Cmd) {
+		service := cmd.StringArg("SERVICE_NAME", "", "")
+		follow := cmd.BoolOpt("f follow", false, "")
+		json := config.JSONOpt(cmd.BoolOpt("json", false, ""))
+		targets := cmd.StringsOpt("target", nil, "")
+		cmd.Action = func() {
+			got["service"] = *service
+			got["target"] = strings.Join(*targets, ",")
+			got["follow"] = boolString(*follow)
+			got["json"] = boolString(*json)
+			got["json-output"] = boolString(config.JSONOutput())
+			got["timezone"] = *timezone
+		}
+		cmd.Spec = "SERVICE_NAME [-f] [--json] [--target...]"
+	})
+	app.Command("environments", "", func(cmd *cli.Cmd) {
+		cmd.Command("list", "", func(subCmd *cli.Cmd) {
+			allPods := subCmd.BoolOpt("all-pods", false, "")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, ""))
+			subCmd.Action = func() {
+				got["all-pods"] = boolString(*allPods)
+				got["json"] = boolString(*json)
+				got["json-output"] = boolString(config.JSONOutput())
+			}
+		})
+	})
+	return app
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+var setTests = []struct {
+	name       string
+	value      string
+	storedName string
+	expectErr  bool
+}{
+	{"logs.follow", "true", "logs.follow", false},
+	{"environments.list.all-pods", "true", "environments.list.all-pods", false},
+	{"json", "true", "json", false},
+	{"--timezone", "UTC", "timezone", false},
+	{"logs.all-pods", "true", "", true},
+	{"services.list.json", "true", "", true},
+	{"missing", "true", "", true},
+	{"logs.", "true", "", true},
+}
+
+func TestSet(t *testing.T) {
+	SetRoot(testApp(map[string]string{}).Cmd)
+	defer SetRoot(nil)
+	for _, data := range setTests {
+		t.Logf("Data: %+v", data)
+		settings := &models.Settings{}
+
+		// test
+		err := CmdSet(data.name, data.value, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if !data.expectErr {
+			test.AssertEquals(t, data.value, settings.Defaults[data.storedName])
+		}
+	}
+}
+
+var runTests = []struct {
+	args     []string
+	expected map[string]string
+}{
+	{[]string{"logs", "app01"}, map[string]string{"service": "app01", "follow": "true", "json": "true", "json-output": "true", "timezone": "UTC", "target": "worker"}},
+	{[]string{"logs", "app01", "--target", "web"}, map[string]string{"target": "web"}},
+	{[]string{"logs", "app01", "--follow=false"}, map[string]string{"service": "app01", "follow": "false", "json": "true", "timezone": "UTC"}},
+	{[]string{"--timezone", "America/Chicago", "logs", "app01", "-f"}, map[string]string{"service": "app01", "follow": "true", "json": "true", "timezone": "America/Chicago"}},
+	{[]string{"environments", "list"}, map[string]string{"all-pods": "false", "json": "false", "json-output": "false"}},
+}
+
+func TestDefaultsApplied(t *testing.T) {
+	defaults := map[string]string{
+		"logs.follow":            "true",
+		"logs.target":            "worker",
+		"json":                   "true",
+		"environments.list.json": "false",
+		"timezone":               "UTC",
+	}
+	cli.OptionDefaults = func(path []string) map[string]string {
+		return Resolve(defaults, path[1:])
+	}
+	defer func() { cli.OptionDefaults = nil }()
+	for _, data := range runTests {
+		t.Logf("Data: %+v", data)
+		got := map[string]string{}
+
+		// test
+		err := testApp(got).Run(append([]string{"datica"}, data.args...))
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for key, value := range data.expected {
+			test.AssertEquals(t, value, got[key])
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/builds"
+	"github.com/daticahealth/cli/commands/releases"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "deploy",
+	ShortHelp: "Deploy code services from a local git repo",
+	LongHelp: "`deploy` pushes the code in the local git repo in the current directory to a code service, the same as a `git push` to the service's git remote. " +
+		"In a monorepo, the directory each code service is built from is mapped in the `.datica.yml` file in the root of the repo along with the services each one depends on\n\n" +
+		"```\nservices:\n  api:\n    path: services/api\n  web:\n    path: services/web\n    depends_on:\n    - api\n```\n\n" +
+		"Only the mapped directory is pushed to the service. " +
+		"With `--all-changed`, every mapped service whose directory changed since its current release is deployed. " +
+		"Services are deployed one at a time so that a service is only deployed after the services it depends on. " +
+		"If a deploy fails, the services that depend on it are skipped. " +
+		"A table with the result for every mapped service is printed once all deploys finish. " +
+		"Use `--no-cache` to clear the build cache of each service before it is deployed, see [builds cache clear](#builds-cache-clear). " +
+		"Once the build finishes, the running jobs of the service are stopped before the new ones start. " +
+		"Use `--strategy rolling` to replace them one at a time instead, so that a service with more than one job keeps serving during the deploy. " +
+		"The strategy is sent with the push as a git push option. " +
+		"The command exits once the code is pushed. Use `--follow` to print the output of the build the push starts and wait until its deploy is running, the same as [deploy watch](#deploy-watch). " +
+		"With `--all-changed`, `--follow` waits for each service before the next one is deployed, so a service is only deployed once the services it depends on are running. " +
+		"`--detach` keeps the default. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" deploy app01\n" +
+		"datica -E \"<your_env_alias>\" deploy app01 --no-cache\n" +
+		"datica -E \"<your_env_alias>\" deploy app01 --strategy rolling\n" +
+		"datica -E \"<your_env_alias>\" deploy app01 --follow\n" +
+		"datica -E \"<your_env_alias>\" deploy --all-changed\n```",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(WatchSubCmd.Name, WatchSubCmd.ShortHelp, help.Render(WatchSubCmd.LongHelp), WatchSubCmd.CmdFunc(settings))
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the code service to deploy. Defaults to the service pinned by the workspace file, or else the associated service.")
+			allChanged := cmd.BoolOpt("all-changed", false, "Deploy every service mapped in the workspace file whose code changed since its current release")
+			noCache := cmd.BoolOpt("no-cache", false, "Clear the build cache of each service before it is deployed so that every dependency is downloaded again")
+			strategy := cmd.StringOpt("strategy", "", "How the running jobs are replaced once the build finishes, either 'rolling' or 'recreate'. Defaults to 'recreate'")
+			cmd.BoolOpt("detach", false, "Exit once the code has been pushed instead of waiting for the build and deploy to finish. This is the default")
+			follow := cmd.BoolOpt("follow", false, "Wait for the build and deploy started by the push to finish before exiting")
+			cmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				workspace, path, err := config.FindWorkspace(".")
+				if err != nil {
+					logrus.Fatalf("Could not read the workspace file %s: %s", path, err)
+				}
+				svcName := config.ServiceName(*serviceName, settings)
+				if svcName == "" && !*allChanged {
+					if svcName, err = associatedServiceLabel(settings, services.New(settings)); err != nil {
+						logrus.Fatal(err.Error())
+					}
+				}
+				err = CmdDeploy(svcName, *allChanged, *noCache, *follow, *strategy, workspace, path, New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[SERVICE_NAME | --all-changed] [--no-cache] [--strategy] [--detach | --follow]"
+		}
+	},
+}
+
+var WatchSubCmd = models.Command{
+	Name:      "watch",
+	ShortHelp: "Follow the build and deploy of a code service after a git push",
+	LongHelp: "`deploy watch` attaches to the build of a code service and prints its output as it runs, followed by every status change of the deploy the build starts, until the new release is running. " +
+		"If no build is in progress, it waits for the next one, so it can be started before or right after a `git push`. " +
+		"The command fails if the build or deploy does not succeed. " +
+		"On pods that do not stream build output, only the status changes are printed. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" deploy watch app01\n" +
+		"git push datica master && datica -E \"<your_env_alias>\" deploy watch app01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service to watch. Defaults to the service pinned by the workspace file, or else the associated service.")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				svcName := config.ServiceName(*serviceName, settings)
+				if svcName == "" {
+					var err error
+					if svcName, err = associatedServiceLabel(settings, services.New(settings)); err != nil {
+						logrus.Fatal(err.Error())
+					}
+				}
+				err := CmdWatch(svcName, New(settings), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME]"
+		}
+	},
+}
+
+// associatedServiceLabel returns the label of the code service associated
+// with the local git repo
+func associatedServiceLabel(settings *models.Settings, is services.IServices) (string, error) {
+	service, err := is.Retrieve(settings.ServiceID)
+	if err != nil {
+		return "", err
+	}
+	return service.Label, nil
+}
+
+// IDeploy
+type IDeploy interface {
+	Tree(root, path, rev string) (string, error)
+	Push(root, path, remote, strategy string) error
+	BuildOutput(jobID, svcID string, offset int) (*models.BuildOutput, error)
+}
+
+// SDeploy is a concrete implementation of IDeploy
+type SDeploy struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IDeploy
+func New(settings *models.Settings) IDeploy {
+	return &SDeploy{
+		Settings: settings,
+	}
+}
+
+// Tree returns the ID of the git tree at the given path of the given revision
+// in the git repo at root.
+func (d *SDeploy) Tree(root, path, rev string) (string, error) {
+	object := rev + "^{tree}"
+	if !isRoot(path) {
+		object = fmt.Sprintf("%s:%s", rev, strings.Trim(path, "/"))
+	}
+	out, err := exec.Command("git", "-C", root, "rev-parse", "--verify", "--quiet", object).Output()
+	if err != nil {
+		return "", fmt.Errorf("Could not find %s in the git repo at %s", object, root)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Push pushes the HEAD of the git repo at root to the master branch of the
+// given remote. When path is a subdirectory, only the history of that
+// subdirectory is pushed. A deploy strategy is sent as a git push option,
+// which the remote passes on to the deploy job the push starts.
+func (d *SDeploy) Push(root, path, remote, strategy string) error {
+	rev := "HEAD"
+	if !isRoot(path) {
+		out, err := exec.Command("git", "-C", root, "subtree", "split", "--prefix", strings.Trim(path, "/"), "HEAD").Output()
+		if err != nil {
+			return fmt.Errorf("Could not split %s out of the git repo at %s: %s", path, root, err)
+		}
+		rev = strings.TrimSpace(string(out))
+	}
+	args := []string{"-C", root, "push"}
+	if strategy != "" {
+		args = append(args, "-o", fmt.Sprintf("strategy=%s", strategy))
+	}
+	cmd := exec.Command("git", append(args, remote, rev+":refs/heads/master")...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func isRoot(path string) bool {
+	path = strings.Trim(path, "/")
+	return path == "" || path == "."
+}
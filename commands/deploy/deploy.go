@@ -0,0 +1,243 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/builds"
+	"github.com/daticahealth/cli/commands/releases"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdDeploy(svcName string, allChanged, noCache, follow bool, strategy string, workspace *models.Workspace, workspacePath string, id IDeploy, ib builds.IBuilds, ir releases.IReleases, is services.IServices, ij jobs.IJobs) error {
+	if err := jobs.ValidateStrategy(strategy); err != nil {
+		return err
+	}
+	root := "."
+	if workspacePath != "" {
+		root = filepath.Dir(workspacePath)
+	}
+	if !allChanged {
+		path := ""
+		if workspace != nil {
+			path = workspace.Services[svcName].Path
+		}
+		service, err := is.RetrieveByLabel(svcName)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+		}
+		if service.Source == "" {
+			return fmt.Errorf("No git remote found for the \"%s\" service.", svcName)
+		}
+		if noCache {
+			if err = ib.ClearCache(service.ID); err != nil {
+				return fmt.Errorf("Failed to clear the build cache of %s: %s", svcName, err)
+			}
+		}
+		logrus.Printf("Deploying %s to %s", displayPath(path), svcName)
+		if err = push(service, root, path, strategy, follow, id, ij); err != nil {
+			return fmt.Errorf("Failed to deploy %s: %s", svcName, err)
+		}
+		if !follow {
+			logrus.Printf("Deploy successful! Follow the build with \"datica deploy watch %s\" or check the status with \"datica status\"", svcName)
+		}
+		return nil
+	}
+
+	if workspace == nil || len(workspace.Services) == 0 {
+		return errors.New("No services are mapped in a workspace file. Add a \"services\" section to the .datica.yml file in the root of your git repo")
+	}
+	order, err := deployOrder(workspace.Services)
+	if err != nil {
+		return err
+	}
+	failed := map[string]bool{}
+	data := [][]string{{"Service", "Path", "Current Release", "Result"}}
+	for _, label := range order {
+		ws := workspace.Services[label]
+		release, result := "", ""
+		for _, dep := range ws.DependsOn {
+			if failed[dep] {
+				result = fmt.Sprintf("skipped, %s was not deployed", dep)
+				break
+			}
+		}
+		if result == "" {
+			release, result = deployIfChanged(label, root, ws.Path, noCache, follow, strategy, id, ib, ir, is, ij)
+		}
+		if result != "deployed" && result != "unchanged" {
+			failed[label] = true
+		}
+		data = append(data, []string{label, displayPath(ws.Path), release, result})
+	}
+
+	logrus.Println()
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.Render()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d services were not deployed", len(failed), len(order))
+	}
+	return nil
+}
+
+// deployIfChanged deploys the given service if the code at path differs from
+// the code of the service's current release. The name of the current release
+// and the result of the deploy are returned.
+func deployIfChanged(label, root, path string, noCache, follow bool, strategy string, id IDeploy, ib builds.IBuilds, ir releases.IReleases, is services.IServices, ij jobs.IJobs) (string, string) {
+	service, err := is.RetrieveByLabel(label)
+	if err != nil {
+		return "", fmt.Sprintf("failed: %s", err)
+	}
+	if service == nil {
+		return "", "failed: service not found"
+	}
+	if service.Source == "" {
+		return "", "failed: no git remote"
+	}
+	current, err := currentRelease(service, ir)
+	if err != nil {
+		return "", fmt.Sprintf("failed: %s", err)
+	}
+	name := ""
+	if current != nil {
+		name = current.Name
+		if current.GitSHA != "" {
+			head, err := id.Tree(root, path, "HEAD")
+			if err != nil {
+				return name, fmt.Sprintf("failed: %s", err)
+			}
+			deployed, err := id.Tree(root, "", current.GitSHA)
+			if err != nil {
+				logrus.Debugf("Treating %s as changed: %s", label, err)
+			} else if head == deployed {
+				return name, "unchanged"
+			}
+		}
+	}
+	if noCache {
+		if err = ib.ClearCache(service.ID); err != nil {
+			return name, fmt.Sprintf("failed: could not clear the build cache: %s", err)
+		}
+	}
+	logrus.Printf("Deploying %s to %s", displayPath(path), label)
+	if err = push(service, root, path, strategy, follow, id, ij); err != nil {
+		return name, fmt.Sprintf("failed: %s", err)
+	}
+	return name, "deployed"
+}
+
+// push pushes the code at path to the service. With follow, it then watches
+// the build the push starts and the deploy of that build, and fails unless
+// the new release is running.
+func push(service *models.Service, root, path, strategy string, follow bool, id IDeploy, ij jobs.IJobs) error {
+	if !follow {
+		return id.Push(root, path, service.Source, strategy)
+	}
+	// the latest jobs are looked up before the push so that the ones it
+	// starts can be told apart from them
+	previousBuild, err := latestJob(service.ID, "build", ij)
+	if err != nil {
+		return err
+	}
+	previousDeploy, err := latestJob(service.ID, "deploy", ij)
+	if err != nil {
+		return err
+	}
+	if err = id.Push(root, path, service.Source, strategy); err != nil {
+		return err
+	}
+	logrus.Printf("Waiting for the build of %s to start", service.Label)
+	build, err := waitForNewJob(service.ID, "build", previousBuild, ij)
+	if err != nil {
+		return err
+	}
+	return watch(build, previousDeploy, service, id, ij)
+}
+
+// currentRelease returns the release the given service is running, or nil if
+// the service has never been deployed
+func currentRelease(service *models.Service, ir releases.IReleases) (*models.Release, error) {
+	rls, err := ir.List(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rls == nil || len(*rls) == 0 {
+		return nil, nil
+	}
+	for _, r := range *rls {
+		if r.Name == service.ReleaseVersion {
+			return &r, nil
+		}
+	}
+	sort.Sort(releases.SortedReleases(*rls))
+	return &(*rls)[0], nil
+}
+
+// deployOrder returns the labels of the given services sorted so that every
+// service comes after the services it depends on
+func deployOrder(svcs map[string]models.WorkspaceService) ([]string, error) {
+	labels := []string{}
+	for label := range svcs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	order := []string{}
+	var visit func(label string) error
+	visit = func(label string) error {
+		switch state[label] {
+		case visiting:
+			return fmt.Errorf("The services in the workspace file depend on each other in a cycle through %s", label)
+		case visited:
+			return nil
+		}
+		state[label] = visiting
+		deps := append([]string{}, svcs[label].DependsOn...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := svcs[dep]; !ok {
+				return fmt.Errorf("%s depends on %s which is not mapped in the workspace file", label, dep)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[label] = visited
+		order = append(order, label)
+		return nil
+	}
+	for _, label := range labels {
+		if err := visit(label); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func displayPath(path string) string {
+	if isRoot(path) {
+		return "."
+	}
+	return path
+}
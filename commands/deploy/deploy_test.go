@@ -0,0 +1,246 @@
+package deploy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/builds"
+	"github.com/daticahealth/cli/commands/releases"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var deployOrderTests = []struct {
+	services  map[string]models.WorkspaceService
+	expected  string
+	expectErr bool
+}{
+	{map[string]models.WorkspaceService{"web": {DependsOn: []string{"api"}}, "api": {}}, "api,web", false},
+	{map[string]models.WorkspaceService{"a": {DependsOn: []string{"c"}}, "b": {}, "c": {DependsOn: []string{"b"}}}, "b,c,a", false},
+	{map[string]models.WorkspaceService{"a": {DependsOn: []string{"b"}}, "b": {DependsOn: []string{"a"}}}, "", true},
+	{map[string]models.WorkspaceService{"a": {DependsOn: []string{"missing"}}}, "", true},
+}
+
+func TestDeployOrder(t *testing.T) {
+	for _, data := range deployOrderTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		order, err := deployOrder(data.services)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if err == nil {
+			test.AssertEquals(t, data.expected, strings.Join(order, ","))
+		}
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		t.Fatalf("git %s failed: %s", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestDeployAllChanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repo := filepath.Join(dir, "repo")
+	remotes := map[string]string{}
+	for _, label := range []string{test.SvcLabel, test.SvcLabelAlt} {
+		remotes[label] = filepath.Join(dir, label+".git")
+		git(t, dir, "init", "--bare", "-q", remotes[label])
+		os.MkdirAll(filepath.Join(repo, label), 0755)
+		ioutil.WriteFile(filepath.Join(repo, label, "main.txt"), []byte(label+"\n"), 0644)
+	}
+	git(t, repo, "init", "-q")
+	git(t, repo, "add", ".")
+	git(t, repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+	deployedSHA := git(t, repo, "subtree", "split", "--prefix", test.SvcLabel, "HEAD")
+
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","source":"%s","release_version":"v1"},{"id":"%s","label":"%s","source":"%s"}]`, test.SvcID, test.SvcLabel, remotes[test.SvcLabel], test.SvcIDAlt, test.SvcLabelAlt, remotes[test.SvcLabelAlt])
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/releases",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"release":"v1","git_sha":"%s"}]`, deployedSHA)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcIDAlt+"/releases",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[]`)
+		},
+	)
+
+	workspace := &models.Workspace{
+		Services: map[string]models.WorkspaceService{
+			test.SvcLabel:    {Path: test.SvcLabel},
+			test.SvcLabelAlt: {Path: test.SvcLabelAlt, DependsOn: []string{test.SvcLabel}},
+		},
+	}
+	err = CmdDeploy("", true, false, false, "", workspace, filepath.Join(repo, ".datica.yml"), New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if out, _ := exec.Command("git", "-C", remotes[test.SvcLabel], "rev-parse", "--verify", "--quiet", "master").Output(); len(out) != 0 {
+		t.Errorf("Expected the unchanged service %s not to be deployed", test.SvcLabel)
+	}
+	test.AssertEquals(t, git(t, repo, "rev-parse", "HEAD:"+test.SvcLabelAlt), git(t, remotes[test.SvcLabelAlt], "rev-parse", "master^{tree}"))
+
+	cleared := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/build-cache",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			cleared = true
+		},
+	)
+	err = CmdDeploy(test.SvcLabel, false, true, false, "", workspace, filepath.Join(repo, ".datica.yml"), New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !cleared {
+		t.Error("Expected the build cache to be cleared before deploying with --no-cache")
+	}
+	test.AssertEquals(t, git(t, repo, "rev-parse", "HEAD:"+test.SvcLabel), git(t, remotes[test.SvcLabel], "rev-parse", "master^{tree}"))
+
+	workspace.Services[test.SvcLabelAlt] = models.WorkspaceService{Path: "missing", DependsOn: []string{test.SvcLabel}}
+	err = CmdDeploy("", true, false, false, "", workspace, filepath.Join(repo, ".datica.yml"), New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+	if err == nil {
+		t.Error("Expected an error when a service fails to deploy")
+	}
+
+	err = CmdDeploy(test.SvcLabelAlt, false, false, false, "blue-green", nil, "", New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+	if err == nil {
+		t.Error("Expected an error for an invalid strategy")
+	}
+	// the remote records the push options it receives
+	optionsPath := filepath.Join(dir, "push-options")
+	git(t, remotes[test.SvcLabelAlt], "config", "receive.advertisePushOptions", "true")
+	hook := fmt.Sprintf("#!/bin/sh\necho \"$GIT_PUSH_OPTION_0\" > %s\n", optionsPath)
+	ioutil.WriteFile(filepath.Join(remotes[test.SvcLabelAlt], "hooks", "pre-receive"), []byte(hook), 0755)
+	ioutil.WriteFile(filepath.Join(repo, test.SvcLabelAlt, "main.txt"), []byte("changed\n"), 0644)
+	git(t, repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-a", "-m", "change")
+	workspace.Services[test.SvcLabelAlt] = models.WorkspaceService{Path: test.SvcLabelAlt}
+	err = CmdDeploy(test.SvcLabelAlt, false, false, false, "rolling", workspace, filepath.Join(repo, ".datica.yml"), New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	options, _ := ioutil.ReadFile(optionsPath)
+	test.AssertEquals(t, "strategy=rolling", strings.TrimSpace(string(options)))
+}
+
+var deployFollowTests = []struct {
+	buildStatus  string
+	deployStatus string
+	expectErr    bool
+}{
+	{"finished", "running", false},
+	{"failed", "running", true},
+	{"finished", "failed", true},
+}
+
+func TestDeployFollow(t *testing.T) {
+	watchInterval = time.Millisecond
+	dir, err := ioutil.TempDir("", "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repo := filepath.Join(dir, "repo")
+	remote := filepath.Join(dir, test.SvcLabel+".git")
+	git(t, dir, "init", "--bare", "-q", remote)
+	os.MkdirAll(repo, 0755)
+	ioutil.WriteFile(filepath.Join(repo, "main.txt"), []byte("main\n"), 0644)
+	git(t, repo, "init", "-q")
+	git(t, repo, "add", ".")
+	git(t, repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	for _, data := range deployFollowTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		var lock sync.Mutex
+		buildLists, buildPolls := 0, 0
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code","source":"%s"}]`, test.SvcID, test.SvcLabel, remote)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				lock.Lock()
+				defer lock.Unlock()
+				switch r.URL.Query().Get("type") {
+				case "build":
+					// the first lookup happens before the push
+					buildLists++
+					if buildLists == 1 {
+						fmt.Fprint(w, `[{"id":"build1","type":"build","status":"finished"}]`)
+					} else {
+						fmt.Fprint(w, `[{"id":"build2","type":"build","status":"queued"}]`)
+					}
+				case "deploy":
+					if buildPolls > 0 {
+						fmt.Fprintf(w, `[{"id":"deploy2","type":"deploy","status":"%s"}]`, data.deployStatus)
+					} else {
+						fmt.Fprint(w, `[{"id":"deploy1","type":"deploy","status":"running"}]`)
+					}
+				}
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/build2",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				lock.Lock()
+				buildPolls++
+				lock.Unlock()
+				fmt.Fprintf(w, `{"id":"build2","type":"build","status":"%s"}`, data.buildStatus)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/build2/output",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, `{"lines":[],"offset":0}`)
+			},
+		)
+
+		// test
+		err := CmdDeploy(test.SvcLabel, false, false, true, "", nil, filepath.Join(repo, ".datica.yml"), New(settings), builds.New(settings), releases.New(settings), services.New(settings), jobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, git(t, repo, "rev-parse", "HEAD^{tree}"), git(t, remote, "rev-parse", "master^{tree}"))
+		test.Teardown(server)
+	}
+}
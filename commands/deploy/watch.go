@@ -0,0 +1,188 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+)
+
+// watchInterval is the time to wait between checks of the build and deploy
+var watchInterval = config.JobPollTime * time.Second
+
+// pendingStatuses are the statuses of a build or deploy that has not
+// completed yet
+var pendingStatuses = map[string]bool{
+	"scheduled": true,
+	"queued":    true,
+	"started":   true,
+	"waiting":   true,
+}
+
+// CmdWatch follows the build of the service and the deploy started by it,
+// printing the build output and every change in the status of either until
+// the new release is running. If no build is in progress, it waits for the
+// next one to start, such as after a git push.
+func CmdWatch(svcName string, id IDeploy, is services.IServices, ij jobs.IJobs) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if service.Type != "code" {
+		return fmt.Errorf("%s is not a code service. Only code services are built and deployed from a git push.", svcName)
+	}
+	build, err := latestJob(service.ID, "build", ij)
+	if err != nil {
+		return err
+	}
+	previousDeploy, err := latestJob(service.ID, "deploy", ij)
+	if err != nil {
+		return err
+	}
+	if build == nil || !inProgress(build) {
+		logrus.Printf("Waiting for a build of %s to start. Push your code with \"git push\" to the service's git remote or with \"datica deploy %s\".", svcName, svcName)
+		build, err = waitForNewJob(service.ID, "build", build, ij)
+		if err != nil {
+			return err
+		}
+	}
+	return watch(build, previousDeploy, service, id, ij)
+}
+
+// watch follows the given build and the deploy it starts, which is the first
+// deploy created after previousDeploy, until the new release is running
+func watch(build, previousDeploy *models.Job, service *models.Service, id IDeploy, ij jobs.IJobs) error {
+	svcName := service.Label
+	logrus.Printf("Watching build %s of %s", build.ID, svcName)
+	if err := watchBuild(build, service, id, ij); err != nil {
+		return err
+	}
+
+	logrus.Println("Waiting for the deploy of the new build to start")
+	deploy, err := waitForNewJob(service.ID, "deploy", previousDeploy, ij)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Watching deploy %s of %s", deploy.ID, svcName)
+	status := ""
+	for {
+		if deploy.Status != status {
+			status = deploy.Status
+			logrus.Printf("[%s] Deploy is %s", config.FormatTimestamp(time.Now()), status)
+		}
+		if !pendingStatuses[status] {
+			break
+		}
+		time.Sleep(watchInterval)
+		deploy, err = ij.Retrieve(deploy.ID, service.ID, false)
+		if err != nil {
+			return err
+		}
+	}
+	if status != "running" {
+		return fmt.Errorf("The deploy of %s ended in status '%s'. Check your logging dashboard or run \"datica jobs describe %s %s\" for details.", svcName, status, svcName, deploy.ID)
+	}
+	logrus.Printf("%s is running the new build", svcName)
+	return nil
+}
+
+// watchBuild prints the output of the build and every change in its status
+// until it completes. An error is returned if the build does not finish
+// successfully.
+func watchBuild(build *models.Job, service *models.Service, id IDeploy, ij jobs.IJobs) error {
+	status := ""
+	offset := 0
+	streamOutput := true
+	for {
+		if build.Status != status {
+			status = build.Status
+			logrus.Printf("[%s] Build is %s", config.FormatTimestamp(time.Now()), status)
+		}
+		if streamOutput {
+			output, err := id.BuildOutput(build.ID, service.ID, offset)
+			if httpclient.IsMissingEndpoint(err) {
+				logrus.Warnln("The pod of this environment does not stream build output, only the status of the build and deploy is shown")
+				streamOutput = false
+			} else if err != nil {
+				return err
+			} else {
+				for _, line := range output.Lines {
+					logrus.Println(strings.TrimRight(line, "\r\n"))
+				}
+				offset = output.Offset
+			}
+		}
+		if !inProgress(build) {
+			break
+		}
+		time.Sleep(watchInterval)
+		var err error
+		build, err = ij.Retrieve(build.ID, service.ID, false)
+		if err != nil {
+			return err
+		}
+	}
+	if status != "finished" {
+		return fmt.Errorf("The build of %s ended in status '%s', so it will not be deployed", service.Label, status)
+	}
+	return nil
+}
+
+// inProgress returns whether the build or deploy job has not completed yet. A
+// running build is still in progress, while a running deploy is complete.
+func inProgress(job *models.Job) bool {
+	return pendingStatuses[job.Status] || (job.Type == "build" && job.Status == "running")
+}
+
+// latestJob returns the most recently created job of the given type, or nil
+// if the service has none
+func latestJob(svcID, jobType string, ij jobs.IJobs) (*models.Job, error) {
+	latest, err := ij.RetrieveByType(svcID, jobType, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || len(*latest) == 0 || (*latest)[0].ID == "" {
+		return nil, nil
+	}
+	return &(*latest)[0], nil
+}
+
+// waitForNewJob waits until a job of the given type other than previous is
+// created and returns it
+func waitForNewJob(svcID, jobType string, previous *models.Job, ij jobs.IJobs) (*models.Job, error) {
+	for {
+		job, err := latestJob(svcID, jobType, ij)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil && (previous == nil || job.ID != previous.ID) {
+			return job, nil
+		}
+		time.Sleep(watchInterval)
+	}
+}
+
+// BuildOutput returns the output of the build job starting at the given
+// offset
+func (d *SDeploy) BuildOutput(jobID, svcID string, offset int) (*models.BuildOutput, error) {
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	resp, statusCode, err := d.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs/%s/output?offset=%d", d.Settings.PaasHost, d.Settings.PaasHostVersion, d.Settings.EnvironmentID, svcID, jobID, offset), headers)
+	if err != nil {
+		return nil, err
+	}
+	var output models.BuildOutput
+	err = d.Settings.HTTPManager.ConvertResp(resp, statusCode, &output)
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
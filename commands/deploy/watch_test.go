@@ -0,0 +1,116 @@
+package deploy
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var watchTests = []struct {
+	buildStatuses  []string
+	deployStatuses []string
+	outputEndpoint bool
+	expectOutputs  string
+	expectErr      bool
+}{
+	{[]string{"queued", "running", "finished"}, []string{"scheduled", "running"}, true, "4", false},
+	{[]string{"running", "finished"}, []string{"running"}, false, "", false},
+	{[]string{"running", "failed"}, []string{"running"}, true, "3", true},
+	{[]string{"started", "finished"}, []string{"scheduled", "failed"}, true, "3", true},
+}
+
+func TestWatch(t *testing.T) {
+	watchInterval = time.Millisecond
+	for _, data := range watchTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		var lock sync.Mutex
+		buildPolls, deployPolls := 0, 0
+		lastOffset := ""
+		next := func(statuses []string, polls *int) string {
+			lock.Lock()
+			defer lock.Unlock()
+			status := statuses[len(statuses)-1]
+			if *polls < len(statuses) {
+				status = statuses[*polls]
+			}
+			*polls++
+			return status
+		}
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `[{"id":"%s","label":"%s","name":"code","type":"code"}]`, test.SvcID, test.SvcLabel)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				switch r.URL.Query().Get("type") {
+				case "build":
+					fmt.Fprintf(w, `[{"id":"build1","type":"build","status":"%s"}]`, data.buildStatuses[0])
+				case "deploy":
+					// the new deploy appears once the build is complete
+					lock.Lock()
+					done := buildPolls >= len(data.buildStatuses)
+					lock.Unlock()
+					if done {
+						fmt.Fprintf(w, `[{"id":"deploy2","type":"deploy","status":"%s"}]`, next(data.deployStatuses, &deployPolls))
+					} else {
+						fmt.Fprint(w, `[{"id":"deploy1","type":"deploy","status":"running"}]`)
+					}
+				}
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/build1",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `{"id":"build1","type":"build","status":"%s"}`, next(data.buildStatuses, &buildPolls))
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/deploy2",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `{"id":"deploy2","type":"deploy","status":"%s"}`, next(data.deployStatuses, &deployPolls))
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/build1/output",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				if !data.outputEndpoint {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				lock.Lock()
+				lastOffset = r.URL.Query().Get("offset")
+				lock.Unlock()
+				var offset int
+				fmt.Sscanf(lastOffset, "%d", &offset)
+				fmt.Fprintf(w, `{"lines":["line %d"],"offset":%d}`, offset+1, offset+1)
+			},
+		)
+
+		// test
+		err := CmdWatch(test.SvcLabel, New(settings), services.New(settings), jobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if data.outputEndpoint {
+			// the output is requested once for the listed build and once
+			// for every time the build is polled
+			var last int
+			fmt.Sscanf(lastOffset, "%d", &last)
+			test.AssertEquals(t, data.expectOutputs, fmt.Sprintf("%d", last+1))
+		}
+		test.Teardown(server)
+	}
+}
@@ -2,6 +2,8 @@ package disassociate
 
 import (
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/git"
+	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
 )
@@ -11,26 +13,51 @@ import (
 var Cmd = models.Command{
 	Name:      "disassociate",
 	ShortHelp: "Remove the association with an environment",
-	LongHelp: "`disassociate` removes the environment from your list of associated environments but **does not** remove the datica git remote on the git repo. " +
-		"Disassociate does not have to be run from within a git repo. Here is a sample command\n\n" +
-		"```\ndatica disassociate myprod\n```",
+	LongHelp: "`disassociate` removes the environment from your list of associated environments. " +
+		"By default the datica git remote on the git repo **is not** removed and disassociate does not have to be run from within a git repo. " +
+		"Use `--remove-remote` from within the git repo to also remove the git remote named by `--remote` and the legacy `catalyze` remote. " +
+		"Use `--all` instead of an alias to clear every association at once, such as when moving to a new machine or before associating again from scratch. " +
+		"Use `--dry-run` to see what would be removed without changing anything. " +
+		"You will be asked to confirm before the association is removed, use the global `--yes` flag to skip the confirmation. Here are some sample commands\n\n" +
+		"```\ndatica disassociate myprod\n" +
+		"datica disassociate myprod --remove-remote --dry-run\n" +
+		"datica disassociate myprod --remove-remote -r datica-prod\n" +
+		"datica disassociate --all\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			alias := cmd.StringArg("ENV_ALIAS", "", "The alias of an already associated environment to disassociate")
+			removeRemote := cmd.BoolOpt("remove-remote", false, "Also remove the git remotes of the environment from the git repo in the current directory")
+			remote := cmd.StringOpt("r remote", "datica", "The name of the git remote to remove with --remove-remote")
+			dryRun := cmd.BoolOpt("dry-run", false, "Print what would be removed without removing anything")
+			all := cmd.BoolOpt("all", false, "Disassociate every associated environment")
 			cmd.Action = func() {
-				err := CmdDisassociate(*alias, New(settings))
+				var err error
+				if *all {
+					if *alias != "" || *removeRemote {
+						logrus.Fatal("--all cannot be combined with an alias or --remove-remote")
+					}
+					err = CmdDisassociateAll(*dryRun, New(settings), prompts.New())
+				} else if *alias == "" {
+					logrus.Fatal("You must specify the alias of an associated environment or --all")
+				} else {
+					err = CmdDisassociate(*alias, *remote, *removeRemote, *dryRun, New(settings), git.New(), prompts.New())
+				}
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			cmd.Spec = "ENV_ALIAS"
+			cmd.Spec = "[ENV_ALIAS] [--all] [--remove-remote] [-r] [--dry-run]"
 		}
 	},
 }
 
 // IDisassociate
 type IDisassociate interface {
+	Aliases() []string
+	Associated(alias string) bool
 	Disassociate(alias string) error
+	DisassociateAll() error
 }
 
 // SDisassociate is a concrete implementation of IDisassociate
@@ -1,20 +1,153 @@
 package disassociate
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/git"
 	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
 )
 
-func CmdDisassociate(alias string, id IDisassociate) error {
-	err := id.Disassociate(alias)
+// legacyRemote is the name of the git remote added alongside the named remote
+// by older versions of the CLI
+const legacyRemote = "catalyze"
+
+// gitRemote is a git remote of the local repo to be removed
+type gitRemote struct {
+	name string
+	url  string
+}
+
+func CmdDisassociate(alias, remote string, removeRemote, dryRun bool, id IDisassociate, ig git.IGit, ip prompts.IPrompts) error {
+	if !id.Associated(alias) {
+		return fmt.Errorf("An environment named \"%s\" has not been associated. Run \"datica associated\" to see current associations.", alias)
+	}
+	var remotes []gitRemote
+	if removeRemote {
+		var err error
+		remotes, err = findRemotes(remote, ig)
+		if err != nil {
+			return err
+		}
+	}
+	if dryRun {
+		logrus.Println("Dry run, nothing will be removed.")
+		logrus.Printf("Would disassociate %s", alias)
+		for _, r := range remotes {
+			logrus.Printf("Would remove the git remote \"%s\" (%s)", r.name, r.url)
+		}
+		if removeRemote && len(remotes) == 0 {
+			logrus.Printf("No git remotes named \"%s\" or \"%s\" were found", remote, legacyRemote)
+		}
+		return nil
+	}
+
+	question := fmt.Sprintf("Are you sure you want to disassociate %s? (y/n) ", alias)
+	if len(remotes) > 0 {
+		names := []string{}
+		for _, r := range remotes {
+			names = append(names, fmt.Sprintf("\"%s\"", r.name))
+		}
+		question = fmt.Sprintf("Are you sure you want to disassociate %s and remove the git remotes %s? (y/n) ", alias, strings.Join(names, ", "))
+	}
+	err := ip.YesNo(question)
+	if err != nil {
+		return err
+	}
+	err = id.Disassociate(alias)
 	if err != nil {
 		return err
 	}
-	logrus.Warnln("Your existing git remote *has not* been removed. You must do this manually.")
+	if !removeRemote {
+		logrus.Warnln("Your existing git remote *has not* been removed. You must do this manually or run disassociate again with --remove-remote.")
+	}
+	for _, r := range remotes {
+		if err = ig.Rm(r.name); err != nil {
+			return fmt.Errorf("Association cleared but the git remote \"%s\" could not be removed: %s", r.name, err)
+		}
+		logrus.Printf("\"%s\" remote removed.", r.name)
+	}
 	logrus.Println("Association cleared.")
 	return nil
 }
 
+// CmdDisassociateAll removes every association after a single confirmation.
+// Git remotes are left in place.
+func CmdDisassociateAll(dryRun bool, id IDisassociate, ip prompts.IPrompts) error {
+	aliases := id.Aliases()
+	if len(aliases) == 0 {
+		logrus.Println("No environments have been associated.")
+		return nil
+	}
+	if dryRun {
+		logrus.Println("Dry run, nothing will be removed.")
+		logrus.Printf("Would disassociate %s", strings.Join(aliases, ", "))
+		return nil
+	}
+	err := ip.YesNo(fmt.Sprintf("Are you sure you want to disassociate all %d environments (%s)? (y/n) ", len(aliases), strings.Join(aliases, ", ")))
+	if err != nil {
+		return err
+	}
+	if err = id.DisassociateAll(); err != nil {
+		return err
+	}
+	logrus.Warnln("Your existing git remotes *have not* been removed. You must do this manually.")
+	logrus.Printf("Cleared %d associations. Run \"datica associate\" from each git repo to associate it again.", len(aliases))
+	return nil
+}
+
+// findRemotes returns the named git remote and the legacy catalyze remote
+// of the git repo in the current directory, whichever exist
+func findRemotes(remote string, ig git.IGit) ([]gitRemote, error) {
+	if !ig.Exists() {
+		return nil, errors.New("No git repo found in the current directory. Run disassociate with --remove-remote from within the git repo to remove its remotes.")
+	}
+	existing, err := ig.List()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{remote}
+	if remote != legacyRemote {
+		names = append(names, legacyRemote)
+	}
+	remotes := []gitRemote{}
+	for _, name := range names {
+		for _, r := range existing {
+			if r != name {
+				continue
+			}
+			url, err := ig.URL(name)
+			if err != nil {
+				return nil, err
+			}
+			remotes = append(remotes, gitRemote{name, url})
+		}
+	}
+	return remotes, nil
+}
+
+// Aliases returns the aliases of every associated environment in order
+func (d *SDisassociate) Aliases() []string {
+	aliases := []string{}
+	for alias := range d.Settings.Environments {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// Associated returns whether an environment is associated under the given
+// alias
+func (d *SDisassociate) Associated(alias string) bool {
+	_, ok := d.Settings.Environments[alias]
+	return ok
+}
+
 // Disassociate removes an existing association with the environment. The
 // `datica` remote on the local github repo will *NOT* be removed.
 func (d *SDisassociate) Disassociate(alias string) error {
@@ -22,3 +155,12 @@ func (d *SDisassociate) Disassociate(alias string) error {
 	// array for you
 	return config.DeleteBreadcrumb(alias, d.Settings)
 }
+
+// DisassociateAll removes every association along with the default
+// environment, which is one of them.
+func (d *SDisassociate) DisassociateAll() error {
+	d.Settings.Environments = map[string]models.AssociatedEnv{}
+	d.Settings.Default = ""
+	config.SaveSettings(d.Settings)
+	return nil
+}
@@ -1,8 +1,12 @@
 package disassociate
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"testing"
 
+	"github.com/daticahealth/cli/commands/git"
 	"github.com/daticahealth/cli/test"
 )
 
@@ -21,7 +25,7 @@ func TestDisassociate(t *testing.T) {
 		t.Logf("Data: %+v", data)
 
 		// test
-		err := CmdDisassociate(data.name, New(settings))
+		err := CmdDisassociate(data.name, "datica", false, false, New(settings), git.New(), &test.FakePrompts{})
 
 		// assert
 		if err != nil != data.expectErr {
@@ -34,3 +38,96 @@ func TestDisassociate(t *testing.T) {
 		}
 	}
 }
+
+func TestDisassociateRemoveRemote(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current working directory: %s", err)
+	}
+	defer os.Chdir(wd)
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Failed to make temp directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Failed to change working directory: %s", err)
+	}
+	settings := test.GetSettings("")
+	ig := git.New()
+	if err = CmdDisassociate(test.Alias, "datica", true, false, New(settings), ig, &test.FakePrompts{}); err == nil {
+		t.Fatal("Expected an error removing remotes outside of a git repo")
+	}
+
+	err = exec.Command("git", "init").Run()
+	if err != nil {
+		t.Fatalf("Failed to initialize a git directory: %s", err)
+	}
+	for _, remote := range []string{"datica", "catalyze", "origin"} {
+		if err = ig.Add(remote, "git@github.com/github/github.git"); err != nil {
+			t.Fatalf("Failed to add a git remote: %s", err)
+		}
+	}
+
+	// a dry run changes nothing
+	err = CmdDisassociate(test.Alias, "datica", true, true, New(settings), ig, &test.FakePrompts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, present := settings.Environments[test.Alias]; !present {
+		t.Fatal("Environment removed from settings by a dry run")
+	}
+	remotes, err := ig.List()
+	if err != nil {
+		t.Fatalf("Failed to list git remotes: %s", err)
+	}
+	if len(remotes) != 3 {
+		t.Fatalf("Unexpected git remotes found after a dry run: %s", remotes)
+	}
+
+	err = CmdDisassociate(test.Alias, "datica", true, false, New(settings), ig, &test.FakePrompts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, present := settings.Environments[test.Alias]; present {
+		t.Fatal("Environment not removed from settings")
+	}
+	remotes, err = ig.List()
+	if err != nil {
+		t.Fatalf("Failed to list git remotes: %s", err)
+	}
+	if len(remotes) != 1 || remotes[0] != "origin" {
+		t.Fatalf("Unexpected git remotes found: %s", remotes)
+	}
+}
+
+func TestDisassociateAll(t *testing.T) {
+	settings := test.GetSettings("")
+	settings.Default = test.Alias
+
+	// a dry run changes nothing
+	err := CmdDisassociateAll(true, New(settings), &test.FakePrompts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(settings.Environments) == 0 {
+		t.Fatal("Environments removed from settings by a dry run")
+	}
+
+	err = CmdDisassociateAll(false, New(settings), &test.FakePrompts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(settings.Environments) != 0 {
+		t.Errorf("Environments not removed from settings: %+v", settings.Environments)
+	}
+	test.AssertEquals(t, "", settings.Default)
+
+	// nothing left to disassociate
+	if err = CmdDisassociateAll(false, New(settings), &test.FakePrompts{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
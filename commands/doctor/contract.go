@@ -0,0 +1,67 @@
+package doctor
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/git"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "doctor",
+	ShortHelp: "Check that the CLI can reach the platform",
+	LongHelp: "`doctor` checks that the hosts the CLI talks to can be reached and behave like the platform. " +
+		"For each host, doctor prints where the host was configured, either from a global flag or environment variable, from the [hosts](#hosts) command, or the default. " +
+		"The PaaS host is checked by listing its pods and making sure the pod of the associated environment is one of them. " +
+		"If you are signed in, the auth host is checked by verifying your session, and every associated environment and code service is checked to still exist. " +
+		"When run from within a git repo, doctor also checks that the `datica` and `catalyze` git remotes push to associated code services and that the associated environment has a git remote. " +
+		"Every failed check is followed by a suggested fix. " +
+		"If a client certificate is used for mutual TLS, its path is printed as well. " +
+		"This is most useful after pointing the CLI at a self-hosted installation. " +
+		"With `--tls`, doctor instead reports the TLS versions and cipher suites the CLI supports, the TLS connection negotiated with each host, and whether those connections meet the TLS requirements of the pod, including requirements announced for a future cutover date. " +
+		"Connections can fall short of them when a proxy inspects TLS traffic. Here are some sample commands\n\n" +
+		"```\ndatica doctor\n" +
+		"datica -E \"<your_env_alias>\" doctor\n" +
+		"datica -E \"<your_env_alias>\" doctor --tls\n```",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			tlsOnly := cmd.BoolOpt("tls", false, "Check the TLS connections to the hosts against the TLS requirements of the pod")
+			cmd.Action = func() {
+				var err error
+				if *tlsOnly {
+					err = CmdDoctorTLS(settings, New(settings), pods.New(settings))
+				} else {
+					err = CmdDoctor(settings, New(settings), pods.New(settings), auth.New(settings, prompts.New()), git.New())
+				}
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[--tls]"
+		}
+	},
+}
+
+// IDoctor
+type IDoctor interface {
+	Association(alias string) (*models.Environment, *models.Service, error)
+	Reachable(host string) error
+}
+
+// SDoctor is a concrete implementation of IDoctor
+type SDoctor struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IDoctor
+func New(settings *models.Settings) IDoctor {
+	return &SDoctor{
+		Settings: settings,
+	}
+}
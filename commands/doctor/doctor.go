@@ -0,0 +1,200 @@
+package doctor
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/git"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdDoctor(settings *models.Settings, id IDoctor, ip pods.IPods, ia auth.IAuth, ig git.IGit) error {
+	override := config.HostOverride(settings, settings.Pod)
+	hosts := []struct {
+		name       string
+		host       string
+		override   string
+		defaultVal string
+	}{
+		{"Accounts", settings.AccountsHost, override.AccountsHost, config.AccountsHost},
+		{"Auth", settings.AuthHost, override.AuthHost, config.AuthHost},
+		{"PaaS", settings.PaasHost, override.PaasHost, config.PaasHost},
+	}
+	failures := 0
+	for _, h := range hosts {
+		source := "flag or environment variable"
+		if h.host == h.override {
+			source = "the \"datica hosts\" command"
+		} else if h.host == h.defaultVal {
+			source = "default"
+		}
+		logrus.Printf("%s host: %s (from %s)", h.name, h.host, source)
+		if err := id.Reachable(h.host); err != nil {
+			logrus.Printf("    FAILED: %s", err)
+			logrus.Println("    FIX: check your network connection and proxy, or the host set with \"datica hosts set\"")
+			failures++
+		} else {
+			logrus.Println("    OK: reachable")
+		}
+	}
+
+	if settings.ClientCert != "" {
+		logrus.Printf("Client certificate: %s", settings.ClientCert)
+	}
+
+	logrus.Println("PaaS pods:")
+	podList, err := ip.List()
+	if err != nil {
+		logrus.Printf("    FAILED: could not list pods: %s", err)
+		logrus.Println("    FIX: make sure the PaaS host above is reachable")
+		failures++
+	} else if settings.Pod != "" && !hasPod(podList, settings.Pod) {
+		logrus.Printf("    FAILED: the %s pod of the %s environment is not served by %s", settings.Pod, settings.EnvironmentName, settings.PaasHost)
+		logrus.Printf("    FIX: point the CLI at the PaaS host of the %s pod with \"datica hosts set %s --paas-host\"", settings.Pod, settings.Pod)
+		failures++
+	} else {
+		logrus.Printf("    OK: %d pods available", len(*podList))
+	}
+
+	logrus.Println("Auth session:")
+	if settings.SessionToken == "" {
+		logrus.Println("    SKIPPED: not signed in")
+	} else if _, err := ia.Verify(); err != nil {
+		logrus.Printf("    FAILED: your session could not be verified: %s", err)
+		logrus.Println("    FIX: run \"datica logout\" and sign in again with any command")
+		failures++
+	} else {
+		logrus.Println("    OK: session verified")
+	}
+
+	failures += checkAssociations(settings, id, ig)
+
+	if failures > 0 {
+		return fmt.Errorf("%d checks failed", failures)
+	}
+	logrus.Println("All checks passed")
+	return nil
+}
+
+// checkAssociations checks that every associated environment and code
+// service still exists and that the git remotes of the git repo in the
+// current directory push to associated code services. It returns the number
+// of failed checks.
+func checkAssociations(settings *models.Settings, id IDoctor, ig git.IGit) int {
+	logrus.Println("Associations:")
+	if len(settings.Environments) == 0 {
+		logrus.Println("    SKIPPED: no environments have been associated")
+		return 0
+	}
+	if settings.SessionToken == "" {
+		logrus.Println("    SKIPPED: not signed in")
+		return 0
+	}
+	aliases := []string{}
+	for alias := range settings.Environments {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	failures := 0
+	// the git URLs of the associated code services
+	sources := map[string]string{}
+	for _, alias := range aliases {
+		env, svc, err := id.Association(alias)
+		if err != nil {
+			logrus.Printf("    FAILED: %s could not be found: %s", alias, err)
+			logrus.Printf("    FIX: if the environment or its code service was deleted or you lost access, run \"datica disassociate %s\" and associate again", alias)
+			failures++
+			continue
+		}
+		logrus.Printf("    OK: %s is the %s service of the %s environment", alias, svc.Label, env.Name)
+		if svc.Source != "" {
+			sources[svc.Source] = alias
+		}
+	}
+
+	// remotes can't be told apart from remotes of missing associations
+	lookupFailed := failures > 0
+	logrus.Println("Git remotes:")
+	if !ig.Exists() {
+		logrus.Println("    SKIPPED: not in a git repo")
+		return failures
+	}
+	remotes, err := ig.List()
+	if err != nil {
+		logrus.Printf("    FAILED: could not list git remotes: %s", err)
+		return failures + 1
+	}
+	pushed := map[string]bool{}
+	for _, remote := range remotes {
+		url, err := ig.URL(remote)
+		if err != nil {
+			continue
+		}
+		if alias, ok := sources[url]; ok {
+			pushed[alias] = true
+			logrus.Printf("    OK: \"%s\" pushes to %s", remote, alias)
+		} else if (remote == "datica" || remote == "catalyze") && lookupFailed {
+			logrus.Printf("    SKIPPED: \"%s\" could not be checked since an association could not be found", remote)
+		} else if remote == "datica" || remote == "catalyze" {
+			logrus.Printf("    FAILED: \"%s\" (%s) does not push to any associated code service", remote, url)
+			logrus.Printf("    FIX: run \"git remote remove %s\" or associate this repo again with \"datica associate\"", remote)
+			failures++
+		}
+	}
+	if alias := settings.EnvironmentName; alias != "" && !pushed[alias] {
+		if _, ok := settings.Environments[alias]; ok && !lookupFailed {
+			logrus.Printf("    FAILED: no git remote pushes to %s", alias)
+			logrus.Printf("    FIX: run \"datica -E %s git-remote add <code_service>\" to add one", alias)
+			failures++
+		}
+	}
+	return failures
+}
+
+func hasPod(podList *[]models.Pod, name string) bool {
+	if podList == nil {
+		return false
+	}
+	for _, p := range *podList {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Reachable makes sure the given host is a valid URL and responds to requests
+func (d *SDoctor) Reachable(host string) error {
+	u, err := url.Parse(host)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("\"%s\" is not a valid URL", host)
+	}
+	if u.Scheme == "http" {
+		logrus.Warnf("%s does not use https, your credentials will be sent unencrypted", host)
+	}
+	headers := d.Settings.HTTPManager.GetHeaders(d.Settings.SessionToken, d.Settings.Version, d.Settings.Pod, d.Settings.UsersID)
+	_, _, err = d.Settings.HTTPManager.Get(nil, host, headers)
+	return err
+}
+
+// Association retrieves the environment and code service associated under
+// the given alias
+func (d *SDoctor) Association(alias string) (*models.Environment, *models.Service, error) {
+	s := config.EnvironmentSettings(alias, d.Settings)
+	env, err := environments.New(s).Retrieve(s.EnvironmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc, err := services.New(s).Retrieve(s.ServiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return env, svc, nil
+}
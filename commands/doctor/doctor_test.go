@@ -0,0 +1,129 @@
+package doctor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/git"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/test"
+)
+
+var doctorTests = []struct {
+	pod       string
+	expectErr bool
+}{
+	{test.Pod, false},
+	{"missing-pod", true},
+}
+
+func TestDoctor(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AccountsHost = baseURL.String()
+	settings.AuthHost = baseURL.String()
+	settings.SessionToken = ""
+	mux.HandleFunc("/pods",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `{"pods":[{"name":"%s"},{"name":"%s"}]}`, test.Pod, test.PodAlt)
+		},
+	)
+
+	for _, data := range doctorTests {
+		t.Logf("Data: %+v", data)
+		settings.Pod = data.pod
+
+		// test
+		err := CmdDoctor(settings, New(settings), pods.New(settings), auth.New(settings, prompts.New()), git.New())
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+}
+
+var associationTests = []struct {
+	remoteURL string
+	envStatus int
+	expectErr bool
+}{
+	{"git@example.com:code1.git", http.StatusOK, false},
+	{"git@example.com:other.git", http.StatusOK, true},
+	{"git@example.com:code1.git", http.StatusNotFound, true},
+}
+
+func TestDoctorAssociations(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current working directory: %s", err)
+	}
+	defer os.Chdir(wd)
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Failed to make temp directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change working directory: %s", err)
+	}
+	if err = exec.Command("git", "init").Run(); err != nil {
+		t.Fatalf("Failed to initialize a git directory: %s", err)
+	}
+
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AccountsHost = baseURL.String()
+	settings.AuthHost = baseURL.String()
+	settings.Pod = test.Pod
+	settings.EnvironmentName = test.Alias
+	envStatus := http.StatusOK
+	mux.HandleFunc("/pods",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"pods":[{"name":"%s"}]}`, test.Pod)
+		},
+	)
+	mux.HandleFunc("/auth/verify",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"id":"user1"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID,
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(envStatus)
+			fmt.Fprintf(w, `{"id":"%s","name":"%s"}`, test.EnvID, test.EnvName)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID,
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"id":"%s","label":"%s","source":"git@example.com:code1.git"}`, test.SvcID, test.SvcLabel)
+		},
+	)
+
+	ig := git.New()
+	for _, data := range associationTests {
+		t.Logf("Data: %+v", data)
+		envStatus = data.envStatus
+		ig.Rm("datica")
+		if err = ig.Add("datica", data.remoteURL); err != nil {
+			t.Fatalf("Failed to add a git remote: %s", err)
+		}
+
+		// test
+		err := CmdDoctor(settings, New(settings), pods.New(settings), auth.New(settings, prompts.New()), ig)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+}
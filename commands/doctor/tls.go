@@ -0,0 +1,151 @@
+package doctor
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/models"
+)
+
+// CmdDoctorTLS reports the TLS versions and cipher suites the CLI supports,
+// the TLS connection negotiated with each host, and whether those
+// connections meet the current and announced TLS requirements of the pods.
+func CmdDoctorTLS(settings *models.Settings, id IDoctor, ip pods.IPods) error {
+	logrus.Println("Local TLS stack:")
+	logrus.Printf("    Go runtime: %s", runtime.Version())
+	logrus.Printf("    TLS versions: %s to %s", tls.VersionName(httpclient.MinTLSVersion), tls.VersionName(httpclient.MaxTLSVersion))
+	suites := []string{}
+	for _, s := range tls.CipherSuites() {
+		if httpclient.WeakConnection(tls.ConnectionState{Version: s.SupportedVersions[len(s.SupportedVersions)-1], CipherSuite: s.ID}) == "" {
+			suites = append(suites, s.Name)
+		}
+	}
+	logrus.Printf("    Cipher suites: %s", strings.Join(suites, ", "))
+
+	failures := 0
+	hosts := []struct {
+		name string
+		host string
+	}{
+		{"Accounts", settings.AccountsHost},
+		{"Auth", settings.AuthHost},
+		{"PaaS", settings.PaasHost},
+	}
+	connections := []connection{}
+	for _, h := range hosts {
+		logrus.Printf("%s host: %s", h.name, h.host)
+		if err := id.Reachable(h.host); err != nil {
+			logrus.Printf("    FAILED: %s", err)
+			logrus.Println("    FIX: check your network connection and proxy, or the host set with \"datica hosts set\"")
+			failures++
+			continue
+		}
+		u, _ := url.Parse(h.host)
+		state, ok := httpclient.Negotiated(u.Host)
+		if !ok {
+			logrus.Println("    SKIPPED: the host does not use TLS")
+			continue
+		}
+		if !hasConnection(connections, h.host) {
+			connections = append(connections, connection{h.host, state})
+		}
+		if weak := httpclient.WeakConnection(state); weak != "" {
+			logrus.Printf("    FAILED: %s with %s, which is weak since %s", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite), weak)
+			logrus.Println("    FIX: if you connect through a proxy that inspects TLS traffic, have it negotiate TLS 1.2 or later with forward secrecy")
+			failures++
+		} else {
+			logrus.Printf("    OK: %s with %s", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+		}
+	}
+
+	logrus.Println("Pod TLS requirements:")
+	podList, err := ip.List()
+	if err != nil {
+		logrus.Printf("    FAILED: could not list pods: %s", err)
+		logrus.Println("    FIX: make sure the PaaS host above is reachable")
+		return fmt.Errorf("%d checks failed", failures+1)
+	}
+	checked := 0
+	for _, p := range *podList {
+		if settings.Pod != "" && p.Name != settings.Pod {
+			continue
+		}
+		checked++
+		failures += checkPodTLS(p, connections)
+	}
+	if checked == 0 {
+		logrus.Printf("    SKIPPED: the %s pod is not served by %s", settings.Pod, settings.PaasHost)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d checks failed", failures)
+	}
+	logrus.Println("All checks passed")
+	return nil
+}
+
+// connection is the TLS connection negotiated with a host
+type connection struct {
+	host  string
+	state tls.ConnectionState
+}
+
+// checkPodTLS checks the given TLS connections against the minimum TLS
+// version of the given pod. It returns the number of failed checks.
+func checkPodTLS(p models.Pod, connections []connection) int {
+	if p.MinTLSVersion == "" {
+		logrus.Printf("    OK: no TLS requirements have been announced for the %s pod", p.Name)
+		return 0
+	}
+	minVersion, err := httpclient.ParseTLSVersion(p.MinTLSVersion)
+	if err != nil {
+		logrus.Printf("    SKIPPED: the %s pod requires an unknown TLS version %s", p.Name, p.MinTLSVersion)
+		return 0
+	}
+	requirement := fmt.Sprintf("the %s pod only accepts %s or later", p.Name, tls.VersionName(minVersion))
+	if p.TLSCutover != "" {
+		if cutover, err := parseCutover(p.TLSCutover); err == nil && cutover.After(time.Now()) {
+			requirement = fmt.Sprintf("from %s on, the %s pod will only accept %s or later", cutover.Format("2006-01-02"), p.Name, tls.VersionName(minVersion))
+		}
+	}
+	if minVersion > httpclient.MaxTLSVersion {
+		logrus.Printf("    FAILED: %s, which this CLI does not support", requirement)
+		logrus.Println("    FIX: update the CLI with \"datica update\"")
+		return 1
+	}
+	failures := 0
+	for _, c := range connections {
+		if c.state.Version < minVersion {
+			logrus.Printf("    FAILED: %s but the connection to %s uses %s", requirement, c.host, tls.VersionName(c.state.Version))
+			logrus.Printf("    FIX: if you connect through a proxy that inspects TLS traffic, have it negotiate %s", tls.VersionName(minVersion))
+			failures++
+		}
+	}
+	if failures == 0 {
+		logrus.Printf("    OK: %s", requirement)
+	}
+	return failures
+}
+
+func hasConnection(connections []connection, host string) bool {
+	for _, c := range connections {
+		if c.host == host {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCutover(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
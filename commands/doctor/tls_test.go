@@ -0,0 +1,56 @@
+package doctor
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/test"
+)
+
+var doctorTLSTests = []struct {
+	maxVersion    uint16
+	minTLSVersion string
+	cutover       string
+	expectErr     bool
+}{
+	{tls.VersionTLS13, "", "", false},
+	{tls.VersionTLS13, "1.3", "", false},
+	{tls.VersionTLS12, "1.2", "", false},
+	{tls.VersionTLS12, "1.3", time.Now().AddDate(0, 1, 0).Format("2006-01-02"), true},
+	{tls.VersionTLS12, "1.3", "", true},
+}
+
+func TestDoctorTLS(t *testing.T) {
+	for _, data := range doctorTLSTests {
+		t.Logf("Data: %+v", data)
+		mux := http.NewServeMux()
+		server := httptest.NewUnstartedServer(mux)
+		server.TLS = &tls.Config{MaxVersion: data.maxVersion}
+		server.StartTLS()
+		settings := test.GetSettings(server.URL)
+		settings.AccountsHost = server.URL
+		settings.AuthHost = server.URL
+		settings.HTTPManager = httpclient.NewTLSHTTPManager(true)
+		mux.HandleFunc("/pods",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `{"pods":[{"name":"%s","minTlsVersion":"%s","tlsCutover":"%s"},{"name":"%s","minTlsVersion":"9.9"}]}`, test.Pod, data.minTLSVersion, data.cutover, test.PodAlt)
+			},
+		)
+
+		// test
+		err := CmdDoctorTLS(settings, New(settings), pods.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		server.Close()
+	}
+}
@@ -20,6 +20,7 @@ var Cmd = models.Command{
 	LongHelp: "`domain` prints out the temporary domain name setup by Datica for an environment. " +
 		"This domain name typically takes the form podXXXXX.catalyzeapps.com but may vary based on the environment. Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" domain\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			cmd.Action = func() {
@@ -0,0 +1,87 @@
+package dr
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "dr",
+	ShortHelp: "Rehearse disaster recovery",
+	LongHelp:  "The `dr` command helps you rehearse recovering an environment into a disaster recovery environment. The dr command can not be run directly but has sub commands.",
+	Category:  models.CategoryData,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(DrillSubCmd.Name, DrillSubCmd.ShortHelp, help.Render(DrillSubCmd.LongHelp), DrillSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var DrillSubCmd = models.Command{
+	Name:      "drill",
+	ShortHelp: "Recover an environment into a DR environment and report how long it took",
+	LongHelp: "`dr drill` rehearses recovering the associated environment into the environment given by `--target-env`. " +
+		"The latest finished backup of every database is downloaded and imported into the database with the same name in the DR environment, replacing its data. " +
+		"Then the configuration in the `--manifest` file is applied to the code services of the DR environment, and finally every smoke check URL must respond with a successful status. A manifest file looks like this\n\n" +
+		"```\nservices:\n" +
+		"  app01:\n" +
+		"    vars:\n" +
+		"      BASE_URL: https://dr.example.com\n" +
+		"    workers:\n" +
+		"      mailer: 1\n" +
+		"smoke_checks:\n" +
+		"  - https://dr.example.com/health\n```\n\n" +
+		"More smoke checks can be given with `--smoke-check`. " +
+		"A failed step does not stop the drill. Once every step has run, a report with the result and duration of each step is printed, and written as JSON to the file given with `--report` to keep as evidence of the drill. " +
+		"The command fails if any step failed. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" dr drill --target-env production-dr --manifest dr.yml --report drill-2026-h2.json\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			targetEnv := subCmd.StringOpt("target-env", "", "The name of the environment to recover into")
+			manifest := subCmd.StringOpt("manifest", "", "The path of the manifest file to apply to the DR environment")
+			smokeChecks := subCmd.StringsOpt("smoke-check", []string{}, "A URL that must respond successfully once the DR environment is recovered. Can be given more than once.")
+			report := subCmd.StringOpt("report", "", "The path of the file to write the JSON report of the drill to")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdDrill(settings.EnvironmentID, *targetEnv, *manifest, *report, *smokeChecks, settings, environments.New(settings), New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "--target-env [--manifest] [--smoke-check...] [--report]"
+		}
+	},
+}
+
+// IDR
+type IDR interface {
+	LoadManifest(filePath string) (*models.DRManifest, error)
+	SmokeCheck(url string) (int, time.Duration, error)
+}
+
+// SDR is a concrete implementation of IDR
+type SDR struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IDR
+func New(settings *models.Settings) IDR {
+	return &SDR{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,381 @@
+package dr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/db"
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/vars"
+	"github.com/daticahealth/cli/commands/worker"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/crypto"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pmylund/sortutil"
+	"gopkg.in/yaml.v2"
+)
+
+// backupPageSize is the number of backups searched for the latest finished
+// one
+const backupPageSize = 100
+
+// smokeCheckClient gives up on a smoke check that does not respond in time
+var smokeCheckClient = &http.Client{Timeout: 30 * time.Second}
+
+// drillEnv is the source or DR environment of a drill
+type drillEnv struct {
+	env *models.Environment
+	is  services.IServices
+	id  db.IDb
+	ij  jobs.IJobs
+	iv  vars.IVars
+	iw  worker.IWorker
+}
+
+// newDrillEnv returns a drillEnv for the given environment which does not
+// need to be associated
+func newDrillEnv(env *models.Environment, settings *models.Settings) drillEnv {
+	s := *settings
+	s.EnvironmentID = env.ID
+	s.EnvironmentName = env.Name
+	s.Pod = env.Pod
+	s.OrgID = env.OrgID
+	ij := jobs.New(&s)
+	return drillEnv{env, services.New(&s), db.New(&s, crypto.New(), ij, nil), ij, vars.New(&s), worker.New(&s)}
+}
+
+// drillStep is the timed result of a single step of a drill
+type drillStep struct {
+	Name     string  `json:"name"`
+	Passed   bool    `json:"passed"`
+	Seconds  float64 `json:"seconds"`
+	Detail   string  `json:"detail,omitempty"`
+	duration time.Duration
+}
+
+// drillReport is the evidence of a drill written with --report
+type drillReport struct {
+	Source     string      `json:"sourceEnvironment"`
+	Target     string      `json:"drEnvironment"`
+	StartedAt  string      `json:"startedAt"`
+	FinishedAt string      `json:"finishedAt"`
+	Seconds    float64     `json:"seconds"`
+	Passed     bool        `json:"passed"`
+	Steps      []drillStep `json:"steps"`
+}
+
+// CmdDrill rehearses recovering the associated environment into the DR
+// environment. The latest backup of every database is restored into the
+// database of the same name in the DR environment, the manifest is applied to
+// its code services, and the smoke checks are run. Every step is timed and
+// reported, and an error is returned if any step failed.
+func CmdDrill(sourceEnvID, targetEnvName, manifestPath, reportPath string, smokeChecks []string, settings *models.Settings, ie environments.IEnvironments, idr IDR, ip prompts.IPrompts) error {
+	manifest := &models.DRManifest{}
+	if manifestPath != "" {
+		var err error
+		if manifest, err = idr.LoadManifest(manifestPath); err != nil {
+			return err
+		}
+	}
+	urls := append(append([]string{}, manifest.SmokeChecks...), smokeChecks...)
+
+	envs, errs := ie.List()
+	for pod, err := range errs {
+		logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
+	}
+	var sourceEnv, targetEnv *models.Environment
+	for i, env := range *envs {
+		if env.ID == sourceEnvID {
+			sourceEnv = &(*envs)[i]
+		}
+		if env.Name == targetEnvName {
+			targetEnv = &(*envs)[i]
+		}
+	}
+	if sourceEnv == nil {
+		return fmt.Errorf("Could not find the associated environment. You can list environments with the \"datica environments list\" command.")
+	}
+	if targetEnv == nil {
+		return fmt.Errorf("Could not find an environment named \"%s\". You can list environments with the \"datica environments list\" command.", targetEnvName)
+	}
+	if targetEnv.ID == sourceEnv.ID {
+		return fmt.Errorf("The DR environment must be different from the environment being recovered")
+	}
+	src := newDrillEnv(sourceEnv, settings)
+	dst := newDrillEnv(targetEnv, settings)
+	srcServices, err := src.is.List()
+	if err != nil {
+		return err
+	}
+	dstServices, err := dst.is.List()
+	if err != nil {
+		return err
+	}
+	dstByLabel := map[string]*models.Service{}
+	for i, svc := range *dstServices {
+		dstByLabel[svc.Label] = &(*dstServices)[i]
+	}
+	databases := []models.Service{}
+	for _, svc := range *srcServices {
+		if services.IsDatabase(&svc) {
+			databases = append(databases, svc)
+		}
+	}
+	sortutil.AscByField(databases, "Label")
+
+	labels := []string{}
+	for _, svc := range databases {
+		labels = append(labels, svc.Label)
+	}
+	logrus.Printf("Disaster recovery drill of %s into %s", sourceEnv.Name, targetEnv.Name)
+	logrus.Printf("Databases to restore: %s", listOrNone(labels))
+	logrus.Printf("Services to configure from the manifest: %s", listOrNone(manifestLabels(manifest)))
+	logrus.Printf("Smoke checks: %s", listOrNone(urls))
+	if len(databases) > 0 {
+		// the backups pass through this machine on their way to the DR
+		// environment
+		if err = ip.PHI(); err != nil {
+			return err
+		}
+	}
+	err = ip.YesNo(fmt.Sprintf("The data and configuration of %s will be replaced. Would you like to start the drill? (y/n) ", targetEnv.Name))
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	steps := []drillStep{}
+	for _, svc := range databases {
+		svc := svc
+		steps = append(steps, runStep(fmt.Sprintf("restore %s", svc.Label), func() (string, error) {
+			return restoreLatestBackup(&svc, dstByLabel[svc.Label], src, dst, ip)
+		}))
+	}
+	for _, label := range manifestLabels(manifest) {
+		label := label
+		steps = append(steps, runStep(fmt.Sprintf("configure %s", label), func() (string, error) {
+			return applyManifest(manifest.Services[label], label, dstByLabel[label], dst)
+		}))
+	}
+	for _, url := range urls {
+		url := url
+		steps = append(steps, runStep(fmt.Sprintf("smoke check %s", url), func() (string, error) {
+			return smokeCheck(url, idr)
+		}))
+	}
+	finished := time.Now()
+
+	failed := 0
+	for _, step := range steps {
+		if !step.Passed {
+			failed++
+		}
+	}
+	printReport(steps, started, finished)
+	if reportPath != "" {
+		report := drillReport{
+			Source:     sourceEnv.Name,
+			Target:     targetEnv.Name,
+			StartedAt:  started.UTC().Format(time.RFC3339),
+			FinishedAt: finished.UTC().Format(time.RFC3339),
+			Seconds:    finished.Sub(started).Seconds(),
+			Passed:     failed == 0,
+			Steps:      steps,
+		}
+		b, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(reportPath, b, 0644); err != nil {
+			return err
+		}
+		logrus.Printf("The report of the drill was written to %s", reportPath)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d drill steps failed", failed, len(steps))
+	}
+	return nil
+}
+
+// runStep runs and times a single step of the drill
+func runStep(name string, f func() (string, error)) drillStep {
+	logrus.Printf("Running %s", name)
+	start := time.Now()
+	detail, err := f()
+	step := drillStep{Name: name, Passed: err == nil, Detail: detail, duration: time.Since(start)}
+	step.Seconds = step.duration.Seconds()
+	if err != nil {
+		step.Detail = err.Error()
+		config.Warn(config.WarningPartialFailure, "%s failed: %s", name, err)
+	}
+	return step
+}
+
+// restoreLatestBackup downloads the latest finished backup of the source
+// database and imports it into the database of the same name in the DR
+// environment
+func restoreLatestBackup(svc, target *models.Service, src, dst drillEnv, ip prompts.IPrompts) (string, error) {
+	if target == nil {
+		return "", fmt.Errorf("%s has no service named %s", dst.env.Name, svc.Label)
+	}
+	backups, err := src.id.List(1, backupPageSize, svc)
+	if err != nil {
+		return "", err
+	}
+	finished := []models.Job{}
+	for _, job := range *backups {
+		if job.Status == "finished" {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) == 0 {
+		return "", fmt.Errorf("%s has no finished backups", svc.Label)
+	}
+	sort.Sort(sort.Reverse(db.SortedJobs(finished)))
+	backup := finished[0]
+	dir, err := ioutil.TempDir("", "datica-dr")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, backup.ID)
+	if err = src.id.Download(backup.ID, filePath, svc); err != nil {
+		return "", err
+	}
+	// the DR database is about to be replaced, so it is not backed up first
+	if err = db.CmdImport(target.Label, filePath, "", "", true, false, dst.id, ip, dst.is, dst.ij); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("backup %s from %s", backup.ID, config.FormatTimestampString(backup.CreatedAt)), nil
+}
+
+// applyManifest sets the variables and worker scale of the manifest on the
+// service of the DR environment and starts its workers
+func applyManifest(ms models.DRManifestService, label string, target *models.Service, dst drillEnv) (string, error) {
+	if target == nil {
+		return "", fmt.Errorf("%s has no service named %s", dst.env.Name, label)
+	}
+	details := []string{}
+	if len(ms.Vars) > 0 {
+		if err := dst.iv.Set(target.ID, ms.Vars); err != nil {
+			return "", err
+		}
+		details = append(details, fmt.Sprintf("%d vars set", len(ms.Vars)))
+	}
+	if len(ms.Workers) > 0 {
+		if err := dst.iw.Update(target.ID, &models.Workers{Workers: ms.Workers}); err != nil {
+			return "", err
+		}
+		for _, t := range workerTargets(ms.Workers) {
+			if ms.Workers[t] == 0 {
+				continue
+			}
+			if _, err := dst.ij.DeployTarget(t, target.ID); err != nil {
+				return "", fmt.Errorf("could not start the %s workers: %s", t, err)
+			}
+			details = append(details, fmt.Sprintf("%s=%d", t, ms.Workers[t]))
+		}
+	}
+	if len(details) == 0 {
+		return "nothing to apply", nil
+	}
+	return strings.Join(details, ", "), nil
+}
+
+// smokeCheck requests the URL and fails unless it responds successfully
+func smokeCheck(url string, idr IDR) (string, error) {
+	statusCode, elapsed, err := idr.SmokeCheck(url)
+	if err != nil {
+		return "", err
+	}
+	if statusCode < 200 || statusCode >= 400 {
+		return "", fmt.Errorf("responded with %d after %s", statusCode, elapsed)
+	}
+	return fmt.Sprintf("responded with %d after %s", statusCode, elapsed), nil
+}
+
+func printReport(steps []drillStep, started, finished time.Time) {
+	logrus.Println()
+	logrus.Printf("Started %s, finished %s, took %s", config.FormatTimestamp(started), config.FormatTimestamp(finished), finished.Sub(started).Round(time.Second))
+	data := [][]string{{"STEP", "RESULT", "DURATION", "DETAILS"}}
+	for _, step := range steps {
+		result := "passed"
+		if !step.Passed {
+			result = "failed"
+		}
+		data = append(data, []string{step.Name, result, step.duration.Round(time.Second).String(), step.Detail})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+}
+
+// LoadManifest reads a DR manifest file
+func (d *SDR) LoadManifest(filePath string) (*models.DRManifest, error) {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var manifest models.DRManifest
+	if err = yaml.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("%s is not a valid DR manifest: %s", filePath, err)
+	}
+	return &manifest, nil
+}
+
+// SmokeCheck requests the URL and returns the status code it responded with
+// and how long it took
+func (d *SDR) SmokeCheck(url string) (int, time.Duration, error) {
+	start := time.Now()
+	resp, err := smokeCheckClient.Get(url)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, time.Since(start).Round(time.Millisecond), nil
+}
+
+// manifestLabels returns the sorted labels of the services in the manifest
+func manifestLabels(manifest *models.DRManifest) []string {
+	labels := []string{}
+	for label := range manifest.Services {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// workerTargets returns the sorted targets of the worker scale
+func workerTargets(workers map[string]int) []string {
+	targets := []string{}
+	for target := range workers {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,151 @@
+package dr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const drEnvID = "dr1"
+
+const drManifest = `services:
+  app01:
+    vars:
+      BASE_URL: https://dr.example.com
+    workers:
+      mailer: 2
+      idle: 0
+smoke_checks:
+  - {{base}}/health
+`
+
+var drillTests = []struct {
+	target       string
+	srcServices  string
+	smokeCheck   string
+	expectSteps  int
+	expectPassed bool
+	expectErr    bool
+}{
+	{"production-dr", `[{"id":"code1","label":"app01","name":"code","type":"code"}]`, "", 2, true, false},
+	{"production-dr", `[{"id":"code1","label":"app01","name":"code","type":"code"}]`, "/unhealthy", 3, false, true},
+	{"production-dr", `[{"id":"db1","label":"db01","name":"postgresql"},{"id":"code1","label":"app01","name":"code","type":"code"}]`, "", 3, false, true},
+	{test.EnvName, `[]`, "", 0, false, true},
+	{"unknown-env", `[]`, "", 0, false, true},
+}
+
+func TestDrill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, data := range drillTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		manifestPath := filepath.Join(dir, "dr.yml")
+		reportPath := filepath.Join(dir, "report.json")
+		os.Remove(reportPath)
+		ioutil.WriteFile(manifestPath, []byte(replaceBase(drManifest, baseURL.String())), 0644)
+
+		mux.HandleFunc("/environments",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				if r.Header.Get("X-Pod-ID") == test.Pod {
+					fmt.Fprintf(w, `[{"id":"%s","name":"%s"},{"id":"%s","name":"production-dr"}]`, test.EnvID, test.EnvName, drEnvID)
+				} else {
+					fmt.Fprint(w, `[]`)
+				}
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, data.srcServices)
+			},
+		)
+		mux.HandleFunc("/environments/"+drEnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, `[{"id":"drcode1","label":"app01","name":"code","type":"code"}]`)
+			},
+		)
+		setVars := map[string]string{}
+		mux.HandleFunc("/environments/"+drEnvID+"/services/drcode1/env",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				json.NewDecoder(r.Body).Decode(&setVars)
+			},
+		)
+		var workers models.Workers
+		mux.HandleFunc("/environments/"+drEnvID+"/services/drcode1/workers",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				json.NewDecoder(r.Body).Decode(&workers)
+			},
+		)
+		deployed := []string{}
+		mux.HandleFunc("/environments/"+drEnvID+"/services/drcode1/deploy",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				deployed = append(deployed, r.URL.Query().Get("target"))
+				fmt.Fprint(w, `{}`)
+			},
+		)
+		mux.HandleFunc("/health",
+			func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `ok`)
+			},
+		)
+		mux.HandleFunc("/unhealthy",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+		)
+		smokeChecks := []string{}
+		if data.smokeCheck != "" {
+			smokeChecks = append(smokeChecks, baseURL.String()+data.smokeCheck)
+		}
+
+		// test
+		err := CmdDrill(test.EnvID, data.target, manifestPath, reportPath, smokeChecks, settings, environments.New(settings), New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.Teardown(server)
+		b, readErr := ioutil.ReadFile(reportPath)
+		if data.expectSteps == 0 {
+			if readErr == nil {
+				t.Errorf("Expected no report to be written")
+			}
+			continue
+		}
+		if readErr != nil {
+			t.Errorf("Expected a report to be written: %s", readErr)
+			continue
+		}
+		var report drillReport
+		json.Unmarshal(b, &report)
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectSteps), fmt.Sprintf("%d", len(report.Steps)))
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectPassed), fmt.Sprintf("%t", report.Passed))
+		test.AssertEquals(t, "https://dr.example.com", setVars["BASE_URL"])
+		test.AssertEquals(t, "2", fmt.Sprintf("%d", workers.Workers["mailer"]))
+		test.AssertEquals(t, "[mailer]", fmt.Sprintf("%v", deployed))
+	}
+}
+
+func replaceBase(s, baseURL string) string {
+	return strings.Replace(s, "{{base}}", baseURL, -1)
+}
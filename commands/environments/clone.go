@@ -0,0 +1,305 @@
+package environments
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/certs"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/vars"
+	"github.com/daticahealth/cli/commands/worker"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// secretMarkers are the parts of a variable name that mark it as a likely
+// secret which is only copied when the user confirms it
+var secretMarkers = []string{"KEY", "SECRET", "PASSWORD", "PASSWD", "TOKEN", "CREDENTIAL", "PRIVATE"}
+
+// cloneEnv is the source or target environment of a clone
+type cloneEnv struct {
+	env *models.Environment
+	is  services.IServices
+	iv  vars.IVars
+	ic  certs.ICerts
+	iw  worker.IWorker
+}
+
+// newCloneEnv returns a cloneEnv for the given environment which does not
+// need to be associated
+func newCloneEnv(env *models.Environment, settings *models.Settings) cloneEnv {
+	s := *settings
+	s.EnvironmentID = env.ID
+	s.EnvironmentName = env.Name
+	s.Pod = env.Pod
+	s.OrgID = env.OrgID
+	return cloneEnv{env, services.New(&s), vars.New(&s), certs.New(&s), worker.New(&s)}
+}
+
+// cloneResult is a single line of the summary report of a clone
+type cloneResult struct {
+	kind   string
+	name   string
+	copied bool
+	detail string
+}
+
+// CmdClone creates a new environment named target in the same organization
+// and pod as the source environment and copies the services, environment
+// variables, certs, and worker scale of the source into it. Variables that
+// look like secrets are only copied if the user confirms each of them, and
+// variables named in exclude are never copied.
+func CmdClone(source, target string, exclude []string, settings *models.Settings, ie IEnvironments, ip prompts.IPrompts) error {
+	if target == "" {
+		return fmt.Errorf("The name of the new environment cannot be empty")
+	}
+	envs, errs := ie.List()
+	for pod, err := range errs {
+		logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
+	}
+	var sourceEnv *models.Environment
+	for i, env := range *envs {
+		if env.Name == target {
+			return fmt.Errorf("An environment named \"%s\" already exists. Please choose a different name.", target)
+		}
+		if env.Name == source {
+			sourceEnv = &(*envs)[i]
+		}
+	}
+	if sourceEnv == nil {
+		return fmt.Errorf("Could not find an environment named \"%s\". You can list environments with the \"datica environments list\" command.", source)
+	}
+	src := newCloneEnv(sourceEnv, settings)
+	srcServices, err := src.is.List()
+	if err != nil {
+		return err
+	}
+
+	excluded := map[string]bool{}
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+	// the secrets are chosen before anything is created so that a clone is
+	// not left half done while waiting on the user
+	copyVars := map[string]map[string]string{}
+	results := []cloneResult{}
+	for _, svc := range *srcServices {
+		if svc.Type != "code" {
+			continue
+		}
+		envVars, err := src.iv.List(svc.ID)
+		if err != nil {
+			return err
+		}
+		copyVars[svc.Label] = map[string]string{}
+		names := []string{}
+		for name := range envVars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			varName := fmt.Sprintf("%s/%s", svc.Label, name)
+			if excluded[name] {
+				results = append(results, cloneResult{"var", varName, false, "excluded"})
+				continue
+			}
+			if isSecret(name) {
+				if err = ip.YesNo(fmt.Sprintf("%s of %s looks like a secret. Would you like to copy it to %s? (y/n) ", name, svc.Label, target)); err != nil {
+					results = append(results, cloneResult{"var", varName, false, "secret left out"})
+					continue
+				}
+			}
+			copyVars[svc.Label][name] = envVars[name]
+		}
+	}
+
+	logrus.Printf("Creating the environment %s", target)
+	targetEnv, err := ie.Create(target, sourceEnv.OrgID, sourceEnv.Pod)
+	if err != nil {
+		return err
+	}
+	dst := newCloneEnv(targetEnv, settings)
+	_, serviceResults, err := cloneServices(*srcServices, copyVars, src, dst)
+	if err != nil {
+		return err
+	}
+	results = append(results, serviceResults...)
+
+	logrus.Printf("Cloned %s into %s\n", source, target)
+	printCloneResults(results)
+	logrus.Printf("Run \"datica associate %s\" to use the new environment from a local git repo", target)
+	return nil
+}
+
+// cloneServices creates every source service that the target environment
+// was not provisioned with and copies the variables, worker scale, and certs
+// of the source. The services of the target environment are returned by
+// label.
+func cloneServices(srcServices []models.Service, copyVars map[string]map[string]string, src, dst cloneEnv) (map[string]*models.Service, []cloneResult, error) {
+	// environments are provisioned with some services, which are reused
+	dstServices, err := dst.is.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	existing := map[string]*models.Service{}
+	for i, svc := range *dstServices {
+		existing[svc.Label] = &(*dstServices)[i]
+	}
+
+	results := []cloneResult{}
+	for _, svc := range srcServices {
+		created := existing[svc.Label]
+		if created == nil {
+			logrus.Printf("Creating the service %s", svc.Label)
+			created, err = dst.is.Create(&models.Service{Label: svc.Label, Name: svc.Name, Type: svc.Type, Size: svc.Size, Scale: svc.Scale})
+			if err != nil {
+				results = append(results, cloneResult{"service", svc.Label, false, err.Error()})
+				continue
+			}
+			existing[svc.Label] = created
+			results = append(results, cloneResult{"service", svc.Label, true, ""})
+		} else {
+			results = append(results, cloneResult{"service", svc.Label, false, "already provisioned"})
+		}
+		if svc.Type != "code" {
+			continue
+		}
+		results = append(results, cloneVars(copyVars[svc.Label], svc.Label, created.ID, dst)...)
+		results = append(results, cloneWorkers(svc, created.ID, src, dst))
+	}
+	if proxy := existing["service_proxy"]; proxy != nil {
+		results = append(results, cloneCerts(srcServices, proxy.ID, src, dst)...)
+	} else {
+		results = append(results, cloneResult{"cert", "*", false, "the new environment has no service_proxy"})
+	}
+	return existing, results, nil
+}
+
+// cloneVars sets the given variables on the service of the target
+// environment
+func cloneVars(envVars map[string]string, svcLabel, svcID string, dst cloneEnv) []cloneResult {
+	if len(envVars) == 0 {
+		return nil
+	}
+	names := []string{}
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	results := []cloneResult{}
+	err := dst.iv.Set(svcID, envVars)
+	for _, name := range names {
+		if err != nil {
+			results = append(results, cloneResult{"var", fmt.Sprintf("%s/%s", svcLabel, name), false, err.Error()})
+		} else {
+			results = append(results, cloneResult{"var", fmt.Sprintf("%s/%s", svcLabel, name), true, ""})
+		}
+	}
+	return results
+}
+
+// cloneWorkers copies the worker scale of a code service. Workers are started
+// by the first deploy of the new service.
+func cloneWorkers(svc models.Service, svcID string, src, dst cloneEnv) cloneResult {
+	workers, err := src.iw.Retrieve(svc.ID)
+	if err != nil {
+		return cloneResult{"workers", svc.Label, false, err.Error()}
+	}
+	if len(workers.Workers) == 0 {
+		return cloneResult{"workers", svc.Label, false, "no workers are scaled"}
+	}
+	if err = dst.iw.Update(svcID, workers); err != nil {
+		return cloneResult{"workers", svc.Label, false, err.Error()}
+	}
+	targets := []string{}
+	for target, scale := range workers.Workers {
+		targets = append(targets, fmt.Sprintf("%s=%d", target, scale))
+	}
+	sort.Strings(targets)
+	return cloneResult{"workers", svc.Label, true, strings.Join(targets, ", ")}
+}
+
+// cloneCerts copies the certs of the service proxy. Only certs whose private
+// key is returned by the API can be copied.
+func cloneCerts(srcServices []models.Service, proxyID string, src, dst cloneEnv) []cloneResult {
+	var srcProxy *models.Service
+	for i, svc := range srcServices {
+		if svc.Label == "service_proxy" {
+			srcProxy = &srcServices[i]
+		}
+	}
+	if srcProxy == nil {
+		return nil
+	}
+	certList, err := src.ic.List(srcProxy.ID)
+	if err != nil {
+		return []cloneResult{{"cert", "*", false, err.Error()}}
+	}
+	results := []cloneResult{}
+	for _, cert := range *certList {
+		if cert.PubKey == "" || cert.PrivKey == "" {
+			results = append(results, cloneResult{"cert", cert.Name, false, "the private key is not available, upload it with \"datica certs create\""})
+			continue
+		}
+		if err = dst.ic.Create(cert.Name, cert.PubKey, cert.PrivKey, proxyID); err != nil {
+			results = append(results, cloneResult{"cert", cert.Name, false, err.Error()})
+			continue
+		}
+		results = append(results, cloneResult{"cert", cert.Name, true, ""})
+	}
+	return results
+}
+
+// isSecret reports whether the name of a variable marks it as a likely secret
+func isSecret(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range secretMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func printCloneResults(results []cloneResult) {
+	data := [][]string{{"TYPE", "NAME", "COPIED", "DETAILS"}}
+	for _, r := range results {
+		copied := "no"
+		if r.copied {
+			copied = "yes"
+		}
+		data = append(data, []string{r.kind, r.name, copied, r.detail})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+}
+
+func (e *SEnvironments) Create(name, orgID, pod string) (*models.Environment, error) {
+	b, err := json.Marshal(models.Environment{Name: name, OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments", e.Settings.PaasHost, e.Settings.PaasHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	var env models.Environment
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &env)
+	if err != nil {
+		return nil, err
+	}
+	env.Pod = pod
+	return &env, nil
+}
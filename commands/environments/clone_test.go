@@ -0,0 +1,119 @@
+package environments
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/test"
+)
+
+const clonedEnvID = "cloned"
+
+var cloneTests = []struct {
+	source    string
+	target    string
+	exclude   []string
+	expectErr bool
+}{
+	{test.EnvName, "staging", []string{}, false},
+	{test.EnvName, "staging", []string{"DATABASE_URL"}, false},
+	{test.EnvName, test.EnvNameAlt, []string{}, true},
+	{test.EnvName, "", []string{}, true},
+	{"invalid-env", "staging", []string{}, true},
+}
+
+func TestClone(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","name":"staging","organizationId":"%s"}`, clonedEnvID, test.OrgID))
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			if r.Header.Get("X-Pod-ID") == test.Pod {
+				fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","name":"%s","namespace":"%s","organizationId":"%s"}]`, test.EnvID, test.EnvName, test.Namespace, test.OrgID))
+			} else {
+				fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","name":"%s","namespace":"%s","organizationId":"%s"}]`, test.EnvIDAlt, test.EnvNameAlt, test.NamespaceAlt, test.OrgIDAlt))
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","name":"code","type":"code"},{"id":"proxy","label":"service_proxy","name":"service_proxy","type":"utility"}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/env",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"DATABASE_URL":"postgres://db","API_TOKEN":"abc123"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"workers":{"worker":2}}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/proxy/certs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"name":"example.com"}]`)
+		},
+	)
+	mux.HandleFunc("/environments/"+clonedEnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				fmt.Fprint(w, fmt.Sprintf(`{"id":"cloned-code","label":"%s","name":"code","type":"code"}`, test.SvcLabel))
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"cloned-proxy","label":"service_proxy","name":"service_proxy","type":"utility"}]`)
+		},
+	)
+	var setVars map[string]string
+	mux.HandleFunc("/environments/"+clonedEnvID+"/services/cloned-code/env",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			setVars = map[string]string{}
+			json.NewDecoder(r.Body).Decode(&setVars)
+			fmt.Fprint(w, `{}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+clonedEnvID+"/services/cloned-code/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range cloneTests {
+		t.Logf("Data: %+v", data)
+		setVars = nil
+
+		// test
+		err := CmdClone(data.source, data.target, data.exclude, settings, New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectErr {
+			continue
+		}
+		if setVars["API_TOKEN"] != "abc123" {
+			t.Errorf("Expected the confirmed secret to be copied but got %v", setVars)
+		}
+		for _, name := range data.exclude {
+			if _, ok := setVars[name]; ok {
+				t.Errorf("Expected %s to be excluded but it was copied", name)
+			}
+		}
+	}
+}
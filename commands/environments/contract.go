@@ -2,8 +2,13 @@ package environments
 
 import (
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/worker"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -16,17 +21,24 @@ var Cmd = models.Command{
 	ShortHelp: "Manage environments for which you have access",
 	LongHelp: "This command has been moved! Please use [environments list](#environments-list) instead. This alias will be removed in the next CLI update.\n\n" +
 		"The `environments` command allows you to manage your environments. The environments command can not be run directly but has sub commands.",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RenameSubCmd.Name, RenameSubCmd.ShortHelp, RenameSubCmd.LongHelp, RenameSubCmd.CmdFunc(settings))
+			cmd.CommandLong(CloneSubCmd.Name, CloneSubCmd.ShortHelp, help.Render(CloneSubCmd.LongHelp), CloneSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(MigrateSubCmd.Name, MigrateSubCmd.ShortHelp, help.Render(MigrateSubCmd.LongHelp), MigrateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RenameSubCmd.Name, RenameSubCmd.ShortHelp, help.Render(RenameSubCmd.LongHelp), RenameSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RestartSubCmd.Name, RestartSubCmd.ShortHelp, help.Render(RestartSubCmd.LongHelp), RestartSubCmd.CmdFunc(settings))
+			cmd.CommandLong(TransferSubCmd.Name, TransferSubCmd.ShortHelp, help.Render(TransferSubCmd.LongHelp), TransferSubCmd.CmdFunc(settings))
 			cmd.Action = func() {
-				logrus.Warnln("This command has been moved! Please use \"datica environments list\" instead. This alias will be removed in the next CLI update.")
+				if err := config.Deprecated("This command has been moved! Please use \"datica environments list\" instead. This alias will be removed in the next CLI update."); err != nil {
+					logrus.Fatal(err.Error())
+				}
 				logrus.Warnln("You can list all available environments subcommands by running \"datica environments --help\".")
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdList(New(settings))
+				err := CmdList(false, settings, New(settings), invites.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
@@ -35,24 +47,98 @@ var Cmd = models.Command{
 	},
 }
 
+var CloneSubCmd = models.Command{
+	Name:      "clone",
+	ShortHelp: "Create a new environment from the services and configuration of an existing one",
+	LongHelp: "`environments clone` creates a new environment named `TARGET` in the same organization and pod as the environment named `SOURCE`. " +
+		"Every service of the source environment is created in the new environment with the same size and scale. " +
+		"For code services, the environment variables and worker scale are copied as well, and the certs of the service proxy are copied when their private keys are available. " +
+		"Environment variables whose names look like secrets, such as those containing `KEY`, `SECRET`, `PASSWORD`, or `TOKEN`, are only copied after you confirm each of them. " +
+		"Variables given with `--exclude` are never copied. " +
+		"Code is not copied, so each code service must be deployed before it runs. " +
+		"When the clone is finished, a report lists everything that was and was not copied. Here are some sample commands\n\n" +
+		"```\ndatica environments clone production staging\n" +
+		"datica environments clone production staging --exclude STRIPE_KEY --exclude SENTRY_DSN\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			source := subCmd.StringArg("SOURCE", "", "The name of the environment to clone")
+			target := subCmd.StringArg("TARGET", "", "The name of the new environment")
+			exclude := subCmd.StringsOpt("exclude", []string{}, "The name of an environment variable that is not copied. Can be given more than once.")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdClone(*source, *target, *exclude, settings, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatalln(err.Error())
+				}
+			}
+			subCmd.Spec = "SOURCE TARGET [--exclude...]"
+		}
+	},
+}
+
 var ListSubCmd = models.Command{
 	Name:      "list",
 	ShortHelp: "List all environments you have access to",
 	LongHelp: "`environments list` lists all environments that you are granted access to. " +
 		"These environments include those you created and those that other Datica customers have added you to. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica environments list\n```",
+		"Use `--all-pods` to list the environments of every pod at once, including pods with hosts configured by the [hosts](#hosts) command, along with the pod, organization, and local association of each environment. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica environments list\n" +
+		"datica environments list --all-pods\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
+			allPods := subCmd.BoolOpt("all-pods", false, "List the environments of every pod along with their pod, organization, and association")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatalln(err.Error())
 				}
-				err := CmdList(New(settings))
+				err := CmdList(*allPods, settings, New(settings), invites.New(settings))
 				if err != nil {
 					logrus.Fatalln(err.Error())
 				}
 			}
+			subCmd.Spec = "[--all-pods]"
+		}
+	},
+}
+
+var MigrateSubCmd = models.Command{
+	Name:      "migrate",
+	ShortHelp: "Copy an environment and its data into a new environment on another pod",
+	LongHelp: "`environments migrate` copies your environment into a new environment on the pod given by `--to-pod`, with the same name unless another is given with `--name`. " +
+		"Pre-flight checks first make sure the pod exists, that it is PHI safe if the current pod is, and that the plan of your organization has room for a second copy of the environment until the old one is removed. " +
+		"Every service is created in the new environment with the same size and scale, and the environment variables, worker scale, certs, and sites are copied. " +
+		"Unlike [environments clone](#environments-clone), every environment variable is copied, secrets included. " +
+		"A new backup of every database is taken and imported into the database of the same name in the new environment. " +
+		"Your environment keeps running and is not changed. " +
+		"You are asked to confirm before anything is created, unless a [break-glass](#breakglass) session of your organization is active. " +
+		"When the migration is finished, a report lists everything that was and was not copied, followed by a checklist of the steps left to cut over, such as deploying your code, stopping writes, and the DNS records to change. " +
+		"The checklist is also written to the file given with `--checklist`. " +
+		"To review the migration before anything is created, `--plan` runs the pre-flight checks and prints every operation in the order it would run, then exits without changing anything. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" environments migrate --to-pod pod02\n" +
+		"datica -E \"<your_env_alias>\" environments migrate --to-pod pod02 --plan\n" +
+		"datica -E \"<your_env_alias>\" environments migrate --to-pod pod02 --name production-pod02 --checklist cutover.md\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			toPod := subCmd.StringOpt("to-pod", "", "The name of the pod to migrate the environment to")
+			name := subCmd.StringOpt("name", "", "The name of the new environment, the name of the environment by default")
+			checklist := subCmd.StringOpt("checklist", "", "The path of the file to write the cutover checklist to")
+			plan := subCmd.BoolOpt("plan", false, "Print every operation of the migration in order without running any of them")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdMigrate(settings.EnvironmentID, *toPod, *name, *checklist, *plan, settings, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "--to-pod [--name] [--checklist] [--plan]"
 		}
 	},
 }
@@ -82,11 +168,77 @@ var RenameSubCmd = models.Command{
 	},
 }
 
+var RestartSubCmd = models.Command{
+	Name:      "restart",
+	ShortHelp: "Restart every service of an environment in dependency order",
+	LongHelp: "`environments restart` restarts every service of your environment in the order they depend on each other. " +
+		"Databases are restarted first, then caches such as redis and memcached, then code services, and the workers of the code services last. " +
+		"Every service of a tier must be running again before the next tier is restarted, and the restart stops at the first service that does not come back up. " +
+		"Services that can not be redeployed, such as the service proxy, are not restarted. " +
+		"The order is printed before anything is restarted and you are asked to confirm it. " +
+		"With `--plan`, every redeploy and worker restart is printed in the order it would run and nothing is restarted, so the plan can be reviewed first. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" environments restart\n" +
+		"datica -E \"<your_env_alias>\" environments restart --plan\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			plan := subCmd.BoolOpt("plan", false, "Print every operation of the restart in order without restarting anything")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRestart(settings.EnvironmentID, *plan, New(settings), services.New(settings), worker.New(settings), jobs.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--plan]"
+		}
+	},
+}
+
+var TransferSubCmd = models.Command{
+	Name:      "transfer",
+	ShortHelp: "Transfer an environment to another organization",
+	LongHelp: "`environments transfer` moves the environment named `ENV_NAME` into another organization you belong to, given by name or ID with `--to-org`. " +
+		"The environment, its services, and its data are not changed, but its billing and who can access it follow the new organization. " +
+		"Before anything is transferred, pre-flight checks make sure the plan of the new organization has room for another environment and for the memory of its services, and that it allows the pod of the environment. " +
+		"If every check passes, you confirm the transfer by typing the name of the environment. " +
+		"The global `--yes` flag does not skip this confirmation, give the name of the environment with `--confirm` instead to transfer it from a script. " +
+		"The confirmation is also skipped during a [break-glass](#breakglass) session of the environment's organization. " +
+		"Local associations of the environment are updated to the new organization. Here are some sample commands\n\n" +
+		"```\ndatica environments transfer production --to-org \"My Other Organization\"\n" +
+		"datica environments transfer production --to-org \"My Other Organization\" --confirm production\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			envName := subCmd.StringArg("ENV_NAME", "", "The name of the environment to transfer")
+			toOrg := subCmd.StringOpt("to-org", "", "The name or ID of the organization to transfer the environment to")
+			confirm := subCmd.StringOpt("confirm", "", "The name of the environment, to confirm the transfer without being prompted")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdTransfer(*envName, *toOrg, *confirm, settings, New(settings), invites.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatalln(err.Error())
+				}
+			}
+			subCmd.Spec = "ENV_NAME --to-org [--confirm]"
+		}
+	},
+}
+
 // IEnvironments is an interface for interacting with environments
 type IEnvironments interface {
+	Create(name, orgID, pod string) (*models.Environment, error)
 	List() (*[]models.Environment, map[string]error)
+	ListAllPods() (*[]models.Environment, map[string]error)
 	Retrieve(envID string) (*models.Environment, error)
 	Update(envID string, updates map[string]string) error
+	Plan(orgID string) (*models.OrgPlan, error)
+	Transfer(envID, pod, orgID string) (*models.Environment, error)
 }
 
 // SEnvironments is a concrete implementation of IEnvironments
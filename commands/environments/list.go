@@ -2,13 +2,22 @@ package environments
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
 )
 
 // CmdList lists all environments which the user has access to
-func CmdList(environments IEnvironments) error {
+func CmdList(allPods bool, settings *models.Settings, environments IEnvironments, ii invites.IInvites) error {
+	if allPods {
+		return listAllPods(settings, environments, ii)
+	}
 	envs, errs := environments.List()
 	if envs == nil || len(*envs) == 0 {
 		logrus.Println("no environments found")
@@ -26,30 +35,157 @@ func CmdList(environments IEnvironments) error {
 	return nil
 }
 
+// listAllPods prints a table of the environments of every pod along with their
+// organization and the aliases they are associated with
+func listAllPods(settings *models.Settings, environments IEnvironments, ii invites.IInvites) error {
+	envs, errs := environments.ListAllPods()
+	orgNames := map[string]string{}
+	if orgs, err := ii.ListOrgs(); err != nil {
+		logrus.Debugf("Failed to list organizations: %s", err)
+	} else {
+		for _, o := range *orgs {
+			orgNames[o.ID] = o.Name
+		}
+	}
+	aliases := map[string][]string{}
+	for alias, e := range settings.Environments {
+		aliases[e.EnvironmentID] = append(aliases[e.EnvironmentID], alias)
+	}
+
+	if envs == nil || len(*envs) == 0 {
+		logrus.Println("no environments found")
+	} else {
+		sort.Sort(SortedEnvironments(*envs))
+		data := [][]string{{"NAME", "ID", "POD", "ORGANIZATION", "ASSOCIATED AS"}}
+		for _, env := range *envs {
+			org := orgNames[env.OrgID]
+			if org == "" {
+				org = env.OrgID
+			}
+			associated := "-"
+			if a, ok := aliases[env.ID]; ok {
+				sort.Strings(a)
+				associated = strings.Join(a, ", ")
+			}
+			data = append(data, []string{env.Name, env.ID, env.Pod, org, associated})
+		}
+		table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+		table.SetBorder(false)
+		table.SetRowLine(false)
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("")
+		table.SetRowSeparator("")
+		table.AppendBulk(data)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	}
+	if len(errs) > 0 {
+		pods := []string{}
+		for pod, err := range errs {
+			logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
+			pods = append(pods, pod)
+		}
+		sort.Strings(pods)
+		logrus.Warnf("Could not list the environments of the pods %s. Set %s=debug to see why.", strings.Join(pods, ", "), config.LogLevelEnvVar)
+	}
+	return nil
+}
+
+// SortedEnvironments is a wrapper for an array of environments in order to
+// sort them by pod and then by name
+type SortedEnvironments []models.Environment
+
+func (envs SortedEnvironments) Len() int {
+	return len(envs)
+}
+
+func (envs SortedEnvironments) Swap(i, j int) {
+	envs[i], envs[j] = envs[j], envs[i]
+}
+
+func (envs SortedEnvironments) Less(i, j int) bool {
+	if envs[i].Pod != envs[j].Pod {
+		return envs[i].Pod < envs[j].Pod
+	}
+	return strings.ToLower(envs[i].Name) < strings.ToLower(envs[j].Name)
+}
+
 func (e *SEnvironments) List() (*[]models.Environment, map[string]error) {
 	allEnvs := []models.Environment{}
 	errs := map[string]error{}
 	for _, pod := range *e.Settings.Pods {
-		headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, pod.Name, e.Settings.UsersID)
-		resp, statusCode, err := e.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments", e.Settings.PaasHost, e.Settings.PaasHostVersion), headers)
+		envs, err := e.listPod(pod.Name, e.Settings.PaasHost)
 		if err != nil {
 			errs[pod.Name] = err
 			continue
 		}
-		var envs []models.Environment
-		err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &envs)
-		if err != nil {
-			errs[pod.Name] = err
-			continue
+		allEnvs = append(allEnvs, *envs...)
+	}
+	return &allEnvs, errs
+}
+
+// ListAllPods lists the environments of every known pod and of every pod with
+// configured hosts at once. Each pod is reached through its own hosts.
+func (e *SEnvironments) ListAllPods() (*[]models.Environment, map[string]error) {
+	podNames := []string{}
+	seen := map[string]bool{}
+	for _, pod := range *e.Settings.Pods {
+		podNames = append(podNames, pod.Name)
+		seen[pod.Name] = true
+	}
+	for pod := range e.Settings.Hosts {
+		if pod != config.AllPods && !seen[pod] {
+			podNames = append(podNames, pod)
+			seen[pod] = true
+		}
+	}
+
+	results := make([]*[]models.Environment, len(podNames))
+	podErrs := make([]error, len(podNames))
+	var wg sync.WaitGroup
+	for i, pod := range podNames {
+		host := config.HostOverride(e.Settings, pod).PaasHost
+		if host == "" {
+			host = e.Settings.PaasHost
 		}
-		for i := 0; i < len(envs); i++ {
-			envs[i].Pod = pod.Name
+		wg.Add(1)
+		go func(i int, pod, host string) {
+			defer wg.Done()
+			results[i], podErrs[i] = e.listPod(pod, host)
+		}(i, pod, host)
+	}
+	wg.Wait()
+
+	allEnvs := []models.Environment{}
+	errs := map[string]error{}
+	for i, pod := range podNames {
+		if podErrs[i] != nil {
+			errs[pod] = podErrs[i]
+			continue
 		}
-		allEnvs = append(allEnvs, envs...)
+		allEnvs = append(allEnvs, *results[i]...)
 	}
 	return &allEnvs, errs
 }
 
+// listPod lists the environments of a single pod
+func (e *SEnvironments) listPod(pod, paasHost string) (*[]models.Environment, error) {
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments", paasHost, e.Settings.PaasHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	var envs []models.Environment
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &envs)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(envs); i++ {
+		envs[i].Pod = pod
+	}
+	return &envs, nil
+}
+
 func (e *SEnvironments) Retrieve(envID string) (*models.Environment, error) {
 	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, e.Settings.Pod, e.Settings.UsersID)
 	resp, statusCode, err := e.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s", e.Settings.PaasHost, e.Settings.PaasHostVersion, envID), headers)
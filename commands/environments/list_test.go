@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/models"
 	"github.com/daticahealth/cli/test"
 )
 
@@ -23,7 +25,7 @@ func TestList(t *testing.T) {
 		},
 	)
 
-	err := CmdList(New(settings))
+	err := CmdList(false, settings, New(settings), invites.New(settings))
 
 	// assert
 	if err != nil {
@@ -42,7 +44,53 @@ func TestListWithPodError(t *testing.T) {
 		},
 	)
 
-	err := CmdList(New(settings))
+	err := CmdList(false, settings, New(settings), invites.New(settings))
+
+	// assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestListAllPods(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.Hosts = map[string]models.HostOverride{"selfhosted": {PaasHost: baseURL.String()}}
+	mux.HandleFunc("/environments",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			switch r.Header.Get("X-Pod-ID") {
+			case test.Pod:
+				fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","name":"%s","namespace":"%s","organizationId":"%s"}]`, test.EnvID, test.EnvName, test.Namespace, test.OrgID))
+			case "selfhosted":
+				fmt.Fprint(w, fmt.Sprintf(`[{"id":"onprem","name":"onprem","organizationId":"%s"}]`, test.OrgID))
+			default:
+				w.WriteHeader(500)
+			}
+		},
+	)
+	mux.HandleFunc("/orgs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","name":"Acme"}]`, test.OrgID))
+		},
+	)
+
+	envs, errs := New(settings).ListAllPods()
+	if len(*envs) != 2 {
+		t.Fatalf("Expected the environments of 2 pods but got %+v", *envs)
+	}
+	if len(errs) != len(*settings.Pods)-1 {
+		t.Errorf("Expected an error for every other pod but got %v", errs)
+	}
+	for _, env := range *envs {
+		if env.ID == "onprem" && env.Pod != "selfhosted" {
+			t.Errorf("Expected the environment to belong to the pod selfhosted but got %s", env.Pod)
+		}
+	}
+
+	err := CmdList(true, settings, New(settings), invites.New(settings))
 
 	// assert
 	if err != nil {
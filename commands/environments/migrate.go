@@ -0,0 +1,368 @@
+package environments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/db"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/sites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/crypto"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// migrateEnv is the source or target environment of a migration
+type migrateEnv struct {
+	cloneEnv
+	id     db.IDb
+	ij     jobs.IJobs
+	isites sites.ISites
+}
+
+// newMigrateEnv returns a migrateEnv for the given environment which does not
+// need to be associated
+func newMigrateEnv(env *models.Environment, settings *models.Settings) migrateEnv {
+	s := *settings
+	s.EnvironmentID = env.ID
+	s.EnvironmentName = env.Name
+	s.Pod = env.Pod
+	s.OrgID = env.OrgID
+	ij := jobs.New(&s)
+	return migrateEnv{newCloneEnv(env, settings), db.New(&s, crypto.New(), ij, nil), ij, sites.New(&s)}
+}
+
+// CmdMigrate copies the associated environment into a new environment on
+// another pod. The services, variables, worker scale, certs, and sites are
+// copied as environments clone does, a new backup of every database is
+// imported into the new environment, and a checklist of the steps left to
+// cut over to the new environment is printed. The source environment is not
+// changed.
+func CmdMigrate(envID, toPod, name, checklistPath string, planFlag bool, settings *models.Settings, ie IEnvironments, ip prompts.IPrompts) error {
+	envs, errs := ie.List()
+	for pod, err := range errs {
+		logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
+	}
+	var sourceEnv *models.Environment
+	for i := range *envs {
+		if (*envs)[i].ID == envID {
+			sourceEnv = &(*envs)[i]
+		}
+	}
+	if sourceEnv == nil {
+		return fmt.Errorf("Could not find the associated environment. You can list environments with the \"datica environments list\" command.")
+	}
+	if toPod == sourceEnv.Pod {
+		return fmt.Errorf("\"%s\" is already on the pod %s", sourceEnv.Name, toPod)
+	}
+	if name == "" {
+		name = sourceEnv.Name
+	}
+	for _, env := range *envs {
+		if env.Name == name && env.Pod == toPod {
+			return fmt.Errorf("An environment named \"%s\" already exists on the pod %s. Please choose a different name with --name.", name, toPod)
+		}
+	}
+
+	src := newMigrateEnv(sourceEnv, settings)
+	srcServices, err := src.is.List()
+	if err != nil {
+		return err
+	}
+	ram := 0
+	codeLabels := []string{}
+	databases := []models.Service{}
+	var srcProxy *models.Service
+	for i, svc := range *srcServices {
+		scale := svc.Scale
+		if scale < 1 {
+			scale = 1
+		}
+		ram += svc.Size.RAM * scale
+		if svc.Type == "code" {
+			codeLabels = append(codeLabels, svc.Label)
+		}
+		if services.IsDatabase(&svc) {
+			databases = append(databases, svc)
+		}
+		if svc.Label == "service_proxy" {
+			srcProxy = &(*srcServices)[i]
+		}
+	}
+	plan, err := ie.Plan(sourceEnv.OrgID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Pre-flight checks for migrating \"%s\" from %s to %s (%s plan):", sourceEnv.Name, sourceEnv.Pod, toPod, plan.Name)
+	if failures := printTransferChecks(checkMigrate(sourceEnv, toPod, ram, *settings.Pods, plan)); failures > 0 {
+		return fmt.Errorf("%d pre-flight checks failed, \"%s\" was not migrated", failures, sourceEnv.Name)
+	}
+
+	// every variable is copied, secrets included, since the new environment
+	// replaces the source instead of living next to it
+	copyVars := map[string]map[string]string{}
+	for _, svc := range *srcServices {
+		if svc.Type != "code" {
+			continue
+		}
+		if copyVars[svc.Label], err = src.iv.List(svc.ID); err != nil {
+			return err
+		}
+	}
+	srcSites := []models.Site{}
+	if srcProxy != nil {
+		siteList, err := src.isites.List(srcProxy.ID)
+		if err != nil {
+			return err
+		}
+		srcSites = *siteList
+	}
+
+	dbLabels := []string{}
+	for _, svc := range databases {
+		dbLabels = append(dbLabels, svc.Label)
+	}
+	siteNames := []string{}
+	for _, site := range srcSites {
+		siteNames = append(siteNames, site.Name)
+	}
+	logrus.Printf("\"%s\" will be copied into the new environment \"%s\" on the pod %s", sourceEnv.Name, name, toPod)
+	logrus.Printf("Services: %d", len(*srcServices))
+	logrus.Printf("Databases backed up and imported: %s", listOrNone(dbLabels))
+	logrus.Printf("Sites: %s", listOrNone(siteNames))
+	if planFlag {
+		printPlan(migratePlan(name, toPod, checklistPath, *srcServices, copyVars, srcSites, srcProxy != nil, databases))
+		return nil
+	}
+	if len(databases) > 0 {
+		if err = ip.PHI(); err != nil {
+			return err
+		}
+	}
+	if config.BreakGlassActiveFor(settings, sourceEnv.OrgID) {
+		logrus.Warnf("Migrating \"%s\" without confirmation during break-glass session %s", sourceEnv.Name, settings.BreakGlass.ID)
+	} else if err = ip.YesNo(fmt.Sprintf("\"%s\" keeps running and is not changed by the migration. Would you like to proceed? (y/n) ", sourceEnv.Name)); err != nil {
+		return err
+	}
+
+	logrus.Printf("Creating the environment %s on the pod %s", name, toPod)
+	targetEnv, err := ie.Create(name, sourceEnv.OrgID, toPod)
+	if err != nil {
+		return err
+	}
+	dst := newMigrateEnv(targetEnv, settings)
+	created, results, err := cloneServices(*srcServices, copyVars, src.cloneEnv, dst.cloneEnv)
+	if err != nil {
+		return err
+	}
+	results = append(results, migrateSites(srcSites, *srcServices, created, dst)...)
+	dataFailures := []string{}
+	backedUpAt := time.Now()
+	for _, svc := range databases {
+		logrus.Printf("Copying the data of %s", svc.Label)
+		detail, err := migrateData(svc, created[svc.Label], src, dst, ip)
+		if err != nil {
+			config.Warn(config.WarningPartialFailure, "Failed to copy the data of %s: %s", svc.Label, err)
+			dataFailures = append(dataFailures, svc.Label)
+			results = append(results, cloneResult{"data", svc.Label, false, err.Error()})
+			continue
+		}
+		results = append(results, cloneResult{"data", svc.Label, true, detail})
+	}
+
+	logrus.Printf("Migrated %s into %s on the pod %s\n", sourceEnv.Name, name, toPod)
+	printCloneResults(results)
+	checklist := cutoverChecklist(sourceEnv, targetEnv, codeLabels, dbLabels, srcSites, srcProxy, created["service_proxy"], backedUpAt)
+	logrus.Printf("\nCutover checklist:\n%s", checklist)
+	if checklistPath != "" {
+		if err = ioutil.WriteFile(checklistPath, []byte(checklist), 0644); err != nil {
+			return err
+		}
+		logrus.Printf("The cutover checklist was written to %s", checklistPath)
+	}
+	if len(dataFailures) > 0 {
+		return fmt.Errorf("The data of %s could not be copied. Import it into \"%s\" with \"datica db import\" before cutting over.", strings.Join(dataFailures, ", "), name)
+	}
+	return nil
+}
+
+// checkMigrate runs the pre-flight checks of moving the given environment,
+// whose services use the given GB of RAM, to another pod. The organization
+// runs both environments until the cutover, so its plan needs room for a
+// second copy of the environment.
+func checkMigrate(env *models.Environment, toPod string, ram int, pods []models.Pod, plan *models.OrgPlan) []transferCheck {
+	var source, target *models.Pod
+	for i := range pods {
+		if pods[i].Name == env.Pod {
+			source = &pods[i]
+		}
+		if pods[i].Name == toPod {
+			target = &pods[i]
+		}
+	}
+	names := []string{}
+	for _, p := range pods {
+		names = append(names, p.Name)
+	}
+	podCheck := transferCheck{name: "Target pod", passed: target != nil, detail: fmt.Sprintf("%s exists", toPod)}
+	if target == nil {
+		podCheck.detail = fmt.Sprintf("%s is not one of the pods %s", toPod, strings.Join(names, ", "))
+	}
+	checks := []transferCheck{podCheck}
+
+	phiCheck := transferCheck{name: "PHI", passed: true, detail: fmt.Sprintf("%s is not PHI safe", env.Pod)}
+	if source != nil && source.PHISafe {
+		phiCheck.passed = target != nil && target.PHISafe
+		phiCheck.detail = fmt.Sprintf("%s is PHI safe", toPod)
+		if !phiCheck.passed {
+			phiCheck.detail = fmt.Sprintf("%s is PHI safe but %s is not", env.Pod, toPod)
+		}
+	}
+	checks = append(checks, phiCheck)
+	return append(checks, checkTransfer(&models.Environment{Pod: toPod}, ram, plan)...)
+}
+
+// migratePlan returns every operation of the migration in the order they run
+func migratePlan(name, toPod, checklistPath string, srcServices []models.Service, copyVars map[string]map[string]string, srcSites []models.Site, hasProxy bool, databases []models.Service) []string {
+	operations := []string{fmt.Sprintf("Create the environment %s on the pod %s", name, toPod)}
+	for _, svc := range srcServices {
+		operations = append(operations, fmt.Sprintf("Create the service %s unless the new environment is provisioned with it", svc.Label))
+		if svc.Type != "code" {
+			continue
+		}
+		if len(copyVars[svc.Label]) > 0 {
+			operations = append(operations, fmt.Sprintf("Set %d environment variables on %s", len(copyVars[svc.Label]), svc.Label))
+		}
+		operations = append(operations, fmt.Sprintf("Copy the worker scale of %s", svc.Label))
+	}
+	if hasProxy {
+		operations = append(operations, "Copy the certs of the service_proxy")
+	}
+	for _, site := range srcSites {
+		operations = append(operations, fmt.Sprintf("Create the site %s", site.Name))
+	}
+	for _, svc := range databases {
+		operations = append(operations, fmt.Sprintf("Back up %s and import the backup into %s of the new environment", svc.Label, svc.Label))
+	}
+	operations = append(operations, "Print the cutover checklist")
+	if checklistPath != "" {
+		operations = append(operations, fmt.Sprintf("Write the cutover checklist to %s", checklistPath))
+	}
+	return operations
+}
+
+// migrateSites creates the sites of the source service proxy on the service
+// proxy of the target environment, routed to the services with the same
+// labels. The certs of the sites were copied with the services.
+func migrateSites(srcSites []models.Site, srcServices []models.Service, created map[string]*models.Service, dst migrateEnv) []cloneResult {
+	if len(srcSites) == 0 {
+		return nil
+	}
+	proxy := created["service_proxy"]
+	if proxy == nil {
+		return []cloneResult{{"site", "*", false, "the new environment has no service_proxy"}}
+	}
+	labels := map[string]string{}
+	for _, svc := range srcServices {
+		labels[svc.ID] = svc.Label
+	}
+	results := []cloneResult{}
+	for _, site := range srcSites {
+		upstream := created[labels[site.UpstreamService]]
+		if upstream == nil {
+			results = append(results, cloneResult{"site", site.Name, false, "the service it routes to was not created"})
+			continue
+		}
+		if _, err := dst.isites.Create(site.Name, site.Cert, upstream.ID, proxy.ID, site.SiteValues); err != nil {
+			results = append(results, cloneResult{"site", site.Name, false, err.Error()})
+			continue
+		}
+		results = append(results, cloneResult{"site", site.Name, true, ""})
+	}
+	return results
+}
+
+// migrateData takes a new backup of the source database, downloads it, and
+// imports it into the database of the same name in the target environment
+func migrateData(svc models.Service, target *models.Service, src, dst migrateEnv, ip prompts.IPrompts) (string, error) {
+	if target == nil {
+		return "", fmt.Errorf("the service was not created in the new environment")
+	}
+	job, err := src.id.Backup(&svc)
+	if err != nil {
+		return "", err
+	}
+	logrus.Printf("Backup of %s started (job ID = %s)", svc.Label, job.ID)
+	if job.IsSnapshotBackup != nil && *job.IsSnapshotBackup {
+		if err = src.ij.WaitToAppear(job.ID, svc.ID); err != nil {
+			return "", err
+		}
+	}
+	status, err := src.ij.PollTillFinished(job.ID, svc.ID)
+	if err != nil {
+		return "", err
+	}
+	if status != "finished" {
+		return "", fmt.Errorf("the backup ended in status '%s'", status)
+	}
+	dir, err := ioutil.TempDir("", "datica-migrate")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, job.ID)
+	if err = src.id.Download(job.ID, filePath, &svc); err != nil {
+		return "", err
+	}
+	// the new database has no data yet, so it is not backed up first
+	if err = db.CmdImport(target.Label, filePath, "", "", true, false, dst.id, ip, dst.is, dst.ij); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("backup %s", job.ID), nil
+}
+
+// cutoverChecklist returns the numbered steps left to move traffic and writes
+// from the source environment to the target environment
+func cutoverChecklist(sourceEnv, targetEnv *models.Environment, codeLabels, dbLabels []string, srcSites []models.Site, srcProxy, dstProxy *models.Service, backedUpAt time.Time) string {
+	steps := []string{}
+	if len(codeLabels) > 0 {
+		steps = append(steps, fmt.Sprintf("Deploy the code of %s to \"%s\". Run \"datica associate %s\" from each code repo and push to the new git remote.", strings.Join(codeLabels, ", "), targetEnv.Name, targetEnv.Name))
+		for _, label := range codeLabels {
+			steps = append(steps, fmt.Sprintf("Stop writes to the source with \"datica -E \\\"%s\\\" maintenance enable %s\".", sourceEnv.Name, label))
+		}
+	}
+	if len(dbLabels) > 0 {
+		steps = append(steps, fmt.Sprintf("The data of %s was copied from backups taken at %s. Once writes are stopped, copy any newer data with \"datica db export\" and \"datica db import\".", strings.Join(dbLabels, ", "), config.FormatTimestamp(backedUpAt)))
+	}
+	newIP := fmt.Sprintf("the load balancer of the service_proxy of \"%s\", see \"datica -E \\\"%s\\\" services list\"", targetEnv.Name, targetEnv.Name)
+	if dstProxy != nil && dstProxy.LBIP != "" {
+		newIP = dstProxy.LBIP
+	}
+	for _, site := range srcSites {
+		step := fmt.Sprintf("Point the DNS record of %s to %s", site.Name, newIP)
+		if srcProxy != nil && srcProxy.LBIP != "" {
+			step += fmt.Sprintf(" (currently %s)", srcProxy.LBIP)
+		}
+		steps = append(steps, step+".")
+	}
+	steps = append(steps, fmt.Sprintf("Once \"%s\" serves all traffic, contact Datica support to remove \"%s\" from the pod %s.", targetEnv.Name, sourceEnv.Name, sourceEnv.Pod))
+	lines := []string{}
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("%d. [ ] %s", i+1, step))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
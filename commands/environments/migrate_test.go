@@ -0,0 +1,198 @@
+package environments
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const migratedEnvID = "migrated"
+
+var migrateTests = []struct {
+	toPod        string
+	name         string
+	withDatabase bool
+	plan         string
+	planFlag     bool
+	expectErr    bool
+}{
+	{test.PodAlt, "", false, `{"name":"pro"}`, false, false},
+	{test.PodAlt, "production-pod2", false, `{"name":"pro","pods":["pod1","pod2"]}`, false, false},
+	{test.PodAlt, "", true, `{"name":"pro"}`, false, true},
+	{test.PodAlt, "", true, `{"name":"pro"}`, true, false},
+	{test.Pod, "", false, `{"name":"pro"}`, false, true},
+	{"pod9", "", false, `{"name":"pro"}`, false, true},
+	{test.PodAlt, "", false, `{"name":"basic","environmentLimit":1,"environments":1}`, false, true},
+	{test.PodAlt, "", false, `{"name":"basic","pods":["pod1"]}`, false, true},
+	{test.PodAlt, test.EnvNameAlt, false, `{"name":"pro"}`, false, true},
+}
+
+func TestMigrate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	dir, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	plan := ""
+	withDatabase := false
+	created := false
+	createdSites := []models.Site{}
+	var setVars map[string]string
+	mux.HandleFunc("/environments",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				test.AssertEquals(t, test.PodAlt, r.Header.Get("X-Pod-ID"))
+				var env models.Environment
+				json.NewDecoder(r.Body).Decode(&env)
+				created = true
+				fmt.Fprintf(w, `{"id":"%s","name":"%s","organizationId":"%s"}`, migratedEnvID, env.Name, test.OrgID)
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			if r.Header.Get("X-Pod-ID") == test.Pod {
+				fmt.Fprintf(w, `[{"id":"%s","name":"%s","organizationId":"%s"}]`, test.EnvID, test.EnvName, test.OrgID)
+			} else {
+				fmt.Fprintf(w, `[{"id":"%s","name":"%s","organizationId":"%s"}]`, test.EnvIDAlt, test.EnvNameAlt, test.OrgID)
+			}
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/plan",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, plan)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			db := ""
+			if withDatabase {
+				db = `,{"id":"db1","label":"db01","name":"postgresql","type":"database"}`
+			}
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","name":"code","type":"code","size":{"ram":1}},{"id":"proxy","label":"service_proxy","name":"service_proxy","type":"utility","load_balancer_ip":"10.0.0.1"}%s]`, test.SvcID, test.SvcLabel, db)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/env",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"DATABASE_URL":"postgres://db","API_TOKEN":"abc123"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"workers":{}}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/proxy/certs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[]`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/proxy/sites",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":1,"name":"app.example.com","cert":"example.com","upstreamService":"%s"}]`, test.SvcID)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/db1/backup",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, `{"id":"backup1","status":"running"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/db1/jobs/backup1",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"id":"backup1","status":"failed"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+migratedEnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				var svc models.Service
+				json.NewDecoder(r.Body).Decode(&svc)
+				fmt.Fprintf(w, `{"id":"migrated-%s","label":"%s","name":"%s","type":"%s"}`, svc.Label, svc.Label, svc.Name, svc.Type)
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"migrated-proxy","label":"service_proxy","name":"service_proxy","type":"utility","load_balancer_ip":"10.1.0.1"}]`)
+		},
+	)
+	mux.HandleFunc("/environments/"+migratedEnvID+"/services/migrated-"+test.SvcLabel+"/env",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			json.NewDecoder(r.Body).Decode(&setVars)
+		},
+	)
+	mux.HandleFunc("/environments/"+migratedEnvID+"/services/migrated-proxy/sites",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			var site models.Site
+			json.NewDecoder(r.Body).Decode(&site)
+			createdSites = append(createdSites, site)
+			fmt.Fprint(w, `{"id":2}`)
+		},
+	)
+
+	for _, data := range migrateTests {
+		t.Logf("Data: %+v", data)
+		plan = data.plan
+		withDatabase = data.withDatabase
+		created = false
+		createdSites = []models.Site{}
+		setVars = nil
+		checklistPath := filepath.Join(dir, "cutover.md")
+		os.Remove(checklistPath)
+
+		// test
+		err := CmdMigrate(test.EnvID, data.toPod, data.name, checklistPath, data.planFlag, settings, New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if data.planFlag || (data.expectErr && !data.withDatabase) {
+			if created {
+				t.Errorf("Expected no environment to be created but one was")
+			}
+			continue
+		}
+		if !created {
+			t.Errorf("Expected the environment to be created but it was not")
+			continue
+		}
+		if setVars["API_TOKEN"] != "abc123" || setVars["DATABASE_URL"] != "postgres://db" {
+			t.Errorf("Expected every variable to be copied but got %v", setVars)
+		}
+		if len(createdSites) != 1 || createdSites[0].UpstreamService != "migrated-"+test.SvcLabel {
+			t.Errorf("Expected app.example.com to route to the migrated service but got %+v", createdSites)
+		}
+		b, err := ioutil.ReadFile(checklistPath)
+		if err != nil {
+			t.Errorf("Expected the checklist to be written: %s", err)
+			continue
+		}
+		checklist := string(b)
+		if !strings.Contains(checklist, "Point the DNS record of app.example.com to 10.1.0.1 (currently 10.0.0.1)") {
+			t.Errorf("Expected the checklist to include the DNS change but got %s", checklist)
+		}
+		if data.withDatabase != strings.Contains(checklist, "db01") {
+			t.Errorf("Unexpected database steps in the checklist %s", checklist)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package environments
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// printPlan prints the numbered operations a composite command runs, in the
+// order they run. It is printed by --plan instead of running anything.
+func printPlan(operations []string) {
+	logrus.Println("\nPlan:")
+	for i, op := range operations {
+		logrus.Println(fmt.Sprintf("%d. %s", i+1, op))
+	}
+}
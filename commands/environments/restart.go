@@ -0,0 +1,223 @@
+package environments
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/worker"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// restartStep is a single service, or a single worker target of a code
+// service, to restart
+type restartStep struct {
+	service *models.Service
+	target  string
+}
+
+func (s restartStep) String() string {
+	if s.target != "" {
+		return fmt.Sprintf("%s (%s)", s.service.Label, s.target)
+	}
+	return s.service.Label
+}
+
+// restartTier is a group of steps that are restarted together. Every step of
+// a tier must be running again before the next tier is restarted.
+type restartTier struct {
+	name  string
+	steps []restartStep
+}
+
+// CmdRestart restarts every service of the environment in dependency order,
+// databases first, then caches, then code services, and their workers last,
+// waiting for each tier to be running before the next one is restarted. With
+// planFlag, the operations of the restart are printed and nothing is
+// restarted.
+func CmdRestart(envID string, planFlag bool, ie IEnvironments, is services.IServices, iw worker.IWorker, ij jobs.IJobs, ip prompts.IPrompts) error {
+	env, err := ie.Retrieve(envID)
+	if err != nil {
+		return err
+	}
+	svcs, err := is.List()
+	if err != nil {
+		return err
+	}
+	tiers, skipped, err := restartTiers(*svcs, iw)
+	if err != nil {
+		return err
+	}
+	total := 0
+	logrus.Printf("Services of environment %s (ID = %s) will be restarted in this order:", env.Name, env.ID)
+	for i, tier := range tiers {
+		if len(tier.steps) == 0 {
+			continue
+		}
+		names := []string{}
+		for _, step := range tier.steps {
+			names = append(names, step.String())
+		}
+		logrus.Printf("%d. %s: %s", i+1, tier.name, strings.Join(names, ", "))
+		total += len(tier.steps)
+	}
+	if len(skipped) > 0 {
+		logrus.Printf("These services will not be restarted: %s", strings.Join(skipped, ", "))
+	}
+	if total == 0 {
+		logrus.Printf("There are no services to restart in environment %s", env.Name)
+		return nil
+	}
+	if planFlag {
+		printPlan(restartPlan(tiers))
+		return nil
+	}
+	err = ip.YesNo(fmt.Sprintf("Restarting %s will briefly interrupt each service as it is replaced, would you like to proceed? (y/n) ", env.Name))
+	if err != nil {
+		return err
+	}
+	for i, tier := range tiers {
+		if len(tier.steps) == 0 {
+			continue
+		}
+		logrus.Printf("Restarting %s", tier.name)
+		if err = restartTierSteps(tier, ij); err != nil {
+			remaining := []string{}
+			for _, t := range tiers[i+1:] {
+				if len(t.steps) > 0 {
+					remaining = append(remaining, t.name)
+				}
+			}
+			if len(remaining) > 0 {
+				return fmt.Errorf("%s. The %s were not restarted.", err, strings.Join(remaining, " and "))
+			}
+			return err
+		}
+	}
+	logrus.Printf("Successfully restarted %d services and worker targets in environment %s", total, env.Name)
+	return nil
+}
+
+// restartTiers sorts the services into the tiers they are restarted in and
+// returns the labels of the services that are not restarted
+func restartTiers(svcs []models.Service, iw worker.IWorker) ([]restartTier, []string, error) {
+	databases := restartTier{name: "databases"}
+	caches := restartTier{name: "caches"}
+	code := restartTier{name: "code services"}
+	workers := restartTier{name: "workers"}
+	skipped := []string{}
+	for i := range svcs {
+		service := &svcs[i]
+		if !service.Redeployable {
+			skipped = append(skipped, service.Label)
+			continue
+		}
+		switch {
+		case services.IsDatabase(service):
+			databases.steps = append(databases.steps, restartStep{service: service})
+		case services.IsCache(service):
+			caches.steps = append(caches.steps, restartStep{service: service})
+		case service.Type == "code":
+			code.steps = append(code.steps, restartStep{service: service})
+			w, err := iw.Retrieve(service.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			targets := []string{}
+			for target, scale := range w.Workers {
+				if scale > 0 {
+					targets = append(targets, target)
+				}
+			}
+			sort.Strings(targets)
+			for _, target := range targets {
+				workers.steps = append(workers.steps, restartStep{service: service, target: target})
+			}
+		default:
+			skipped = append(skipped, service.Label)
+		}
+	}
+	sort.Strings(skipped)
+	return []restartTier{databases, caches, code, workers}, skipped, nil
+}
+
+// restartPlan returns every operation of the restart in the order they run
+func restartPlan(tiers []restartTier) []string {
+	operations := []string{}
+	for _, tier := range tiers {
+		if len(tier.steps) == 0 {
+			continue
+		}
+		for _, step := range tier.steps {
+			if step.target == "" {
+				operations = append(operations, fmt.Sprintf("Redeploy %s", step.service.Label))
+			} else {
+				operations = append(operations, fmt.Sprintf("Stop the running jobs of %s and deploy them again", step))
+			}
+		}
+		operations = append(operations, fmt.Sprintf("Wait for the %s to be running", tier.name))
+	}
+	return operations
+}
+
+// restartTierSteps starts every step of the tier and then waits until each of
+// them is running. Services are redeployed and the running jobs of worker
+// targets are replaced.
+func restartTierSteps(tier restartTier, ij jobs.IJobs) error {
+	started := map[int]*models.Job{}
+	for i, step := range tier.steps {
+		var job *models.Job
+		var err error
+		if step.target == "" {
+			job, err = ij.Redeploy(step.service.ID, "")
+		} else {
+			job, err = restartTarget(step.service.ID, step.target, ij)
+		}
+		if err != nil {
+			return fmt.Errorf("Could not restart %s: %s", step, err)
+		}
+		started[i] = job
+	}
+	for i, step := range tier.steps {
+		job := started[i]
+		if job.ID == "" {
+			logrus.Printf("%s restarted", step)
+			continue
+		}
+		jobType := job.Type
+		if step.target != "" {
+			jobType = "worker"
+		}
+		status, err := ij.PollForStatus(jobs.TerminalStatuses(jobType), job.ID, step.service.ID)
+		if err != nil {
+			return fmt.Errorf("Could not wait for %s to restart: %s", step, err)
+		}
+		if status != "running" && status != "finished" {
+			return fmt.Errorf("%s ended in status '%s' after restarting (job ID = %s)", step, status, job.ID)
+		}
+		logrus.Printf("\n%s is %s", step, status)
+	}
+	return nil
+}
+
+// restartTarget stops the running jobs of the worker target and deploys it
+// again, which brings it back up to its scale
+func restartTarget(svcID, target string, ij jobs.IJobs) (*models.Job, error) {
+	targetJobs, err := ij.RetrieveByTarget(svcID, target)
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range *targetJobs {
+		if j.Status != "running" {
+			continue
+		}
+		if err = ij.Delete(j.ID, svcID); err != nil {
+			return nil, err
+		}
+	}
+	return ij.DeployTarget(target, svcID)
+}
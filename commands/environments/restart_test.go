@@ -0,0 +1,95 @@
+package environments
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/worker"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var restartTests = []struct {
+	failing     string
+	plan        bool
+	expectOrder string
+	expectErr   bool
+}{
+	{"", false, "db1,cache1,code1,code1:worker", false},
+	{"cache1", false, "db1,cache1", true},
+	{"", true, "", false},
+}
+
+func TestRestart(t *testing.T) {
+	for _, data := range restartTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		mux.HandleFunc("/environments/"+test.EnvID,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `{"id":"%s","name":"%s"}`, test.EnvID, test.EnvName)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, `[{"id":"code1","label":"app01","name":"code","type":"code","redeployable":true},{"id":"proxy1","label":"service_proxy","name":"service_proxy","redeployable":false},{"id":"cache1","label":"redis01","name":"redis","redeployable":true},{"id":"db1","label":"db01","name":"postgresql","redeployable":true}]`)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/code1/workers",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, `{"workers":{"worker":1,"paused":0}}`)
+			},
+		)
+		var lock sync.Mutex
+		order := []string{}
+		for _, svcID := range []string{"db1", "cache1", "code1"} {
+			svcID := svcID
+			mux.HandleFunc("/environments/"+test.EnvID+"/services/"+svcID+"/deploy",
+				func(w http.ResponseWriter, r *http.Request) {
+					test.AssertEquals(t, r.Method, "POST")
+					lock.Lock()
+					defer lock.Unlock()
+					step := svcID
+					if target := r.URL.Query().Get("target"); target != "" {
+						step += ":" + target
+					}
+					order = append(order, step)
+					fmt.Fprintf(w, `{"id":"%s-job","type":"deploy","status":"scheduled"}`, svcID)
+				},
+			)
+			mux.HandleFunc("/environments/"+test.EnvID+"/services/"+svcID+"/jobs/"+svcID+"-job",
+				func(w http.ResponseWriter, r *http.Request) {
+					test.AssertEquals(t, r.Method, "GET")
+					status := "running"
+					if svcID == data.failing {
+						status = "failed"
+					}
+					fmt.Fprintf(w, `{"id":"%s-job","type":"deploy","status":"%s"}`, svcID, status)
+				},
+			)
+		}
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/code1/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, `[]`)
+			},
+		)
+
+		// test
+		err := CmdRestart(test.EnvID, data.plan, New(settings), services.New(settings), worker.New(settings), jobs.New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, data.expectOrder, strings.Join(order, ","))
+		test.Teardown(server)
+	}
+}
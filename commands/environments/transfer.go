@@ -0,0 +1,214 @@
+package environments
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// transferCheck is a single pre-flight check of a transfer
+type transferCheck struct {
+	name   string
+	passed bool
+	detail string
+}
+
+// CmdTransfer moves the environment with the given name into the
+// organization with the given name or ID. The transfer is only made once
+// every pre-flight check passes and the user confirms it by typing the name
+// of the environment, or gives it as confirm. The confirmation is skipped
+// during a break-glass session of the environment's organization.
+func CmdTransfer(envName, toOrg, confirm string, settings *models.Settings, ie IEnvironments, ii invites.IInvites, ip prompts.IPrompts) error {
+	if toOrg == "" {
+		return fmt.Errorf("The organization to transfer the environment to must be given with --to-org")
+	}
+	envs, errs := ie.List()
+	for pod, err := range errs {
+		logrus.Debugf("Failed to list environments for pod \"%s\": %s", pod, err)
+	}
+	var env *models.Environment
+	for i := range *envs {
+		if (*envs)[i].Name == envName {
+			env = &(*envs)[i]
+			break
+		}
+	}
+	if env == nil {
+		return fmt.Errorf("Could not find an environment named \"%s\". You can list environments with the \"datica environments list\" command.", envName)
+	}
+
+	orgs, err := ii.ListOrgs()
+	if err != nil {
+		return err
+	}
+	var dest *models.Org
+	names := []string{}
+	for i, o := range *orgs {
+		if strings.EqualFold(o.Name, toOrg) || o.ID == toOrg {
+			dest = &(*orgs)[i]
+		}
+		names = append(names, o.Name)
+	}
+	if dest == nil {
+		return fmt.Errorf("Could not find an organization named \"%s\". Your organizations are: %s", toOrg, strings.Join(names, ", "))
+	}
+	if dest.ID == env.OrgID {
+		return fmt.Errorf("The environment \"%s\" already belongs to %s", env.Name, dest.Name)
+	}
+
+	svcs, err := services.New(settings).ListByEnvID(env.ID, env.Pod)
+	if err != nil {
+		return err
+	}
+	ram := 0
+	for _, svc := range *svcs {
+		scale := svc.Scale
+		if scale < 1 {
+			scale = 1
+		}
+		ram += svc.Size.RAM * scale
+	}
+	plan, err := ie.Plan(dest.ID)
+	if err != nil {
+		return err
+	}
+
+	checks := checkTransfer(env, ram, plan)
+	logrus.Printf("Pre-flight checks for transferring \"%s\" to %s (%s plan):", env.Name, dest.Name, plan.Name)
+	if failures := printTransferChecks(checks); failures > 0 {
+		return fmt.Errorf("%d pre-flight checks failed, \"%s\" was not transferred. An owner of %s can upgrade its plan to make room for it.", failures, env.Name, dest.Name)
+	}
+
+	if confirm != "" {
+		if confirm != env.Name {
+			return fmt.Errorf("\"%s\" given with --confirm does not match the name of the environment \"%s\"", confirm, env.Name)
+		}
+	} else if config.BreakGlassActiveFor(settings, env.OrgID) {
+		logrus.Warnf("Transferring \"%s\" without confirmation during break-glass session %s", env.Name, settings.BreakGlass.ID)
+	} else {
+		if prompts.NonInteractive() {
+			return fmt.Errorf("Unable to confirm the transfer because the CLI is running non-interactively. Give the name of the environment with --confirm instead")
+		}
+		msg := fmt.Sprintf("\nBilling and access of \"%s\" will move to %s, and members of its current organization will lose access unless they also belong to %s.\nType the name of the environment to confirm: ", env.Name, dest.Name, dest.Name)
+		if err = ip.ConfirmName(msg, env.Name); err != nil {
+			return err
+		}
+	}
+
+	if _, err = ie.Transfer(env.ID, env.Pod, dest.ID); err != nil {
+		return err
+	}
+	for alias, a := range settings.Environments {
+		if a.EnvironmentID == env.ID {
+			a.OrgID = dest.ID
+			settings.Environments[alias] = a
+		}
+	}
+	logrus.Printf("\"%s\" has been transferred to %s", env.Name, dest.Name)
+	return nil
+}
+
+// checkTransfer runs the pre-flight checks of moving the given environment,
+// whose services use the given GB of RAM, into an organization with the given
+// plan
+func checkTransfer(env *models.Environment, ram int, plan *models.OrgPlan) []transferCheck {
+	checks := []transferCheck{}
+
+	envCheck := transferCheck{name: "Environments", passed: true, detail: fmt.Sprintf("%d in use, no limit", plan.Environments)}
+	if plan.EnvironmentLimit > 0 {
+		envCheck.passed = plan.Environments < plan.EnvironmentLimit
+		envCheck.detail = fmt.Sprintf("%d of %d in use", plan.Environments, plan.EnvironmentLimit)
+	}
+	checks = append(checks, envCheck)
+
+	ramCheck := transferCheck{name: "RAM", passed: true, detail: fmt.Sprintf("%d GB needed, no limit", ram)}
+	if plan.RAMLimit > 0 {
+		ramCheck.passed = plan.RAM+ram <= plan.RAMLimit
+		ramCheck.detail = fmt.Sprintf("%d GB needed, %d of %d GB in use", ram, plan.RAM, plan.RAMLimit)
+	}
+	checks = append(checks, ramCheck)
+
+	podCheck := transferCheck{name: "Pod", passed: true, detail: fmt.Sprintf("%s is allowed", env.Pod)}
+	if len(plan.Pods) > 0 {
+		podCheck.passed = false
+		for _, pod := range plan.Pods {
+			if pod == env.Pod {
+				podCheck.passed = true
+			}
+		}
+		if !podCheck.passed {
+			podCheck.detail = fmt.Sprintf("%s is not one of the allowed pods %s", env.Pod, strings.Join(plan.Pods, ", "))
+		}
+	}
+	checks = append(checks, podCheck)
+	return checks
+}
+
+// printTransferChecks prints a table of the pre-flight checks and returns the
+// number of checks that failed
+func printTransferChecks(checks []transferCheck) int {
+	data := [][]string{{"CHECK", "RESULT", "DETAIL"}}
+	failures := 0
+	for _, c := range checks {
+		result := "OK"
+		if !c.passed {
+			result = "FAILED"
+			failures++
+		}
+		data = append(data, []string{c.name, result, c.detail})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return failures
+}
+
+// Plan retrieves the plan of the organization with the given ID
+func (e *SEnvironments) Plan(orgID string) (*models.OrgPlan, error) {
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, e.Settings.Pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/plan", e.Settings.PaasHost, e.Settings.PaasHostVersion, orgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var plan models.OrgPlan
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// Transfer moves the environment with the given ID on the given pod into the
+// organization with the given ID
+func (e *SEnvironments) Transfer(envID, pod, orgID string) (*models.Environment, error) {
+	b, err := json.Marshal(map[string]string{"organizationId": orgID})
+	if err != nil {
+		return nil, err
+	}
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/transfer", e.Settings.PaasHost, e.Settings.PaasHostVersion, envID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var env models.Environment
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &env)
+	if err != nil {
+		return nil, err
+	}
+	env.Pod = pod
+	return &env, nil
+}
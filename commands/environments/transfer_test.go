@@ -0,0 +1,109 @@
+package environments
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var transferTests = []struct {
+	envName        string
+	toOrg          string
+	confirm        string
+	plan           string
+	breakGlass     bool
+	nonInteractive bool
+	expectTransfer bool
+	expectErr      bool
+}{
+	{test.EnvName, "other org", "", `{"name":"pro","environmentLimit":5,"environments":1,"ramLimit":16,"ram":4}`, false, false, true, false},
+	{test.EnvName, "other org", test.EnvName, `{"name":"pro","pods":["pod1"]}`, false, false, true, false},
+	{test.EnvName, "other org", "wrong-name", `{"name":"pro"}`, false, false, false, true},
+	{test.EnvName, "other org", "", `{"name":"basic","environmentLimit":1,"environments":1}`, false, false, false, true},
+	{test.EnvName, "other org", "", `{"name":"basic","ramLimit":4,"ram":2}`, false, false, false, true},
+	{test.EnvName, "other org", "", `{"name":"basic","pods":["pod2"]}`, false, false, false, true},
+	{test.EnvName, "my org", "", `{"name":"pro"}`, false, false, false, true},
+	{test.EnvName, "unknown org", "", `{"name":"pro"}`, false, false, false, true},
+	{"invalid-env", "other org", "", `{"name":"pro"}`, false, false, false, true},
+	{test.EnvName, "other org", "", `{"name":"pro"}`, false, true, false, true},
+	{test.EnvName, "other org", "", `{"name":"pro"}`, true, true, true, false},
+	{test.EnvName, "other org", "wrong-name", `{"name":"pro"}`, true, true, false, true},
+}
+
+func TestTransfer(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	plan := ""
+	transferred := false
+	mux.HandleFunc("/environments",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			if r.Header.Get("X-Pod-ID") == test.Pod {
+				fmt.Fprintf(w, `[{"id":"%s","name":"%s","organizationId":"%s"}]`, test.EnvID, test.EnvName, test.OrgID)
+			} else {
+				fmt.Fprint(w, `[]`)
+			}
+		},
+	)
+	mux.HandleFunc("/orgs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","name":"my org"},{"id":"%s","name":"other org"}]`, test.OrgID, test.OrgIDAlt)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code","size":{"ram":1},"scale":2},{"id":"db","label":"db01","type":"database","size":{"ram":2}}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgIDAlt+"/plan",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, plan)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/transfer",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			test.AssertEquals(t, test.Pod, r.Header.Get("X-Pod-ID"))
+			transferred = true
+			fmt.Fprintf(w, `{"id":"%s","name":"%s","organizationId":"%s"}`, test.EnvID, test.EnvName, test.OrgIDAlt)
+		},
+	)
+
+	for _, data := range transferTests {
+		t.Logf("Data: %+v", data)
+		plan = data.plan
+		transferred = false
+		settings.Environments = map[string]models.AssociatedEnv{
+			test.Alias: {EnvironmentID: test.EnvID, Name: test.EnvName, OrgID: test.OrgID, Pod: test.Pod},
+		}
+		settings.BreakGlass = nil
+		if data.breakGlass {
+			settings.BreakGlass = &models.BreakGlassSession{ID: "bg1", OrgID: test.OrgID, ExpiresAt: time.Now().Add(time.Hour).Format(time.RFC3339)}
+		}
+		prompts.SetNonInteractive(data.nonInteractive)
+
+		// test
+		err := CmdTransfer(data.envName, data.toOrg, data.confirm, settings, New(settings), invites.New(settings), &test.FakePrompts{})
+		prompts.SetNonInteractive(false)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectTransfer), fmt.Sprintf("%t", transferred))
+		if data.expectTransfer {
+			test.AssertEquals(t, test.OrgIDAlt, settings.Environments[test.Alias].OrgID)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package es
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "es",
+	ShortHelp: "Administer Elasticsearch services",
+	LongHelp: "The `es` command gives access to common maintenance tasks for Elasticsearch services such as checking cluster health, listing indices, reindexing, and taking snapshots. " +
+		"All requests are tunneled through the Datica platform so no console session is required. " +
+		"The es command can not be run directly but has sub commands.",
+	Category: models.CategoryData,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(HealthSubCmd.Name, HealthSubCmd.ShortHelp, help.Render(HealthSubCmd.LongHelp), HealthSubCmd.CmdFunc(settings))
+			cmd.CommandLong(IndicesSubCmd.Name, IndicesSubCmd.ShortHelp, help.Render(IndicesSubCmd.LongHelp), IndicesSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ReindexSubCmd.Name, ReindexSubCmd.ShortHelp, help.Render(ReindexSubCmd.LongHelp), ReindexSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SnapshotSubCmd.Name, SnapshotSubCmd.ShortHelp, help.Render(SnapshotSubCmd.LongHelp), SnapshotSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var HealthSubCmd = models.Command{
+	Name:      "health",
+	ShortHelp: "Print the cluster health of an Elasticsearch service",
+	LongHelp: "`es health` prints the cluster health of the given Elasticsearch service including its status, node count, and shard allocation. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" es health search01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the Elasticsearch service (i.e. 'search01')")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdHealth(*serviceName, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME"
+		}
+	},
+}
+
+var IndicesSubCmd = models.Command{
+	Name:      "indices",
+	ShortHelp: "Manage indices of an Elasticsearch service",
+	LongHelp:  "`es indices` allows you to inspect the indices of an Elasticsearch service. The indices command can not be run directly but has sub commands.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(IndicesListSubCmd.Name, IndicesListSubCmd.ShortHelp, help.Render(IndicesListSubCmd.LongHelp), IndicesListSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var IndicesListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List all indices of an Elasticsearch service",
+	LongHelp: "`es indices list` prints every index of the given Elasticsearch service along with its health, document count, and size on disk. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" es indices list search01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the Elasticsearch service (i.e. 'search01')")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdIndicesList(*serviceName, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME"
+		}
+	},
+}
+
+var ReindexSubCmd = models.Command{
+	Name:      "reindex",
+	ShortHelp: "Copy the documents of one index into another",
+	LongHelp: "`es reindex` copies all documents from a source index into a destination index on the given Elasticsearch service. " +
+		"The reindex runs in the background on the service and the ID of the Elasticsearch task performing it is printed once it has started. " +
+		"The destination index is created automatically if it does not already exist. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" es reindex search01 products-v1 products-v2\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the Elasticsearch service (i.e. 'search01')")
+			source := subCmd.StringArg("SOURCE_INDEX", "", "The name of the index to copy documents from")
+			dest := subCmd.StringArg("DEST_INDEX", "", "The name of the index to copy documents into")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdReindex(*serviceName, *source, *dest, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME SOURCE_INDEX DEST_INDEX"
+		}
+	},
+}
+
+var SnapshotSubCmd = models.Command{
+	Name:      "snapshot",
+	ShortHelp: "Take a snapshot of an Elasticsearch service",
+	LongHelp: "`es snapshot` takes a snapshot of all indices of the given Elasticsearch service into a snapshot repository managed by the platform. " +
+		"If no snapshot name is given, one is generated from the current time. " +
+		"Use `-r` to snapshot into a different repository that has already been registered on the service. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" es snapshot search01\n" +
+		"datica -E \"<your_env_alias>\" es snapshot search01 before-upgrade\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the Elasticsearch service (i.e. 'search01')")
+			snapshotName := subCmd.StringArg("SNAPSHOT_NAME", "", "The name of the snapshot to create. Defaults to a name generated from the current time")
+			repository := subCmd.StringOpt("r repository", defaultRepository, "The snapshot repository to store the snapshot in")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdSnapshot(*serviceName, *snapshotName, *repository, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME [SNAPSHOT_NAME] [-r]"
+		}
+	},
+}
+
+// IES
+type IES interface {
+	Health(svcID string) (*models.ESHealth, error)
+	ListIndices(svcID string) (*[]models.ESIndex, error)
+	Reindex(source, dest, svcID string) (string, error)
+	Snapshot(snapshotName, repository, svcID string) error
+}
+
+// SES is a concrete implementation of IES
+type SES struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IES
+func New(settings *models.Settings) IES {
+	return &SES{
+		Settings: settings,
+	}
+}
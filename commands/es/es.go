@@ -0,0 +1,32 @@
+package es
+
+import (
+	"fmt"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+)
+
+const esServiceName = "elasticsearch"
+
+// retrieveService looks up a service by its label and ensures it is an
+// Elasticsearch service.
+func retrieveService(svcName string, is services.IServices) (*models.Service, error) {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if service.Name != esServiceName {
+		return nil, fmt.Errorf("The \"%s\" service is a %s service, not an Elasticsearch service", svcName, service.Name)
+	}
+	return service, nil
+}
+
+// proxyURL builds the URL used to tunnel a request to the Elasticsearch API
+// of the given service through the platform.
+func (e *SES) proxyURL(svcID, path string) string {
+	return fmt.Sprintf("%s%s/environments/%s/services/%s/es/%s", e.Settings.PaasHost, e.Settings.PaasHostVersion, e.Settings.EnvironmentID, svcID, path)
+}
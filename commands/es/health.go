@@ -0,0 +1,44 @@
+package es
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdHealth(svcName string, ie IES, is services.IServices) error {
+	service, err := retrieveService(svcName, is)
+	if err != nil {
+		return err
+	}
+	health, err := ie.Health(service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Cluster:             %s", health.ClusterName)
+	logrus.Printf("Status:              %s", health.Status)
+	logrus.Printf("Nodes:               %d (%d data)", health.NumberOfNodes, health.NumberOfDataNodes)
+	logrus.Printf("Active shards:       %d (%d primary)", health.ActiveShards, health.ActivePrimaryShards)
+	logrus.Printf("Relocating shards:   %d", health.RelocatingShards)
+	logrus.Printf("Initializing shards: %d", health.InitializingShards)
+	logrus.Printf("Unassigned shards:   %d", health.UnassignedShards)
+	if health.Status == "red" {
+		logrus.Warnln("One or more primary shards are not allocated. Some data is unavailable until the cluster recovers.")
+	}
+	return nil
+}
+
+// Health retrieves the cluster health of an Elasticsearch service
+func (e *SES) Health(svcID string) (*models.ESHealth, error) {
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, e.Settings.Pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Get(nil, e.proxyURL(svcID, "_cluster/health"), headers)
+	if err != nil {
+		return nil, err
+	}
+	var health models.ESHealth
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &health)
+	if err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
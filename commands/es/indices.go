@@ -0,0 +1,83 @@
+package es
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdIndicesList(svcName string, ie IES, is services.IServices) error {
+	service, err := retrieveService(svcName, is)
+	if err != nil {
+		return err
+	}
+	indices, err := ie.ListIndices(service.ID)
+	if err != nil {
+		return err
+	}
+	if indices == nil || len(*indices) == 0 {
+		logrus.Println("No indices found")
+		return nil
+	}
+	sort.Sort(SortedIndices(*indices))
+	data := [][]string{{"INDEX", "HEALTH", "STATUS", "DOCS", "SIZE"}}
+	for _, i := range *indices {
+		data = append(data, []string{i.Index, i.Health, i.Status, i.DocsCount, formatStoreSize(i.StoreSize)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// SortedIndices is a wrapper for ESIndex arrays in order to sort them by name
+type SortedIndices []models.ESIndex
+
+func (indices SortedIndices) Len() int {
+	return len(indices)
+}
+
+func (indices SortedIndices) Swap(i, j int) {
+	indices[i], indices[j] = indices[j], indices[i]
+}
+
+func (indices SortedIndices) Less(i, j int) bool {
+	return indices[i].Index < indices[j].Index
+}
+
+// ListIndices lists all indices of an Elasticsearch service
+func (e *SES) ListIndices(svcID string) (*[]models.ESIndex, error) {
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, e.Settings.Pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Get(nil, e.proxyURL(svcID, "_cat/indices?format=json&bytes=b"), headers)
+	if err != nil {
+		return nil, err
+	}
+	var indices []models.ESIndex
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &indices)
+	if err != nil {
+		return nil, err
+	}
+	return &indices, nil
+}
+
+// formatStoreSize formats an index size which is requested from elasticsearch
+// in bytes
+func formatStoreSize(storeSize string) string {
+	size, err := strconv.ParseFloat(storeSize, 64)
+	if err != nil {
+		return storeSize
+	}
+	return config.FormatBytes(size)
+}
@@ -0,0 +1,52 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+)
+
+func CmdReindex(svcName, source, dest string, ie IES, is services.IServices) error {
+	if source == dest {
+		return fmt.Errorf("The source and destination indices must be different")
+	}
+	service, err := retrieveService(svcName, is)
+	if err != nil {
+		return err
+	}
+	taskID, err := ie.Reindex(source, dest, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Reindexing %s into %s (task ID = %s)", source, dest, taskID)
+	logrus.Printf("You can watch the document count of %s grow with the \"datica es indices list %s\" command", dest, svcName)
+	return nil
+}
+
+// Reindex starts a background reindex from the source index into the dest
+// index and returns the ID of the Elasticsearch task performing it.
+func (e *SES) Reindex(source, dest, svcID string) (string, error) {
+	body := map[string]interface{}{
+		"source": map[string]string{"index": source},
+		"dest":   map[string]string{"index": dest},
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, e.Settings.Pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Post(b, e.proxyURL(svcID, "_reindex?wait_for_completion=false"), headers)
+	if err != nil {
+		return "", err
+	}
+	var task struct {
+		Task string `json:"task"`
+	}
+	err = e.Settings.HTTPManager.ConvertResp(resp, statusCode, &task)
+	if err != nil {
+		return "", err
+	}
+	return task.Task, nil
+}
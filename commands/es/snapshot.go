@@ -0,0 +1,58 @@
+package es
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+)
+
+// defaultRepository is the snapshot repository registered on every
+// Elasticsearch service by the platform
+const defaultRepository = "datica"
+
+func CmdSnapshot(svcName, snapshotName, repository string, ie IES, is services.IServices) error {
+	if snapshotName == "" {
+		snapshotName = fmt.Sprintf("snapshot-%s", time.Now().UTC().Format("20060102-150405"))
+	}
+	if err := checkName("snapshot", snapshotName); err != nil {
+		return err
+	}
+	if err := checkName("repository", repository); err != nil {
+		return err
+	}
+	service, err := retrieveService(svcName, is)
+	if err != nil {
+		return err
+	}
+	err = ie.Snapshot(snapshotName, repository, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Snapshot \"%s\" started in repository \"%s\"", snapshotName, repository)
+	return nil
+}
+
+// checkName returns an error if the given snapshot or repository name is not
+// accepted by Elasticsearch
+func checkName(kind, name string) error {
+	if name == "" || name == "." || name == ".." || name != strings.ToLower(name) || strings.ContainsAny(name, " ,\"*\\<|>/?#") {
+		return fmt.Errorf("Invalid %s name \"%s\". Names must be lowercase and must not be empty, contain spaces, or contain any of the following characters: ,\"*\\<|>/?#", kind, name)
+	}
+	return nil
+}
+
+// Snapshot starts a snapshot of all indices of an Elasticsearch service into
+// the given repository. The snapshot continues in the background on the
+// service after this returns.
+func (e *SES) Snapshot(snapshotName, repository, svcID string) error {
+	headers := e.Settings.HTTPManager.GetHeaders(e.Settings.SessionToken, e.Settings.Version, e.Settings.Pod, e.Settings.UsersID)
+	resp, statusCode, err := e.Settings.HTTPManager.Put(nil, e.proxyURL(svcID, fmt.Sprintf("_snapshot/%s/%s", url.PathEscape(repository), url.PathEscape(snapshotName))), headers)
+	if err != nil {
+		return err
+	}
+	return e.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
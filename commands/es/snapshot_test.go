@@ -0,0 +1,73 @@
+package es
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+const esSvcLabel = "search01"
+
+var snapshotTests = []struct {
+	svcName      string
+	snapshotName string
+	repository   string
+	expectPath   string
+	expectErr    bool
+}{
+	{esSvcLabel, "before-upgrade", defaultRepository, "/_snapshot/datica/before-upgrade", false},
+	{esSvcLabel, "", defaultRepository, "/_snapshot/datica/snapshot-", false},
+	{esSvcLabel, "before-upgrade", "backups%2f_all", "/_snapshot/backups%252f_all/before-upgrade", false},
+	{esSvcLabel, "upgrade;v2", defaultRepository, "/_snapshot/datica/upgrade%3Bv2", false},
+	{esSvcLabel, "before-upgrade", "backups/../_all", "", true},
+	{esSvcLabel, "before-upgrade", "nightly backups", "", true},
+	{esSvcLabel, "..", defaultRepository, "", true},
+	{esSvcLabel, "Before-Upgrade", defaultRepository, "", true},
+	{esSvcLabel, "before/upgrade", defaultRepository, "", true},
+	{esSvcLabel, "before upgrade", defaultRepository, "", true},
+	{esSvcLabel, "before-upgrade", "", "", true},
+	{test.SvcLabel, "before-upgrade", defaultRepository, "", true},
+	{"invalid-svc", "before-upgrade", defaultRepository, "", true},
+}
+
+func TestSnapshot(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","name":"code"},{"id":"es1","label":"%s","name":"%s"}]`, test.SvcID, test.SvcLabel, esSvcLabel, esServiceName))
+		},
+	)
+	path := ""
+	prefix := "/environments/" + test.EnvID + "/services/es1/es"
+	mux.HandleFunc(prefix+"/",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			path = strings.TrimPrefix(r.URL.EscapedPath(), prefix)
+			fmt.Fprint(w, `{"accepted":true}`)
+		},
+	)
+
+	for _, data := range snapshotTests {
+		t.Logf("Data: %+v", data)
+		path = ""
+
+		// test
+		err := CmdSnapshot(data.svcName, data.snapshotName, data.repository, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if !strings.HasPrefix(path, data.expectPath) || (data.expectPath == "") != (path == "") {
+			t.Errorf("Expected the snapshot to be requested at %s but got %s", data.expectPath, path)
+		}
+	}
+}
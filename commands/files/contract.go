@@ -5,7 +5,9 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
 )
@@ -18,10 +20,11 @@ var Cmd = models.Command{
 	LongHelp: "The `files` command gives access to service files on your environment's services. " +
 		"Service files can include Nginx configs, SSL certificates, and any other file that might be injected into your running service. " +
 		"The files command can not be run directly but has sub commands.",
+	Category: models.CategoryData,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(DownloadSubCmd.Name, DownloadSubCmd.ShortHelp, DownloadSubCmd.LongHelp, DownloadSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DownloadSubCmd.Name, DownloadSubCmd.ShortHelp, help.Render(DownloadSubCmd.LongHelp), DownloadSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -32,7 +35,8 @@ var DownloadSubCmd = models.Command{
 	LongHelp: "`files download` allows you to view the contents of a service file and save it to your local machine. " +
 		"Most service files are stored on your service_proxy and therefore you should not have to specify the `SERVICE_NAME` argument. " +
 		"Simply supply the `FILE_NAME` found from the [files list](#files-list) command and the contents of the file, as well as the permissions string, will be printed to your console. " +
-		"You can always store the file locally, applying the same permissions as those on the remote server, by specifying an output file with the `-o` flag. Here is a sample command\n\n" +
+		"You can always store the file locally, applying the same permissions as those on the remote server, by specifying an output file with the `-o` flag. " +
+		"Use `--limit-rate` to limit how fast the file is written. Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" files download /etc/nginx/sites-enabled/mywebsite.com\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
@@ -40,6 +44,7 @@ var DownloadSubCmd = models.Command{
 			fileName := subCmd.StringArg("FILE_NAME", "", "The name of the service file from running \"datica files list\"")
 			output := subCmd.StringOpt("o output", "", "The downloaded file will be saved to the given location with the same file permissions as it has on the remote host. If those file permissions cannot be applied, a warning will be printed and default 0644 permissions applied. If no output is specified, stdout is used.")
 			force := subCmd.BoolOpt("f force", false, "If the specified output file already exists, automatically overwrite it")
+			limitRate := subCmd.StringOpt("limit-rate", "", "The maximum transfer rate in bytes per second, such as 500K or 5M")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -47,12 +52,17 @@ var DownloadSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdDownload(*serviceName, *fileName, *output, *force, New(settings), services.New(settings))
+				rate, err := transfer.ParseRate(*limitRate)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetRateLimit(rate)
+				err = CmdDownload(*serviceName, *fileName, *output, *force, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "[SERVICE_NAME] FILE_NAME [-o] [-f]"
+			subCmd.Spec = "[SERVICE_NAME] FILE_NAME [-o] [-f] [--limit-rate]"
 		}
 	},
 }
@@ -5,9 +5,11 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 )
 
@@ -87,6 +89,6 @@ func (f *SFiles) Save(output string, force bool, file *models.ServiceFile) error
 		logrus.Printf("Mode: %s\n\nContent:", fileModeToRWXString(filePerms))
 		wr = os.Stdout
 	}
-	wr.Write([]byte(file.Contents))
-	return nil
+	_, err = io.Copy(wr, transfer.NewReaderTransfer(strings.NewReader(file.Contents), len(file.Contents)))
+	return err
 }
@@ -0,0 +1,219 @@
+package flags
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "flags",
+	ShortHelp: "Manage the feature flags of an environment",
+	LongHelp: "The `flags` command allows you to manage feature flags that are stored by the platform alongside your environment, so no third party service sees your traffic. " +
+		"Every code service targeted by a flag has an environment variable named `DATICA_FLAG_<NAME>`, where `<NAME>` is the upper case flag name with dashes replaced by underscores, set to `true` or `false`. " +
+		"Changes to the environment variables take effect when the service is redeployed. " +
+		"For flags rolled out to a percentage of requests, or to see changes without a redeploy, your application can check a flag at the URL in its `DATICA_FLAGS_URL` environment variable. " +
+		"The flags command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DisableSubCmd.Name, DisableSubCmd.ShortHelp, help.Render(DisableSubCmd.LongHelp), DisableSubCmd.CmdFunc(settings))
+			cmd.CommandLong(EnableSubCmd.Name, EnableSubCmd.ShortHelp, help.Render(EnableSubCmd.LongHelp), EnableSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(TargetingSubCmd.Name, TargetingSubCmd.ShortHelp, help.Render(TargetingSubCmd.LongHelp), TargetingSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Create a feature flag",
+	LongHelp: "`flags create` creates a feature flag that targets every code service and every request. " +
+		"Flags are created disabled unless `--enabled` is given. " +
+		"Flag names may contain lower case letters, numbers, dashes, and underscores. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" flags create new-checkout -d \"The redesigned checkout flow\"\n" +
+		"datica -E \"<your_env_alias>\" flags create audit-export --enabled\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the new feature flag")
+			description := subCmd.StringOpt("d description", "", "A description of what the flag controls")
+			enabled := subCmd.BoolOpt("enabled", false, "Create the flag enabled")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCreate(*name, *description, *enabled, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME [--description] [--enabled]"
+		}
+	},
+}
+
+var DisableSubCmd = models.Command{
+	Name:      "disable",
+	ShortHelp: "Disable a feature flag",
+	LongHelp: "`flags disable` turns a feature flag off for every code service and request it targets. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" flags disable new-checkout\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the feature flag to disable")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdSetEnabled(*name, false, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+var EnableSubCmd = models.Command{
+	Name:      "enable",
+	ShortHelp: "Enable a feature flag",
+	LongHelp: "`flags enable` turns a feature flag on for every code service and request it targets. " +
+		"Use [flags targeting](#flags-targeting) to change what the flag targets. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" flags enable new-checkout\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the feature flag to enable")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdSetEnabled(*name, true, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the feature flags of an environment",
+	LongHelp: "`flags list` lists every feature flag in the environment along with whether it is enabled and what it targets. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" flags list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove a feature flag",
+	LongHelp: "`flags rm` removes a feature flag. " +
+		"Code services stop receiving the flag's environment variable on their next redeploy, so make sure your application no longer reads it. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" flags rm new-checkout\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the feature flag to remove")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRm(*name, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+var TargetingSubCmd = models.Command{
+	Name:      "targeting",
+	ShortHelp: "Change the code services and share of requests a feature flag targets",
+	LongHelp: "`flags targeting` changes what an enabled feature flag targets. " +
+		"Give `--service` once for each code service that should see the flag, or `--all-services` to target every code service again. " +
+		"Give `--percentage` to roll the flag out to a share of requests. " +
+		"Percentage rollouts are only visible to applications that check the flag at the URL in `DATICA_FLAGS_URL`, the environment variable is `true` whenever the flag is enabled. " +
+		"Targeting not given is left unchanged. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" flags targeting new-checkout --service app01 --percentage 10\n" +
+		"datica -E \"<your_env_alias>\" flags targeting new-checkout --all-services --percentage 100\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the feature flag to change the targeting of")
+			svcNames := subCmd.StringsOpt("s service", []string{}, "The name of a code service the flag targets")
+			allServices := subCmd.BoolOpt("all-services", false, "Target every code service")
+			percentage := subCmd.IntOpt("p percentage", -1, "The percentage of requests the flag is enabled for, from 0 to 100")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdTargeting(*name, *svcNames, *allServices, *percentage, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME [--service... | --all-services] [--percentage]"
+		}
+	},
+}
+
+// IFlags
+type IFlags interface {
+	Create(flag *models.FeatureFlag) error
+	List() (*[]models.FeatureFlag, error)
+	Retrieve(name string) (*models.FeatureFlag, error)
+	Rm(name string) error
+	Update(flag *models.FeatureFlag) error
+}
+
+// SFlags is a concrete implementation of IFlags
+type SFlags struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IFlags
+func New(settings *models.Settings) IFlags {
+	return &SFlags{
+		Settings: settings,
+	}
+}
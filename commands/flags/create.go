@@ -0,0 +1,46 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+)
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+func CmdCreate(name, description string, enabled bool, iflags IFlags) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("\"%s\" is not a valid flag name. Flag names may contain lower case letters, numbers, dashes, and underscores", name)
+	}
+	flag := &models.FeatureFlag{
+		Name:        name,
+		Description: description,
+		Enabled:     enabled,
+		Targeting:   models.FlagTargeting{Percentage: 100},
+	}
+	if err := iflags.Create(flag); err != nil {
+		return err
+	}
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	logrus.Printf("Created the %s flag (%s). Code services see it as %s after their next redeploy.", name, state, EnvVarName(name))
+	return nil
+}
+
+func (f *SFlags) Create(flag *models.FeatureFlag) error {
+	b, err := json.Marshal(flag)
+	if err != nil {
+		return err
+	}
+	headers := f.Settings.HTTPManager.GetHeaders(f.Settings.SessionToken, f.Settings.Version, f.Settings.Pod, f.Settings.UsersID)
+	resp, statusCode, err := f.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/flags", f.Settings.PaasHost, f.Settings.PaasHostVersion, f.Settings.EnvironmentID), headers)
+	if err != nil {
+		return err
+	}
+	return f.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
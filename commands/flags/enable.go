@@ -0,0 +1,57 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdSetEnabled(name string, enabled bool, iflags IFlags) error {
+	flag, err := iflags.Retrieve(name)
+	if err != nil {
+		return err
+	}
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	if flag.Enabled == enabled {
+		logrus.Printf("The %s flag is already %s", name, state)
+		return nil
+	}
+	flag.Enabled = enabled
+	if err = iflags.Update(flag); err != nil {
+		return err
+	}
+	logrus.Printf("The %s flag is now %s. %s changes on the next redeploy of each targeted code service.", name, state, EnvVarName(name))
+	return nil
+}
+
+func (f *SFlags) Retrieve(name string) (*models.FeatureFlag, error) {
+	headers := f.Settings.HTTPManager.GetHeaders(f.Settings.SessionToken, f.Settings.Version, f.Settings.Pod, f.Settings.UsersID)
+	resp, statusCode, err := f.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/flags/%s", f.Settings.PaasHost, f.Settings.PaasHostVersion, f.Settings.EnvironmentID, name), headers)
+	if err != nil {
+		return nil, err
+	}
+	var flag models.FeatureFlag
+	err = f.Settings.HTTPManager.ConvertResp(resp, statusCode, &flag)
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (f *SFlags) Update(flag *models.FeatureFlag) error {
+	b, err := json.Marshal(flag)
+	if err != nil {
+		return err
+	}
+	headers := f.Settings.HTTPManager.GetHeaders(f.Settings.SessionToken, f.Settings.Version, f.Settings.Pod, f.Settings.UsersID)
+	resp, statusCode, err := f.Settings.HTTPManager.Put(b, fmt.Sprintf("%s%s/environments/%s/flags/%s", f.Settings.PaasHost, f.Settings.PaasHostVersion, f.Settings.EnvironmentID, flag.Name), headers)
+	if err != nil {
+		return err
+	}
+	return f.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
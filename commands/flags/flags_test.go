@@ -0,0 +1,112 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const flagName = "new-checkout"
+
+var createTests = []struct {
+	name      string
+	expectErr bool
+}{
+	{flagName, false},
+	{"New Checkout", true},
+	{"-checkout", true},
+}
+
+func TestCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/flags",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			var flag models.FeatureFlag
+			json.NewDecoder(r.Body).Decode(&flag)
+			test.AssertEquals(t, "100", fmt.Sprintf("%d", flag.Targeting.Percentage))
+			w.WriteHeader(201)
+		},
+	)
+
+	for _, data := range createTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdCreate(data.name, "", false, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	}
+}
+
+var targetingTests = []struct {
+	svcNames    []string
+	allServices bool
+	percentage  int
+	expected    string
+	expectErr   bool
+}{
+	{[]string{test.SvcLabel}, false, -1, test.SvcID + " 100", false},
+	{nil, true, 25, " 25", false},
+	{nil, false, -1, "", true},
+	{nil, false, 101, "", true},
+	{[]string{test.SvcLabelAlt}, false, -1, "", true},
+	{[]string{"invalid-svc"}, false, -1, "", true},
+}
+
+func TestTargeting(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"%s","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	var updated models.FeatureFlag
+	mux.HandleFunc("/environments/"+test.EnvID+"/flags/"+flagName,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprintf(w, `{"name":"%s","enabled":true,"targeting":{"services":["%s"],"percentage":100}}`, flagName, test.SvcIDAlt)
+			case "PUT":
+				updated = models.FeatureFlag{}
+				json.NewDecoder(r.Body).Decode(&updated)
+			default:
+				t.Errorf("Unexpected method %s", r.Method)
+			}
+		},
+	)
+
+	for _, data := range targetingTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdTargeting(flagName, data.svcNames, data.allServices, data.percentage, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if err == nil {
+			test.AssertEquals(t, data.expected, fmt.Sprintf("%s %d", strings.Join(updated.Targeting.Services, ","), updated.Targeting.Percentage))
+		}
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	test.AssertEquals(t, "DATICA_FLAG_NEW_CHECKOUT", EnvVarName(flagName))
+}
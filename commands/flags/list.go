@@ -0,0 +1,79 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdList(iflags IFlags, is services.IServices) error {
+	flags, err := iflags.List()
+	if err != nil {
+		return err
+	}
+	if flags == nil || len(*flags) == 0 {
+		logrus.Println("No feature flags found")
+		return nil
+	}
+	svcs, err := is.List()
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{}
+	for _, s := range *svcs {
+		labels[s.ID] = s.Label
+	}
+
+	data := [][]string{{"NAME", "ENABLED", "SERVICES", "PERCENTAGE", "ENV VAR", "UPDATED AT", "DESCRIPTION"}}
+	for _, f := range *flags {
+		targeted := "(all)"
+		if len(f.Targeting.Services) > 0 {
+			names := []string{}
+			for _, id := range f.Targeting.Services {
+				if label, ok := labels[id]; ok {
+					names = append(names, label)
+				} else {
+					names = append(names, id)
+				}
+			}
+			targeted = strings.Join(names, ", ")
+		}
+		data = append(data, []string{f.Name, fmt.Sprintf("%t", f.Enabled), targeted, fmt.Sprintf("%d%%", f.Targeting.Percentage), EnvVarName(f.Name), config.FormatTimestampString(f.UpdatedAt), f.Description})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// EnvVarName returns the name of the environment variable a flag is exposed
+// to code services as
+func EnvVarName(name string) string {
+	return "DATICA_FLAG_" + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+func (f *SFlags) List() (*[]models.FeatureFlag, error) {
+	headers := f.Settings.HTTPManager.GetHeaders(f.Settings.SessionToken, f.Settings.Version, f.Settings.Pod, f.Settings.UsersID)
+	resp, statusCode, err := f.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/flags", f.Settings.PaasHost, f.Settings.PaasHostVersion, f.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var flags []models.FeatureFlag
+	err = f.Settings.HTTPManager.ConvertResp(resp, statusCode, &flags)
+	if err != nil {
+		return nil, err
+	}
+	return &flags, nil
+}
@@ -0,0 +1,29 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdRm(name string, iflags IFlags, ip prompts.IPrompts) error {
+	err := ip.YesNo(fmt.Sprintf("Are you sure you want to remove the %s flag? (y/n) ", name))
+	if err != nil {
+		return err
+	}
+	if err = iflags.Rm(name); err != nil {
+		return err
+	}
+	logrus.Printf("Removed the %s flag", name)
+	return nil
+}
+
+func (f *SFlags) Rm(name string) error {
+	headers := f.Settings.HTTPManager.GetHeaders(f.Settings.SessionToken, f.Settings.Version, f.Settings.Pod, f.Settings.UsersID)
+	resp, statusCode, err := f.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/flags/%s", f.Settings.PaasHost, f.Settings.PaasHostVersion, f.Settings.EnvironmentID, name), headers)
+	if err != nil {
+		return err
+	}
+	return f.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
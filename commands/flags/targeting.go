@@ -0,0 +1,48 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+)
+
+func CmdTargeting(name string, svcNames []string, allServices bool, percentage int, iflags IFlags, is services.IServices) error {
+	if len(svcNames) == 0 && !allServices && percentage < 0 {
+		return errors.New("You must specify --service, --all-services, or --percentage")
+	}
+	if percentage > 100 {
+		return fmt.Errorf("%d is not a valid percentage. Percentages are from 0 to 100", percentage)
+	}
+	flag, err := iflags.Retrieve(name)
+	if err != nil {
+		return err
+	}
+	if allServices {
+		flag.Targeting.Services = nil
+	} else if len(svcNames) > 0 {
+		flag.Targeting.Services = []string{}
+		for _, svcName := range svcNames {
+			service, err := is.RetrieveByLabel(svcName)
+			if err != nil {
+				return err
+			}
+			if service == nil {
+				return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+			}
+			if service.Type != "code" {
+				return fmt.Errorf("The \"%s\" service is not a code service. Flags can only target code services.", svcName)
+			}
+			flag.Targeting.Services = append(flag.Targeting.Services, service.ID)
+		}
+	}
+	if percentage >= 0 {
+		flag.Targeting.Percentage = percentage
+	}
+	if err = iflags.Update(flag); err != nil {
+		return err
+	}
+	logrus.Printf("Updated the targeting of the %s flag. Run \"datica flags list\" to see it.", name)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -17,10 +18,11 @@ var Cmd = models.Command{
 	ShortHelp: "Manage git remotes to Datica code services",
 	LongHelp: "The `git-remote` command allows you to interact with code service remote git URLs. " +
 		"The git-remote command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(AddSubCmd.Name, AddSubCmd.ShortHelp, AddSubCmd.LongHelp, AddSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, ShowSubCmd.LongHelp, ShowSubCmd.CmdFunc(settings))
+			cmd.CommandLong(AddSubCmd.Name, AddSubCmd.ShortHelp, help.Render(AddSubCmd.LongHelp), AddSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, help.Render(ShowSubCmd.LongHelp), ShowSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -87,6 +89,7 @@ type IGit interface {
 	List() ([]string, error)
 	Rm(remote string) error
 	SetURL(remote, gitURL string) error
+	URL(remote string) (string, error)
 }
 
 // SGit is an implementor of IGit
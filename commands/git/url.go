@@ -0,0 +1,16 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// URL returns the URL of the given git remote in the current working
+// directory.
+func (g *SGit) URL(remote string) (string, error) {
+	out, err := exec.Command("git", "remote", "get-url", remote).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,99 @@
+package githooks
+
+import (
+	"os"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "git-hooks",
+	ShortHelp: "Manage git hooks that validate pushes to your code services",
+	LongHelp: "The `git-hooks` command allows you to install git hooks in a local git repository that validate a push before it is deployed to a code service. " +
+		"The git-hooks command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(InstallSubCmd.Name, InstallSubCmd.ShortHelp, help.Render(InstallSubCmd.LongHelp), InstallSubCmd.CmdFunc(settings))
+			cmd.CommandLong(PrePushSubCmd.Name, PrePushSubCmd.ShortHelp, help.Render(PrePushSubCmd.LongHelp), PrePushSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var InstallSubCmd = models.Command{
+	Name:      "install",
+	ShortHelp: "Install a pre-push hook that validates deploys",
+	LongHelp: "`git-hooks install` installs a git pre-push hook in the git repository in the current directory. " +
+		"Whenever you push to the given remote, the hook runs [git-hooks pre-push](#git-hooks-pre-push) which makes sure the repository is associated with an environment and that the remote points to the associated code service. " +
+		"If the environment is a production environment, pushing any branch other than the production branch is refused. " +
+		"An environment is considered a production environment when its name contains `prod` or when `--production` is given. " +
+		"The production branch check is lifted while a [break-glass](#breakglass) session is active. " +
+		"An existing pre-push hook is not overwritten unless `-f` is specified. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" git-hooks install\n" +
+		"datica -E \"<your_env_alias>\" git-hooks install --remote datica-prod --production-branch release --production\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			remote := subCmd.StringOpt("r remote", "datica", "The name of the git remote pushes are validated for")
+			productionBranch := subCmd.StringOpt("b production-branch", "master", "The only branch that may be pushed to a production environment")
+			production := subCmd.BoolOpt("p production", false, "Treat the environment as a production environment regardless of its name")
+			force := subCmd.BoolOpt("f force", false, "Overwrite an existing pre-push hook")
+			subCmd.Action = func() {
+				err := CmdInstall(*remote, *productionBranch, *production, *force, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--remote] [--production-branch] [--production] [-f]"
+		}
+	},
+}
+
+var PrePushSubCmd = models.Command{
+	Name:      "pre-push",
+	ShortHelp: "Validate a git push, run by the pre-push hook",
+	LongHelp: "`git-hooks pre-push` validates a git push and is run by the hook installed with [git-hooks install](#git-hooks-install). " +
+		"It is not meant to be run directly. " +
+		"It reads the refs being pushed from stdin in the format git passes them to pre-push hooks and fails if the push should not be deployed. " +
+		"To push anyway, use `git push --no-verify`.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			pushRemote := subCmd.StringArg("REMOTE", "", "The name of the remote being pushed to")
+			pushURL := subCmd.StringArg("URL", "", "The URL of the remote being pushed to")
+			remote := subCmd.StringOpt("r remote", "datica", "The name of the git remote pushes are validated for")
+			productionBranch := subCmd.StringOpt("b production-branch", "master", "The only branch that may be pushed to a production environment")
+			production := subCmd.BoolOpt("p production", false, "Treat the environment as a production environment regardless of its name")
+			subCmd.Action = func() {
+				err := CmdPrePush(*pushRemote, *pushURL, *remote, *productionBranch, *production, os.Stdin, settings, auth.New(settings, prompts.New()), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "REMOTE URL [--remote] [--production-branch] [--production]"
+		}
+	},
+}
+
+// IGitHooks
+type IGitHooks interface {
+	Install(name, script string, force bool) (string, error)
+}
+
+// SGitHooks is a concrete implementation of IGitHooks
+type SGitHooks struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IGitHooks
+func New(settings *models.Settings) IGitHooks {
+	return &SGitHooks{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,56 @@
+package githooks
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// hookMarker identifies hooks installed by the CLI so they can be replaced
+// without --force
+const hookMarker = "Installed by \"datica git-hooks install\""
+
+func CmdInstall(remote, productionBranch string, production, force bool, ig IGitHooks) error {
+	args := fmt.Sprintf("--remote %s --production-branch %s", shellQuote(remote), shellQuote(productionBranch))
+	if production {
+		args += " --production"
+	}
+	script := "#!/bin/sh\n" +
+		"# " + hookMarker + ". Validates pushes before they are deployed.\n" +
+		"# To push without validation, use \"git push --no-verify\".\n" +
+		"exec datica git-hooks pre-push " + args + " \"$1\" \"$2\"\n"
+	path, err := ig.Install("pre-push", script, force)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Installed a pre-push hook at %s that validates pushes to the \"%s\" remote", path, remote)
+	return nil
+}
+
+// Install writes a hook script into the hooks directory of the git repository
+// in the current working directory and returns the path of the hook.
+func (g *SGitHooks) Install(name, script string, force bool) (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-dir").Output()
+	if err != nil {
+		return "", errors.New("No git repo found in the current directory")
+	}
+	hooksDir := filepath.Join(strings.TrimSpace(string(out)), "hooks")
+	if err = os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(hooksDir, name)
+	if existing, err := ioutil.ReadFile(path); err == nil && !force && !strings.Contains(string(existing), hookMarker) {
+		return "", fmt.Errorf("A %s hook already exists at %s, please specify -f to overwrite it", name, path)
+	}
+	return path, ioutil.WriteFile(path, []byte(script), 0755)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", "'\\''", -1) + "'"
+}
@@ -0,0 +1,51 @@
+package githooks
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdPrePush(pushRemote, pushURL, remote, productionBranch string, production bool, refs io.Reader, settings *models.Settings, ia auth.IAuth, is services.IServices) error {
+	if pushRemote != remote {
+		return nil
+	}
+	if err := config.CheckRequiredAssociation(true, false, settings); err != nil {
+		return errors.New("This push was not validated because no environment is associated. Run \"datica associate\" or set the DATICA_ENV environment variable, or use \"git push --no-verify\" to push anyway")
+	}
+	if _, err := ia.Signin(); err != nil {
+		logrus.Warnf("Could not sign in to check the \"%s\" remote: %s", remote, err)
+	} else if service, err := is.Retrieve(settings.ServiceID); err != nil {
+		logrus.Warnf("Could not retrieve the associated code service to check the \"%s\" remote: %s", remote, err)
+	} else if service.Source != "" && service.Source != pushURL {
+		return fmt.Errorf("The \"%s\" remote points to %s but the code service associated with the %s environment is at %s. Run \"datica git-remote add %s -r %s -f\" to fix the remote, or use \"git push --no-verify\" to push anyway", remote, pushURL, settings.EnvironmentName, service.Source, service.Label, remote)
+	}
+	if !production && !strings.Contains(strings.ToLower(settings.EnvironmentName), "prod") {
+		return nil
+	}
+	scanner := bufio.NewScanner(refs)
+	for scanner.Scan() {
+		// each line is "<local ref> <local sha> <remote ref> <remote sha>"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || fields[0] == "(delete)" {
+			continue
+		}
+		branch := strings.TrimPrefix(fields[0], "refs/heads/")
+		if branch != productionBranch && config.BreakGlassActive(settings) {
+			logrus.Warnf("Pushing the \"%s\" branch to the %s production environment during break-glass session %s", branch, settings.EnvironmentName, settings.BreakGlass.ID)
+			continue
+		}
+		if branch != productionBranch {
+			return fmt.Errorf("Refusing to push the \"%s\" branch to the %s production environment, only \"%s\" may be pushed. Use \"git push --no-verify\" to push anyway", branch, settings.EnvironmentName, productionBranch)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,74 @@
+package githooks
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const source = "git@git.datica.com:code-1234.git"
+
+type fakeAuth struct{}
+
+func (f *fakeAuth) Signin() (*models.User, error) {
+	return &models.User{}, nil
+}
+func (f *fakeAuth) Signout() error {
+	return nil
+}
+func (f *fakeAuth) Verify() (*models.User, error) {
+	return &models.User{}, nil
+}
+
+var prePushTests = []struct {
+	pushRemote string
+	pushURL    string
+	production bool
+	breakGlass bool
+	refs       string
+	expectErr  bool
+}{
+	{"datica", source, false, false, "refs/heads/feature 1111 refs/heads/master 2222\n", false},
+	{"datica", source, true, false, "refs/heads/master 1111 refs/heads/master 2222\n", false},
+	{"datica", source, true, false, "refs/heads/feature 1111 refs/heads/master 2222\n", true},
+	{"datica", source, true, true, "refs/heads/feature 1111 refs/heads/master 2222\n", false},
+	{"datica", source, true, false, "(delete) 0000 refs/heads/feature 2222\n", false},
+	{"datica", "git@git.datica.com:code-5678.git", false, false, "refs/heads/master 1111 refs/heads/master 2222\n", true},
+	{"origin", "git@github.com:org/repo.git", true, false, "refs/heads/feature 1111 refs/heads/master 2222\n", false},
+}
+
+func TestPrePush(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `{"id":"%s","label":"%s","source":"%s"}`, test.SvcID, test.SvcLabel, source)
+		},
+	)
+
+	for _, data := range prePushTests {
+		t.Logf("Data: %+v", data)
+
+		settings.BreakGlass = nil
+		if data.breakGlass {
+			settings.BreakGlass = &models.BreakGlassSession{ID: "bg1", OrgID: settings.OrgID, ExpiresAt: time.Now().Add(time.Hour).Format(time.RFC3339)}
+		}
+
+		// test
+		err := CmdPrePush(data.pushRemote, data.pushURL, "datica", "master", data.production, strings.NewReader(data.refs), settings, &fakeAuth{}, services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+	}
+}
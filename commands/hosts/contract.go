@@ -0,0 +1,122 @@
+package hosts
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "hosts",
+	ShortHelp: "Point the CLI at a self-hosted installation of the platform",
+	LongHelp: "The `hosts` command allows you to point the CLI at a self-hosted or on-prem installation of the platform instead of the hosted one. " +
+		"Hosts can be set for a single pod or for every pod. " +
+		"The hosts of the pod an associated environment belongs to are used for every command run against that environment. " +
+		"Installations that require mutual TLS can also be given a client certificate and key to present to the hosts. " +
+		"If your network has a proxy that inspects TLS traffic, set its certificate with `--ca-cert` so the CLI trusts it. " +
+		"The proxy itself is read from the `HTTPS_PROXY` and `NO_PROXY` environment variables. " +
+		"The global `--accounts-host`, `--auth-host`, `--paas-host`, `--client-cert`, `--client-key`, and `--ca-cert` flags take precedence over the values set with this command. " +
+		"Run [doctor](#doctor) to check that the hosts can be reached. " +
+		"The hosts command can not be run directly but has sub commands.",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, help.Render(SetSubCmd.LongHelp), SetSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the hosts set for self-hosted installations",
+	LongHelp: "`hosts list` lists the hosts set for each pod. Hosts set for every pod are shown with a pod of `*`. Here is a sample command\n\n" +
+		"```\ndatica hosts list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				err := CmdList(New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove the hosts set for a pod",
+	LongHelp: "`hosts rm` removes the hosts set for the given pod so the CLI goes back to the hosts set for every pod or the hosted platform. " +
+		"If no pod is given, the hosts set for every pod are removed. Here are some sample commands\n\n" +
+		"```\ndatica hosts rm pod01\n" +
+		"datica hosts rm\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			pod := subCmd.StringArg("POD", "", "The name of the pod to remove the hosts for")
+			subCmd.Action = func() {
+				err := CmdRm(*pod, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[POD]"
+		}
+	},
+}
+
+var SetSubCmd = models.Command{
+	Name:      "set",
+	ShortHelp: "Set the hosts of a self-hosted installation",
+	LongHelp: "`hosts set` sets the hosts the CLI talks to for the given pod. " +
+		"If no pod is given, the hosts are used for every pod without hosts of its own. " +
+		"Only the hosts given are changed, hosts not given keep their current value. " +
+		"Hosts must be full URLs including the scheme. " +
+		"If the installation requires mutual TLS, give the paths of a PEM encoded client certificate and its private key with `--client-cert` and `--client-key`. " +
+		"To trust a certificate authority in addition to the system's, such as the certificate of a proxy that inspects TLS traffic, give the path of a PEM encoded certificate with `--ca-cert`. Here are some sample commands\n\n" +
+		"```\ndatica hosts set --paas-host https://paas.example.com --auth-host https://auth.example.com --accounts-host https://accounts.example.com\n" +
+		"datica hosts set pod01 --paas-host https://paas-pod01.example.com\n" +
+		"datica hosts set pod01 --client-cert ~/certs/client.pem --client-key ~/certs/client-key.pem\n" +
+		"datica hosts set --ca-cert ~/certs/proxy-ca.pem\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			pod := subCmd.StringArg("POD", "", "The name of the pod to set the hosts for")
+			accountsHost := subCmd.StringOpt("accounts-host", "", "The URL of the accounts host")
+			authHost := subCmd.StringOpt("auth-host", "", "The URL of the auth host")
+			paasHost := subCmd.StringOpt("paas-host", "", "The URL of the PaaS host")
+			clientCert := subCmd.StringOpt("client-cert", "", "The path to a client certificate to present to the hosts")
+			clientKey := subCmd.StringOpt("client-key", "", "The path to the private key of the client certificate")
+			caCert := subCmd.StringOpt("ca-cert", "", "The path to PEM encoded certificate authorities to trust in addition to the system's")
+			subCmd.Action = func() {
+				err := CmdSet(*pod, models.HostOverride{AccountsHost: *accountsHost, AuthHost: *authHost, PaasHost: *paasHost, ClientCert: *clientCert, ClientKey: *clientKey, CACert: *caCert}, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[POD] [--accounts-host] [--auth-host] [--paas-host] [--client-cert --client-key] [--ca-cert]"
+		}
+	},
+}
+
+// IHosts
+type IHosts interface {
+	List() map[string]models.HostOverride
+	Rm(pod string) error
+	Set(pod string, hosts models.HostOverride) error
+}
+
+// SHosts is a concrete implementation of IHosts
+type SHosts struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IHosts
+func New(settings *models.Settings) IHosts {
+	return &SHosts{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,169 @@
+package hosts
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdList(ih IHosts) error {
+	hosts := ih.List()
+	if len(hosts) == 0 {
+		logrus.Println("No hosts have been set. The CLI is using the hosted platform.")
+		return nil
+	}
+	pods := []string{}
+	for pod := range hosts {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+	data := [][]string{{"POD", "ACCOUNTS HOST", "AUTH HOST", "PAAS HOST", "CLIENT CERT", "CA CERT"}}
+	for _, pod := range pods {
+		h := hosts[pod]
+		clientCert := h.ClientCert
+		if clientCert == "" {
+			clientCert = "(none)"
+		}
+		caCert := h.CACert
+		if caCert == "" {
+			caCert = "(none)"
+		}
+		data = append(data, []string{pod, valueOrDefault(h.AccountsHost), valueOrDefault(h.AuthHost), valueOrDefault(h.PaasHost), clientCert, caCert})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+func CmdRm(pod string, ih IHosts) error {
+	if err := ih.Rm(pod); err != nil {
+		return err
+	}
+	if pod == "" {
+		logrus.Println("Removed the hosts set for every pod")
+	} else {
+		logrus.Printf("Removed the hosts set for the %s pod", pod)
+	}
+	return nil
+}
+
+func CmdSet(pod string, hosts models.HostOverride, ih IHosts) error {
+	if hosts.AccountsHost == "" && hosts.AuthHost == "" && hosts.PaasHost == "" && hosts.ClientCert == "" && hosts.CACert == "" {
+		return errors.New("You must specify at least one of --accounts-host, --auth-host, --paas-host, --client-cert, or --ca-cert")
+	}
+	if (hosts.ClientCert == "") != (hosts.ClientKey == "") {
+		return errors.New("You must specify both --client-cert and --client-key to use a client certificate")
+	}
+	if hosts.ClientCert != "" {
+		var err error
+		if hosts.ClientCert, err = filepath.Abs(hosts.ClientCert); err != nil {
+			return err
+		}
+		if hosts.ClientKey, err = filepath.Abs(hosts.ClientKey); err != nil {
+			return err
+		}
+		if _, err = tls.LoadX509KeyPair(hosts.ClientCert, hosts.ClientKey); err != nil {
+			return fmt.Errorf("Could not load the client certificate %s with the key %s: %s", hosts.ClientCert, hosts.ClientKey, err)
+		}
+	}
+	if hosts.CACert != "" {
+		var err error
+		if hosts.CACert, err = filepath.Abs(hosts.CACert); err != nil {
+			return err
+		}
+		if _, err = httpclient.LoadCACert(hosts.CACert); err != nil {
+			return fmt.Errorf("Could not load the CA certificate %s: %s", hosts.CACert, err)
+		}
+	}
+	for _, host := range []string{hosts.AccountsHost, hosts.AuthHost, hosts.PaasHost} {
+		if host == "" {
+			continue
+		}
+		if u, err := url.Parse(host); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("\"%s\" is not a valid host. Hosts must be full URLs such as https://paas.example.com", host)
+		}
+	}
+	if err := ih.Set(pod, hosts); err != nil {
+		return err
+	}
+	if pod == "" {
+		logrus.Println("Hosts set for every pod. Run \"datica doctor\" to check that they can be reached.")
+	} else {
+		logrus.Printf("Hosts set for the %s pod. Run \"datica doctor\" to check that they can be reached.", pod)
+	}
+	return nil
+}
+
+// List returns the hosts set for each pod
+func (h *SHosts) List() map[string]models.HostOverride {
+	return h.Settings.Hosts
+}
+
+// Rm removes the hosts set for the given pod. An empty pod removes the hosts
+// set for every pod.
+func (h *SHosts) Rm(pod string) error {
+	if pod == "" {
+		if _, ok := h.Settings.Hosts[config.AllPods]; !ok {
+			return errors.New("No hosts have been set for every pod")
+		}
+		pod = config.AllPods
+	} else if _, ok := h.Settings.Hosts[pod]; !ok {
+		return fmt.Errorf("No hosts have been set for the %s pod", pod)
+	}
+	delete(h.Settings.Hosts, pod)
+	return nil
+}
+
+// Set merges the given hosts into the hosts set for the given pod. An empty
+// pod sets the hosts for every pod.
+func (h *SHosts) Set(pod string, hosts models.HostOverride) error {
+	if pod == "" {
+		pod = config.AllPods
+	}
+	if h.Settings.Hosts == nil {
+		h.Settings.Hosts = map[string]models.HostOverride{}
+	}
+	current := h.Settings.Hosts[pod]
+	if hosts.AccountsHost != "" {
+		current.AccountsHost = hosts.AccountsHost
+	}
+	if hosts.AuthHost != "" {
+		current.AuthHost = hosts.AuthHost
+	}
+	if hosts.PaasHost != "" {
+		current.PaasHost = hosts.PaasHost
+	}
+	if hosts.ClientCert != "" {
+		current.ClientCert = hosts.ClientCert
+		current.ClientKey = hosts.ClientKey
+	}
+	if hosts.CACert != "" {
+		current.CACert = hosts.CACert
+	}
+	h.Settings.Hosts[pod] = current
+	return nil
+}
+
+func valueOrDefault(host string) string {
+	if host == "" {
+		return "(default)"
+	}
+	return host
+}
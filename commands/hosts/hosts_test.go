@@ -0,0 +1,89 @@
+package hosts
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var setTests = []struct {
+	pod       string
+	hosts     models.HostOverride
+	expectErr bool
+}{
+	{"", models.HostOverride{PaasHost: "https://paas.example.com"}, false},
+	{test.Pod, models.HostOverride{AuthHost: "https://auth-pod.example.com"}, false},
+	{test.Pod, models.HostOverride{}, true},
+	{test.Pod, models.HostOverride{PaasHost: "paas.example.com"}, true},
+	{test.Pod, models.HostOverride{ClientCert: "client.pem"}, true},
+	{test.Pod, models.HostOverride{ClientCert: "missing.pem", ClientKey: "missing-key.pem"}, true},
+	{test.Pod, models.HostOverride{CACert: "missing.pem"}, true},
+}
+
+func TestSet(t *testing.T) {
+	settings := &models.Settings{}
+	for _, data := range setTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdSet(data.pod, data.hosts, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	override := config.HostOverride(settings, test.Pod)
+	test.AssertEquals(t, "https://paas.example.com", override.PaasHost)
+	test.AssertEquals(t, "https://auth-pod.example.com", override.AuthHost)
+	test.AssertEquals(t, "", override.AccountsHost)
+	override = config.HostOverride(settings, test.PodAlt)
+	test.AssertEquals(t, "", override.AuthHost)
+}
+
+func TestSetCACert(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	f, err := ioutil.TempFile("", "ca-cert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	f.Close()
+
+	settings := &models.Settings{}
+	if err = CmdSet("", models.HostOverride{CACert: f.Name()}, New(settings)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, f.Name(), config.HostOverride(settings, test.Pod).CACert)
+
+	ioutil.WriteFile(f.Name(), []byte("not a certificate"), 0644)
+	if err = CmdSet("", models.HostOverride{CACert: f.Name()}, New(settings)); err == nil {
+		t.Error("Expected an error setting a file without certificates")
+	}
+}
+
+func TestRm(t *testing.T) {
+	settings := &models.Settings{
+		Hosts: map[string]models.HostOverride{
+			test.Pod: models.HostOverride{PaasHost: "https://paas.example.com"},
+		},
+	}
+	if err := CmdRm(test.Pod, New(settings)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := CmdRm(test.Pod, New(settings)); err == nil {
+		t.Fatalf("Expected an error removing hosts that are not set")
+	}
+	if err := CmdRm("", New(settings)); err == nil {
+		t.Fatalf("Expected an error removing hosts that are not set")
+	}
+}
@@ -0,0 +1,118 @@
+package inbox
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "inbox",
+	ShortHelp: "Read platform announcements sent to you",
+	LongHelp: "The `inbox` command gives access to the notifications the platform sends you, such as announcements, deprecation notices, and scheduled maintenance of the pods your environments run on. " +
+		"Once a day, the number of unread notifications is printed when you run a command. " +
+		"Use [inbox notify](#inbox-notify) to turn this off. " +
+		"The inbox command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(NotifySubCmd.Name, NotifySubCmd.ShortHelp, help.Render(NotifySubCmd.LongHelp), NotifySubCmd.CmdFunc(settings))
+			cmd.CommandLong(ReadSubCmd.Name, ReadSubCmd.ShortHelp, help.Render(ReadSubCmd.LongHelp), ReadSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List your notifications",
+	LongHelp: "`inbox list` lists your unread notifications, newest first, along with the environments they are about. " +
+		"Use `--all` to include notifications you have already read. " +
+		"Read a notification with [inbox read](#inbox-read). Here are some sample commands\n\n" +
+		"```\ndatica inbox list\n" +
+		"datica inbox list --all\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			all := subCmd.BoolOpt("a all", false, "Include notifications that have been read")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(*all, settings, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[--all]"
+		}
+	},
+}
+
+var NotifySubCmd = models.Command{
+	Name:      "notify",
+	ShortHelp: "Turn the daily unread notification count on or off",
+	LongHelp: "`inbox notify` turns the unread notification count printed once a day on or off. " +
+		"If neither is given, whether it is on is printed. Here are some sample commands\n\n" +
+		"```\ndatica inbox notify off\n" +
+		"datica inbox notify on\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			state := subCmd.StringArg("STATE", "", "Either \"on\" or \"off\"")
+			subCmd.Action = func() {
+				err := CmdNotify(*state, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[STATE]"
+		}
+	},
+}
+
+var ReadSubCmd = models.Command{
+	Name:      "read",
+	ShortHelp: "Read a notification",
+	LongHelp: "`inbox read` prints the full text of a notification and marks it as read. " +
+		"Find the ID of a notification with [inbox list](#inbox-list). Here is a sample command\n\n" +
+		"```\ndatica inbox read 2f3c8a9e-5b1d-4d0e-9d7a-0c6f1b2e3a4d\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			id := subCmd.StringArg("ID", "", "The ID of the notification to read")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRead(*id, settings, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "ID"
+		}
+	},
+}
+
+// IInbox
+type IInbox interface {
+	List(unreadOnly bool) (*[]models.Notification, error)
+	MarkRead(id string) error
+	Retrieve(id string) (*models.Notification, error)
+	Send(notification *models.Notification) error
+}
+
+// SInbox is a concrete implementation of IInbox
+type SInbox struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IInbox
+func New(settings *models.Settings) IInbox {
+	return &SInbox{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,113 @@
+package inbox
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/test"
+)
+
+const (
+	notificationID     = "notification1"
+	notificationReadID = "notification2"
+)
+
+var readTests = []struct {
+	id         string
+	expectRead bool
+	expectErr  bool
+}{
+	{notificationID, true, false},
+	{notificationReadID, false, false},
+	{"invalid", false, true},
+}
+
+func TestRead(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	for _, id := range []string{notificationID, notificationReadID} {
+		id := id
+		mux.HandleFunc("/notifications/"+id,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `{"id":"%s","type":"maintenance","title":"Scheduled maintenance","environments":["%s"],"created_at":"2017-03-01T12:00:00Z","read":%t}`, id, test.EnvID, id == notificationReadID)
+			},
+		)
+	}
+	marked := false
+	mux.HandleFunc("/notifications/"+notificationID+"/read",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			marked = true
+		},
+	)
+
+	for _, data := range readTests {
+		t.Logf("Data: %+v", data)
+		marked = false
+
+		// test
+		err := CmdRead(data.id, settings, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if marked != data.expectRead {
+			t.Errorf("Expected the notification to be marked read: %t, marked read: %t", data.expectRead, marked)
+		}
+	}
+}
+
+func TestUnreadCount(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/notifications",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			test.AssertEquals(t, "true", r.URL.Query().Get("unread"))
+			fmt.Fprintf(w, `[{"id":"%s","type":"announcement","title":"New CLI release"},{"id":"%s","type":"deprecation","title":"TLS 1.0 is going away"}]`, notificationID, notificationReadID)
+		},
+	)
+
+	count, err := UnreadCount(New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, "2", fmt.Sprintf("%d", count))
+}
+
+var notifyTests = []struct {
+	state     string
+	quiet     bool
+	expectErr bool
+}{
+	{"off", true, false},
+	{"", true, false},
+	{"ON", false, false},
+	{"sometimes", false, true},
+}
+
+func TestNotify(t *testing.T) {
+	settings := test.GetSettings("")
+	for _, data := range notifyTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdNotify(data.state, settings)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		if settings.InboxQuiet != data.quiet {
+			t.Errorf("Expected quiet to be %t but was %t", data.quiet, settings.InboxQuiet)
+		}
+	}
+}
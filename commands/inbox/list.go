@@ -0,0 +1,104 @@
+package inbox
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// SortedNotifications is a wrapper for Notification array in order to sort
+// them by CreatedAt, newest first
+type SortedNotifications []models.Notification
+
+func (ns SortedNotifications) Len() int {
+	return len(ns)
+}
+
+func (ns SortedNotifications) Swap(i, j int) {
+	ns[i], ns[j] = ns[j], ns[i]
+}
+
+func (ns SortedNotifications) Less(i, j int) bool {
+	return ns[i].CreatedAt > ns[j].CreatedAt
+}
+
+func CmdList(all bool, settings *models.Settings, ii IInbox) error {
+	notifications, err := ii.List(!all)
+	if err != nil {
+		return err
+	}
+	if notifications == nil || len(*notifications) == 0 {
+		if all {
+			logrus.Println("Your inbox is empty")
+		} else {
+			logrus.Println("You have no unread notifications")
+		}
+		return nil
+	}
+	sort.Sort(SortedNotifications(*notifications))
+
+	data := [][]string{{"ID", "TYPE", "TITLE", "ENVIRONMENTS", "RECEIVED", "READ"}}
+	for _, n := range *notifications {
+		data = append(data, []string{n.ID, n.Type, n.Title, environmentNames(n, settings), config.FormatTimestampString(n.CreatedAt), fmt.Sprintf("%t", n.Read)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// environmentNames returns the local aliases of the environments a
+// notification is about. Environments that are not associated are shown by
+// ID, and notifications about a pod show the pod.
+func environmentNames(n models.Notification, settings *models.Settings) string {
+	names := []string{}
+	for _, id := range n.EnvironmentIDs {
+		name := id
+		for alias, env := range settings.Environments {
+			if env.EnvironmentID == id {
+				name = alias
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 && n.Pod != "" {
+		names = append(names, "pod "+n.Pod)
+	}
+	return strings.Join(names, ", ")
+}
+
+// UnreadCount returns the number of unread notifications
+func UnreadCount(ii IInbox) (int, error) {
+	notifications, err := ii.List(true)
+	if err != nil {
+		return 0, err
+	}
+	return len(*notifications), nil
+}
+
+func (i *SInbox) List(unreadOnly bool) (*[]models.Notification, error) {
+	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
+	resp, statusCode, err := i.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/notifications?unread=%t", i.Settings.AuthHost, i.Settings.AuthHostVersion, unreadOnly), headers)
+	if err != nil {
+		return nil, err
+	}
+	var notifications []models.Notification
+	err = i.Settings.HTTPManager.ConvertResp(resp, statusCode, &notifications)
+	if err != nil {
+		return nil, err
+	}
+	return &notifications, nil
+}
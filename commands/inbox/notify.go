@@ -0,0 +1,29 @@
+package inbox
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdNotify(state string, settings *models.Settings) error {
+	switch strings.ToLower(state) {
+	case "":
+		if settings.InboxQuiet {
+			logrus.Println("The unread notification count is off")
+		} else {
+			logrus.Println("The unread notification count is printed once a day")
+		}
+	case "on":
+		settings.InboxQuiet = false
+		logrus.Println("The unread notification count will be printed once a day")
+	case "off":
+		settings.InboxQuiet = true
+		logrus.Println("The unread notification count will no longer be printed. Run \"datica inbox list\" to check your notifications.")
+	default:
+		return fmt.Errorf("\"%s\" is not a valid state. Use \"on\" or \"off\"", state)
+	}
+	return nil
+}
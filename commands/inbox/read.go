@@ -0,0 +1,52 @@
+package inbox
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdRead(id string, settings *models.Settings, ii IInbox) error {
+	n, err := ii.Retrieve(id)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("%s\n", n.Title)
+	logrus.Printf("Type: %s", n.Type)
+	logrus.Printf("Received: %s", config.FormatTimestampString(n.CreatedAt))
+	if envs := environmentNames(*n, settings); envs != "" {
+		logrus.Printf("Environments: %s", envs)
+	}
+	if n.Body != "" {
+		logrus.Printf("\n%s", n.Body)
+	}
+	if n.Read {
+		return nil
+	}
+	return ii.MarkRead(id)
+}
+
+func (i *SInbox) Retrieve(id string) (*models.Notification, error) {
+	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
+	resp, statusCode, err := i.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/notifications/%s", i.Settings.AuthHost, i.Settings.AuthHostVersion, id), headers)
+	if err != nil {
+		return nil, err
+	}
+	var notification models.Notification
+	err = i.Settings.HTTPManager.ConvertResp(resp, statusCode, &notification)
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (i *SInbox) MarkRead(id string) error {
+	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
+	resp, statusCode, err := i.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/notifications/%s/read", i.Settings.AuthHost, i.Settings.AuthHostVersion, id), headers)
+	if err != nil {
+		return err
+	}
+	return i.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,23 @@
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/daticahealth/cli/models"
+)
+
+// Send delivers the notification to the inbox of every user of the associated
+// environment's organization with one of the roles of the notification
+func (i *SInbox) Send(notification *models.Notification) error {
+	b, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
+	resp, statusCode, err := i.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/orgs/%s/notifications", i.Settings.AuthHost, i.Settings.AuthHostVersion, i.Settings.OrgID), headers)
+	if err != nil {
+		return err
+	}
+	return i.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/daticahealth/cli/lib/prompts"
 )
 
-// CmdAccept creates an environment from a JSON env spec
+// CmdAccept accepts an invite to an organization and offers to associate one
+// of its environments
 func CmdAccept(inviteCode string, ii IInvites, ia auth.IAuth, ip prompts.IPrompts) error {
 	user, err := ia.Signin()
 	if err != nil {
@@ -24,7 +25,22 @@ func CmdAccept(inviteCode string, ii IInvites, ia auth.IAuth, ip prompts.IPrompt
 		return err
 	}
 	logrus.Printf("Successfully joined organization (%s) as %s\n", orgID, user.Email)
-	return nil
+	if associator == nil {
+		return nil
+	}
+	return associator(orgID)
+}
+
+// associator offers to associate an environment of the organization with the
+// given ID
+var associator func(orgID string) error
+
+// SetAssociator sets the function used to offer an environment to associate
+// after an invite is accepted, so that the next command of a new member does
+// not fail for lack of an association. It is set where commands are
+// registered since the associate command depends on this package.
+func SetAssociator(a func(orgID string) error) {
+	associator = a
 }
 
 func (i *SInvites) Accept(inviteCode string) (string, error) {
@@ -2,8 +2,10 @@ package invites
 
 import (
 	"github.com/Sirupsen/logrus"
-	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/commands/trash"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/journal"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -18,12 +20,14 @@ var Cmd = models.Command{
 		"Every environment is owned by an organization and users join organizations in order to access individual environments. " +
 		"You can invite new users by email and manage pending invites through the CLI. " +
 		"You cannot call the `invites` command directly, but must call one of its subcommands.",
+	Category: models.CategoryAccess,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(AcceptSubCmd.Name, AcceptSubCmd.ShortHelp, AcceptSubCmd.LongHelp, AcceptSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, RmSubCmd.LongHelp, RmSubCmd.CmdFunc(settings))
-			cmd.CommandLong(SendSubCmd.Name, SendSubCmd.ShortHelp, SendSubCmd.LongHelp, SendSubCmd.CmdFunc(settings))
+			cmd.CommandLong(AcceptSubCmd.Name, AcceptSubCmd.ShortHelp, help.Render(AcceptSubCmd.LongHelp), AcceptSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RolesSubCmd.Name, RolesSubCmd.ShortHelp, help.Render(RolesSubCmd.LongHelp), RolesSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SendSubCmd.Name, SendSubCmd.ShortHelp, help.Render(SendSubCmd.LongHelp), SendSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -33,6 +37,7 @@ var AcceptSubCmd = models.Command{
 	ShortHelp: "Accept an organization invite",
 	LongHelp: "`invites accept` is an alternative form of accepting an invitation sent by email. " +
 		"The invitation email you receive will have instructions as well as the invite code to use with this command. " +
+		"Once you have joined the organization, you are offered to associate one of its environments you have access to so that you can start running commands against it. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" invites accept 5a206aa8-04f4-4bc1-a017-ede7e6c7dbe2\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
@@ -61,18 +66,22 @@ var ListSubCmd = models.Command{
 	LongHelp: "`invites list` lists all pending invites for the associated environment's organization. " +
 		"Any invites that have already been accepted will not appear in this list. " +
 		"To manage users who have already accepted invitations or are already granted access to your environment, use the [users](#users) group of commands. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" invites list\n```",
+		"To list the invites of an organization that none of your associated environments belong to, give its name with `--org`. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" invites list\n" +
+		"datica invites list --org \"My Organization\"\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
+			org := subCmd.StringOpt("o org", "", "The name of the organization to manage invites for instead of the associated environment's organization")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+				ii := New(settings)
+				orgName, err := useOrg(*org, settings, ii)
+				if err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdList(settings.EnvironmentName, New(settings))
+				err = CmdList(orgName, ii)
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
@@ -88,24 +97,61 @@ var RmSubCmd = models.Command{
 		"Once an invite has already been accepted, it cannot be removed. " +
 		"Removing an invitation is helpful if an email was misspelled or an invitation was sent to an incorrect email address. " +
 		"If you want to revoke access to a user who already has been given access to your environment, use the [users rm](#users-rm) command. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" invites rm 78b5d0ed-f71c-47f7-a4c8-6c8c58c29db1\n```",
+		"You will be asked to confirm before the invitation is removed, use the global `--yes` flag to skip the confirmation. " +
+		"Invites of an organization that none of your associated environments belong to can be removed by giving its name with `--org`. " +
+		"Use `--soft` to move the invitation to the trash instead, where it can be restored with [trash restore](#trash-restore) until it expires. " +
+		"If the platform does not support soft deletes, `--soft` fails and the invitation is not removed. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" invites rm 78b5d0ed-f71c-47f7-a4c8-6c8c58c29db1\n" +
+		"datica -E \"<your_env_alias>\" invites rm 78b5d0ed-f71c-47f7-a4c8-6c8c58c29db1 --soft\n" +
+		"datica invites rm 78b5d0ed-f71c-47f7-a4c8-6c8c58c29db1 --org \"My Organization\"\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			inviteID := subCmd.StringArg("INVITE_ID", "", "The ID of an invitation to remove")
+			org := subCmd.StringOpt("o org", "", "The name of the organization to manage invites for instead of the associated environment's organization")
+			soft := subCmd.BoolOpt("soft", false, "Move the invitation to the trash so it can be restored")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+				ii := New(settings)
+				_, err := useOrg(*org, settings, ii)
+				if err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRm(*inviteID, New(settings))
+				err = CmdRm(*inviteID, *soft, ii, prompts.New(), trash.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "INVITE_ID [--org] [--soft]"
+		}
+	},
+}
+
+var RolesSubCmd = models.Command{
+	Name:      "roles",
+	ShortHelp: "List the roles users can be invited with",
+	LongHelp: "`invites roles` lists the names of the built-in and custom roles defined by the associated environment's organization. " +
+		"Any of these names can be given to the `--role` option of [invites send](#invites-send). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" invites roles\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			org := subCmd.StringOpt("o org", "", "The name of the organization to manage invites for instead of the associated environment's organization")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				ii := New(settings)
+				_, err := useOrg(*org, settings, ii)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdRoles(ii)
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "INVITE_ID"
 		}
 	},
 }
@@ -115,32 +161,38 @@ var SendSubCmd = models.Command{
 	ShortHelp: "Send an invite to a user by email for a given organization",
 	LongHelp: "`invites send` invites a new user to your environment's organization. " +
 		"The only piece of information required is the email address to send the invitation to. " +
-		"The invited user will join the organization as a member with no permissions. " +
-		"You must grant them permission through the dashboard. " +
+		"The invited user will join the organization with the role given by `--role`, which can be any built-in or custom role your organization defines. " +
+		"Run [invites roles](#invites-roles) to see the available roles and [roles create](#roles-create) to define a custom role with only the permissions the user needs. " +
+		"If no role is given, the user joins as a member with no permissions and you must grant them permission through the dashboard. " +
 		"The recipient does **not** need to have a Dashboard account in order to send them an invitation. " +
-		"However, they will need to have a Dashboard account to accept the invitation. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" invites send coworker@datica.com\n```",
+		"However, they will need to have a Dashboard account to accept the invitation. " +
+		"Multiple users can be invited at once by giving more than one email. " +
+		"If sending a batch of invites is interrupted, it can be continued with the [resume](#resume) command without re-inviting anyone. " +
+		"To invite users to an organization that none of your associated environments belong to, give its name with `--org`. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" invites send coworker@datica.com\n" +
+		"datica -E \"<your_env_alias>\" invites send coworker@datica.com teammate@datica.com\n" +
+		"datica -E \"<your_env_alias>\" invites send coworker@datica.com --role admin\n" +
+		"datica invites send coworker@datica.com --org \"My Organization\"\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			email := subCmd.StringArg("EMAIL", "", "The email of a user to invite to the associated environment. This user does not need to have a Datica account prior to sending the invitation")
-			memberRole := subCmd.BoolOpt("m member", false, "[DEPRECATED] Whether or not the user will be invited as a basic member. This flag will be removed in the next version")
-			adminRole := subCmd.BoolOpt("a admin", false, "[DEPRECATED] Whether or not the user will be invited as an admin. This flag will be removed in the next version")
+			emails := subCmd.StringsArg("EMAIL", []string{}, "The email of a user to invite to the associated environment. This user does not need to have a Datica account prior to sending the invitation")
+			role := subCmd.StringOpt("r role", "", "The name of the role the invited users will have in the organization. Run \"datica invites roles\" to see the available roles")
+			org := subCmd.StringOpt("o org", "", "The name of the organization to invite users to instead of the associated environment's organization")
 			subCmd.Action = func() {
-				if *memberRole || *adminRole {
-					logrus.Infoln("The -m and -a flags have been DEPRECATED. You must assign permissions by visiting the dashboard.")
-				}
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+				ii := New(settings)
+				orgName, err := useOrg(*org, settings, ii)
+				if err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdSend(*email, settings.EnvironmentName, New(settings), prompts.New())
+				err = CmdSend(*emails, *role, orgName, ii, prompts.New(), journal.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "EMAIL [-m | -a]"
+			subCmd.Spec = "EMAIL... [--role] [--org]"
 		}
 	},
 }
@@ -149,9 +201,11 @@ var SendSubCmd = models.Command{
 type IInvites interface {
 	Accept(inviteCode string) (string, error)
 	List() (*[]models.Invite, error)
-	Rm(inviteID string) error
-	Send(email string) error
+	Rm(inviteID string, soft bool) error
+	Send(email string, roleID int) error
 	ListOrgGroups() (*[]models.Group, error)
+	ListRoles() (*[]models.Role, error)
+	ListOrgs() (*[]models.Org, error)
 }
 
 // SInvites is a concrete implementation of IInvites
@@ -0,0 +1,50 @@
+package invites
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+)
+
+// useOrg points the invite commands at the organization with the given name
+// or ID instead of the organization of the associated environment, which
+// allows managing invites for organizations without an associated
+// environment. The name to refer to the organization by is returned.
+func useOrg(org string, settings *models.Settings, ii IInvites) (string, error) {
+	if org == "" {
+		if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+			return "", err
+		}
+		return settings.EnvironmentName, nil
+	}
+	orgs, err := ii.ListOrgs()
+	if err != nil {
+		return "", err
+	}
+	names := []string{}
+	for _, o := range *orgs {
+		if strings.EqualFold(o.Name, org) || o.ID == org {
+			settings.OrgID = o.ID
+			return o.Name, nil
+		}
+		names = append(names, o.Name)
+	}
+	return "", fmt.Errorf("Could not find an organization named \"%s\". Your organizations are: %s", org, strings.Join(names, ", "))
+}
+
+// ListOrgs lists the organizations the signed in user belongs to
+func (i *SInvites) ListOrgs() (*[]models.Org, error) {
+	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
+	resp, statusCode, err := i.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs", i.Settings.AuthHost, i.Settings.AuthHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	var orgs []models.Org
+	err = i.Settings.HTTPManager.ConvertResp(resp, statusCode, &orgs)
+	if err != nil {
+		return nil, err
+	}
+	return &orgs, nil
+}
@@ -0,0 +1,89 @@
+package invites
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const orgNameAlt = "Other Org"
+
+var useOrgTests = []struct {
+	org          string
+	associated   bool
+	expectName   string
+	expectOrgID  string
+	expectErr    bool
+	expectErrMsg string
+}{
+	{"", true, test.EnvName, test.OrgID, false, ""},
+	{"", false, "", "", true, ""},
+	{orgNameAlt, false, orgNameAlt, test.OrgIDAlt, false, ""},
+	{strings.ToLower(orgNameAlt), true, orgNameAlt, test.OrgIDAlt, false, ""},
+	{test.OrgIDAlt, true, orgNameAlt, test.OrgIDAlt, false, ""},
+	{"unknown", true, "", test.OrgID, true, "Your organizations are: My Org, Other Org"},
+	{"org", true, "", test.OrgID, true, ""},
+}
+
+func TestUseOrg(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	mux.HandleFunc("/orgs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","name":"My Org"},{"id":"%s","name":"%s"}]`, test.OrgID, test.OrgIDAlt, orgNameAlt))
+		},
+	)
+
+	for _, data := range useOrgTests {
+		t.Logf("Data: %+v", data)
+		settings := test.GetSettings(baseURL.String())
+		settings.AuthHost = baseURL.String()
+		settings.EnvironmentName = test.EnvName
+		if !data.associated {
+			settings.Environments = map[string]models.AssociatedEnv{}
+			settings.EnvironmentID = ""
+			settings.ServiceID = ""
+			settings.OrgID = ""
+		}
+
+		// test
+		name, err := useOrg(data.org, settings, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if err != nil && !strings.Contains(err.Error(), data.expectErrMsg) {
+			t.Errorf("Expected the error to contain \"%s\" but got %s", data.expectErrMsg, err)
+		}
+		test.AssertEquals(t, data.expectName, name)
+		test.AssertEquals(t, data.expectOrgID, settings.OrgID)
+	}
+}
+
+func TestUseOrgListFails(t *testing.T) {
+	httpclient.SetRetries(1)
+	defer httpclient.SetRetries(httpclient.DefaultRetries)
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+			fmt.Fprint(w, `{"title":"Error","description":"error","code":500}`)
+		},
+	)
+
+	if _, err := useOrg(orgNameAlt, settings, New(settings)); err == nil {
+		t.Fatal("Expected an error when the organizations cannot be listed")
+	}
+	test.AssertEquals(t, test.OrgID, settings.OrgID)
+}
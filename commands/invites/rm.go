@@ -4,22 +4,42 @@ import (
 	"fmt"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/trash"
+	"github.com/daticahealth/cli/lib/prompts"
 )
 
-func CmdRm(inviteID string, ii IInvites) error {
-	err := ii.Rm(inviteID)
+func CmdRm(inviteID string, soft bool, ii IInvites, ip prompts.IPrompts, it trash.ITrash) error {
+	if soft {
+		if err := trash.CheckSoft("invite", "invite "+inviteID, it); err != nil {
+			return err
+		}
+	}
+	err := ip.YesNo(fmt.Sprintf("Are you sure you want to remove invite %s? (y/n) ", inviteID))
+	if err != nil {
+		return err
+	}
+	err = ii.Rm(inviteID, soft)
 	if err != nil {
 		return err
 	}
+	if soft {
+		logrus.Printf("Moved invite %s to the trash. Until it expires, it can be restored with \"datica trash restore\".", inviteID)
+		return nil
+	}
 	logrus.Printf("Invite %s removed", inviteID)
 	return nil
 }
 
 // Rm deletes an invite sent to a user. This invite must not already be
-// accepted.
-func (i *SInvites) Rm(inviteID string) error {
+// accepted. A soft deleted invite is moved to the trash of the organization
+// instead.
+func (i *SInvites) Rm(inviteID string, soft bool) error {
+	query := ""
+	if soft {
+		query = "?soft=true"
+	}
 	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
-	resp, statusCode, err := i.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/invites/%s", i.Settings.AuthHost, i.Settings.AuthHostVersion, i.Settings.OrgID, inviteID), headers)
+	resp, statusCode, err := i.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/invites/%s%s", i.Settings.AuthHost, i.Settings.AuthHostVersion, i.Settings.OrgID, inviteID, query), headers)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,52 @@
+package invites
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdRoles(ii IInvites) error {
+	roles, err := ii.ListRoles()
+	if err != nil {
+		return err
+	}
+	for _, r := range *roles {
+		logrus.Println(r.Name)
+	}
+	return nil
+}
+
+// FindRole looks up the role with the given name, ignoring case
+func FindRole(name string, ii IInvites) (*models.Role, error) {
+	roles, err := ii.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, r := range *roles {
+		if strings.EqualFold(r.Name, name) {
+			return &r, nil
+		}
+		names = append(names, r.Name)
+	}
+	return nil, fmt.Errorf("\"%s\" is not a role in your organization. Valid roles are: %s", name, strings.Join(names, ", "))
+}
+
+// ListRoles lists the roles defined by the organization of the associated
+// environment
+func (i *SInvites) ListRoles() (*[]models.Role, error) {
+	headers := i.Settings.HTTPManager.GetHeaders(i.Settings.SessionToken, i.Settings.Version, i.Settings.Pod, i.Settings.UsersID)
+	resp, statusCode, err := i.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/roles", i.Settings.AuthHost, i.Settings.AuthHostVersion, i.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var roles []models.Role
+	err = i.Settings.HTTPManager.ConvertResp(resp, statusCode, &roles)
+	if err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
@@ -5,30 +5,95 @@ import (
 	"fmt"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/journal"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 )
 
-func CmdSend(email string, envName string, ii IInvites, ip prompts.IPrompts) error {
-	err := ip.YesNo(fmt.Sprintf("Are you sure you want to invite %s to your %s organization? (y/n) ", email, envName))
+// SendOperation is the name under which bulk invites are recorded in the
+// operation journal
+const SendOperation = "invites send"
+
+// defaultRoleID is the role users are invited with when no role is given
+const defaultRoleID = 5
+
+func CmdSend(emails []string, role, envName string, ii IInvites, ip prompts.IPrompts, ij journal.IJournal) error {
+	roleID, err := resolveRole(role, ii)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Are you sure you want to invite %s to your %s organization? (y/n) ", emails[0], envName)
+	if len(emails) > 1 {
+		msg = fmt.Sprintf("Are you sure you want to invite %d users to your %s organization? (y/n) ", len(emails), envName)
+	}
+	err = ip.YesNo(msg)
+	if err != nil {
+		return err
+	}
+	if len(emails) == 1 {
+		err = ii.Send(emails[0], roleID)
+		if err != nil {
+			return err
+		}
+		logrus.Printf("%s has been invited!", emails[0])
+		return nil
+	}
+	op, err := ij.Start(SendOperation, emails, map[string]string{"role": role})
 	if err != nil {
 		return err
 	}
-	err = ii.Send(email)
+	return sendAll(op, roleID, ii, ij)
+}
+
+// CmdResumeSend continues a bulk invite that was interrupted, skipping every
+// email that was already invited.
+func CmdResumeSend(op *models.Operation, ii IInvites, ij journal.IJournal) error {
+	roleID, err := resolveRole(op.Options["role"], ii)
 	if err != nil {
 		return err
 	}
-	logrus.Printf("%s has been invited!", email)
-	return nil
+	return sendAll(op, roleID, ii, ij)
+}
+
+// resolveRole returns the ID of the role with the given name, or the default
+// role if no name is given
+func resolveRole(name string, ii IInvites) (int, error) {
+	if name == "" {
+		return defaultRoleID, nil
+	}
+	role, err := FindRole(name, ii)
+	if err != nil {
+		return 0, err
+	}
+	return role.ID, nil
+}
+
+func sendAll(op *models.Operation, roleID int, ii IInvites, ij journal.IJournal) error {
+	for i, step := range op.Steps {
+		if step.Done {
+			logrus.Printf("Skipping %s, already invited", step.Name)
+			continue
+		}
+		err := ii.Send(step.Name, roleID)
+		if err != nil {
+			return fmt.Errorf("Failed to invite %s: %s\nRun \"datica resume %s\" to continue with the remaining invites", step.Name, err, op.ID)
+		}
+		logrus.Printf("%s has been invited!", step.Name)
+		err = ij.Complete(op, i)
+		if err != nil {
+			return err
+		}
+	}
+	return ij.Finish(op)
 }
 
 // Send invites a user by email to the associated environment. They do
 // not need a Dashboard account prior to inviting them, but they must have a
 // Dashboard account in order to accept the invitation.
-func (i *SInvites) Send(email string) error {
+func (i *SInvites) Send(email string, roleID int) error {
 	inv := models.PostInvite{
 		Email:        email,
-		Role:         5,
+		Role:         roleID,
 		LinkTemplate: fmt.Sprintf("%s/accept-invite?code={inviteCode}", i.Settings.AccountsHost),
 	}
 	b, err := json.Marshal(inv)
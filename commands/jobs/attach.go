@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+)
+
+type pollResult struct {
+	status string
+	err    error
+}
+
+func CmdAttach(svcName, jobID, timeout string, is services.IServices, ij libjobs.IJobs) error {
+	var deadline <-chan time.Time
+	if timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("Invalid timeout \"%s\". Timeouts must be a positive duration such as 30m or 2h", timeout)
+		}
+		deadline = time.After(d)
+		logrus.Printf("Waiting until %s for the job to complete", config.FormatTimestamp(time.Now().Add(d)))
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	job, err := ij.Retrieve(jobID, service.ID, false)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Attached to %s job %s (status = %s)", job.Type, job.ID, job.Status)
+
+	done := make(chan pollResult, 1)
+	go func() {
+		status, err := ij.PollForStatus(libjobs.TerminalStatuses(job.Type), job.ID, service.ID)
+		done <- pollResult{status, err}
+	}()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return res.err
+		}
+		logrus.Printf("\nEnded in status '%s'", res.status)
+	case <-deadline:
+		return fmt.Errorf("\nTimed out after %s. The job is still running, you can re-attach with \"datica jobs attach %s %s\"", timeout, svcName, job.ID)
+	}
+	return nil
+}
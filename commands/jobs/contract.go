@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "jobs",
+	ShortHelp: "Interact with the server-side jobs of a service",
+	LongHelp:  "The `jobs` command allows you to list, inspect, stop, and retry the jobs of a service and to follow the jobs created by long running commands such as [db import](#db-import), [redeploy](#redeploy), and [rollback](#rollback). The jobs command can not be run directly but has sub commands.",
+	Category:  models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(AttachSubCmd.Name, AttachSubCmd.ShortHelp, help.Render(AttachSubCmd.LongHelp), AttachSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DescribeSubCmd.Name, DescribeSubCmd.ShortHelp, help.Render(DescribeSubCmd.LongHelp), DescribeSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RetrySubCmd.Name, RetrySubCmd.ShortHelp, help.Render(RetrySubCmd.LongHelp), RetrySubCmd.CmdFunc(settings))
+			cmd.CommandLong(StopSubCmd.Name, StopSubCmd.ShortHelp, help.Render(StopSubCmd.LongHelp), StopSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var AttachSubCmd = models.Command{
+	Name:      "attach",
+	ShortHelp: "Wait for a previously started job to complete",
+	LongHelp: "`jobs attach` re-attaches to a job that was started with `--detach`, or whose command was interrupted, and polls until it completes. " +
+		"Deploy and worker jobs are complete once they are running, all other jobs are complete once they are finished. " +
+		"Use `--timeout` to stop waiting after a given duration such as `30m` or `2h`, the job will keep running on the server. " +
+		"The job ID is printed by every command that starts a job. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" jobs attach db01 cd2b4bce-2727-42d1-89e0-027bf3f1a203 --timeout 1h\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service the job belongs to")
+			jobID := subCmd.StringArg("JOB_ID", "", "The ID of the job to attach to")
+			timeout := subCmd.StringOpt("t timeout", "", "The maximum amount of time to wait for the job, i.e. '45m'. Waits indefinitely if not given")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdAttach(*serviceName, *jobID, *timeout, services.New(settings), libjobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME JOB_ID [-t]"
+		}
+	},
+}
+
+var DescribeSubCmd = models.Command{
+	Name:      "describe",
+	ShortHelp: "Show the details of a job",
+	LongHelp: "`jobs describe` prints the type, target, status, and creation time of a job. " +
+		"For finished jobs, the exit code and the reason the job ended are printed as well. " +
+		"Jobs killed for exceeding the memory limit of their service have the status `oom_killed`, even though the platform restarts them like any other failed job. " +
+		"Use `--notify-on-oom` to be notified in your inbox whenever a job of the same service and target is killed for exceeding its memory limit. " +
+		"If the service is not given, every service in the environment is searched for the job. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" jobs describe cd2b4bce-2727-42d1-89e0-027bf3f1a203\n" +
+		"datica -E \"<your_env_alias>\" jobs describe code-1 cd2b4bce-2727-42d1-89e0-027bf3f1a203 --notify-on-oom\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service the job belongs to. Every service is searched if not given")
+			jobID := subCmd.StringArg("JOB_ID", "", "The ID of the job to describe")
+			notifyOnOOM := subCmd.BoolOpt("notify-on-oom", false, "Create an alert rule that notifies you when a job of the same service and target is killed for exceeding its memory limit")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdDescribe(*serviceName, *jobID, *notifyOnOOM, services.New(settings), libjobs.New(settings), alerts.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] JOB_ID [--notify-on-oom]"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the jobs of a service",
+	LongHelp: "`jobs list` lists the jobs of a service, newest first, along with their type, target, and status. " +
+		"Use `--type` to only list jobs of a given type such as `deploy`, `worker`, or `backup`. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" jobs list code-1\n" +
+		"datica -E \"<your_env_alias>\" jobs list code-1 --type worker -n 50\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to list jobs for")
+			jobType := subCmd.StringOpt("t type", "", "Only list jobs of this type")
+			page := subCmd.IntOpt("p page", 1, "The page to view")
+			pageSize := subCmd.IntOpt("n page-size", 20, "The number of items to show per page")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(*serviceName, *jobType, *page, *pageSize, services.New(settings), libjobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME [-t] [-p] [-n]"
+		}
+	},
+}
+
+var RetrySubCmd = models.Command{
+	Name:      "retry",
+	ShortHelp: "Start a failed job again",
+	LongHelp: "`jobs retry` starts a new job with the same spec as a job that has ended, such as a backup or worker that failed. " +
+		"Jobs that have not ended yet can not be retried. " +
+		"Use `--follow` to wait for the new job to complete, otherwise you can wait for it later with [jobs attach](#jobs-attach). " +
+		"If the service is not given, every service in the environment is searched for the job. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" jobs retry cd2b4bce-2727-42d1-89e0-027bf3f1a203\n" +
+		"datica -E \"<your_env_alias>\" jobs retry db01 cd2b4bce-2727-42d1-89e0-027bf3f1a203 --follow\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service the job belongs to. Every service is searched if not given")
+			jobID := subCmd.StringArg("JOB_ID", "", "The ID of the job to retry")
+			follow := subCmd.BoolOpt("f follow", false, "Wait for the new job to complete")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRetry(*serviceName, *jobID, *follow, services.New(settings), libjobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] JOB_ID [-f]"
+		}
+	},
+}
+
+var StopSubCmd = models.Command{
+	Name:      "stop",
+	ShortHelp: "Stop a job that has not ended",
+	LongHelp: "`jobs stop` stops a job that is stuck or no longer needed. " +
+		"You will be asked to confirm before the job is stopped, use the global `--yes` flag to skip the confirmation. " +
+		"Stopping a worker does not change the scale of its target, use [worker scale](#worker-scale) for that. " +
+		"If the service is not given, every service in the environment is searched for the job. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" jobs stop cd2b4bce-2727-42d1-89e0-027bf3f1a203\n" +
+		"datica -E \"<your_env_alias>\" jobs stop code-1 cd2b4bce-2727-42d1-89e0-027bf3f1a203\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service the job belongs to. Every service is searched if not given")
+			jobID := subCmd.StringArg("JOB_ID", "", "The ID of the job to stop")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdStop(*serviceName, *jobID, services.New(settings), libjobs.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] JOB_ID"
+		}
+	},
+}
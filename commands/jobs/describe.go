@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/alerts"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// activeStatuses are the statuses of jobs that have not ended yet
+var activeStatuses = []string{"scheduled", "queued", "started", "running", "waiting"}
+
+func CmdDescribe(svcName, jobID string, notifyOnOOM bool, is services.IServices, ij libjobs.IJobs, ia alerts.IAlerts) error {
+	service, job, err := findJob(svcName, jobID, is, ij)
+	if err != nil {
+		return err
+	}
+	data := [][]string{
+		{"ID", job.ID},
+		{"Service", service.Label},
+		{"Type", job.Type},
+	}
+	if job.Target != "" {
+		data = append(data, []string{"Target", job.Target})
+	}
+	data = append(data,
+		[]string{"Status", libjobs.DisplayStatus(job)},
+		[]string{"Created", config.FormatTimestampString(job.CreatedAt)},
+	)
+	if job.Termination != nil {
+		data = append(data,
+			[]string{"Finished", config.FormatTimestampString(job.Termination.FinishedAt)},
+			[]string{"Exit Code", fmt.Sprintf("%d", job.Termination.ExitCode)},
+		)
+		if job.Termination.Reason != "" {
+			data = append(data, []string{"Termination Reason", job.Termination.Reason})
+		}
+	}
+	if job.IsSnapshotBackup != nil {
+		data = append(data, []string{"Snapshot Backup", fmt.Sprintf("%t", *job.IsSnapshotBackup)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	if libjobs.OOMKilled(job) {
+		logrus.Warnf("\nThis job was killed for exceeding the memory limit of %s. Consider reducing its memory usage or resizing the service.", service.Label)
+	}
+	if notifyOnOOM {
+		_, err = ia.CreateRule(&models.AlertRule{Event: alerts.EventOOM, ServiceID: service.ID, Target: job.Target})
+		if err != nil {
+			return err
+		}
+		if job.Target != "" {
+			logrus.Printf("You will be notified in your inbox when a %s job of %s is killed for exceeding its memory limit", job.Target, service.Label)
+		} else {
+			logrus.Printf("You will be notified in your inbox when a job of %s is killed for exceeding its memory limit", service.Label)
+		}
+	}
+	return nil
+}
+
+// findJob finds the job with the given ID. If no service is given, every
+// service in the environment is searched for the job.
+func findJob(svcName, jobID string, is services.IServices, ij libjobs.IJobs) (*models.Service, *models.Job, error) {
+	if svcName != "" {
+		service, err := is.RetrieveByLabel(svcName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if service == nil {
+			return nil, nil, fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+		}
+		job, err := ij.Retrieve(jobID, service.ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return service, job, nil
+	}
+	svcs, err := is.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range *svcs {
+		job, err := ij.Retrieve(jobID, (*svcs)[i].ID, false)
+		if err == nil && job.ID != "" {
+			return &(*svcs)[i], job, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("Could not find a job with the ID \"%s\" in any service of the environment. You can list jobs with the \"datica jobs list\" command.", jobID)
+}
+
+func isActive(status string) bool {
+	for _, s := range activeStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,370 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/alerts"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+// svcJobs are the jobs of each service by job ID
+var svcJobs = map[string]map[string]string{
+	test.SvcID: {
+		"running1": `{"id":"running1","type":"worker","target":"mailer","status":"running","created_at":"2026-10-16T12:00:00Z"}`,
+		"oom1":     `{"id":"oom1","type":"worker","target":"mailer","status":"failed","created_at":"2026-10-16T11:00:00Z","termination":{"reason":"OOMKilled","exit_code":137,"finished_at":"2026-10-16T11:30:00Z"}}`,
+	},
+	test.SvcIDAlt: {
+		"failed1": `{"id":"failed1","type":"backup","status":"failed","created_at":"2026-10-15T12:00:00Z"}`,
+	},
+}
+
+var findJobTests = []struct {
+	svcName   string
+	jobID     string
+	expectSvc string
+	expectErr bool
+}{
+	{"", "running1", test.SvcLabel, false},
+	{"", "failed1", test.SvcLabelAlt, false},
+	{test.SvcLabelAlt, "failed1", test.SvcLabelAlt, false},
+	{test.SvcLabel, "failed1", "", true},
+	{"", "unknown", "", true},
+	{"unknown", "running1", "", true},
+}
+
+var stopTests = []struct {
+	jobID      string
+	expectStop bool
+	expectErr  bool
+}{
+	{"running1", true, false},
+	{"failed1", false, true},
+	{"unknown", false, true},
+}
+
+var retryTests = []struct {
+	jobID       string
+	expectRetry bool
+	expectErr   bool
+}{
+	{"failed1", true, false},
+	{"running1", false, true},
+	{"unknown", false, true},
+}
+
+var describeTests = []struct {
+	jobID       string
+	notifyOnOOM bool
+	expectAlert bool
+	expectErr   bool
+}{
+	{"running1", false, false, false},
+	{"oom1", false, false, false},
+	{"oom1", true, true, false},
+	{"unknown", true, false, true},
+}
+
+func TestFindJob(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"},{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	for svcID, jobs := range svcJobs {
+		prefix := "/environments/" + test.EnvID + "/services/" + svcID + "/jobs/"
+		jobs := jobs
+		mux.HandleFunc(prefix,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				job, ok := jobs[strings.TrimPrefix(r.URL.Path, prefix)]
+				if !ok {
+					w.WriteHeader(404)
+					fmt.Fprint(w, `{"title":"Not Found","description":"Job not found","code":404}`)
+					return
+				}
+				fmt.Fprint(w, job)
+			},
+		)
+	}
+
+	for _, data := range findJobTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		service, job, err := findJob(data.svcName, data.jobID, services.New(settings), libjobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if err == nil {
+			test.AssertEquals(t, data.expectSvc, service.Label)
+			test.AssertEquals(t, data.jobID, job.ID)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"},{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	for svcID, jobs := range svcJobs {
+		prefix := "/environments/" + test.EnvID + "/services/" + svcID + "/jobs/"
+		jobs := jobs
+		mux.HandleFunc(prefix,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				job, ok := jobs[strings.TrimPrefix(r.URL.Path, prefix)]
+				if !ok {
+					w.WriteHeader(404)
+					fmt.Fprint(w, `{"title":"Not Found","description":"Job not found","code":404}`)
+					return
+				}
+				fmt.Fprint(w, job)
+			},
+		)
+	}
+	alerted := []string{}
+	mux.HandleFunc("/environments/"+test.EnvID+"/alerts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			var rule models.AlertRule
+			json.NewDecoder(r.Body).Decode(&rule)
+			alerted = append(alerted, fmt.Sprintf("%s %s %s", rule.Event, rule.ServiceID, rule.Target))
+			fmt.Fprint(w, `{"id":"1","event":"oom"}`)
+		},
+	)
+
+	for _, data := range describeTests {
+		t.Logf("Data: %+v", data)
+		alerted = []string{}
+
+		// test
+		err := CmdDescribe("", data.jobID, data.notifyOnOOM, services.New(settings), libjobs.New(settings), alerts.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if data.expectAlert {
+			test.AssertEquals(t, fmt.Sprintf("[oom %s mailer]", test.SvcID), fmt.Sprintf("%v", alerted))
+		} else if len(alerted) != 0 {
+			t.Errorf("Expected no alert rule to be created but got %v", alerted)
+		}
+	}
+}
+
+func TestOOMKilled(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	for svcID, jobs := range svcJobs {
+		prefix := "/environments/" + test.EnvID + "/services/" + svcID + "/jobs/"
+		jobs := jobs
+		mux.HandleFunc(prefix,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				job, ok := jobs[strings.TrimPrefix(r.URL.Path, prefix)]
+				if !ok {
+					w.WriteHeader(404)
+					fmt.Fprint(w, `{"title":"Not Found","description":"Job not found","code":404}`)
+					return
+				}
+				fmt.Fprint(w, job)
+			},
+		)
+	}
+	ij := libjobs.New(settings)
+
+	for jobID, expected := range map[string]string{"running1": "running", "oom1": libjobs.StatusOOMKilled} {
+		job, err := ij.Retrieve(jobID, test.SvcID, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		test.AssertEquals(t, expected, libjobs.DisplayStatus(job))
+	}
+}
+
+var listTests = []struct {
+	svcName    string
+	jobType    string
+	pageSize   int
+	expectRows []string
+	expectErr  bool
+}{
+	{test.SvcLabel, "", 20, []string{"ID", "running1", "mailer", "running", "oom1", libjobs.StatusOOMKilled}, false},
+	{test.SvcLabel, "", 2, []string{"running1", "oom1", "(for older jobs, try with --page 2 or adjust --page-size)"}, false},
+	{test.SvcLabelAlt, "backup", 20, []string{"failed1", "backup", "failed"}, false},
+	{test.SvcLabelAlt, "worker", 20, []string{"No jobs found for service " + test.SvcLabelAlt}, false},
+	{"unknown", "", 20, nil, true},
+}
+
+func TestList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"},{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	for svcID, jobs := range svcJobs {
+		jobs := jobs
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+svcID+"/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				jobType := r.URL.Query().Get("type")
+				list := []string{}
+				for _, j := range jobs {
+					if jobType == "" || strings.Contains(j, `"type":"`+jobType+`"`) {
+						list = append(list, j)
+					}
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(list, ","))
+			},
+		)
+	}
+
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdList(data.svcName, data.jobType, 1, data.pageSize, services.New(settings), libjobs.New(settings))
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+func TestStop(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"},{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	stopped := []string{}
+	for svcID, jobs := range svcJobs {
+		prefix := "/environments/" + test.EnvID + "/services/" + svcID + "/jobs/"
+		jobs := jobs
+		mux.HandleFunc(prefix,
+			func(w http.ResponseWriter, r *http.Request) {
+				jobID := strings.TrimPrefix(r.URL.Path, prefix)
+				job, ok := jobs[jobID]
+				if !ok {
+					w.WriteHeader(404)
+					fmt.Fprint(w, `{"title":"Not Found","description":"Job not found","code":404}`)
+					return
+				}
+				switch r.Method {
+				case "GET":
+					fmt.Fprint(w, job)
+				case "DELETE":
+					stopped = append(stopped, jobID)
+				}
+			},
+		)
+	}
+
+	for _, data := range stopTests {
+		t.Logf("Data: %+v", data)
+		stopped = []string{}
+
+		// test
+		err := CmdStop("", data.jobID, services.New(settings), libjobs.New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if ok := len(stopped) == 1 && stopped[0] == data.jobID; ok != data.expectStop {
+			t.Errorf("Expected stop: %t, actual: %v", data.expectStop, stopped)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"},{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	retried := []string{}
+	for svcID, jobs := range svcJobs {
+		prefix := "/environments/" + test.EnvID + "/services/" + svcID + "/jobs/"
+		jobs := jobs
+		mux.HandleFunc(prefix,
+			func(w http.ResponseWriter, r *http.Request) {
+				jobID := strings.TrimPrefix(r.URL.Path, prefix)
+				if strings.HasSuffix(jobID, "/retry") {
+					test.AssertEquals(t, r.Method, "POST")
+					retried = append(retried, strings.TrimSuffix(jobID, "/retry"))
+					fmt.Fprint(w, `{"id":"new1","type":"backup","status":"scheduled"}`)
+					return
+				}
+				test.AssertEquals(t, r.Method, "GET")
+				job, ok := jobs[jobID]
+				if !ok {
+					w.WriteHeader(404)
+					fmt.Fprint(w, `{"title":"Not Found","description":"Job not found","code":404}`)
+					return
+				}
+				fmt.Fprint(w, job)
+			},
+		)
+	}
+
+	for _, data := range retryTests {
+		t.Logf("Data: %+v", data)
+		retried = []string{}
+
+		// test
+		err := CmdRetry("", data.jobID, false, services.New(settings), libjobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if ok := len(retried) == 1 && retried[0] == data.jobID; ok != data.expectRetry {
+			t.Errorf("Expected retry: %t, actual: %v", data.expectRetry, retried)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// SortedJobs is a wrapper for Job array in order to sort them by CreatedAt,
+// newest first
+type SortedJobs []models.Job
+
+func (jobs SortedJobs) Len() int {
+	return len(jobs)
+}
+
+func (jobs SortedJobs) Swap(i, j int) {
+	jobs[i], jobs[j] = jobs[j], jobs[i]
+}
+
+func (jobs SortedJobs) Less(i, j int) bool {
+	return jobs[i].CreatedAt > jobs[j].CreatedAt
+}
+
+func CmdList(svcName, jobType string, page, pageSize int, is services.IServices, ij libjobs.IJobs) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	var jobs *[]models.Job
+	if jobType != "" {
+		jobs, err = ij.RetrieveByType(service.ID, jobType, page, pageSize)
+	} else {
+		jobs, err = ij.List(service.ID, page, pageSize)
+	}
+	if err != nil {
+		return err
+	}
+	if len(*jobs) == 0 {
+		if page == 1 {
+			logrus.Printf("No jobs found for service %s", svcName)
+		} else {
+			logrus.Println("No jobs found with the given parameters.")
+		}
+		return nil
+	}
+	sort.Sort(SortedJobs(*jobs))
+
+	data := [][]string{{"ID", "TYPE", "TARGET", "STATUS", "CREATED"}}
+	for _, j := range *jobs {
+		data = append(data, []string{j.ID, j.Type, j.Target, libjobs.DisplayStatus(&j), config.FormatTimestampString(j.CreatedAt)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	if len(*jobs) == pageSize && page == 1 {
+		logrus.Println("(for older jobs, try with --page 2 or adjust --page-size)")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+)
+
+func CmdRetry(svcName, jobID string, follow bool, is services.IServices, ij libjobs.IJobs) error {
+	service, job, err := findJob(svcName, jobID, is, ij)
+	if err != nil {
+		return err
+	}
+	if isActive(job.Status) {
+		return fmt.Errorf("The %s job %s has not ended yet (status = %s). You can stop it with \"datica jobs stop %s\"", job.Type, job.ID, job.Status, job.ID)
+	}
+	newJob, err := ij.Retry(job.ID, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Retrying the %s job %s (job ID = %s)", job.Type, job.ID, newJob.ID)
+	if !follow {
+		logrus.Printf("You can wait for it to complete with \"datica jobs attach %s %s\"", service.Label, newJob.ID)
+		return nil
+	}
+	logrus.Println("Polling until the job completes.")
+	status, err := ij.PollForStatus(libjobs.TerminalStatuses(job.Type), newJob.ID, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("\nEnded in status '%s'", status)
+	return nil
+}
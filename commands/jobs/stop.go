@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	libjobs "github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdStop(svcName, jobID string, is services.IServices, ij libjobs.IJobs, ip prompts.IPrompts) error {
+	service, job, err := findJob(svcName, jobID, is, ij)
+	if err != nil {
+		return err
+	}
+	if !isActive(job.Status) {
+		return fmt.Errorf("The %s job %s has already ended in status '%s'", job.Type, job.ID, job.Status)
+	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to stop the %s job %s for service %s? (y/n) ", job.Type, job.ID, service.Label))
+	if err != nil {
+		return err
+	}
+	if err = ij.Delete(job.ID, service.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Stopped the %s job %s", job.Type, job.ID)
+	return nil
+}
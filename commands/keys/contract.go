@@ -4,6 +4,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/deploykeys"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -18,12 +19,13 @@ var Cmd = models.Command{
 		"Any SSH keys added to your user account should not be shared but be treated as private SSH keys. " +
 		"Any SSH key uploaded to your user account will be able to be used with all code services and environments that you have access to. " +
 		"The keys command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(AddSubCmd.Name, AddSubCmd.ShortHelp, AddSubCmd.LongHelp, AddSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RemoveSubCmd.Name, RemoveSubCmd.ShortHelp, RemoveSubCmd.LongHelp, RemoveSubCmd.CmdFunc(settings))
-			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, SetSubCmd.LongHelp, SetSubCmd.CmdFunc(settings))
+			cmd.CommandLong(AddSubCmd.Name, AddSubCmd.ShortHelp, help.Render(AddSubCmd.LongHelp), AddSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RemoveSubCmd.Name, RemoveSubCmd.ShortHelp, help.Render(RemoveSubCmd.LongHelp), RemoveSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, help.Render(SetSubCmd.LongHelp), SetSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -104,6 +106,8 @@ var SetSubCmd = models.Command{
 		"This can be useful for automation or where shared workstations are involved. " +
 		"Please note that you must pass in the path to the private key and not the public key. " +
 		"The given key must already be added to your account by using the [keys add](#keys-add) command. " +
+		"When your OS has a keychain (macOS Keychain, Windows Credential Manager, or the Secret Service on Linux), the key is copied into it and read from there when signing in. " +
+		"Run `keys set` again after replacing the key files. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica keys set ~/.ssh/my_key\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
@@ -36,11 +37,15 @@ func CmdSet(path string, settings *models.Settings) error {
 
 	settings.PrivateKeyPath = fullPath
 	settings.SessionToken = ""
+	settings.RefreshToken = ""
 	a := auth.New(settings, prompts.New())
 	user, err := a.Signin()
 	if err != nil {
 		return err
 	}
+	if err = config.StorePrivateKey(fullPath); err != nil {
+		logrus.Warnf("Unable to store the key in the keychain, it will be read from %s instead: %s", fullPath, err)
+	}
 	logrus.Printf("Successfully added key and signed in as %s.", user.Email)
 	return nil
 }
@@ -15,8 +15,10 @@ var Cmd = models.Command{
 	ShortHelp: "Clear the stored user information from your local machine",
 	LongHelp: "When using the CLI, your username and password are **never** stored in any file on your filesystem. " +
 		"However, in order to not type in your username and password each and every command, a session token is stored in the CLI's configuration file and used until it expires. " +
-		"`logout` removes this session token from the configuration file. Here is a sample command\n\n" +
+		"When the session expires, it is refreshed with a refresh token stored alongside it instead of prompting you to sign in again. " +
+		"`logout` removes the session token and the refresh token. Here is a sample command\n\n" +
 		"```\ndatica logout\n```",
+	Category: models.CategoryAccess,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			cmd.Action = func() {
@@ -15,6 +15,8 @@ func CmdLogout(il ILogout, ia auth.IAuth) error {
 // not remove environment data.
 func (l *SLogout) Clear() error {
 	l.Settings.SessionToken = ""
+	l.Settings.RefreshToken = ""
+	l.Settings.BreakGlass = nil
 	l.Settings.UsersID = ""
 	return nil
 }
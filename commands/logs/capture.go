@@ -0,0 +1,331 @@
+package logs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/sites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/daticahealth/cli/models"
+)
+
+// ManifestFile is the name of the manifest written to the output directory
+// of a capture
+const ManifestFile = "manifest.json"
+
+// captureManifest describes an evidence bundle written by logs capture
+type captureManifest struct {
+	Environment   string            `json:"environment"`
+	EnvironmentID string            `json:"environment_id"`
+	Query         string            `json:"query"`
+	Level         string            `json:"level,omitempty"`
+	UserID        string            `json:"user_id"`
+	LogsSince     string            `json:"logs_since"`
+	StartedAt     string            `json:"started_at"`
+	EndedAt       string            `json:"ended_at"`
+	Services      []capturedService `json:"services"`
+}
+
+// capturedService holds the files the logs of a single service were written
+// to, in order
+type capturedService struct {
+	Service string         `json:"service"`
+	Lines   int            `json:"lines"`
+	Files   []capturedFile `json:"files"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type capturedFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// CmdCapture writes the logs of the given services to a file per service in
+// outputDir until the duration has passed or the command is interrupted. The
+// logs sent during the since period before the capture started are written
+// first. Files are rotated once they reach maxSize megabytes and a manifest
+// listing every file with its checksum is written last.
+func CmdCapture(queryString string, serviceNames []string, level string, since, duration time.Duration, outputDir string, maxSize int, settings *models.Settings, il ILogs, ie environments.IEnvironments, is services.IServices, isites sites.ISites, ij jobs.IJobs) error {
+	if duration <= 0 {
+		return fmt.Errorf("The duration must be greater than zero")
+	}
+	if maxSize <= 0 {
+		return fmt.Errorf("The maximum file size must be greater than zero")
+	}
+	if _, err := NewFilter("", level); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, ManifestFile)); err == nil {
+		return fmt.Errorf("%s already holds a capture. Please choose a different output directory.", outputDir)
+	}
+	env, err := ie.Retrieve(settings.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	svcs, err := captureServices(serviceNames, is)
+	if err != nil {
+		return err
+	}
+	domain, err := logsDomain(env, is, isites)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(outputDir, 0700); err != nil {
+		return err
+	}
+
+	start := time.Now().UTC()
+	end := start.Add(duration)
+	manifest := captureManifest{
+		Environment:   env.Name,
+		EnvironmentID: env.ID,
+		Query:         queryString,
+		Level:         level,
+		UserID:        settings.UsersID,
+		LogsSince:     start.Add(-since).Format(time.RFC3339),
+		StartedAt:     start.Format(time.RFC3339),
+		Services:      make([]capturedService, len(svcs)),
+	}
+
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		if _, ok := <-interrupt; ok {
+			logrus.Println("Stopping the capture...")
+			close(stop)
+		}
+	}()
+
+	logrus.Printf("Capturing the logs of %d services to %s until %s, hit ctrl-c to stop early", len(svcs), outputDir, config.FormatTimestamp(end))
+	var wg sync.WaitGroup
+	for i, svc := range svcs {
+		wg.Add(1)
+		go func(i int, svc models.Service) {
+			defer wg.Done()
+			result := capturedService{Service: svc.Label}
+			filter, _ := NewFilter(svc.Label, level)
+			if svc.Type == "code" {
+				filter.targets = newTargetResolver([]models.Service{svc}, ij)
+			}
+			out := newRotatingFile(outputDir, svc.Label, int64(maxSize)*1024*1024)
+			lines, err := il.Capture(queryString, filter, settings.SessionToken, domain, start.Add(-since), end, transfer.NewWriteCloserTransfer(out, 0), stop)
+			result.Lines = lines
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				result.Error = err.Error()
+				config.Warn(config.WarningPartialFailure, "Failed to capture the logs of %s: %s", svc.Label, err)
+			}
+			result.Files = out.files
+			manifest.Services[i] = result
+		}(i, svc)
+	}
+	wg.Wait()
+	manifest.EndedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if err = writeManifest(outputDir, &manifest); err != nil {
+		return err
+	}
+	lines := 0
+	for _, svc := range manifest.Services {
+		lines += svc.Lines
+	}
+	logrus.Printf("Captured %d log lines of %d services to %s", lines, len(svcs), outputDir)
+	return nil
+}
+
+// captureServices returns the services with the given labels, or every
+// service of the environment if the labels include "all". Labels can be
+// given separated by commas.
+func captureServices(serviceNames []string, is services.IServices) ([]models.Service, error) {
+	labels := []string{}
+	for _, name := range serviceNames {
+		for _, label := range strings.Split(name, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+	}
+	svcs, err := is.List()
+	if err != nil {
+		return nil, err
+	}
+	chosen := []models.Service{}
+	for _, label := range labels {
+		if label == "all" {
+			return *svcs, nil
+		}
+		found := false
+		for _, svc := range *svcs {
+			if svc.Label == label {
+				chosen = append(chosen, svc)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", label)
+		}
+	}
+	if len(chosen) == 0 {
+		return nil, fmt.Errorf("You must specify at least one service or \"all\"")
+	}
+	return chosen, nil
+}
+
+// writeManifest computes the size and checksum of every captured file and
+// writes the manifest to the output directory
+func writeManifest(outputDir string, manifest *captureManifest) error {
+	sort.Sort(SortedCapturedServices(manifest.Services))
+	for i := range manifest.Services {
+		for j, f := range manifest.Services[i].Files {
+			b, err := ioutil.ReadFile(filepath.Join(outputDir, f.Name))
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(b)
+			manifest.Services[i].Files[j].Size = int64(len(b))
+			manifest.Services[i].Files[j].SHA256 = hex.EncodeToString(sum[:])
+		}
+	}
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(outputDir, ManifestFile), b, 0600)
+}
+
+// rotatingFile writes the captured logs of a service to numbered files,
+// starting a new file once the current file would grow past maxBytes
+type rotatingFile struct {
+	dir      string
+	name     string
+	maxBytes int64
+	f        *os.File
+	size     int64
+	files    []capturedFile
+}
+
+func newRotatingFile(dir, name string, maxBytes int64) *rotatingFile {
+	return &rotatingFile{
+		dir:      dir,
+		name:     name,
+		maxBytes: maxBytes,
+	}
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.f == nil || (r.size > 0 && r.size+int64(len(p)) > r.maxBytes) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.closeCurrent(); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%03d.log", r.name, len(r.files)+1)
+	f, err := os.OpenFile(filepath.Join(r.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	r.f = f
+	r.size = 0
+	r.files = append(r.files, capturedFile{Name: name})
+	return nil
+}
+
+// Close closes the current file. Services without any logs still get an
+// empty file so that the bundle shows they were captured.
+func (r *rotatingFile) Close() error {
+	if len(r.files) == 0 {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	return r.closeCurrent()
+}
+
+func (r *rotatingFile) closeCurrent() error {
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+// Capture writes the matching logs sent between start and end to out,
+// polling for new logs until end or until stop is closed, and returns the
+// number of lines written. Failed polls are retried until end.
+func (l *SLogs) Capture(queryString string, filter *Filter, sessionToken, domain string, start, end time.Time, out io.Writer, stop <-chan struct{}) (int, error) {
+	pos := newStreamPosition(start)
+	lines := 0
+	var writeErr error
+	for {
+		err := l.searchSince(queryString, filter, domain, sessionToken, pos, func(lh models.LogHits) {
+			line := fmt.Sprintf("%s - %s\n", field(lh, "@timestamp"), field(lh, "message"))
+			if target := filter.target(field(lh, jobField)); target != "" {
+				line = fmt.Sprintf("%s - [%s] %s\n", field(lh, "@timestamp"), target, field(lh, "message"))
+			}
+			if _, err := io.WriteString(out, line); err != nil && writeErr == nil {
+				writeErr = err
+			}
+			lines++
+		})
+		if writeErr != nil {
+			return lines, writeErr
+		}
+		if err != nil {
+			logrus.Debugf("Failed to retrieve the logs of %s, retrying: %s", filter.Service, err)
+		}
+		if !time.Now().Before(end) {
+			return lines, err
+		}
+		select {
+		case <-stop:
+			return lines, nil
+		case <-time.After(config.LogPollTime * time.Second):
+		}
+	}
+}
+
+// SortedCapturedServices is a wrapper for an array of captured services in
+// order to sort them by label
+type SortedCapturedServices []capturedService
+
+func (cs SortedCapturedServices) Len() int {
+	return len(cs)
+}
+
+func (cs SortedCapturedServices) Swap(i, j int) {
+	cs[i], cs[j] = cs[j], cs[i]
+}
+
+func (cs SortedCapturedServices) Less(i, j int) bool {
+	return cs[i].Service < cs[j].Service
+}
@@ -0,0 +1,88 @@
+package logs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/test"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := newRotatingFile(dir, test.SvcLabel, 10)
+	for _, line := range []string{"12345\n", "12345\n", "1234567890123\n", "1\n"} {
+		if _, err = out.Write([]byte(line)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	if err = out.Close(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	names := []string{}
+	for _, f := range out.files {
+		names = append(names, f.Name)
+	}
+	test.AssertEquals(t, "code1-001.log code1-002.log code1-003.log code1-004.log", strings.Join(names, " "))
+	b, err := ioutil.ReadFile(filepath.Join(dir, "code1-003.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEquals(t, "1234567890123\n", string(b))
+
+	empty := newRotatingFile(dir, test.SvcLabelAlt, 10)
+	if err = empty.Close(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(empty.files) != 1 {
+		t.Errorf("Expected an empty file for a service without logs but got %+v", empty.files)
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "code1-001.log"), []byte("line\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := &captureManifest{
+		Environment: test.EnvName,
+		Services: []capturedService{
+			{Service: test.SvcLabelAlt, Error: "failed"},
+			{Service: test.SvcLabel, Lines: 1, Files: []capturedFile{{Name: "code1-001.log"}}},
+		},
+	}
+	if err = writeManifest(dir, manifest); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written captureManifest
+	if err = json.Unmarshal(b, &written); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEquals(t, test.SvcLabel, written.Services[0].Service)
+	f := written.Services[0].Files[0]
+	if f.Size != 5 {
+		t.Errorf("Expected a size of 5 but got %d", f.Size)
+	}
+	sum := sha256.Sum256([]byte("line\n"))
+	test.AssertEquals(t, hex.EncodeToString(sum[:]), f.SHA256)
+}
@@ -1,15 +1,20 @@
 package logs
 
 import (
+	"io"
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/audit"
 	"github.com/daticahealth/cli/commands/environments"
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/commands/sites"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
 )
@@ -23,12 +28,29 @@ var Cmd = models.Command{
 		"If you do not see your logs, try adjusting the number of hours, minutes, or seconds of logs that are retrieved with the `--hours`, `--minutes`, and `--seconds` options respectively. " +
 		"You can also follow the logs with the `-f` option. " +
 		"When using `-f` all logs will be printed to the console within the given time frame as well as any new logs that are sent to the logging Dashboard for the duration of the command. " +
+		"You can limit the logs to a single service with `--service`, which defaults to the service pinned by the workspace file of the current git repo, and to a minimum severity with `--level`, which is one of `debug`, `info`, `warn`, `error`, or `fatal`. " +
+		"Lines sent by workers are prefixed with their worker target, and `--target` limits the logs to the workers of a single target. " +
+		"Deploys, rollbacks, restarts, and scaling of the environment found in the [audit](#audit) trail are printed between the log lines as markers starting with `***`, so you can tell whether a change in the logs started before or after a deploy. " +
+		"Use `--no-markers` to only print log lines. " +
+		"Logs without a level are matched by the level found in the message. " +
+		"The query can be given with `--query` or as an argument. " +
+		"If the connection to your logging Dashboard is dropped while following logs, the CLI reconnects automatically and prints any logs that were sent while disconnected. " +
 		"When using the `-f` option, hit ctrl-c to stop. Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" logs --hours=6 --minutes=30\n" +
-		"datica -E \"<your_env_alias>\" logs -f\n```",
+		"datica -E \"<your_env_alias>\" logs -f\n" +
+		"datica -E \"<your_env_alias>\" logs -f --service worker01 --level error\n" +
+		"datica -E \"<your_env_alias>\" logs -f --service code-1 --target etl\n```\n\n" +
+		"Use [logs capture](#logs-capture) to save the logs of several services to files during an incident.",
+	Category: models.CategoryObservability,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CaptureSubCmd.Name, CaptureSubCmd.ShortHelp, help.Render(CaptureSubCmd.LongHelp), CaptureSubCmd.CmdFunc(settings))
 			query := cmd.StringArg("QUERY", "*", "The query to send to your logging dashboard's elastic search (regex is supported)")
+			queryOpt := cmd.StringOpt("q query", "", "The query to send to your logging dashboard's elastic search (regex is supported). This is the same as the QUERY argument")
+			serviceName := cmd.StringOpt("service", "", "The name of the service to show logs for (i.e. 'code-1'). Defaults to the service pinned by the workspace file.")
+			target := cmd.StringOpt("target", "", "The worker target to show logs for (i.e. 'etl')")
+			level := cmd.StringOpt("level", "", "The minimum severity of logs to show (debug, info, warn, error, or fatal)")
+			noMarkers := cmd.BoolOpt("no-markers", false, "Do not print deploy and scaling markers between log lines")
 			follow := cmd.BoolOpt("f follow", false, "Tail/follow the logs (Equivalent to -t)")
 			tail := cmd.BoolOpt("t tail", false, "Tail/follow the logs (Equivalent to -f)")
 			hours := cmd.IntOpt("hours", 0, "The number of hours before now (in combination with minutes and seconds) to retrieve logs")
@@ -41,21 +63,78 @@ var Cmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdLogs(*query, *follow || *tail, *hours, *mins, *secs, settings.EnvironmentID, settings, New(settings), prompts.New(), environments.New(settings), services.New(settings), sites.New(settings))
+				if *queryOpt != "" {
+					*query = *queryOpt
+				}
+				err := CmdLogs(*query, config.ServiceName(*serviceName, settings), *target, *level, *follow || *tail, !*noMarkers, *hours, *mins, *secs, settings.EnvironmentID, settings, New(settings), prompts.New(), environments.New(settings), services.New(settings), sites.New(settings), jobs.New(settings), audit.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[QUERY | --query] [--service] [--target] [--level] [--no-markers] [(-f | -t)] [--hours] [--minutes] [--seconds]"
+		}
+	},
+}
+
+var CaptureSubCmd = models.Command{
+	Name:      "capture",
+	ShortHelp: "Save the logs of services to files for an incident",
+	LongHelp: "`logs capture` saves the logs of the given services to a file per service in the output directory, such as while investigating an incident. " +
+		"The logs of every service are retrieved at the same time for the given `--duration`, or until you hit ctrl-c. " +
+		"Use `--services all` to capture every service of the environment, or give the labels of the services to capture. " +
+		"Use `--since` to also capture the logs sent before the capture started. " +
+		"Each file holds at most `--max-size` megabytes of logs, after which a new numbered file is started. " +
+		"Once the capture is finished, a `" + ManifestFile + "` file is written listing the environment, the time frame, and every file along with its size and SHA-256 checksum so the output directory can be kept as evidence. " +
+		"Use `--limit-rate` to limit how fast the logs of all services together are written, which also slows down how fast they are retrieved. " +
+		"The query and `--level` work like they do for [logs](#logs). Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" logs capture --services all --duration 30m --output incident-2024-05-01/\n" +
+		"datica -E \"<your_env_alias>\" logs capture --services code-1,worker01 --since 1h --duration 10m --level warn --output incident/\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			query := subCmd.StringArg("QUERY", "*", "The query to send to your logging dashboard's elastic search (regex is supported)")
+			serviceNames := subCmd.StringsOpt("services", []string{"all"}, "The labels of the services to capture, or \"all\"")
+			level := subCmd.StringOpt("level", "", "The minimum severity of logs to capture (debug, info, warn, error, or fatal)")
+			since := subCmd.StringOpt("since", "0s", "How long before now to start capturing logs from (i.e. '1h')")
+			duration := subCmd.StringOpt("duration", "30m", "How long to capture new logs for (i.e. '30m')")
+			maxSize := subCmd.IntOpt("max-size", 100, "The size in megabytes after which a new file is started")
+			output := subCmd.StringOpt("o output", "", "The directory to write the captured logs and manifest to")
+			limitRate := subCmd.StringOpt("limit-rate", "", "The maximum transfer rate in bytes per second, such as 500K or 5M")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				sinceDuration, err := time.ParseDuration(*since)
+				if err != nil {
+					logrus.Fatalf("Invalid --since \"%s\": %s", *since, err)
+				}
+				captureDuration, err := time.ParseDuration(*duration)
+				if err != nil {
+					logrus.Fatalf("Invalid --duration \"%s\": %s", *duration, err)
+				}
+				rate, err := transfer.ParseRate(*limitRate)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				transfer.SetRateLimit(rate)
+				err = CmdCapture(*query, *serviceNames, *level, sinceDuration, captureDuration, *output, *maxSize, settings, New(settings), environments.New(settings), services.New(settings), sites.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			cmd.Spec = "[QUERY] [(-f | -t)] [--hours] [--minutes] [--seconds]"
+			subCmd.Spec = "[QUERY] [--services...] [--level] [--since] [--duration] [--max-size] [--limit-rate] -o"
 		}
 	},
 }
 
 // ILogs ...
 type ILogs interface {
-	Output(queryString, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, startTimestamp time.Time, endTimestamp time.Time, env *models.Environment) (int, time.Time, error)
-	Stream(queryString, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, timestamp time.Time, env *models.Environment) error
-	Watch(queryString, domain, sessionToken string) error
+	Capture(queryString string, filter *Filter, sessionToken, domain string, start, end time.Time, out io.Writer, stop <-chan struct{}) (int, error)
+	Output(queryString string, filter *Filter, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, startTimestamp time.Time, endTimestamp time.Time, env *models.Environment) (int, time.Time, error)
+	Stream(queryString string, filter *Filter, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, timestamp time.Time, env *models.Environment) error
+	Watch(queryString string, filter *Filter, domain, sessionToken string) error
 }
 
 // SLogs is a concrete implementation of ILogs
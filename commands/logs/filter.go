@@ -0,0 +1,154 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daticahealth/cli/models"
+)
+
+const (
+	// serviceField is the log field holding the label of the service that
+	// sent the log line
+	serviceField = "service_label"
+	// levelField is the log field holding the severity of the log line
+	levelField = "level"
+	// jobField is the log field holding the ID of the job that sent the log
+	// line
+	jobField = "job_id"
+)
+
+// severities are the supported log levels in increasing order of severity
+var severities = []string{"debug", "info", "warn", "error", "fatal"}
+
+var severityAliases = map[string]string{
+	"trace":    "debug",
+	"notice":   "info",
+	"warning":  "warn",
+	"err":      "error",
+	"critical": "fatal",
+	"crit":     "fatal",
+	"panic":    "fatal",
+}
+
+var levelRegex = regexp.MustCompile(`(?i)\b(trace|debug|info|notice|warn|warning|err|error|crit|critical|fatal|panic)\b`)
+
+// Filter restricts the logs that are printed to a single service, a single
+// worker target, and a minimum severity. Filters are sent to the logging
+// dashboard so only matching logs are retrieved, and are applied again to
+// each log line for sources that do not support them, such as the live log
+// stream. Worker targets are not known to the logging dashboard so they are
+// only matched here.
+type Filter struct {
+	Service string
+	Target  string
+	Level   string
+	targets *targetResolver
+	// markers are printed between the log lines that pass the filter
+	markers *markerQueue
+}
+
+// NewFilter validates the given level and returns a Filter
+func NewFilter(service, level string) (*Filter, error) {
+	f := &Filter{Service: service}
+	if level != "" {
+		f.Level = normalizeLevel(level)
+		if severity(f.Level) < 0 {
+			return nil, fmt.Errorf("Invalid log level \"%s\". Please specify one of %s.", level, strings.Join(severities, ", "))
+		}
+	}
+	return f, nil
+}
+
+// Match returns whether or not a log line should be printed. If the line does
+// not have a level, the level is determined from the message.
+func (f *Filter) Match(service, jobID, level, message string) bool {
+	if f == nil {
+		return true
+	}
+	if f.Service != "" && service != "" && service != f.Service {
+		return false
+	}
+	if f.Target != "" && f.targets.target(jobID) != f.Target {
+		return false
+	}
+	if f.Level != "" {
+		if level == "" {
+			level = levelRegex.FindString(message)
+		}
+		if severity(normalizeLevel(level)) < severity(f.Level) {
+			return false
+		}
+	}
+	return true
+}
+
+// target returns the worker target of the job that sent a log line or an
+// empty string if it was not sent by a worker
+func (f *Filter) target(jobID string) string {
+	if f == nil {
+		return ""
+	}
+	return f.targets.target(jobID)
+}
+
+// clauses returns the elastic search filters that implement this Filter.
+// Logs that are missing a field are not filtered out by the logging dashboard
+// so they can be matched by Match instead.
+func (f *Filter) clauses() []string {
+	if f == nil {
+		return nil
+	}
+	clauses := []string{}
+	if f.Service != "" {
+		clauses = append(clauses, termsClause(serviceField, []string{f.Service}))
+	}
+	if f.Level != "" {
+		levels := []string{}
+		for _, s := range severities[severity(f.Level):] {
+			levels = append(levels, s, strings.ToUpper(s))
+			for alias, name := range severityAliases {
+				if name == s {
+					levels = append(levels, alias, strings.ToUpper(alias))
+				}
+			}
+		}
+		clauses = append(clauses, termsClause(levelField, levels))
+	}
+	return clauses
+}
+
+func termsClause(field string, values []string) string {
+	b, _ := json.Marshal(values)
+	return `{"bool": {"should": [{"terms": {"` + field + `": ` + string(b) + `}}, {"missing": {"field": "` + field + `"}}]}}`
+}
+
+func normalizeLevel(level string) string {
+	level = strings.ToLower(level)
+	if name, ok := severityAliases[level]; ok {
+		return name
+	}
+	return level
+}
+
+// severity returns the position of the given level in severities or -1 if
+// it is not a known level
+func severity(level string) int {
+	for i, s := range severities {
+		if s == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// field returns the first value of the given field in a log hit or an empty
+// string if the field is missing
+func field(lh models.LogHits, name string) string {
+	if values, ok := lh.Fields[name]; ok && len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var filterTests = []struct {
+	service   string
+	level     string
+	lineSvc   string
+	lineLevel string
+	message   string
+	match     bool
+	expectErr bool
+}{
+	{"", "", "worker01", "", "anything", true, false},
+	{"worker01", "", "worker01", "", "anything", true, false},
+	{"worker01", "", "code01", "", "anything", false, false},
+	{"worker01", "", "", "", "no service on this line", true, false},
+	{"", "error", "", "ERROR", "failed", true, false},
+	{"", "error", "", "info", "started", false, false},
+	{"", "warn", "", "critical", "down", true, false},
+	{"", "error", "", "", "[ERROR] could not connect", true, false},
+	{"", "error", "", "", "GET / 200", false, false},
+	{"worker01", "warning", "worker01", "", "WARNING: disk almost full", true, false},
+	{"", "loud", "", "", "", false, true},
+}
+
+func TestFilter(t *testing.T) {
+	for _, data := range filterTests {
+		t.Logf("Data: %+v", data)
+		f, err := NewFilter(data.service, data.level)
+		if err != nil {
+			if !data.expectErr {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			continue
+		}
+		if data.expectErr {
+			t.Errorf("Expected error but got nil")
+			continue
+		}
+		if match := f.Match(data.lineSvc, "", data.lineLevel, data.message); match != data.match {
+			t.Errorf("Expected match to be %t but got %t", data.match, match)
+		}
+		var query map[string]interface{}
+		if err := json.Unmarshal(generateQuery("*", "source", "app", f, time.Now(), 0), &query); err != nil {
+			t.Errorf("Generated an invalid query: %s", err)
+		}
+	}
+}
+
+var targetFilterTests = []struct {
+	target       string
+	jobID        string
+	expectPrefix string
+	match        bool
+}{
+	{"", "etl1", "etl", true},
+	{"", "deploy1", "", true},
+	{"", "", "", true},
+	{"etl", "etl1", "etl", true},
+	{"etl", "etl2", "etl", true},
+	{"etl", "mailer1", "mailer", false},
+	{"etl", "deploy1", "", false},
+	{"etl", "", "", false},
+}
+
+func TestTargetFilter(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			test.AssertEquals(t, r.URL.Query().Get("type"), "worker")
+			fmt.Fprint(w, `[{"id":"etl1","type":"worker","target":"etl"},{"id":"etl2","type":"worker","target":"etl"},{"id":"mailer1","type":"worker","target":"mailer"}]`)
+		},
+	)
+	resolver := newTargetResolver([]models.Service{{ID: test.SvcID, Label: test.SvcLabel}}, jobs.New(settings))
+	if !resolver.has("etl") || resolver.has("unknown") {
+		t.Fatalf("Expected only the targets of the worker jobs to be known but got %v", resolver.targets)
+	}
+
+	for _, data := range targetFilterTests {
+		t.Logf("Data: %+v", data)
+		f, err := NewFilter("", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		f.Target = data.target
+		f.targets = resolver
+		if prefix := f.target(data.jobID); prefix != data.expectPrefix {
+			t.Errorf("Expected prefix to be %s but got %s", data.expectPrefix, prefix)
+		}
+		if match := f.Match(test.SvcLabel, data.jobID, "", "message"); match != data.match {
+			t.Errorf("Expected match to be %t but got %t", data.match, match)
+		}
+	}
+}
@@ -10,11 +10,15 @@ import (
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/audit"
 	"github.com/daticahealth/cli/commands/environments"
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/commands/sites"
 	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/lib/stream"
 	"github.com/daticahealth/cli/models"
 )
 
@@ -25,83 +29,113 @@ const size = 50
 // log statement into a separate block that spans multiple lines so it's
 // not very cohesive. This is intended to be similar to the `heroku logs`
 // command.
-func CmdLogs(queryString string, follow bool, hours, minutes, seconds int, envID string, settings *models.Settings, il ILogs, ip prompts.IPrompts, ie environments.IEnvironments, is services.IServices, isites sites.ISites) error {
+func CmdLogs(queryString, serviceName, target, level string, follow, markers bool, hours, minutes, seconds int, envID string, settings *models.Settings, il ILogs, ip prompts.IPrompts, ie environments.IEnvironments, is services.IServices, isites sites.ISites, ij jobs.IJobs, ia audit.IAudit) error {
 	if follow && (hours > 0 || minutes > 0 || seconds > 0) {
-		logrus.Warnln("Specifying \"logs -f\" in combination with \"--hours\", \"--minutes\", or \"--seconds\" has been deprecated!")
-		logrus.Warnln("Please specify either \"-f\" or use \"--hours\", \"--minutes\", \"--seconds\" but not both. Support for \"-f\" and a specified time frame will be removed in a later version.")
+		if err := config.Deprecated("Specifying \"logs -f\" in combination with \"--hours\", \"--minutes\", or \"--seconds\" has been deprecated! Please specify either \"-f\" or use \"--hours\", \"--minutes\", \"--seconds\" but not both. Support for \"-f\" and a specified time frame will be removed in a later version."); err != nil {
+			return err
+		}
 	}
-	env, err := ie.Retrieve(envID)
+	filter, err := NewFilter("", level)
 	if err != nil {
 		return err
 	}
-	serviceProxy, err := is.RetrieveByLabel("service_proxy")
+	env, err := ie.Retrieve(envID)
 	if err != nil {
 		return err
 	}
-	sites, err := isites.List(serviceProxy.ID)
+	// the worker jobs of these services are used to prefix log lines with
+	// their worker target
+	codeServices := []models.Service{}
+	if serviceName != "" {
+		service, err := is.RetrieveByLabel(serviceName)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", serviceName)
+		}
+		filter.Service = service.Label
+		codeServices = append(codeServices, *service)
+	} else {
+		svcs, err := is.List()
+		if err != nil {
+			return err
+		}
+		for _, svc := range *svcs {
+			if svc.Type == "code" {
+				codeServices = append(codeServices, svc)
+			}
+		}
+	}
+	filter.targets = newTargetResolver(codeServices, ij)
+	filter.Target = target
+	if target != "" && !follow && !filter.targets.has(target) {
+		return fmt.Errorf("Could not find any worker jobs with the target \"%s\". You can list the workers of a service with the \"datica worker list\" command.", target)
+	}
+	domain, err := logsDomain(env, is, isites)
 	if err != nil {
 		return err
 	}
-	domain := ""
-	for _, site := range *sites {
-		if strings.HasPrefix(site.Name, env.Namespace) {
-			domain = site.Name
-			break
-		}
-	}
-	if domain == "" {
-		return errors.New("Could not determine the fully qualified domain name of your environment. Please contact Datica Support at https://datica.com/support with this error message to resolve this issue.")
+	offset := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	timestamp := time.Now().In(time.UTC).Add(-1 * offset)
+	if markers {
+		filter.markers = newMarkerQueue(env.ID, timestamp, ia)
 	}
 	if follow {
-		if err := il.Watch(queryString, domain, settings.SessionToken); err != nil {
+		if err := il.Watch(queryString, filter, domain, settings.SessionToken); err != nil {
 			logrus.Debugf("Error attempting to stream logs from logwatch: %s", err)
 		} else {
 			return nil
 		}
 	}
 	from := 0
-	offset := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
-	timestamp := time.Now().In(time.UTC).Add(-1 * offset)
-	from, timestamp, err = il.Output(queryString, settings.SessionToken, domain, follow, hours, minutes, seconds, from, timestamp, time.Now(), env)
+	from, timestamp, err = il.Output(queryString, filter, settings.SessionToken, domain, follow, hours, minutes, seconds, from, timestamp, time.Now(), env)
 	if err != nil {
 		return err
 	}
 	if follow {
-		return il.Stream(queryString, settings.SessionToken, domain, follow, hours, minutes, seconds, from, timestamp, env)
+		return il.Stream(queryString, filter, settings.SessionToken, domain, follow, hours, minutes, seconds, from, timestamp, env)
 	}
 	return nil
 }
 
-func (l *SLogs) Output(queryString, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, startTimestamp, endTimestamp time.Time, env *models.Environment) (int, time.Time, error) {
-	appLogsIdentifier := "source"
-	appLogsValue := "app"
-	if strings.HasPrefix(domain, "pod01") || strings.HasPrefix(domain, "csb01") {
-		appLogsIdentifier = "syslog_program"
-		appLogsValue = "supervisord"
+// logsDomain returns the domain of the logging dashboard of an environment
+func logsDomain(env *models.Environment, is services.IServices, isites sites.ISites) (string, error) {
+	serviceProxy, err := is.RetrieveByLabel("service_proxy")
+	if err != nil {
+		return "", err
 	}
+	sites, err := isites.List(serviceProxy.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, site := range *sites {
+		if strings.HasPrefix(site.Name, env.Namespace) {
+			return site.Name, nil
+		}
+	}
+	return "", errors.New("Could not determine the fully qualified domain name of your environment. Please contact Datica Support at https://datica.com/support with this error message to resolve this issue.")
+}
 
-	urlString := fmt.Sprintf("https://%s/__es", domain)
-
-	headers := map[string][]string{"Cookie": {"sessionToken=" + url.QueryEscape(sessionToken)}}
+func (l *SLogs) Output(queryString string, filter *Filter, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, startTimestamp, endTimestamp time.Time, env *models.Environment) (int, time.Time, error) {
+	appLogsIdentifier, appLogsValue := appLogsFields(domain)
 
 	logrus.Println("        @timestamp       -        message")
 	for {
-		queryBytes := generateQuery(queryString, appLogsIdentifier, appLogsValue, startTimestamp, from)
+		queryBytes := generateQuery(queryString, appLogsIdentifier, appLogsValue, filter, startTimestamp, from)
 
-		resp, statusCode, err := l.Settings.HTTPManager.Get(queryBytes, fmt.Sprintf("%s/_search", urlString), headers)
-		if err != nil {
-			return from, startTimestamp, err
-		}
-		var logs models.Logs
-		err = l.Settings.HTTPManager.ConvertResp(resp, statusCode, &logs)
+		logs, err := l.search(queryBytes, sessionToken, domain)
 		if err != nil {
 			return from, startTimestamp, err
 		}
 
 		end := time.Time{}
 		for _, lh := range *logs.Hits.Hits {
-			logrus.Printf("%s - %s", lh.Fields["@timestamp"][0], lh.Fields["message"][0])
-			end, _ = time.Parse(time.RFC3339Nano, lh.Fields["@timestamp"][0])
+			if filter.Match(field(lh, serviceField), field(lh, jobField), field(lh, levelField), field(lh, "message")) {
+				filter.markers.printBeforeLine(field(lh, "@timestamp"))
+				printLine(field(lh, "@timestamp"), filter.target(field(lh, jobField)), field(lh, "message"))
+			}
+			end, _ = time.Parse(time.RFC3339Nano, field(lh, "@timestamp"))
 		}
 		amount := len(*logs.Hits.Hits)
 
@@ -113,24 +147,72 @@ func (l *SLogs) Output(queryString, sessionToken, domain string, follow bool, ho
 		}
 		time.Sleep(config.JobPollTime * time.Second)
 	}
+	filter.markers.printBefore(endTimestamp)
 	return from, startTimestamp, nil
 }
 
-func (l *SLogs) Stream(queryString, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, timestamp time.Time, env *models.Environment) error {
+// Stream polls for new logs until the command is stopped. If a poll fails
+// with a network or server error, it is retried with an increasing backoff
+// starting from the last log retrieved. Any other error is returned.
+func (l *SLogs) Stream(queryString string, filter *Filter, sessionToken, domain string, follow bool, hours, minutes, seconds, from int, timestamp time.Time, env *models.Environment) error {
+	backoff := config.LogReconnectTime * time.Second
 	for {
-		f, t, err := l.Output(queryString, sessionToken, domain, follow, hours, minutes, seconds, from, timestamp, time.Now(), env)
+		f, t, err := l.Output(queryString, filter, sessionToken, domain, follow, hours, minutes, seconds, from, timestamp, time.Now(), env)
 		if err != nil {
-			return err
+			if !reconnectable(err) {
+				return err
+			}
+			logrus.Warnf("Unable to retrieve logs, retrying in %s: %s", backoff, err)
+			time.Sleep(backoff)
+			backoff = stream.NextBackoff(backoff)
+			continue
 		}
+		backoff = config.LogReconnectTime * time.Second
 		from = f
 		timestamp = t
 		time.Sleep(config.LogPollTime * time.Second)
 	}
 }
 
-func generateQuery(queryString, appLogsIdentifier, appLogsValue string, timestamp time.Time, from int) []byte {
+// search runs the given elastic search query against the logging dashboard
+func (l *SLogs) search(queryBytes []byte, sessionToken, domain string) (*models.Logs, error) {
+	headers := map[string][]string{"Cookie": {"sessionToken=" + url.QueryEscape(sessionToken)}}
+	resp, statusCode, err := l.Settings.HTTPManager.Get(queryBytes, fmt.Sprintf("https://%s/__es/_search", domain), headers)
+	if err != nil {
+		return nil, err
+	}
+	var logs models.Logs
+	err = l.Settings.HTTPManager.ConvertResp(resp, statusCode, &logs)
+	if err != nil {
+		return nil, err
+	}
+	return &logs, nil
+}
+
+// appLogsFields returns the field and value that identify application logs
+// in the logging dashboard for the given domain.
+func appLogsFields(domain string) (string, string) {
+	if strings.HasPrefix(domain, "pod01") || strings.HasPrefix(domain, "csb01") {
+		return "syslog_program", "supervisord"
+	}
+	return "source", "app"
+}
+
+// reconnectable returns whether polling for logs may succeed again after the
+// given error. Client errors, such as an expired session or a deleted
+// service, are returned by every following poll as well.
+func reconnectable(err error) bool {
+	apiErr, ok := err.(*httpclient.APIError)
+	return !ok || apiErr.StatusCode >= 500
+}
+
+func generateQuery(queryString, appLogsIdentifier, appLogsValue string, filter *Filter, timestamp time.Time, from int) []byte {
+	clauses := ""
+	for _, c := range filter.clauses() {
+		clauses += ",\n\t\t\t\t" + c
+	}
 	query := `{
-	"fields": ["@timestamp", "message", "` + appLogsIdentifier + `"],
+	"fields": ["@timestamp", "message", "` + appLogsIdentifier + `", "` + serviceField + `", "` + jobField + `", "` + levelField + `"],
 	"query": {
 		"wildcard": {
 			"message": "` + queryString + `"
@@ -140,7 +222,7 @@ func generateQuery(queryString, appLogsIdentifier, appLogsValue string, timestam
 		"bool": {
 			"must": [
 				{"term": {"` + appLogsIdentifier + `": "` + appLogsValue + `"}},
-				{"range": {"@timestamp": {"gt": "` + fmt.Sprintf("%04d-%02d-%02dT%02d:%02d:%02dZ", timestamp.Year(), timestamp.Month(), timestamp.Day(), timestamp.Hour(), timestamp.Minute(), timestamp.Second()) + `"}}}
+				{"range": {"@timestamp": {"gt": "` + fmt.Sprintf("%04d-%02d-%02dT%02d:%02d:%02dZ", timestamp.Year(), timestamp.Month(), timestamp.Day(), timestamp.Hour(), timestamp.Minute(), timestamp.Second()) + `"}}}` + clauses + `
 			]
 		}
 	},
@@ -0,0 +1,61 @@
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/test"
+)
+
+var reconnectableTests = []struct {
+	err      error
+	expected bool
+}{
+	{errors.New("connection reset by peer"), true},
+	{&httpclient.APIError{StatusCode: 500}, true},
+	{&httpclient.APIError{StatusCode: 502}, true},
+	{&httpclient.APIError{StatusCode: 401}, false},
+	{&httpclient.APIError{StatusCode: 403}, false},
+	{&httpclient.APIError{StatusCode: 404}, false},
+}
+
+func TestReconnectable(t *testing.T) {
+	for _, data := range reconnectableTests {
+		t.Logf("Data: %+v", data)
+		if actual := reconnectable(data.err); actual != data.expected {
+			t.Errorf("Expected %t but got %t", data.expected, actual)
+		}
+	}
+}
+
+func TestStreamStopsOnClientError(t *testing.T) {
+	httpclient.SetRetries(1)
+	defer httpclient.SetRetries(httpclient.DefaultRetries)
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		test.AssertEquals(t, "/__es/_search", r.URL.Path)
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code":1,"title":"Unauthorized","description":"Session expired"}`)
+	}))
+	defer server.Close()
+	settings := test.GetSettings("")
+	settings.HTTPManager = httpclient.NewTLSHTTPManager(true)
+	l := New(settings)
+
+	err := l.Stream("*", &Filter{}, "session", strings.TrimPrefix(server.URL, "https://"), true, 0, 0, 0, 0, time.Now(), nil)
+	if err == nil {
+		t.Fatal("Expected the stream to stop on the client error")
+	}
+	test.AssertEquals(t, "2", fmt.Sprintf("%d", requests))
+}
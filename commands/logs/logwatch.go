@@ -1,7 +1,6 @@
 package logs
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,78 +11,184 @@ import (
 	"time"
 
 	"github.com/Sirupsen/logrus"
-	"github.com/gorilla/websocket"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/stream"
+	"github.com/daticahealth/cli/models"
 )
 
 const (
-	writeTimeout = 5 * time.Second
+	// maxTrackedLines is the number of printed lines remembered before lines
+	// older than trackedLineAge are forgotten
+	maxTrackedLines = 1000
+	trackedLineAge  = time.Minute
 )
 
 type LogMessage struct {
 	Message   string `json:"message"`
 	Timestamp string `json:"@timestamp"`
 	Source    string `json:"source"`
+	Service   string `json:"service_label"`
+	JobID     string `json:"job_id"`
+	Level     string `json:"level"`
 }
 
-func (l *SLogs) Watch(queryString, domain, sessionToken string) error {
-	if queryString == "*" {
-		queryString = ""
+// streamPosition keeps track of the lines printed while following logs so
+// that after reconnecting, the logs missed while disconnected can be printed
+// without repeating lines that were already printed.
+type streamPosition struct {
+	start   time.Time
+	last    time.Time
+	printed map[string]time.Time
+}
+
+func newStreamPosition(start time.Time) *streamPosition {
+	return &streamPosition{
+		start:   start,
+		printed: map[string]time.Time{},
+	}
+}
+
+// since returns the time to resume the logs from
+func (p *streamPosition) since() time.Time {
+	if p.last.IsZero() {
+		return p.start
 	}
-	query, err := regexp.Compile(queryString)
+	return p.last
+}
+
+// record returns whether or not the given line has not yet been printed and
+// remembers it so it is not printed again.
+func (p *streamPosition) record(timestamp, message string) bool {
+	key := timestamp + " " + message
+	if _, ok := p.printed[key]; ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
 	if err != nil {
-		return err
+		return true
 	}
-	logrus.Println("Streaming logs...")
-	dialer := &websocket.Dialer{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		},
+	if t.After(p.last) {
+		p.last = t
 	}
-	headers := http.Header{"Cookie": {"sessionToken=" + url.QueryEscape(sessionToken)}}
-	urlString := fmt.Sprintf("wss://%s/stream/", domain)
-	c, _, err := dialer.Dial(urlString, headers)
+	p.printed[key] = t
+	if len(p.printed) > maxTrackedLines {
+		for k, printedAt := range p.printed {
+			if printedAt.Before(p.last.Add(-trackedLineAge)) {
+				delete(p.printed, k)
+			}
+		}
+	}
+	return true
+}
+
+// Watch streams logs from the logging dashboard until interrupted. If the
+// connection is dropped, Watch reconnects with an increasing backoff and
+// prints any logs that were missed while disconnected.
+func (l *SLogs) Watch(queryString string, filter *Filter, domain, sessionToken string) error {
+	pattern := queryString
+	if pattern == "*" {
+		pattern = ""
+	}
+	query, err := regexp.Compile(pattern)
 	if err != nil {
 		return err
 	}
-	defer c.Close()
+	logrus.Println("Streaming logs...")
+	headers := func() http.Header {
+		return http.Header{"Cookie": {"sessionToken=" + url.QueryEscape(sessionToken)}}
+	}
+	urlString := fmt.Sprintf("wss://%s/stream/", domain)
 
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
 
-	done := make(chan struct{}, 2)
+	pos := newStreamPosition(time.Now().UTC())
+	done := make(chan error, 1)
 	go func() {
-		<-interrupt
-		done <- struct{}{}
+		done <- stream.New(l.Settings).Follow(urlString, headers, stream.Callbacks{
+			Lost: func(wait time.Duration) {
+				logrus.Warnf("Lost connection to the log stream, reconnecting in %s", wait)
+			},
+			Reconnected: func() {
+				logrus.Println("Reconnected")
+				if err := l.backfill(queryString, filter, domain, sessionToken, pos); err != nil {
+					logrus.Warnf("Some logs may be missing, unable to retrieve the logs sent while disconnected: %s", err)
+				}
+			},
+		}, func(msg []byte) error {
+			printMessage(msg, query, filter, pos)
+			return nil
+		})
 	}()
-	go readWS(c, query, done)
-	<-done
-	logrus.Println("Disconnected")
-	return nil
+	select {
+	case err := <-done:
+		return err
+	case <-interrupt:
+		logrus.Println("Disconnected")
+		return nil
+	}
 }
 
-// Reads incoming data from the websocket and forwards it to stdout.
-func readWS(ws *websocket.Conn, query *regexp.Regexp, done chan struct{}) {
-	defer func() {
-		done <- struct{}{}
-	}()
-	ws.SetPingHandler(func(string) error {
-		ws.SetWriteDeadline(time.Now().Add(writeTimeout))
-		return ws.WriteMessage(websocket.PongMessage, []byte{})
+// backfill prints the logs sent since the last line printed from the stream
+func (l *SLogs) backfill(queryString string, filter *Filter, domain, sessionToken string, pos *streamPosition) error {
+	return l.searchSince(queryString, filter, domain, sessionToken, pos, func(lh models.LogHits) {
+		filter.markers.printBeforeLine(field(lh, "@timestamp"))
+		printLine(field(lh, "@timestamp"), filter.target(field(lh, jobField)), field(lh, "message"))
 	})
+}
+
+// searchSince calls handle with every matching log line sent since the last
+// line recorded by the stream position that has not been recorded yet
+func (l *SLogs) searchSince(queryString string, filter *Filter, domain, sessionToken string, pos *streamPosition, handle func(lh models.LogHits)) error {
+	appLogsIdentifier, appLogsValue := appLogsFields(domain)
+	// queries only have second precision, lines that were already handled
+	// are skipped by the stream position
+	since := pos.since().Truncate(time.Second).Add(-time.Second)
+	from := 0
 	for {
-		_, msg, err := ws.ReadMessage()
+		logs, err := l.search(generateQuery(queryString, appLogsIdentifier, appLogsValue, filter, since, from), sessionToken, domain)
 		if err != nil {
-			return
+			return err
 		}
-		var log LogMessage
-		err = json.Unmarshal(msg, &log)
-		if err == nil {
-			if query == nil || query.MatchString(log.Message) {
-				logrus.Printf("%s - %s", log.Timestamp, log.Message)
+		for _, lh := range *logs.Hits.Hits {
+			if !filter.Match(field(lh, serviceField), field(lh, jobField), field(lh, levelField), field(lh, "message")) {
+				continue
 			}
-		} else {
-			logrus.StandardLogger().Out.Write(msg)
+			if pos.record(field(lh, "@timestamp"), field(lh, "message")) {
+				handle(lh)
+			}
+		}
+		from += len(*logs.Hits.Hits)
+		if len(*logs.Hits.Hits) < size {
+			return nil
 		}
 	}
 }
+
+// printMessage prints a message received from the log stream if it matches
+// the query and filter and has not been printed yet
+func printMessage(msg []byte, query *regexp.Regexp, filter *Filter, pos *streamPosition) {
+	var log LogMessage
+	if err := json.Unmarshal(msg, &log); err != nil {
+		logrus.StandardLogger().Out.Write(msg)
+		return
+	}
+	if (query == nil || query.MatchString(log.Message)) && filter.Match(log.Service, log.JobID, log.Level, log.Message) && pos.record(log.Timestamp, log.Message) {
+		filter.markers.printBeforeLine(log.Timestamp)
+		printLine(log.Timestamp, filter.target(log.JobID), log.Message)
+	}
+}
+
+// printLine prints a single log line with its timestamp converted to the
+// configured timezone. Lines sent by workers are prefixed with their target.
+func printLine(timestamp, target, message string) {
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		timestamp = config.FormatPreciseTimestamp(t)
+	}
+	if target != "" {
+		logrus.Printf("%s - [%s] %s", timestamp, target, message)
+		return
+	}
+	logrus.Printf("%s - %s", timestamp, message)
+}
@@ -0,0 +1,38 @@
+package logs
+
+import (
+	"testing"
+	"time"
+)
+
+var streamPositionTests = []struct {
+	timestamp string
+	message   string
+	printed   bool
+}{
+	{"2017-03-01T12:00:01.000Z", "first", true},
+	{"2017-03-01T12:00:01.000Z", "second", true},
+	{"2017-03-01T12:00:01.000Z", "first", false},
+	{"2017-03-01T12:00:00.500Z", "late", true},
+	{"2017-03-01T12:00:02.000Z", "third", true},
+	{"2017-03-01T12:00:02.000Z", "third", false},
+	{"invalid", "unparsable", true},
+}
+
+func TestStreamPosition(t *testing.T) {
+	start := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	pos := newStreamPosition(start)
+	if !pos.since().Equal(start) {
+		t.Fatalf("Expected to resume from %s but got %s", start, pos.since())
+	}
+	for _, data := range streamPositionTests {
+		t.Logf("Data: %+v", data)
+		if printed := pos.record(data.timestamp, data.message); printed != data.printed {
+			t.Errorf("Expected printed to be %t but got %t", data.printed, printed)
+		}
+	}
+	expected := time.Date(2017, 3, 1, 12, 0, 2, 0, time.UTC)
+	if !pos.since().Equal(expected) {
+		t.Errorf("Expected to resume from %s but got %s", expected, pos.since())
+	}
+}
@@ -0,0 +1,161 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/models"
+)
+
+const (
+	// markerPageSize is the number of audit events retrieved at once
+	markerPageSize = 100
+	// markerRefreshTime is the minimum time between two retrievals of new
+	// audit events while following logs
+	markerRefreshTime = 30 * time.Second
+)
+
+// markerActions are the actions of the audit trail that change what is
+// running in an environment. They are printed as markers between log lines
+// so that changes in the logs can be matched with the deploy that caused
+// them.
+var markerActions = []string{"deploy", "redeploy", "rollback", "restart", "scale", "worker"}
+
+// markerQueue holds the audit events not printed yet, oldest first
+type markerQueue struct {
+	envID     string
+	ia        audit.IAudit
+	pending   []models.AuditEvent
+	seen      map[string]bool
+	fetched   time.Time
+	refreshed time.Time
+}
+
+// newMarkerQueue retrieves the audit events of the given environment since
+// the given time
+func newMarkerQueue(envID string, since time.Time, ia audit.IAudit) *markerQueue {
+	q := &markerQueue{
+		envID:   envID,
+		ia:      ia,
+		seen:    map[string]bool{},
+		fetched: since,
+	}
+	q.fetch(time.Now())
+	return q
+}
+
+// fetch retrieves the audit events from the end of the last retrieval until
+// the given time. The audit trail is optional, so errors are only logged.
+func (q *markerQueue) fetch(until time.Time) {
+	q.refreshed = time.Now()
+	if !q.fetched.Before(until) {
+		return
+	}
+	for page := 1; ; page++ {
+		events, err := q.ia.List(q.fetched, until, page, markerPageSize)
+		if err != nil {
+			logrus.Debugf("Failed to retrieve deploy markers from the audit trail: %s", err)
+			return
+		}
+		for _, e := range *events {
+			if !q.seen[e.ID] && isMarker(e, q.envID) {
+				q.seen[e.ID] = true
+				q.pending = append(q.pending, e)
+			}
+		}
+		if len(*events) < markerPageSize {
+			break
+		}
+	}
+	// the audit trail is newest first
+	sort.Stable(SortedMarkers(q.pending))
+	q.fetched = until
+}
+
+// upTo removes and returns the markers at or before the given time. Markers
+// after the retrieved period are retrieved first, at most once every
+// markerRefreshTime.
+func (q *markerQueue) upTo(t time.Time) []models.AuditEvent {
+	if q == nil {
+		return nil
+	}
+	if t.After(q.fetched) && time.Since(q.refreshed) > markerRefreshTime {
+		q.fetch(time.Now())
+	}
+	i := 0
+	for i < len(q.pending) && !markerTime(q.pending[i]).After(t) {
+		i++
+	}
+	markers := q.pending[:i]
+	q.pending = q.pending[i:]
+	return markers
+}
+
+// printBefore prints the markers at or before the given time
+func (q *markerQueue) printBefore(t time.Time) {
+	for _, m := range q.upTo(t) {
+		printMarker(m)
+	}
+}
+
+// printBeforeLine prints the markers at or before the time of a log line about
+// to be printed
+func (q *markerQueue) printBeforeLine(timestamp string) {
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		q.printBefore(t)
+	}
+}
+
+func printMarker(m models.AuditEvent) {
+	message := fmt.Sprintf("*** %s", m.Action)
+	if m.Target != "" {
+		message += " " + m.Target
+	}
+	if m.ActorEmail != "" {
+		message += " by " + m.ActorEmail
+	}
+	printLine(m.Timestamp, "", message+" ***")
+}
+
+func isMarker(e models.AuditEvent, envID string) bool {
+	if e.EnvironmentID != "" && e.EnvironmentID != envID {
+		return false
+	}
+	action := strings.ToLower(e.Action)
+	for _, a := range markerActions {
+		if strings.HasPrefix(action, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// markerTime returns the time of an audit event, or the zero time if it can't
+// be parsed so that it is printed first
+func markerTime(e models.AuditEvent) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, e.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// SortedMarkers is a wrapper for an array of audit events in order to sort
+// them oldest first
+type SortedMarkers []models.AuditEvent
+
+func (ms SortedMarkers) Len() int {
+	return len(ms)
+}
+
+func (ms SortedMarkers) Swap(i, j int) {
+	ms[i], ms[j] = ms[j], ms[i]
+}
+
+func (ms SortedMarkers) Less(i, j int) bool {
+	return markerTime(ms[i]).Before(markerTime(ms[j]))
+}
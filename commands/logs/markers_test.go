@@ -0,0 +1,52 @@
+package logs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/test"
+)
+
+var markerTests = []struct {
+	upTo    string
+	markers []string
+}{
+	{"2017-03-01T11:00:00Z", []string{}},
+	{"2017-03-01T12:30:00Z", []string{"1"}},
+	{"2017-03-01T12:30:00Z", []string{}},
+	{"2017-03-01T14:00:00Z", []string{"4", "5"}},
+	{"2017-03-02T00:00:00Z", []string{}},
+}
+
+func TestMarkers(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs/"+test.OrgID+"/audit",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[
+				{"id":"5","timestamp":"2017-03-01T14:00:00Z","actorEmail":"user@example.com","action":"worker scale","target":"code-1","environmentId":"%s"},
+				{"id":"4","timestamp":"2017-03-01T13:00:00Z","actorEmail":"user@example.com","action":"rollback","target":"code-1"},
+				{"id":"3","timestamp":"2017-03-01T12:45:00Z","actorEmail":"user@example.com","action":"deploy","target":"code-2","environmentId":"%s"},
+				{"id":"2","timestamp":"2017-03-01T12:15:00Z","actorEmail":"user@example.com","action":"vars set","target":"code-1","environmentId":"%s"},
+				{"id":"1","timestamp":"2017-03-01T12:00:00Z","actorEmail":"user@example.com","action":"deploy","target":"code-1","environmentId":"%s"}
+			]`, test.EnvID, test.EnvIDAlt, test.EnvID, test.EnvID))
+		},
+	)
+
+	q := newMarkerQueue(test.EnvID, time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC), audit.New(settings))
+	for _, data := range markerTests {
+		t.Logf("Data: %+v", data)
+		upTo, _ := time.Parse(time.RFC3339, data.upTo)
+		ids := []string{}
+		for _, m := range q.upTo(upTo) {
+			ids = append(ids, m.ID)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%v", data.markers), fmt.Sprintf("%v", ids))
+	}
+}
@@ -0,0 +1,75 @@
+package logs
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+)
+
+// targetResolver maps the IDs of worker jobs to their targets. Log lines only
+// hold the ID of the job that sent them, so the targets are looked up from
+// the worker jobs of the services whose logs are shown.
+type targetResolver struct {
+	services  []models.Service
+	ij        jobs.IJobs
+	targets   map[string]string
+	refreshed time.Time
+}
+
+func newTargetResolver(services []models.Service, ij jobs.IJobs) *targetResolver {
+	r := &targetResolver{
+		services: services,
+		ij:       ij,
+		targets:  map[string]string{},
+	}
+	r.refresh()
+	return r
+}
+
+// refresh retrieves the worker jobs of every service. Services whose jobs
+// cannot be retrieved are skipped so that logs are still printed.
+func (r *targetResolver) refresh() {
+	r.refreshed = time.Now()
+	for _, svc := range r.services {
+		workerJobs, err := r.ij.RetrieveAllByType(svc.ID, "worker")
+		if err != nil {
+			logrus.Debugf("Failed to retrieve the worker jobs of %s: %s", svc.Label, err)
+			continue
+		}
+		for _, j := range *workerJobs {
+			r.targets[j.ID] = j.Target
+		}
+	}
+}
+
+// target returns the worker target of the job with the given ID or an empty
+// string if the job is not a worker. Jobs started after the last refresh
+// cause another refresh, at most once per log poll.
+func (r *targetResolver) target(jobID string) string {
+	if r == nil || jobID == "" {
+		return ""
+	}
+	target, ok := r.targets[jobID]
+	if !ok && time.Since(r.refreshed) > config.LogPollTime*time.Second {
+		r.refresh()
+		target, ok = r.targets[jobID]
+	}
+	if !ok {
+		// remember jobs that are not workers so they are not looked up again
+		r.targets[jobID] = ""
+	}
+	return target
+}
+
+// has returns whether any known worker job runs the given target
+func (r *targetResolver) has(target string) bool {
+	for _, t := range r.targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
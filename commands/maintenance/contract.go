@@ -5,6 +5,7 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -17,11 +18,12 @@ var Cmd = models.Command{
 	ShortHelp: "Manage maintenance mode for code services",
 	LongHelp: "Maintenance mode can be enabled or disabled for code services " +
 		"on demand. This redirects all traffic to a default maintenance page.",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(DisableSubCmd.Name, DisableSubCmd.ShortHelp, DisableSubCmd.LongHelp, DisableSubCmd.CmdFunc(settings))
-			cmd.CommandLong(EnableSubCmd.Name, EnableSubCmd.ShortHelp, EnableSubCmd.LongHelp, EnableSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, ShowSubCmd.LongHelp, ShowSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DisableSubCmd.Name, DisableSubCmd.ShortHelp, help.Render(DisableSubCmd.LongHelp), DisableSubCmd.CmdFunc(settings))
+			cmd.CommandLong(EnableSubCmd.Name, EnableSubCmd.ShortHelp, help.Render(EnableSubCmd.LongHelp), EnableSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, help.Render(ShowSubCmd.LongHelp), ShowSubCmd.CmdFunc(settings))
 		}
 	},
 }
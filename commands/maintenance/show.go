@@ -5,6 +5,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/models"
 	"github.com/olekukonko/tablewriter"
 )
@@ -34,7 +35,7 @@ func CmdShow(svcName, envID, podID string, im IMaintenance, is services.IService
 			status := "disabled"
 			for _, mm := range *svcMaintenance {
 				if mm.UpstreamID == svc.ID {
-					createdAt = mm.CreatedAt
+					createdAt = config.FormatTimestampString(mm.CreatedAt)
 					status = "enabled"
 				}
 			}
@@ -5,6 +5,7 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -17,6 +18,7 @@ const (
 	Memory
 	NetworkIn
 	NetworkOut
+	Disk
 )
 
 // Cmd is the contract between the user and the CLI. This specifies the command
@@ -26,13 +28,43 @@ var Cmd = models.Command{
 	ShortHelp: "Print service and environment metrics in your local time zone",
 	LongHelp: "The `metrics` command gives access to environment metrics or individual service metrics through a variety of formats. " +
 		"This is useful for checking on the status and performance of your application or environment as a whole. " +
-		"The metrics command cannot be run directly but has sub commands.",
+		"When metrics are streamed with `--stream`, pods that support it push new metrics over a single long-lived connection as soon as they are available. " +
+		"Other pods are polled once a minute. " +
+		"Given a service, `metrics` prints a dashboard of the latest CPU, memory, disk, and network usage of each job of the service, busiest first, with the trend of its CPU usage over the last 15 minutes. " +
+		"With `--stream`, the dashboard is redrawn as new metrics arrive until you hit ctrl-c, similar to `top`, so an incident can be watched from the terminal. " +
+		"Pods that do not support streaming are polled every 15 seconds instead. " +
+		"The sub commands print a single kind of metric for a service or the entire environment in a variety of formats. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" metrics app01\n" +
+		"datica -E \"<your_env_alias>\" metrics --stream app01\n```",
+	Category: models.CategoryObservability,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(CPUSubCmd.Name, CPUSubCmd.ShortHelp, CPUSubCmd.LongHelp, CPUSubCmd.CmdFunc(settings))
-			cmd.CommandLong(MemorySubCmd.Name, MemorySubCmd.ShortHelp, MemorySubCmd.LongHelp, MemorySubCmd.CmdFunc(settings))
-			cmd.CommandLong(NetworkInSubCmd.Name, NetworkInSubCmd.ShortHelp, NetworkInSubCmd.LongHelp, NetworkInSubCmd.CmdFunc(settings))
-			cmd.CommandLong(NetworkOutSubCmd.Name, NetworkOutSubCmd.ShortHelp, NetworkOutSubCmd.LongHelp, NetworkOutSubCmd.CmdFunc(settings))
+			cmd.CommandLong(CPUSubCmd.Name, CPUSubCmd.ShortHelp, help.Render(CPUSubCmd.LongHelp), CPUSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DiskSubCmd.Name, DiskSubCmd.ShortHelp, help.Render(DiskSubCmd.LongHelp), DiskSubCmd.CmdFunc(settings))
+			cmd.CommandLong(MemorySubCmd.Name, MemorySubCmd.ShortHelp, help.Render(MemorySubCmd.LongHelp), MemorySubCmd.CmdFunc(settings))
+			cmd.CommandLong(NetworkInSubCmd.Name, NetworkInSubCmd.ShortHelp, help.Render(NetworkInSubCmd.LongHelp), NetworkInSubCmd.CmdFunc(settings))
+			cmd.CommandLong(NetworkOutSubCmd.Name, NetworkOutSubCmd.ShortHelp, help.Render(NetworkOutSubCmd.LongHelp), NetworkOutSubCmd.CmdFunc(settings))
+			cmd.CommandLong(UsageSubCmd.Name, UsageSubCmd.ShortHelp, help.Render(UsageSubCmd.LongHelp), UsageSubCmd.CmdFunc(settings))
+			stream := config.StreamOpt(cmd.BoolOpt("stream", false, "Redraw the dashboard as new metrics arrive until this process is interrupted"))
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the service to print a dashboard for")
+			cmd.Action = func() {
+				if *serviceName == "" {
+					// without a service, only the sub commands can be run
+					cmd.PrintHelp()
+					return
+				}
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdDashboard(*serviceName, *stream, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[--stream] [SERVICE_NAME]"
 		}
 	},
 }
@@ -46,20 +78,72 @@ var CPUSubCmd = models.Command{
 		"You can only stream metrics using plain text or spark lines formats. " +
 		"To print out metrics for every service in your environment, omit the `SERVICE_NAME` argument. " +
 		"Otherwise you may choose a service, such as an app service, to retrieve metrics for. " +
+		"Metrics are retrieved for the last minute, or the number of minutes given with `-m`. " +
+		"To retrieve a specific period instead, give `--since` and optionally `--until`, which accept a date such as `2017-06-01`, a date and time such as `2017-06-01 13:30`, or an RFC3339 timestamp. " +
 		"Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" metrics cpu\n" +
 		"datica -E \"<your_env_alias>\" metrics cpu app01 --stream\n" +
 		"datica -E \"<your_env_alias>\" metrics cpu --json\n" +
-		"datica -E \"<your_env_alias>\" metrics cpu db01 --csv -m 60\n```",
+		"datica -E \"<your_env_alias>\" metrics cpu db01 --csv -m 60\n" +
+		"datica -E \"<your_env_alias>\" metrics cpu app01 --csv --since 2017-06-01 --until 2017-06-08\n```",
+	JSONOutput: []cpu{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to print metrics for")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the data as json"))
+			csv := subCmd.BoolOpt("csv", false, "Output the data as csv")
+			text := subCmd.BoolOpt("text", true, "Output the data in plain text")
+			spark := subCmd.BoolOpt("spark", false, "Output the data using spark lines")
+			stream := config.StreamOpt(subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted. On pods that support streaming, new data is printed as soon as it is available instead"))
+			mins := subCmd.IntOpt("m mins", 1, "How many minutes worth of metrics to retrieve.")
+			since := subCmd.StringOpt("since", "", "Retrieve metrics at or after this time instead of the last few minutes")
+			until := subCmd.StringOpt("until", "", "Retrieve metrics before this time. Defaults to now")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdMetrics(*serviceName, CPU, *json, *csv, *text, *spark, *stream, *mins, *since, *until, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m] [--since] [--until]"
+		}
+	},
+}
+
+var DiskSubCmd = models.Command{
+	Name:      "disk",
+	ShortHelp: "Print service and environment disk metrics in your local time zone",
+	LongHelp: "`metrics disk` prints out disk metrics for your environment or individual services. " +
+		"You can print out metrics in csv, json, plain text, or spark lines format. " +
+		"If you want plain text format, simply omit the `--json`, `--csv`, and `--spark` flags. " +
+		"You can only stream metrics using plain text or spark lines formats. " +
+		"To print out metrics for every service in your environment, omit the `SERVICE_NAME` argument. " +
+		"Otherwise you may choose a service, such as an app service, to retrieve metrics for. " +
+		"Metrics are retrieved for the last minute, or the number of minutes given with `-m`. " +
+		"To retrieve a specific period instead, give `--since` and optionally `--until`, which accept a date such as `2017-06-01`, a date and time such as `2017-06-01 13:30`, or an RFC3339 timestamp. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" metrics disk\n" +
+		"datica -E \"<your_env_alias>\" metrics disk app01 --stream\n" +
+		"datica -E \"<your_env_alias>\" metrics disk --json\n" +
+		"datica -E \"<your_env_alias>\" metrics disk db01 --csv -m 60\n" +
+		"datica -E \"<your_env_alias>\" metrics disk app01 --csv --since 2017-06-01 --until 2017-06-08\n```",
+	JSONOutput: []disk{},
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to print metrics for")
-			json := subCmd.BoolOpt("json", false, "Output the data as json")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the data as json"))
 			csv := subCmd.BoolOpt("csv", false, "Output the data as csv")
 			text := subCmd.BoolOpt("text", true, "Output the data in plain text")
 			spark := subCmd.BoolOpt("spark", false, "Output the data using spark lines")
-			stream := subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted.")
+			stream := config.StreamOpt(subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted. On pods that support streaming, new data is printed as soon as it is available instead"))
 			mins := subCmd.IntOpt("m mins", 1, "How many minutes worth of metrics to retrieve.")
+			since := subCmd.StringOpt("since", "", "Retrieve metrics at or after this time instead of the last few minutes")
+			until := subCmd.StringOpt("until", "", "Retrieve metrics before this time. Defaults to now")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -67,12 +151,12 @@ var CPUSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdMetrics(*serviceName, CPU, *json, *csv, *text, *spark, *stream, *mins, New(settings), services.New(settings))
+				err := CmdMetrics(*serviceName, Disk, *json, *csv, *text, *spark, *stream, *mins, *since, *until, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m]"
+			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m] [--since] [--until]"
 		}
 	},
 }
@@ -86,20 +170,26 @@ var MemorySubCmd = models.Command{
 		"You can only stream metrics using plain text or spark lines formats. " +
 		"To print out metrics for every service in your environment, omit the `SERVICE_NAME` argument. " +
 		"Otherwise you may choose a service, such as an app service, to retrieve metrics for. " +
+		"Metrics are retrieved for the last minute, or the number of minutes given with `-m`. " +
+		"To retrieve a specific period instead, give `--since` and optionally `--until`, which accept a date such as `2017-06-01`, a date and time such as `2017-06-01 13:30`, or an RFC3339 timestamp. " +
 		"Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" metrics memory\n" +
 		"datica -E \"<your_env_alias>\" metrics memory app01 --stream\n" +
 		"datica -E \"<your_env_alias>\" metrics memory --json\n" +
-		"datica -E \"<your_env_alias>\" metrics memory db01 --csv -m 60\n```",
+		"datica -E \"<your_env_alias>\" metrics memory db01 --csv -m 60\n" +
+		"datica -E \"<your_env_alias>\" metrics memory app01 --csv --since 2017-06-01 --until 2017-06-08\n```",
+	JSONOutput: []mem{},
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to print metrics for")
-			json := subCmd.BoolOpt("json", false, "Output the data as json")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the data as json"))
 			csv := subCmd.BoolOpt("csv", false, "Output the data as csv")
 			text := subCmd.BoolOpt("text", true, "Output the data in plain text")
 			spark := subCmd.BoolOpt("spark", false, "Output the data using spark lines")
-			stream := subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted.")
+			stream := config.StreamOpt(subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted. On pods that support streaming, new data is printed as soon as it is available instead"))
 			mins := subCmd.IntOpt("m mins", 1, "How many minutes worth of metrics to retrieve.")
+			since := subCmd.StringOpt("since", "", "Retrieve metrics at or after this time instead of the last few minutes")
+			until := subCmd.StringOpt("until", "", "Retrieve metrics before this time. Defaults to now")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -107,12 +197,12 @@ var MemorySubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdMetrics(*serviceName, Memory, *json, *csv, *text, *spark, *stream, *mins, New(settings), services.New(settings))
+				err := CmdMetrics(*serviceName, Memory, *json, *csv, *text, *spark, *stream, *mins, *since, *until, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m]"
+			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m] [--since] [--until]"
 		}
 	},
 }
@@ -129,16 +219,20 @@ var NetworkInSubCmd = models.Command{
 		"```\ndatica -E \"<your_env_alias>\" metrics network-in\n" +
 		"datica -E \"<your_env_alias>\" metrics network-in app01 --stream\n" +
 		"datica -E \"<your_env_alias>\" metrics network-in --json\n" +
-		"datica -E \"<your_env_alias>\" metrics network-in db01 --csv -m 60\n```",
+		"datica -E \"<your_env_alias>\" metrics network-in db01 --csv -m 60\n" +
+		"datica -E \"<your_env_alias>\" metrics network-in app01 --csv --since 2017-06-01 --until 2017-06-08\n```",
+	JSONOutput: []netin{},
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to print metrics for")
-			json := subCmd.BoolOpt("json", false, "Output the data as json")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the data as json"))
 			csv := subCmd.BoolOpt("csv", false, "Output the data as csv")
 			text := subCmd.BoolOpt("text", true, "Output the data in plain text")
 			spark := subCmd.BoolOpt("spark", false, "Output the data using spark lines")
-			stream := subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted.")
+			stream := config.StreamOpt(subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted. On pods that support streaming, new data is printed as soon as it is available instead"))
 			mins := subCmd.IntOpt("m mins", 1, "How many minutes worth of metrics to retrieve.")
+			since := subCmd.StringOpt("since", "", "Retrieve metrics at or after this time instead of the last few minutes")
+			until := subCmd.StringOpt("until", "", "Retrieve metrics before this time. Defaults to now")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -146,12 +240,12 @@ var NetworkInSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdMetrics(*serviceName, NetworkIn, *json, *csv, *text, *spark, *stream, *mins, New(settings), services.New(settings))
+				err := CmdMetrics(*serviceName, NetworkIn, *json, *csv, *text, *spark, *stream, *mins, *since, *until, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m]"
+			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m] [--since] [--until]"
 		}
 	},
 }
@@ -165,20 +259,61 @@ var NetworkOutSubCmd = models.Command{
 		"You can only stream metrics using plain text or spark lines formats. " +
 		"To print out metrics for every service in your environment, omit the `SERVICE_NAME` argument. " +
 		"Otherwise you may choose a service, such as an app service, to retrieve metrics for. " +
+		"Metrics are retrieved for the last minute, or the number of minutes given with `-m`. " +
+		"To retrieve a specific period instead, give `--since` and optionally `--until`, which accept a date such as `2017-06-01`, a date and time such as `2017-06-01 13:30`, or an RFC3339 timestamp. " +
 		"Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" metrics network-out\n" +
 		"datica -E \"<your_env_alias>\" metrics network-out app01 --stream\n" +
 		"datica -E \"<your_env_alias>\" metrics network-out --json\n" +
-		"datica -E \"<your_env_alias>\" metrics network-out db01 --csv -m 60\n```",
+		"datica -E \"<your_env_alias>\" metrics network-out db01 --csv -m 60\n" +
+		"datica -E \"<your_env_alias>\" metrics network-out app01 --csv --since 2017-06-01 --until 2017-06-08\n```",
+	JSONOutput: []netout{},
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to print metrics for")
-			json := subCmd.BoolOpt("json", false, "Output the data as json")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the data as json"))
 			csv := subCmd.BoolOpt("csv", false, "Output the data as csv")
 			text := subCmd.BoolOpt("text", true, "Output the data in plain text")
 			spark := subCmd.BoolOpt("spark", false, "Output the data using spark lines")
-			stream := subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted.")
+			stream := config.StreamOpt(subCmd.BoolOpt("stream", false, "Repeat calls once per minute until this process is interrupted. On pods that support streaming, new data is printed as soon as it is available instead"))
 			mins := subCmd.IntOpt("m mins", 1, "How many minutes worth of metrics to retrieve.")
+			since := subCmd.StringOpt("since", "", "Retrieve metrics at or after this time instead of the last few minutes")
+			until := subCmd.StringOpt("until", "", "Retrieve metrics before this time. Defaults to now")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdMetrics(*serviceName, NetworkOut, *json, *csv, *text, *spark, *stream, *mins, *since, *until, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m] [--since] [--until]"
+		}
+	},
+}
+
+var UsageSubCmd = models.Command{
+	Name:      "usage",
+	ShortHelp: "Summarize the CPU, memory, disk, and network usage of a service over a period of time",
+	LongHelp: "`metrics usage` retrieves the CPU, memory, disk, and network metrics of a single service at once and summarizes them for capacity planning. " +
+		"By default a table is printed with the minimum, average, and maximum of each metric along with a sparkline of how it changed over the period. " +
+		"Give `--csv` or `--json` to instead print one row per timestamp with every metric side by side, which can be loaded into a spreadsheet. " +
+		"The period defaults to the last 24 hours and can be changed with `--since` and `--until`, which accept a date such as `2017-06-01`, a date and time such as `2017-06-01 13:30`, or an RFC3339 timestamp. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" metrics usage app01\n" +
+		"datica -E \"<your_env_alias>\" metrics usage db01 --since 2017-06-01 --until 2017-07-01 --csv > db01.csv\n```",
+	JSONOutput: []usage{},
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to summarize metrics for")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output the data as json"))
+			csv := subCmd.BoolOpt("csv", false, "Output the data as csv")
+			since := subCmd.StringOpt("since", "", "Summarize metrics at or after this time. Defaults to 24 hours before --until")
+			until := subCmd.StringOpt("until", "", "Summarize metrics before this time. Defaults to now")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -186,20 +321,22 @@ var NetworkOutSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdMetrics(*serviceName, NetworkOut, *json, *csv, *text, *spark, *stream, *mins, New(settings), services.New(settings))
+				err := CmdUsage(*serviceName, *since, *until, *json, *csv, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "[SERVICE_NAME] [(--json | --csv | --text | --spark)] [--stream] [-m]"
+			subCmd.Spec = "SERVICE_NAME [(--json | --csv)] [--since] [--until]"
 		}
 	},
 }
 
 // IMetrics
 type IMetrics interface {
-	RetrieveEnvironmentMetrics(mins int) (*[]models.Metrics, error)
-	RetrieveServiceMetrics(mins int, svcID string) (*models.Metrics, error)
+	RetrieveEnvironmentMetrics(r Range) (*[]models.Metrics, error)
+	RetrieveServiceMetrics(r Range, svcID string) (*models.Metrics, error)
+	StreamEnvironmentMetrics(r Range, handle func(metrics *[]models.Metrics)) error
+	StreamServiceMetrics(r Range, svcID string, handle func(metrics *models.Metrics)) error
 }
 
 // SMetrics is a concrete implementation of IMetrics
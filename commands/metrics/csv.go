@@ -31,6 +31,19 @@ func (csv *CSVTransformer) WriteHeadersCPU() {
 	}
 }
 
+// WriteHeadersDisk outputs the csv headers needed for disk data. If GroupMode
+// is enabled, the service name is the first header.
+func (csv *CSVTransformer) WriteHeadersDisk() {
+	if !csv.HeadersWritten {
+		headers := []string{"timestamp", "disk_used", "disk_total", "disk_read_kb", "disk_write_kb"}
+		if csv.GroupMode {
+			headers = append([]string{"service_name"}, headers...)
+		}
+		csv.Writer.Write(headers)
+		csv.HeadersWritten = true
+	}
+}
+
 // WriteHeadersMemory outputs the csv headers needed for memory data. If
 // GroupMode is enabled, the service name is the first header.
 func (csv *CSVTransformer) WriteHeadersMemory() {
@@ -83,6 +96,20 @@ func (csv *CSVTransformer) TransformGroupCPU(metrics *[]models.Metrics) {
 	logrus.Println(csv.Buffer.String())
 }
 
+// TransformGroupDisk transforms an entire environment's disk data into csv
+// format. This outputs TransformSingleDisk for each service in the
+// environment.
+func (csv *CSVTransformer) TransformGroupDisk(metrics *[]models.Metrics) {
+	csv.GroupMode = true
+	for _, metric := range *metrics {
+		if _, ok := blacklist[metric.ServiceLabel]; !ok {
+			csv.TransformSingleDisk(&metric)
+		}
+	}
+	csv.Writer.Flush()
+	logrus.Println(csv.Buffer.String())
+}
+
 // TransformGroupMemory transforms an entire environment's memory data into csv
 // format. This outputs TransformSingleMemory for each service in the
 // environment.
@@ -146,6 +173,30 @@ func (csv *CSVTransformer) TransformSingleCPU(metric *models.Metrics) {
 	}
 }
 
+// TransformSingleDisk transforms a single service's disk data into csv format.
+func (csv *CSVTransformer) TransformSingleDisk(metric *models.Metrics) {
+	csv.WriteHeadersDisk()
+	if metric.Data != nil && metric.Data.DiskUsage != nil {
+		for _, data := range *metric.Data.DiskUsage {
+			row := []string{
+				fmt.Sprintf("%d", data.TS),
+				fmt.Sprintf("%f", data.Used/1024.0),
+				fmt.Sprintf("%f", data.Total/1024.0),
+				fmt.Sprintf("%f", data.ReadKB),
+				fmt.Sprintf("%f", data.WriteKB),
+			}
+			if csv.GroupMode {
+				row = append([]string{metric.ServiceLabel}, row...)
+			}
+			csv.Writer.Write(row)
+		}
+	}
+	if !csv.GroupMode {
+		csv.Writer.Flush()
+		logrus.Println(csv.Buffer.String())
+	}
+}
+
 // TransformSingleMemory transforms a single service's memory data into csv
 // format.
 func (csv *CSVTransformer) TransformSingleMemory(metric *models.Metrics) {
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// dashboardMins is how many minutes of metrics the trend of each job is drawn
+// from
+const dashboardMins = 15
+
+// dashboardPollInterval is the time between requests for metrics on pods that
+// do not support streaming them
+var dashboardPollInterval = 15 * time.Second
+
+// clearScreen moves the cursor to the top left of the terminal and clears it
+const clearScreen = "\033[H\033[2J"
+
+// jobStats is the latest usage of a single job of a service along with the
+// trend of its CPU usage
+type jobStats struct {
+	id       string
+	cpu      float64
+	memory   float64
+	disk     float64
+	rx       float64
+	tx       float64
+	cpuTrend []float64
+}
+
+// CmdDashboard prints the latest CPU, memory, disk, and network usage of every
+// job of a service. When streaming, the dashboard is redrawn every time new
+// metrics are available until the process is interrupted, similar to top.
+func CmdDashboard(svcName string, streamFlag bool, im IMetrics, is services.IServices) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\"", svcName)
+	}
+	r := Range{Mins: dashboardMins}
+	if !streamFlag {
+		metrics, err := im.RetrieveServiceMetrics(r, service.ID)
+		if err != nil {
+			return err
+		}
+		renderDashboard(service, metrics, false)
+		return nil
+	}
+	redraw := runtime.GOOS != "windows" && terminal.IsTerminal(int(os.Stdout.Fd()))
+	err = im.StreamServiceMetrics(r, service.ID, func(metrics *models.Metrics) {
+		renderDashboard(service, metrics, redraw)
+	})
+	logrus.Debugf("Falling back to polling for metrics: %s", err)
+	for {
+		metrics, err := im.RetrieveServiceMetrics(r, service.ID)
+		if err != nil {
+			return err
+		}
+		renderDashboard(service, metrics, redraw)
+		time.Sleep(dashboardPollInterval)
+	}
+}
+
+// renderDashboard prints a table of the usage of each job, busiest first. The
+// screen is cleared first when redraw is set so that the dashboard stays in
+// place, otherwise each update is printed after the last.
+func renderDashboard(service *models.Service, metrics *models.Metrics, redraw bool) {
+	buffer := &bytes.Buffer{}
+	if redraw {
+		buffer.WriteString(clearScreen)
+	}
+	stats := dashboardStats(metrics)
+	fmt.Fprintf(buffer, "%s (%d jobs, %d GB of memory each) at %s\n\n", service.Label, len(stats), service.Size.RAM, config.FormatTimestamp(time.Now()))
+	if len(stats) == 0 {
+		fmt.Fprintf(buffer, "No metrics have been recorded for this service in the last %d minutes\n", dashboardMins)
+	} else {
+		memoryTotal := float64(service.Size.RAM) * 1024.0
+		data := [][]string{{"JOB", "CPU", "MEMORY", "MEM %", "DISK", "RECEIVED", "TRANSMITTED", fmt.Sprintf("CPU (%dm)", dashboardMins)}}
+		for _, s := range stats {
+			memPercent := "-"
+			if memoryTotal > 0 {
+				memPercent = formatPercentage(100.0 * s.memory / memoryTotal)
+			}
+			data = append(data, []string{s.id, formatPercentage(s.cpu), formatMB(s.memory), memPercent, formatMB(s.disk), formatKB(s.rx), formatKB(s.tx), sparkline(s.cpuTrend)})
+		}
+		table := tablewriter.NewWriter(buffer)
+		table.SetBorder(false)
+		table.SetRowLine(false)
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("")
+		table.SetRowSeparator("")
+		table.AppendBulk(data)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	}
+	if redraw {
+		buffer.WriteString("\nPress ctrl-c to quit\n")
+	}
+	logrus.StandardLogger().Out.Write(buffer.Bytes())
+}
+
+// dashboardStats returns the latest usage of each job of the service sorted by
+// CPU usage, busiest first
+func dashboardStats(metrics *models.Metrics) []*jobStats {
+	if metrics == nil || metrics.Data == nil {
+		return nil
+	}
+	byJob := map[string]*jobStats{}
+	job := func(id string) *jobStats {
+		if byJob[id] == nil {
+			byJob[id] = &jobStats{id: id}
+		}
+		return byJob[id]
+	}
+	// latest reports whether a sample is at least as new as the last one seen
+	// of the same kind for the job
+	latest := map[string]int{}
+	isLatest := func(kind, id string, ts int) bool {
+		key := kind + "/" + id
+		if last, ok := latest[key]; ok && last > ts {
+			return false
+		}
+		latest[key] = ts
+		return true
+	}
+	if metrics.Data.CPUUsage != nil {
+		samples := *metrics.Data.CPUUsage
+		sort.SliceStable(samples, func(i, j int) bool {
+			return samples[i].TS < samples[j].TS
+		})
+		for _, d := range samples {
+			s := job(d.JobID)
+			s.cpuTrend = append(s.cpuTrend, d.CorePercent*100.0)
+			if isLatest("cpu", d.JobID, d.TS) {
+				s.cpu = d.CorePercent * 100.0
+			}
+		}
+	}
+	if metrics.Data.MemoryUsage != nil {
+		for _, d := range *metrics.Data.MemoryUsage {
+			if isLatest("memory", d.JobID, d.TS) {
+				job(d.JobID).memory = d.AVG / 1024.0
+			}
+		}
+	}
+	if metrics.Data.DiskUsage != nil {
+		for _, d := range *metrics.Data.DiskUsage {
+			if isLatest("disk", d.JobID, d.TS) {
+				job(d.JobID).disk = d.Used / 1024.0
+			}
+		}
+	}
+	if metrics.Data.NetworkUsage != nil {
+		for _, d := range *metrics.Data.NetworkUsage {
+			if isLatest("network", d.JobID, d.TS) {
+				s := job(d.JobID)
+				s.rx = d.RXKB
+				s.tx = d.TXKB
+			}
+		}
+	}
+	var stats []*jobStats
+	for _, s := range byJob {
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].cpu != stats[j].cpu {
+			return stats[i].cpu > stats[j].cpu
+		}
+		return stats[i].id < stats[j].id
+	})
+	return stats
+}
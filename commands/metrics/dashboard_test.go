@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const dashboardMetrics = `{"serviceId":"%s","serviceLabel":"%s","size":{"ram":1},"metrics":{"cpu.usage":[{"job":"j1","core_percent":0.5,"ts":2000},{"job":"j1","core_percent":0.25,"ts":1000},{"job":"j2","core_percent":0.75,"ts":1000},{"job":"j2","core_percent":0.1,"ts":2000}],"memory.usage":[{"job":"j1","ave":262144,"ts":1000},{"job":"j1","ave":524288,"ts":2000}],"network.usage":[{"job":"j2","rx_kb":10,"tx_kb":20,"ts":2000}]}}`
+
+var dashboardTests = []struct {
+	svcName   string
+	expectErr bool
+}{
+	{test.SvcLabel, false},
+	{"invalid-svc", true},
+}
+
+func TestDashboard(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","size":{"ram":1}}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/metrics",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			test.AssertEquals(t, r.URL.Query().Get("time"), fmt.Sprintf("%dm", dashboardMins))
+			fmt.Fprint(w, fmt.Sprintf(dashboardMetrics, test.SvcID, test.SvcLabel))
+		},
+	)
+
+	for _, data := range dashboardTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdDashboard(data.svcName, false, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
+
+func TestDashboardStats(t *testing.T) {
+	var metrics models.Metrics
+	if err := json.Unmarshal([]byte(fmt.Sprintf(dashboardMetrics, test.SvcID, test.SvcLabel)), &metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	// test
+	stats := dashboardStats(&metrics)
+
+	// assert
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 jobs but got %d", len(stats))
+	}
+	if stats[0].id != "j1" || stats[0].cpu != 50.0 || stats[0].memory != 512.0 {
+		t.Errorf("Expected j1 to be the busiest job with its latest usage but got %+v", stats[0])
+	}
+	if len(stats[0].cpuTrend) != 2 || stats[0].cpuTrend[0] != 25.0 {
+		t.Errorf("Expected the CPU trend of j1 to be oldest first but got %v", stats[0].cpuTrend)
+	}
+	if stats[1].id != "j2" || stats[1].cpu != 10.0 || stats[1].rx != 10.0 || stats[1].tx != 20.0 {
+		t.Errorf("Expected j2 second with its latest usage but got %+v", stats[1])
+	}
+}
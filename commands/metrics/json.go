@@ -17,6 +17,15 @@ type cpu struct {
 	Percentage  float64 `json:"percentage"`
 }
 
+type disk struct {
+	ServiceName string  `json:"service_name,omitempty"`
+	TS          int     `json:"ts"`
+	Used        float64 `json:"used"`
+	Total       float64 `json:"total"`
+	ReadKB      float64 `json:"read_kb"`
+	WriteKB     float64 `json:"write_kb"`
+}
+
 type mem struct {
 	ServiceName string  `json:"service_name,omitempty"`
 	TS          int     `json:"ts"`
@@ -55,6 +64,22 @@ func (j *JSONTransformer) TransformGroupCPU(metrics *[]models.Metrics) {
 	logrus.Println(string(b))
 }
 
+// TransformGroupDisk transforms an entire environment's disk data into json
+// format. This outputs TransformSingleDisk for every service in the
+// environment.
+func (j *JSONTransformer) TransformGroupDisk(metrics *[]models.Metrics) {
+	var data []disk
+	for _, m := range *metrics {
+		if _, ok := blacklist[m.ServiceLabel]; !ok && m.Data != nil && m.Data.DiskUsage != nil {
+			for _, d := range *m.Data.DiskUsage {
+				data = append(data, disk{m.ServiceLabel, d.TS, d.Used / 1024.0, d.Total / 1024.0, d.ReadKB, d.WriteKB})
+			}
+		}
+	}
+	b, _ := json.MarshalIndent(data, "", "    ")
+	logrus.Println(string(b))
+}
+
 // TransformGroupMemory transforms an entire environment's memory data into json
 // format. This outputs TransformSingleMemory for every service in the
 // environment.
@@ -115,6 +140,18 @@ func (j *JSONTransformer) TransformSingleCPU(metric *models.Metrics) {
 	logrus.Println(string(b))
 }
 
+// TransformSingleDisk transforms a single service's disk data into json format.
+func (j *JSONTransformer) TransformSingleDisk(metric *models.Metrics) {
+	var data []disk
+	if metric.Data != nil && metric.Data.DiskUsage != nil {
+		for _, d := range *metric.Data.DiskUsage {
+			data = append(data, disk{TS: d.TS, Used: d.Used / 1024.0, Total: d.Total / 1024.0, ReadKB: d.ReadKB, WriteKB: d.WriteKB})
+		}
+	}
+	b, _ := json.MarshalIndent(data, "", "    ")
+	logrus.Println(string(b))
+}
+
 // TransformSingleMemory transforms a single service's memory data into json
 // format.
 func (j *JSONTransformer) TransformSingleMemory(metric *models.Metrics) {
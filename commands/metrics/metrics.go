@@ -3,11 +3,15 @@ package metrics
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/stream"
 	"github.com/daticahealth/cli/models"
 	ui "github.com/gizak/termui"
 )
@@ -23,20 +27,72 @@ var blacklist = map[string]struct{}{
 // service metrics data (single).
 type Transformer interface {
 	TransformGroupCPU(*[]models.Metrics)
+	TransformGroupDisk(*[]models.Metrics)
 	TransformGroupMemory(*[]models.Metrics)
 	TransformGroupNetworkIn(*[]models.Metrics)
 	TransformGroupNetworkOut(*[]models.Metrics)
 	TransformSingleCPU(*models.Metrics)
+	TransformSingleDisk(*models.Metrics)
 	TransformSingleMemory(*models.Metrics)
 	TransformSingleNetworkIn(*models.Metrics)
 	TransformSingleNetworkOut(*models.Metrics)
 }
 
+// Range is the period of time metrics are retrieved for. This is the last Mins
+// minutes unless Since is set, in which case it is from Since until Until.
+type Range struct {
+	Mins  int
+	Since time.Time
+	Until time.Time
+}
+
+// query returns the query string that requests the range from the metrics API
+func (r Range) query() string {
+	if r.Since.IsZero() {
+		return fmt.Sprintf("time=%dm", r.Mins)
+	}
+	params := url.Values{}
+	params.Set("since", r.Since.UTC().Format(time.RFC3339))
+	params.Set("until", r.Until.UTC().Format(time.RFC3339))
+	return params.Encode()
+}
+
+// parseRange builds the range to retrieve metrics for from the --mins,
+// --since, and --until flags. When only --until is given, the range is the
+// given number of minutes before it.
+func parseRange(mins int, since, until string) (Range, error) {
+	if since == "" && until == "" {
+		return Range{Mins: mins}, nil
+	}
+	untilTime := time.Now()
+	if until != "" {
+		t, err := config.ParseTimestamp(until)
+		if err != nil {
+			return Range{}, err
+		}
+		untilTime = t
+	}
+	sinceTime := untilTime.Add(-time.Duration(mins) * time.Minute)
+	if since != "" {
+		t, err := config.ParseTimestamp(since)
+		if err != nil {
+			return Range{}, err
+		}
+		sinceTime = t
+	}
+	if !sinceTime.Before(untilTime) {
+		return Range{}, fmt.Errorf("--since must be before --until")
+	}
+	return Range{Since: sinceTime, Until: untilTime}, nil
+}
+
 // CmdMetrics prints out metrics for a given service or if the service is not
 // specified, metrics for the entire environment are printed.
-func CmdMetrics(svcName string, metricType MetricType, jsonFlag, csvFlag, textFlag, sparkFlag, streamFlag bool, mins int, im IMetrics, is services.IServices) error {
+func CmdMetrics(svcName string, metricType MetricType, jsonFlag, csvFlag, textFlag, sparkFlag, streamFlag bool, mins int, since, until string, im IMetrics, is services.IServices) error {
 	if sparkFlag {
-		logrus.Warnln("The \"--spark\" flag has been deprecated! Please use \"--csv\", \"--json\", or \"--text\" instead. \"--spark\" will be removed in the next CLI update.")
+		if err := config.Deprecated("The \"--spark\" flag has been deprecated! Please use \"--csv\", \"--json\", or \"--text\" instead. \"--spark\" will be removed in the next CLI update."); err != nil {
+			return err
+		}
 	}
 	if streamFlag && (jsonFlag || csvFlag || mins != 1) {
 		return fmt.Errorf("--stream cannot be used with CSV or JSON formats and multiple records")
@@ -44,6 +100,18 @@ func CmdMetrics(svcName string, metricType MetricType, jsonFlag, csvFlag, textFl
 	if mins > 1440 {
 		return fmt.Errorf("--mins cannot be greater than 1440")
 	}
+	if since != "" || until != "" {
+		if streamFlag || sparkFlag {
+			return fmt.Errorf("--since and --until cannot be used with --stream or --spark")
+		}
+		if since != "" && mins != 1 {
+			return fmt.Errorf("--mins cannot be used with --since")
+		}
+	}
+	r, err := parseRange(mins, since, until)
+	if err != nil {
+		return err
+	}
 	var mt Transformer
 	if jsonFlag {
 		mt = &JSONTransformer{}
@@ -59,7 +127,7 @@ func CmdMetrics(svcName string, metricType MetricType, jsonFlag, csvFlag, textFl
 		// the spark lines interface stays up until closed by the user, so
 		// we might as well keep updating it as long as it is there
 		streamFlag = true
-		mins = 30
+		r = Range{Mins: 30}
 		err := ui.Init()
 		if err != nil {
 			return err
@@ -93,29 +161,26 @@ func CmdMetrics(svcName string, metricType MetricType, jsonFlag, csvFlag, textFl
 		if service == nil {
 			return fmt.Errorf("Could not find a service with the label \"%s\"", svcName)
 		}
-		return CmdServiceMetrics(metricType, streamFlag, sparkFlag, mins, service, mt, im)
+		return CmdServiceMetrics(metricType, streamFlag, sparkFlag, r, service, mt, im)
 	}
-	return CmdEnvironmentMetrics(metricType, streamFlag, sparkFlag, mins, mt, im)
+	return CmdEnvironmentMetrics(metricType, streamFlag, sparkFlag, r, mt, im)
 }
 
-func CmdEnvironmentMetrics(metricType MetricType, stream, sparkLines bool, mins int, t Transformer, im IMetrics) error {
+func CmdEnvironmentMetrics(metricType MetricType, stream, sparkLines bool, r Range, t Transformer, im IMetrics) error {
 	done := make(chan struct{})
 	go func() {
+		if stream {
+			err := im.StreamEnvironmentMetrics(r, func(metrics *[]models.Metrics) {
+				transformGroup(metricType, t, metrics)
+			})
+			logrus.Debugf("Falling back to polling for metrics: %s", err)
+		}
 		for {
-			metrics, err := im.RetrieveEnvironmentMetrics(mins)
+			metrics, err := im.RetrieveEnvironmentMetrics(r)
 			if err != nil {
 				logrus.Fatal(err.Error())
 			}
-			switch metricType {
-			case CPU:
-				t.TransformGroupCPU(metrics)
-			case Memory:
-				t.TransformGroupMemory(metrics)
-			case NetworkIn:
-				t.TransformGroupNetworkIn(metrics)
-			case NetworkOut:
-				t.TransformGroupNetworkOut(metrics)
-			}
+			transformGroup(metricType, t, metrics)
 			if !stream {
 				break
 			}
@@ -131,24 +196,21 @@ func CmdEnvironmentMetrics(metricType MetricType, stream, sparkLines bool, mins
 	return nil
 }
 
-func CmdServiceMetrics(metricType MetricType, stream, sparkLines bool, mins int, service *models.Service, t Transformer, im IMetrics) error {
+func CmdServiceMetrics(metricType MetricType, stream, sparkLines bool, r Range, service *models.Service, t Transformer, im IMetrics) error {
 	done := make(chan struct{})
 	go func() {
+		if stream {
+			err := im.StreamServiceMetrics(r, service.ID, func(metrics *models.Metrics) {
+				transformSingle(metricType, t, metrics)
+			})
+			logrus.Debugf("Falling back to polling for metrics: %s", err)
+		}
 		for {
-			metrics, err := im.RetrieveServiceMetrics(mins, service.ID)
+			metrics, err := im.RetrieveServiceMetrics(r, service.ID)
 			if err != nil {
 				logrus.Fatal(err.Error())
 			}
-			switch metricType {
-			case CPU:
-				t.TransformSingleCPU(metrics)
-			case Memory:
-				t.TransformSingleMemory(metrics)
-			case NetworkIn:
-				t.TransformSingleNetworkIn(metrics)
-			case NetworkOut:
-				t.TransformSingleNetworkOut(metrics)
-			}
+			transformSingle(metricType, t, metrics)
 			if !stream {
 				break
 			}
@@ -164,10 +226,42 @@ func CmdServiceMetrics(metricType MetricType, stream, sparkLines bool, mins int,
 	return nil
 }
 
+func transformGroup(metricType MetricType, t Transformer, metrics *[]models.Metrics) {
+	switch metricType {
+	case CPU:
+		t.TransformGroupCPU(metrics)
+	case Disk:
+		t.TransformGroupDisk(metrics)
+	case Memory:
+		t.TransformGroupMemory(metrics)
+	case NetworkIn:
+		t.TransformGroupNetworkIn(metrics)
+	case NetworkOut:
+		t.TransformGroupNetworkOut(metrics)
+	}
+}
+
+func transformSingle(metricType MetricType, t Transformer, metrics *models.Metrics) {
+	switch metricType {
+	case CPU:
+		t.TransformSingleCPU(metrics)
+	case Disk:
+		t.TransformSingleDisk(metrics)
+	case Memory:
+		t.TransformSingleMemory(metrics)
+	case NetworkIn:
+		t.TransformSingleNetworkIn(metrics)
+	case NetworkOut:
+		t.TransformSingleNetworkOut(metrics)
+	}
+}
+
 func metricsTypeToString(metricType MetricType) string {
 	switch metricType {
 	case CPU:
 		return "CPU"
+	case Disk:
+		return "Disk"
 	case Memory:
 		return "Memory"
 	case NetworkIn:
@@ -181,9 +275,9 @@ func metricsTypeToString(metricType MetricType) string {
 
 // RetrieveEnvironmentMetrics retrieves metrics data for all services in
 // the associated environment.
-func (m *SMetrics) RetrieveEnvironmentMetrics(mins int) (*[]models.Metrics, error) {
+func (m *SMetrics) RetrieveEnvironmentMetrics(r Range) (*[]models.Metrics, error) {
 	headers := m.Settings.HTTPManager.GetHeaders(m.Settings.SessionToken, m.Settings.Version, m.Settings.Pod, m.Settings.UsersID)
-	resp, statusCode, err := m.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/metrics?time=%dm", m.Settings.PaasHost, m.Settings.PaasHostVersion, m.Settings.EnvironmentID, mins), headers)
+	resp, statusCode, err := m.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/metrics?%s", m.Settings.PaasHost, m.Settings.PaasHostVersion, m.Settings.EnvironmentID, r.query()), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -196,9 +290,9 @@ func (m *SMetrics) RetrieveEnvironmentMetrics(mins int) (*[]models.Metrics, erro
 }
 
 // RetrieveServiceMetrics retrieves metrics data for the given service.
-func (m *SMetrics) RetrieveServiceMetrics(mins int, svcID string) (*models.Metrics, error) {
+func (m *SMetrics) RetrieveServiceMetrics(r Range, svcID string) (*models.Metrics, error) {
 	headers := m.Settings.HTTPManager.GetHeaders(m.Settings.SessionToken, m.Settings.Version, m.Settings.Pod, m.Settings.UsersID)
-	resp, statusCode, err := m.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/metrics?time=%dm", m.Settings.PaasHost, m.Settings.PaasHostVersion, m.Settings.EnvironmentID, svcID, mins), headers)
+	resp, statusCode, err := m.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/metrics?%s", m.Settings.PaasHost, m.Settings.PaasHostVersion, m.Settings.EnvironmentID, svcID, r.query()), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -209,3 +303,33 @@ func (m *SMetrics) RetrieveServiceMetrics(mins int, svcID string) (*models.Metri
 	}
 	return &metrics, nil
 }
+
+// StreamEnvironmentMetrics calls handle with metrics data for all services in
+// the associated environment each time the pod pushes new data. It returns
+// stream.ErrUnsupported if the pod does not support streaming, in which case
+// metrics must be polled with RetrieveEnvironmentMetrics instead.
+func (m *SMetrics) StreamEnvironmentMetrics(r Range, handle func(metrics *[]models.Metrics)) error {
+	return stream.New(m.Settings).Subscribe(fmt.Sprintf("/environments/%s/metrics/stream?%s", m.Settings.EnvironmentID, r.query()), func(msg []byte) error {
+		var metrics []models.Metrics
+		if err := json.Unmarshal(msg, &metrics); err != nil {
+			return err
+		}
+		handle(&metrics)
+		return nil
+	})
+}
+
+// StreamServiceMetrics calls handle with metrics data for the given service
+// each time the pod pushes new data. It returns stream.ErrUnsupported if the
+// pod does not support streaming, in which case metrics must be polled with
+// RetrieveServiceMetrics instead.
+func (m *SMetrics) StreamServiceMetrics(r Range, svcID string, handle func(metrics *models.Metrics)) error {
+	return stream.New(m.Settings).Subscribe(fmt.Sprintf("/environments/%s/services/%s/metrics/stream?%s", m.Settings.EnvironmentID, svcID, r.query()), func(msg []byte) error {
+		var metrics models.Metrics
+		if err := json.Unmarshal(msg, &metrics); err != nil {
+			return err
+		}
+		handle(&metrics)
+		return nil
+	})
+}
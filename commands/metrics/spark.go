@@ -8,6 +8,7 @@ import (
 const (
 	titleColor      = ui.ColorWhite
 	cpuColor        = ui.ColorBlue
+	diskColor       = ui.ColorYellow
 	memoryColor     = ui.ColorGreen
 	networkInColor  = ui.ColorRed
 	networkOutColor = ui.ColorWhite
@@ -29,6 +30,17 @@ func (spark *SparkTransformer) TransformGroupCPU(metrics *[]models.Metrics) {
 	}
 }
 
+// TransformGroupDisk transforms an entire environment's disk data into spark
+// lines. This outputs TransformSingleDisk for every service in the
+// environment.
+func (spark *SparkTransformer) TransformGroupDisk(metrics *[]models.Metrics) {
+	for _, metric := range *metrics {
+		if _, ok := blacklist[metric.ServiceLabel]; !ok {
+			spark.TransformSingleDisk(&metric)
+		}
+	}
+}
+
 // TransformGroupMemory transforms an entire environment's memory data into
 // spark lines. This outputs TransformSingleMemory for every service in the
 // environment.
@@ -83,6 +95,31 @@ func (spark *SparkTransformer) TransformSingleCPU(metric *models.Metrics) {
 	ui.Render(ui.Body)
 }
 
+// TransformSingleDisk transforms a single service's disk data into spark lines.
+func (spark *SparkTransformer) TransformSingleDisk(metric *models.Metrics) {
+	var diskUsed []int
+	var diskTotal []int
+	if metric.Data != nil && metric.Data.DiskUsage != nil {
+		for _, data := range *metric.Data.DiskUsage {
+			diskUsed = append(diskUsed, int(data.Used/1024.0))
+			diskTotal = append(diskTotal, int(data.Total/1024.0))
+		}
+	}
+	var sparkLines = spark.SparkLines[metric.ServiceLabel]
+	if sparkLines == nil {
+		sparkLines = addSparkLine(metric.ServiceLabel, []string{"Disk Used", "Disk Total"}, diskColor)
+		spark.SparkLines[metric.ServiceLabel] = sparkLines
+	}
+	for i := range sparkLines.Lines {
+		if sparkLines.Lines[i].Title == "Disk Used" {
+			sparkLines.Lines[i].Data = diskUsed
+		} else if sparkLines.Lines[i].Title == "Disk Total" {
+			sparkLines.Lines[i].Data = diskTotal
+		}
+	}
+	ui.Render(ui.Body)
+}
+
 // TransformSingleMemory transforms a single service's memory data into spark
 // lines.
 func (spark *SparkTransformer) TransformSingleMemory(metric *models.Metrics) {
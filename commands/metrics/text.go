@@ -1,10 +1,11 @@
 package metrics
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/models"
 )
 
@@ -23,6 +24,18 @@ func (text *TextTransformer) TransformGroupCPU(metrics *[]models.Metrics) {
 	}
 }
 
+// TransformGroupDisk transforms an entire environment's disk data into text
+// format. This outputs TransformSingleDisk for every service in the
+// environment.
+func (text *TextTransformer) TransformGroupDisk(metrics *[]models.Metrics) {
+	for _, metric := range *metrics {
+		if _, ok := blacklist[metric.ServiceLabel]; !ok {
+			logrus.Printf("%s:", metric.ServiceLabel)
+			text.TransformSingleDisk(&metric)
+		}
+	}
+}
+
 // TransformGroupMemory transforms an entire environment's memory data into
 // text format. This outputs TransformSingleMemory for every service in the
 // environment.
@@ -67,12 +80,29 @@ func (text *TextTransformer) TransformSingleCPU(metric *models.Metrics) {
 			ts := time.Unix(int64(data.TS/1000.0), 0)
 			logrus.Printf("%s%s | CPU Percentage: %6.2f%%",
 				prefix,
-				fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d", ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second()),
+				config.FormatTimestamp(ts),
 				data.CorePercent*100.0)
 		}
 	}
 }
 
+// TransformSingleDisk transforms a single service's disk data into text format.
+func (text *TextTransformer) TransformSingleDisk(metric *models.Metrics) {
+	prefix := "    "
+	if metric.Data != nil && metric.Data.DiskUsage != nil {
+		for _, data := range *metric.Data.DiskUsage {
+			ts := time.Unix(int64(data.TS/1000.0), 0)
+			logrus.Printf("%s%s | Disk Used: %s | Disk Total: %s | Read: %s | Written: %s",
+				prefix,
+				config.FormatTimestamp(ts),
+				config.FormatBytes(data.Used*1024.0),
+				config.FormatBytes(data.Total*1024.0),
+				config.FormatBytes(data.ReadKB*1024.0),
+				config.FormatBytes(data.WriteKB*1024.0))
+		}
+	}
+}
+
 // TransformSingleMemory transforms a single service's memory data into text
 // format.
 func (text *TextTransformer) TransformSingleMemory(metric *models.Metrics) {
@@ -80,13 +110,13 @@ func (text *TextTransformer) TransformSingleMemory(metric *models.Metrics) {
 	if metric.Data != nil && metric.Data.MemoryUsage != nil {
 		for _, data := range *metric.Data.MemoryUsage {
 			ts := time.Unix(int64(data.TS/1000.0), 0)
-			logrus.Printf("%s%s | Memory Min: %.2f MB | Memory Max: %.2f MB | Memory AVG: %.2f MB | Memory Total: %.2f MB",
+			logrus.Printf("%s%s | Memory Min: %s | Memory Max: %s | Memory AVG: %s | Memory Total: %s",
 				prefix,
-				fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d", ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second()),
-				data.Min/1024.0,
-				data.Max/1024.0,
-				data.AVG/1024.0,
-				float64(metric.Size.RAM)*1024.0)
+				config.FormatTimestamp(ts),
+				config.FormatBytes(data.Min*1024.0),
+				config.FormatBytes(data.Max*1024.0),
+				config.FormatBytes(data.AVG*1024.0),
+				config.FormatBytes(float64(metric.Size.RAM)*float64(transfer.GB)))
 		}
 	}
 }
@@ -98,10 +128,10 @@ func (text *TextTransformer) TransformSingleNetworkIn(metric *models.Metrics) {
 	if metric.Data != nil && metric.Data.NetworkUsage != nil {
 		for _, data := range *metric.Data.NetworkUsage {
 			ts := time.Unix(int64(data.TS/1000.0), 0)
-			logrus.Printf("%s%s | Received Bytes: %.2f KB | Received Packets: %.2f",
+			logrus.Printf("%s%s | Received: %s | Received Packets: %.2f",
 				prefix,
-				fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d", ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second()),
-				data.RXKB,
+				config.FormatTimestamp(ts),
+				config.FormatBytes(data.RXKB*1024.0),
 				data.RXPackets)
 		}
 	}
@@ -114,10 +144,10 @@ func (text *TextTransformer) TransformSingleNetworkOut(metric *models.Metrics) {
 	if metric.Data != nil && metric.Data.NetworkUsage != nil {
 		for _, data := range *metric.Data.NetworkUsage {
 			ts := time.Unix(int64(data.TS/1000.0), 0)
-			logrus.Printf("%s%s | Transmitted Bytes: %.2f KB | Transmitted Packets: %.2f",
+			logrus.Printf("%s%s | Transmitted: %s | Transmitted Packets: %.2f",
 				prefix,
-				fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d", ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second()),
-				data.TXKB,
+				config.FormatTimestamp(ts),
+				config.FormatBytes(data.TXKB*1024.0),
 				data.TXPackets)
 		}
 	}
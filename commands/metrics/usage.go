@@ -0,0 +1,268 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// usageMins is how many minutes of metrics are summarized when no --since is
+// given
+const usageMins = 24 * 60
+
+// sparkLevels are the characters of an ASCII sparkline from lowest to highest
+var sparkLevels = []byte("_.-=+*#")
+
+// sparkWidth is the most characters a sparkline is drawn with. Longer series
+// are averaged into buckets.
+const sparkWidth = 40
+
+// usage is every metric of a service at a single timestamp. Memory and disk
+// are in MB and every value is summed across the jobs of the service. Metrics
+// that were not recorded at the timestamp are zero and not marked as present.
+type usage struct {
+	TS            int     `json:"ts"`
+	CPUPercentage float64 `json:"cpu_percentage"`
+	MemoryAVG     float64 `json:"memory_avg"`
+	MemoryMax     float64 `json:"memory_max"`
+	MemoryTotal   float64 `json:"memory_total"`
+	DiskUsed      float64 `json:"disk_used"`
+	DiskTotal     float64 `json:"disk_total"`
+	RXKB          float64 `json:"rx_kb"`
+	TXKB          float64 `json:"tx_kb"`
+
+	hasCPU     bool
+	hasMemory  bool
+	hasDisk    bool
+	hasNetwork bool
+}
+
+// CmdUsage summarizes the CPU, memory, disk, and network usage of a service
+// over a period of time, or prints every metric side by side as CSV or JSON.
+func CmdUsage(svcName, since, until string, jsonFlag, csvFlag bool, im IMetrics, is services.IServices) error {
+	r, err := parseRange(usageMins, since, until)
+	if err != nil {
+		return err
+	}
+	if r.Since.IsZero() {
+		r.Until = time.Now()
+		r.Since = r.Until.Add(-usageMins * time.Minute)
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\"", svcName)
+	}
+	metrics, err := im.RetrieveServiceMetrics(r, service.ID)
+	if err != nil {
+		return err
+	}
+	rows := usageRows(metrics)
+	if jsonFlag {
+		if rows == nil {
+			rows = []usage{}
+		}
+		b, _ := json.MarshalIndent(rows, "", "    ")
+		logrus.Println(string(b))
+		return nil
+	}
+	if csvFlag {
+		buffer := &bytes.Buffer{}
+		w := csv.NewWriter(buffer)
+		w.Write([]string{"timestamp", "time", "cpu_percentage", "memory_avg", "memory_max", "memory_total", "disk_used", "disk_total", "rx_kb", "tx_kb"})
+		for _, row := range rows {
+			w.Write([]string{
+				fmt.Sprintf("%d", row.TS),
+				time.Unix(int64(row.TS/1000.0), 0).UTC().Format(time.RFC3339),
+				fmt.Sprintf("%f", row.CPUPercentage),
+				fmt.Sprintf("%f", row.MemoryAVG),
+				fmt.Sprintf("%f", row.MemoryMax),
+				fmt.Sprintf("%f", row.MemoryTotal),
+				fmt.Sprintf("%f", row.DiskUsed),
+				fmt.Sprintf("%f", row.DiskTotal),
+				fmt.Sprintf("%f", row.RXKB),
+				fmt.Sprintf("%f", row.TXKB),
+			})
+		}
+		w.Flush()
+		logrus.Println(buffer.String())
+		return nil
+	}
+	logrus.Printf("Usage of %s from %s to %s", service.Label, config.FormatTimestamp(r.Since), config.FormatTimestamp(r.Until))
+	if len(rows) == 0 {
+		logrus.Println("No metrics were recorded in this period")
+		return nil
+	}
+	series := []struct {
+		name    string
+		format  func(float64) string
+		present func(usage) bool
+		value   func(usage) float64
+	}{
+		{"CPU", formatPercentage, func(u usage) bool { return u.hasCPU }, func(u usage) float64 { return u.CPUPercentage }},
+		{"Memory", formatMB, func(u usage) bool { return u.hasMemory }, func(u usage) float64 { return u.MemoryAVG }},
+		{"Disk", formatMB, func(u usage) bool { return u.hasDisk }, func(u usage) float64 { return u.DiskUsed }},
+		{"Network In", formatKB, func(u usage) bool { return u.hasNetwork }, func(u usage) float64 { return u.RXKB }},
+		{"Network Out", formatKB, func(u usage) bool { return u.hasNetwork }, func(u usage) float64 { return u.TXKB }},
+	}
+	data := [][]string{{"METRIC", "MIN", "AVG", "MAX", "TREND"}}
+	for _, s := range series {
+		values := []float64{}
+		for _, row := range rows {
+			if s.present(row) {
+				values = append(values, s.value(row))
+			}
+		}
+		if len(values) == 0 {
+			data = append(data, []string{s.name, "-", "-", "-", ""})
+			continue
+		}
+		min, avg, max := summarize(values)
+		data = append(data, []string{s.name, s.format(min), s.format(avg), s.format(max), sparkline(values)})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	peakMemory := 0.0
+	var lastDisk *usage
+	for i, row := range rows {
+		if row.hasMemory && row.MemoryMax > peakMemory {
+			peakMemory = row.MemoryMax
+		}
+		if row.hasDisk {
+			lastDisk = &rows[i]
+		}
+	}
+	if memoryTotal := rows[0].MemoryTotal; peakMemory > 0 && memoryTotal > 0 {
+		logrus.Printf("Peak memory was %.0f%% of the %s available", 100.0*peakMemory/memoryTotal, formatMB(memoryTotal))
+	}
+	if lastDisk != nil && lastDisk.DiskTotal > 0 {
+		logrus.Printf("Disk is %.0f%% full", 100.0*lastDisk.DiskUsed/lastDisk.DiskTotal)
+	}
+	return nil
+}
+
+// usageRows merges the metrics of a service into a single row per timestamp,
+// sorted from oldest to newest
+func usageRows(metrics *models.Metrics) []usage {
+	if metrics == nil || metrics.Data == nil {
+		return nil
+	}
+	byTS := map[int]*usage{}
+	row := func(ts int) *usage {
+		if byTS[ts] == nil {
+			byTS[ts] = &usage{TS: ts, MemoryTotal: float64(metrics.Size.RAM) * 1024.0}
+		}
+		return byTS[ts]
+	}
+	if metrics.Data.CPUUsage != nil {
+		for _, d := range *metrics.Data.CPUUsage {
+			u := row(d.TS)
+			u.CPUPercentage += d.CorePercent * 100.0
+			u.hasCPU = true
+		}
+	}
+	if metrics.Data.MemoryUsage != nil {
+		for _, d := range *metrics.Data.MemoryUsage {
+			u := row(d.TS)
+			u.MemoryAVG += d.AVG / 1024.0
+			u.MemoryMax += d.Max / 1024.0
+			u.hasMemory = true
+		}
+	}
+	if metrics.Data.DiskUsage != nil {
+		for _, d := range *metrics.Data.DiskUsage {
+			u := row(d.TS)
+			u.DiskUsed += d.Used / 1024.0
+			u.DiskTotal += d.Total / 1024.0
+			u.hasDisk = true
+		}
+	}
+	if metrics.Data.NetworkUsage != nil {
+		for _, d := range *metrics.Data.NetworkUsage {
+			u := row(d.TS)
+			u.RXKB += d.RXKB
+			u.TXKB += d.TXKB
+			u.hasNetwork = true
+		}
+	}
+	var rows []usage
+	for _, u := range byTS {
+		rows = append(rows, *u)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].TS < rows[j].TS
+	})
+	return rows
+}
+
+// summarize returns the minimum, average, and maximum of the values
+func summarize(values []float64) (float64, float64, float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	min, max, total := values[0], values[0], 0.0
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+		total += v
+	}
+	return min, total / float64(len(values)), max
+}
+
+// sparkline draws the values with ASCII characters, scaled between the
+// smallest and largest value. A flat series is drawn at the lowest level.
+func sparkline(values []float64) string {
+	if len(values) > sparkWidth {
+		buckets := make([]float64, sparkWidth)
+		for i := range buckets {
+			start := i * len(values) / sparkWidth
+			end := (i + 1) * len(values) / sparkWidth
+			_, buckets[i], _ = summarize(values[start:end])
+		}
+		values = buckets
+	}
+	min, _, max := summarize(values)
+	line := make([]byte, len(values))
+	for i, v := range values {
+		level := 0
+		if max > min {
+			level = int((v - min) / (max - min) * float64(len(sparkLevels)-1))
+		}
+		line[i] = sparkLevels[level]
+	}
+	return string(line)
+}
+
+func formatPercentage(v float64) string {
+	return fmt.Sprintf("%.2f%%", v)
+}
+
+func formatMB(v float64) string {
+	return config.FormatBytes(v * 1024.0 * 1024.0)
+}
+
+func formatKB(v float64) string {
+	return config.FormatBytes(v * 1024.0)
+}
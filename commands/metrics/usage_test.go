@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+var usageTests = []struct {
+	svcName   string
+	since     string
+	until     string
+	json      bool
+	csv       bool
+	expectErr bool
+}{
+	{test.SvcLabel, "", "", false, false, false},
+	{test.SvcLabel, "2017-06-01", "2017-07-01", false, true, false},
+	{test.SvcLabel, "2017-06-01", "", true, false, false},
+	{test.SvcLabel, "", "2017-07-01 13:30", false, false, false},
+	{test.SvcLabel, "2017-07-01", "2017-06-01", false, false, true},
+	{test.SvcLabel, "yesterday", "", false, false, true},
+	{"invalid-svc", "", "", false, false, true},
+}
+
+func TestUsage(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/metrics",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			if r.URL.Query().Get("since") == "" || r.URL.Query().Get("until") == "" {
+				t.Errorf("Expected a since and until time but got %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, fmt.Sprintf(`{"serviceId":"%s","serviceLabel":"%s","size":{"ram":1},"metrics":{"cpu.usage":[{"job":"j1","core_percent":0.25,"ts":1000},{"job":"j1","core_percent":0.5,"ts":2000}],"memory.usage":[{"job":"j1","ave":262144,"max":524288,"min":131072,"ts":1000}],"disk.usage":[{"job":"j1","used":1048576,"total":10485760,"ts":2000}],"network.usage":[{"job":"j1","rx_kb":10,"tx_kb":20,"ts":1000}]}}`, test.SvcID, test.SvcLabel))
+		},
+	)
+
+	for _, data := range usageTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdUsage(data.svcName, data.since, data.until, data.json, data.csv, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
+
+var metricsRangeTests = []struct {
+	stream    bool
+	mins      int
+	since     string
+	until     string
+	expectErr bool
+}{
+	{false, 1, "2017-06-01", "2017-06-02", false},
+	{false, 60, "", "2017-06-02", false},
+	{true, 1, "2017-06-01", "", true},
+	{false, 60, "2017-06-01", "", true},
+	{false, 1, "2017-06-02", "2017-06-01", true},
+}
+
+func TestMetricsRange(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/metrics",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			if r.URL.Query().Get("since") == "" || r.URL.Query().Get("until") == "" {
+				t.Errorf("Expected a since and until time but got %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, fmt.Sprintf(`[{"serviceId":"%s","serviceLabel":"%s","metrics":{"disk.usage":[{"job":"j1","used":1024,"total":2048,"ts":1000}]}}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+
+	for _, data := range metricsRangeTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdMetrics("", Disk, false, true, false, false, data.stream, data.mins, data.since, data.until, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package mfa
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "mfa",
+	ShortHelp: "Manage the second factor of your account",
+	LongHelp: "The `mfa` command manages two-factor authentication for your user account with one-time passwords from an authenticator app. " +
+		"Once enabled, signing in prompts for a one-time password. " +
+		"Scripts can give it with the global `--mfa-code` option or the `DATICA_MFA_CODE` environment variable instead. " +
+		"The mfa command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(DisableSubCmd.Name, DisableSubCmd.ShortHelp, help.Render(DisableSubCmd.LongHelp), DisableSubCmd.CmdFunc(settings))
+			cmd.CommandLong(EnableSubCmd.Name, EnableSubCmd.ShortHelp, help.Render(EnableSubCmd.LongHelp), EnableSubCmd.CmdFunc(settings))
+			cmd.CommandLong(StatusSubCmd.Name, StatusSubCmd.ShortHelp, help.Render(StatusSubCmd.LongHelp), StatusSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var DisableSubCmd = models.Command{
+	Name:      "disable",
+	ShortHelp: "Turn off two-factor authentication",
+	LongHelp: "`mfa disable` turns off two-factor authentication for your user account so that signing in no longer asks for a one-time password. " +
+		"A current one-time password from your authenticator app is required, and is read from the global `--mfa-code` option if given. Here is a sample command\n\n" +
+		"```\ndatica mfa disable\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdDisable(settings.MFACode, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var EnableSubCmd = models.Command{
+	Name:      "enable",
+	ShortHelp: "Turn on two-factor authentication with an authenticator app",
+	LongHelp: "`mfa enable` adds an authenticator app, such as Google Authenticator or 1Password, as the second factor of your user account. " +
+		"The secret to add to your authenticator app is printed along with an `otpauth://` URI that most password managers can import. " +
+		"Two-factor authentication is turned on once you enter a one-time password from the app, so an interrupted setup leaves your account as it was. Here is a sample command\n\n" +
+		"```\ndatica mfa enable\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdEnable(New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var StatusSubCmd = models.Command{
+	Name:      "status",
+	ShortHelp: "Check whether two-factor authentication is on",
+	LongHelp: "`mfa status` prints whether two-factor authentication is turned on for your user account and which kind of one-time password signing in asks for. Here is a sample command\n\n" +
+		"```\ndatica mfa status\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdStatus(New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+// IMFA
+type IMFA interface {
+	Status() (*models.MFAStatus, error)
+	Enroll() (*models.MFAEnrollment, error)
+	Verify(enrollmentID, otp string) error
+	Disable(otp string) error
+}
+
+// SMFA is a concrete implementation of IMFA
+type SMFA struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IMFA
+func New(settings *models.Settings) IMFA {
+	return &SMFA{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,59 @@
+package mfa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdDisable(otp string, im IMFA, ip prompts.IPrompts) error {
+	status, err := im.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Enabled {
+		logrus.Println("Two-factor authentication is already off")
+		return nil
+	}
+	if err = ip.YesNo("Turning off two-factor authentication makes your account easier to break into. Are you sure you want to turn it off? (y/n) "); err != nil {
+		return err
+	}
+	if otp == "" {
+		if prompts.NonInteractive() {
+			return errors.New("Unable to prompt for a one-time password because the CLI is running non-interactively. Give it with --mfa-code instead")
+		}
+		if otp, err = ip.OTP(status.PreferredMode); err != nil {
+			return err
+		}
+	}
+	otp = strings.Replace(otp, " ", "", -1)
+	if otp == "" {
+		return errors.New("A one-time password is required to turn off two-factor authentication")
+	}
+	if err = im.Disable(otp); err != nil {
+		return err
+	}
+	logrus.Println("Two-factor authentication is off")
+	return nil
+}
+
+// Disable turns off two-factor authentication for the signed in user given a
+// current one-time password
+func (m *SMFA) Disable(otp string) error {
+	b, err := json.Marshal(struct {
+		OTP string `json:"otp"`
+	}{OTP: otp})
+	if err != nil {
+		return err
+	}
+	headers := m.Settings.HTTPManager.GetHeaders(m.Settings.SessionToken, m.Settings.Version, m.Settings.Pod, m.Settings.UsersID)
+	resp, statusCode, err := m.Settings.HTTPManager.Delete(b, fmt.Sprintf("%s%s/auth/mfa", m.Settings.AuthHost, m.Settings.AuthHostVersion), headers)
+	if err != nil {
+		return err
+	}
+	return m.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,81 @@
+package mfa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdEnable(im IMFA, ip prompts.IPrompts) error {
+	if prompts.NonInteractive() {
+		return errors.New("Two-factor authentication can only be turned on interactively since a one-time password from your new authenticator app has to be entered")
+	}
+	status, err := im.Status()
+	if err != nil {
+		return err
+	}
+	if status.Enabled && status.PreferredMode == auth.MFAModeAuthenticator {
+		return errors.New("Two-factor authentication with an authenticator app is already on. Run \"datica mfa disable\" first to replace your authenticator app.")
+	}
+	enrollment, err := im.Enroll()
+	if err != nil {
+		return err
+	}
+	logrus.Println("Add your Datica account to your authenticator app with this secret:")
+	logrus.Printf("\n    %s\n", enrollment.Secret)
+	if enrollment.URI != "" {
+		logrus.Printf("or import this URI into it:\n\n    %s\n", enrollment.URI)
+	}
+	otp, err := ip.OTP(auth.MFAModeAuthenticator)
+	if err != nil {
+		return err
+	}
+	otp = strings.Replace(otp, " ", "", -1)
+	if err = auth.CheckTOTP(otp); err != nil {
+		return err
+	}
+	if err = im.Verify(enrollment.ID, otp); err != nil {
+		return err
+	}
+	logrus.Println("Two-factor authentication is on. Signing in will now ask for a one-time password from your authenticator app.")
+	return nil
+}
+
+// Enroll starts adding an authenticator app as the second factor of the
+// signed in user
+func (m *SMFA) Enroll() (*models.MFAEnrollment, error) {
+	headers := m.Settings.HTTPManager.GetHeaders(m.Settings.SessionToken, m.Settings.Version, m.Settings.Pod, m.Settings.UsersID)
+	resp, statusCode, err := m.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/auth/mfa/authenticator", m.Settings.AuthHost, m.Settings.AuthHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	var enrollment models.MFAEnrollment
+	err = m.Settings.HTTPManager.ConvertResp(resp, statusCode, &enrollment)
+	if err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+// Verify finishes adding an authenticator app with a one-time password from
+// it, which turns on two-factor authentication
+func (m *SMFA) Verify(enrollmentID, otp string) error {
+	b, err := json.Marshal(struct {
+		OTP string `json:"otp"`
+	}{OTP: otp})
+	if err != nil {
+		return err
+	}
+	headers := m.Settings.HTTPManager.GetHeaders(m.Settings.SessionToken, m.Settings.Version, m.Settings.Pod, m.Settings.UsersID)
+	resp, statusCode, err := m.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/auth/mfa/authenticator/%s/verify", m.Settings.AuthHost, m.Settings.AuthHostVersion, enrollmentID), headers)
+	if err != nil {
+		return err
+	}
+	return m.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
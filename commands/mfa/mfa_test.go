@@ -0,0 +1,129 @@
+package mfa
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/test"
+)
+
+func TestStatus(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/auth/mfa",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"enabled":true,"preferredMode":"authenticator"}`)
+		},
+	)
+
+	if err := CmdStatus(New(settings)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+var enableTests = []struct {
+	enabled   string
+	mode      string
+	expectErr bool
+}{
+	{"false", "", false},
+	{"true", "email", false},
+	{"true", "authenticator", true},
+}
+
+func TestEnable(t *testing.T) {
+	for _, data := range enableTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		settings.AuthHost = baseURL.String()
+		verified := false
+		mux.HandleFunc("/auth/mfa",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `{"enabled":%s,"preferredMode":"%s"}`, data.enabled, data.mode)
+			},
+		)
+		mux.HandleFunc("/auth/mfa/authenticator",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				fmt.Fprint(w, `{"id":"enrollment1","secret":"JBSWY3DPEHPK3PXP","uri":"otpauth://totp/Datica:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Datica"}`)
+			},
+		)
+		mux.HandleFunc("/auth/mfa/authenticator/enrollment1/verify",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				var body struct {
+					OTP string `json:"otp"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				test.AssertEquals(t, "123456", body.OTP)
+				verified = true
+			},
+		)
+
+		// test
+		err := CmdEnable(New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if verified == data.expectErr {
+			t.Errorf("Expected the enrollment to be verified: %t", !data.expectErr)
+		}
+		test.Teardown(server)
+	}
+}
+
+var disableTests = []struct {
+	otp       string
+	expected  string
+	expectErr bool
+}{
+	{"", "123456", false},
+	{"654 321", "654321", false},
+}
+
+func TestDisable(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	var disabledWith string
+	mux.HandleFunc("/auth/mfa",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprint(w, `{"enabled":true,"preferredMode":"authenticator"}`)
+			case "DELETE":
+				var body struct {
+					OTP string `json:"otp"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				disabledWith = body.OTP
+			default:
+				t.Errorf("Unexpected method %s", r.Method)
+			}
+		},
+	)
+
+	for _, data := range disableTests {
+		t.Logf("Data: %+v", data)
+		disabledWith = ""
+
+		// test
+		err := CmdDisable(data.otp, New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, data.expected, disabledWith)
+	}
+}
@@ -0,0 +1,47 @@
+package mfa
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdStatus(im IMFA) error {
+	status, err := im.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Enabled {
+		logrus.Println("Two-factor authentication is off. Turn it on with \"datica mfa enable\".")
+		return nil
+	}
+	logrus.Printf("Two-factor authentication is on, using %s", modeDescription(status.PreferredMode))
+	return nil
+}
+
+func modeDescription(mode string) string {
+	switch mode {
+	case auth.MFAModeAuthenticator:
+		return "one-time passwords from an authenticator app"
+	case "email":
+		return "one-time passwords sent to your email"
+	}
+	return "one-time passwords"
+}
+
+// Status returns the state of the second factor of the signed in user
+func (m *SMFA) Status() (*models.MFAStatus, error) {
+	headers := m.Settings.HTTPManager.GetHeaders(m.Settings.SessionToken, m.Settings.Version, m.Settings.Pod, m.Settings.UsersID)
+	resp, statusCode, err := m.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/auth/mfa", m.Settings.AuthHost, m.Settings.AuthHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	var status models.MFAStatus
+	err = m.Settings.HTTPManager.ConvertResp(resp, statusCode, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
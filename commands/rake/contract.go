@@ -18,9 +18,10 @@ var Cmd = models.Command{
 	LongHelp: "`rake` executes a rake task by its name asynchronously. " +
 		"Once executed, the output of the task can be seen through your logging Dashboard. Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" rake code-1 db:migrate\n```",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			serviceName := cmd.StringArg("SERVICE_NAME", "", "The service that will run the rake task. Defaults to the associated service.")
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The service that will run the rake task. Defaults to the service pinned by the workspace file, or else the associated service.")
 			taskName := cmd.StringArg("TASK_NAME", "", "The name of the rake task to run")
 			cmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
@@ -29,7 +30,7 @@ var Cmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRake(*serviceName, *taskName, settings.ServiceID, New(settings), services.New(settings))
+				err := CmdRake(config.ServiceName(*serviceName, settings), *taskName, settings.ServiceID, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
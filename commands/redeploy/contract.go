@@ -4,6 +4,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/environments"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/sites"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
 	"github.com/daticahealth/cli/lib/jobs"
@@ -23,11 +24,25 @@ var Cmd = models.Command{
 		"All other service types cannot be redeployed with this command. " +
 		"For service proxy redeploys, there will be approximately 5 minutes of downtime. " +
 		"For code service redeploys, there will be approximately 30 seconds of downtime. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" redeploy app01\n```",
+		"Use `--strategy rolling` to replace the running jobs one at a time instead, so that a service with more than one job keeps serving during the redeploy. " +
+		"The default `--strategy recreate` stops every running job before the new ones start. " +
+		"The redeploy is started asynchronously and its job ID is printed. Use `--follow` to wait until the new deploy is running, or attach later with [jobs attach](#jobs-attach). `--detach` keeps the default and exits once the job ID is printed. " +
+		"Use `--wait` to wait at most the given duration, such as `5m`, for the new deploy to be running and exit with an error if it is not, so that CI can gate on a successful redeploy. " +
+		"With `--healthcheck-path`, the command also waits, within the same duration, until the path responds with a successful status on the first site that routes to the service. " +
+		"The path can be a full URL instead for services that are not reachable through a site. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" redeploy app01\n" +
+		"datica -E \"<your_env_alias>\" redeploy app01 --strategy rolling\n" +
+		"datica -E \"<your_env_alias>\" redeploy app01 --wait 5m --healthcheck-path /health\n```",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the service to redeploy (i.e. 'app01')")
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the service to redeploy (i.e. 'app01'). Defaults to the service pinned by the workspace file.")
+			strategy := cmd.StringOpt("strategy", "", "How the running jobs are replaced, either 'rolling' or 'recreate'. Defaults to 'recreate'")
+			cmd.BoolOpt("detach", false, "Exit once the redeploy job has been created instead of waiting for it to finish. This is the default")
+			follow := cmd.BoolOpt("follow", false, "Wait for the redeploy to finish before exiting")
+			wait := cmd.StringOpt("wait", "", "The maximum amount of time to wait for the redeploy to be running and healthy, i.e. '5m'. Fails if it is not")
+			healthCheckPath := cmd.StringOpt("healthcheck-path", "", "A path, such as /health, or a full URL that must respond successfully before the redeploy is complete. Requires --wait")
 			cmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -35,12 +50,16 @@ var Cmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRedeploy(settings.EnvironmentID, *serviceName, jobs.New(settings), services.New(settings), environments.New(settings))
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdRedeploy(settings.EnvironmentID, svcName, *strategy, *follow, *wait, *healthCheckPath, jobs.New(settings), services.New(settings), environments.New(settings), sites.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			cmd.Spec = "SERVICE_NAME"
+			cmd.Spec = "[SERVICE_NAME] [--strategy] [--detach | --follow | --wait [--healthcheck-path]]"
 		}
 	},
 }
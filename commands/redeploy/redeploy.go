@@ -2,14 +2,47 @@ package redeploy
 
 import (
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/environments"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/sites"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
 )
 
-func CmdRedeploy(envID, svcName string, ij jobs.IJobs, is services.IServices, ie environments.IEnvironments) error {
+// healthCheckInterval is the time to wait between requests to the health
+// check URL
+var healthCheckInterval = config.JobPollTime * time.Second
+
+// healthCheckClient gives up on a single health check request long before the
+// overall wait is over
+var healthCheckClient = &http.Client{Timeout: 10 * time.Second}
+
+type pollResult struct {
+	status string
+	err    error
+}
+
+func CmdRedeploy(envID, svcName, strategy string, follow bool, wait, healthCheckPath string, ij jobs.IJobs, is services.IServices, ie environments.IEnvironments, isites sites.ISites) error {
+	if err := jobs.ValidateStrategy(strategy); err != nil {
+		return err
+	}
+	var timeout time.Duration
+	if wait != "" {
+		d, err := time.ParseDuration(wait)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("Invalid wait \"%s\". The wait must be a positive duration such as 5m or 90s", wait)
+		}
+		timeout = d
+	} else if healthCheckPath != "" {
+		return fmt.Errorf("--healthcheck-path can only be given with --wait")
+	}
 	env, err := ie.Retrieve(envID)
 	if err != nil {
 		return err
@@ -21,11 +54,123 @@ func CmdRedeploy(envID, svcName string, ij jobs.IJobs, is services.IServices, ie
 	if service == nil {
 		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
 	}
-	logrus.Printf("Redeploying service %s (ID = %s) in environment %s (ID = %s)", svcName, service.ID, env.Name, env.ID)
-	err = ij.Redeploy(service.ID)
+	healthCheckURL := ""
+	if healthCheckPath != "" {
+		// the URL is found before redeploying so that a service without a
+		// site fails right away instead of after the redeploy
+		healthCheckURL, err = findHealthCheckURL(healthCheckPath, service, is, isites)
+		if err != nil {
+			return err
+		}
+	}
+	if strategy != "" {
+		logrus.Printf("Redeploying service %s (ID = %s) in environment %s (ID = %s) with the %s strategy", svcName, service.ID, env.Name, env.ID, strategy)
+	} else {
+		logrus.Printf("Redeploying service %s (ID = %s) in environment %s (ID = %s)", svcName, service.ID, env.Name, env.ID)
+	}
+	job, err := ij.Redeploy(service.ID, strategy)
+	if err != nil {
+		return err
+	}
+	if job.ID != "" {
+		logrus.Printf("Redeploy started (job ID = %s)", job.ID)
+	}
+	if timeout > 0 {
+		return waitHealthy(job, service, timeout, healthCheckURL, ij)
+	}
+	if !follow || job.ID == "" {
+		logrus.Println("Redeploy successful! Check the status with \"datica status\" and your logging dashboard for updates")
+		return nil
+	}
+	logrus.Println("Polling until the redeploy is running.")
+	status, err := ij.PollForStatus(jobs.TerminalStatuses(job.Type), job.ID, service.ID)
 	if err != nil {
 		return err
 	}
-	logrus.Println("Redeploy successful! Check the status with \"datica status\" and your logging dashboard for updates")
+	logrus.Printf("\nRedeploy complete (end status = '%s')", status)
 	return nil
 }
+
+// waitHealthy waits until the redeploy job is running and, if a health check
+// URL is given, until the URL responds successfully. An error is returned if
+// either does not happen before the timeout.
+func waitHealthy(job *models.Job, service *models.Service, timeout time.Duration, healthCheckURL string, ij jobs.IJobs) error {
+	deadline := time.Now().Add(timeout)
+	logrus.Printf("Waiting until %s for %s to become healthy", config.FormatTimestamp(deadline), service.Label)
+	if job.ID != "" {
+		done := make(chan pollResult, 1)
+		go func() {
+			status, err := ij.PollForStatus(jobs.TerminalStatuses(job.Type), job.ID, service.ID)
+			done <- pollResult{status, err}
+		}()
+		select {
+		case res := <-done:
+			if res.err != nil {
+				return res.err
+			}
+			logrus.Printf("\nRedeploy is %s", res.status)
+		case <-time.After(timeout):
+			return fmt.Errorf("\nTimed out after %s waiting for the redeploy of %s to be running. You can keep waiting with \"datica jobs attach %s %s\"", timeout, service.Label, service.Label, job.ID)
+		}
+	}
+	if healthCheckURL == "" {
+		logrus.Printf("Redeploy of %s complete", service.Label)
+		return nil
+	}
+	logrus.Printf("Checking %s", healthCheckURL)
+	lastErr := ""
+	for {
+		resp, err := healthCheckClient.Get(healthCheckURL)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+				logrus.Printf("\n%s is healthy (%s responded with %d)", service.Label, healthCheckURL, resp.StatusCode)
+				return nil
+			}
+			lastErr = fmt.Sprintf("responded with %d", resp.StatusCode)
+		} else {
+			lastErr = err.Error()
+		}
+		if time.Now().Add(healthCheckInterval).After(deadline) {
+			return fmt.Errorf("\n%s did not become healthy within %s, the last health check %s", service.Label, timeout, lastErr)
+		}
+		// all because logrus treats print, println, and printf the same
+		logrus.StandardLogger().Out.Write([]byte("."))
+		time.Sleep(healthCheckInterval)
+	}
+}
+
+// findHealthCheckURL returns the URL to check the health of the service at. A
+// full URL is used as is, while a path is requested from the first site of
+// the service proxy that routes to the service.
+func findHealthCheckURL(healthCheckPath string, service *models.Service, is services.IServices, isites sites.ISites) (string, error) {
+	if strings.HasPrefix(healthCheckPath, "http://") || strings.HasPrefix(healthCheckPath, "https://") {
+		return healthCheckPath, nil
+	}
+	if !strings.HasPrefix(healthCheckPath, "/") {
+		healthCheckPath = "/" + healthCheckPath
+	}
+	serviceProxy, err := is.RetrieveByLabel("service_proxy")
+	if err != nil {
+		return "", err
+	}
+	if serviceProxy == nil {
+		return "", fmt.Errorf("Could not find the service proxy to find a site for %s. Give --healthcheck-path as a full URL instead.", service.Label)
+	}
+	siteList, err := isites.List(serviceProxy.ID)
+	if err != nil {
+		return "", err
+	}
+	names := []string{}
+	for _, site := range *siteList {
+		// wildcard sites have no single hostname to check
+		if site.UpstreamService == service.ID && !strings.Contains(site.Name, "*") {
+			names = append(names, site.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("No site routes to %s, so there is no URL to check the health of it at. Give --healthcheck-path as a full URL instead.", service.Label)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("https://%s%s", names[0], healthCheckPath), nil
+}
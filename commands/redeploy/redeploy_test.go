@@ -0,0 +1,136 @@
+package redeploy
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/sites"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var redeployTests = []struct {
+	strategy        string
+	wait            string
+	healthCheckPath string
+	expectRedeploy  bool
+	expectErr       bool
+}{
+	{"", "", "", true, false},
+	{"rolling", "", "", true, false},
+	{"recreate", "1s", "", true, false},
+	{"blue-green", "", "", false, true},
+	{"", "1s", "", true, false},
+	{"", "1s", "BASE/health", true, false},
+	{"", "1s", "BASE/unhealthy", true, true},
+	{"", "", "/health", false, true},
+	{"", "soon", "", false, true},
+}
+
+func TestRedeploy(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	healthCheckInterval = 10 * time.Millisecond
+
+	redeployed := false
+	strategy := ""
+	mux.HandleFunc("/environments/"+test.EnvID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `{"id":"%s","name":"%s"}`, test.EnvID, test.EnvName)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","name":"code"},{"id":"proxy1","label":"service_proxy","name":"service_proxy"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/proxy1/sites",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":1,"name":"*.example.com","upstreamService":"%s"},{"id":2,"name":"app.example.com","upstreamService":"%s"}]`, test.SvcID, test.SvcID)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/deploy",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			test.AssertEquals(t, "true", r.URL.Query().Get("redeploy"))
+			redeployed = true
+			strategy = r.URL.Query().Get("strategy")
+			fmt.Fprint(w, `{"id":"job1","type":"deploy","status":"scheduled"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/job1",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"id":"job1","type":"deploy","status":"running"}`)
+		},
+	)
+	mux.HandleFunc("/health",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `ok`)
+		},
+	)
+	mux.HandleFunc("/unhealthy",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	)
+
+	for _, data := range redeployTests {
+		t.Logf("Data: %+v", data)
+		redeployed = false
+		strategy = ""
+		path := data.healthCheckPath
+		if strings.HasPrefix(path, "BASE") {
+			path = strings.Replace(path, "BASE", baseURL.String(), 1)
+		}
+
+		// test
+		err := CmdRedeploy(test.EnvID, test.SvcLabel, data.strategy, false, data.wait, path, jobs.New(settings), services.New(settings), environments.New(settings), sites.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if redeployed != data.expectRedeploy {
+			t.Errorf("Expected redeploy to be %t but was %t", data.expectRedeploy, redeployed)
+		}
+		if redeployed && strategy != data.strategy {
+			t.Errorf("Expected strategy \"%s\" but got \"%s\"", data.strategy, strategy)
+		}
+	}
+}
+
+func TestFindHealthCheckURL(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","name":"code"},{"id":"proxy1","label":"service_proxy","name":"service_proxy"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/proxy1/sites",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `[{"id":1,"name":"*.example.com","upstreamService":"%s"},{"id":2,"name":"www.example.com","upstreamService":"%s"},{"id":3,"name":"app.example.com","upstreamService":"%s"},{"id":4,"name":"other.example.com","upstreamService":"other"}]`, test.SvcID, test.SvcID, test.SvcID)
+		},
+	)
+	is := services.New(settings)
+	service, err := is.RetrieveByLabel(test.SvcLabel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, err := findHealthCheckURL("health", service, is, sites.New(settings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEquals(t, "https://app.example.com/health", url)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -22,11 +23,13 @@ var Cmd = models.Command{
 		"Please contact Support if you require more than the last three releases to be retained. " +
 		"You can rollback to a specific release by using the [rollback](#rollback) command. " +
 		"The releases command cannot be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, RmSubCmd.LongHelp, RmSubCmd.CmdFunc(settings))
-			cmd.CommandLong(UpdateSubCmd.Name, UpdateSubCmd.ShortHelp, UpdateSubCmd.LongHelp, UpdateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, help.Render(ShowSubCmd.LongHelp), ShowSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(UpdateSubCmd.Name, UpdateSubCmd.ShortHelp, help.Render(UpdateSubCmd.LongHelp), UpdateSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -36,6 +39,8 @@ var ListSubCmd = models.Command{
 	ShortHelp: "List all releases for a given code service",
 	LongHelp: "`releases list` lists all of the releases for a given service. " +
 		"A release is automatically created each time a git push is performed. " +
+		"Each release shows the git SHA it was built from, who deployed it, when it was created, and the status of its deploy. " +
+		"The release that is currently running is marked with a `*`. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" releases list code-1\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
@@ -57,6 +62,34 @@ var ListSubCmd = models.Command{
 	},
 }
 
+var ShowSubCmd = models.Command{
+	Name:      "show",
+	ShortHelp: "Show the details of a release for a given code service",
+	LongHelp: "`releases show` prints the details of a single release including the git SHA it was built from, who deployed it, when it was created, the status of its deploy, its notes, and whether or not it is the release currently running. " +
+		"If no release is given, the release currently running is shown. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" releases show code-1\n" +
+		"datica -E \"<your_env_alias>\" releases show code-1 f93ced037f828dcaabccfc825e6d8d32cc5a1883\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the service to show a release for")
+			releaseName := cmd.StringArg("RELEASE_NAME", "", "The name of the release to show. Defaults to the release currently running")
+			cmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdShow(*serviceName, *releaseName, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err)
+				}
+			}
+			cmd.Spec = "SERVICE_NAME [RELEASE_NAME]"
+		}
+	},
+}
+
 var RmSubCmd = models.Command{
 	Name:      "rm",
 	ShortHelp: "Remove a release from a code service",
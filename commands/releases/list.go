@@ -3,10 +3,10 @@ package releases
 import (
 	"fmt"
 	"sort"
-	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/models"
 	"github.com/olekukonko/tablewriter"
 )
@@ -46,15 +46,13 @@ func CmdList(svcName string, ir IReleases, is services.IServices) error {
 	}
 
 	sort.Sort(SortedReleases(*rls))
-	const dateForm = "2006-01-02T15:04:05"
-	data := [][]string{{"Release Name", "Created At", "Notes"}}
+	data := [][]string{{"Release Name", "Git SHA", "Deployed By", "Created At", "Status", "Notes"}}
 	for _, r := range *rls {
 		name := r.Name
 		if r.Name == service.ReleaseVersion {
 			name = fmt.Sprintf("*%s", r.Name)
 		}
-		t, _ := time.Parse(dateForm, r.CreatedAt)
-		data = append(data, []string{name, t.Local().Format(time.Stamp), r.Notes})
+		data = append(data, []string{name, shortSHA(r.GitSHA), r.DeployedBy, config.FormatTimestampString(r.CreatedAt), r.Status, r.Notes})
 	}
 
 	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
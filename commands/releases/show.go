@@ -0,0 +1,63 @@
+package releases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+)
+
+func CmdShow(svcName, releaseName string, ir IReleases, is services.IServices) error {
+	if strings.ContainsAny(releaseName, config.InvalidChars) {
+		return fmt.Errorf("Invalid release name. Names must not contain the following characters: %s", config.InvalidChars)
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if releaseName == "" {
+		if service.ReleaseVersion == "" {
+			return fmt.Errorf("No release is currently running for the service \"%s\"", svcName)
+		}
+		releaseName = service.ReleaseVersion
+	}
+	r, err := ir.Retrieve(releaseName, service.ID)
+	if err != nil {
+		return err
+	}
+	if r.Name == "" {
+		r.Name = releaseName
+	}
+	running := "no"
+	if r.Name == service.ReleaseVersion {
+		running = "yes"
+	}
+	logrus.Printf("Release:     %s", r.Name)
+	logrus.Printf("Git SHA:     %s", valueOrNone(r.GitSHA))
+	logrus.Printf("Deployed By: %s", valueOrNone(r.DeployedBy))
+	logrus.Printf("Created At:  %s", valueOrNone(config.FormatTimestampString(r.CreatedAt)))
+	logrus.Printf("Status:      %s", valueOrNone(r.Status))
+	logrus.Printf("Running:     %s", running)
+	logrus.Printf("Notes:       %s", valueOrNone(r.Notes))
+	return nil
+}
+
+// shortSHA abbreviates a git SHA the same way git does by default
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
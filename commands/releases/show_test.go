@@ -0,0 +1,63 @@
+package releases
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+const releaseName = "f93ced037f828dcaabccfc825e6d8d32cc5a1883"
+
+var releasesShowTests = []struct {
+	svcName     string
+	releaseName string
+	expectErr   bool
+}{
+	{test.SvcLabel, releaseName, false},
+	{test.SvcLabel, "", false},
+	{test.SvcLabelAlt, "", true},
+	{test.SvcLabel, "invalid/name", true},
+	{test.SvcLabel, "missing", true},
+	{"invalid-svc", releaseName, true},
+}
+
+func TestReleasesShow(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","release_version":"%s"},{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel, releaseName, test.SvcIDAlt, test.SvcLabelAlt))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/releases/"+releaseName,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`{"release":"%s","created_at":"2017-03-01T12:00:00","git_sha":"%s","deployed_by":"user@example.com","status":"running"}`, releaseName, releaseName))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/releases/missing",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"title":"Not Found","description":"Release not found","code":404}`)
+		},
+	)
+
+	for _, data := range releasesShowTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdShow(data.svcName, data.releaseName, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package reports
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "reports",
+	ShortHelp: "Manage recurring reports for an environment",
+	LongHelp: "The `reports` command allows you to manage the reports generated for your environment. " +
+		"The reports command can not be run directly but has sub commands.",
+	Category: models.CategoryObservability,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ScheduleSubCmd.Name, ScheduleSubCmd.ShortHelp, help.Render(ScheduleSubCmd.LongHelp), ScheduleSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ScheduleSubCmd = models.Command{
+	Name:      "schedule",
+	ShortHelp: "Create, list, and remove scheduled report deliveries",
+	LongHelp: "`reports schedule` manages reports that are generated and emailed on a recurring schedule. " +
+		"Scheduled reports are produced and delivered by Datica, so no one needs to run the CLI for them to be sent. " +
+		"The schedule command can not be run directly but has sub commands.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ScheduleCreateSubCmd.Name, ScheduleCreateSubCmd.ShortHelp, help.Render(ScheduleCreateSubCmd.LongHelp), ScheduleCreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ScheduleListSubCmd.Name, ScheduleListSubCmd.ShortHelp, help.Render(ScheduleListSubCmd.LongHelp), ScheduleListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ScheduleRmSubCmd.Name, ScheduleRmSubCmd.ShortHelp, help.Render(ScheduleRmSubCmd.LongHelp), ScheduleRmSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ScheduleCreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Schedule a recurring report",
+	LongHelp: "`reports schedule create` schedules a report to be generated and emailed on a recurring basis. " +
+		"The type of report is one of `usage`, `compliance`, or `backups`. " +
+		"The schedule is given as a standard five field cron expression (minute, hour, day of month, month, day of week) in UTC. " +
+		"Specify `--email` once for each recipient. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" reports schedule create --type usage --cron \"0 8 * * MON\" --email ops@example.com\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			reportType := subCmd.StringOpt("t type", "", "The type of report to deliver (usage, compliance, or backups)")
+			cron := subCmd.StringOpt("c cron", "", "The cron expression describing when to deliver the report (i.e. '0 8 * * MON')")
+			emails := subCmd.StringsOpt("e email", []string{}, "An email address to deliver the report to. Specify this option once per recipient")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdScheduleCreate(*reportType, *cron, *emails, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "--type --cron --email..."
+		}
+	},
+}
+
+var ScheduleListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List scheduled reports",
+	LongHelp: "`reports schedule list` lists every report scheduled for your environment along with when it is delivered and to whom. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" reports schedule list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdScheduleList(New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var ScheduleRmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove a scheduled report",
+	LongHelp: "`reports schedule rm` stops delivering a scheduled report. " +
+		"Schedule IDs can be found with the [reports schedule list](#reports-schedule-list) command. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" reports schedule rm 5a8b0f1c\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			scheduleID := subCmd.StringArg("SCHEDULE_ID", "", "The ID of the scheduled report to remove")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdScheduleRm(*scheduleID, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SCHEDULE_ID"
+		}
+	},
+}
+
+// IReports
+type IReports interface {
+	CreateSchedule(schedule *models.ReportSchedule) (*models.ReportSchedule, error)
+	ListSchedules() (*[]models.ReportSchedule, error)
+	RemoveSchedule(scheduleID string) error
+}
+
+// SReports is a concrete implementation of IReports
+type SReports struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IReports
+func New(settings *models.Settings) IReports {
+	return &SReports{
+		Settings: settings,
+	}
+}
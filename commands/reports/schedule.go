@@ -0,0 +1,128 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	libcron "github.com/daticahealth/cli/lib/cron"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+var reportTypes = []string{"usage", "compliance", "backups"}
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+func CmdScheduleCreate(reportType, cron string, emails []string, ir IReports) error {
+	reportType = strings.ToLower(reportType)
+	valid := false
+	for _, t := range reportTypes {
+		if t == reportType {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("Invalid report type \"%s\". Please specify one of %s.", reportType, strings.Join(reportTypes, ", "))
+	}
+	if _, err := libcron.Parse(cron); err != nil {
+		return err
+	}
+	if len(emails) == 0 {
+		return fmt.Errorf("At least one email address must be given with --email")
+	}
+	for _, email := range emails {
+		if !emailRegex.MatchString(email) {
+			return fmt.Errorf("Invalid email address \"%s\"", email)
+		}
+	}
+	schedule, err := ir.CreateSchedule(&models.ReportSchedule{
+		Type:   reportType,
+		Cron:   cron,
+		Emails: emails,
+	})
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Scheduled the %s report (ID = %s) to be delivered to %s on \"%s\"", schedule.Type, schedule.ID, strings.Join(schedule.Emails, ", "), schedule.Cron)
+	return nil
+}
+
+func CmdScheduleList(ir IReports) error {
+	schedules, err := ir.ListSchedules()
+	if err != nil {
+		return err
+	}
+	if schedules == nil || len(*schedules) == 0 {
+		logrus.Println("No reports have been scheduled for this environment.")
+		return nil
+	}
+	data := [][]string{{"ID", "TYPE", "CRON", "RECIPIENTS"}}
+	for _, s := range *schedules {
+		data = append(data, []string{s.ID, s.Type, s.Cron, strings.Join(s.Emails, ", ")})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+func CmdScheduleRm(scheduleID string, ir IReports) error {
+	err := ir.RemoveSchedule(scheduleID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Scheduled report %s removed", scheduleID)
+	return nil
+}
+
+func (r *SReports) CreateSchedule(schedule *models.ReportSchedule) (*models.ReportSchedule, error) {
+	b, err := json.Marshal(schedule)
+	if err != nil {
+		return nil, err
+	}
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/reports/schedules", r.Settings.PaasHost, r.Settings.PaasHostVersion, r.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var created models.ReportSchedule
+	err = r.Settings.HTTPManager.ConvertResp(resp, statusCode, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *SReports) ListSchedules() (*[]models.ReportSchedule, error) {
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/reports/schedules", r.Settings.PaasHost, r.Settings.PaasHostVersion, r.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var schedules []models.ReportSchedule
+	err = r.Settings.HTTPManager.ConvertResp(resp, statusCode, &schedules)
+	if err != nil {
+		return nil, err
+	}
+	return &schedules, nil
+}
+
+func (r *SReports) RemoveSchedule(scheduleID string) error {
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/reports/schedules/%s", r.Settings.PaasHost, r.Settings.PaasHostVersion, r.Settings.EnvironmentID, scheduleID), headers)
+	if err != nil {
+		return err
+	}
+	return r.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
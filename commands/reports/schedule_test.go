@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var scheduleCreateTests = []struct {
+	reportType string
+	cron       string
+	emails     []string
+	expectErr  bool
+}{
+	{"usage", "0 8 * * MON", []string{"ops@example.com"}, false},
+	{"Backups", "*/15 0-6 1,15 * *", []string{"ops@example.com", "dev@example.com"}, false},
+	{"invoices", "0 8 * * MON", []string{"ops@example.com"}, true},
+	{"usage", "0 8 * *", []string{"ops@example.com"}, true},
+	{"usage", "0 8 * * MON;", []string{"ops@example.com"}, true},
+	{"usage", "0 8 * * MON", []string{}, true},
+	{"usage", "0 8 * * MON", []string{"not-an-email"}, true},
+}
+
+func TestScheduleCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/reports/schedules",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			var schedule models.ReportSchedule
+			json.Unmarshal(b, &schedule)
+			schedule.ID = "1"
+			b, _ = json.Marshal(schedule)
+			fmt.Fprint(w, string(b))
+		},
+	)
+
+	for _, data := range scheduleCreateTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdScheduleCreate(data.reportType, data.cron, data.emails, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+	}
+}
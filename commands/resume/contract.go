@@ -0,0 +1,56 @@
+package resume
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/journal"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "resume",
+	ShortHelp: "Resume an interrupted multi-step operation",
+	LongHelp: "`resume` continues a multi-step operation, such as sending a batch of invites with [invites send](#invites-send), that was interrupted before it finished. " +
+		"Steps that already completed are skipped. " +
+		"The operation is resumed against the environment it was originally run in, regardless of the `-E` flag. " +
+		"Run `resume` without an operation ID to list all interrupted operations. Here are some sample commands\n\n" +
+		"```\ndatica resume\n" +
+		"datica resume 5f2b9a1c7e3d4b60\n```",
+	Category: models.CategoryData,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			opID := cmd.StringArg("OPERATION_ID", "", "The ID of the interrupted operation to resume")
+			cmd.Action = func() {
+				if *opID == "" {
+					err := CmdList(journal.New(settings))
+					if err != nil {
+						logrus.Fatal(err.Error())
+					}
+					return
+				}
+				ij := journal.New(settings)
+				op, err := ij.Retrieve(*opID)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				settings.EnvironmentID = op.EnvironmentID
+				settings.EnvironmentName = op.EnvironmentName
+				settings.ServiceID = op.ServiceID
+				settings.Pod = op.Pod
+				settings.OrgID = op.OrgID
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdResume(op, settings, ij)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[OPERATION_ID]"
+		}
+	},
+}
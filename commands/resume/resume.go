@@ -0,0 +1,49 @@
+package resume
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/journal"
+	"github.com/daticahealth/cli/models"
+)
+
+// CmdResume dispatches an interrupted operation back to the command that
+// started it.
+func CmdResume(op *models.Operation, settings *models.Settings, ij journal.IJournal) error {
+	logrus.Printf("Resuming \"%s\" in environment %s (%d of %d steps completed)", op.Command, op.EnvironmentName, completed(op), len(op.Steps))
+	switch op.Command {
+	case invites.SendOperation:
+		return invites.CmdResumeSend(op, invites.New(settings), ij)
+	default:
+		return fmt.Errorf("The \"%s\" operation cannot be resumed by this version of the CLI", op.Command)
+	}
+}
+
+// CmdList prints all operations that were interrupted before finishing.
+func CmdList(ij journal.IJournal) error {
+	ops, err := ij.List()
+	if err != nil {
+		return err
+	}
+	if len(*ops) == 0 {
+		logrus.Println("No interrupted operations found")
+		return nil
+	}
+	for _, op := range *ops {
+		logrus.Printf("%s %s \"%s\" in %s (%d of %d steps completed)", op.ID, config.FormatTimestampString(op.CreatedAt), op.Command, op.EnvironmentName, completed(&op), len(op.Steps))
+	}
+	return nil
+}
+
+func completed(op *models.Operation) int {
+	done := 0
+	for _, s := range op.Steps {
+		if s.Done {
+			done++
+		}
+	}
+	return done
+}
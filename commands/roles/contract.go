@@ -0,0 +1,222 @@
+package roles
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "roles",
+	ShortHelp: "Manage the roles of users in the given organization",
+	LongHelp: "The `roles` command allows you to see the roles defined by your environment's organization and change which role each user has. " +
+		"A user's role determines what they can access in the organization's environments. " +
+		"Besides the built-in roles, you can define custom roles with only the permissions they need, such as a role that can only deploy or only read logs. " +
+		"The permissions a custom role can be granted are " + permissionList + ". " +
+		"Custom roles can be given to new users with [invites send](#invites-send) and to existing users with [users update](#users-update) or [roles grant](#roles-grant). " +
+		"The roles command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(EditSubCmd.Name, EditSubCmd.ShortHelp, help.Render(EditSubCmd.LongHelp), EditSubCmd.CmdFunc(settings))
+			cmd.CommandLong(GrantSubCmd.Name, GrantSubCmd.ShortHelp, help.Render(GrantSubCmd.LongHelp), GrantSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RevokeSubCmd.Name, RevokeSubCmd.ShortHelp, help.Render(RevokeSubCmd.LongHelp), RevokeSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Create a custom role",
+	LongHelp: "`roles create` defines a new role in your environment's organization with the permissions given by `--permission`, which can be given more than once. " +
+		"Start from a common set of permissions with `--preset`, which is one of " + presetList + ", and add to it with `--permission`. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" roles create deployer --preset deploy-only\n" +
+		"datica -E \"<your_env_alias>\" roles create on-call --permission logs --permission metrics --permission console\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the new role")
+			preset := subCmd.StringOpt("preset", "", "A common set of permissions to start from")
+			permissions := subCmd.StringsOpt("p permission", []string{}, "A permission to grant. Can be given more than once")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCreate(*name, *preset, *permissions, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME [--preset] [--permission...]"
+		}
+	},
+}
+
+var EditSubCmd = models.Command{
+	Name:      "edit",
+	ShortHelp: "Change the permissions or name of a custom role",
+	LongHelp: "`roles edit` grants the permissions given by `--add` to a custom role and revokes the permissions given by `--rm` from it, and renames it if `--name` is given. " +
+		"The changes apply to every user with the role. Built-in roles can't be edited. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" roles edit deployer --add logs\n" +
+		"datica -E \"<your_env_alias>\" roles edit on-call --rm console --name observer\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the role to edit")
+			newName := subCmd.StringOpt("name", "", "A new name for the role")
+			add := subCmd.StringsOpt("add", []string{}, "A permission to grant. Can be given more than once")
+			rm := subCmd.StringsOpt("rm", []string{}, "A permission to revoke. Can be given more than once")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdEdit(*name, *newName, *add, *rm, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME [--name] [--add...] [--rm...]"
+		}
+	},
+}
+
+var GrantSubCmd = models.Command{
+	Name:      "grant",
+	ShortHelp: "Grant a role to a user",
+	LongHelp: "`roles grant` grants the given role to a user of your environment's organization. " +
+		"The user is found by their email and the role by its name as shown by [roles list](#roles-list). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" roles grant user@example.com admin\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			email := subCmd.StringArg("EMAIL", "", "The email address of the user to grant the role to")
+			role := subCmd.StringArg("ROLE", "", "The name of the role to grant")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdGrant(*email, *role, New(settings), users.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "EMAIL ROLE"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the roles defined by the given organization",
+	LongHelp: "`roles list` lists every role defined by your environment's organization along with the number of users who have it and the permissions of each custom role. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" roles list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(New(settings), users.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RevokeSubCmd = models.Command{
+	Name:      "revoke",
+	ShortHelp: "Revoke a role from a user",
+	LongHelp: "`roles revoke` revokes the given role from a user of your environment's organization. " +
+		"The user remains a member of the organization. " +
+		"To remove the user from the organization entirely, use [users rm](#users-rm). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" roles revoke user@example.com admin\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			email := subCmd.StringArg("EMAIL", "", "The email address of the user to revoke the role from")
+			role := subCmd.StringArg("ROLE", "", "The name of the role to revoke")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRevoke(*email, *role, New(settings), users.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "EMAIL ROLE"
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove a custom role",
+	LongHelp: "`roles rm` removes a custom role from your environment's organization. " +
+		"Built-in roles and roles that users still have can't be removed, grant those users another role first with [roles grant](#roles-grant). " +
+		"You will be asked to confirm before the role is removed, use the global `--yes` flag to skip the confirmation. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" roles rm deployer\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the role to remove")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRm(*name, New(settings), users.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+// IRoles
+type IRoles interface {
+	List() (*[]models.Role, error)
+	Grant(usersID string, roleID int) error
+	Revoke(usersID string, roleID int) error
+	Create(role *models.Role) (*models.Role, error)
+	Update(roleID int, role *models.Role) (*models.Role, error)
+	Rm(roleID int) error
+}
+
+// SRoles is a concrete implementation of IRoles
+type SRoles struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IRoles
+func New(settings *models.Settings) IRoles {
+	return &SRoles{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,60 @@
+package roles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdCreate(name, preset string, permissions []string, ir IRoles) error {
+	if name == "" {
+		return errors.New("The name of the role cannot be empty")
+	}
+	if preset != "" {
+		presetPermissions, ok := Presets[preset]
+		if !ok {
+			return fmt.Errorf("\"%s\" is not a preset. Valid presets are: %s", preset, strings.Join(presetNames, ", "))
+		}
+		permissions = union(presetPermissions, permissions)
+	} else {
+		permissions = union(permissions)
+	}
+	if len(permissions) == 0 {
+		return errors.New("A role needs at least one permission. Give one with --permission or --preset")
+	}
+	if err := checkPermissions(permissions); err != nil {
+		return err
+	}
+	if _, err := findRole(name, ir); err == nil {
+		return fmt.Errorf("A role named \"%s\" already exists. Change it with \"datica roles edit %s\"", name, name)
+	}
+	role, err := ir.Create(&models.Role{Name: name, Permissions: permissions})
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Created the %s role with the permissions %s", role.Name, strings.Join(role.Permissions, ", "))
+	return nil
+}
+
+// Create defines a new custom role
+func (r *SRoles) Create(role *models.Role) (*models.Role, error) {
+	b, err := json.Marshal(role)
+	if err != nil {
+		return nil, err
+	}
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/orgs/%s/roles", r.Settings.AuthHost, r.Settings.AuthHostVersion, r.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var created models.Role
+	err = r.Settings.HTTPManager.ConvertResp(resp, statusCode, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
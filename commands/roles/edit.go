@@ -0,0 +1,69 @@
+package roles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdEdit(name, newName string, add, rm []string, ir IRoles) error {
+	if newName == "" && len(add) == 0 && len(rm) == 0 {
+		return errors.New("No changes were given. Give permissions to grant with --add, permissions to revoke with --rm, or a new name with --name")
+	}
+	if err := checkPermissions(union(add, rm)); err != nil {
+		return err
+	}
+	role, err := findRole(name, ir)
+	if err != nil {
+		return err
+	}
+	if !role.Custom {
+		return fmt.Errorf("%s is a built-in role and can't be edited. Create a custom role with \"datica roles create\" instead", role.Name)
+	}
+	revoked := map[string]bool{}
+	for _, p := range rm {
+		revoked[p] = true
+	}
+	permissions := []string{}
+	for _, p := range union(role.Permissions, add) {
+		if !revoked[p] {
+			permissions = append(permissions, p)
+		}
+	}
+	if len(permissions) == 0 {
+		return fmt.Errorf("A role needs at least one permission. Remove the %s role with \"datica roles rm %s\" instead", role.Name, role.Name)
+	}
+	updated := &models.Role{Name: role.Name, Permissions: permissions}
+	if newName != "" {
+		updated.Name = newName
+	}
+	updated, err = ir.Update(role.ID, updated)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("The %s role now has the permissions %s", updated.Name, strings.Join(updated.Permissions, ", "))
+	return nil
+}
+
+// Update replaces the name and permissions of the custom role with the given ID
+func (r *SRoles) Update(roleID int, role *models.Role) (*models.Role, error) {
+	b, err := json.Marshal(role)
+	if err != nil {
+		return nil, err
+	}
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Put(b, fmt.Sprintf("%s%s/orgs/%s/roles/%d", r.Settings.AuthHost, r.Settings.AuthHostVersion, r.Settings.OrgID, roleID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var updated models.Role
+	err = r.Settings.HTTPManager.ConvertResp(resp, statusCode, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,127 @@
+package roles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/daticahealth/cli/models"
+)
+
+// Permissions are the permissions a custom role can be granted
+var Permissions = []string{"billing", "certs", "console", "deploy", "environments", "logs", "metrics", "services", "users", "vars"}
+
+// Presets are common sets of permissions a custom role can start from
+var Presets = map[string][]string{
+	"billing-only": {"billing"},
+	"deploy-only":  {"deploy"},
+	"logs-only":    {"logs"},
+}
+
+// CommandPermissions maps the name of a top level command to the permission a
+// role needs to run it. Commands that are not listed only work on the local
+// machine or are open to every role.
+var CommandPermissions = map[string]string{
+	"access":           "users",
+	"admin":            "users",
+	"alerts":           "environments",
+	"audit":            "users",
+	"builds":           "deploy",
+	"certs":            "certs",
+	"console":          "console",
+	"cron":             "deploy",
+	"db":               "services",
+	"deploy":           "deploy",
+	"deploy-keys":      "deploy",
+	"dr":               "services",
+	"environments":     "environments",
+	"es":               "services",
+	"files":            "services",
+	"flags":            "environments",
+	"git-remote":       "deploy",
+	"invites":          "users",
+	"jobs":             "services",
+	"logs":             "logs",
+	"maintenance":      "services",
+	"metrics":          "metrics",
+	"rake":             "console",
+	"redeploy":         "deploy",
+	"releases":         "deploy",
+	"reports":          "environments",
+	"roles":            "users",
+	"rollback":         "deploy",
+	"run":              "console",
+	"service-accounts": "users",
+	"services":         "services",
+	"sites":            "certs",
+	"ssl":              "certs",
+	"users":            "users",
+	"vars":             "vars",
+	"volumes":          "services",
+	"worker":           "deploy",
+}
+
+// RolePermissions returns the permissions of the role. Built-in roles do not
+// list their permissions and are treated as having every permission.
+func RolePermissions(role *models.Role) []string {
+	if !role.Custom && len(role.Permissions) == 0 {
+		return Permissions
+	}
+	return role.Permissions
+}
+
+var permissionList = "`" + strings.Join(Permissions, "`, `") + "`"
+
+var presetNames = []string{"billing-only", "deploy-only", "logs-only"}
+
+var presetList = "`" + strings.Join(presetNames, "`, `") + "`"
+
+// findRole looks up the role with the given name, ignoring case
+func findRole(name string, ir IRoles) (*models.Role, error) {
+	roles, err := ir.List()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for i, r := range *roles {
+		if strings.EqualFold(r.Name, name) {
+			return &(*roles)[i], nil
+		}
+		names = append(names, r.Name)
+	}
+	return nil, fmt.Errorf("\"%s\" is not a role in your organization. Valid roles are: %s", name, strings.Join(names, ", "))
+}
+
+// checkPermissions returns an error naming the first of the given
+// permissions that a role can't be granted
+func checkPermissions(permissions []string) error {
+	for _, p := range permissions {
+		valid := false
+		for _, known := range Permissions {
+			if p == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("\"%s\" is not a permission. Valid permissions are: %s", p, strings.Join(Permissions, ", "))
+		}
+	}
+	return nil
+}
+
+// union returns the sorted permissions found in any of the given lists
+func union(lists ...[]string) []string {
+	set := map[string]bool{}
+	for _, list := range lists {
+		for _, p := range list {
+			set[p] = true
+		}
+	}
+	permissions := []string{}
+	for p := range set {
+		permissions = append(permissions, p)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
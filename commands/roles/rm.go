@@ -0,0 +1,50 @@
+package roles
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdRm(name string, ir IRoles, iu users.IUsers, ip prompts.IPrompts) error {
+	role, err := findRole(name, ir)
+	if err != nil {
+		return err
+	}
+	if !role.Custom {
+		return fmt.Errorf("%s is a built-in role and can't be removed", role.Name)
+	}
+	orgUsers, err := iu.List()
+	if err != nil {
+		return err
+	}
+	count := 0
+	for _, u := range *orgUsers {
+		if u.RoleID == role.ID {
+			count++
+		}
+	}
+	if count > 0 {
+		return fmt.Errorf("%d user(s) still have the %s role. Grant them another role with \"datica roles grant\" before removing it", count, role.Name)
+	}
+	if err = ip.YesNo(fmt.Sprintf("Are you sure you want to remove the %s role? (y/n) ", role.Name)); err != nil {
+		return err
+	}
+	if err = ir.Rm(role.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Removed the %s role", role.Name)
+	return nil
+}
+
+// Rm removes the custom role with the given ID
+func (r *SRoles) Rm(roleID int) error {
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/roles/%d", r.Settings.AuthHost, r.Settings.AuthHostVersion, r.Settings.OrgID, roleID), headers)
+	if err != nil {
+		return err
+	}
+	return r.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,139 @@
+package roles
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdList(ir IRoles, iu users.IUsers) error {
+	roles, err := ir.List()
+	if err != nil {
+		return err
+	}
+	if roles == nil || len(*roles) == 0 {
+		logrus.Println("No roles found")
+		return nil
+	}
+	orgUsers, err := iu.List()
+	if err != nil {
+		return err
+	}
+	counts := map[int]int{}
+	for _, u := range *orgUsers {
+		counts[u.RoleID]++
+	}
+	data := [][]string{{"NAME", "USERS", "TYPE", "PERMISSIONS"}}
+	for _, r := range *roles {
+		roleType := "built-in"
+		permissions := "-"
+		if r.Custom {
+			roleType = "custom"
+			permissions = strings.Join(r.Permissions, ", ")
+		}
+		data = append(data, []string{r.Name, strconv.Itoa(counts[r.ID]), roleType, permissions})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+func CmdGrant(email, roleName string, ir IRoles, iu users.IUsers) error {
+	user, role, err := lookup(email, roleName, ir, iu)
+	if err != nil {
+		return err
+	}
+	if user.RoleID == role.ID {
+		logrus.Printf("%s already has the %s role", email, role.Name)
+		return nil
+	}
+	if err = ir.Grant(user.ID, role.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Granted the %s role to %s", role.Name, email)
+	return nil
+}
+
+func CmdRevoke(email, roleName string, ir IRoles, iu users.IUsers) error {
+	user, role, err := lookup(email, roleName, ir, iu)
+	if err != nil {
+		return err
+	}
+	if user.RoleID != role.ID {
+		return fmt.Errorf("%s does not have the %s role", email, role.Name)
+	}
+	if err = ir.Revoke(user.ID, role.ID); err != nil {
+		return err
+	}
+	logrus.Printf("Revoked the %s role from %s", role.Name, email)
+	return nil
+}
+
+// lookup finds the organization user with the given email and the role with
+// the given name, ignoring case
+func lookup(email, roleName string, ir IRoles, iu users.IUsers) (*models.OrgUser, *models.Role, error) {
+	orgUsers, err := iu.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	var user *models.OrgUser
+	for i := range *orgUsers {
+		if strings.EqualFold((*orgUsers)[i].Email, email) {
+			user = &(*orgUsers)[i]
+			break
+		}
+	}
+	if user == nil {
+		return nil, nil, fmt.Errorf("A user with email %s was not found", email)
+	}
+	role, err := findRole(roleName, ir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, role, nil
+}
+
+// List lists the roles defined by the organization of the associated
+// environment
+func (r *SRoles) List() (*[]models.Role, error) {
+	return invites.New(r.Settings).ListRoles()
+}
+
+// Grant gives the user the role with the given ID
+func (r *SRoles) Grant(usersID string, roleID int) error {
+	b, err := json.Marshal(map[string]int{"roleID": roleID})
+	if err != nil {
+		return err
+	}
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/orgs/%s/users/%s/roles", r.Settings.AuthHost, r.Settings.AuthHostVersion, r.Settings.OrgID, usersID), headers)
+	if err != nil {
+		return err
+	}
+	return r.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
+
+// Revoke takes the role with the given ID away from the user
+func (r *SRoles) Revoke(usersID string, roleID int) error {
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/users/%s/roles/%d", r.Settings.AuthHost, r.Settings.AuthHostVersion, r.Settings.OrgID, usersID, roleID), headers)
+	if err != nil {
+		return err
+	}
+	return r.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,319 @@
+package roles
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/users"
+	"github.com/daticahealth/cli/test"
+)
+
+const rolesList = `[{"id":1,"name":"admin"},{"id":5,"name":"member"},{"id":7,"name":"deployer","permissions":["deploy"],"custom":true},{"id":8,"name":"on-call","permissions":["console","logs"],"custom":true}]`
+
+var grantTests = []struct {
+	email         string
+	role          string
+	expectGranted bool
+	expectErr     bool
+}{
+	{"user@example.com", "admin", true, false},
+	{"user@example.com", "Member", false, false},
+	{"unknown@example.com", "admin", false, true},
+	{"user@example.com", "owner", false, true},
+}
+
+func TestGrant(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	granted := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rolesList)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com","roleID":5},{"id":"2","email":"oncall@example.com","roleID":8}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users/1/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			granted = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range grantTests {
+		t.Logf("Data: %+v", data)
+		granted = false
+
+		// test
+		err := CmdGrant(data.email, data.role, New(settings), users.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectGranted), fmt.Sprintf("%t", granted))
+	}
+}
+
+var revokeTests = []struct {
+	email         string
+	role          string
+	expectRevoked bool
+	expectErr     bool
+}{
+	{"user@example.com", "member", true, false},
+	{"user@example.com", "admin", false, true},
+	{"unknown@example.com", "member", false, true},
+}
+
+func TestRevoke(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	revoked := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rolesList)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com","roleID":5},{"id":"2","email":"oncall@example.com","roleID":8}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users/1/roles/5",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			revoked = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range revokeTests {
+		t.Logf("Data: %+v", data)
+		revoked = false
+
+		// test
+		err := CmdRevoke(data.email, data.role, New(settings), users.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectRevoked), fmt.Sprintf("%t", revoked))
+	}
+}
+
+var listTests = []struct {
+	roles      string
+	expectRows []string
+}{
+	{rolesList, []string{"NAME", "admin", "member", "built-in", "deployer", "custom", "on-call", "console, logs"}},
+	{`[]`, []string{"No roles found"}},
+}
+
+func TestList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	roles := ""
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, roles)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com","roleID":5},{"id":"2","email":"oncall@example.com","roleID":8}]`)
+		},
+	)
+
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+		roles = data.roles
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdList(New(settings), users.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+var createTests = []struct {
+	name        string
+	preset      string
+	permissions []string
+	expectErr   bool
+}{
+	{"releaser", "deploy-only", []string{}, false},
+	{"releaser", "deploy-only", []string{"logs"}, false},
+	{"reader", "", []string{"logs", "metrics"}, false},
+	{"reader", "", []string{}, true},
+	{"reader", "read-only", []string{}, true},
+	{"reader", "", []string{"logs", "root"}, true},
+	{"Deployer", "deploy-only", []string{}, true},
+	{"", "deploy-only", []string{}, true},
+}
+
+func TestCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	created := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				created = true
+				fmt.Fprint(w, `{"id":9,"name":"created","permissions":["deploy","logs"],"custom":true}`)
+				return
+			}
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rolesList)
+		},
+	)
+
+	for _, data := range createTests {
+		t.Logf("Data: %+v", data)
+		created = false
+
+		// test
+		err := CmdCreate(data.name, data.preset, data.permissions, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", created))
+	}
+}
+
+var editTests = []struct {
+	name      string
+	newName   string
+	add       []string
+	rm        []string
+	expectErr bool
+}{
+	{"deployer", "", []string{"logs"}, []string{}, false},
+	{"deployer", "releaser", []string{}, []string{}, false},
+	{"deployer", "", []string{}, []string{"deploy"}, true},
+	{"deployer", "", []string{}, []string{}, true},
+	{"deployer", "", []string{"root"}, []string{}, true},
+	{"admin", "", []string{"logs"}, []string{}, true},
+	{"unknown", "", []string{"logs"}, []string{}, true},
+}
+
+func TestEdit(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	edited := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rolesList)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles/7",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			edited = true
+			fmt.Fprint(w, `{"id":7,"name":"deployer","permissions":["deploy","logs"],"custom":true}`)
+		},
+	)
+
+	for _, data := range editTests {
+		t.Logf("Data: %+v", data)
+		edited = false
+
+		// test
+		err := CmdEdit(data.name, data.newName, data.add, data.rm, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", edited))
+	}
+}
+
+var rmTests = []struct {
+	name      string
+	expectErr bool
+}{
+	{"deployer", false},
+	{"on-call", true},
+	{"admin", true},
+	{"unknown", true},
+}
+
+func TestRm(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	removed := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, rolesList)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"1","email":"user@example.com","roleID":5},{"id":"2","email":"oncall@example.com","roleID":8}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles/7",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			removed = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range rmTests {
+		t.Logf("Data: %+v", data)
+		removed = false
+
+		// test
+		err := CmdRm(data.name, New(settings), users.New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", removed))
+	}
+}
@@ -16,15 +16,21 @@ import (
 // name, arguments, and required/optional arguments and flags for the command.
 var Cmd = models.Command{
 	Name:      "rollback",
-	ShortHelp: "Rollback a code service to a specific release",
-	LongHelp: "`rollback` is a way to redeploy older versions of your code service. " +
-		"You must specify the name of the service to rollback and the name of an existing release to rollback to. " +
-		"Releases can be found with the [releases list](#releases-list) command. Here are some sample commands\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" rollback code-1 f93ced037f828dcaabccfc825e6d8d32cc5a1883\n```",
+	ShortHelp: "Rollback a code service to a previous release",
+	LongHelp: "`rollback` is a way to redeploy older versions of your code service without a new git push. " +
+		"You must specify the name of the service to rollback and optionally the name of an existing release to rollback to. " +
+		"If no release is given, the service is rolled back to the release created before the one currently running. " +
+		"Releases can be found with the [releases list](#releases-list) command. " +
+		"The rollback is started asynchronously and its job ID is printed. Use `--follow` to wait until the release is running, or attach later with [jobs attach](#jobs-attach). `--detach` keeps the default and exits once the job ID is printed. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" rollback code-1\n" +
+		"datica -E \"<your_env_alias>\" rollback code-1 f93ced037f828dcaabccfc825e6d8d32cc5a1883\n```",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the service to rollback")
-			releaseName := cmd.StringArg("RELEASE_NAME", "", "The name of the release to rollback to")
+			releaseName := cmd.StringArg("RELEASE_NAME", "", "The name of the release to rollback to. Defaults to the release before the one currently running")
+			cmd.BoolOpt("detach", false, "Exit once the rollback job has been created instead of waiting for it to finish. This is the default")
+			follow := cmd.BoolOpt("follow", false, "Wait for the rollback to finish before exiting")
 			cmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -32,12 +38,12 @@ var Cmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRollback(*serviceName, *releaseName, jobs.New(settings), releases.New(settings), services.New(settings))
+				err := CmdRollback(*serviceName, *releaseName, *follow, jobs.New(settings), releases.New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			cmd.Spec = "SERVICE_NAME RELEASE_NAME"
+			cmd.Spec = "SERVICE_NAME [RELEASE_NAME] [--detach | --follow]"
 		}
 	},
 }
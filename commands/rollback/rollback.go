@@ -1,7 +1,9 @@
 package rollback
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -11,7 +13,7 @@ import (
 	"github.com/daticahealth/cli/lib/jobs"
 )
 
-func CmdRollback(svcName, releaseName string, ij jobs.IJobs, irs releases.IReleases, is services.IServices) error {
+func CmdRollback(svcName, releaseName string, follow bool, ij jobs.IJobs, irs releases.IReleases, is services.IServices) error {
 	if strings.ContainsAny(releaseName, config.InvalidChars) {
 		return fmt.Errorf("Invalid release name. Names must not contain the following characters: %s", config.InvalidChars)
 	}
@@ -22,6 +24,12 @@ func CmdRollback(svcName, releaseName string, ij jobs.IJobs, irs releases.IRelea
 	if service == nil {
 		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
 	}
+	if releaseName == "" {
+		releaseName, err = previousRelease(service.ReleaseVersion, service.ID, irs)
+		if err != nil {
+			return err
+		}
+	}
 	logrus.Printf("Rolling back %s to %s", svcName, releaseName)
 	release, err := irs.Retrieve(releaseName, service.ID)
 	if err != nil {
@@ -30,10 +38,44 @@ func CmdRollback(svcName, releaseName string, ij jobs.IJobs, irs releases.IRelea
 	if release == nil {
 		return fmt.Errorf("Could not find a release with the name \"%s\". You can list releases for this code service with the \"datica releases list %s\" command.", releaseName, svcName)
 	}
-	err = ij.DeployRelease(releaseName, service.ID)
+	job, err := ij.DeployRelease(releaseName, service.ID)
+	if err != nil {
+		return err
+	}
+	if job.ID != "" {
+		logrus.Printf("Rollback started (job ID = %s)", job.ID)
+	}
+	if !follow || job.ID == "" {
+		logrus.Println("Rollback successful! Check the status with \"datica status\" and your logging dashboard for updates.")
+		return nil
+	}
+	logrus.Println("Polling until the rollback is running.")
+	status, err := ij.PollForStatus(jobs.TerminalStatuses(job.Type), job.ID, service.ID)
 	if err != nil {
 		return err
 	}
-	logrus.Println("Rollback successful! Check the status with \"datica status\" and your logging dashboard for updates.")
+	logrus.Printf("\nRollback complete (end status = '%s')", status)
 	return nil
 }
+
+// previousRelease returns the name of the release created before the one
+// currently running
+func previousRelease(current, svcID string, irs releases.IReleases) (string, error) {
+	if current == "" {
+		return "", errors.New("No release is currently running for this service. Please specify the release to rollback to.")
+	}
+	rls, err := irs.List(svcID)
+	if err != nil {
+		return "", err
+	}
+	sort.Sort(releases.SortedReleases(*rls))
+	for i, r := range *rls {
+		if r.Name == current {
+			if i+1 < len(*rls) {
+				return (*rls)[i+1].Name, nil
+			}
+			break
+		}
+	}
+	return "", errors.New("Could not find a release before the one currently running. Please specify the release to rollback to.")
+}
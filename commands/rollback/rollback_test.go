@@ -0,0 +1,81 @@
+package rollback
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/releases"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var rollbackTests = []struct {
+	svcName     string
+	releaseName string
+	deployed    string
+	expectErr   bool
+}{
+	{test.SvcLabel, "v1", "v1", false},
+	{test.SvcLabel, "", "v2", false},
+	{test.SvcLabelAlt, "", "", true},
+	{test.SvcLabel, "invalid/name", "", true},
+	{"invalid-svc", "v1", "", true},
+}
+
+func TestRollback(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	deployed := ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","type":"code","release_version":"v3"},{"id":"%s","label":"%s","type":"code","release_version":"v1"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt))
+		},
+	)
+	releasesJSON := `[{"release":"v1","created_at":"2017-03-01T12:00:00"},{"release":"v3","created_at":"2017-03-03T12:00:00"},{"release":"v2","created_at":"2017-03-02T12:00:00"}]`
+	for _, svcID := range []string{test.SvcID, test.SvcIDAlt} {
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+svcID+"/releases",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, releasesJSON)
+			},
+		)
+	}
+	for _, name := range []string{"v1", "v2"} {
+		name := name
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/releases/"+name,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, fmt.Sprintf(`{"release":"%s"}`, name))
+			},
+		)
+	}
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/deploy",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			deployed = r.URL.Query().Get("release")
+			fmt.Fprint(w, `{"id":"job1","type":"deploy","status":"scheduled"}`)
+		},
+	)
+
+	for _, data := range rollbackTests {
+		t.Logf("Data: %+v", data)
+		deployed = ""
+
+		// test
+		err := CmdRollback(data.svcName, data.releaseName, false, jobs.New(settings), releases.New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if deployed != data.deployed {
+			t.Errorf("Expected release %s to be deployed but got %s", data.deployed, deployed)
+		}
+	}
+}
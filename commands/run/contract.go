@@ -0,0 +1,71 @@
+package run
+
+import (
+	"io"
+	"os"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "run",
+	ShortHelp: "Run a one-off command against a service",
+	LongHelp: "`run` starts a one-off job that runs the given command with the image and environment variables of a code service, such as a database migration or an ad-hoc script. " +
+		"The output of the command is streamed back as it runs, with the command's stdout written to stdout and its stderr written to stderr, and the CLI exits with the exit code of the command so that it can be used from CI. " +
+		"Everything after `--` is the command and its arguments, and is passed on as is. " +
+		"Unlike [console](#console), no terminal is attached and nothing is read from stdin. " +
+		"If the CLI is interrupted the job keeps running, and can be stopped with [jobs stop](#jobs-stop). Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" run code-1 -- python manage.py migrate\n" +
+		"datica -E \"<your_env_alias>\" run code-1 -- bundle exec rake db:seed\n```",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			serviceName := cmd.StringArg("SERVICE_NAME", "", "The name of the code service whose image and environment the command runs with")
+			command := cmd.StringsArg("COMMAND", []string{}, "The command to run and its arguments")
+			cmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				exitCode, err := CmdRun(*serviceName, *command, os.Stdout, os.Stderr, New(settings), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if exitCode != 0 {
+					cli.Exit(exitCode)
+				}
+			}
+			cmd.Spec = "SERVICE_NAME -- COMMAND..."
+		}
+	},
+}
+
+// IRun
+type IRun interface {
+	Start(command []string, svcID string) (*models.Job, error)
+	RetrieveToken(jobID, svcID string) (*models.ConsoleCredentials, error)
+	Stream(url, token string, stdout, stderr io.Writer) error
+}
+
+// SRun is a concrete implementation of IRun
+type SRun struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IRun
+func New(settings *models.Settings) IRun {
+	return &SRun{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,172 @@
+package run
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/terminal"
+	"github.com/daticahealth/cli/models"
+	"golang.org/x/net/websocket"
+)
+
+// startedStatuses are the statuses of a job whose output can be streamed
+var startedStatuses = []string{"running", "finished", "failed"}
+
+// endedStatuses are the statuses of a job whose command has exited
+var endedStatuses = []string{"finished", "failed"}
+
+// pendingStatuses are the statuses of a job that has not ended yet
+var pendingStatuses = []string{"scheduled", "queued", "started", "running", "waiting"}
+
+// frame is a chunk of the output of the command, sent as JSON in a text
+// frame. Stream is either stdout or stderr.
+type frame struct {
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+// CmdRun runs the command in a one-off job of the service, writes its output
+// to the given writers, and returns its exit code. Progress is written to
+// stderr so that stdout only holds the output of the command.
+func CmdRun(svcName string, command []string, stdout, stderr io.Writer, ir IRun, is services.IServices, ij jobs.IJobs) (int, error) {
+	if len(command) == 0 {
+		return 0, errors.New("No command was given. Give the command to run after \"--\", such as \"datica run code-1 -- python manage.py migrate\"")
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return 0, err
+	}
+	if service == nil {
+		return 0, fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if service.Type != "" && service.Type != "code" {
+		return 0, fmt.Errorf("%s is a %s service. Commands can only be run with the image of a code service", service.Label, service.Type)
+	}
+	job, err := ir.Start(command, service.ID)
+	if err != nil {
+		return 0, err
+	}
+	fmt.Fprintf(stderr, "Running \"%s\" on %s (job ID = %s)\n", strings.Join(command, " "), service.Label, job.ID)
+	if _, err = waitFor(startedStatuses, job.ID, service.ID, ij); err != nil {
+		return 0, err
+	}
+	creds, err := ir.RetrieveToken(job.ID, service.ID)
+	if err != nil {
+		return 0, err
+	}
+	if err = ir.Stream(strings.Replace(creds.URL, "http", "ws", 1), creds.Token, stdout, stderr); err != nil {
+		return 0, fmt.Errorf("Lost the output of the command: %s. The job is still running, you can wait for it with \"datica jobs attach %s %s\"", err, service.Label, job.ID)
+	}
+	job, err = waitFor(endedStatuses, job.ID, service.ID, ij)
+	if err != nil {
+		return 0, err
+	}
+	if job.Termination != nil {
+		if job.Termination.ExitCode != 0 {
+			fmt.Fprintf(stderr, "The command exited with code %d\n", job.Termination.ExitCode)
+		}
+		return job.Termination.ExitCode, nil
+	}
+	if job.Status != "finished" {
+		fmt.Fprintf(stderr, "The job ended in status '%s'\n", job.Status)
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// waitFor polls the job until it is in one of the given statuses. Unlike
+// jobs.PollForStatus, nothing is printed while waiting so that stdout only
+// holds the output of the command.
+func waitFor(statuses []string, jobID, svcID string, ij jobs.IJobs) (*models.Job, error) {
+	for {
+		job, err := ij.Retrieve(jobID, svcID, false)
+		if err != nil {
+			return nil, err
+		}
+		if contains(job.Status, statuses) {
+			return job, nil
+		}
+		if !contains(job.Status, pendingStatuses) {
+			return nil, fmt.Errorf("The job ended in status '%s' before the command ran", job.Status)
+		}
+		time.Sleep(config.JobPollTime * time.Second)
+	}
+}
+
+func contains(s string, a []string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Start creates a one-off job that runs the command with the image and
+// environment of the service
+func (r *SRun) Start(command []string, svcID string) (*models.Job, error) {
+	b, err := json.Marshal(map[string][]string{"command": command})
+	if err != nil {
+		return nil, err
+	}
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/services/%s/run", r.Settings.PaasHost, r.Settings.PaasHostVersion, r.Settings.EnvironmentID, svcID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var job models.Job
+	err = r.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RetrieveToken retrieves the URL and token to stream the output of the job
+// from
+func (r *SRun) RetrieveToken(jobID, svcID string) (*models.ConsoleCredentials, error) {
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs/%s/console-token", r.Settings.PaasHost, r.Settings.PaasHostVersion, r.Settings.EnvironmentID, svcID, jobID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var credentials models.ConsoleCredentials
+	err = r.Settings.HTTPManager.ConvertResp(resp, statusCode, &credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &credentials, nil
+}
+
+// Stream writes the output of the job to the given writers until the command
+// exits and the server closes the connection
+func (r *SRun) Stream(url, token string, stdout, stderr io.Writer) error {
+	ws, err := terminal.Dial(url, http.Header{"X-Console-Token": {token}}, r.Settings.HTTPManager)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	for {
+		var f frame
+		err = websocket.JSON.Receive(ws, &f)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		w := stdout
+		if f.Stream == "stderr" {
+			w = stderr
+		}
+		io.WriteString(w, f.Data)
+	}
+}
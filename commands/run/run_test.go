@@ -0,0 +1,86 @@
+package run
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+	"golang.org/x/net/websocket"
+)
+
+const runJobID = "run-job"
+
+var runTests = []struct {
+	svcName    string
+	command    []string
+	exitCode   int
+	expectCode int
+	expectErr  bool
+}{
+	{test.SvcLabel, []string{"python", "manage.py", "migrate"}, 0, 0, false},
+	{test.SvcLabel, []string{"false"}, 3, 3, false},
+	{test.SvcLabel, []string{}, 0, 0, true},
+	{"db01", []string{"ls"}, 0, 0, true},
+	{"invalid-svc", []string{"ls"}, 0, 0, true},
+}
+
+func TestRun(t *testing.T) {
+	for _, data := range runTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","type":"code"},{"id":"%s","label":"db01","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt))
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/run",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"run","status":"scheduled"}`, runJobID))
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+runJobID,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, fmt.Sprintf(`{"id":"%s","type":"run","status":"finished","termination":{"exit_code":%d}}`, runJobID, data.exitCode))
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"+runJobID+"/console-token",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				fmt.Fprint(w, fmt.Sprintf(`{"url":"%s/output","token":"token"}`, baseURL.String()))
+			},
+		)
+		mux.Handle("/output", websocket.Handler(func(ws *websocket.Conn) {
+			websocket.JSON.Send(ws, frame{Stream: "stdout", Data: "out\n"})
+			websocket.JSON.Send(ws, frame{Stream: "stderr", Data: "err\n"})
+		}))
+		var stdout, stderr bytes.Buffer
+
+		// test
+		code, err := CmdRun(data.svcName, data.command, &stdout, &stderr, New(settings), services.New(settings), jobs.New(settings))
+		test.Teardown(server)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectErr {
+			continue
+		}
+		if code != data.expectCode {
+			t.Errorf("Expected exit code %d but got %d", data.expectCode, code)
+		}
+		test.AssertEquals(t, stdout.String(), "out\n")
+		if !bytes.HasPrefix(stderr.Bytes(), []byte("Running")) || !bytes.Contains(stderr.Bytes(), []byte("err\n")) {
+			t.Errorf("Expected the progress and the stderr of the command on stderr but got %q", stderr.String())
+		}
+	}
+}
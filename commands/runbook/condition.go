@@ -0,0 +1,63 @@
+package runbook
+
+import (
+	"strings"
+
+	"github.com/daticahealth/cli/commands/saved"
+)
+
+// operators are the comparisons a condition can make. "not contains" comes
+// before "contains" so that it is matched first.
+var operators = []string{" not contains ", " contains ", " != ", " == "}
+
+// evaluate checks the condition of a step. A condition is either a single
+// value or a comparison of two values, with placeholders filled in after the
+// condition is split so that captured outputs can not change the comparison.
+func evaluate(condition string, values map[string]string) (bool, error) {
+	for _, op := range operators {
+		parts := strings.SplitN(condition, op, 2)
+		if len(parts) != 2 {
+			continue
+		}
+		left, err := operand(parts[0], values)
+		if err != nil {
+			return false, err
+		}
+		right, err := operand(parts[1], values)
+		if err != nil {
+			return false, err
+		}
+		switch strings.TrimSpace(op) {
+		case "not contains":
+			return !strings.Contains(left, right), nil
+		case "contains":
+			return strings.Contains(left, right), nil
+		case "!=":
+			return left != right, nil
+		default:
+			return left == right, nil
+		}
+	}
+	value, err := operand(condition, values)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(value) {
+	case "", "false", "no", "0":
+		return false, nil
+	}
+	return true, nil
+}
+
+// operand fills in a side of a condition, dropping the quotes around it
+func operand(s string, values map[string]string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = s[1 : len(s)-1]
+	}
+	filled, err := saved.Fill(s, values)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(filled), nil
+}
@@ -0,0 +1,99 @@
+package runbook
+
+import (
+	"io"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "runbook",
+	ShortHelp: "Run procedures described in runbook files",
+	LongHelp: "The `runbook` command allows you to run a procedure, such as the steps taken during an incident, from a YAML runbook file instead of following a wiki page by hand. " +
+		"Every step of a runbook is a CLI invocation which can be skipped based on a condition, confirmed by the user before it is run, and have its output captured for later steps. " +
+		"The runbook command can not be run directly but has sub commands.",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(RunSubCmd.Name, RunSubCmd.ShortHelp, help.Render(RunSubCmd.LongHelp), RunSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var RunSubCmd = models.Command{
+	Name:      "run",
+	ShortHelp: "Run the steps of a runbook file",
+	LongHelp: "`runbook run` runs the steps of the given runbook file in order. A runbook file looks like this\n\n" +
+		"```\nname: Scale up the api\n" +
+		"params:\n" +
+		"  service: api\n" +
+		"steps:\n" +
+		"  - name: Scale up\n" +
+		"    run: worker scale {service} web 4\n" +
+		"    confirm: Scale {service} to 4 web workers?\n" +
+		"  - name: Check memory\n" +
+		"    run: metrics memory {service} --json\n" +
+		"    capture: memory\n" +
+		"  - name: Notify\n" +
+		"    if: \"{memory} contains oom_killed\"\n" +
+		"    run: saved run notify-oncall service={service}\n" +
+		"    continue_on_error: true\n```\n\n" +
+		"`run` is everything you would type after `datica` and can hold `{param}` and `{param=default}` placeholders like [saved commands](#saved). " +
+		"Placeholders are filled in with the `params` of the runbook, `param=value` arguments, and the outputs of earlier steps. " +
+		"A step with `capture` makes its output available as `{capture}` and its exit code as `{capture_exit}`. " +
+		"`if` is either a single value, which holds unless it is empty, `false`, `no`, or `0`, or a comparison using `==`, `!=`, `contains`, or `not contains`. " +
+		"A step with `confirm` is only run once the user agrees, and declining stops the runbook. Use the global `--yes` flag to agree to every confirmation. " +
+		"The runbook stops at the first failed step unless the step has `continue_on_error`. " +
+		"Every step that is run, skipped, or declined is appended to the runbook log at `~/.datica_runbooks.log`, or the file given with `--log`, along with the time and your user ID. " +
+		"The global `-E` option given to `runbook run` applies to every step unless a step chooses its own environment, and so do the global `--yes`, `--non-interactive`, `--strict`, and `--trace` options. " +
+		"The API requests of every step are tagged with the name of the runbook and the number of the step, so the changes a runbook made can be found in the audit trail. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" runbook run scale-up.yml\n" +
+		"datica -E \"<your_env_alias>\" runbook run scale-up.yml service=worker01 --log incident-42.log\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			filePath := subCmd.StringArg("FILE", "", "The path of the runbook file to run")
+			params := subCmd.StringsArg("PARAMS", []string{}, "Values of the params of the runbook as param=value")
+			logPath := subCmd.StringOpt("log", "", "The path of the file the steps are logged to")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				// every step is run by its own CLI process which reads the
+				// session from the settings file
+				config.SaveSettings(settings)
+				err := CmdRun(*filePath, *params, *logPath, settings.EnvironmentName, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "FILE [PARAMS...] [--log]"
+		}
+	},
+}
+
+// IRunbook
+type IRunbook interface {
+	Load(filePath string) (*models.Runbook, error)
+	Log(logPath string, entry LogEntry) error
+	Run(args []string, envName, step string, out io.Writer) (int, error)
+}
+
+// SRunbook is a concrete implementation of IRunbook
+type SRunbook struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IRunbook
+func New(settings *models.Settings) IRunbook {
+	return &SRunbook{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,256 @@
+package runbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/saved"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/mitchellh/go-homedir"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v2"
+)
+
+// LogFile is the name of the runbook log in the home directory
+const LogFile = ".datica_runbooks.log"
+
+// the results of a step as written to the runbook log
+const (
+	resultOK       = "ok"
+	resultFailed   = "failed"
+	resultSkipped  = "skipped"
+	resultDeclined = "declined"
+)
+
+// captureRegex matches the names outputs can be captured as
+var captureRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// LogEntry is a line of the runbook log recording a single step
+type LogEntry struct {
+	Time        string `json:"time"`
+	UserID      string `json:"user_id"`
+	Environment string `json:"environment,omitempty"`
+	Runbook     string `json:"runbook"`
+	Step        string `json:"step"`
+	Command     string `json:"command,omitempty"`
+	Result      string `json:"result"`
+	ExitCode    int    `json:"exit_code"`
+}
+
+// CmdRun runs the steps of a runbook file in order. Values given as
+// param=value replace the defaults of the runbook.
+func CmdRun(filePath string, params []string, logPath, envName string, ir IRunbook, ip prompts.IPrompts) error {
+	runbook, err := ir.Load(filePath)
+	if err != nil {
+		return err
+	}
+	values := map[string]string{}
+	for name, value := range runbook.Params {
+		values[name] = value
+	}
+	for _, p := range params {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("Invalid parameter \"%s\". Parameters must be given as param=value", p)
+		}
+		values[parts[0]] = parts[1]
+	}
+
+	logrus.Printf("Running the runbook %s", runbook.Name)
+	data := [][]string{{"STEP", "NAME", "RESULT"}}
+	defer func() {
+		printResults(data)
+	}()
+	for i, step := range runbook.Steps {
+		logrus.Printf("\n==> Step %d/%d: %s", i+1, len(runbook.Steps), step.Name)
+		entry := LogEntry{Environment: envName, Runbook: runbook.Name, Step: step.Name}
+		result := func(r string) {
+			entry.Result = r
+			data = append(data, []string{strconv.Itoa(i + 1), step.Name, r})
+			if err := ir.Log(logPath, entry); err != nil {
+				logrus.Warnf("Failed to write to the runbook log: %s", err)
+			}
+		}
+		if step.If != "" {
+			holds, err := evaluate(step.If, values)
+			if err != nil {
+				return fmt.Errorf("Could not check the condition of step %d (%s): %s", i+1, step.Name, err)
+			}
+			if !holds {
+				logrus.Printf("Skipped since \"%s\" does not hold", step.If)
+				result(resultSkipped)
+				continue
+			}
+		}
+		args, err := saved.Expand(step.Run, values)
+		if err != nil {
+			return fmt.Errorf("Could not run step %d (%s): %s", i+1, step.Name, err)
+		}
+		entry.Command = strings.Join(args, " ")
+		if step.Confirm != "" {
+			question, err := saved.Fill(step.Confirm, values)
+			if err != nil {
+				return fmt.Errorf("Could not run step %d (%s): %s", i+1, step.Name, err)
+			}
+			if err = ip.YesNo(fmt.Sprintf("%s (y/n) ", question)); err != nil {
+				result(resultDeclined)
+				return fmt.Errorf("The runbook was stopped at step %d (%s): %s", i+1, step.Name, err)
+			}
+		}
+
+		logrus.Printf("datica %s", entry.Command)
+		var output bytes.Buffer
+		entry.ExitCode, err = ir.Run(args, envName, fmt.Sprintf("%s/%d", runbook.Name, i+1), io.MultiWriter(logrus.StandardLogger().Out, &output))
+		if step.Capture != "" {
+			values[step.Capture] = strings.TrimSpace(output.String())
+			values[step.Capture+"_exit"] = strconv.Itoa(entry.ExitCode)
+		}
+		if err == nil && entry.ExitCode == 0 {
+			result(resultOK)
+			continue
+		}
+		result(resultFailed)
+		if err == nil {
+			err = fmt.Errorf("exit code %d", entry.ExitCode)
+		}
+		if !step.ContinueOnError {
+			return fmt.Errorf("The runbook was stopped since step %d (%s) failed: %s", i+1, step.Name, err)
+		}
+		config.Warn(config.WarningPartialFailure, "Step %d (%s) failed: %s. Continuing since the step allows errors.", i+1, step.Name, err)
+	}
+	logrus.Printf("\nFinished the runbook %s", runbook.Name)
+	return nil
+}
+
+func printResults(data [][]string) {
+	if len(data) == 1 {
+		return
+	}
+	logrus.Println()
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+}
+
+// Load reads and checks a runbook file. Steps without a name are named after
+// their position.
+func (r *SRunbook) Load(filePath string) (*models.Runbook, error) {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var runbook models.Runbook
+	if err = yaml.Unmarshal(b, &runbook); err != nil {
+		return nil, fmt.Errorf("%s is not a valid runbook file: %s", filePath, err)
+	}
+	if runbook.Name == "" {
+		runbook.Name = filepath.Base(filePath)
+	}
+	if len(runbook.Steps) == 0 {
+		return nil, fmt.Errorf("The runbook %s has no steps", filePath)
+	}
+	for i := range runbook.Steps {
+		step := &runbook.Steps[i]
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step %d", i+1)
+		}
+		if strings.TrimSpace(step.Run) == "" {
+			return nil, fmt.Errorf("Step %d (%s) of the runbook %s has nothing to run", i+1, step.Name, filePath)
+		}
+		if step.Capture != "" && !captureRegex.MatchString(step.Capture) {
+			return nil, fmt.Errorf("Step %d (%s) of the runbook %s captures its output as \"%s\". Captured outputs may only be named with letters, numbers, \"_\", and \"-\".", i+1, step.Name, filePath, step.Capture)
+		}
+	}
+	return &runbook, nil
+}
+
+// Log appends an entry to the runbook log at the given path, or the default
+// runbook log in the home directory if no path is given
+func (r *SRunbook) Log(logPath string, entry LogEntry) error {
+	if logPath == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+		logPath = filepath.Join(homeDir, LogFile)
+	}
+	entry.Time = time.Now().UTC().Format(time.RFC3339)
+	entry.UserID = r.Settings.UsersID
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// Run runs the given arguments with a new CLI process, writing its output to
+// out, and returns its exit code. The process targets the given environment
+// unless the arguments choose their own, and tags its requests with the given
+// runbook step.
+func (r *SRunbook) Run(args []string, envName, step string, out io.Writer) (int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	cmd := exec.Command(executable, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), stepEnv(envName, step)...)
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// stepEnv returns the environment variables set for the process of a step.
+// The global options of this process that decide how commands may prompt,
+// fail, and trace their requests are passed on through the environment
+// variables of those options, so that the steps run the same way the runbook
+// does unless their arguments say otherwise.
+func stepEnv(envName, step string) []string {
+	env := []string{fmt.Sprintf("%s=%s", config.RunbookEnvVar, step)}
+	if envName != "" {
+		env = append(env, fmt.Sprintf("%s=%s", config.DaticaEnvironmentEnvVar, envName))
+	}
+	if prompts.AssumeYes() {
+		env = append(env, config.AssumeYesEnvVar+"=true")
+	}
+	if prompts.NonInteractive() {
+		env = append(env, config.NonInteractiveEnvVar+"=true")
+	}
+	if config.Strict() {
+		env = append(env, config.StrictEnvVar+"=true")
+	}
+	if traceFile := httpclient.TraceFile(); traceFile != "" {
+		env = append(env, fmt.Sprintf("%s=%s", config.TraceEnvVar, traceFile))
+	}
+	return env
+}
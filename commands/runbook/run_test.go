@@ -0,0 +1,226 @@
+package runbook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+// fakeRunbook runs steps by looking up their output instead of starting a new
+// CLI process
+type fakeRunbook struct {
+	SRunbook
+	outputs map[string]string
+	failing map[string]bool
+	ran     []string
+}
+
+func (f *fakeRunbook) Run(args []string, envName, step string, out io.Writer) (int, error) {
+	command := strings.Join(args, " ")
+	f.ran = append(f.ran, command)
+	fmt.Fprint(out, f.outputs[command])
+	if f.failing[command] {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+const runbookFile = `name: scale up
+params:
+  service: api
+steps:
+  - name: scale
+    run: worker scale {service} web 4
+    confirm: Scale {service}?
+  - name: check
+    run: jobs list {service}
+    capture: jobs
+  - name: notify
+    if: "{jobs} contains oom_killed"
+    run: saved run notify service={service}
+  - name: done
+    if: "{jobs_exit} == 0"
+    run: status
+`
+
+var runTests = []struct {
+	params    []string
+	outputs   map[string]string
+	failing   map[string]bool
+	expected  []string
+	expectErr bool
+}{
+	{[]string{}, map[string]string{"jobs list api": "web running"}, nil, []string{"worker scale api web 4", "jobs list api", "status"}, false},
+	{[]string{"service=worker01"}, map[string]string{"jobs list worker01": "web oom_killed"}, nil, []string{"worker scale worker01 web 4", "jobs list worker01", "saved run notify service=worker01", "status"}, false},
+	{[]string{}, nil, map[string]bool{"worker scale api web 4": true}, []string{"worker scale api web 4"}, true},
+	{[]string{"service"}, nil, nil, nil, true},
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "scale-up.yml")
+	if err = ioutil.WriteFile(filePath, []byte(runbookFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logPath := filepath.Join(dir, "runbooks.log")
+
+	for _, data := range runTests {
+		t.Logf("Data: %+v", data)
+		ir := &fakeRunbook{SRunbook: SRunbook{Settings: &models.Settings{UsersID: "user1"}}, outputs: data.outputs, failing: data.failing}
+
+		// test
+		err := CmdRun(filePath, data.params, logPath, test.Alias, ir, &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if strings.Join(ir.ran, ", ") != strings.Join(data.expected, ", ") {
+			t.Errorf("Expected to run %v but ran %v", data.expected, ir.ran)
+		}
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatalf("The runbook log was not written: %s", err)
+	}
+	defer f.Close()
+	results := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid runbook log entry: %s", err)
+		}
+		test.AssertEquals(t, "user1", entry.UserID)
+		results = append(results, entry.Result)
+	}
+	test.AssertEquals(t, "ok ok skipped ok ok ok ok ok failed", strings.Join(results, " "))
+}
+
+var loadTests = []struct {
+	runbook   string
+	expectErr bool
+}{
+	{"steps:\n  - run: status\n", false},
+	{"name: empty\n", true},
+	{"steps:\n  - name: nothing\n", true},
+	{"steps:\n  - run: status\n    capture: bad name\n", true},
+	{"steps: [", true},
+}
+
+func TestLoad(t *testing.T) {
+	f, err := ioutil.TempFile("", "runbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	for _, data := range loadTests {
+		t.Logf("Data: %+v", data)
+		if err = ioutil.WriteFile(f.Name(), []byte(data.runbook), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		// test
+		runbook, err := New(&models.Settings{}).Load(f.Name())
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if err == nil && runbook.Steps[0].Name != "step 1" {
+			t.Errorf("Expected the step to be named after its position but got %s", runbook.Steps[0].Name)
+		}
+	}
+}
+
+var evaluateTests = []struct {
+	condition string
+	expected  bool
+	expectErr bool
+}{
+	{"{out} contains error", true, false},
+	{"{out} not contains error", false, false},
+	{"{code} == 0", true, false},
+	{"'{code}' != \"0\"", false, false},
+	{"{out} == 'some error here'", true, false},
+	{"{flag}", false, false},
+	{"{empty}", false, false},
+	{"{out}", true, false},
+	{"{missing} == 1", false, true},
+	{"{missing=1} == 1", true, false},
+}
+
+func TestEvaluate(t *testing.T) {
+	values := map[string]string{"out": "some error here", "code": "0", "flag": "false", "empty": ""}
+	for _, data := range evaluateTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		holds, err := evaluate(data.condition, values)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if holds != data.expected {
+			t.Errorf("Expected %t but got %t", data.expected, holds)
+		}
+	}
+}
+
+func TestStepEnv(t *testing.T) {
+	traceFile := filepath.Join(os.TempDir(), "runbook-trace.log")
+	defer os.Remove(traceFile)
+	tests := []struct {
+		envName        string
+		assumeYes      bool
+		nonInteractive bool
+		strict         bool
+		traceFile      string
+		expected       []string
+	}{
+		{"", false, false, false, "", []string{"DATICA_RUNBOOK=scale up/1"}},
+		{test.EnvName, false, false, false, "", []string{"DATICA_RUNBOOK=scale up/1", "DATICA_ENV=" + test.EnvName}},
+		{"", true, true, true, traceFile, []string{"DATICA_RUNBOOK=scale up/1", "DATICA_YES=true", "DATICA_NONINTERACTIVE=true", "DATICA_STRICT=true", "DATICA_TRACE=" + traceFile}},
+	}
+	defer prompts.SetAssumeYes(false)
+	defer prompts.SetNonInteractive(false)
+	defer config.SetStrict(false)
+	defer httpclient.SetTraceFile("")
+	for _, data := range tests {
+		t.Logf("Data: %+v", data)
+		prompts.SetAssumeYes(data.assumeYes)
+		prompts.SetNonInteractive(data.nonInteractive)
+		config.SetStrict(data.strict)
+		if err := httpclient.SetTraceFile(data.traceFile); err != nil {
+			t.Fatal(err)
+		}
+
+		// test
+		env := stepEnv(data.envName, "scale up/1")
+
+		// assert
+		test.AssertEquals(t, strings.Join(data.expected, ","), strings.Join(env, ","))
+	}
+}
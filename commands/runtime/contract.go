@@ -0,0 +1,128 @@
+package runtimecmd
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Runtimes are the language runtimes whose versions can be pinned
+var Runtimes = []string{"go", "java", "node", "php", "python", "ruby"}
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "runtime",
+	ShortHelp: "Pin the build stack and runtime versions of a code service",
+	LongHelp: "The `runtime` command allows you to pin the build stack and language runtime versions a code service is built with. " +
+		"Pins are stored with the service rather than in your code, so every build uses the same versions no matter which branch is pushed. " +
+		"Pins take effect on the next build. " +
+		"The current pins are shown by [services describe](#services-describe). " +
+		"The runtime command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, help.Render(SetSubCmd.LongHelp), SetSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var SetSubCmd = models.Command{
+	Name:      "set",
+	ShortHelp: "Pin the build stack or runtime versions of a code service",
+	LongHelp: "`runtime set` pins the build stack and language runtime versions of a code service. " +
+		"Only the pins given are changed, the rest keep their current value. " +
+		"Versions are numbers separated by dots, such as `18.19` or `3.1.4`. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" runtime set app01 --stack cedar-14 --node 18.19\n" +
+		"datica -E \"<your_env_alias>\" runtime set app01 --ruby 3.1.4\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service to pin. Defaults to the service pinned by the workspace file.")
+			stack := subCmd.StringOpt("stack", "", "The build stack to build the service on")
+			versions := map[string]*string{}
+			for _, r := range Runtimes {
+				versions[r] = subCmd.StringOpt(r, "", "The "+r+" version to build the service with")
+			}
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				pins := models.RuntimePins{Stack: *stack, Versions: map[string]string{}}
+				for r, v := range versions {
+					if *v != "" {
+						pins.Versions[r] = *v
+					}
+				}
+				err := CmdSet(config.ServiceName(*serviceName, settings), pins, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] [--stack] [--go] [--java] [--node] [--php] [--python] [--ruby]"
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove build stack or runtime version pins from a code service",
+	LongHelp: "`runtime rm` removes pins from a code service so that its buildpack picks the version again. " +
+		"Only the pins given are removed. If none are given, every pin is removed. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" runtime rm app01 --node\n" +
+		"datica -E \"<your_env_alias>\" runtime rm app01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the code service to remove pins from. Defaults to the service pinned by the workspace file.")
+			stack := subCmd.BoolOpt("stack", false, "Remove the build stack pin")
+			versions := map[string]*bool{}
+			for _, r := range Runtimes {
+				versions[r] = subCmd.BoolOpt(r, false, "Remove the "+r+" version pin")
+			}
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				runtimes := []string{}
+				for _, r := range Runtimes {
+					if *versions[r] {
+						runtimes = append(runtimes, r)
+					}
+				}
+				err := CmdRm(config.ServiceName(*serviceName, settings), *stack, runtimes, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] [--stack] [--go] [--java] [--node] [--php] [--python] [--ruby]"
+		}
+	},
+}
+
+// IRuntime
+type IRuntime interface {
+	Update(svcID string, pins *models.RuntimePins) error
+}
+
+// SRuntime is a concrete implementation of IRuntime
+type SRuntime struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IRuntime
+func New(settings *models.Settings) IRuntime {
+	return &SRuntime{
+		Settings: settings,
+	}
+}
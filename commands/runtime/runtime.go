@@ -0,0 +1,109 @@
+package runtimecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+)
+
+var versionRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+
+func CmdSet(svcName string, pins models.RuntimePins, ir IRuntime, is services.IServices) error {
+	if pins.Stack == "" && len(pins.Versions) == 0 {
+		return errors.New("You must specify --stack or the version of at least one runtime to pin")
+	}
+	for r, v := range pins.Versions {
+		if !versionRegex.MatchString(v) {
+			return fmt.Errorf("\"%s\" is not a valid %s version. Versions are numbers separated by dots, such as 18.19", v, r)
+		}
+	}
+	service, err := codeService(svcName, is)
+	if err != nil {
+		return err
+	}
+	current := currentPins(service)
+	if pins.Stack != "" {
+		current.Stack = pins.Stack
+	}
+	for r, v := range pins.Versions {
+		current.Versions[r] = v
+	}
+	if err = ir.Update(service.ID, &current); err != nil {
+		return err
+	}
+	logrus.Printf("%s is now pinned to %s. The pins take effect on the next build.", svcName, services.FormatPins(&current))
+	return nil
+}
+
+func CmdRm(svcName string, stack bool, runtimes []string, ir IRuntime, is services.IServices) error {
+	service, err := codeService(svcName, is)
+	if err != nil {
+		return err
+	}
+	current := currentPins(service)
+	if !stack && len(runtimes) == 0 {
+		current = models.RuntimePins{Versions: map[string]string{}}
+	}
+	if stack {
+		current.Stack = ""
+	}
+	for _, r := range runtimes {
+		delete(current.Versions, r)
+	}
+	if err = ir.Update(service.ID, &current); err != nil {
+		return err
+	}
+	if current.Stack == "" && len(current.Versions) == 0 {
+		logrus.Printf("%s has no pins. Its buildpack picks the stack and runtime versions on the next build.", svcName)
+	} else {
+		logrus.Printf("%s is now pinned to %s. The pins take effect on the next build.", svcName, services.FormatPins(&current))
+	}
+	return nil
+}
+
+// currentPins returns a copy of the pins of the given service
+func currentPins(service *models.Service) models.RuntimePins {
+	current := models.RuntimePins{Versions: map[string]string{}}
+	if service.Runtime != nil {
+		current.Stack = service.Runtime.Stack
+		for r, v := range service.Runtime.Versions {
+			current.Versions[r] = v
+		}
+	}
+	return current
+}
+
+func codeService(svcName string, is services.IServices) (*models.Service, error) {
+	if svcName == "" {
+		return nil, errors.New("You must specify the name of a code service")
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	if service.Type != "code" {
+		return nil, fmt.Errorf("The \"%s\" service is not a code service. Only code services can be pinned.", svcName)
+	}
+	return service, nil
+}
+
+func (r *SRuntime) Update(svcID string, pins *models.RuntimePins) error {
+	b, err := json.Marshal(pins)
+	if err != nil {
+		return err
+	}
+	headers := r.Settings.HTTPManager.GetHeaders(r.Settings.SessionToken, r.Settings.Version, r.Settings.Pod, r.Settings.UsersID)
+	resp, statusCode, err := r.Settings.HTTPManager.Put(b, fmt.Sprintf("%s%s/environments/%s/services/%s/runtime", r.Settings.PaasHost, r.Settings.PaasHostVersion, r.Settings.EnvironmentID, svcID), headers)
+	if err != nil {
+		return err
+	}
+	return r.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
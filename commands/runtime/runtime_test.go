@@ -0,0 +1,106 @@
+package runtimecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var setTests = []struct {
+	svcName   string
+	pins      models.RuntimePins
+	expected  string
+	expectErr bool
+}{
+	{test.SvcLabel, models.RuntimePins{Versions: map[string]string{"node": "18.19"}}, "stack cedar-14, node 18.19, ruby 2.3.1", false},
+	{test.SvcLabel, models.RuntimePins{Stack: "heroku-22"}, "stack heroku-22, ruby 2.3.1", false},
+	{test.SvcLabel, models.RuntimePins{}, "", true},
+	{test.SvcLabel, models.RuntimePins{Versions: map[string]string{"node": "latest"}}, "", true},
+	{test.SvcLabelAlt, models.RuntimePins{Stack: "heroku-22"}, "", true},
+	{"invalid-svc", models.RuntimePins{Stack: "heroku-22"}, "", true},
+}
+
+func TestSet(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code","runtime":{"stack":"cedar-14","versions":{"ruby":"2.3.1"}}},{"id":"%s","label":"%s","type":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt)
+		},
+	)
+	var updated models.RuntimePins
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/runtime",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			updated = models.RuntimePins{}
+			json.NewDecoder(r.Body).Decode(&updated)
+		},
+	)
+
+	for _, data := range setTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdSet(data.svcName, data.pins, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if err == nil {
+			test.AssertEquals(t, data.expected, services.FormatPins(&updated))
+		}
+	}
+}
+
+var rmTests = []struct {
+	stack    bool
+	runtimes []string
+	expected string
+}{
+	{false, []string{"ruby"}, "stack cedar-14, node 18.19"},
+	{true, nil, "node 18.19, ruby 2.3.1"},
+	{false, nil, "(none)"},
+}
+
+func TestRm(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code","runtime":{"stack":"cedar-14","versions":{"node":"18.19","ruby":"2.3.1"}}}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	var updated models.RuntimePins
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/runtime",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			updated = models.RuntimePins{}
+			json.NewDecoder(r.Body).Decode(&updated)
+		},
+	)
+
+	for _, data := range rmTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdRm(test.SvcLabel, data.stack, data.runtimes, New(settings), services.New(settings))
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		test.AssertEquals(t, data.expected, services.FormatPins(&updated))
+	}
+}
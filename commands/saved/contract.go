@@ -0,0 +1,178 @@
+package saved
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "saved",
+	ShortHelp: "Save long commands under a short name and run them again",
+	LongHelp: "The `saved` command allows you to save a CLI invocation under a short name so that it can be run again, or referenced from a runbook, without retyping every flag. " +
+		"Saved commands can hold placeholders such as `{service}` or `{since=1h}` that are filled in when the command is run, with the value after `=` used when none is given. " +
+		"Saved commands are stored in your local settings and can be shared with your team with `saved export` and `saved import`. " +
+		"The saved command can not be run directly but has sub commands.",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(AddSubCmd.Name, AddSubCmd.ShortHelp, help.Render(AddSubCmd.LongHelp), AddSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ExportSubCmd.Name, ExportSubCmd.ShortHelp, help.Render(ExportSubCmd.LongHelp), ExportSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ImportSubCmd.Name, ImportSubCmd.ShortHelp, help.Render(ImportSubCmd.LongHelp), ImportSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RunSubCmd.Name, RunSubCmd.ShortHelp, help.Render(RunSubCmd.LongHelp), RunSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var AddSubCmd = models.Command{
+	Name:      "add",
+	ShortHelp: "Save a command under a short name",
+	LongHelp: "`saved add` saves the given command under NAME. " +
+		"The command is everything you would type after `datica`, given as a single quoted argument, and is checked when it is run rather than when it is saved. " +
+		"Use `{param}` for a value that must be given when the command is run and `{param=default}` for a value with a default. " +
+		"An existing saved command with the same name is only replaced with `--force`. Here are some sample commands\n\n" +
+		"```\ndatica saved add api-errors 'logs --service api --level error --hours 1'\n" +
+		"datica saved add errors 'logs --service {service} --level error --hours {hours=1}' -d \"Recent errors of a service\"\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name to save the command under")
+			command := subCmd.StringArg("COMMAND", "", "The command to save, without the leading \"datica\"")
+			description := subCmd.StringOpt("d description", "", "A description of what the command does")
+			force := subCmd.BoolOpt("f force", false, "Replace an existing saved command with the same name")
+			subCmd.Action = func() {
+				err := CmdAdd(*name, *command, *description, *force, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME COMMAND [-d] [-f]"
+		}
+	},
+}
+
+var ExportSubCmd = models.Command{
+	Name:      "export",
+	ShortHelp: "Export saved commands to share them",
+	LongHelp: "`saved export` writes your saved commands as JSON to the given file, or prints them if no file is given, so they can be shared and loaded with [saved import](#saved-import). " +
+		"Give the names of saved commands with `--name` to only export some of them. Here are some sample commands\n\n" +
+		"```\ndatica saved export team-commands.json\n" +
+		"datica saved export --name api-errors --name errors\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			filePath := subCmd.StringArg("FILE", "", "The path of the file to export to")
+			names := subCmd.StringsOpt("n name", []string{}, "The name of a saved command to export. Can be given more than once")
+			subCmd.Action = func() {
+				err := CmdExport(*filePath, *names, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[FILE] [-n...]"
+		}
+	},
+}
+
+var ImportSubCmd = models.Command{
+	Name:      "import",
+	ShortHelp: "Import saved commands exported by someone else",
+	LongHelp: "`saved import` adds the saved commands in a file written by [saved export](#saved-export). " +
+		"Saved commands that already exist with a different command are skipped unless `--force` is given. Here is a sample command\n\n" +
+		"```\ndatica saved import team-commands.json\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			filePath := subCmd.StringArg("FILE", "", "The path of the file to import")
+			force := subCmd.BoolOpt("f force", false, "Replace existing saved commands with the same names")
+			subCmd.Action = func() {
+				err := CmdImport(*filePath, *force, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "FILE [-f]"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List saved commands",
+	LongHelp: "`saved list` lists your saved commands along with the command they run and their description. Here is a sample command\n\n" +
+		"```\ndatica saved list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				err := CmdList(New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove a saved command",
+	LongHelp: "`saved rm` removes the saved command with the given name. Here is a sample command\n\n" +
+		"```\ndatica saved rm api-errors\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the saved command to remove")
+			subCmd.Action = func() {
+				err := CmdRm(*name, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+var RunSubCmd = models.Command{
+	Name:      "run",
+	ShortHelp: "Run a saved command",
+	LongHelp: "`saved run` runs the saved command with the given name. " +
+		"Placeholders are filled in with `param=value` arguments, and placeholders without a value or a default are reported before anything is run. " +
+		"The global options given to `saved run`, such as `-E`, `-y`, or `--trace`, apply to the saved command unless the saved command gives its own. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" saved run api-errors\n" +
+		"datica -E \"<your_env_alias>\" saved run errors service=worker01 hours=6\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the saved command to run")
+			params := subCmd.StringsArg("PARAMS", []string{}, "Values of the placeholders of the saved command as param=value")
+			subCmd.Action = func() {
+				err := CmdRun(*name, *params, settings.EnvironmentName, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME [PARAMS...]"
+		}
+	},
+}
+
+// ISaved
+type ISaved interface {
+	Add(name string, saved models.SavedCommand) error
+	List() map[string]models.SavedCommand
+	Rm(name string) error
+	Run(args []string) error
+}
+
+// SSaved is a concrete implementation of ISaved
+type SSaved struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of ISaved
+func New(settings *models.Settings) ISaved {
+	return &SSaved{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,339 @@
+package saved
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// placeholderRegex matches {param} and {param=default} in a saved command
+var placeholderRegex = regexp.MustCompile(`\{([A-Za-z0-9_-]+)(=([^}]*))?\}`)
+
+// runner runs a CLI invocation given without the leading program name
+var runner func(args []string) error
+
+// SetRunner sets the function used to run saved commands. This must be called
+// once every command has been registered.
+func SetRunner(r func(args []string) error) {
+	runner = r
+}
+
+func CmdAdd(name, command, description string, force bool, is ISaved) error {
+	if name == "" {
+		return errors.New("The name of a saved command cannot be empty")
+	}
+	if err := checkCommand(command); err != nil {
+		return err
+	}
+	if _, ok := is.List()[name]; ok && !force {
+		return fmt.Errorf("A saved command named \"%s\" already exists. Use --force to replace it.", name)
+	}
+	if err := is.Add(name, models.SavedCommand{Command: command, Description: description}); err != nil {
+		return err
+	}
+	logrus.Printf("Saved \"%s\" as %s. Run it with \"datica saved run %s\"", command, name, name)
+	if params := placeholders(command); len(params) > 0 {
+		logrus.Printf("It takes the parameters %s", strings.Join(params, ", "))
+	}
+	return nil
+}
+
+// checkCommand returns an error if the given command can't be saved
+func checkCommand(command string) error {
+	args, err := splitArgs(command)
+	if err != nil {
+		return err
+	}
+	_, args = config.SplitGlobalArgs(args)
+	if len(args) == 0 {
+		return errors.New("The command to save cannot be empty")
+	}
+	if args[0] == "datica" {
+		return errors.New("Leave out the leading \"datica\" from the command to save")
+	}
+	if args[0] == "saved" {
+		return errors.New("Saved commands cannot run other saved commands")
+	}
+	return nil
+}
+
+func CmdList(is ISaved) error {
+	saved := is.List()
+	if len(saved) == 0 {
+		logrus.Println("No commands have been saved. Save one with \"datica saved add\".")
+		return nil
+	}
+	names := []string{}
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data := [][]string{{"NAME", "COMMAND", "DESCRIPTION"}}
+	for _, name := range names {
+		data = append(data, []string{name, saved[name].Command, saved[name].Description})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+func CmdRm(name string, is ISaved) error {
+	if err := is.Rm(name); err != nil {
+		return err
+	}
+	logrus.Printf("Removed the saved command %s", name)
+	return nil
+}
+
+// CmdRun fills in the placeholders of a saved command with the given
+// param=value pairs and runs it. The environment the saved command is run
+// from is passed along unless the saved command chooses its own.
+func CmdRun(name string, params []string, envName string, is ISaved) error {
+	saved, ok := is.List()[name]
+	if !ok {
+		return fmt.Errorf("No command named \"%s\" has been saved. Run \"datica saved list\" to see your saved commands.", name)
+	}
+	values := map[string]string{}
+	for _, p := range params {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("Invalid parameter \"%s\". Parameters must be given as param=value", p)
+		}
+		values[parts[0]] = parts[1]
+	}
+	args, err := Expand(saved.Command, values)
+	if err != nil {
+		return err
+	}
+	if envName != "" && !hasEnvOpt(args) {
+		args = append([]string{"-E", envName}, args...)
+	}
+	logrus.Debugf("Running the saved command %s: %s", name, strings.Join(args, " "))
+	return is.Run(args)
+}
+
+func CmdExport(filePath string, names []string, is ISaved) error {
+	saved := is.List()
+	exported := map[string]models.SavedCommand{}
+	if len(names) == 0 {
+		exported = saved
+	}
+	for _, name := range names {
+		s, ok := saved[name]
+		if !ok {
+			return fmt.Errorf("No command named \"%s\" has been saved", name)
+		}
+		exported[name] = s
+	}
+	b, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+	if filePath == "" {
+		logrus.Println(string(b))
+		return nil
+	}
+	if err = ioutil.WriteFile(filePath, b, 0644); err != nil {
+		return err
+	}
+	logrus.Printf("Exported %d saved commands to %s", len(exported), filePath)
+	return nil
+}
+
+func CmdImport(filePath string, force bool, is ISaved) error {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	var imported map[string]models.SavedCommand
+	if err = json.Unmarshal(b, &imported); err != nil {
+		return fmt.Errorf("%s is not a file of saved commands: %s", filePath, err)
+	}
+	names := []string{}
+	for name := range imported {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err = checkCommand(imported[name].Command); err != nil {
+			return fmt.Errorf("The saved command %s in %s can't be imported: %s", name, filePath, err)
+		}
+	}
+	saved := is.List()
+	added := 0
+	for _, name := range names {
+		s := imported[name]
+		if current, ok := saved[name]; ok && current.Command != s.Command && !force {
+			config.Warn(config.WarningSkipped, "Skipping %s since a different command is already saved under that name. Use --force to replace it.", name)
+			continue
+		}
+		if err = is.Add(name, s); err != nil {
+			return err
+		}
+		added++
+	}
+	logrus.Printf("Imported %d saved commands from %s", added, filePath)
+	return nil
+}
+
+// Expand splits a command into arguments the way a shell would and fills in
+// its {param} and {param=default} placeholders with the given values. Every
+// placeholder without a value or a default is reported at once.
+func Expand(command string, values map[string]string) ([]string, error) {
+	args, err := splitArgs(command)
+	if err != nil {
+		return nil, err
+	}
+	missing := []string{}
+	for i, arg := range args {
+		args[i] = fill(arg, values, &missing)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("Missing values for the parameters %s. Give them as param=value", strings.Join(missing, ", "))
+	}
+	return args, nil
+}
+
+// Fill fills in the {param} and {param=default} placeholders of a string
+// with the given values
+func Fill(s string, values map[string]string) (string, error) {
+	missing := []string{}
+	filled := fill(s, values, &missing)
+	if len(missing) > 0 {
+		return "", fmt.Errorf("Missing values for the parameters %s", strings.Join(missing, ", "))
+	}
+	return filled, nil
+}
+
+func fill(s string, values map[string]string, missing *[]string) string {
+	return placeholderRegex.ReplaceAllStringFunc(s, func(match string) string {
+		parts := placeholderRegex.FindStringSubmatch(match)
+		if v, ok := values[parts[1]]; ok {
+			return v
+		}
+		if parts[2] != "" {
+			return parts[3]
+		}
+		*missing = append(*missing, parts[1])
+		return match
+	})
+}
+
+// placeholders returns the names of the placeholders in a saved command
+func placeholders(command string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, match := range placeholderRegex.FindAllStringSubmatch(command, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// splitArgs splits a command into arguments the way a shell would, keeping
+// quoted strings together
+func splitArgs(command string) ([]string, error) {
+	args := []string{}
+	var current []rune
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range command {
+		switch {
+		case escaped:
+			current = append(current, r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current = append(current, r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, string(current))
+				current = nil
+				inArg = false
+			}
+		default:
+			current = append(current, r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("The command \"%s\" has an unterminated quote or escape", command)
+	}
+	if inArg {
+		args = append(args, string(current))
+	}
+	return args, nil
+}
+
+// hasEnvOpt returns whether the given arguments choose an environment with
+// the global -E option
+func hasEnvOpt(args []string) bool {
+	for _, arg := range args {
+		if arg == "-E" || arg == "--env" || strings.HasPrefix(arg, "-E=") || strings.HasPrefix(arg, "--env=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Add saves a command under the given name, replacing any command already
+// saved under it
+func (s *SSaved) Add(name string, saved models.SavedCommand) error {
+	if s.Settings.Saved == nil {
+		s.Settings.Saved = map[string]models.SavedCommand{}
+	}
+	s.Settings.Saved[name] = saved
+	return nil
+}
+
+// List returns the saved commands keyed by name
+func (s *SSaved) List() map[string]models.SavedCommand {
+	return s.Settings.Saved
+}
+
+// Rm removes the saved command with the given name
+func (s *SSaved) Rm(name string) error {
+	if _, ok := s.Settings.Saved[name]; !ok {
+		return fmt.Errorf("No command named \"%s\" has been saved", name)
+	}
+	delete(s.Settings.Saved, name)
+	return nil
+}
+
+// Run runs the given arguments as a CLI invocation
+func (s *SSaved) Run(args []string) error {
+	if runner == nil {
+		return errors.New("Saved commands cannot be run from here")
+	}
+	return runner(args)
+}
@@ -0,0 +1,192 @@
+package saved
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var addTests = []struct {
+	name      string
+	command   string
+	force     bool
+	expectErr bool
+}{
+	{"errors", "logs --service {service} --level error --hours {hours=1}", false, false},
+	{"errors", "logs --service api", false, true},
+	{"errors", "logs --service {service} --level error --hours {hours=1}", true, false},
+	{"", "logs", false, true},
+	{"empty", "", false, true},
+	{"quote", "logs --query 'unterminated", false, true},
+	{"prefixed", "datica logs", false, true},
+	{"nested", "saved run errors", false, true},
+	{"nested-env", "-E prod saved run errors", false, true},
+	{"nested-flags", "-y --retries 3 --trace=trace.log saved run errors", false, true},
+	{"global-only", "-E prod", false, true},
+}
+
+func TestAdd(t *testing.T) {
+	settings := &models.Settings{}
+	for _, data := range addTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdAdd(data.name, data.command, "", data.force, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if len(settings.Saved) != 1 {
+		t.Errorf("Expected 1 saved command but found %d", len(settings.Saved))
+	}
+}
+
+func TestRm(t *testing.T) {
+	settings := &models.Settings{Saved: map[string]models.SavedCommand{"errors": {Command: "logs"}}}
+	if err := CmdRm("missing", New(settings)); err == nil {
+		t.Error("Expected an error removing a command that was not saved")
+	}
+	if err := CmdRm("errors", New(settings)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(settings.Saved) != 0 {
+		t.Errorf("Expected no saved commands but found %d", len(settings.Saved))
+	}
+}
+
+var runTests = []struct {
+	name      string
+	params    []string
+	envName   string
+	expected  []string
+	expectErr bool
+}{
+	{"errors", []string{"service=api"}, "", []string{"logs", "--service", "api", "--level", "error", "--hours", "1"}, false},
+	{"errors", []string{"service=api", "hours=6"}, test.Alias, []string{"-E", test.Alias, "logs", "--service", "api", "--level", "error", "--hours", "6"}, false},
+	{"errors", []string{}, "", nil, true},
+	{"errors", []string{"service"}, "", nil, true},
+	{"query", []string{}, test.Alias, []string{"logs", "--query", "level:error AND api", "-E", "other"}, false},
+	{"missing", []string{}, "", nil, true},
+}
+
+func TestRun(t *testing.T) {
+	settings := &models.Settings{Saved: map[string]models.SavedCommand{
+		"errors": {Command: "logs --service {service} --level error --hours {hours=1}"},
+		"query":  {Command: "logs --query \"level:error AND api\" -E other"},
+	}}
+	var ran []string
+	SetRunner(func(args []string) error {
+		ran = args
+		return nil
+	})
+	defer SetRunner(nil)
+	for _, data := range runTests {
+		t.Logf("Data: %+v", data)
+		ran = nil
+
+		// test
+		err := CmdRun(data.name, data.params, data.envName, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(data.expected, ran) {
+			t.Errorf("Expected to run %v but ran %v", data.expected, ran)
+		}
+	}
+}
+
+var splitArgsTests = []struct {
+	command   string
+	expected  []string
+	expectErr bool
+}{
+	{"logs --hours 1", []string{"logs", "--hours", "1"}, false},
+	{"  logs   --query 'a b'  ", []string{"logs", "--query", "a b"}, false},
+	{`logs --query "say \"hi\""`, []string{"logs", "--query", `say "hi"`}, false},
+	{`vars set -v KEY=''`, []string{"vars", "set", "-v", "KEY="}, false},
+	{`logs --query "open`, nil, true},
+	{`logs \`, nil, true},
+}
+
+func TestSplitArgs(t *testing.T) {
+	for _, data := range splitArgsTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		args, err := splitArgs(data.command)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if !data.expectErr && !reflect.DeepEqual(data.expected, args) {
+			t.Errorf("Expected %v but got %v", data.expected, args)
+		}
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	f, err := ioutil.TempFile("", "saved")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	source := &models.Settings{Saved: map[string]models.SavedCommand{
+		"errors": {Command: "logs --level error", Description: "Recent errors"},
+		"status": {Command: "status"},
+	}}
+	if err = CmdExport(f.Name(), []string{"missing"}, New(source)); err == nil {
+		t.Error("Expected an error exporting a command that was not saved")
+	}
+	if err = CmdExport(f.Name(), []string{}, New(source)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	target := &models.Settings{Saved: map[string]models.SavedCommand{"status": {Command: "status --historical"}}}
+	if err = CmdImport(f.Name(), false, New(target)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, "Recent errors", target.Saved["errors"].Description)
+	test.AssertEquals(t, "status --historical", target.Saved["status"].Command)
+
+	if err = CmdImport(f.Name(), true, New(target)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, "status", target.Saved["status"].Command)
+}
+
+func TestImportNested(t *testing.T) {
+	f, err := ioutil.TempFile("", "saved")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	source := &models.Settings{Saved: map[string]models.SavedCommand{
+		"errors": {Command: "logs --level error"},
+		"nested": {Command: "-E prod saved run errors"},
+	}}
+	if err = CmdExport(f.Name(), []string{}, New(source)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	target := &models.Settings{}
+	if err = CmdImport(f.Name(), false, New(target)); err == nil {
+		t.Error("Expected an error importing a saved command that runs another saved command")
+	}
+	if len(target.Saved) != 0 {
+		t.Errorf("Expected nothing to be imported but got %+v", target.Saved)
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/access"
+	"github.com/daticahealth/cli/commands/alerts"
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/commands/capabilities"
+	"github.com/daticahealth/cli/commands/certs"
+	"github.com/daticahealth/cli/commands/metrics"
+	"github.com/daticahealth/cli/commands/vars"
+	"github.com/daticahealth/cli/commands/whoami"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "schema",
+	ShortHelp: "Print the JSON schema of the output of a command",
+	LongHelp: "`schema` prints the [JSON Schema](http://json-schema.org) of the output the given command prints with `--json`, generated from the same types the output is printed from. " +
+		"Use it to validate what your scripts parse and to find out when an update of the CLI changes the output they depend on. " +
+		"Without a command, the commands with `--json` output are listed. " +
+		"Use `--all` to print the schemas of every command keyed by command, which can be saved and compared between versions of the CLI. Here are some sample commands\n\n" +
+		"```\ndatica schema\n" +
+		"datica schema metrics cpu\n" +
+		"datica schema --all > datica-schemas.json\n```",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			command := cmd.StringsArg("COMMAND", []string{}, "The command to print the JSON schema of the output of, such as \"metrics cpu\"")
+			all := cmd.BoolOpt("all", false, "Print the JSON schemas of every command with --json output")
+			cmd.Action = func() {
+				err := CmdSchema(*command, *all, outputs)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[COMMAND...] [--all]"
+		}
+	},
+}
+
+// outputs are the commands with a --json option keyed by the full command
+var outputs = map[string]models.Command{
+	access.Cmd.Name + " " + access.AdviseSubCmd.Name: access.AdviseSubCmd,
+	alerts.Cmd.Name + " " + alerts.ListSubCmd.Name:   alerts.ListSubCmd,
+	audit.Cmd.Name:        audit.Cmd,
+	capabilities.Cmd.Name: capabilities.Cmd,
+	certs.Cmd.Name + " " + certs.InventorySubCmd.Name:      certs.InventorySubCmd,
+	metrics.Cmd.Name + " " + metrics.CPUSubCmd.Name:        metrics.CPUSubCmd,
+	metrics.Cmd.Name + " " + metrics.DiskSubCmd.Name:       metrics.DiskSubCmd,
+	metrics.Cmd.Name + " " + metrics.MemorySubCmd.Name:     metrics.MemorySubCmd,
+	metrics.Cmd.Name + " " + metrics.NetworkInSubCmd.Name:  metrics.NetworkInSubCmd,
+	metrics.Cmd.Name + " " + metrics.NetworkOutSubCmd.Name: metrics.NetworkOutSubCmd,
+	metrics.Cmd.Name + " " + metrics.UsageSubCmd.Name:      metrics.UsageSubCmd,
+	vars.Cmd.Name + " " + vars.ListSubCmd.Name:             vars.ListSubCmd,
+	whoami.Cmd.Name: whoami.Cmd,
+}
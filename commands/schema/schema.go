@@ -0,0 +1,205 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Draft is the version of JSON Schema the schemas are written in
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func CmdSchema(command []string, all bool, commands map[string]models.Command) error {
+	if all && len(command) > 0 {
+		return errors.New("Give either a command or --all, not both")
+	}
+	if all {
+		schemas := map[string]map[string]interface{}{}
+		for name, c := range commands {
+			schemas[name] = Generate(c.JSONOutput)
+		}
+		return printJSON(schemas)
+	}
+	if len(command) == 0 {
+		return list(commands)
+	}
+	name := strings.Join(command, " ")
+	c, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("\"%s\" does not have --json output. Run \"datica schema\" to list the commands that do.", name)
+	}
+	return printJSON(Generate(c.JSONOutput))
+}
+
+func list(commands map[string]models.Command) error {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data := [][]string{{"COMMAND", "DESCRIPTION"}}
+	for _, name := range names {
+		data = append(data, []string{name, commands[name].ShortHelp})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	logrus.Println(string(b))
+	return nil
+}
+
+// Generate returns the JSON schema of the JSON encoding of the given value.
+// Named structs other than the value itself are added to the definitions of
+// the schema and referenced from where they are used.
+func Generate(v interface{}) map[string]interface{} {
+	g := &generator{definitions: map[string]interface{}{}}
+	t := reflect.TypeOf(v)
+	var s map[string]interface{}
+	if t != nil && t.Kind() == reflect.Struct && t != timeType {
+		s = g.object(t)
+	} else {
+		s = g.schema(t)
+	}
+	s["$schema"] = Draft
+	if len(g.definitions) > 0 {
+		s["definitions"] = g.definitions
+	}
+	return s
+}
+
+type generator struct {
+	definitions map[string]interface{}
+}
+
+// schema returns the schema of the given type the way encoding/json encodes
+// it. Pointers, slices, and maps can be encoded as null.
+func (g *generator) schema(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.schema(t.Elem()))
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are encoded as base64 strings
+			return nullable(map[string]interface{}{"type": "string"})
+		}
+		return nullable(map[string]interface{}{"type": "array", "items": g.schema(t.Elem())})
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())})
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := t.String()
+		if _, ok := g.definitions[name]; !ok {
+			// reserve the name first so that recursive types end
+			g.definitions[name] = nil
+			g.definitions[name] = g.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+// object returns the schema of a struct. Fields without omitempty are
+// required, and the fields of embedded structs are promoted as encoding/json
+// does.
+func (g *generator) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	g.fields(t, properties, &required)
+	s := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *generator) fields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			g.fields(f.Type, properties, required)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = g.schema(f.Type)
+		omitEmpty := false
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		if !omitEmpty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// nullable allows the given schema to also be null
+func nullable(s map[string]interface{}) map[string]interface{} {
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []string{typ, "null"}
+		return s
+	}
+	if len(s) == 0 {
+		return s
+	}
+	return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+}
@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/models"
+)
+
+type embedded struct {
+	Region string `json:"region"`
+}
+
+type node struct {
+	Name     string `json:"name"`
+	Children []node `json:"children,omitempty"`
+}
+
+type sample struct {
+	embedded
+	ID       string            `json:"id"`
+	Count    int               `json:"count"`
+	Ratio    float64           `json:"ratio,omitempty"`
+	Enabled  *bool             `json:"enabled"`
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels"`
+	Pod      models.Pod        `json:"pod"`
+	Tree     *node             `json:"tree,omitempty"`
+	Internal string            `json:"-"`
+	secret   string
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(sample{})
+	b, _ := json.Marshal(s)
+	var actual map[string]interface{}
+	json.Unmarshal(b, &actual)
+
+	if actual["$schema"] != Draft {
+		t.Errorf("Expected the draft %s but got %v", Draft, actual["$schema"])
+	}
+	properties := actual["properties"].(map[string]interface{})
+	expected := map[string]string{
+		"region":  `{"type":"string"}`,
+		"id":      `{"type":"string"}`,
+		"count":   `{"type":"integer"}`,
+		"ratio":   `{"type":"number"}`,
+		"enabled": `{"type":["boolean","null"]}`,
+		"created": `{"format":"date-time","type":"string"}`,
+		"labels":  `{"additionalProperties":{"type":"string"},"type":["object","null"]}`,
+		"pod":     `{"$ref":"#/definitions/models.Pod"}`,
+		"tree":    `{"anyOf":[{"$ref":"#/definitions/schema.node"},{"type":"null"}]}`,
+	}
+	if len(properties) != len(expected) {
+		t.Errorf("Expected %d properties but got %d: %v", len(expected), len(properties), properties)
+	}
+	for name, e := range expected {
+		b, _ := json.Marshal(properties[name])
+		if string(b) != e {
+			t.Errorf("Expected the schema of %s to be %s but got %s", name, e, string(b))
+		}
+	}
+	required := []interface{}{"region", "id", "count", "enabled", "created", "labels", "pod"}
+	if !reflect.DeepEqual(required, actual["required"]) {
+		t.Errorf("Expected the required properties %v but got %v", required, actual["required"])
+	}
+	definitions := actual["definitions"].(map[string]interface{})
+	if _, ok := definitions["models.Pod"]; !ok {
+		t.Error("Expected a definition of models.Pod")
+	}
+	tree := definitions["schema.node"].(map[string]interface{})
+	b, _ = json.Marshal(tree["properties"].(map[string]interface{})["children"])
+	if string(b) != `{"items":{"$ref":"#/definitions/schema.node"},"type":["array","null"]}` {
+		t.Errorf("Unexpected schema of a recursive type: %s", string(b))
+	}
+}
+
+func TestOutputs(t *testing.T) {
+	for name, c := range outputs {
+		if c.JSONOutput == nil {
+			t.Errorf("%s does not have a JSONOutput", name)
+			continue
+		}
+		s := Generate(c.JSONOutput)
+		if s["type"] == nil {
+			t.Errorf("Expected the schema of %s to have a type", name)
+		}
+	}
+}
+
+var schemaTests = []struct {
+	command   []string
+	all       bool
+	expectErr bool
+}{
+	{[]string{"metrics", "cpu"}, false, false},
+	{[]string{"audit"}, false, false},
+	{[]string{}, false, false},
+	{[]string{}, true, false},
+	{[]string{"metrics"}, false, true},
+	{[]string{"audit"}, true, true},
+}
+
+func TestSchema(t *testing.T) {
+	for _, data := range schemaTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdSchema(data.command, data.all, outputs)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package serviceaccounts
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "service-accounts",
+	ShortHelp: "Manage the non-human accounts of the given organization used for automation",
+	LongHelp: "The `service-accounts` command manages accounts of your environment's organization that belong to automation, such as CI pipelines, rather than a person. " +
+		"A service account signs in with a token instead of a username and password, has no second factor or interactive login, and keeps working when the people who set it up leave. " +
+		"Its token only has the permissions of the role the account was created with, in the environment it was created in. " +
+		"To run the CLI as a service account, set the " + config.DaticaTokenEnvVar + " environment variable to its token. " +
+		"The service-accounts command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RotateSubCmd.Name, RotateSubCmd.ShortHelp, help.Render(RotateSubCmd.LongHelp), RotateSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var CreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Create a service account and print its token",
+	LongHelp: "`service-accounts create` creates a service account with the role given by `--role` that can only act on the environment given with `-E`. " +
+		"The role can be any built-in or custom role shown by [roles list](#roles-list), so consider a custom role with only the permissions the automation needs. " +
+		"The token of the new account is printed once and can not be shown again, so store it in the secret store of your CI system right away. " +
+		"If it is lost, create a new one with [service-accounts rotate](#service-accounts-rotate). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" service-accounts create ci-deploy --role deploy-only\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name of the new service account, such as the pipeline that uses it")
+			role := subCmd.StringOpt("r role", "", "The name of the role the service account acts with")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdCreate(*name, *role, New(settings), invites.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME --role"
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the service accounts of the given organization",
+	LongHelp: "`service-accounts list` lists the service accounts of your environment's organization along with their role, the environment they act on, who created them, and when each was last used. " +
+		"Accounts that have not been used in a long time are good candidates for [service-accounts rm](#service-accounts-rm). Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" service-accounts list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(settings.Environments, New(settings), invites.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RmSubCmd = models.Command{
+	Name:      "rm",
+	ShortHelp: "Remove a service account",
+	LongHelp: "`service-accounts rm` removes a service account and revokes its token right away, so anything still using the token stops working. " +
+		"The account is given by its name or ID. " +
+		"You will be asked to confirm before the account is removed, use the global `--yes` flag to skip the confirmation. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" service-accounts rm ci-deploy\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name or ID of the service account to remove")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRm(*name, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+var RotateSubCmd = models.Command{
+	Name:      "rotate",
+	ShortHelp: "Replace the token of a service account",
+	LongHelp: "`service-accounts rotate` issues a new token for a service account and revokes the old one right away. " +
+		"Rotate tokens on a regular basis, whenever someone who had access to a token leaves, and whenever a token may have leaked. " +
+		"The account is given by its name or ID. The new token is printed once and can not be shown again, so update the secret store of your CI system with it right away. " +
+		"You will be asked to confirm before the token is replaced, use the global `--yes` flag to skip the confirmation. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" service-accounts rotate ci-deploy\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			name := subCmd.StringArg("NAME", "", "The name or ID of the service account to issue a new token for")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRotate(*name, New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "NAME"
+		}
+	},
+}
+
+// IServiceAccounts
+type IServiceAccounts interface {
+	Create(account *models.ServiceAccount) (*models.ServiceAccount, error)
+	List() (*[]models.ServiceAccount, error)
+	Rm(accountID string) error
+	Rotate(accountID string) (*models.ServiceAccount, error)
+}
+
+// SServiceAccounts is a concrete implementation of IServiceAccounts
+type SServiceAccounts struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IServiceAccounts
+func New(settings *models.Settings) IServiceAccounts {
+	return &SServiceAccounts{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,71 @@
+package serviceaccounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+)
+
+var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+func CmdCreate(name, roleName string, isa IServiceAccounts, ii invites.IInvites) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("Invalid service account name \"%s\". Names may only contain letters, numbers, periods, dashes, and underscores", name)
+	}
+	accounts, err := isa.List()
+	if err != nil {
+		return err
+	}
+	for _, a := range *accounts {
+		if strings.EqualFold(a.Name, name) {
+			return fmt.Errorf("A service account named \"%s\" already exists. To issue a new token for it, run \"datica service-accounts rotate %s\"", a.Name, a.Name)
+		}
+	}
+	role, err := invites.FindRole(roleName, ii)
+	if err != nil {
+		return err
+	}
+	account, err := isa.Create(&models.ServiceAccount{
+		Name:   name,
+		RoleID: role.ID,
+	})
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Created service account %s (ID = %s) with the %s role", account.Name, account.ID, role.Name)
+	printToken(account.Token)
+	return nil
+}
+
+// printToken prints a token that was just issued. Tokens are only returned
+// when they are issued, so this is the only chance to store them.
+func printToken(token string) {
+	logrus.Printf("Store the token below in the secret store of your CI system and give it to the CLI with the %s environment variable. It can not be shown again.", config.DaticaTokenEnvVar)
+	logrus.Println(token)
+}
+
+// Create creates a service account that acts on the associated environment
+func (s *SServiceAccounts) Create(account *models.ServiceAccount) (*models.ServiceAccount, error) {
+	account.EnvironmentID = s.Settings.EnvironmentID
+	b, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+	headers := s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID)
+	resp, statusCode, err := s.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/orgs/%s/service-accounts", s.Settings.AuthHost, s.Settings.AuthHostVersion, s.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var created models.ServiceAccount
+	err = s.Settings.HTTPManager.ConvertResp(resp, statusCode, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
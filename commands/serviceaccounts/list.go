@@ -0,0 +1,92 @@
+package serviceaccounts
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pmylund/sortutil"
+)
+
+func CmdList(envs map[string]models.AssociatedEnv, isa IServiceAccounts, ii invites.IInvites) error {
+	accounts, err := isa.List()
+	if err != nil {
+		return err
+	}
+	if accounts == nil || len(*accounts) == 0 {
+		logrus.Println("No service accounts have been created. Create one with \"datica service-accounts create\"")
+		return nil
+	}
+	roles, err := ii.ListRoles()
+	if err != nil {
+		return err
+	}
+	roleNames := map[int]string{}
+	for _, r := range *roles {
+		roleNames[r.ID] = r.Name
+	}
+	envNames := map[string]string{}
+	for alias, env := range envs {
+		envNames[env.EnvironmentID] = alias
+	}
+	sortutil.AscByField(*accounts, "Name")
+	data := [][]string{{"NAME", "ID", "ROLE", "ENVIRONMENT", "CREATED BY", "CREATED", "LAST USED"}}
+	for _, a := range *accounts {
+		role, ok := roleNames[a.RoleID]
+		if !ok {
+			role = fmt.Sprintf("%d", a.RoleID)
+		}
+		env, ok := envNames[a.EnvironmentID]
+		if !ok {
+			env = a.EnvironmentID
+		}
+		lastUsed := "never"
+		if a.LastUsedAt != "" {
+			lastUsed = config.FormatTimestampString(a.LastUsedAt)
+		}
+		data = append(data, []string{a.Name, a.ID, role, env, a.CreatedBy, config.FormatTimestampString(a.CreatedAt), lastUsed})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// find returns the service account with the given name or ID
+func find(name string, isa IServiceAccounts) (*models.ServiceAccount, error) {
+	accounts, err := isa.List()
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range *accounts {
+		if a.ID == name || a.Name == name {
+			return &(*accounts)[i], nil
+		}
+	}
+	return nil, fmt.Errorf("A service account with the name or ID \"%s\" was not found. You can list service accounts with the \"datica service-accounts list\" command", name)
+}
+
+// List lists the service accounts of the organization
+func (s *SServiceAccounts) List() (*[]models.ServiceAccount, error) {
+	headers := s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID)
+	resp, statusCode, err := s.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/service-accounts", s.Settings.AuthHost, s.Settings.AuthHostVersion, s.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []models.ServiceAccount
+	err = s.Settings.HTTPManager.ConvertResp(resp, statusCode, &accounts)
+	if err != nil {
+		return nil, err
+	}
+	return &accounts, nil
+}
@@ -0,0 +1,35 @@
+package serviceaccounts
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdRm(name string, isa IServiceAccounts, ip prompts.IPrompts) error {
+	account, err := find(name, isa)
+	if err != nil {
+		return err
+	}
+	err = ip.YesNo(fmt.Sprintf("Anything still using the token of %s will stop working. Are you sure you want to remove this service account? (y/n) ", account.Name))
+	if err != nil {
+		return err
+	}
+	err = isa.Rm(account.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Service account %s removed and its token revoked", account.Name)
+	return nil
+}
+
+// Rm removes the service account and revokes its token
+func (s *SServiceAccounts) Rm(accountID string) error {
+	headers := s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID)
+	resp, statusCode, err := s.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/orgs/%s/service-accounts/%s", s.Settings.AuthHost, s.Settings.AuthHostVersion, s.Settings.OrgID, accountID), headers)
+	if err != nil {
+		return err
+	}
+	return s.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
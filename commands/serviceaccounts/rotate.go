@@ -0,0 +1,42 @@
+package serviceaccounts
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdRotate(name string, isa IServiceAccounts, ip prompts.IPrompts) error {
+	account, err := find(name, isa)
+	if err != nil {
+		return err
+	}
+	err = ip.YesNo(fmt.Sprintf("The current token of %s will stop working right away. Are you sure you want to issue a new one? (y/n) ", account.Name))
+	if err != nil {
+		return err
+	}
+	rotated, err := isa.Rotate(account.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Issued a new token for service account %s and revoked the old one", account.Name)
+	printToken(rotated.Token)
+	return nil
+}
+
+// Rotate issues a new token for the service account and revokes the old one
+func (s *SServiceAccounts) Rotate(accountID string) (*models.ServiceAccount, error) {
+	headers := s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID)
+	resp, statusCode, err := s.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/orgs/%s/service-accounts/%s/rotate", s.Settings.AuthHost, s.Settings.AuthHostVersion, s.Settings.OrgID, accountID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var account models.ServiceAccount
+	err = s.Settings.HTTPManager.ConvertResp(resp, statusCode, &account)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
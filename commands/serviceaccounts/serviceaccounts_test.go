@@ -0,0 +1,208 @@
+package serviceaccounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+const accountsList = `[{"id":"sa1","name":"nightly","roleID":7,"environmentId":"` + test.EnvID + `","createdBy":"lead@example.com","createdAt":"2026-10-01T12:00:00Z"}]`
+
+var createTests = []struct {
+	name          string
+	role          string
+	expectCreated bool
+	expectErr     bool
+}{
+	{"ci-deploy", "deploy-only", true, false},
+	{"ci.deploy_2", "Deploy-Only", true, false},
+	{"nightly", "deploy-only", false, true},
+	{"NIGHTLY", "deploy-only", false, true},
+	{"ci deploy", "deploy-only", false, true},
+	{"-ci", "deploy-only", false, true},
+	{"ci-deploy", "superuser", false, true},
+}
+
+func TestCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	created := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":7,"name":"deploy-only","custom":true}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/service-accounts",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				fmt.Fprint(w, accountsList)
+				return
+			}
+			test.AssertEquals(t, r.Method, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			var account models.ServiceAccount
+			json.Unmarshal(b, &account)
+			test.AssertEquals(t, test.EnvID, account.EnvironmentID)
+			test.AssertEquals(t, "7", fmt.Sprintf("%d", account.RoleID))
+			created = true
+			fmt.Fprintf(w, `{"id":"sa2","name":"%s","roleID":7,"token":"token2"}`, account.Name)
+		},
+	)
+
+	for _, data := range createTests {
+		t.Logf("Data: %+v", data)
+		created = false
+
+		// test
+		err := CmdCreate(data.name, data.role, New(settings), invites.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectCreated), fmt.Sprintf("%t", created))
+	}
+}
+
+var listTests = []struct {
+	accounts   string
+	expectRows []string
+}{
+	{accountsList, []string{"NAME", "nightly", "sa1", "deploy-only", "prod", "lead@example.com", "never"}},
+	{`[{"id":"sa3","name":"legacy","roleID":9,"environmentId":"env-other","lastUsedAt":"2026-10-02T12:00:00Z"}]`, []string{"legacy", "sa3", "9", "env-other"}},
+	{`[]`, []string{"No service accounts have been created"}},
+}
+
+func TestList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	accounts := ""
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"admin"},{"id":7,"name":"deploy-only","custom":true}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/service-accounts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, accounts)
+		},
+	)
+	envs := map[string]models.AssociatedEnv{"prod": {EnvironmentID: test.EnvID}}
+
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+		accounts = data.accounts
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdList(envs, New(settings), invites.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+var nameTests = []struct {
+	name      string
+	expectErr bool
+}{
+	{"nightly", false},
+	{"sa1", false},
+	{"unknown", true},
+}
+
+func TestRotate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs/"+test.OrgID+"/service-accounts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, accountsList)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/service-accounts/sa1/rotate",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, `{"id":"sa1","name":"nightly","roleID":7,"token":"token3"}`)
+		},
+	)
+
+	for _, data := range nameTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdRotate(data.name, New(settings), &test.FakePrompts{})
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if !data.expectErr && !strings.Contains(output, "token3") {
+			t.Errorf("Expected the new token to be printed but got %s", output)
+		}
+	}
+}
+
+func TestRm(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	removed := false
+	mux.HandleFunc("/orgs/"+test.OrgID+"/service-accounts",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, accountsList)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/service-accounts/sa1",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			removed = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range nameTests {
+		t.Logf("Data: %+v", data)
+		removed = false
+
+		// test
+		err := CmdRm(data.name, New(settings), &test.FakePrompts{})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", removed))
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/lib/volumes"
 	"github.com/daticahealth/cli/models"
@@ -17,13 +19,17 @@ var Cmd = models.Command{
 	Name:      "services",
 	ShortHelp: "Perform operations on an environment's services",
 	LongHelp:  "The `services` command allows you to manage your services. The services command cannot be run directly but has sub commands.",
+	Category:  models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(StopSubCmd.Name, StopSubCmd.ShortHelp, StopSubCmd.LongHelp, StopSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RenameSubCmd.Name, RenameSubCmd.ShortHelp, RenameSubCmd.LongHelp, RenameSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DescribeSubCmd.Name, DescribeSubCmd.ShortHelp, help.Render(DescribeSubCmd.LongHelp), DescribeSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(StopSubCmd.Name, StopSubCmd.ShortHelp, help.Render(StopSubCmd.LongHelp), StopSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RenameSubCmd.Name, RenameSubCmd.ShortHelp, help.Render(RenameSubCmd.LongHelp), RenameSubCmd.CmdFunc(settings))
 			cmd.Action = func() {
-				logrus.Warnln("This command has been moved! Please use \"datica services list\" instead. This alias will be removed in the next CLI update.")
+				if err := config.Deprecated("This command has been moved! Please use \"datica services list\" instead. This alias will be removed in the next CLI update."); err != nil {
+					logrus.Fatal(err.Error())
+				}
 				logrus.Warnln("You can list all available services subcommands by running \"datica services --help\".")
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -31,11 +37,38 @@ var Cmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdServices(New(settings), volumes.New(settings))
+				err := CmdServices("", New(settings), volumes.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var DescribeSubCmd = models.Command{
+	Name:      "describe",
+	ShortHelp: "Show the details of a service",
+	LongHelp: "`services describe` prints the details of a single service, including its size and DNS name. " +
+		"For code services, the git remote, the current release, and the build stack and runtime versions pinned with the [runtime](#runtime) command are printed as well. " +
+		"Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" services describe app01\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to describe. Defaults to the service pinned by the workspace file.")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdDescribe(config.ServiceName(*serviceName, settings), New(settings), jobs.New(settings), probe.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
+			subCmd.Spec = "[SERVICE_NAME]"
 		}
 	},
 }
@@ -43,13 +76,17 @@ var Cmd = models.Command{
 var ListSubCmd = models.Command{
 	Name:      "list",
 	ShortHelp: "List all services for your environment",
-	LongHelp: "`services list` prints out a list of all services in your environment and their sizes. " +
+	LongHelp: "`services list` prints out a list of all services in your environment along with their type, size, scale, and the number of jobs currently running for each. " +
 		"The services will be printed regardless of their currently running state. " +
 		"To see which services are currently running and which are not, use the [status](#status) command. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" services list\n```",
+		"Use `--filter` to only list services of a given type. " +
+		"The `code` and `database` types match code services and databases, `worker` matches services with running workers, and any other type matches the kind of service shown in the TYPE column, such as `redis`. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" services list\n" +
+		"datica -E \"<your_env_alias>\" services list --filter type=database\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
+			filter := subCmd.StringOpt("filter", "", "Only list services matching the filter, i.e. 'type=code', 'type=database', or 'type=worker'")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -57,11 +94,12 @@ var ListSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdServices(New(settings), volumes.New(settings))
+				err := CmdServices(*filter, New(settings), volumes.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
+			subCmd.Spec = "[--filter]"
 		}
 	},
 }
@@ -69,7 +107,10 @@ var ListSubCmd = models.Command{
 var RenameSubCmd = models.Command{
 	Name:      "rename",
 	ShortHelp: "Rename a service",
-	LongHelp: "`services rename` allows you to rename any service in your environment. Here is a sample command\n\n" +
+	LongHelp: "`services rename` allows you to rename any service in your environment. " +
+		"Scripts and commands refer to services by name, so the old name stops working as soon as the rename succeeds. " +
+		"If the current git repo has a workspace file for this environment, every reference to the old name in it is updated as well, including the pinned service, the services map, and `depends_on` lists. " +
+		"Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" services rename code-1 api-svc\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
@@ -82,7 +123,11 @@ var RenameSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRename(*serviceName, *label, New(settings))
+				workspace, path, err := renameWorkspace(settings)
+				if err != nil {
+					logrus.Fatalf("Could not read the workspace file %s: %s", path, err)
+				}
+				err = CmdRename(*serviceName, *label, workspace, path, New(settings))
 				if err != nil {
 					logrus.Fatalln(err.Error())
 				}
@@ -122,6 +167,7 @@ var StopSubCmd = models.Command{
 
 // IServices
 type IServices interface {
+	Create(service *models.Service) (*models.Service, error)
 	List() (*[]models.Service, error)
 	ListByEnvID(envID, podID string) (*[]models.Service, error)
 	Retrieve(svcID string) (*models.Service, error)
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/daticahealth/cli/models"
+)
+
+func (s *SServices) Create(service *models.Service) (*models.Service, error) {
+	b, err := json.Marshal(service)
+	if err != nil {
+		return nil, err
+	}
+	headers := s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID)
+	resp, statusCode, err := s.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/services", s.Settings.PaasHost, s.Settings.PaasHostVersion, s.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var created models.Service
+	err = s.Settings.HTTPManager.ConvertResp(resp, statusCode, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdDescribe(svcName string, is IServices, ij jobs.IJobs, ip probe.IProbe) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	data := [][]string{
+		{"Name", service.Label},
+		{"ID", service.ID},
+		{"Type", service.Type},
+		{"DNS", service.DNS},
+		{"RAM", config.FormatBytes(float64(service.Size.RAM) * float64(transfer.GB))},
+		{"CPU", fmt.Sprintf("%d", service.Size.CPU)},
+		{"Scale", fmt.Sprintf("%d", service.Scale)},
+	}
+	if ip.Available(probe.RunningJobs) {
+		running, err := ij.RetrieveByStatus(service.ID, "running")
+		if err = ip.Check(probe.RunningJobs, err); err != nil {
+			return err
+		}
+		if running != nil {
+			data = append(data, []string{"Running Jobs", fmt.Sprintf("%d", len(*running))})
+		}
+	}
+	if service.Type == "code" {
+		data = append(data,
+			[]string{"Worker Limit", fmt.Sprintf("%d", service.WorkerScale)},
+			[]string{"Git Remote", service.Source},
+			[]string{"Release", service.ReleaseVersion},
+			[]string{"Runtime Pins", FormatPins(service.Runtime)},
+		)
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	ip.Note()
+	return nil
+}
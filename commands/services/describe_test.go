@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
+	"github.com/daticahealth/cli/test"
+)
+
+var describeTests = []struct {
+	svcName           string
+	jobsStatus        int
+	expectUnavailable bool
+	expectErr         bool
+}{
+	{test.SvcLabel, http.StatusOK, false, false},
+	{test.SvcLabel, http.StatusNotFound, true, false},
+	{test.SvcLabel, http.StatusForbidden, false, true},
+	{"invalid-svc", http.StatusOK, false, true},
+}
+
+func TestDescribe(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	jobsStatus := http.StatusOK
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			test.AssertEquals(t, "running", r.URL.Query().Get("status"))
+			w.WriteHeader(jobsStatus)
+			fmt.Fprint(w, `[{"id":"1","type":"deploy","status":"running"}]`)
+		},
+	)
+
+	for _, data := range describeTests {
+		t.Logf("Data: %+v", data)
+		jobsStatus = data.jobsStatus
+		settings.Unavailable = nil
+		ip := probe.New(settings)
+
+		// test
+		err := CmdDescribe(data.svcName, New(settings), jobs.New(settings), ip)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectUnavailable), fmt.Sprintf("%t", !ip.Available(probe.RunningJobs)))
+	}
+}
@@ -2,15 +2,49 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/transfer"
 	"github.com/daticahealth/cli/lib/volumes"
 	"github.com/daticahealth/cli/models"
 	"github.com/olekukonko/tablewriter"
 )
 
-// CmdServices lists the names of all services for an environment.
-func CmdServices(is IServices, v volumes.IVolumes) error {
+// databaseNames are the kinds of services matched by the database filter
+var databaseNames = map[string]bool{
+	"mongodb":    true,
+	"mysql":      true,
+	"postgresql": true,
+}
+
+// cacheNames are the kinds of services that cache data for code services
+var cacheNames = map[string]bool{
+	"memcached": true,
+	"redis":     true,
+}
+
+// IsDatabase returns whether the service is a database service
+func IsDatabase(service *models.Service) bool {
+	return databaseNames[service.Name]
+}
+
+// IsCache returns whether the service is a cache service
+func IsCache(service *models.Service) bool {
+	return cacheNames[service.Name]
+}
+
+// CmdServices lists the names of all services for an environment along with
+// their size, scale, and the number of jobs running for each. The services
+// can be filtered by type with a filter such as "type=code".
+func CmdServices(filter string, is IServices, v volumes.IVolumes, ij jobs.IJobs) error {
+	match, err := parseFilter(filter)
+	if err != nil {
+		return err
+	}
 	svcs, err := is.List()
 
 	if err != nil {
@@ -20,8 +54,17 @@ func CmdServices(is IServices, v volumes.IVolumes) error {
 		logrus.Println("No services found")
 		return nil
 	}
-	data := [][]string{{"NAME", "DNS", "RAM (GB)", "CPU", "WORKER LIMIT", "SCALE", "STORAGE (GB)"}}
+	data := [][]string{{"NAME", "TYPE", "DNS", "RAM", "CPU", "WORKER LIMIT", "SCALE", "STORAGE", "RUNNING JOBS"}}
 	for _, s := range *svcs {
+		running, err := ij.RetrieveByStatus(s.ID, "running")
+		if err != nil {
+			logrus.Errorf("Failed to retrieve the running jobs for service %s", s.Label)
+			logrus.Debugf("Running jobs error for %s: %s", s.Label, err)
+			running = &[]models.Job{}
+		}
+		if !match(s, *running) {
+			continue
+		}
 
 		vols, err := v.List(s.ID)
 		if err != nil {
@@ -37,12 +80,16 @@ func CmdServices(is IServices, v volumes.IVolumes) error {
 			if i > 0 {
 				volume += ", "
 			}
-			volume += fmt.Sprintf("%d", v.Size)
+			volume += config.FormatBytes(float64(v.Size) * float64(transfer.GB))
 		}
 
-		data = append(data, []string{s.Label, s.DNS, fmt.Sprintf("%d", s.Size.RAM), fmt.Sprintf("%d", s.Size.CPU), fmt.Sprintf("%d", s.WorkerScale), fmt.Sprintf("%d", s.Scale), volume})
+		data = append(data, []string{s.Label, s.Name, s.DNS, config.FormatBytes(float64(s.Size.RAM) * float64(transfer.GB)), fmt.Sprintf("%d", s.Size.CPU), fmt.Sprintf("%d", s.WorkerScale), fmt.Sprintf("%d", s.Scale), volume, fmt.Sprintf("%d", len(*running))})
 
 	}
+	if len(data) == 1 {
+		logrus.Printf("No services match the filter %s", filter)
+		return nil
+	}
 
 	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
 	table.SetBorder(false)
@@ -57,6 +104,38 @@ func CmdServices(is IServices, v volumes.IVolumes) error {
 	return nil
 }
 
+// parseFilter parses a filter such as "type=code" into a function that
+// returns whether a service with the given running jobs matches it. The code
+// and database types match services by kind, worker matches services with
+// running workers, and any other type matches the name of the service kind
+// such as "redis".
+func parseFilter(filter string) (func(s models.Service, running []models.Job) bool, error) {
+	if filter == "" {
+		return func(models.Service, []models.Job) bool { return true }, nil
+	}
+	parts := strings.SplitN(filter, "=", 2)
+	if len(parts) != 2 || parts[0] != "type" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid filter \"%s\". Filters must be of the form type=code, type=database, or type=worker", filter)
+	}
+	switch value := parts[1]; value {
+	case "code":
+		return func(s models.Service, _ []models.Job) bool { return s.Type == "code" }, nil
+	case "database":
+		return func(s models.Service, _ []models.Job) bool { return IsDatabase(&s) }, nil
+	case "worker":
+		return func(_ models.Service, running []models.Job) bool {
+			for _, j := range running {
+				if j.Type == "worker" {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return func(s models.Service, _ []models.Job) bool { return s.Name == value }, nil
+	}
+}
+
 func (s *SServices) List() (*[]models.Service, error) {
 	return s.ListByEnvID(s.Settings.EnvironmentID, s.Settings.Pod)
 }
@@ -103,3 +182,27 @@ func (s *SServices) RetrieveByLabel(label string) (*models.Service, error) {
 	}
 	return service, nil
 }
+
+// FormatPins formats pins for printing such as "stack cedar-14, node 18.19".
+// No pins are formatted as "(none)".
+func FormatPins(pins *models.RuntimePins) string {
+	if pins == nil {
+		return "(none)"
+	}
+	parts := []string{}
+	if pins.Stack != "" {
+		parts = append(parts, "stack "+pins.Stack)
+	}
+	runtimes := []string{}
+	for r := range pins.Versions {
+		runtimes = append(runtimes, r)
+	}
+	sort.Strings(runtimes)
+	for _, r := range runtimes {
+		parts = append(parts, r+" "+pins.Versions[r])
+	}
+	if len(parts) == 0 {
+		return "(none)"
+	}
+	return strings.Join(parts, ", ")
+}
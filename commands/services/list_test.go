@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/volumes"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var filterServices = []struct {
+	service models.Service
+	running []models.Job
+}{
+	{models.Service{Label: "code-1", Type: "code", Name: "code"}, []models.Job{{Type: "deploy"}, {Type: "worker"}}},
+	{models.Service{Label: "code-2", Type: "code", Name: "code"}, []models.Job{{Type: "deploy"}}},
+	{models.Service{Label: "db01", Name: "postgresql"}, []models.Job{{Type: "deploy"}}},
+	{models.Service{Label: "cache", Name: "redis"}, []models.Job{}},
+}
+
+var filterTests = []struct {
+	filter    string
+	expected  string
+	expectErr bool
+}{
+	{"", "code-1,code-2,db01,cache", false},
+	{"type=code", "code-1,code-2", false},
+	{"type=database", "db01", false},
+	{"type=worker", "code-1", false},
+	{"type=redis", "cache", false},
+	{"type=", "", true},
+	{"label=code-1", "", true},
+	{"code", "", true},
+}
+
+func TestParseFilter(t *testing.T) {
+	for _, data := range filterTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		match, err := parseFilter(data.filter)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		matched := ""
+		for _, s := range filterServices {
+			if match(s.service, s.running) {
+				if matched != "" {
+					matched += ","
+				}
+				matched += s.service.Label
+			}
+		}
+		test.AssertEquals(t, data.expected, matched)
+	}
+}
+
+func TestServicesList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","type":"code","name":"code"},{"id":"%s","label":"db01","name":"postgresql"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt)
+		},
+	)
+	for _, svcID := range []string{test.SvcID, test.SvcIDAlt} {
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+svcID+"/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				test.AssertEquals(t, "running", r.URL.Query().Get("status"))
+				fmt.Fprint(w, `[{"id":"1","type":"deploy","status":"running"}]`)
+			},
+		)
+	}
+
+	for _, filter := range []string{"", "type=database", "type=worker"} {
+		if err := CmdServices(filter, New(settings), volumes.New(settings), jobs.New(settings)); err != nil {
+			t.Errorf("Unexpected error for filter %s: %s", filter, err)
+		}
+	}
+	if err := CmdServices("name=db01", New(settings), volumes.New(settings), jobs.New(settings)); err == nil {
+		t.Error("Expected an error for an invalid filter")
+	}
+}
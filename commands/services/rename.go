@@ -5,9 +5,16 @@ import (
 	"fmt"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
 )
 
-func CmdRename(svcName, label string, is IServices) error {
+// CmdRename changes the label of a service. The workspace file, if one is
+// given, is rewritten so that it references the service by its new label.
+func CmdRename(svcName, label string, workspace *models.Workspace, workspacePath string, is IServices) error {
+	if label == "" {
+		return fmt.Errorf("The new name for the service cannot be empty")
+	}
 	service, err := is.RetrieveByLabel(svcName)
 	if err != nil {
 		return err
@@ -15,6 +22,17 @@ func CmdRename(svcName, label string, is IServices) error {
 	if service == nil {
 		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
 	}
+	if svcName == label {
+		logrus.Printf("Your service is already named %s", label)
+		return nil
+	}
+	existing, err := is.RetrieveByLabel(label)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("A service with the label \"%s\" already exists. Please choose a different name.", label)
+	}
 	data := map[string]string{}
 	data["label"] = label
 	err = is.Update(service.ID, data)
@@ -22,9 +40,54 @@ func CmdRename(svcName, label string, is IServices) error {
 		return err
 	}
 	logrus.Printf("Successfully renamed your service to %s", label)
+	if workspace != nil && renameInWorkspace(workspace, svcName, label) {
+		if err = config.SaveWorkspace(workspace, workspacePath); err != nil {
+			return fmt.Errorf("The service was renamed but the workspace file %s could not be updated: %s", workspacePath, err)
+		}
+		logrus.Printf("Updated the references to %s in the workspace file %s", svcName, workspacePath)
+	}
 	return nil
 }
 
+// renameInWorkspace replaces every reference to the old label in the
+// workspace with the new label and reports whether anything changed
+func renameInWorkspace(workspace *models.Workspace, old, label string) bool {
+	changed := false
+	if workspace.Service == old {
+		workspace.Service = label
+		changed = true
+	}
+	if svc, ok := workspace.Services[old]; ok {
+		delete(workspace.Services, old)
+		workspace.Services[label] = svc
+		changed = true
+	}
+	for name, svc := range workspace.Services {
+		for i, dep := range svc.DependsOn {
+			if dep == old {
+				svc.DependsOn[i] = label
+				changed = true
+			}
+		}
+		workspace.Services[name] = svc
+	}
+	return changed
+}
+
+// renameWorkspace returns the workspace file of the current git repo and its
+// path if it pins the environment used for this command, or nil otherwise
+func renameWorkspace(settings *models.Settings) (*models.Workspace, string, error) {
+	workspace, path, err := config.FindWorkspace(".")
+	if err != nil || workspace == nil {
+		return nil, path, err
+	}
+	if workspace.Environment != "" && settings.Environments[workspace.Environment].EnvironmentID != settings.EnvironmentID {
+		logrus.Debugf("Not updating the workspace file %s since it pins the environment %s", path, workspace.Environment)
+		return nil, path, nil
+	}
+	return workspace, path, nil
+}
+
 func (s *SServices) Update(svcID string, updates map[string]string) error {
 	b, err := json.Marshal(updates)
 	if err != nil {
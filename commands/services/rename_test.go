@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var servicesRenameTests = []struct {
+	svcName   string
+	label     string
+	expectErr bool
+}{
+	{test.SvcLabel, "api", false},
+	{test.SvcLabel, test.SvcLabelAlt, true},
+	{test.SvcLabel, "", true},
+	{"invalid-svc-name", "api", true},
+}
+
+func TestServicesRename(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s","name":"code"},{"id":"%s","label":"%s","name":"code"}]`, test.SvcID, test.SvcLabel, test.SvcIDAlt, test.SvcLabelAlt))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID,
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "PUT")
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	dir, err := ioutil.TempDir("", "rename")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, data := range servicesRenameTests {
+		t.Logf("Data: %+v", data)
+
+		workspace := &models.Workspace{
+			Service: test.SvcLabel,
+			Services: map[string]models.WorkspaceService{
+				test.SvcLabel:    {Path: "api"},
+				test.SvcLabelAlt: {Path: "web", DependsOn: []string{test.SvcLabel}},
+			},
+		}
+		path := filepath.Join(dir, config.WorkspaceFile)
+
+		// test
+		err := CmdRename(data.svcName, data.label, workspace, path, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectErr {
+			continue
+		}
+		if workspace.Service != data.label {
+			t.Errorf("Expected the pinned service to be %s but got %s", data.label, workspace.Service)
+		}
+		if _, ok := workspace.Services[data.label]; !ok {
+			t.Errorf("Expected the workspace to map the service %s", data.label)
+		}
+		if _, ok := workspace.Services[data.svcName]; ok {
+			t.Errorf("Expected the workspace to no longer map the service %s", data.svcName)
+		}
+		if deps := workspace.Services[test.SvcLabelAlt].DependsOn; len(deps) != 1 || deps[0] != data.label {
+			t.Errorf("Expected %s to depend on %s but got %v", test.SvcLabelAlt, data.label, deps)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected the workspace file to be written: %s", err)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package sites
 import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/trash"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -18,12 +20,13 @@ var Cmd = models.Command{
 	LongHelp: "The `sites` command gives access to hostname and SSL certificate usage for public facing services. " +
 		"`sites` are different from `certs` in that `sites` use an instance of a `cert` and are associated with a single service. " +
 		"`certs` can be used by multiple sites. The sites command can not be run directly but has sub commands.",
+	Category: models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, CreateSubCmd.LongHelp, CreateSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, RmSubCmd.LongHelp, RmSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, ShowSubCmd.LongHelp, ShowSubCmd.CmdFunc(settings))
+			cmd.CommandLong(CreateSubCmd.Name, CreateSubCmd.ShortHelp, help.Render(CreateSubCmd.LongHelp), CreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ShowSubCmd.Name, ShowSubCmd.ShortHelp, help.Render(ShowSubCmd.LongHelp), ShowSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -116,11 +119,15 @@ var RmSubCmd = models.Command{
 	LongHelp: "`sites rm` allows you to remove a site by name. " +
 		"Since sites cannot be updated, if you want to change the name of a site, you must `rm` the site and then [create](#sites-create) it again. " +
 		"If you simply need to update your SSL certificates, you can use the [certs update](#certs-update) command on the cert instance used by the site in question. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" sites rm mywebsite.com\n```",
+		"Use `--soft` to move the site to the environment's trash instead, where it can be restored with [trash restore](#trash-restore) until it expires. " +
+		"If the platform does not support soft deletes, `--soft` fails and the site is not removed. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" sites rm mywebsite.com\n" +
+		"datica -E \"<your_env_alias>\" sites rm mywebsite.com --soft\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
 			name := subCmd.StringArg("NAME", "", "The name of the site configuration to delete")
+			soft := subCmd.BoolOpt("soft", false, "Move the site to the trash so it can be restored")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -128,12 +135,12 @@ var RmSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRm(*name, New(settings), services.New(settings))
+				err := CmdRm(*name, *soft, New(settings), services.New(settings), trash.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "NAME"
+			subCmd.Spec = "NAME [--soft]"
 		}
 	},
 }
@@ -169,7 +176,7 @@ type ISites interface {
 	Create(name, cert, upstreamServiceID, svcID string, siteValues map[string]interface{}) (*models.Site, error)
 	List(svcID string) (*[]models.Site, error)
 	Retrieve(siteID int, svcID string) (*models.Site, error)
-	Rm(siteID int, svcID string) error
+	Rm(siteID int, svcID string, soft bool) error
 }
 
 // SSites is a concrete implementation of ISites
@@ -5,10 +5,11 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/trash"
 	"github.com/daticahealth/cli/models"
 )
 
-func CmdRm(name string, is ISites, iservices services.IServices) error {
+func CmdRm(name string, soft bool, is ISites, iservices services.IServices, it trash.ITrash) error {
 	serviceProxy, err := iservices.RetrieveByLabel("service_proxy")
 	if err != nil {
 		return err
@@ -27,18 +28,31 @@ func CmdRm(name string, is ISites, iservices services.IServices) error {
 	if site == nil {
 		return fmt.Errorf("Could not find a site with the label \"%s\". You can list sites with the \"datica sites list\" command.", name)
 	}
-	err = is.Rm(site.ID, serviceProxy.ID)
+	if soft {
+		if err = trash.CheckSoft("site", name, it); err != nil {
+			return err
+		}
+	}
+	err = is.Rm(site.ID, serviceProxy.ID, soft)
 	if err != nil {
 		return err
 	}
-	logrus.Println("Site removed")
+	if soft {
+		logrus.Printf("Moved site %s to the trash. Until it expires, it can be restored with \"datica trash restore\".", name)
+	} else {
+		logrus.Println("Site removed")
+	}
 	logrus.Println("To make your changes go live, you must redeploy your service proxy with the \"datica redeploy service_proxy\" command")
 	return nil
 }
 
-func (s *SSites) Rm(siteID int, svcID string) error {
+func (s *SSites) Rm(siteID int, svcID string, soft bool) error {
+	query := ""
+	if soft {
+		query = "?soft=true"
+	}
 	headers := s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID)
-	resp, statusCode, err := s.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/sites/%d", s.Settings.PaasHost, s.Settings.PaasHostVersion, s.Settings.EnvironmentID, svcID, siteID), headers)
+	resp, statusCode, err := s.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/sites/%d%s", s.Settings.PaasHost, s.Settings.PaasHostVersion, s.Settings.EnvironmentID, svcID, siteID, query), headers)
 	if err != nil {
 		return err
 	}
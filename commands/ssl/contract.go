@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
 )
@@ -36,10 +37,11 @@ var Cmd = models.Command{
 	Name:      "ssl",
 	ShortHelp: "Perform operations on local certificates to verify their validity",
 	LongHelp:  "The `ssl` command offers access to subcommands that deal with SSL certificates. You cannot run the SSL command directly but must call a subcommand.",
+	Category:  models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(ResolveSubCmd.Name, ResolveSubCmd.ShortHelp, ResolveSubCmd.LongHelp, ResolveSubCmd.CmdFunc(settings))
-			cmd.CommandLong(VerifySubCmd.Name, VerifySubCmd.ShortHelp, VerifySubCmd.LongHelp, VerifySubCmd.CmdFunc(settings))
+			cmd.CommandLong(ResolveSubCmd.Name, ResolveSubCmd.ShortHelp, help.Render(ResolveSubCmd.LongHelp), ResolveSubCmd.CmdFunc(settings))
+			cmd.CommandLong(VerifySubCmd.Name, VerifySubCmd.ShortHelp, help.Render(VerifySubCmd.LongHelp), VerifySubCmd.CmdFunc(settings))
 		}
 	},
 }
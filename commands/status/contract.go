@@ -7,6 +7,7 @@ import (
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -21,6 +22,7 @@ var Cmd = models.Command{
 		"This includes your environment name, environment ID, and for each service the name, size, build status, deploy status, and service ID. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" status\ndatica -E \"<your_env_alias>\" status --historical\n```",
+	Category: models.CategoryObservability,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			historical := cmd.BoolOpt("historical", false, "If this option is specified, a complete history of jobs will be reported")
@@ -50,6 +52,7 @@ type IStatus interface {
 type SStatus struct {
 	Settings *models.Settings
 	Jobs     jobs.IJobs
+	Probe    probe.IProbe
 }
 
 // New returns an instance of IStatus
@@ -57,5 +60,6 @@ func New(settings *models.Settings, ij jobs.IJobs) IStatus {
 	return &SStatus{
 		Settings: settings,
 		Jobs:     ij,
+		Probe:    probe.New(settings),
 	}
 }
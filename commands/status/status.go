@@ -4,16 +4,15 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
-	"time"
 
 	"github.com/daticahealth/cli/commands/environments"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/probe"
 	"github.com/daticahealth/cli/models"
 	"github.com/pmylund/sortutil"
 )
 
-const dateForm = "2006-01-02T15:04:05"
-
 var historicalStatus = map[string]bool{
 	"finished":    true,
 	"failed":      true,
@@ -56,13 +55,13 @@ func (s *SStatus) Status(env *models.Environment, services *[]models.Service, hi
 				displayType := service.Label
 				if job.Type != "deploy" {
 					displayType = fmt.Sprintf("%s (%s)", service.Label, job.Type)
-					if job.Type == "worker" {
+					if job.Type == "worker" && s.Probe.Available(probe.WorkerTargets) {
 						// fetch the worker separately to get the procfile target run
 						workerJob, err := s.Jobs.Retrieve(job.ID, service.ID, true)
-						if err != nil {
+						if err = s.Probe.Check(probe.WorkerTargets, err); err != nil {
 							return err
 						}
-						if workerJob.Spec != nil && workerJob.Spec.Payload != nil && workerJob.Spec.Payload.Environment != nil {
+						if workerJob != nil && workerJob.Spec != nil && workerJob.Spec.Payload != nil && workerJob.Spec.Payload.Environment != nil {
 							if target, contains := workerJob.Spec.Payload.Environment["PROCFILE_TARGET"]; contains {
 								displayType = fmt.Sprintf("%s (%s: target=%s)", service.Label, job.Type, target)
 							}
@@ -72,14 +71,16 @@ func (s *SStatus) Status(env *models.Environment, services *[]models.Service, hi
 					displayType = fmt.Sprintf("%s (git:%s)", service.Label, service.ReleaseVersion)
 				}
 
-				t, _ := time.Parse(dateForm, job.CreatedAt)
-				fmt.Fprintln(w, displayType+"\t"+job.Status+"\t"+t.Local().Format(time.Stamp))
+				fmt.Fprintln(w, displayType+"\t"+job.Status+"\t"+config.FormatTimestampString(job.CreatedAt))
 			}
-			if service.Type == "code" {
+			if service.Type == "code" && s.Probe.Available(probe.BuildJobs) {
 				latestBuildJobs, err := s.Jobs.RetrieveByType(service.ID, "build", 1, 1)
-				if err != nil {
+				if err = s.Probe.Check(probe.BuildJobs, err); err != nil {
 					return err
 				}
+				if latestBuildJobs == nil {
+					continue
+				}
 				for _, latestBuildJob := range *latestBuildJobs {
 					if !historical && historicalStatus[latestBuildJob.Status] {
 						continue
@@ -87,14 +88,14 @@ func (s *SStatus) Status(env *models.Environment, services *[]models.Service, hi
 					if latestBuildJob.ID == "" {
 						fmt.Fprintln(w, "--------"+"\t"+service.Label+"\t"+"-------"+"\t"+"---------------")
 					} else if latestBuildJob.ID != "" {
-						t, _ := time.Parse(dateForm, latestBuildJob.CreatedAt)
 						displayType := fmt.Sprintf("%s (%s)", service.Label, latestBuildJob.Type)
-						fmt.Fprintln(w, displayType+"\t"+latestBuildJob.Status+"\t"+t.Local().Format(time.Stamp))
+						fmt.Fprintln(w, displayType+"\t"+latestBuildJob.Status+"\t"+config.FormatTimestampString(latestBuildJob.CreatedAt))
 					}
 				}
 			}
 		}
 	}
 	w.Flush()
+	s.Probe.Note()
 	return nil
 }
@@ -0,0 +1,53 @@
+package strict
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "strict",
+	ShortHelp: "Make deprecated usage fail instead of warning",
+	LongHelp: "`strict` turns strict mode on or off. " +
+		"In strict mode, every use of a deprecated command, flag, output format, or environment variable fails instead of printing a warning. " +
+		"This lets platform teams find and remove deprecated usage from their scripts and automation before support for it ends. " +
+		"If neither `on` nor `off` is given, whether strict mode is on is printed. " +
+		"Strict mode can also be turned on for a single command with the global `--strict` flag or the `DATICA_STRICT` environment variable. Here are some sample commands\n\n" +
+		"```\ndatica strict on\n" +
+		"datica strict off\n" +
+		"datica --strict -E \"<your_env_alias>\" metrics cpu --spark\n```",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			mode := cmd.StringArg("MODE", "", "Whether strict mode is \"on\" or \"off\"")
+			cmd.Action = func() {
+				err := CmdStrict(*mode, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[MODE]"
+		}
+	},
+}
+
+// IStrict
+type IStrict interface {
+	Get() bool
+	Set(strict bool)
+}
+
+// SStrict is a concrete implementation of IStrict
+type SStrict struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IStrict
+func New(settings *models.Settings) IStrict {
+	return &SStrict{
+		Settings: settings,
+	}
+}
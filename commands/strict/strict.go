@@ -0,0 +1,40 @@
+package strict
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+)
+
+func CmdStrict(mode string, is IStrict) error {
+	switch strings.ToLower(mode) {
+	case "":
+		if is.Get() {
+			logrus.Println("Strict mode is on, use of deprecated commands, flags, and output formats fails")
+		} else {
+			logrus.Println("Strict mode is off, use of deprecated commands, flags, and output formats prints a warning")
+		}
+		return nil
+	case "on":
+		is.Set(true)
+		logrus.Println("Strict mode is now on, use of deprecated commands, flags, and output formats will fail")
+		return nil
+	case "off":
+		is.Set(false)
+		logrus.Println("Strict mode is now off, use of deprecated commands, flags, and output formats will print a warning")
+		return nil
+	default:
+		return fmt.Errorf("Invalid mode \"%s\". The mode must be \"on\" or \"off\"", mode)
+	}
+}
+
+// Get returns whether strict mode is saved as on
+func (s *SStrict) Get() bool {
+	return s.Settings.Strict
+}
+
+// Set saves whether strict mode is on
+func (s *SStrict) Set(strict bool) {
+	s.Settings.Strict = strict
+}
@@ -15,6 +15,7 @@ var Cmd = models.Command{
 		"If you are having an issue with a CLI command or anything with your environment, it is helpful to run this command and copy the output into the initial correspondence with a Datica engineer. " +
 		"This will help Datica identify the environment faster and help come to resolution faster. Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" support-ids\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			cmd.Action = func() {
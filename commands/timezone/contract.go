@@ -0,0 +1,52 @@
+package timezone
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "timezone",
+	ShortHelp: "Set the default timezone timestamps are printed in",
+	LongHelp: "`timezone` sets the default timezone used for every timestamp the CLI prints, including logs, job times, and backup times. " +
+		"The timezone can be `local`, `UTC`, or any timezone name such as `America/Chicago`. " +
+		"If no timezone is given, the current default is printed. " +
+		"The default can be overridden for a single command with the global `--utc` or `--timezone` flags or the `DATICA_TIMEZONE` environment variable. Here are some sample commands\n\n" +
+		"```\ndatica timezone UTC\n" +
+		"datica timezone local\n" +
+		"datica --timezone America/Chicago logs\n```",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			tz := cmd.StringArg("TIMEZONE", "", "The timezone to print timestamps in (i.e. 'UTC' or 'America/Chicago')")
+			cmd.Action = func() {
+				err := CmdTimezone(*tz, New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			cmd.Spec = "[TIMEZONE]"
+		}
+	},
+}
+
+// ITimezone
+type ITimezone interface {
+	Get() string
+	Set(tz string) error
+}
+
+// STimezone is a concrete implementation of ITimezone
+type STimezone struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of ITimezone
+func New(settings *models.Settings) ITimezone {
+	return &STimezone{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,42 @@
+package timezone
+
+import (
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+)
+
+func CmdTimezone(tz string, it ITimezone) error {
+	if tz == "" {
+		current := it.Get()
+		if current == "" {
+			current = "local"
+		}
+		logrus.Printf("Timestamps are printed in the %s timezone", current)
+		return nil
+	}
+	err := it.Set(tz)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Timestamps will now be printed in the %s timezone", tz)
+	return nil
+}
+
+// Get returns the default timezone. An empty string means the local timezone.
+func (t *STimezone) Get() string {
+	return t.Settings.Timezone
+}
+
+// Set validates and stores the default timezone
+func (t *STimezone) Set(tz string) error {
+	if _, err := config.ParseTimezone(tz); err != nil {
+		return err
+	}
+	if strings.ToLower(tz) == "local" {
+		tz = ""
+	}
+	t.Settings.Timezone = tz
+	return nil
+}
@@ -0,0 +1,98 @@
+package trash
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "trash",
+	ShortHelp: "List and restore soft deleted environment variables, sites, and invites",
+	LongHelp: "The `trash` command gives access to the environment variables, sites, and invites removed with the `--soft` flag of [vars unset](#vars-unset), [sites rm](#sites-rm), and [invites rm](#invites-rm). " +
+		"Soft deleted items are kept in the trash for a grace window set by the platform, after which they are removed for good. " +
+		"The trash command can not be run directly but has sub commands.",
+	Category: models.CategoryEnvironment,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RestoreSubCmd.Name, RestoreSubCmd.ShortHelp, help.Render(RestoreSubCmd.LongHelp), RestoreSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List the soft deleted items that can still be restored",
+	LongHelp: "`trash list` lists the soft deleted environment variables and sites of the environment and invites of its organization, along with who removed them and when they expire. " +
+		"Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" trash list\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdList(New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+		}
+	},
+}
+
+var RestoreSubCmd = models.Command{
+	Name:      "restore",
+	ShortHelp: "Restore a soft deleted item",
+	LongHelp: "`trash restore` puts back a soft deleted environment variable, site, or invite found by its ID as shown by [trash list](#trash-list). " +
+		"Restored environment variables and sites, like new ones, only take effect once the service is redeployed. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" trash restore 5a8fbb91-2a68-4a2a-9b54-6ac6e4b9e1c3\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			id := subCmd.StringArg("ID", "", "The ID of the item to restore")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRestore(*id, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "ID"
+		}
+	},
+}
+
+// ITrash
+type ITrash interface {
+	List() (*[]models.TrashItem, error)
+	Restore(item *models.TrashItem) error
+	Supported(itemType string) (bool, error)
+}
+
+// STrash is a concrete implementation of ITrash
+type STrash struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of ITrash
+func New(settings *models.Settings) ITrash {
+	return &STrash{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,165 @@
+package trash
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdList(it ITrash, is services.IServices) error {
+	items, err := it.List()
+	if err != nil {
+		return err
+	}
+	if items == nil || len(*items) == 0 {
+		logrus.Println("The trash is empty")
+		return nil
+	}
+	labels, err := serviceLabels(is)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{{"ID", "TYPE", "NAME", "SERVICE", "DELETED BY", "DELETED", "EXPIRES"}}
+	for _, item := range *items {
+		data = append(data, []string{item.ID, item.Type, item.Name, labels[item.ServiceID], item.DeletedBy, config.FormatTimestampString(item.DeletedAt), config.FormatTimestampString(item.ExpiresAt)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+func CmdRestore(id string, it ITrash, is services.IServices) error {
+	items, err := it.List()
+	if err != nil {
+		return err
+	}
+	var item *models.TrashItem
+	for i := range *items {
+		if (*items)[i].ID == id {
+			item = &(*items)[i]
+			break
+		}
+	}
+	if item == nil {
+		return fmt.Errorf("Could not find an item with the ID \"%s\" in the trash. It may have expired. You can list the trash with the \"datica trash list\" command.", id)
+	}
+	if err = it.Restore(item); err != nil {
+		return err
+	}
+	switch item.Type {
+	case "var":
+		labels, err := serviceLabels(is)
+		if err != nil {
+			return err
+		}
+		logrus.Printf("Restored the environment variable %s. For it to take effect, you will need to redeploy your service with \"datica redeploy %s\"", item.Name, labels[item.ServiceID])
+	case "site":
+		logrus.Printf("Restored the site %s. To make your changes go live, you must redeploy your service proxy with the \"datica redeploy service_proxy\" command", item.Name)
+	default:
+		logrus.Printf("Restored the %s %s", item.Type, item.Name)
+	}
+	return nil
+}
+
+// serviceLabels maps the IDs of the services in the environment to their
+// labels
+func serviceLabels(is services.IServices) (map[string]string, error) {
+	svcs, err := is.List()
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{}
+	for _, s := range *svcs {
+		labels[s.ID] = s.Label
+	}
+	return labels, nil
+}
+
+// List returns the soft deleted environment variables and sites of the
+// associated environment followed by the soft deleted invites of its
+// organization
+func (t *STrash) List() (*[]models.TrashItem, error) {
+	headers := t.Settings.HTTPManager.GetHeaders(t.Settings.SessionToken, t.Settings.Version, t.Settings.Pod, t.Settings.UsersID)
+	resp, statusCode, err := t.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/trash", t.Settings.PaasHost, t.Settings.PaasHostVersion, t.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var items []models.TrashItem
+	err = t.Settings.HTTPManager.ConvertResp(resp, statusCode, &items)
+	if err != nil {
+		return nil, err
+	}
+	resp, statusCode, err = t.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/trash", t.Settings.AuthHost, t.Settings.AuthHostVersion, t.Settings.OrgID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var orgItems []models.TrashItem
+	err = t.Settings.HTTPManager.ConvertResp(resp, statusCode, &orgItems)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, orgItems...)
+	return &items, nil
+}
+
+// Restore restores a soft deleted item. Invites are restored through the
+// organization and everything else through the environment.
+func (t *STrash) Restore(item *models.TrashItem) error {
+	url := fmt.Sprintf("%s%s/environments/%s/trash/%s/restore", t.Settings.PaasHost, t.Settings.PaasHostVersion, t.Settings.EnvironmentID, item.ID)
+	if item.Type == "invite" {
+		url = fmt.Sprintf("%s%s/orgs/%s/trash/%s/restore", t.Settings.AuthHost, t.Settings.AuthHostVersion, t.Settings.OrgID, item.ID)
+	}
+	headers := t.Settings.HTTPManager.GetHeaders(t.Settings.SessionToken, t.Settings.Version, t.Settings.Pod, t.Settings.UsersID)
+	resp, statusCode, err := t.Settings.HTTPManager.Post(nil, url, headers)
+	if err != nil {
+		return err
+	}
+	return t.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
+
+// Supported returns whether items of the given type, such as "var" or
+// "invite", can be soft deleted. Pods that have no trash ignore a request to
+// soft delete and remove the item for good, so support is checked first.
+func (t *STrash) Supported(itemType string) (bool, error) {
+	url := fmt.Sprintf("%s%s/environments/%s/trash", t.Settings.PaasHost, t.Settings.PaasHostVersion, t.Settings.EnvironmentID)
+	if itemType == "invite" {
+		url = fmt.Sprintf("%s%s/orgs/%s/trash", t.Settings.AuthHost, t.Settings.AuthHostVersion, t.Settings.OrgID)
+	}
+	headers := t.Settings.HTTPManager.GetHeaders(t.Settings.SessionToken, t.Settings.Version, t.Settings.Pod, t.Settings.UsersID)
+	resp, statusCode, err := t.Settings.HTTPManager.Get(nil, url, headers)
+	if err != nil {
+		return false, err
+	}
+	err = t.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+	if httpclient.IsMissingEndpoint(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CheckSoft returns an error if items of the given type can not be soft
+// deleted, so that --soft never silently removes an item for good
+func CheckSoft(itemType, name string, it ITrash) error {
+	supported, err := it.Supported(itemType)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("This platform does not support soft deletes, so %s can not be moved to the trash. Remove it without --soft to delete it for good.", name)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package trash
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+var listTests = []struct {
+	envTrash    string
+	orgTrash    string
+	expectItems int
+	expectRows  []string
+}{
+	{
+		`[{"id":"var1","type":"var","name":"API_KEY","service":"` + test.SvcID + `","deleted_by":"user@example.com","deleted_at":"2026-10-16T12:00:00Z","expires_at":"2026-10-23T12:00:00Z"},{"id":"site1","type":"site","name":"example.com","deleted_at":"2026-10-16T12:00:00Z","expires_at":"2026-10-23T12:00:00Z"}]`,
+		`[{"id":"invite1","type":"invite","name":"user@example.com","deleted_at":"2026-10-16T12:00:00Z","expires_at":"2026-10-23T12:00:00Z"}]`,
+		3,
+		[]string{"ID", "var1", "API_KEY", test.SvcLabel, "site1", "example.com", "invite1"},
+	},
+	{`[]`, `[]`, 0, []string{"The trash is empty"}},
+}
+
+func TestList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	envTrash, orgTrash := "", ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/trash",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, envTrash)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/trash",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, orgTrash)
+		},
+	)
+
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+		envTrash, orgTrash = data.envTrash, data.orgTrash
+
+		// test
+		items, err := New(settings).List()
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		output := test.CaptureOutput(func() {
+			err = CmdList(New(settings), services.New(settings))
+		})
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectItems), fmt.Sprintf("%d", len(*items)))
+		if data.expectItems > 0 {
+			test.AssertEquals(t, "invite1", (*items)[data.expectItems-1].ID)
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+	}
+}
+
+var restoreTests = []struct {
+	id         string
+	expectPath string
+	expectErr  bool
+}{
+	{"var1", "/environments/" + test.EnvID + "/trash/var1/restore", false},
+	{"site1", "/environments/" + test.EnvID + "/trash/site1/restore", false},
+	{"invite1", "/orgs/" + test.OrgID + "/trash/invite1/restore", false},
+	{"expired", "", true},
+}
+
+func TestRestore(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	envTrash := listTests[0].envTrash
+	orgTrash := listTests[0].orgTrash
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/trash",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, envTrash)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/trash",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, orgTrash)
+		},
+	)
+	restored := []string{}
+	restore := func(w http.ResponseWriter, r *http.Request) {
+		test.AssertEquals(t, r.Method, "POST")
+		restored = append(restored, r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	}
+	mux.HandleFunc("/environments/"+test.EnvID+"/trash/", restore)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/trash/", restore)
+
+	for _, data := range restoreTests {
+		t.Logf("Data: %+v", data)
+		restored = []string{}
+
+		// test
+		err := CmdRestore(data.id, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if data.expectPath == "" {
+			if len(restored) != 0 {
+				t.Errorf("Expected nothing to be restored, actual: %v", restored)
+			}
+		} else if len(restored) != 1 || restored[0] != data.expectPath {
+			t.Errorf("Expected %s to be restored, actual: %v", data.expectPath, restored)
+		}
+	}
+}
+
+var checkSoftTests = []struct {
+	itemType   string
+	statusCode int
+	expectErr  bool
+}{
+	{"var", 200, false},
+	{"invite", 200, false},
+	{"var", 405, true},
+	{"site", 501, true},
+	{"invite", 403, true},
+}
+
+func TestCheckSoft(t *testing.T) {
+	for _, data := range checkSoftTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		settings.AuthHost = baseURL.String()
+		path := "/environments/" + test.EnvID + "/trash"
+		if data.itemType == "invite" {
+			path = "/orgs/" + test.OrgID + "/trash"
+		}
+		mux.HandleFunc(path,
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				w.WriteHeader(data.statusCode)
+				fmt.Fprint(w, `[]`)
+			},
+		)
+
+		// test
+		err := CheckSoft(data.itemType, "item", New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.Teardown(server)
+	}
+}
@@ -14,16 +14,22 @@ var Cmd = models.Command{
 	LongHelp: "`update` is a shortcut to update your CLI instantly. " +
 		"If a newer version of the CLI is available, it will be downloaded and installed automatically. " +
 		"This is used when you want to apply an update before the CLI automatically applies it on its own. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica update\n```",
+		"The new version is verified against its published checksum and, for release builds, its signature before it replaces the current executable. " +
+		"If verification fails, the current executable is left untouched. " +
+		"Use `--check` to only report whether a newer version is available without installing it. Here are some sample commands\n\n" +
+		"```\ndatica update\n" +
+		"datica update --check\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
+			check := cmd.BoolOpt("check", false, "Only check whether a newer version is available")
 			cmd.Action = func() {
-				err := CmdUpdate(New(settings))
+				err := CmdUpdate(*check, New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
+			cmd.Spec = "[--check]"
 		}
 	},
 }
@@ -10,12 +10,20 @@ import (
 	"github.com/daticahealth/cli/lib/updater"
 )
 
-func CmdUpdate(iu IUpdate) error {
+func CmdUpdate(check bool, iu IUpdate) error {
 	logrus.Println("Checking for available updates...")
 	needsUpdate, err := iu.Check()
 	if err != nil {
 		return err
 	}
+	if check {
+		if needsUpdate {
+			logrus.Printf("Version %s is available, you are running version %s. Run \"datica update\" to update your CLI", updater.AutoUpdater.Info.Version, updater.AutoUpdater.CurrentVersion)
+		} else {
+			logrus.Println("You are already running the latest version of the Datica CLI")
+		}
+		return nil
+	}
 	// check if we can overwrite exe
 	if needsUpdate && (runtime.GOOS == "linux" || runtime.GOOS == "darwin") {
 		err = verifyExeDirWriteable()
@@ -42,17 +50,19 @@ func exeGenericError() error {
 }
 
 func (u *SUpdate) Check() (bool, error) {
-	updater.AutoUpdater.FetchInfo()
+	if err := updater.AutoUpdater.FetchInfo(); err != nil {
+		return false, err
+	}
 	if updater.AutoUpdater.CurrentVersion >= updater.AutoUpdater.Info.Version {
 		return false, nil
 	}
 	return true, nil
 }
 
-// Update updates the  CLI if a new update is available.
+// Update updates the CLI if a new update is available. The new binary is
+// verified before it replaces the current one.
 func (u *SUpdate) Update() error {
-	updater.AutoUpdater.ForcedUpgrade()
-	return nil
+	return updater.AutoUpdater.ForcedUpgrade()
 }
 
 // UpdatePods retrieves the latest list of pods and refreshes the local cache. If an error occurs,
@@ -5,6 +5,7 @@ import (
 	"github.com/daticahealth/cli/commands/invites"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -16,11 +17,13 @@ var Cmd = models.Command{
 	Name:      "users",
 	ShortHelp: "Manage users who have access to the given organization",
 	LongHelp: "The `users` command allows you to manage who has access to your environment through the organization that owns the environment. " +
-		"The users command can not be run directly but has three sub commands.",
+		"The users command can not be run directly but has sub commands.",
+	Category: models.CategoryAccess,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, RmSubCmd.LongHelp, RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(UpdateSubCmd.Name, UpdateSubCmd.ShortHelp, help.Render(UpdateSubCmd.LongHelp), UpdateSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -30,6 +33,7 @@ var ListSubCmd = models.Command{
 	ShortHelp: "List all users who have access to the given organization",
 	LongHelp: "`users list` shows every user that belongs to your environment's organization. " +
 		"Users who belong to your environment's organization may access to your environment's services and data depending on their role in the organization. " +
+		"For each user, the role, groups, time of the last sign in, and whether multi-factor authentication is enabled are shown so access can be audited. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" users list\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
@@ -55,6 +59,7 @@ var RmSubCmd = models.Command{
 	ShortHelp: "Revoke access to the given organization for the given user",
 	LongHelp: "`users rm` revokes a users access to your environment's organization. " +
 		"Revoking a user's access to your environment's organization will revoke their access to your organization's environments. " +
+		"You will be asked to confirm before the user is removed, use the global `--yes` flag to skip the confirmation. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" users rm user@example.com\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
@@ -67,7 +72,7 @@ var RmSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRm(*email, New(settings))
+				err := CmdRm(*email, New(settings), prompts.New())
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
@@ -77,10 +82,40 @@ var RmSubCmd = models.Command{
 	},
 }
 
+var UpdateSubCmd = models.Command{
+	Name:      "update",
+	ShortHelp: "Change the role of a user in the given organization",
+	LongHelp: "`users update` changes the role a user has in your environment's organization to the role given by `--role`. " +
+		"The role can be any built-in or custom role your organization defines, run [roles list](#roles-list) to see them and [roles create](#roles-create) to define a new one. " +
+		"To give a user a role for a limited time only, use [access grant](#access-grant) instead. " +
+		"Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" users update user@example.com --role deployer\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			email := subCmd.StringArg("EMAIL", "", "The email address of the user to update")
+			role := subCmd.StringOpt("r role", "", "The name of the role to give the user")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdUpdate(*email, *role, New(settings), invites.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "EMAIL --role"
+		}
+	},
+}
+
 // IUsers
 type IUsers interface {
 	List() (*[]models.OrgUser, error)
 	Rm(usersID string) error
+	UpdateRole(usersID string, roleID int) error
 }
 
 // SUsers is a concrete implementation of IUsers
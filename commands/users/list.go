@@ -2,10 +2,12 @@ package users
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/models"
 	"github.com/olekukonko/tablewriter"
 )
@@ -23,6 +25,14 @@ func CmdList(myUsersID string, iu IUsers, ii invites.IInvites) error {
 	if err != nil {
 		return err
 	}
+	roles, err := ii.ListRoles()
+	if err != nil {
+		return err
+	}
+	roleNames := map[int]string{}
+	for _, r := range *roles {
+		roleNames[r.ID] = r.Name
+	}
 	members := make(map[string][]string)
 	for _, group := range *orgGroups {
 		groupMembers := group.Members
@@ -30,13 +40,25 @@ func CmdList(myUsersID string, iu IUsers, ii invites.IInvites) error {
 			members[member.Email] = append(members[member.Email], group.Name)
 		}
 	}
-	data := [][]string{{"EMAIL", "GROUP(S)"}}
+	data := [][]string{{"EMAIL", "ROLE", "GROUP(S)", "LAST SIGN IN", "MFA"}}
 	for _, user := range *orgUsers {
+		role, ok := roleNames[user.RoleID]
+		if !ok {
+			role = strconv.Itoa(user.RoleID)
+		}
+		groups := "none"
 		if val, ok := members[user.Email]; ok {
-			data = append(data, []string{user.Email, strings.Join(val, ", ")})
-		} else {
-			data = append(data, []string{user.Email, "none"})
+			groups = strings.Join(val, ", ")
+		}
+		lastLogin := "never"
+		if user.LastLogin != "" {
+			lastLogin = config.FormatTimestampString(user.LastLogin)
+		}
+		mfa := "disabled"
+		if user.MFAEnabled {
+			mfa = "enabled"
 		}
+		data = append(data, []string{user.Email, role, groups, lastLogin, mfa})
 	}
 	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
 	table.SetBorder(false)
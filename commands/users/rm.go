@@ -4,9 +4,10 @@ import (
 	"fmt"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/prompts"
 )
 
-func CmdRm(email string, iu IUsers) error {
+func CmdRm(email string, iu IUsers, ip prompts.IPrompts) error {
 	orgUsers, err := iu.List()
 	if err != nil {
 		return err
@@ -21,6 +22,10 @@ func CmdRm(email string, iu IUsers) error {
 	if usersID == "" {
 		return fmt.Errorf("A user with email %s was not found", email)
 	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to remove %s from your environment's organization? They will lose access to all of the organization's environments. (y/n) ", email))
+	if err != nil {
+		return err
+	}
 
 	err = iu.Rm(usersID)
 	if err != nil {
@@ -0,0 +1,57 @@
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/invites"
+)
+
+func CmdUpdate(email, roleName string, iu IUsers, ii invites.IInvites) error {
+	orgUsers, err := iu.List()
+	if err != nil {
+		return err
+	}
+	usersID := ""
+	roleID := 0
+	for _, u := range *orgUsers {
+		if strings.EqualFold(u.Email, email) {
+			usersID = u.ID
+			roleID = u.RoleID
+			break
+		}
+	}
+	if usersID == "" {
+		return fmt.Errorf("A user with email %s was not found", email)
+	}
+	role, err := invites.FindRole(roleName, ii)
+	if err != nil {
+		return err
+	}
+	if roleID == role.ID {
+		logrus.Printf("%s already has the %s role", email, role.Name)
+		return nil
+	}
+	err = iu.UpdateRole(usersID, role.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("%s now has the %s role", email, role.Name)
+	return nil
+}
+
+// UpdateRole replaces the role the user has in the organization
+func (u *SUsers) UpdateRole(usersID string, roleID int) error {
+	b, err := json.Marshal(map[string]int{"roleID": roleID})
+	if err != nil {
+		return err
+	}
+	headers := u.Settings.HTTPManager.GetHeaders(u.Settings.SessionToken, u.Settings.Version, u.Settings.Pod, u.Settings.UsersID)
+	resp, statusCode, err := u.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/orgs/%s/users/%s/roles", u.Settings.AuthHost, u.Settings.AuthHostVersion, u.Settings.OrgID, usersID), headers)
+	if err != nil {
+		return err
+	}
+	return u.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,145 @@
+package vars
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+// bulkTarget is an environment whose code services have their variables
+// transformed by a bulk change
+type bulkTarget struct {
+	name string
+	iv   IVars
+	is   services.IServices
+}
+
+// bulkTargets returns the current environment, or every associated environment
+// if all is true, as targets of a bulk change
+func bulkTargets(all bool, settings *models.Settings) []bulkTarget {
+	if !all {
+		return []bulkTarget{{settings.EnvironmentName, New(settings), services.New(settings)}}
+	}
+	aliases := []string{}
+	for alias := range settings.Environments {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	targets := []bulkTarget{}
+	for _, alias := range aliases {
+		envSettings := config.EnvironmentSettings(alias, settings)
+		targets = append(targets, bulkTarget{alias, New(envSettings), services.New(envSettings)})
+	}
+	return targets
+}
+
+// change is the set of variables of a single code service modified by a bulk
+// change
+type change struct {
+	target  bulkTarget
+	service models.Service
+	set     map[string]string
+	unset   []string
+	// names are the variables shown as changed, with a description of the
+	// change
+	names map[string]string
+}
+
+// transform computes the changes to a service's variables. It returns nil if
+// the variables are left alone.
+type transform func(vars map[string]string) (*change, error)
+
+// planChanges applies t to the variables of every code service of the
+// targets without modifying anything
+func planChanges(targets []bulkTarget, t transform) ([]change, error) {
+	changes := []change{}
+	for _, target := range targets {
+		svcs, err := target.is.List()
+		if err != nil {
+			return nil, fmt.Errorf("Could not list the services of %s: %s", target.name, err)
+		}
+		for _, svc := range *svcs {
+			if svc.Type != "code" {
+				continue
+			}
+			vars, err := target.iv.List(svc.ID)
+			if err != nil {
+				return nil, fmt.Errorf("Could not list the environment variables of %s in %s: %s", svc.Label, target.name, err)
+			}
+			c, err := t(vars)
+			if err != nil {
+				return nil, fmt.Errorf("%s in %s: %s", svc.Label, target.name, err)
+			}
+			if c != nil {
+				c.target = target
+				c.service = svc
+				changes = append(changes, *c)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// applyChanges prints the planned changes and, unless dryRun is true and
+// once confirmed, applies them. New values are set before old variables are
+// unset so a variable is never missing.
+func applyChanges(changes []change, dryRun bool, ip prompts.IPrompts) error {
+	if len(changes) == 0 {
+		logrus.Println("No environment variables match, nothing to change")
+		return nil
+	}
+	data := [][]string{{"ENVIRONMENT", "SERVICE", "VARIABLE", "CHANGE"}}
+	count := 0
+	for _, c := range changes {
+		names := []string{}
+		for name := range c.names {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			data = append(data, []string{c.target.name, c.service.Label, name, c.names[name]})
+			count++
+		}
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	if dryRun {
+		logrus.Println("\nThis was a dry run, nothing was changed")
+		return nil
+	}
+
+	err := ip.YesNo(fmt.Sprintf("\nChange %d environment variables across %d services? (y/n) ", count, len(changes)))
+	if err != nil {
+		return err
+	}
+	redeploy := []string{}
+	for _, c := range changes {
+		if len(c.set) > 0 {
+			if err = c.target.iv.Set(c.service.ID, c.set); err != nil {
+				return fmt.Errorf("Could not update the environment variables of %s in %s: %s", c.service.Label, c.target.name, err)
+			}
+		}
+		for _, name := range c.unset {
+			if err = c.target.iv.Unset(c.service.ID, name, false); err != nil {
+				return fmt.Errorf("Could not unset %s of %s in %s: %s", name, c.service.Label, c.target.name, err)
+			}
+		}
+		redeploy = append(redeploy, fmt.Sprintf("%s (%s)", c.service.Label, c.target.name))
+	}
+	logrus.Printf("Changed %d environment variables. For these changes to take effect, you will need to redeploy %s with \"datica redeploy\"", count, strings.Join(redeploy, ", "))
+	return nil
+}
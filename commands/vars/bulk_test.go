@@ -0,0 +1,190 @@
+package vars
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var renameTests = []struct {
+	oldName      string
+	newName      string
+	dryRun       bool
+	allEnvs      bool
+	expectCalls  string
+	expectOutput []string
+	expectErr    bool
+}{
+	{"REDIS_URL", "CACHE_URL", false, false, "POST env1/svc1 CACHE_URL,DELETE env1/svc1 REDIS_URL", []string{"REDIS_URL", "renamed to CACHE_URL", "Changed 1 environment variables", "code (1)"}, false},
+	{"REDIS_URL", "CACHE_URL", true, false, "", []string{"renamed to CACHE_URL", "This was a dry run, nothing was changed"}, false},
+	{"REDIS_URL", "CACHE_URL", false, true, "POST env1/svc1 CACHE_URL,DELETE env1/svc1 REDIS_URL,POST env2/svc2 CACHE_URL,DELETE env2/svc2 REDIS_URL", []string{"Changed 2 environment variables", "code (1), code (2)"}, false},
+	{"REDIS_URL", "DB_URL", false, false, "", nil, true},
+	{"MISSING", "OTHER", false, false, "", []string{"No environment variables match, nothing to change"}, false},
+	{"REDIS_URL", "1BAD", false, false, "", nil, true},
+}
+
+var rewriteTests = []struct {
+	pattern      string
+	replace      string
+	dryRun       bool
+	expectCalls  string
+	expectOutput []string
+	expectErr    bool
+}{
+	{"old-host", "new-host", false, "POST env1/svc1 DB_URL=postgres://new-host:5432;REDIS_URL=redis://new-host:6379", []string{"DB_URL", "REDIS_URL", "value rewritten", "Changed 2 environment variables"}, false},
+	{"old-host", "new-host", true, "", []string{"value rewritten", "This was a dry run, nothing was changed"}, false},
+	{"unknown", "new-host", false, "", []string{"No environment variables match, nothing to change"}, false},
+	{"", "new-host", false, "", nil, true},
+}
+
+func TestRename(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.EnvironmentName = test.Alias
+	settings.Environments[test.AliasAlt] = models.AssociatedEnv{
+		Name:          test.EnvNameAlt,
+		EnvironmentID: test.EnvIDAlt,
+		ServiceID:     test.SvcIDAlt,
+		Pod:           test.PodAlt,
+		OrgID:         test.OrgIDAlt,
+	}
+	calls := []string{}
+	for envID, svcID := range map[string]string{test.EnvID: test.SvcID, test.EnvIDAlt: test.SvcIDAlt} {
+		envID, svcID := envID, svcID
+		mux.HandleFunc("/environments/"+envID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `[{"id":"%s","label":"code","type":"code"},{"id":"db","label":"db01","type":"postgresql"}]`, svcID)
+			},
+		)
+		mux.HandleFunc("/environments/"+envID+"/services/"+svcID+"/env",
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case "GET":
+					fmt.Fprint(w, `{"REDIS_URL":"redis://old-host:6379","DB_URL":"postgres://old-host:5432","PORT":"8080"}`)
+				case "POST":
+					var vars map[string]string
+					json.NewDecoder(r.Body).Decode(&vars)
+					pairs := []string{}
+					for k, v := range vars {
+						if len(vars) == 1 {
+							pairs = append(pairs, k)
+						} else {
+							pairs = append(pairs, k+"="+v)
+						}
+					}
+					sort.Strings(pairs)
+					calls = append(calls, fmt.Sprintf("POST %s/%s %s", envID, svcID, strings.Join(pairs, ";")))
+				}
+			},
+		)
+		mux.HandleFunc("/environments/"+envID+"/services/"+svcID+"/env/",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "DELETE")
+				calls = append(calls, fmt.Sprintf("DELETE %s/%s %s", envID, svcID, strings.TrimPrefix(r.URL.Path, "/environments/"+envID+"/services/"+svcID+"/env/")))
+			},
+		)
+	}
+
+	for _, data := range renameTests {
+		t.Logf("Data: %+v", data)
+		calls = []string{}
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdRename(data.oldName, data.newName, data.dryRun, bulkTargets(data.allEnvs, settings), &test.FakePrompts{})
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, data.expectCalls, strings.Join(calls, ","))
+		for _, line := range data.expectOutput {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected the output to contain %q but got %s", line, output)
+			}
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.EnvironmentName = test.Alias
+	settings.Environments[test.AliasAlt] = models.AssociatedEnv{
+		Name:          test.EnvNameAlt,
+		EnvironmentID: test.EnvIDAlt,
+		ServiceID:     test.SvcIDAlt,
+		Pod:           test.PodAlt,
+		OrgID:         test.OrgIDAlt,
+	}
+	calls := []string{}
+	for envID, svcID := range map[string]string{test.EnvID: test.SvcID, test.EnvIDAlt: test.SvcIDAlt} {
+		envID, svcID := envID, svcID
+		mux.HandleFunc("/environments/"+envID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `[{"id":"%s","label":"code","type":"code"},{"id":"db","label":"db01","type":"postgresql"}]`, svcID)
+			},
+		)
+		mux.HandleFunc("/environments/"+envID+"/services/"+svcID+"/env",
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case "GET":
+					fmt.Fprint(w, `{"REDIS_URL":"redis://old-host:6379","DB_URL":"postgres://old-host:5432","PORT":"8080"}`)
+				case "POST":
+					var vars map[string]string
+					json.NewDecoder(r.Body).Decode(&vars)
+					pairs := []string{}
+					for k, v := range vars {
+						if len(vars) == 1 {
+							pairs = append(pairs, k)
+						} else {
+							pairs = append(pairs, k+"="+v)
+						}
+					}
+					sort.Strings(pairs)
+					calls = append(calls, fmt.Sprintf("POST %s/%s %s", envID, svcID, strings.Join(pairs, ";")))
+				}
+			},
+		)
+		mux.HandleFunc("/environments/"+envID+"/services/"+svcID+"/env/",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "DELETE")
+				calls = append(calls, fmt.Sprintf("DELETE %s/%s %s", envID, svcID, strings.TrimPrefix(r.URL.Path, "/environments/"+envID+"/services/"+svcID+"/env/")))
+			},
+		)
+	}
+
+	for _, data := range rewriteTests {
+		t.Logf("Data: %+v", data)
+		calls = []string{}
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdRewrite(data.pattern, data.replace, data.dryRun, bulkTargets(false, settings), &test.FakePrompts{})
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, data.expectCalls, strings.Join(calls, ","))
+		for _, line := range data.expectOutput {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected the output to contain %q but got %s", line, output)
+			}
+		}
+	}
+}
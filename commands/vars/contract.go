@@ -3,8 +3,10 @@ package vars
 import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/trash"
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -16,11 +18,79 @@ var Cmd = models.Command{
 	Name:      "vars",
 	ShortHelp: "Interaction with environment variables for the associated environment",
 	LongHelp:  "The `vars` command allows you to manage environment variables for your code services. The vars command can not be run directly but has sub commands.",
+	Category:  models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, SetSubCmd.LongHelp, SetSubCmd.CmdFunc(settings))
-			cmd.CommandLong(UnsetSubCmd.Name, UnsetSubCmd.ShortHelp, UnsetSubCmd.LongHelp, UnsetSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ExportSubCmd.Name, ExportSubCmd.ShortHelp, help.Render(ExportSubCmd.LongHelp), ExportSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ImportSubCmd.Name, ImportSubCmd.ShortHelp, help.Render(ImportSubCmd.LongHelp), ImportSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RenameSubCmd.Name, RenameSubCmd.ShortHelp, help.Render(RenameSubCmd.LongHelp), RenameSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RewriteSubCmd.Name, RewriteSubCmd.ShortHelp, help.Render(RewriteSubCmd.LongHelp), RewriteSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SetSubCmd.Name, SetSubCmd.ShortHelp, help.Render(SetSubCmd.LongHelp), SetSubCmd.CmdFunc(settings))
+			cmd.CommandLong(UnsetSubCmd.Name, UnsetSubCmd.ShortHelp, help.Render(UnsetSubCmd.LongHelp), UnsetSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var ExportSubCmd = models.Command{
+	Name:      "export",
+	ShortHelp: "Export all environment variables to a file",
+	LongHelp: "`vars export` writes all environment variables for the given code service to a local file so they can be versioned or replayed into another environment with [vars import](#vars-import). " +
+		"Variables are written in dotenv format by default or in JSON format with the `--json` flag. " +
+		"The exported file contains the values of every variable, so be sure to store it securely. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" vars export code-1 ./staging.env\n" +
+		"datica -E \"<your_env_alias>\" vars export code-1 ./staging.json --json\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service containing the environment variables. Defaults to the service pinned by the workspace file, or else the associated service.")
+			filePath := subCmd.StringArg("FILEPATH", "", "The location to save the exported environment variables. This location must NOT already exist unless -f is specified")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Export environment variables in JSON format"))
+			force := subCmd.BoolOpt("f force", false, "If a file previously exists at \"filepath\", overwrite it")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdExport(config.ServiceName(*serviceName, settings), settings.ServiceID, *filePath, *json, *force, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] FILEPATH [--json] [-f]"
+		}
+	},
+}
+
+var ImportSubCmd = models.Command{
+	Name:      "import",
+	ShortHelp: "Set environment variables from a file",
+	LongHelp: "`vars import` reads environment variables from a local file and sets all of them on the given code service in a single request. " +
+		"Existing variables with the same name are updated and variables not present in the file are left untouched. " +
+		"The file is parsed as dotenv by default, with one `<key>=<value>` pair per line, or as a JSON object of string values with the `--json` flag. " +
+		"Files created by [vars export](#vars-export) can be imported directly. " +
+		"Once the variables are imported, a [redeploy](#redeploy) is required for the given code service to have access to the new values. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" vars import code-1 ./staging.env\n" +
+		"datica -E \"<your_env_alias>\" vars import code-1 ./staging.json --json\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service on which the environment variables will be set. Defaults to the service pinned by the workspace file, or else the associated service.")
+			filePath := subCmd.StringArg("FILEPATH", "", "The location of the file containing the environment variables to import")
+			json := subCmd.BoolOpt("json", false, "Parse the file as JSON instead of dotenv")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdImport(config.ServiceName(*serviceName, settings), settings.ServiceID, *filePath, *json, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] FILEPATH [--json]"
 		}
 	},
 }
@@ -33,10 +103,11 @@ var ListSubCmd = models.Command{
 		"Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" vars list code-1\n" +
 		"datica -E \"<your_env_alias>\" vars list code-1 --json\n```",
+	JSONOutput: map[string]string{},
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service containing the environment variables. Defaults to the associated service.")
-			json := subCmd.BoolOpt("json", false, "Output environment variables in JSON format")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service containing the environment variables. Defaults to the service pinned by the workspace file, or else the associated service.")
+			json := config.JSONOpt(subCmd.BoolOpt("json", false, "Output environment variables in JSON format"))
 			yaml := subCmd.BoolOpt("yaml", false, "Output environment variables in YAML format")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
@@ -53,7 +124,7 @@ var ListSubCmd = models.Command{
 				} else {
 					formatter = &PlainFormatter{}
 				}
-				err := CmdList(*serviceName, settings.ServiceID, formatter, New(settings), services.New(settings))
+				err := CmdList(config.ServiceName(*serviceName, settings), settings.ServiceID, formatter, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
@@ -63,6 +134,72 @@ var ListSubCmd = models.Command{
 	},
 }
 
+var RenameSubCmd = models.Command{
+	Name:      "rename",
+	ShortHelp: "Rename an environment variable in every code service",
+	LongHelp: "`vars rename` renames an environment variable in every code service of the environment that has it, keeping its value. " +
+		"Use `--all-environments` to rename it in every associated environment instead. " +
+		"Nothing is changed if a service already has a variable with the new name. " +
+		"The changes are listed and you will be asked to confirm before they are made, use the global `--yes` flag to skip the confirmation or `--dry-run` to only list them. " +
+		"Once renamed, a [redeploy](#redeploy) is required for each changed service to pick up the new name. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" vars rename REDIS_URL CACHE_URL --dry-run\n" +
+		"datica vars rename REDIS_URL CACHE_URL --all-environments\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			oldName := subCmd.StringArg("OLD", "", "The current name of the environment variable")
+			newName := subCmd.StringArg("NEW", "", "The new name of the environment variable")
+			allEnvs := subCmd.BoolOpt("all-environments", false, "Rename the variable in every associated environment")
+			dryRun := subCmd.BoolOpt("dry-run", false, "List the variables that would be renamed without changing anything")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(!*allEnvs, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRename(*oldName, *newName, *dryRun, bulkTargets(*allEnvs, settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "OLD NEW [--all-environments] [--dry-run]"
+		}
+	},
+}
+
+var RewriteSubCmd = models.Command{
+	Name:      "rewrite",
+	ShortHelp: "Replace text in the values of every environment variable",
+	LongHelp: "`vars rewrite` replaces every occurrence of a pattern in the values of the environment variables of every code service in the environment, such as a host name during an infrastructure migration. " +
+		"The pattern is matched as plain text, not as a regular expression. " +
+		"Use `--all-environments` to rewrite the variables of every associated environment instead. " +
+		"The changed variables are listed, without their values, and you will be asked to confirm before they are changed, use the global `--yes` flag to skip the confirmation or `--dry-run` to only list them. " +
+		"Once rewritten, a [redeploy](#redeploy) is required for each changed service to pick up the new values. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" vars rewrite --pattern 'redis://old-host' --replace 'redis://new-host' --dry-run\n" +
+		"datica vars rewrite --pattern 'redis://old-host' --replace 'redis://new-host' --all-environments\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			pattern := subCmd.StringOpt("pattern", "", "The text to replace")
+			replace := subCmd.StringOpt("replace", "", "The text to replace the pattern with")
+			allEnvs := subCmd.BoolOpt("all-environments", false, "Rewrite the variables of every associated environment")
+			dryRun := subCmd.BoolOpt("dry-run", false, "List the variables that would be rewritten without changing anything")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(!*allEnvs, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdRewrite(*pattern, *replace, *dryRun, bulkTargets(*allEnvs, settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "--pattern --replace [--all-environments] [--dry-run]"
+		}
+	},
+}
+
 var SetSubCmd = models.Command{
 	Name:      "set",
 	ShortHelp: "Set one or more new environment variables or update the values of existing ones",
@@ -73,7 +210,7 @@ var SetSubCmd = models.Command{
 		"```\ndatica -E \"<your_env_alias>\" vars set code-1 -v AWS_ACCESS_KEY_ID=1234 -v AWS_SECRET_ACCESS_KEY=5678\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service on which the environment variables will be set. Defaults to the associated service.")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service on which the environment variables will be set. Defaults to the service pinned by the workspace file, or else the associated service.")
 			variables := subCmd.Strings(cli.StringsOpt{
 				Name:      "v variable",
 				Value:     []string{},
@@ -87,7 +224,7 @@ var SetSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdSet(*serviceName, settings.ServiceID, *variables, New(settings), services.New(settings))
+				err := CmdSet(config.ServiceName(*serviceName, settings), settings.ServiceID, *variables, New(settings), services.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
@@ -103,12 +240,16 @@ var UnsetSubCmd = models.Command{
 	LongHelp: "`vars unset` removes an environment variables from the given code service. " +
 		"Only the environment variable name is required to unset. " +
 		"Once environment variables are unset, a [redeploy](#redeploy) is required for the given code service to realize the variable was removed. " +
-		"Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" vars unset code-1 AWS_ACCESS_KEY_ID\n```",
+		"Use `--soft` to move the variable to the environment's trash instead, where it can be restored with [trash restore](#trash-restore) until it expires. " +
+		"If the platform does not support soft deletes, `--soft` fails and the variable is not removed. " +
+		"Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" vars unset code-1 AWS_ACCESS_KEY_ID\n" +
+		"datica -E \"<your_env_alias>\" vars unset code-1 AWS_ACCESS_KEY_ID --soft\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service on which the environment variables will be unset. Defaults to the associated service.")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service on which the environment variables will be unset. Defaults to the service pinned by the workspace file, or else the associated service.")
 			variable := subCmd.StringArg("VARIABLE", "", "The name of the environment variable to unset")
+			soft := subCmd.BoolOpt("soft", false, "Move the environment variable to the trash so it can be restored")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -116,12 +257,12 @@ var UnsetSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdUnset(*serviceName, settings.ServiceID, *variable, New(settings), services.New(settings))
+				err := CmdUnset(config.ServiceName(*serviceName, settings), settings.ServiceID, *variable, *soft, New(settings), services.New(settings), trash.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "[SERVICE_NAME] VARIABLE"
+			subCmd.Spec = "[SERVICE_NAME] VARIABLE [--soft]"
 		}
 	},
 }
@@ -130,7 +271,7 @@ var UnsetSubCmd = models.Command{
 type IVars interface {
 	List(svcID string) (map[string]string, error)
 	Set(svcID string, envVarsMap map[string]string) error
-	Unset(svcID, key string) error
+	Unset(svcID, key string, soft bool) error
 }
 
 // SVars is a concrete implementation of IVars
@@ -0,0 +1,68 @@
+package vars
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+)
+
+func CmdExport(svcName, defaultSvcID, filePath string, asJSON, force bool, iv IVars, is services.IServices) error {
+	if !force {
+		if _, err := os.Stat(filePath); err == nil {
+			return fmt.Errorf("File already exists at path '%s'. Specify `--force` to overwrite", filePath)
+		}
+	}
+	if svcName != "" {
+		service, err := is.RetrieveByLabel(svcName)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+		}
+		defaultSvcID = service.ID
+	}
+	envVars, err := iv.List(defaultSvcID)
+	if err != nil {
+		return err
+	}
+	var b []byte
+	if asJSON {
+		b, err = json.MarshalIndent(envVars, "", "    ")
+		if err != nil {
+			return err
+		}
+		b = append(b, '\n')
+	} else {
+		b = dotenvEncode(envVars)
+	}
+	err = ioutil.WriteFile(filePath, b, 0600)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("%d environment variables exported to %s", len(envVars), filePath)
+	return nil
+}
+
+// dotenvEncode renders the given environment variables as a dotenv file with
+// one KEY="value" pair per line sorted by key. Values are always quoted so
+// that newlines and other special characters survive a round trip through
+// `vars import`.
+func dotenvEncode(envVars map[string]string) []byte {
+	var keys []string
+	for k := range envVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b []byte
+	for _, key := range keys {
+		b = append(b, fmt.Sprintf("%s=%s\n", key, strconv.Quote(envVars[key]))...)
+	}
+	return b
+}
@@ -0,0 +1,91 @@
+package vars
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+)
+
+func CmdImport(svcName, defaultSvcID, filePath string, asJSON bool, iv IVars, is services.IServices) error {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	var envVarsMap map[string]string
+	if asJSON {
+		err = json.Unmarshal(b, &envVarsMap)
+		if err != nil {
+			return fmt.Errorf("Invalid JSON in %s: %s", filePath, err)
+		}
+	} else {
+		envVarsMap, err = dotenvDecode(b)
+		if err != nil {
+			return fmt.Errorf("Invalid dotenv file %s: %s", filePath, err)
+		}
+	}
+	if len(envVarsMap) == 0 {
+		return fmt.Errorf("No environment variables found in %s", filePath)
+	}
+	for name := range envVarsMap {
+		if !varNameRegex.MatchString(name) {
+			return fmt.Errorf("Invalid environment variable name '%s'. Environment variable names must only contain letters, numbers, and underscores and must not start with a number.", name)
+		}
+	}
+	if svcName != "" {
+		service, err := is.RetrieveByLabel(svcName)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+		}
+		defaultSvcID = service.ID
+	}
+	err = iv.Set(defaultSvcID, envVarsMap)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("%d environment variables imported from %s. For these environment variables to take effect, you will need to redeploy your service with \"datica redeploy\"", len(envVarsMap), filePath)
+	return nil
+}
+
+// dotenvDecode parses the contents of a dotenv file. Blank lines and lines
+// starting with `#` are ignored and an optional leading `export ` is
+// stripped. Double quoted values are unescaped, single quoted values are
+// taken literally, and unquoted values are trimmed of surrounding whitespace.
+func dotenvDecode(b []byte) (map[string]string, error) {
+	envVars := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		pieces := strings.SplitN(line, "=", 2)
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("line %d: expected <key>=<value> but got %s", lineNum, line)
+		}
+		name, value := strings.TrimSpace(pieces[0]), strings.TrimSpace(pieces[1])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted value for %s", lineNum, name)
+			}
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		envVars[name] = value
+	}
+	return envVars, scanner.Err()
+}
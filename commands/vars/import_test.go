@@ -0,0 +1,95 @@
+package vars
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+const (
+	dotenvPath  = "vars_test.env"
+	jsonVarPath = "vars_test.json"
+	badVarPath  = "vars_test_bad.env"
+)
+
+var importTests = []struct {
+	svcName   string
+	filePath  string
+	asJSON    bool
+	expectErr bool
+}{
+	{test.SvcLabel, dotenvPath, false, false},
+	{"", dotenvPath, false, false},
+	{test.SvcLabel, jsonVarPath, true, false},
+	{test.SvcLabel, badVarPath, false, true},
+	{test.SvcLabel, "invalid-file", false, true},
+	{"invalid-svc", dotenvPath, false, true},
+}
+
+func TestImport(t *testing.T) {
+	ioutil.WriteFile(dotenvPath, []byte("# comment\nexport KEY1=value1\nKEY2=\"multi\\nline\"\nKEY3='single quoted'\n"), 0644)
+	ioutil.WriteFile(jsonVarPath, []byte(`{"KEY1":"value1","KEY2":"multi\nline","KEY3":"single quoted"}`), 0644)
+	ioutil.WriteFile(badVarPath, []byte("1KEY=value\n"), 0644)
+	defer os.Remove(dotenvPath)
+	defer os.Remove(jsonVarPath)
+	defer os.Remove(badVarPath)
+
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, fmt.Sprintf(`[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel))
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/env",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			var envVars map[string]string
+			json.NewDecoder(r.Body).Decode(&envVars)
+			test.AssertEquals(t, "value1", envVars["KEY1"])
+			test.AssertEquals(t, "multi\nline", envVars["KEY2"])
+			test.AssertEquals(t, "single quoted", envVars["KEY3"])
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range importTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		err := CmdImport(data.svcName, settings.ServiceID, data.filePath, data.asJSON, New(settings), services.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+	}
+}
+
+func TestDotenvRoundTrip(t *testing.T) {
+	envVars := map[string]string{
+		"PLAIN":   "value",
+		"QUOTES":  `say "hi"`,
+		"NEWLINE": "line1\nline2",
+		"EMPTY":   "",
+	}
+	decoded, err := dotenvDecode(dotenvEncode(envVars))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(decoded) != len(envVars) {
+		t.Errorf("Expected %d variables, got %d", len(envVars), len(decoded))
+	}
+	for k, v := range envVars {
+		test.AssertEquals(t, v, decoded[k])
+	}
+}
@@ -0,0 +1,34 @@
+package vars
+
+import (
+	"fmt"
+
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdRename(oldName, newName string, dryRun bool, targets []bulkTarget, ip prompts.IPrompts) error {
+	if !varNameRegex.MatchString(newName) {
+		return fmt.Errorf("Invalid environment variable name '%s'. Environment variable names must only contain letters, numbers, and underscores and must not start with a number.", newName)
+	}
+	if oldName == newName {
+		return fmt.Errorf("The old and new names are both %s", oldName)
+	}
+	changes, err := planChanges(targets, func(vars map[string]string) (*change, error) {
+		value, ok := vars[oldName]
+		if !ok {
+			return nil, nil
+		}
+		if _, exists := vars[newName]; exists {
+			return nil, fmt.Errorf("%s is already set, unset it first or choose another name", newName)
+		}
+		return &change{
+			set:   map[string]string{newName: value},
+			unset: []string{oldName},
+			names: map[string]string{oldName: "renamed to " + newName},
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+	return applyChanges(changes, dryRun, ip)
+}
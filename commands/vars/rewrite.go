@@ -0,0 +1,34 @@
+package vars
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdRewrite(pattern, replace string, dryRun bool, targets []bulkTarget, ip prompts.IPrompts) error {
+	if pattern == "" {
+		return errors.New("The pattern to replace can not be empty")
+	}
+	changes, err := planChanges(targets, func(vars map[string]string) (*change, error) {
+		c := &change{
+			set:   map[string]string{},
+			names: map[string]string{},
+		}
+		for name, value := range vars {
+			if strings.Contains(value, pattern) {
+				c.set[name] = strings.Replace(value, pattern, replace, -1)
+				c.names[name] = "value rewritten"
+			}
+		}
+		if len(c.set) == 0 {
+			return nil, nil
+		}
+		return c, nil
+	})
+	if err != nil {
+		return err
+	}
+	return applyChanges(changes, dryRun, ip)
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/daticahealth/cli/commands/services"
 )
 
+// varNameRegex matches valid environment variable names
+var varNameRegex = regexp.MustCompile("^[a-zA-Z_]+[a-zA-Z0-9_]*$")
+
 func CmdSet(svcName, defaultSvcID string, variables []string, iv IVars, is services.IServices) error {
 	if svcName != "" {
 		service, err := is.RetrieveByLabel(svcName)
@@ -22,14 +25,13 @@ func CmdSet(svcName, defaultSvcID string, variables []string, iv IVars, is servi
 		defaultSvcID = service.ID
 	}
 	envVarsMap := make(map[string]string, len(variables))
-	r := regexp.MustCompile("^[a-zA-Z_]+[a-zA-Z0-9_]*$")
 	for _, envVar := range variables {
 		pieces := strings.SplitN(envVar, "=", 2)
 		if len(pieces) != 2 {
 			return fmt.Errorf("Invalid variable format. Expected <key>=<value> but got %s", envVar)
 		}
 		name, value := pieces[0], pieces[1]
-		if !r.MatchString(name) {
+		if !varNameRegex.MatchString(name) {
 			return fmt.Errorf("Invalid environment variable name '%s'. Environment variable names must only contain letters, numbers, and underscores and must not start with a number.", name)
 		}
 		envVarsMap[name] = value
@@ -5,9 +5,10 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/commands/trash"
 )
 
-func CmdUnset(svcName, defaultSvcID, key string, iv IVars, is services.IServices) error {
+func CmdUnset(svcName, defaultSvcID, key string, soft bool, iv IVars, is services.IServices, it trash.ITrash) error {
 	if svcName != "" {
 		service, err := is.RetrieveByLabel(svcName)
 		if err != nil {
@@ -18,20 +19,34 @@ func CmdUnset(svcName, defaultSvcID, key string, iv IVars, is services.IServices
 		}
 		defaultSvcID = service.ID
 	}
-	err := iv.Unset(defaultSvcID, key)
+	if soft {
+		if err := trash.CheckSoft("var", key, it); err != nil {
+			return err
+		}
+	}
+	err := iv.Unset(defaultSvcID, key, soft)
 	if err != nil {
 		return err
 	}
+	if soft {
+		logrus.Printf("Moved %s to the trash. Until it expires, it can be restored with \"datica trash restore\". For this change to take effect, you will need to redeploy your service with \"datica redeploy %s\"", key, svcName)
+		return nil
+	}
 	logrus.Printf("Unset. For these environment variable changes to take effect, you will need to redeploy your service with \"datica redeploy %s\"", svcName)
 	return nil
 }
 
 // Unset deletes an environment variable. Any changes to environment variables
 // will not take effect until the service is redeployed by pushing new code
-// or via `datica redeploy`.
-func (v *SVars) Unset(svcID, variable string) error {
+// or via `datica redeploy`. A soft deleted variable is moved to the trash of
+// the environment instead.
+func (v *SVars) Unset(svcID, variable string, soft bool) error {
+	query := ""
+	if soft {
+		query = "?soft=true"
+	}
 	headers := v.Settings.HTTPManager.GetHeaders(v.Settings.SessionToken, v.Settings.Version, v.Settings.Pod, v.Settings.UsersID)
-	resp, statusCode, err := v.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/env/%s", v.Settings.PaasHost, v.Settings.PaasHostVersion, v.Settings.EnvironmentID, svcID, variable), headers)
+	resp, statusCode, err := v.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/env/%s%s", v.Settings.PaasHost, v.Settings.PaasHostVersion, v.Settings.EnvironmentID, svcID, variable, query), headers)
 	if err != nil {
 		return err
 	}
@@ -15,6 +15,7 @@ var Cmd = models.Command{
 		"This is useful to see if you have the latest version of the CLI and when working with Datica support engineers to ensure you have the correct CLI installed. " +
 		"Here is a sample command\n\n" +
 		"```\ndatica version\n```",
+	Category: models.CategoryEnvironment,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
 			cmd.Action = func() {
@@ -0,0 +1,150 @@
+package volumes
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+)
+
+// Cmd is the contract between the user and the CLI. This specifies the command
+// name, arguments, and required/optional arguments and flags for the command.
+var Cmd = models.Command{
+	Name:      "volumes",
+	ShortHelp: "Manage storage volumes for stateful services",
+	LongHelp:  "The `volumes` command allows you to manage the storage volumes attached to stateful services such as uploaded files or search indexes. The volumes command can not be run directly but has sub commands.",
+	Category:  models.CategoryData,
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(SnapshotSubCmd.Name, SnapshotSubCmd.ShortHelp, help.Render(SnapshotSubCmd.LongHelp), SnapshotSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var SnapshotSubCmd = models.Command{
+	Name:      "snapshot",
+	ShortHelp: "Create, list, and restore volume snapshots",
+	LongHelp: "`volumes snapshot` manages point in time snapshots of the storage volumes attached to a service. " +
+		"Snapshots complement [db backup](#db-backup) for services that are not databases but still hold state on disk. " +
+		"The snapshot command can not be run directly but has sub commands.",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(cmd *cli.Cmd) {
+			cmd.CommandLong(SnapshotCreateSubCmd.Name, SnapshotCreateSubCmd.ShortHelp, help.Render(SnapshotCreateSubCmd.LongHelp), SnapshotCreateSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SnapshotListSubCmd.Name, SnapshotListSubCmd.ShortHelp, help.Render(SnapshotListSubCmd.LongHelp), SnapshotListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(SnapshotRestoreSubCmd.Name, SnapshotRestoreSubCmd.ShortHelp, help.Render(SnapshotRestoreSubCmd.LongHelp), SnapshotRestoreSubCmd.CmdFunc(settings))
+		}
+	},
+}
+
+var SnapshotCreateSubCmd = models.Command{
+	Name:      "create",
+	ShortHelp: "Create a new snapshot of a service's volumes",
+	LongHelp: "`volumes snapshot create` takes a snapshot of every volume attached to the given service. " +
+		"Use `--retain-days` to have the snapshot expire automatically after a number of days and `--keep` to limit how many snapshots are kept for the service, in which case the oldest snapshots are pruned once the new snapshot finishes. " +
+		"Unless `-s` is specified, the CLI will poll every few seconds until the snapshot finishes. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" volumes snapshot create uploads\n" +
+		"datica -E \"<your_env_alias>\" volumes snapshot create uploads --retain-days 30 --keep 7\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to snapshot (i.e. 'uploads')")
+			retainDays := subCmd.IntOpt("r retain-days", 0, "The number of days to keep this snapshot before it expires. 0 keeps it until it is pruned or removed")
+			keep := subCmd.IntOpt("k keep", 0, "The maximum number of snapshots to keep for this service. 0 keeps all snapshots")
+			skipPoll := subCmd.BoolOpt("s skip-poll", false, "Whether or not to wait for the snapshot to finish")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdSnapshotCreate(*serviceName, *retainDays, *keep, *skipPoll, New(settings), services.New(settings), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME [--retain-days] [--keep] [-s]"
+		}
+	},
+}
+
+var SnapshotListSubCmd = models.Command{
+	Name:      "list",
+	ShortHelp: "List snapshots for a service",
+	LongHelp: "`volumes snapshot list` lists all snapshots taken of the given service's volumes along with their status, size, and expiration. " +
+		"After listing snapshots you can copy the snapshot ID and use it to [restore](#volumes-snapshot-restore) that snapshot. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" volumes snapshot list uploads\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to list snapshots for (i.e. 'uploads')")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdSnapshotList(*serviceName, New(settings), services.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME"
+		}
+	},
+}
+
+var SnapshotRestoreSubCmd = models.Command{
+	Name:      "restore",
+	ShortHelp: "Restore a service's volumes from a snapshot",
+	LongHelp: "`volumes snapshot restore` replaces the contents of the given service's volumes with the contents of a previous snapshot. " +
+		"Any data written since the snapshot was taken will be lost, so a new snapshot is taken automatically before the restore begins unless `--skip-snapshot` is given. " +
+		"The service will be unavailable while the restore is in progress. " +
+		"The ID of the snapshot is found by first running the [volumes snapshot list](#volumes-snapshot-list) command. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" volumes snapshot restore uploads 5e3e3ec1-3ddb-4a1e-a8a6-9b1d8c2c0f11\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to restore (i.e. 'uploads')")
+			snapshotID := subCmd.StringArg("SNAPSHOT_ID", "", "The ID of the snapshot to restore (found from \"datica volumes snapshot list\")")
+			skipSnapshot := subCmd.BoolOpt("skip-snapshot", false, "Skip taking a snapshot of the current volume contents before restoring")
+			skipPoll := subCmd.BoolOpt("s skip-poll", false, "Whether or not to wait for the restore to finish")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err := CmdSnapshotRestore(*serviceName, *snapshotID, *skipSnapshot, *skipPoll, New(settings), services.New(settings), jobs.New(settings), prompts.New())
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "SERVICE_NAME SNAPSHOT_ID [--skip-snapshot] [-s]"
+		}
+	},
+}
+
+// IVolumes
+type IVolumes interface {
+	CreateSnapshot(svcID string, retainDays int) (*models.Job, error)
+	ListSnapshots(svcID string) (*[]models.VolumeSnapshot, error)
+	RemoveSnapshot(svcID, snapshotID string) error
+	RestoreSnapshot(svcID, snapshotID string) (*models.Job, error)
+}
+
+// SVolumes is a concrete implementation of IVolumes
+type SVolumes struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IVolumes
+func New(settings *models.Settings) IVolumes {
+	return &SVolumes{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,111 @@
+package volumes
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdSnapshotCreate(svcName string, retainDays, keep int, skipPoll bool, iv IVolumes, is services.IServices, ij jobs.IJobs) error {
+	if retainDays < 0 {
+		return fmt.Errorf("Invalid value for --retain-days. The number of days must be 0 or greater")
+	}
+	if keep < 0 {
+		return fmt.Errorf("Invalid value for --keep. The number of snapshots must be 0 or greater")
+	}
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	job, err := iv.CreateSnapshot(service.ID, retainDays)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Snapshot started (job ID = %s)", job.ID)
+	if skipPoll {
+		if keep > 0 {
+			logrus.Warnln("--keep is ignored when --skip-poll is given since old snapshots can only be pruned once the new snapshot finishes")
+		}
+		logrus.Printf("You can wait for the snapshot to finish with the \"datica jobs attach %s %s\" command", svcName, job.ID)
+		return nil
+	}
+	logrus.Println("Polling until snapshot finishes.")
+	status, err := ij.PollTillFinished(job.ID, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Ended in status '%s'", status)
+	if keep > 0 {
+		err = pruneSnapshots(keep, service.ID, iv)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneSnapshots removes the oldest finished snapshots for a service so that
+// at most keep snapshots remain.
+func pruneSnapshots(keep int, svcID string, iv IVolumes) error {
+	snapshots, err := iv.ListSnapshots(svcID)
+	if err != nil {
+		return err
+	}
+	var finished []models.VolumeSnapshot
+	for _, s := range *snapshots {
+		if s.Status == "finished" {
+			finished = append(finished, s)
+		}
+	}
+	sort.Sort(SortedSnapshots(finished))
+	for i := 0; i < len(finished)-keep; i++ {
+		logrus.Printf("Pruning snapshot %s created at %s", finished[i].ID, config.FormatTimestampString(finished[i].CreatedAt))
+		err = iv.RemoveSnapshot(svcID, finished[i].ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateSnapshot starts a new snapshot of all volumes attached to a service.
+// If retainDays is greater than 0, the snapshot will be expired automatically
+// after that many days.
+func (v *SVolumes) CreateSnapshot(svcID string, retainDays int) (*models.Job, error) {
+	b, err := json.Marshal(struct {
+		RetentionDays int `json:"retentionDays,omitempty"`
+	}{RetentionDays: retainDays})
+	if err != nil {
+		return nil, err
+	}
+	headers := v.Settings.HTTPManager.GetHeaders(v.Settings.SessionToken, v.Settings.Version, v.Settings.Pod, v.Settings.UsersID)
+	resp, statusCode, err := v.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/services/%s/volumes/snapshots", v.Settings.PaasHost, v.Settings.PaasHostVersion, v.Settings.EnvironmentID, svcID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var job models.Job
+	err = v.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RemoveSnapshot deletes a previously created snapshot
+func (v *SVolumes) RemoveSnapshot(svcID, snapshotID string) error {
+	headers := v.Settings.HTTPManager.GetHeaders(v.Settings.SessionToken, v.Settings.Version, v.Settings.Pod, v.Settings.UsersID)
+	resp, statusCode, err := v.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/volumes/snapshots/%s", v.Settings.PaasHost, v.Settings.PaasHostVersion, v.Settings.EnvironmentID, svcID, snapshotID), headers)
+	if err != nil {
+		return err
+	}
+	return v.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
@@ -0,0 +1,99 @@
+package volumes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var snapshotCreateTests = []struct {
+	svcName         string
+	retainDays      int
+	keep            int
+	skipPoll        bool
+	expectRetention int
+	expectPruned    string
+	expectOutput    []string
+	expectErr       bool
+}{
+	{test.SvcLabel, 0, 0, false, 0, "", []string{"Snapshot started (job ID = newjob)", "Ended in status 'finished'"}, false},
+	{test.SvcLabel, 30, 2, false, 30, "snap1", []string{"Pruning snapshot snap1"}, false},
+	{test.SvcLabel, 0, 3, false, 0, "", nil, false},
+	{test.SvcLabel, 0, 2, true, 0, "", []string{"--keep is ignored", "datica jobs attach " + test.SvcLabel + " newjob"}, false},
+	{test.SvcLabel, -1, 0, false, -1, "", nil, true},
+	{test.SvcLabel, 0, -1, false, -1, "", nil, true},
+	{"invalid-svc", 0, 0, false, -1, "", nil, true},
+}
+
+func TestSnapshotCreate(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	retention := -1
+	pruned := []string{}
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				fmt.Fprint(w, snapshotsList)
+				return
+			}
+			test.AssertEquals(t, r.Method, "POST")
+			var body struct {
+				RetentionDays int `json:"retentionDays"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			retention = body.RetentionDays
+			fmt.Fprint(w, `{"id":"newjob","type":"snapshot","status":"scheduled"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots/",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			pruned = append(pruned, strings.TrimPrefix(r.URL.Path, "/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots/"))
+			fmt.Fprint(w, `{}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/newjob",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `{"id":"newjob","type":"snapshot","status":"finished"}`)
+		},
+	)
+
+	for _, data := range snapshotCreateTests {
+		t.Logf("Data: %+v", data)
+		retention = -1
+		pruned = []string{}
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdSnapshotCreate(data.svcName, data.retainDays, data.keep, data.skipPoll, New(settings), services.New(settings), jobs.New(settings))
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectRetention), fmt.Sprintf("%d", retention))
+		test.AssertEquals(t, data.expectPruned, strings.Join(pruned, ","))
+		for _, line := range data.expectOutput {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected the output to contain %q but got %s", line, output)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package volumes
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/transfer"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+)
+
+func CmdSnapshotList(svcName string, iv IVolumes, is services.IServices) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	snapshots, err := iv.ListSnapshots(service.ID)
+	if err != nil {
+		return err
+	}
+	if snapshots == nil || len(*snapshots) == 0 {
+		logrus.Println("No snapshots created yet for this service.")
+		return nil
+	}
+	sort.Sort(SortedSnapshots(*snapshots))
+	data := [][]string{{"ID", "CREATED AT", "STATUS", "SIZE", "EXPIRES AT"}}
+	for _, s := range *snapshots {
+		expiresAt := "never"
+		if s.ExpiresAt != "" {
+			expiresAt = config.FormatTimestampString(s.ExpiresAt)
+		}
+		data = append(data, []string{s.ID, config.FormatTimestampString(s.CreatedAt), s.Status, config.FormatBytes(float64(s.Size) * float64(transfer.GB)), expiresAt})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return nil
+}
+
+// SortedSnapshots is a wrapper for VolumeSnapshot arrays in order to sort
+// them by CreatedAt
+type SortedSnapshots []models.VolumeSnapshot
+
+func (s SortedSnapshots) Len() int {
+	return len(s)
+}
+
+func (s SortedSnapshots) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s SortedSnapshots) Less(i, j int) bool {
+	return s[i].CreatedAt < s[j].CreatedAt
+}
+
+// ListSnapshots lists all snapshots taken of a service's volumes
+func (v *SVolumes) ListSnapshots(svcID string) (*[]models.VolumeSnapshot, error) {
+	headers := v.Settings.HTTPManager.GetHeaders(v.Settings.SessionToken, v.Settings.Version, v.Settings.Pod, v.Settings.UsersID)
+	resp, statusCode, err := v.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/volumes/snapshots", v.Settings.PaasHost, v.Settings.PaasHostVersion, v.Settings.EnvironmentID, svcID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []models.VolumeSnapshot
+	err = v.Settings.HTTPManager.ConvertResp(resp, statusCode, &snapshots)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshots, nil
+}
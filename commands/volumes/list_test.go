@@ -0,0 +1,71 @@
+package volumes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/test"
+)
+
+// snapshotsList has three finished snapshots, out of order, and one that is
+// still running
+const snapshotsList = `[{"id":"snap3","job":"job3","status":"finished","size":2,"created_at":"2026-10-15T12:00:00Z"},{"id":"snap1","job":"job1","status":"finished","size":1,"created_at":"2026-10-01T12:00:00Z","expires_at":"2026-10-31T12:00:00Z"},{"id":"snap2","job":"job2","status":"finished","size":1,"created_at":"2026-10-08T12:00:00Z"},{"id":"snap4","job":"job4","status":"running","size":0,"created_at":"2026-10-16T12:00:00Z"}]`
+
+var snapshotListTests = []struct {
+	svcName    string
+	snapshots  string
+	expectRows []string
+	expectErr  bool
+}{
+	{test.SvcLabel, snapshotsList, []string{"EXPIRES AT", "snap1", "snap2", "snap3", "snap4", "running", "2.0 GiB", "never"}, false},
+	{test.SvcLabel, `[]`, []string{"No snapshots created yet for this service."}, false},
+	{"invalid-svc", snapshotsList, nil, true},
+}
+
+func TestSnapshotList(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	snapshots := ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, snapshots)
+		},
+	)
+
+	for _, data := range snapshotListTests {
+		t.Logf("Data: %+v", data)
+		snapshots = data.snapshots
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdSnapshotList(data.svcName, New(settings), services.New(settings))
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		for _, row := range data.expectRows {
+			if !strings.Contains(output, row) {
+				t.Errorf("Expected the list to contain %q but got %s", row, output)
+			}
+		}
+		// snapshots are listed oldest first
+		if !data.expectErr && strings.Contains(output, "snap1") && strings.Index(output, "snap1") > strings.Index(output, "snap3") {
+			t.Errorf("Expected the snapshots to be sorted by creation time but got %s", output)
+		}
+	}
+}
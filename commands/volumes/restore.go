@@ -0,0 +1,88 @@
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+func CmdSnapshotRestore(svcName, snapshotID string, skipSnapshot, skipPoll bool, iv IVolumes, is services.IServices, ij jobs.IJobs, ip prompts.IPrompts) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services\" command.", svcName)
+	}
+	// the snapshot is checked before the pre-restore snapshot is taken so a
+	// mistyped ID doesn't leave an extra snapshot behind
+	snapshots, err := iv.ListSnapshots(service.ID)
+	if err != nil {
+		return err
+	}
+	var snapshot *models.VolumeSnapshot
+	for i := range *snapshots {
+		if (*snapshots)[i].ID == snapshotID {
+			snapshot = &(*snapshots)[i]
+			break
+		}
+	}
+	if snapshot == nil {
+		return fmt.Errorf("Could not find a snapshot with the ID \"%s\" for %s. You can list snapshots with the \"datica volumes snapshot list %s\" command.", snapshotID, svcName, svcName)
+	}
+	if snapshot.Status != "finished" {
+		return fmt.Errorf("Snapshot %s is in status '%s' and can only be restored once it has finished", snapshotID, snapshot.Status)
+	}
+	err = ip.YesNo(fmt.Sprintf("Are you sure you want to restore %s from snapshot %s? Any data written since the snapshot was taken will be lost. (y/n) ", svcName, snapshotID))
+	if err != nil {
+		return err
+	}
+	if !skipSnapshot {
+		logrus.Println("Taking a snapshot of the current volume contents before restoring")
+		job, err := iv.CreateSnapshot(service.ID, 0)
+		if err != nil {
+			return err
+		}
+		status, err := ij.PollTillFinished(job.ID, service.ID)
+		if err != nil {
+			return err
+		}
+		logrus.Printf("Pre-restore snapshot ended in status '%s' (job ID = %s)", status, job.ID)
+	}
+	job, err := iv.RestoreSnapshot(service.ID, snapshotID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Restore started (job ID = %s)", job.ID)
+	if skipPoll {
+		logrus.Printf("You can wait for the restore to finish with the \"datica jobs attach %s %s\" command", svcName, job.ID)
+		return nil
+	}
+	logrus.Println("Polling until restore finishes.")
+	status, err := ij.PollTillFinished(job.ID, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Ended in status '%s'", status)
+	return nil
+}
+
+// RestoreSnapshot starts a job replacing the contents of a service's volumes
+// with the contents of the given snapshot.
+func (v *SVolumes) RestoreSnapshot(svcID, snapshotID string) (*models.Job, error) {
+	headers := v.Settings.HTTPManager.GetHeaders(v.Settings.SessionToken, v.Settings.Version, v.Settings.Pod, v.Settings.UsersID)
+	resp, statusCode, err := v.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/volumes/snapshots/%s/restore", v.Settings.PaasHost, v.Settings.PaasHostVersion, v.Settings.EnvironmentID, svcID, snapshotID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var job models.Job
+	err = v.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
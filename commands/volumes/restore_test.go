@@ -0,0 +1,97 @@
+package volumes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var snapshotRestoreTests = []struct {
+	svcName        string
+	snapshotID     string
+	skipSnapshot   bool
+	skipPoll       bool
+	expectSnapshot bool
+	expectRestore  bool
+	expectOutput   []string
+	expectErr      bool
+}{
+	{test.SvcLabel, "snap2", false, false, true, true, []string{"Pre-restore snapshot ended in status 'finished' (job ID = snapjob)", "Restore started (job ID = restorejob)", "Ended in status 'finished'"}, false},
+	{test.SvcLabel, "snap2", true, false, false, true, []string{"Restore started (job ID = restorejob)"}, false},
+	{test.SvcLabel, "snap3", true, true, false, true, []string{"datica jobs attach " + test.SvcLabel + " restorejob"}, false},
+	{test.SvcLabel, "unknown", false, false, false, false, nil, true},
+	{test.SvcLabel, "snap4", false, false, false, false, nil, true},
+	{"invalid-svc", "snap2", false, false, false, false, nil, true},
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	snapshotted := false
+	restored := ""
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","scale":1}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				fmt.Fprint(w, snapshotsList)
+				return
+			}
+			test.AssertEquals(t, r.Method, "POST")
+			snapshotted = true
+			fmt.Fprint(w, `{"id":"snapjob","type":"snapshot","status":"scheduled"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots/",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			restored = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/environments/"+test.EnvID+"/services/"+test.SvcID+"/volumes/snapshots/"), "/restore")
+			fmt.Fprint(w, `{"id":"restorejob","type":"restore","status":"scheduled"}`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `{"id":"%s","status":"finished"}`, strings.TrimPrefix(r.URL.Path, "/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/"))
+		},
+	)
+
+	for _, data := range snapshotRestoreTests {
+		t.Logf("Data: %+v", data)
+		snapshotted = false
+		restored = ""
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdSnapshotRestore(data.svcName, data.snapshotID, data.skipSnapshot, data.skipPoll, New(settings), services.New(settings), jobs.New(settings), &test.FakePrompts{})
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectSnapshot), fmt.Sprintf("%t", snapshotted))
+		if data.expectRestore {
+			test.AssertEquals(t, data.snapshotID, restored)
+		} else if restored != "" {
+			t.Errorf("Expected no restore to be started but %s was restored", restored)
+		}
+		for _, line := range data.expectOutput {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected the output to contain %q but got %s", line, output)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package whoami
 
 import (
 	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/lib/auth"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
@@ -12,28 +13,36 @@ import (
 // name, arguments, and required/optional arguments and flags for the command.
 var Cmd = models.Command{
 	Name:      "whoami",
-	ShortHelp: "Retrieve your user ID",
-	LongHelp: "`whoami` prints out the currently logged in user's users ID. " +
-		"This is used with Datica support engineers. Here is a sample command\n\n" +
-		"```\ndatica whoami\n```",
+	ShortHelp: "Retrieve your email, user ID, and organizations",
+	LongHelp: "`whoami` prints out the currently logged in user's email and users ID, and the organizations they belong to with their role in each. " +
+		"The users ID is used with Datica support engineers. " +
+		"Scripts can use this to check which account they are about to act as, use `--json` to print it as JSON. Here are some sample commands\n\n" +
+		"```\ndatica whoami\n" +
+		"datica whoami --json\n```",
+	Category:   models.CategoryAccess,
+	JSONOutput: identity{},
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
+			jsonOutput := config.JSONOpt(cmd.BoolOpt("json", false, "Output your user and organizations as JSON"))
 			cmd.Action = func() {
-				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+				user, err := auth.New(settings, prompts.New()).Signin()
+				if err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdWhoAmI(New(settings))
+				err = CmdWhoAmI(user, *jsonOutput, New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
+			cmd.Spec = "[--json]"
 		}
 	},
 }
 
 // IWhoAmI
 type IWhoAmI interface {
-	WhoAmI() (string, error)
+	Orgs() (*[]models.Org, error)
+	Role(orgID, usersID string) (string, error)
 }
 
 // SWhoAmI is a concrete implementation of IWhoAmI
@@ -1,17 +1,135 @@
 package whoami
 
-import "github.com/Sirupsen/logrus"
+import (
+	"encoding/json"
+	"fmt"
 
-func CmdWhoAmI(w IWhoAmI) error {
-	usersID, err := w.WhoAmI()
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pmylund/sortutil"
+)
+
+// identity is the signed in user and the organizations they belong to
+type identity struct {
+	Email         string       `json:"email"`
+	UsersID       string       `json:"userId"`
+	Organizations []membership `json:"organizations"`
+}
+
+// membership is an organization the signed in user belongs to. The role is
+// empty when it could not be looked up.
+type membership struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+func CmdWhoAmI(user *models.User, jsonOutput bool, iw IWhoAmI) error {
+	orgs, err := iw.Orgs()
 	if err != nil {
 		return err
 	}
-	logrus.Printf("user ID = %s", usersID)
+	sortutil.AscByField(*orgs, "Name")
+	id := identity{
+		Email:         user.Email,
+		UsersID:       user.UsersID,
+		Organizations: []membership{},
+	}
+	for _, org := range *orgs {
+		role, err := iw.Role(org.ID, user.UsersID)
+		if err != nil {
+			logrus.Debugf("Unable to look up your role in %s: %s", org.Name, err)
+		}
+		id.Organizations = append(id.Organizations, membership{ID: org.ID, Name: org.Name, Role: role})
+	}
+
+	if jsonOutput {
+		b, err := json.MarshalIndent(id, "", "    ")
+		if err != nil {
+			return err
+		}
+		logrus.Println(string(b))
+		return nil
+	}
+
+	logrus.Printf("email = %s", id.Email)
+	logrus.Printf("user ID = %s", id.UsersID)
+	if len(id.Organizations) == 0 {
+		logrus.Println("\nYou do not belong to any organizations")
+		return nil
+	}
+	logrus.Println()
+	data := [][]string{{"ORGANIZATION", "ID", "ROLE"}}
+	for _, m := range id.Organizations {
+		role := m.Role
+		if role == "" {
+			role = "unknown"
+		}
+		data = append(data, []string{m.Name, m.ID, role})
+	}
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
 	return nil
 }
 
-// WhoAmI returns your user ID.
-func (w *SWhoAmI) WhoAmI() (string, error) {
-	return w.Settings.UsersID, nil
+// Orgs lists the organizations the signed in user belongs to
+func (w *SWhoAmI) Orgs() (*[]models.Org, error) {
+	headers := w.Settings.HTTPManager.GetHeaders(w.Settings.SessionToken, w.Settings.Version, w.Settings.Pod, w.Settings.UsersID)
+	resp, statusCode, err := w.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs", w.Settings.AuthHost, w.Settings.AuthHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	var orgs []models.Org
+	err = w.Settings.HTTPManager.ConvertResp(resp, statusCode, &orgs)
+	if err != nil {
+		return nil, err
+	}
+	return &orgs, nil
+}
+
+// Role returns the name of the role the user with the given ID has in the
+// given organization
+func (w *SWhoAmI) Role(orgID, usersID string) (string, error) {
+	headers := w.Settings.HTTPManager.GetHeaders(w.Settings.SessionToken, w.Settings.Version, w.Settings.Pod, w.Settings.UsersID)
+	resp, statusCode, err := w.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/users", w.Settings.AuthHost, w.Settings.AuthHostVersion, orgID), headers)
+	if err != nil {
+		return "", err
+	}
+	var users []models.OrgUser
+	if err = w.Settings.HTTPManager.ConvertResp(resp, statusCode, &users); err != nil {
+		return "", err
+	}
+	roleID := -1
+	for _, u := range users {
+		if u.ID == usersID {
+			roleID = u.RoleID
+			break
+		}
+	}
+	if roleID == -1 {
+		return "", fmt.Errorf("You are not a member of the organization %s", orgID)
+	}
+
+	resp, statusCode, err = w.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/orgs/%s/roles", w.Settings.AuthHost, w.Settings.AuthHostVersion, orgID), headers)
+	if err != nil {
+		return "", err
+	}
+	var roles []models.Role
+	if err = w.Settings.HTTPManager.ConvertResp(resp, statusCode, &roles); err != nil {
+		return "", err
+	}
+	for _, r := range roles {
+		if r.ID == roleID {
+			return r.Name, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown role %d", roleID)
 }
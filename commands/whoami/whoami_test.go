@@ -0,0 +1,92 @@
+package whoami
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var whoAmITests = []struct {
+	orgsStatus int
+	jsonOutput bool
+	expectErr  bool
+}{
+	{http.StatusOK, false, false},
+	{http.StatusOK, true, false},
+	{http.StatusUnauthorized, false, true},
+}
+
+func TestWhoAmI(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	orgsStatus := http.StatusOK
+	mux.HandleFunc("/orgs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			w.WriteHeader(orgsStatus)
+			fmt.Fprintf(w, `[{"id":"%s","name":"org1"},{"id":"%s","name":"org2"}]`, test.OrgID, test.OrgIDAlt)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"user1","roleID":2},{"id":"user2","roleID":1}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":1,"name":"owner"},{"id":2,"name":"member"}]`)
+		},
+	)
+	// roles that can't be looked up are shown as unknown
+	mux.HandleFunc("/orgs/"+test.OrgIDAlt+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+	)
+
+	for _, data := range whoAmITests {
+		t.Logf("Data: %+v", data)
+		orgsStatus = data.orgsStatus
+
+		// test
+		err := CmdWhoAmI(&models.User{Email: "me@example.com", UsersID: "user1"}, data.jsonOutput, New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func TestRole(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	settings.AuthHost = baseURL.String()
+	mux.HandleFunc("/orgs/"+test.OrgID+"/users",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"id":"user1","roleID":2}]`)
+		},
+	)
+	mux.HandleFunc("/orgs/"+test.OrgID+"/roles",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"id":1,"name":"owner"},{"id":2,"name":"member"}]`)
+		},
+	)
+
+	role, err := New(settings).Role(test.OrgID, "user1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, "member", role)
+	if _, err = New(settings).Role(test.OrgID, "user2"); err == nil {
+		t.Error("Expected an error for a user that is not a member")
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/alerts"
 	"github.com/daticahealth/cli/lib/auth"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
@@ -16,13 +19,17 @@ import (
 var Cmd = models.Command{
 	Name:      "worker",
 	ShortHelp: "Manage a service's workers",
-	LongHelp:  "The `worker` command allows to deploy, list, remove, and scale the workers in a code service.",
+	LongHelp:  "The `worker` command allows to deploy, list, pause, remove, restart, resume, and scale the workers in a code service.",
+	Category:  models.CategoryDeploy,
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(cmd *cli.Cmd) {
-			cmd.CommandLong(DeploySubCmd.Name, DeploySubCmd.ShortHelp, DeploySubCmd.LongHelp, DeploySubCmd.CmdFunc(settings))
-			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, ListSubCmd.LongHelp, ListSubCmd.CmdFunc(settings))
-			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, RmSubCmd.LongHelp, RmSubCmd.CmdFunc(settings))
-			cmd.CommandLong(ScaleSubCmd.Name, ScaleSubCmd.ShortHelp, ScaleSubCmd.LongHelp, ScaleSubCmd.CmdFunc(settings))
+			cmd.CommandLong(DeploySubCmd.Name, DeploySubCmd.ShortHelp, help.Render(DeploySubCmd.LongHelp), DeploySubCmd.CmdFunc(settings))
+			cmd.CommandLong(ListSubCmd.Name, ListSubCmd.ShortHelp, help.Render(ListSubCmd.LongHelp), ListSubCmd.CmdFunc(settings))
+			cmd.CommandLong(PauseSubCmd.Name, PauseSubCmd.ShortHelp, help.Render(PauseSubCmd.LongHelp), PauseSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RestartSubCmd.Name, RestartSubCmd.ShortHelp, help.Render(RestartSubCmd.LongHelp), RestartSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ResumeSubCmd.Name, ResumeSubCmd.ShortHelp, help.Render(ResumeSubCmd.LongHelp), ResumeSubCmd.CmdFunc(settings))
+			cmd.CommandLong(RmSubCmd.Name, RmSubCmd.ShortHelp, help.Render(RmSubCmd.LongHelp), RmSubCmd.CmdFunc(settings))
+			cmd.CommandLong(ScaleSubCmd.Name, ScaleSubCmd.ShortHelp, help.Render(ScaleSubCmd.LongHelp), ScaleSubCmd.CmdFunc(settings))
 		}
 	},
 }
@@ -36,7 +43,7 @@ var DeploySubCmd = models.Command{
 		"```\ndatica -E \"<your_env_alias>\" worker deploy code-1 mailer\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to use to deploy a worker")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to use to deploy a worker. Defaults to the service pinned by the workspace file.")
 			target := subCmd.StringArg("TARGET", "", "The name of the Procfile target to invoke as a worker")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
@@ -45,12 +52,16 @@ var DeploySubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdDeploy(*serviceName, *target, New(settings), services.New(settings), jobs.New(settings))
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdDeploy(svcName, *target, New(settings), services.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "SERVICE_NAME TARGET"
+			subCmd.Spec = "[SERVICE_NAME] TARGET"
 		}
 	},
 }
@@ -58,11 +69,110 @@ var DeploySubCmd = models.Command{
 var ListSubCmd = models.Command{
 	Name:      "list",
 	ShortHelp: "Lists all workers for a given service",
-	LongHelp: "`worker list` lists all workers and their scale for a given code service along with the number of currently running instances of each worker target. Here is a sample command\n\n" +
-		"```\ndatica -E \"<your_env_alias>\" worker list code-1\n```",
+	LongHelp: "`worker list` lists all workers and their scale for a given code service along with the number of currently running instances of each worker target. " +
+		"The number of jobs of each target that were killed for exceeding the memory limit of the service is listed as well, since the platform restarts them like any other failed job. " +
+		"Use `--notify-on-oom` to be notified in your inbox whenever a job of the service is killed for exceeding its memory limit. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" worker list code-1\n" +
+		"datica -E \"<your_env_alias>\" worker list code-1 --notify-on-oom\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to list workers for. Defaults to the service pinned by the workspace file.")
+			notifyOnOOM := subCmd.BoolOpt("notify-on-oom", false, "Create an alert rule that notifies you when a job of the service is killed for exceeding its memory limit")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdList(svcName, *notifyOnOOM, New(settings), services.New(settings), jobs.New(settings), alerts.New(settings), probe.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] [--notify-on-oom]"
+		}
+	},
+}
+
+var PauseSubCmd = models.Command{
+	Name:      "pause",
+	ShortHelp: "Stop the workers for a given service and target until they are resumed",
+	LongHelp: "`worker pause` scales a worker TARGET to zero and stops its running instances, such as to halt consumers while data is migrated. " +
+		"Unlike [worker rm](#worker-rm), the target is kept along with its current scale, and [worker resume](#worker-resume) starts the same number of workers again. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" worker pause code-1 mailer\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers. Defaults to the service pinned by the workspace file.")
+			target := subCmd.StringArg("TARGET", "", "The worker target to pause")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdPause(svcName, *target, New(settings), services.New(settings), prompts.New(), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] TARGET"
+		}
+	},
+}
+
+var RestartSubCmd = models.Command{
+	Name:      "restart",
+	ShortHelp: "Restart the running workers for a given service and target",
+	LongHelp: "`worker restart` stops the running instances of a worker TARGET and starts new ones in their place, which is useful to pick up a change such as new environment variables without a full redeploy. " +
+		"By default the workers are restarted one at a time and each replacement must be running before the next worker is stopped, so the rest of the workers keep processing. " +
+		"Use `--all` to stop every worker at once and replace them together. Here are some sample commands\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" worker restart code-1 mailer\n" +
+		"datica -E \"<your_env_alias>\" worker restart code-1 mailer --all\n```",
+	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
+		return func(subCmd *cli.Cmd) {
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers. Defaults to the service pinned by the workspace file.")
+			target := subCmd.StringArg("TARGET", "", "The worker target to restart")
+			all := subCmd.BoolOpt("all", false, "Restart every worker at once instead of one at a time")
+			subCmd.Action = func() {
+				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
+					logrus.Fatal(err.Error())
+				}
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdRestart(svcName, *target, *all, services.New(settings), prompts.New(), jobs.New(settings))
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+			}
+			subCmd.Spec = "[SERVICE_NAME] TARGET [--all]"
+		}
+	},
+}
+
+var ResumeSubCmd = models.Command{
+	Name:      "resume",
+	ShortHelp: "Start the workers for a given service and target again after a pause",
+	LongHelp: "`worker resume` restores a worker TARGET that was stopped with [worker pause](#worker-pause) to the scale it had when it was paused and starts its workers. Here is a sample command\n\n" +
+		"```\ndatica -E \"<your_env_alias>\" worker resume code-1 mailer\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service to list workers for")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers. Defaults to the service pinned by the workspace file.")
+			target := subCmd.StringArg("TARGET", "", "The worker target to resume")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -70,12 +180,16 @@ var ListSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdList(*serviceName, New(settings), services.New(settings), jobs.New(settings))
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdResume(svcName, *target, New(settings), services.New(settings), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "SERVICE_NAME"
+			subCmd.Spec = "[SERVICE_NAME] TARGET"
 		}
 	},
 }
@@ -87,7 +201,7 @@ var RmSubCmd = models.Command{
 		"```\ndatica -E \"<your_env_alias>\" worker rm code-1 mailer\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers. Defaults to the service pinned by the workspace file.")
 			target := subCmd.StringArg("TARGET", "", "The worker target to remove")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
@@ -96,12 +210,16 @@ var RmSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdRm(*serviceName, *target, New(settings), services.New(settings), prompts.New(), jobs.New(settings))
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdRm(svcName, *target, New(settings), services.New(settings), prompts.New(), jobs.New(settings))
 				if err != nil {
 					logrus.Fatalln(err.Error())
 				}
 			}
-			subCmd.Spec = "SERVICE_NAME TARGET"
+			subCmd.Spec = "[SERVICE_NAME] TARGET"
 		}
 	},
 }
@@ -110,14 +228,19 @@ var ScaleSubCmd = models.Command{
 	Name:      "scale",
 	ShortHelp: "Scale existing workers up or down for a given service and target",
 	LongHelp: "`worker scale` allows you to scale up or down a given worker TARGET. " +
-		"Scaling up will launch new instances of the worker TARGET while scaling down will immediately stop running instances of the worker TARGET if applicable. Here are some sample commands\n\n" +
+		"Scaling up will launch new instances of the worker TARGET while scaling down will immediately stop running instances of the worker TARGET if applicable. " +
+		"The scale can be a number of workers, a change in the number of workers such as `+2` or `-1`, or a percentage of the current scale such as `150%`. " +
+		"The total scale of every target can not exceed the worker limit of the service. " +
+		"Use `--dry-run` to print the scale of every target before and after the change without changing anything. Here are some sample commands\n\n" +
 		"```\ndatica -E \"<your_env_alias>\" worker scale code-1 mailer 1\n" +
-		"datica -E \"<your_env_alias>\" worker scale code-1 mailer -- -2\n```",
+		"datica -E \"<your_env_alias>\" worker scale code-1 mailer -- -2\n" +
+		"datica -E \"<your_env_alias>\" worker scale code-1 mailer 150% --dry-run\n```",
 	CmdFunc: func(settings *models.Settings) func(cmd *cli.Cmd) {
 		return func(subCmd *cli.Cmd) {
-			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers")
+			serviceName := subCmd.StringArg("SERVICE_NAME", "", "The name of the service running the workers. Defaults to the service pinned by the workspace file.")
 			target := subCmd.StringArg("TARGET", "", "The worker target to scale up or down")
-			scale := subCmd.StringArg("SCALE", "", "The new scale (or change in scale) for the given worker target. This can be a single value (i.e. 2) representing the final number of workers that should be running. Or this can be a change represented by a plus or minus sign followed by the value (i.e. +2 or -1). When using a change in value, be sure to insert the \"--\" operator to signal the end of options. For example, \"datica worker scale code-1 worker -- -1\"")
+			scale := subCmd.StringArg("SCALE", "", "The new scale (or change in scale) for the given worker target. This can be a single value (i.e. 2) representing the final number of workers that should be running. Or this can be a change represented by a plus or minus sign followed by the value (i.e. +2 or -1), or a percentage of the current scale (i.e. 150%). When using a change in value, be sure to insert the \"--\" operator to signal the end of options. For example, \"datica worker scale code-1 worker -- -1\"")
+			dryRun := subCmd.BoolOpt("dry-run", false, "Print the scale of every target before and after the change without changing anything")
 			subCmd.Action = func() {
 				if _, err := auth.New(settings, prompts.New()).Signin(); err != nil {
 					logrus.Fatal(err.Error())
@@ -125,12 +248,16 @@ var ScaleSubCmd = models.Command{
 				if err := config.CheckRequiredAssociation(true, true, settings); err != nil {
 					logrus.Fatal(err.Error())
 				}
-				err := CmdScale(*serviceName, *target, *scale, New(settings), services.New(settings), prompts.New(), jobs.New(settings))
+				svcName, err := config.RequiredServiceName(*serviceName, settings)
+				if err != nil {
+					logrus.Fatal(err.Error())
+				}
+				err = CmdScale(svcName, *target, *scale, *dryRun, New(settings), services.New(settings), prompts.New(), jobs.New(settings))
 				if err != nil {
 					logrus.Fatal(err.Error())
 				}
 			}
-			subCmd.Spec = "SERVICE_NAME TARGET SCALE"
+			subCmd.Spec = "[SERVICE_NAME] TARGET SCALE [--dry-run]"
 		}
 	},
 }
@@ -29,7 +29,7 @@ func CmdDeploy(svcName, target string, iw IWorker, is services.IServices, ij job
 	if err != nil {
 		return err
 	}
-	err = ij.DeployTarget(target, service.ID)
+	_, err = ij.DeployTarget(target, service.ID)
 	if err != nil {
 		return err
 	}
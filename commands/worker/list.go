@@ -5,12 +5,14 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/alerts"
 	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
 	"github.com/daticahealth/cli/models"
 	"github.com/olekukonko/tablewriter"
 )
 
-func CmdList(svcName string, iw IWorker, is services.IServices, ij jobs.IJobs) error {
+func CmdList(svcName string, notifyOnOOM bool, iw IWorker, is services.IServices, ij jobs.IJobs, ia alerts.IAlerts, ip probe.IProbe) error {
 	service, err := is.RetrieveByLabel(svcName)
 	if err != nil {
 		return err
@@ -18,42 +20,73 @@ func CmdList(svcName string, iw IWorker, is services.IServices, ij jobs.IJobs) e
 	if service == nil {
 		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services list\" command.", svcName)
 	}
+	if notifyOnOOM {
+		if err = notifyOOM(service, ia); err != nil {
+			return err
+		}
+	}
 	workers, err := iw.Retrieve(service.ID)
 	if err != nil {
 		return err
 	}
 
-	jobs, err := ij.RetrieveByType(service.ID, "worker", 1, 1000)
-	if err != nil {
-		return err
+	// older pods can't list the jobs of every worker, so only the scale of
+	// each target is shown
+	var jobList *[]models.Job
+	if ip.Available(probe.WorkerJobs) {
+		jobList, err = ij.RetrieveAllByType(service.ID, "worker")
+		if err = ip.Check(probe.WorkerJobs, err); err != nil {
+			return err
+		}
 	}
 	type workerJob struct {
 		scale   int
 		running int
+		oom     int
 	}
 	var workerJobs = map[string]*workerJob{}
 	for target, scale := range workers.Workers {
-		workerJobs[target] = &workerJob{scale, 0}
+		workerJobs[target] = &workerJob{scale, 0, 0}
 	}
 	if len(workerJobs) == 0 {
 		logrus.Printf("No running workers found for %s", svcName)
 		logrus.Printf("\nYou are using 0 out of your available %d workers for %s", service.WorkerScale, svcName)
 		return nil
 	}
-	for _, j := range *jobs {
+	oomKilled := 0
+	var workerJobList []models.Job
+	if jobList != nil {
+		workerJobList = *jobList
+	}
+	for i, j := range workerJobList {
 		if _, ok := workerJobs[j.Target]; !ok {
-			workerJobs[j.Target] = &workerJob{0, 0}
+			workerJobs[j.Target] = &workerJob{0, 0, 0}
 		}
 		if j.Status == "running" {
 			workerJobs[j.Target].running += 1
 		}
+		if jobs.OOMKilled(&workerJobList[i]) {
+			workerJobs[j.Target].oom += 1
+			oomKilled++
+		}
 	}
 
-	data := [][]string{{"TARGET", "SCALE", "RUNNING JOBS"}}
+	data := [][]string{{"TARGET", "SCALE"}}
+	if jobList != nil {
+		data[0] = append(data[0], "RUNNING JOBS", "OOM KILLED")
+	}
 	total := 0
 	for target, wj := range workerJobs {
 		total += wj.scale
-		data = append(data, []string{target, fmt.Sprintf("%d", wj.scale), fmt.Sprintf("%d", wj.running)})
+		scale := fmt.Sprintf("%d", wj.scale)
+		if paused, ok := workers.Paused[target]; ok {
+			scale = fmt.Sprintf("%d (paused, resumes at %d)", wj.scale, paused)
+		}
+		row := []string{target, scale}
+		if jobList != nil {
+			row = append(row, fmt.Sprintf("%d", wj.running), fmt.Sprintf("%d", wj.oom))
+		}
+		data = append(data, row)
 	}
 
 	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
@@ -65,6 +98,25 @@ func CmdList(svcName string, iw IWorker, is services.IServices, ij jobs.IJobs) e
 	table.AppendBulk(data)
 	table.Render()
 	logrus.Printf("\nYou are using %d out of your available %d workers for %s", total, service.WorkerScale, svcName)
+	ip.Note()
+	if oomKilled > 0 {
+		logrus.Warnf("%d worker jobs of %s were killed for exceeding the memory limit of the service. Workers share the memory of the service, so consider scaling down workers or resizing the service.", oomKilled, svcName)
+		if !notifyOnOOM {
+			logrus.Printf("Run \"datica worker list %s --notify-on-oom\" to be notified when this happens again", svcName)
+		}
+	}
+	return nil
+}
+
+// notifyOOM creates an alert rule that notifies the members of the
+// environment when a job of the service is killed for exceeding its memory
+// limit
+func notifyOOM(service *models.Service, ia alerts.IAlerts) error {
+	_, err := ia.CreateRule(&models.AlertRule{Event: alerts.EventOOM, ServiceID: service.ID})
+	if err != nil {
+		return err
+	}
+	logrus.Printf("You will be notified in your inbox when a job of %s is killed for exceeding its memory limit", service.Label)
 	return nil
 }
 
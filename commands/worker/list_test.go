@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
+	"github.com/daticahealth/cli/test"
+)
+
+var listTests = []struct {
+	jobsStatus        int
+	expectUnavailable bool
+	expectErr         bool
+}{
+	{http.StatusOK, false, false},
+	{http.StatusNotFound, true, false},
+	{http.StatusForbidden, false, true},
+}
+
+func TestList(t *testing.T) {
+	for _, data := range listTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `[{"id":"%s","label":"%s","workerScale":2}]`, test.SvcID, test.SvcLabel)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprint(w, `{"workers":{"worker":1}}`)
+			},
+		)
+		jobsRequests := 0
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				jobsRequests++
+				w.WriteHeader(data.jobsStatus)
+				fmt.Fprint(w, `[{"id":"1","type":"worker","target":"worker","status":"running"}]`)
+			},
+		)
+		ip := probe.New(settings)
+
+		// test
+		err := CmdList(test.SvcLabel, false, New(settings), services.New(settings), jobs.New(settings), alerts.New(settings), ip)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if !data.expectErr {
+			// a missing endpoint is not requested again
+			err = CmdList(test.SvcLabel, false, New(settings), services.New(settings), jobs.New(settings), alerts.New(settings), probe.New(settings))
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			expectRequests := 2
+			if data.expectUnavailable {
+				expectRequests = 1
+			}
+			test.AssertEquals(t, fmt.Sprintf("%d", expectRequests), fmt.Sprintf("%d", jobsRequests))
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectUnavailable), fmt.Sprintf("%t", !ip.Available(probe.WorkerJobs)))
+		test.Teardown(server)
+	}
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+)
+
+func CmdPause(svcName, target string, iw IWorker, is services.IServices, ip prompts.IPrompts, ij jobs.IJobs) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services list\" command.", svcName)
+	}
+	workers, err := iw.Retrieve(service.ID)
+	if err != nil {
+		return err
+	}
+	if scale, ok := workers.Paused[target]; ok {
+		logrus.Printf("Worker target %s for service %s is already paused and will be resumed at a scale of %d", target, svcName, scale)
+		return nil
+	}
+	scale, ok := workers.Workers[target]
+	if !ok || scale == 0 {
+		return fmt.Errorf("Worker target %s for service %s is not running any workers. You can list workers with the \"datica worker list %s\" command.", target, svcName, svcName)
+	}
+	err = ip.YesNo(fmt.Sprintf("Pausing the worker target %s for service %s will stop its %d workers until it is resumed, would you like to proceed? (y/n) ", target, svcName, scale))
+	if err != nil {
+		return err
+	}
+	// the target is scaled to zero before its jobs are stopped so that they
+	// are not started again in the meantime
+	if workers.Paused == nil {
+		workers.Paused = map[string]int{}
+	}
+	workers.Paused[target] = scale
+	workers.Workers[target] = 0
+	err = iw.Update(service.ID, workers)
+	if err != nil {
+		return err
+	}
+	jobs, err := ij.RetrieveByTarget(service.ID, target)
+	if err != nil {
+		return err
+	}
+	for _, j := range *jobs {
+		err = ij.Delete(j.ID, service.ID)
+		if err != nil {
+			return err
+		}
+	}
+	logrus.Printf("Successfully paused worker target %s for service %s. Run \"datica worker resume %s %s\" to start its %d workers again.", target, svcName, svcName, target, scale)
+	return nil
+}
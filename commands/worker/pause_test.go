@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/alerts"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/probe"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+// workersList has a running worker target and a mailer target that was
+// paused at a scale of 3
+const workersList = `{"workers":{"worker":2,"mailer":0},"paused":{"mailer":3}}`
+
+// lookup returns the scale of the target in the last update, or -1 if the
+// target was not updated
+func lookup(m map[string]int, key string) int {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return -1
+}
+
+var pauseTests = []struct {
+	target       string
+	expectScale  int
+	expectPaused int
+	expectStop   bool
+	expectOutput []string
+	expectErr    bool
+}{
+	{"worker", 0, 2, true, []string{"Successfully paused worker target worker", "to start its 2 workers again"}, false},
+	{"mailer", -1, -1, false, []string{"is already paused and will be resumed at a scale of 3"}, false},
+	{"unknown", -1, -1, false, nil, true},
+}
+
+func TestPause(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	scales := map[string]int{}
+	paused := map[string]int{}
+	stopped := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","worker_scale":%d}]`, test.SvcID, test.SvcLabel, 6)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprint(w, workersList)
+			case "POST":
+				var workers models.Workers
+				json.NewDecoder(r.Body).Decode(&workers)
+				for target, scale := range workers.Workers {
+					scales[target] = scale
+				}
+				for target, scale := range workers.Paused {
+					paused[target] = scale
+				}
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"job1","type":"worker","target":"worker","status":"running"}]`)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/job1",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			stopped = true
+		},
+	)
+
+	for _, data := range pauseTests {
+		t.Logf("Data: %+v", data)
+		scales = map[string]int{}
+		paused = map[string]int{}
+		stopped = false
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdPause(test.SvcLabel, data.target, New(settings), services.New(settings), &test.FakePrompts{}, jobs.New(settings))
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectScale), fmt.Sprintf("%d", lookup(scales, data.target)))
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectPaused), fmt.Sprintf("%d", lookup(paused, data.target)))
+		test.AssertEquals(t, fmt.Sprintf("%t", data.expectStop), fmt.Sprintf("%t", stopped))
+		for _, line := range data.expectOutput {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected the output to contain %q but got %s", line, output)
+			}
+		}
+	}
+}
+
+var resumeTests = []struct {
+	target       string
+	limit        int
+	expectScale  int
+	expectOutput []string
+	expectErr    bool
+}{
+	{"mailer", 6, 3, []string{"Successfully resumed worker target mailer", "at a scale of 3"}, false},
+	{"mailer", 4, -1, nil, true},
+	{"worker", 6, -1, nil, true},
+}
+
+func TestResume(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	scales := map[string]int{}
+	paused := map[string]int{}
+	limit := 0
+	deployed := false
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","worker_scale":%d}]`, test.SvcID, test.SvcLabel, limit)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprint(w, workersList)
+			case "POST":
+				var workers models.Workers
+				json.NewDecoder(r.Body).Decode(&workers)
+				for target, scale := range workers.Workers {
+					scales[target] = scale
+				}
+				for target, scale := range workers.Paused {
+					paused[target] = scale
+				}
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/deploy",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			deployed = true
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range resumeTests {
+		t.Logf("Data: %+v", data)
+		scales = map[string]int{}
+		paused = map[string]int{}
+		limit = data.limit
+		deployed = false
+
+		// test
+		var err error
+		output := test.CaptureOutput(func() {
+			err = CmdResume(test.SvcLabel, data.target, New(settings), services.New(settings), jobs.New(settings))
+		})
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectScale), fmt.Sprintf("%d", lookup(scales, data.target)))
+		if lookup(paused, data.target) != -1 {
+			t.Errorf("Expected %s to no longer be paused", data.target)
+		}
+		test.AssertEquals(t, fmt.Sprintf("%t", !data.expectErr), fmt.Sprintf("%t", deployed))
+		for _, line := range data.expectOutput {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected the output to contain %q but got %s", line, output)
+			}
+		}
+	}
+}
+
+func TestListPaused(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	scales := map[string]int{}
+	paused := map[string]int{}
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","worker_scale":%d}]`, test.SvcID, test.SvcLabel, 6)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprint(w, workersList)
+			case "POST":
+				var workers models.Workers
+				json.NewDecoder(r.Body).Decode(&workers)
+				for target, scale := range workers.Workers {
+					scales[target] = scale
+				}
+				for target, scale := range workers.Paused {
+					paused[target] = scale
+				}
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprint(w, `[{"id":"job1","type":"worker","target":"worker","status":"running"}]`)
+		},
+	)
+
+	// test
+	var err error
+	output := test.CaptureOutput(func() {
+		err = CmdList(test.SvcLabel, false, New(settings), services.New(settings), jobs.New(settings), alerts.New(settings), probe.New(settings))
+	})
+
+	// assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, line := range []string{"mailer", "0 (paused, resumes at 3)", "You are using 2 out of your available 6 workers"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected the list to contain %q but got %s", line, output)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// CmdRestart restarts the running workers of the given target. By default the
+// workers are restarted one at a time, waiting for each replacement to be
+// running before stopping the next worker, so the rest of the workers keep
+// processing. With all, every worker is stopped at once and replaced.
+func CmdRestart(svcName, target string, all bool, is services.IServices, ip prompts.IPrompts, ij jobs.IJobs) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services list\" command.", svcName)
+	}
+	targetJobs, err := ij.RetrieveByTarget(service.ID, target)
+	if err != nil {
+		return err
+	}
+	running := []models.Job{}
+	for _, j := range *targetJobs {
+		if j.Status == "running" {
+			running = append(running, j)
+		}
+	}
+	if len(running) == 0 {
+		return fmt.Errorf("There are no running workers with target %s for service %s. You can list workers with the \"datica worker list\" command.", target, svcName)
+	}
+
+	if all {
+		err = ip.YesNo(fmt.Sprintf("Restarting all %d workers with target %s for service %s at once will stop them all before they are replaced, would you like to proceed? (y/n) ", len(running), target, svcName))
+		if err != nil {
+			return err
+		}
+		for _, j := range running {
+			if err = ij.Delete(j.ID, service.ID); err != nil {
+				return err
+			}
+		}
+		if err = replaceWorker(target, service.ID, ij); err != nil {
+			return err
+		}
+		logrus.Printf("Successfully restarted %d workers with target %s for service %s", len(running), target, svcName)
+		return nil
+	}
+
+	for i, j := range running {
+		logrus.Printf("Restarting worker %d of %d with target %s (job ID = %s)", i+1, len(running), target, j.ID)
+		if err = ij.Delete(j.ID, service.ID); err != nil {
+			return err
+		}
+		if err = replaceWorker(target, service.ID, ij); err != nil {
+			return fmt.Errorf("Restarted %d of %d workers with target %s for service %s before failing: %s", i, len(running), target, svcName, err)
+		}
+	}
+	logrus.Printf("Successfully restarted %d workers with target %s for service %s", len(running), target, svcName)
+	return nil
+}
+
+// replaceWorker deploys the given target, which brings its workers back up to
+// their scale, and waits until the new worker is running
+func replaceWorker(target, svcID string, ij jobs.IJobs) error {
+	job, err := ij.DeployTarget(target, svcID)
+	if err != nil {
+		return err
+	}
+	if job.ID == "" {
+		return nil
+	}
+	status, err := ij.PollForStatus(jobs.TerminalStatuses("worker"), job.ID, svcID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("\nWorker %s is %s", job.ID, status)
+	return nil
+}
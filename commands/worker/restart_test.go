@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/test"
+)
+
+var restartTests = []struct {
+	target        string
+	all           bool
+	expectDeletes int
+	expectDeploys int
+	expectErr     bool
+}{
+	{"worker", false, 3, 3, false},
+	{"worker", true, 3, 1, false},
+	{"mailer", false, 0, 0, true},
+	{"unknown", false, 0, 0, true},
+}
+
+func TestRestart(t *testing.T) {
+	for _, data := range restartTests {
+		t.Logf("Data: %+v", data)
+		mux, server, baseURL := test.Setup()
+		settings := test.GetSettings(baseURL.String())
+		mux.HandleFunc("/environments/"+test.EnvID+"/services",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				fmt.Fprintf(w, `[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel)
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "GET")
+				if page, _ := strconv.Atoi(r.URL.Query().Get("pageNumber")); page > 1 {
+					fmt.Fprint(w, `[]`)
+					return
+				}
+				fmt.Fprint(w, `[{"id":"1","type":"worker","target":"worker","status":"running"},{"id":"2","type":"worker","target":"worker","status":"running"},{"id":"3","type":"worker","target":"worker","status":"running"},{"id":"4","type":"worker","target":"worker","status":"finished"},{"id":"5","type":"worker","target":"mailer","status":"failed"}]`)
+			},
+		)
+		var lock sync.Mutex
+		deletes := 0
+		deploys := 0
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/",
+			func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+				switch r.Method {
+				case "DELETE":
+					deletes++
+				case "GET":
+					fmt.Fprint(w, `{"id":"new","type":"worker","target":"worker","status":"running"}`)
+				}
+			},
+		)
+		mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/deploy",
+			func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEquals(t, r.Method, "POST")
+				test.AssertEquals(t, data.target, r.URL.Query().Get("target"))
+				lock.Lock()
+				deploys++
+				lock.Unlock()
+				fmt.Fprintf(w, `{"id":"new%d","type":"worker","target":"%s","status":"scheduled"}`, deploys, data.target)
+			},
+		)
+
+		// test
+		err := CmdRestart(test.SvcLabel, data.target, data.all, services.New(settings), &test.FakePrompts{}, jobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		test.AssertEquals(t, strconv.Itoa(data.expectDeletes), strconv.Itoa(deletes))
+		test.AssertEquals(t, strconv.Itoa(data.expectDeploys), strconv.Itoa(deploys))
+		test.Teardown(server)
+	}
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+)
+
+func CmdResume(svcName, target string, iw IWorker, is services.IServices, ij jobs.IJobs) error {
+	service, err := is.RetrieveByLabel(svcName)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("Could not find a service with the label \"%s\". You can list services with the \"datica services list\" command.", svcName)
+	}
+	workers, err := iw.Retrieve(service.ID)
+	if err != nil {
+		return err
+	}
+	scale, ok := workers.Paused[target]
+	if !ok {
+		return fmt.Errorf("Worker target %s for service %s is not paused", target, svcName)
+	}
+	total := scale
+	for t, s := range workers.Workers {
+		if t != target {
+			total += s
+		}
+	}
+	if service.WorkerScale > 0 && total > service.WorkerScale {
+		return fmt.Errorf("Resuming the %s target at a scale of %d would use %d workers but %s is limited to %d. Scale down another target first.", target, scale, total, svcName, service.WorkerScale)
+	}
+	if workers.Workers == nil {
+		workers.Workers = map[string]int{}
+	}
+	workers.Workers[target] = scale
+	delete(workers.Paused, target)
+	err = iw.Update(service.ID, workers)
+	if err != nil {
+		return err
+	}
+	_, err = ij.DeployTarget(target, service.ID)
+	if err != nil {
+		return err
+	}
+	logrus.Printf("Successfully resumed worker target %s for service %s at a scale of %d", target, svcName, scale)
+	return nil
+}
@@ -21,7 +21,7 @@ func CmdRm(svcName, target string, iw IWorker, is services.IServices, ip prompts
 	if err != nil {
 		return err
 	}
-	jobs, err := ij.RetrieveByTarget(service.ID, target, 1, 1000)
+	jobs, err := ij.RetrieveByTarget(service.ID, target)
 	if err != nil {
 		return err
 	}
@@ -36,6 +36,7 @@ func CmdRm(svcName, target string, iw IWorker, is services.IServices, ip prompts
 		return err
 	}
 	delete(workers.Workers, target)
+	delete(workers.Paused, target)
 	err = iw.Update(service.ID, workers)
 	if err != nil {
 		return err
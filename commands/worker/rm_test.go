@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+func TestRmPaginatesJobs(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+
+	// more worker jobs than fit on a few pages, every third one with the target
+	const totalJobs = 2345
+	allJobs := []models.Job{}
+	expected := 0
+	for i := 0; i < totalJobs; i++ {
+		target := "other"
+		if i%3 == 0 {
+			target = "worker"
+			expected++
+		}
+		allJobs = append(allJobs, models.Job{ID: fmt.Sprintf("job%d", i), Type: "worker", Target: target, Status: "running"})
+	}
+
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s"}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				fmt.Fprint(w, `{"workers":{"worker":800,"other":1600}}`)
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			page, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+			pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+			start := (page - 1) * pageSize
+			end := start + pageSize
+			if start > len(allJobs) {
+				start = len(allJobs)
+			}
+			if end > len(allJobs) {
+				end = len(allJobs)
+			}
+			json.NewEncoder(w).Encode(allJobs[start:end])
+		},
+	)
+	var lock sync.Mutex
+	deleted := map[string]bool{}
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "DELETE")
+			lock.Lock()
+			deleted[strings.TrimPrefix(r.URL.Path, "/environments/"+test.EnvID+"/services/"+test.SvcID+"/jobs/")] = true
+			lock.Unlock()
+		},
+	)
+
+	err := CmdRm(test.SvcLabel, "worker", New(settings), services.New(settings), &test.FakePrompts{}, jobs.New(settings))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	test.AssertEquals(t, strconv.Itoa(expected), strconv.Itoa(len(deleted)))
+}
@@ -3,6 +3,7 @@ package worker
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -11,9 +12,10 @@ import (
 	"github.com/daticahealth/cli/lib/jobs"
 	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
+	"github.com/olekukonko/tablewriter"
 )
 
-func CmdScale(svcName, target, scaleString string, iw IWorker, is services.IServices, ip prompts.IPrompts, ij jobs.IJobs) error {
+func CmdScale(svcName, target, scaleString string, dryRun bool, iw IWorker, is services.IServices, ip prompts.IPrompts, ij jobs.IJobs) error {
 	service, err := is.RetrieveByLabel(svcName)
 	if err != nil {
 		return err
@@ -33,14 +35,29 @@ func CmdScale(svcName, target, scaleString string, iw IWorker, is services.IServ
 	if scale <= 0 {
 		return fmt.Errorf("Invalid scale specified: %d. You must set the scale to an integer greater than 0 or use the \"worker rm\" command to remove workers.", scale)
 	}
+	total := scale
+	for t, s := range workers.Workers {
+		if t != target {
+			total += s
+		}
+	}
+	if dryRun {
+		printScaleSummary(workers.Workers, target, scale, total, service.WorkerScale)
+		return nil
+	}
+	if service.WorkerScale > 0 && total > service.WorkerScale && scale > workers.Workers[target] {
+		return fmt.Errorf("Scaling the %s target to %d would use %d workers but %s is limited to %d. Scale down another target first.", target, scale, total, svcName, service.WorkerScale)
+	}
 	if existingScale, ok := workers.Workers[target]; !ok || scale > existingScale {
 		logrus.Printf("Deploying %d new workers with target %s for service %s", scale-existingScale, target, svcName)
 		workers.Workers[target] = scale
+		// scaling a paused target up replaces the scale it would resume at
+		delete(workers.Paused, target)
 		err = iw.Update(service.ID, workers)
 		if err != nil {
 			return err
 		}
-		err = ij.DeployTarget(target, service.ID)
+		_, err = ij.DeployTarget(target, service.ID)
 		if err != nil {
 			return err
 		}
@@ -50,7 +67,7 @@ func CmdScale(svcName, target, scaleString string, iw IWorker, is services.IServ
 		if err != nil {
 			return err
 		}
-		jobs, err := ij.RetrieveByTarget(service.ID, target, 1, 1000)
+		jobs, err := ij.RetrieveByTarget(service.ID, target)
 		if err != nil {
 			return err
 		}
@@ -92,7 +109,50 @@ func (w *SWorker) Update(svcID string, workers *models.Workers) error {
 	return w.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
 }
 
+// printScaleSummary prints the scale of every target before and after the
+// given target is scaled
+func printScaleSummary(current map[string]int, target string, scale, total, limit int) {
+	targets := []string{}
+	for t := range current {
+		targets = append(targets, t)
+	}
+	if _, ok := current[target]; !ok {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	data := [][]string{{"TARGET", "BEFORE", "AFTER"}}
+	for _, t := range targets {
+		after := current[t]
+		if t == target {
+			after = scale
+		}
+		data = append(data, []string{t, fmt.Sprintf("%d", current[t]), fmt.Sprintf("%d", after)})
+	}
+
+	table := tablewriter.NewWriter(logrus.StandardLogger().Out)
+	table.SetBorder(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.AppendBulk(data)
+	table.Render()
+
+	if limit > 0 && total > limit {
+		logrus.Printf("\nThis would use %d out of your available %d workers, which is over the limit. Nothing was changed.", total, limit)
+	} else {
+		logrus.Printf("\nThis would use %d out of your available %d workers. Nothing was changed.", total, limit)
+	}
+}
+
 func (w *SWorker) ParseScale(scaleString string) (func(scale, change int) int, int, error) {
+	if strings.HasSuffix(scaleString, "%") {
+		percentage, err := strconv.Atoi(strings.TrimSuffix(scaleString, "%"))
+		if err != nil || percentage <= 0 {
+			return nil, 0, fmt.Errorf("Invalid percentage specified: %s. Percentages must be a whole number greater than 0 followed by a percent sign such as 150%%", scaleString)
+		}
+		return percentScale, percentage, nil
+	}
 	scale, err := strconv.Atoi(scaleString)
 	if err != nil {
 		return nil, 0, err
@@ -111,3 +171,9 @@ func changeScale(scale, change int) int {
 func constantScale(scale, newScale int) int {
 	return newScale
 }
+
+// percentScale scales to the given percentage of the current scale, rounded
+// to the nearest worker
+func percentScale(scale, percentage int) int {
+	return (scale*percentage + 50) / 100
+}
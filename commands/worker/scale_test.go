@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/daticahealth/cli/commands/services"
+	"github.com/daticahealth/cli/lib/jobs"
+	"github.com/daticahealth/cli/models"
+	"github.com/daticahealth/cli/test"
+)
+
+var parseScaleTests = []struct {
+	scale     string
+	current   int
+	expected  int
+	expectErr bool
+}{
+	{"3", 2, 3, false},
+	{"+2", 2, 4, false},
+	{"-1", 2, 1, false},
+	{"150%", 2, 3, false},
+	{"50%", 3, 2, false},
+	{"0%", 2, 0, true},
+	{"abc%", 2, 0, true},
+	{"abc", 2, 0, true},
+}
+
+func TestParseScale(t *testing.T) {
+	iw := New(test.GetSettings(""))
+	for _, data := range parseScaleTests {
+		t.Logf("Data: %+v", data)
+
+		// test
+		scaleFunc, change, err := iw.ParseScale(data.scale)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if err == nil {
+			test.AssertEquals(t, fmt.Sprintf("%d", data.expected), fmt.Sprintf("%d", scaleFunc(data.current, change)))
+		}
+	}
+}
+
+var scaleTests = []struct {
+	target       string
+	scale        string
+	dryRun       bool
+	expectUpdate int
+	expectErr    bool
+}{
+	{"worker", "+2", true, 0, false},
+	{"worker", "500%", true, 0, false},
+	{"worker", "+2", false, 4, false},
+	{"worker", "300%", false, 0, true},
+	{"mailer", "+4", false, 0, true},
+}
+
+func TestScale(t *testing.T) {
+	mux, server, baseURL := test.Setup()
+	defer test.Teardown(server)
+	settings := test.GetSettings(baseURL.String())
+	mux.HandleFunc("/environments/"+test.EnvID+"/services",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "GET")
+			fmt.Fprintf(w, `[{"id":"%s","label":"%s","worker_scale":6}]`, test.SvcID, test.SvcLabel)
+		},
+	)
+	updated := 0
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/workers",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprint(w, `{"workers":{"worker":2,"mailer":1}}`)
+			case "POST":
+				var workers models.Workers
+				json.NewDecoder(r.Body).Decode(&workers)
+				updated = workers.Workers["worker"]
+			}
+		},
+	)
+	mux.HandleFunc("/environments/"+test.EnvID+"/services/"+test.SvcID+"/deploy",
+		func(w http.ResponseWriter, r *http.Request) {
+			test.AssertEquals(t, r.Method, "POST")
+			fmt.Fprint(w, `{}`)
+		},
+	)
+
+	for _, data := range scaleTests {
+		t.Logf("Data: %+v", data)
+		updated = 0
+
+		// test
+		err := CmdScale(test.SvcLabel, data.target, data.scale, data.dryRun, New(settings), services.New(settings), &test.FakePrompts{}, jobs.New(settings))
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		test.AssertEquals(t, fmt.Sprintf("%d", data.expectUpdate), fmt.Sprintf("%d", updated))
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import "strings"
+
+// BoolGlobalOptions are the global options that do not take a value
+var BoolGlobalOptions = map[string]bool{
+	"--utc":             true,
+	"--raw":             true,
+	"--non-interactive": true,
+	"-y":                true,
+	"--yes":             true,
+	"--strict":          true,
+	"--web":             true,
+	"-v":                true,
+	"--version":         true,
+}
+
+// SplitGlobalArgs splits command line arguments, given without the program
+// name, into the global options that come before the command and the command
+// with its own arguments
+func SplitGlobalArgs(args []string) ([]string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return args[:i], args[i:]
+		}
+		if strings.Contains(arg, "=") || BoolGlobalOptions[arg] {
+			continue
+		}
+		// short options can be combined, such as -yE, in which case only the
+		// last one can take a value
+		if !strings.HasPrefix(arg, "--") && BoolGlobalOptions["-"+arg[len(arg)-1:]] {
+			continue
+		}
+		i++
+	}
+	return args, []string{}
+}
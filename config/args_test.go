@@ -0,0 +1,30 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+var splitGlobalArgsTests = []struct {
+	args         []string
+	expectGlobal []string
+	expectRest   []string
+}{
+	{[]string{"logs", "-f"}, []string{}, []string{"logs", "-f"}},
+	{[]string{"-E", "prod", "logs"}, []string{"-E", "prod"}, []string{"logs"}},
+	{[]string{"-y", "--non-interactive", "--trace", "t.log", "saved", "run", "x"}, []string{"-y", "--non-interactive", "--trace", "t.log"}, []string{"saved", "run", "x"}},
+	{[]string{"--env=prod", "--strict", "status"}, []string{"--env=prod", "--strict"}, []string{"status"}},
+	{[]string{"-yE", "prod", "status"}, []string{"-yE", "prod"}, []string{"status"}},
+	{[]string{"-U", "user", "-P", "pass", "--", "status"}, []string{"-U", "user", "-P", "pass"}, []string{"status"}},
+	{[]string{"-E", "prod"}, []string{"-E", "prod"}, []string{}},
+}
+
+func TestSplitGlobalArgs(t *testing.T) {
+	for _, data := range splitGlobalArgsTests {
+		t.Logf("Data: %+v", data)
+		global, rest := SplitGlobalArgs(data.args)
+		if !reflect.DeepEqual(global, data.expectGlobal) || !reflect.DeepEqual(rest, data.expectRest) {
+			t.Errorf("Expected %v and %v but got %v and %v", data.expectGlobal, data.expectRest, global, rest)
+		}
+	}
+}
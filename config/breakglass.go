@@ -0,0 +1,29 @@
+package config
+
+import (
+	"time"
+
+	"github.com/daticahealth/cli/models"
+)
+
+// BreakGlassActive reports whether a break-glass session has been started for
+// the organization of the chosen environment and has not expired yet.
+func BreakGlassActive(settings *models.Settings) bool {
+	return BreakGlassActiveFor(settings, settings.OrgID)
+}
+
+// BreakGlassActiveFor reports whether a break-glass session has been started
+// for the given organization and has not expired yet. The production branch
+// check of the pre-push hook and the confirmations of environments transfer
+// and migrate are lifted for the environments of the organization while it
+// is active.
+func BreakGlassActiveFor(settings *models.Settings, orgID string) bool {
+	s := settings.BreakGlass
+	return s != nil && s.OrgID == orgID && !BreakGlassExpired(s)
+}
+
+// BreakGlassExpired reports whether the break-glass session has run out
+func BreakGlassExpired(session *models.BreakGlassSession) bool {
+	expires, err := time.Parse(time.RFC3339, session.ExpiresAt)
+	return err != nil || !time.Now().Before(expires)
+}
@@ -28,6 +28,14 @@ const (
 	JobPollTime = 5
 	// LogPollTime is the amount of time in seconds to wait between polls for new logs
 	LogPollTime = 3
+	// LogReconnectTime is the amount of time in seconds to wait before the first attempt to reconnect to a dropped log stream
+	LogReconnectTime = 1
+	// LogReconnectMaxTime is the maximum amount of time in seconds to wait between attempts to reconnect to a dropped log stream
+	LogReconnectMaxTime = 30
+	// TransferRetries is the number of attempts made to finish a download before giving up
+	TransferRetries = 5
+	// TransferRetryTime is the amount of time in seconds to wait before resuming an interrupted download
+	TransferRetryTime = 3
 
 	// AccountsHostEnvVar is the env variable used to override AccountsHost
 	AccountsHostEnvVar = "ACCOUNTS_HOST"
@@ -43,10 +51,47 @@ const (
 	DaticaUsernameEnvVar = "DATICA_USERNAME"
 	// DaticaPasswordEnvVar is the env variable used to override the passowrd
 	DaticaPasswordEnvVar = "DATICA_PASSWORD"
+	// DaticaMFACodeEnvVar is the env variable used to give the one-time password of the second factor when signing in
+	DaticaMFACodeEnvVar = "DATICA_MFA_CODE"
+	// DaticaTokenEnvVar is the env variable used to sign in as a service account with its token
+	DaticaTokenEnvVar = "DATICA_TOKEN"
 	// DaticaEnvironmentEnvVar is the env variable used to override the environment used in the current command
 	DaticaEnvironmentEnvVar = "DATICA_ENV"
 	// LogLevelEnvVar is the env variable used to override the logging level used
 	LogLevelEnvVar = "DATICA_LOG_LEVEL"
+	// TimezoneEnvVar is the env variable used to override the timezone timestamps are printed in
+	TimezoneEnvVar = "DATICA_TIMEZONE"
+	// RawOutputEnvVar is the env variable used to print exact sizes, durations, and timestamps
+	RawOutputEnvVar = "DATICA_RAW"
+	// ClientCertEnvVar is the env variable used to set the client certificate used for mutual TLS
+	ClientCertEnvVar = "DATICA_CLIENT_CERT"
+	// ClientKeyEnvVar is the env variable used to set the private key of the client certificate
+	ClientKeyEnvVar = "DATICA_CLIENT_KEY"
+	// CACertEnvVar is the env variable used to set additional trusted certificate authorities
+	CACertEnvVar = "DATICA_CA_CERT"
+	// TraceEnvVar is the env variable used to set the file request metadata is traced to
+	TraceEnvVar = "DATICA_TRACE"
+	// RunbookEnvVar is the env variable set for the steps of a runbook to tag
+	// their requests with the runbook step they were made for
+	RunbookEnvVar = "DATICA_RUNBOOK"
+	// NonInteractiveEnvVar is the env variable used to make every prompt fail
+	// instead of waiting for input
+	NonInteractiveEnvVar = "DATICA_NONINTERACTIVE"
+	// AssumeYesEnvVar is the env variable used to answer yes to every
+	// confirmation
+	AssumeYesEnvVar = "DATICA_YES"
+	// RetriesEnvVar is the env variable used to set the number of attempts
+	// made for every API request
+	RetriesEnvVar = "DATICA_RETRIES"
+	// StrictEnvVar is the env variable used to make use of deprecated
+	// commands, flags, and output formats fail instead of warning
+	StrictEnvVar = "DATICA_STRICT"
+	// ScheduleWaitEnvVar is the env variable set for a command run again in the
+	// background once the schedule window of its transfers opens
+	ScheduleWaitEnvVar = "DATICA_SCHEDULE_WAIT"
+	// ScheduleLogFile is the file in the home directory that the output of
+	// commands run in the background by the schedule window is appended to
+	ScheduleLogFile = ".datica_schedule.log"
 	// SkipVerifyEnvVar is the env variable used to accept invalid SSL certificates
 	SkipVerifyEnvVar = "SKIP_VERIFY"
 
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"io/ioutil"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/keyring"
+	"github.com/daticahealth/cli/models"
+)
+
+const (
+	sessionTokenKey  = "session_token"
+	refreshTokenKey  = "refresh_token"
+	privateKeyPrefix = "private_key:"
+	publicKeyPrefix  = "public_key:"
+)
+
+// storedToken is the session token last read from or written to the keyring
+// so that it is only written again when it changes
+var storedToken *string
+
+// loadSessionToken reads the session token from the keyring into the given
+// settings. A session token found in the settings file was saved by an older
+// version of the CLI and is moved into the keyring.
+func loadSessionToken(settings *models.Settings) {
+	if settings.SessionToken != "" {
+		if saveSessionToken(settings.SessionToken) {
+			logrus.Debugln("Moved the session token from the settings file into the keyring")
+			SaveSettings(settings)
+		}
+		return
+	}
+	token, err := keyring.New().Get(sessionTokenKey)
+	if err != nil && err != keyring.ErrNotFound {
+		logrus.Debugf("Unable to read the session token from the keyring: %s", err)
+		return
+	}
+	settings.SessionToken = token
+	storedToken = &token
+}
+
+// saveSessionToken stores the session token in the keyring, or removes it if
+// it is empty, and returns whether it was stored. The session token is kept
+// in the settings file when it could not be stored.
+func saveSessionToken(token string) bool {
+	if storedToken != nil && *storedToken == token {
+		return true
+	}
+	k := keyring.New()
+	var err error
+	if token == "" {
+		err = k.Delete(sessionTokenKey)
+	} else {
+		err = k.Set(sessionTokenKey, token)
+	}
+	if err != nil {
+		logrus.Warnf("Unable to store the session token in the %s keyring, it will be saved in the settings file instead: %s", k.Name(), err)
+		return false
+	}
+	storedToken = &token
+	return true
+}
+
+// storedRefreshToken is the refresh token last read from or written to the
+// keyring so that it is only written again when it changes
+var storedRefreshToken *string
+
+// loadRefreshToken reads the refresh token from the keyring into the given
+// settings. Unlike the session token, the refresh token is never saved in the
+// settings file.
+func loadRefreshToken(settings *models.Settings) {
+	token, err := keyring.New().Get(refreshTokenKey)
+	if err != nil && err != keyring.ErrNotFound {
+		logrus.Debugf("Unable to read the refresh token from the keyring: %s", err)
+		return
+	}
+	settings.RefreshToken = token
+	storedRefreshToken = &token
+}
+
+// saveRefreshToken stores the refresh token in the keyring, or removes it if
+// it is empty. A refresh token that could not be stored is dropped, so the
+// next expired session is signed in to again.
+func saveRefreshToken(token string) {
+	if storedRefreshToken != nil && *storedRefreshToken == token {
+		return
+	}
+	k := keyring.New()
+	var err error
+	if token == "" {
+		err = k.Delete(refreshTokenKey)
+	} else {
+		err = k.Set(refreshTokenKey, token)
+	}
+	if err != nil {
+		logrus.Debugf("Unable to store the refresh token in the %s keyring: %s", k.Name(), err)
+		return
+	}
+	storedRefreshToken = &token
+}
+
+// PersistedRefreshToken reads the refresh token saved by any process,
+// bypassing the token remembered by this process. Refresh tokens can only be
+// used once, so another process may have replaced the one this process read.
+func PersistedRefreshToken() (string, error) {
+	token, err := keyring.New().Get(refreshTokenKey)
+	if err == keyring.ErrNotFound {
+		return "", nil
+	}
+	return token, err
+}
+
+// persistedSessionToken reads the session token saved by any process,
+// bypassing the token remembered by this process
+func persistedSessionToken() (string, error) {
+	token, err := keyring.New().Get(sessionTokenKey)
+	if err == keyring.ErrNotFound {
+		return "", nil
+	}
+	return token, err
+}
+
+// StorePrivateKey copies the private key at the given path and its public key
+// into the keychain of the OS so that signing in keeps working once the key
+// files are removed. Nothing is copied when the OS has no keychain, since the
+// key files are then already the safest place for the keys.
+func StorePrivateKey(path string) error {
+	k := keyring.New()
+	if k.Name() == keyring.FileBackend {
+		logrus.Debugf("Leaving the private key in %s since there is no keychain to store it in", path)
+		return nil
+	}
+	privateKey, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	publicKey, err := ioutil.ReadFile(path + ".pub")
+	if err != nil {
+		return err
+	}
+	return storeKeys(k, path, privateKey, publicKey)
+}
+
+func storeKeys(k keyring.IKeyring, path string, privateKey, publicKey []byte) error {
+	if err := k.Set(privateKeyPrefix+path, string(privateKey)); err != nil {
+		return err
+	}
+	return k.Set(publicKeyPrefix+path, string(publicKey))
+}
+
+// PrivateKey returns the contents of the private key at the given path and
+// its public key. The key files are preferred over the copy stored with
+// StorePrivateKey so that a key rotated in place is never shadowed by a stale
+// copy, which is replaced with the new key instead. The stored copy is only
+// used when the key files can no longer be read.
+func PrivateKey(path string) ([]byte, []byte, error) {
+	k := keyring.New()
+	storedPrivateKey, err := k.Get(privateKeyPrefix + path)
+	var storedPublicKey string
+	if err == nil {
+		storedPublicKey, err = k.Get(publicKeyPrefix + path)
+	}
+	stored := err == nil
+	if err != nil && err != keyring.ErrNotFound {
+		logrus.Debugf("Unable to read the private key %s from the keyring: %s", path, err)
+	}
+	privateKey, err := ioutil.ReadFile(path)
+	var publicKey []byte
+	if err == nil {
+		publicKey, err = ioutil.ReadFile(path + ".pub")
+	}
+	if err != nil {
+		if stored {
+			logrus.Debugf("Using the private key %s stored in the keyring since the key files could not be read: %s", path, err)
+			return []byte(storedPrivateKey), []byte(storedPublicKey), nil
+		}
+		return nil, nil, err
+	}
+	if stored && (string(privateKey) != storedPrivateKey || string(publicKey) != storedPublicKey) {
+		logrus.Debugf("The private key %s changed since it was stored in the keyring, replacing the stored copy", path)
+		if err = storeKeys(k, path, privateKey, publicKey); err != nil {
+			logrus.Debugf("Unable to replace the private key %s in the keyring: %s", path, err)
+		}
+	}
+	return privateKey, publicKey, nil
+}
+
+// DeletePrivateKey removes the private key at the given path and its public
+// key from the keyring. The key files are left as they are.
+func DeletePrivateKey(path string) error {
+	k := keyring.New()
+	if err := k.Delete(privateKeyPrefix + path); err != nil {
+		return err
+	}
+	return k.Delete(publicKeyPrefix + path)
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/daticahealth/cli/lib/keyring"
+	"github.com/daticahealth/cli/models"
+)
+
+// setupCredentials points the home directory at an empty temp dir and stores
+// secrets in the credentials file in it
+func setupCredentials(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	home, backend := os.Getenv("HOME"), os.Getenv(keyring.BackendEnvVar)
+	os.Setenv("HOME", dir)
+	os.Setenv(keyring.BackendEnvVar, keyring.FileBackend)
+	storedToken, storedRefreshToken = nil, nil
+	return func() {
+		os.Setenv("HOME", home)
+		os.Setenv(keyring.BackendEnvVar, backend)
+		storedToken, storedRefreshToken = nil, nil
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	teardown := setupCredentials(t)
+	defer teardown()
+
+	settings := &models.Settings{}
+	loadRefreshToken(settings)
+	if settings.RefreshToken != "" {
+		t.Fatalf("Expected no refresh token but got %s", settings.RefreshToken)
+	}
+	saveRefreshToken("refresh1")
+	loadRefreshToken(settings)
+	if settings.RefreshToken != "refresh1" {
+		t.Errorf("Expected the refresh token refresh1 but got %s", settings.RefreshToken)
+	}
+
+	// another process rotates the refresh token after this one read it
+	if err := keyring.New().Set(refreshTokenKey, "refresh2"); err != nil {
+		t.Fatal(err)
+	}
+	persisted, err := PersistedRefreshToken()
+	if err != nil || persisted != "refresh2" {
+		t.Errorf("Expected the refresh token saved by the other process but got %s, %v", persisted, err)
+	}
+
+	// a rejected refresh token is removed
+	saveRefreshToken("")
+	persisted, err = PersistedRefreshToken()
+	if err != nil || persisted != "" {
+		t.Errorf("Expected the refresh token to be removed but got %s, %v", persisted, err)
+	}
+}
+
+func TestSessionTokenMigration(t *testing.T) {
+	teardown := setupCredentials(t)
+	defer teardown()
+
+	// a settings file written by an older version holds the session token
+	settings := &models.Settings{SessionToken: "session1"}
+	loadSessionToken(settings)
+
+	stored, err := keyring.New().Get(sessionTokenKey)
+	if err != nil || stored != "session1" {
+		t.Errorf("Expected the session token to be moved into the keyring but got %s, %v", stored, err)
+	}
+	saved, err := readSettingsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.SessionToken != "" {
+		t.Errorf("Expected the session token to be removed from the settings file but got %s", saved.SessionToken)
+	}
+
+	// the next run reads it from the keyring
+	storedToken = nil
+	settings = &models.Settings{}
+	loadSessionToken(settings)
+	if settings.SessionToken != "session1" {
+		t.Errorf("Expected the session token from the keyring but got %s", settings.SessionToken)
+	}
+}
+
+func TestPrivateKeyRotation(t *testing.T) {
+	teardown := setupCredentials(t)
+	defer teardown()
+
+	path := os.Getenv("HOME") + "/id_rsa"
+	k := keyring.New()
+	if err := storeKeys(k, path, []byte("old"), []byte("old.pub")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stored copy is used while there are no key files
+	privateKey, publicKey, err := PrivateKey(path)
+	if err != nil || string(privateKey) != "old" || string(publicKey) != "old.pub" {
+		t.Errorf("Expected the stored key but got %s, %s, %v", privateKey, publicKey, err)
+	}
+
+	// the key is rotated in place
+	if err = ioutil.WriteFile(path, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(path+".pub", []byte("new.pub"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	privateKey, publicKey, err = PrivateKey(path)
+	if err != nil || string(privateKey) != "new" || string(publicKey) != "new.pub" {
+		t.Errorf("Expected the rotated key but got %s, %s, %v", privateKey, publicKey, err)
+	}
+	stored, err := k.Get(privateKeyPrefix + path)
+	if err != nil || stored != "new" {
+		t.Errorf("Expected the stored copy to be replaced but got %s, %v", stored, err)
+	}
+}
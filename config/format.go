@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var rawOutput = false
+
+// jsonOpt and streamOpt are the --json and --stream options of the command
+// being run
+var jsonOpt, streamOpt *bool
+
+// JSONOpt registers the given --json option of a command and returns it. Only
+// the commands on the path being run are initialized, so the option
+// registered last belongs to the command being run.
+func JSONOpt(opt *bool) *bool {
+	jsonOpt = opt
+	return opt
+}
+
+// StreamOpt registers the given --stream option of a command and returns it,
+// as JSONOpt does
+func StreamOpt(opt *bool) *bool {
+	streamOpt = opt
+	return opt
+}
+
+// JSONOutput returns whether the command being run prints JSON, whether
+// --json was given on the command line or set with "datica defaults". This is
+// only known once the command line has been parsed.
+func JSONOutput() bool {
+	return jsonOpt != nil && *jsonOpt
+}
+
+// StreamOutput returns whether the command being run prints its output as it
+// arrives, as JSONOutput does for --json
+func StreamOutput() bool {
+	return streamOpt != nil && *streamOpt
+}
+
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+
+// SetRawOutput sets whether sizes, durations, and timestamps are printed as
+// exact values instead of in a human readable format
+func SetRawOutput(raw bool) {
+	rawOutput = raw
+}
+
+// RawOutput returns whether sizes, durations, and timestamps are printed as
+// exact values instead of in a human readable format
+func RawOutput() bool {
+	return rawOutput
+}
+
+// FormatBytes formats a size in bytes. Raw output prints the exact number of
+// bytes, otherwise the size is printed in the largest binary unit that keeps
+// the value at or above 1 such as "1.4 GiB".
+func FormatBytes(bytes float64) string {
+	if rawOutput {
+		return fmt.Sprintf("%.0f", bytes)
+	}
+	if bytes < 1024 {
+		return fmt.Sprintf("%.0f B", bytes)
+	}
+	unit := ""
+	for _, u := range byteUnits {
+		bytes /= 1024
+		unit = u
+		if bytes < 1024 {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", bytes, unit)
+}
+
+// FormatDuration formats a duration. Raw output prints the exact number of
+// seconds, otherwise the duration is printed to the nearest second such as
+// "2h13m".
+func FormatDuration(d time.Duration) string {
+	if rawOutput {
+		return fmt.Sprintf("%.0f", d.Seconds())
+	}
+	s := (d / time.Second * time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
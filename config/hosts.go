@@ -0,0 +1,44 @@
+package config
+
+import "github.com/daticahealth/cli/models"
+
+// AllPods is the key of the host override used for every pod without an
+// override of its own
+const AllPods = "*"
+
+// HostOverride returns the hosts configured for the given pod. Hosts not set
+// for the pod fall back to the hosts configured for all pods.
+func HostOverride(settings *models.Settings, pod string) models.HostOverride {
+	override := settings.Hosts[AllPods]
+	podOverride, ok := settings.Hosts[pod]
+	if !ok || pod == "" {
+		return override
+	}
+	if podOverride.AccountsHost != "" {
+		override.AccountsHost = podOverride.AccountsHost
+	}
+	if podOverride.AuthHost != "" {
+		override.AuthHost = podOverride.AuthHost
+	}
+	if podOverride.PaasHost != "" {
+		override.PaasHost = podOverride.PaasHost
+	}
+	if podOverride.ClientCert != "" {
+		override.ClientCert = podOverride.ClientCert
+		override.ClientKey = podOverride.ClientKey
+	}
+	if podOverride.CACert != "" {
+		override.CACert = podOverride.CACert
+	}
+	return override
+}
+
+// firstHost returns the first of the given hosts that is set
+func firstHost(hosts ...string) string {
+	for _, host := range hosts {
+		if host != "" {
+			return host
+		}
+	}
+	return ""
+}
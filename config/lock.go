@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	// SettingsLockFile is the location of the lock file used to coordinate
+	// processes that share the same settings file.
+	SettingsLockFile = ".datica.lock"
+	// SettingsLockTimeout is the amount of time in seconds to wait for another
+	// process to release the settings lock
+	SettingsLockTimeout = 30
+)
+
+// LockSettings acquires an exclusive lock on the settings file that is
+// shared by every CLI process running as the current user. The returned func
+// releases the lock and must always be called. This is used to make sure only
+// one process refreshes or saves the session at a time when many run in
+// parallel. The lock is held by the OS on the open lock file, so it is
+// released as soon as the process holding it exits, however it exits. It
+// should never be held while waiting on a prompt.
+func LockSettings() (func(), error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+	lockPath := filepath.Join(homeDir, SettingsLockFile)
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(SettingsLockTimeout * time.Second)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if locked {
+			return func() {
+				unlock(file)
+				file.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("Timed out waiting for another datica process to release %s", lockPath)
+		}
+		logrus.Debugf("Waiting for another datica process to release %s", lockPath)
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// PersistedSession reads the session token and user ID currently saved in the
+// keyring and settings file. This may differ from the in memory settings if
+// another process signed in after this process started.
+func PersistedSession() (string, string, error) {
+	settings, err := readSettingsFile()
+	if err != nil {
+		return "", "", err
+	}
+	if settings.SessionToken == "" {
+		settings.SessionToken, err = persistedSessionToken()
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return settings.SessionToken, settings.UsersID, nil
+}
+
+// readSettingsFile reads the settings file as it is currently saved
+func readSettingsFile() (*models.Settings, error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filepath.Join(homeDir, SettingsFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var settings models.Settings
+	err = json.NewDecoder(file).Decode(&settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockSettings(t *testing.T) {
+	teardown := setupCredentials(t)
+	defer teardown()
+
+	unlock, err := LockSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	locked := make(chan func())
+	go func() {
+		unlockOther, err := LockSettings()
+		if err != nil {
+			t.Error(err)
+		}
+		locked <- unlockOther
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("Expected the lock to be held until it is released")
+	case <-time.After(300 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case unlockOther := <-locked:
+		if unlockOther != nil {
+			unlockOther()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the lock to be taken once it was released")
+	}
+}
@@ -0,0 +1,22 @@
+// +build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// tryLock takes an exclusive flock on the given file without waiting and
+// returns whether it was taken
+func tryLock(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlock(file *os.File) {
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
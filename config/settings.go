@@ -17,6 +17,12 @@ import (
 const (
 	OldSettingsFile = ".catalyze"
 	SettingsFile    = ".datica"
+	// JournalDir is the location of the directory holding interrupted
+	// multi-step operations that can be resumed.
+	JournalDir = ".datica_journal"
+	// CacheDir is the location of the directory holding downloaded artifacts
+	// by digest so they are not downloaded again.
+	CacheDir = ".datica_cache"
 )
 
 // SettingsRetriever defines an interface for a class responsible for generating
@@ -62,6 +68,32 @@ func (s FileSettingsRetriever) GetSettings(envName, svcName, accountsHost, authH
 	if settings.Environments == nil {
 		settings.Environments = make(map[string]models.AssociatedEnv)
 	}
+	loadRefreshToken(&settings)
+	loadSessionToken(&settings)
+
+	// the workspace file of the current git repo pins the environment unless
+	// one is given, and the service used when none is given. Problems with it
+	// are kept for the commands that need an association so that the rest,
+	// such as associate, can still be run to fix them.
+	if wd, err := os.Getwd(); err == nil {
+		workspace, path, err := FindWorkspace(wd)
+		if err != nil {
+			settings.WorkspaceErr = fmt.Errorf("Error reading the workspace file %s: %s", path, err)
+		}
+		if workspace != nil {
+			logrus.Debugf("Using the workspace file %s", path)
+			if envName == "" && workspace.Environment != "" {
+				setGivenEnv(workspace.Environment, &settings)
+				if settings.EnvironmentID == "" || settings.ServiceID == "" {
+					settings.WorkspaceErr = fmt.Errorf("The workspace file %s pins the environment \"%s\" but no environment with that name has been associated. Run \"datica associated\" to see what environments have been associated or run \"datica associate\" to create a new association", path, workspace.Environment)
+				}
+			}
+			// the pinned service belongs to the pinned environment
+			if envName == "" || workspace.Environment == "" || envName == workspace.Environment {
+				settings.ServiceLabel = workspace.Service
+			}
+		}
+	}
 
 	// try and set the given env first, if it exists
 	if envName != "" {
@@ -76,9 +108,12 @@ func (s FileSettingsRetriever) GetSettings(envName, svcName, accountsHost, authH
 		setGivenEnv(settings.Default, &settings)
 	}
 
-	settings.AccountsHost = accountsHost
-	settings.AuthHost = authHost
-	settings.PaasHost = paasHost
+	// hosts given as flags or environment variables take precedence over the
+	// hosts configured for the pod of the chosen environment
+	override := HostOverride(&settings, settings.Pod)
+	settings.AccountsHost = firstHost(accountsHost, override.AccountsHost, AccountsHost)
+	settings.AuthHost = firstHost(authHost, override.AuthHost, AuthHost)
+	settings.PaasHost = firstHost(paasHost, override.PaasHost, PaasHost)
 	settings.Username = username
 	settings.Password = password
 
@@ -94,9 +129,9 @@ func (s FileSettingsRetriever) GetSettings(envName, svcName, accountsHost, authH
 	}
 	settings.PaasHostVersion = paasHostVersion
 
-	logrus.Debugf("Accounts Host: %s", accountsHost)
-	logrus.Debugf("Auth Host: %s", authHost)
-	logrus.Debugf("Paas Host: %s", paasHost)
+	logrus.Debugf("Accounts Host: %s", settings.AccountsHost)
+	logrus.Debugf("Auth Host: %s", settings.AuthHost)
+	logrus.Debugf("Paas Host: %s", settings.PaasHost)
 	logrus.Debugf("Auth Host Version: %s", authHostVersion)
 	logrus.Debugf("Paas Host Version: %s", paasHostVersion)
 	logrus.Debugf("Environment ID: %s", settings.EnvironmentID)
@@ -109,14 +144,53 @@ func (s FileSettingsRetriever) GetSettings(envName, svcName, accountsHost, authH
 	return &settings
 }
 
-// SaveSettings persists the settings to disk
+// FlagDefaults returns the default option values saved with the "datica
+// defaults" command, or nil if the settings file cannot be read. They are read
+// on their own because they are needed before the command line is parsed, and
+// so before the rest of the settings are loaded.
+func FlagDefaults() map[string]string {
+	settings, err := readSettingsFile()
+	if err != nil {
+		return nil
+	}
+	return settings.Defaults
+}
+
+// StrictSetting returns whether strict mode was turned on with the "datica
+// strict" command. Like FlagDefaults, it is read on its own because it is
+// needed before the rest of the settings are loaded.
+func StrictSetting() bool {
+	settings, err := readSettingsFile()
+	if err != nil {
+		return false
+	}
+	return settings.Strict
+}
+
+// SaveSettings persists the settings to disk. The session token is stored in
+// the keyring rather than the settings file whenever possible, and the
+// refresh token is only ever stored in the keyring.
 func SaveSettings(settings *models.Settings) {
 	HomeDir, err := homedir.Dir()
 	if err != nil {
 		logrus.Println(err.Error())
 		os.Exit(1)
 	}
-	b, _ := json.Marshal(&settings)
+	persisted := *settings
+	if settings.AccountToken != "" {
+		// service account tokens are never saved, so the session of the user
+		// who signed in before is left as it was
+		persisted.SessionToken, persisted.UsersID = "", ""
+		if saved, err := readSettingsFile(); err == nil {
+			persisted.SessionToken, persisted.UsersID = saved.SessionToken, saved.UsersID
+		}
+	} else {
+		if saveSessionToken(settings.SessionToken) {
+			persisted.SessionToken = ""
+		}
+		saveRefreshToken(settings.RefreshToken)
+	}
+	b, _ := json.Marshal(&persisted)
 	err = ioutil.WriteFile(filepath.Join(HomeDir, SettingsFile), b, 0644)
 	if err != nil {
 		logrus.Println(err.Error())
@@ -151,6 +225,14 @@ func setGivenEnv(envName string, settings *models.Settings) {
 	}
 }
 
+// EnvironmentSettings returns a copy of the settings that targets the
+// associated environment with the given alias instead of the current one
+func EnvironmentSettings(alias string, settings *models.Settings) *models.Settings {
+	s := *settings
+	setGivenEnv(alias, &s)
+	return &s
+}
+
 // defaultEnvPrompt asks the user when they dont have a default environment and
 // aren't in an associated directory if they would like to proceed with the
 // first environment found.
@@ -178,6 +260,9 @@ func defaultEnvPrompt(envName string) error {
 // given settings object before a command is run. This is intended to be called
 // before every command.
 func CheckRequiredAssociation(required, prompt bool, settings *models.Settings) error {
+	if required && settings.WorkspaceErr != nil {
+		return settings.WorkspaceErr
+	}
 	if required && (settings.EnvironmentID == "" || settings.ServiceID == "") {
 		err := ErrEnvRequired
 		if prompt {
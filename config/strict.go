@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+)
+
+var strictMode = false
+
+// SetStrict sets whether use of deprecated commands, flags, and output formats
+// fails instead of printing a warning
+func SetStrict(strict bool) {
+	strictMode = strict
+}
+
+// Strict returns whether use of deprecated commands, flags, and output formats
+// fails instead of printing a warning
+func Strict() bool {
+	return strictMode
+}
+
+// Deprecated reports use of a deprecated command, flag, or output format. The
+// message is printed as a warning, unless strict mode is on, in which case it
+// is returned as an error for the command to fail with.
+func Deprecated(msg string) error {
+	if strictMode {
+		return fmt.Errorf("%s This fails because strict mode is on. Run \"datica strict off\" or drop the --strict flag to only warn about deprecated usage.", msg)
+	}
+	Warn(WarningDeprecation, "%s", msg)
+	return nil
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// TimestampFormat is the layout used for every timestamp the CLI prints
+	TimestampFormat = "2006-01-02 15:04:05 MST"
+	// PreciseTimestampFormat is the layout used for timestamps that need
+	// sub-second precision, such as log lines
+	PreciseTimestampFormat = "2006-01-02 15:04:05.000 MST"
+)
+
+// timestampLayouts are the formats timestamps are returned in by the API.
+// Timestamps without a zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+var location = time.Local
+
+// ParseTimezone returns the location for the given timezone name. The name
+// can be "local", "UTC", or any IANA timezone name such as
+// "America/Chicago". An empty name is the same as "local".
+func ParseTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid timezone \"%s\". Please specify \"local\", \"UTC\", or a timezone name such as \"America/Chicago\".", name)
+	}
+	return loc, nil
+}
+
+// SetTimezone sets the timezone every printed timestamp is converted to
+func SetTimezone(name string) error {
+	loc, err := ParseTimezone(name)
+	if err != nil {
+		return err
+	}
+	location = loc
+	return nil
+}
+
+// Timezone returns the timezone every printed timestamp is converted to
+func Timezone() *time.Location {
+	return location
+}
+
+// inputLayouts are the formats accepted for timestamps given on the command
+// line. Layouts without a zone are in the configured timezone.
+var inputLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a timestamp given on the command line, such as
+// "2017-06-01", "2017-06-01 13:30", or "2017-06-01T13:30:00Z". Timestamps
+// without a zone are in the configured timezone.
+func ParseTimestamp(timestamp string) (time.Time, error) {
+	for _, layout := range inputLayouts {
+		if t, err := time.ParseInLocation(layout, timestamp, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid timestamp \"%s\". Please specify a date such as \"2017-06-01\", a date and time such as \"2017-06-01 13:30\", or an RFC3339 timestamp.", timestamp)
+}
+
+// FormatTimestamp converts the given time to the configured timezone and
+// formats it with TimestampFormat, or RFC3339 for raw output.
+func FormatTimestamp(t time.Time) string {
+	if rawOutput {
+		return t.In(location).Format(time.RFC3339)
+	}
+	return t.In(location).Format(TimestampFormat)
+}
+
+// FormatPreciseTimestamp is the same as FormatTimestamp but keeps sub-second
+// precision
+func FormatPreciseTimestamp(t time.Time) string {
+	if rawOutput {
+		return t.In(location).Format(time.RFC3339Nano)
+	}
+	return t.In(location).Format(PreciseTimestampFormat)
+}
+
+// FormatTimestampString parses a timestamp returned by the API and formats
+// it with FormatTimestamp. If the timestamp cannot be parsed it is returned
+// unchanged.
+func FormatTimestampString(timestamp string) string {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return FormatTimestamp(t)
+		}
+	}
+	return timestamp
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// The kinds of warnings a command can run into without failing
+const (
+	WarningDeprecation    = "deprecation"
+	WarningSkipped        = "skipped"
+	WarningPartialFailure = "partial-failure"
+	WarningOther          = "warning"
+)
+
+// warningKindField is the logrus field the kind of a warning is stored in
+const warningKindField = "warning_kind"
+
+// Warning is a non-fatal issue a command ran into
+type Warning struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// warningCollector is a logrus hook that keeps every warning logged while a
+// command runs so that they can be summarized once it is done
+type warningCollector struct {
+	lock     sync.Mutex
+	warnings []Warning
+	onFatal  func()
+}
+
+var collector = &warningCollector{}
+
+func (c *warningCollector) Levels() []logrus.Level {
+	return []logrus.Level{logrus.WarnLevel, logrus.FatalLevel}
+}
+
+func (c *warningCollector) Fire(entry *logrus.Entry) error {
+	if entry.Level == logrus.FatalLevel {
+		if c.onFatal != nil {
+			c.onFatal()
+		}
+		return nil
+	}
+	kind, ok := entry.Data[warningKindField].(string)
+	if !ok {
+		kind = WarningOther
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.warnings = append(c.warnings, Warning{Kind: kind, Message: entry.Message})
+	return nil
+}
+
+// CollectWarnings starts keeping every warning that is logged. The given
+// function is called before the command exits with a fatal error so that the
+// warnings seen up to then can still be summarized.
+func CollectWarnings(onFatal func()) {
+	collector.onFatal = onFatal
+	logrus.AddHook(collector)
+}
+
+// Warn logs a warning of the given kind
+func Warn(kind, format string, args ...interface{}) {
+	logrus.WithField(warningKindField, kind).Warnf(format, args...)
+}
+
+// Warnings returns the warnings logged so far, oldest first
+func Warnings() []Warning {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	return append([]Warning{}, collector.warnings...)
+}
+
+// AddWarnings returns the given JSON output of a command with the warnings
+// logged so far added to it under "warnings". Only a JSON object can hold
+// them, so any other output, such as an array, is returned unchanged and
+// false is returned.
+func AddWarnings(output []byte) ([]byte, bool) {
+	warnings := Warnings()
+	if len(warnings) == 0 {
+		return output, true
+	}
+	trimmed := bytes.TrimSpace(output)
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil || fields == nil {
+		return output, false
+	}
+	if _, ok := fields["warnings"]; ok {
+		return output, false
+	}
+	b, _ := json.MarshalIndent(warnings, "    ", "    ")
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSpace(trimmed[:len(trimmed)-1]))
+	if len(fields) > 0 {
+		buf.WriteString(",")
+	}
+	fmt.Fprintf(&buf, "\n    \"warnings\": %s\n}\n", b)
+	return buf.Bytes(), true
+}
+
+// PrintWarnings writes a summary of the warnings logged so far, or nothing if
+// there were none. With jsonOutput, the warnings are written as a JSON object
+// instead, for output that AddWarnings could not add them to.
+func PrintWarnings(w io.Writer, jsonOutput bool) {
+	warnings := Warnings()
+	if len(warnings) == 0 {
+		return
+	}
+	if jsonOutput {
+		b, _ := json.MarshalIndent(struct {
+			Warnings []Warning `json:"warnings"`
+		}{warnings}, "", "    ")
+		fmt.Fprintln(w, string(b))
+		return
+	}
+	plural := "s"
+	if len(warnings) == 1 {
+		plural = ""
+	}
+	fmt.Fprintf(w, "\n%d warning%s:\n", len(warnings), plural)
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "  [%s] %s\n", warning.Kind, warning.Message)
+	}
+}
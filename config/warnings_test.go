@@ -0,0 +1,114 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// collectWarnings starts collecting the given warnings and returns a function
+// that forgets them again
+func collectWarnings(warnings ...Warning) func() {
+	out := logrus.StandardLogger().Out
+	logrus.SetOutput(&bytes.Buffer{})
+	CollectWarnings(nil)
+	for _, w := range warnings {
+		Warn(w.Kind, "%s", w.Message)
+	}
+	return func() {
+		logrus.SetOutput(out)
+		logrus.StandardLogger().Hooks = logrus.LevelHooks{}
+		collector.warnings = nil
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	teardown := collectWarnings(Warning{WarningSkipped, "Skipped app01"})
+	defer teardown()
+	logrus.Warn("Something else")
+
+	expected := []Warning{{WarningSkipped, "Skipped app01"}, {WarningOther, "Something else"}}
+	actual := Warnings()
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected %+v but got %+v", expected[i], actual[i])
+		}
+	}
+}
+
+func TestPrintWarnings(t *testing.T) {
+	var out bytes.Buffer
+	PrintWarnings(&out, false)
+	if out.Len() != 0 {
+		t.Errorf("Expected nothing to be printed without warnings but got %s", out.String())
+	}
+
+	teardown := collectWarnings(Warning{WarningDeprecation, "Old flag"}, Warning{WarningSkipped, "Skipped app01"})
+	defer teardown()
+	PrintWarnings(&out, false)
+	expected := "\n2 warnings:\n  [deprecation] Old flag\n  [skipped] Skipped app01\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, out.String())
+	}
+
+	out.Reset()
+	PrintWarnings(&out, true)
+	var summary struct {
+		Warnings []Warning `json:"warnings"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil || len(summary.Warnings) != 2 {
+		t.Errorf("Expected a JSON summary of 2 warnings but got %s", out.String())
+	}
+}
+
+var addWarningsTests = []struct {
+	output      string
+	expectAdded bool
+	expectKeys  int
+}{
+	{`{"name": "app01"}`, true, 2},
+	{"{\n    \"name\": \"app01\",\n    \"size\": 2\n}\n", true, 3},
+	{"{}\n", true, 1},
+	{`[{"name": "app01"}]`, false, 0},
+	{`{"name": "app01"}` + "\n" + `{"name": "app02"}`, false, 0},
+	{`{"warnings": []}`, false, 0},
+	{"not json", false, 0},
+	{"", false, 0},
+}
+
+func TestAddWarnings(t *testing.T) {
+	teardown := collectWarnings(Warning{WarningPartialFailure, "Could not read app02"})
+	defer teardown()
+	for _, data := range addWarningsTests {
+		t.Logf("Data: %+v", data)
+		actual, added := AddWarnings([]byte(data.output))
+		if added != data.expectAdded {
+			t.Errorf("Expected the warnings to be added: %t, but got %t", data.expectAdded, added)
+			continue
+		}
+		if !added {
+			if string(actual) != data.output {
+				t.Errorf("Expected the output to be unchanged but got %s", actual)
+			}
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(actual, &fields); err != nil {
+			t.Errorf("Expected valid JSON but got %s: %s", actual, err)
+			continue
+		}
+		if len(fields) != data.expectKeys {
+			t.Errorf("Expected %d keys but got %s", data.expectKeys, actual)
+		}
+		var warnings []Warning
+		json.Unmarshal(fields["warnings"], &warnings)
+		if len(warnings) != 1 || warnings[0].Kind != WarningPartialFailure {
+			t.Errorf("Expected the warning to be added but got %s", actual)
+		}
+	}
+}
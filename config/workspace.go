@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/models"
+	"gopkg.in/yaml.v2"
+)
+
+// WorkspaceFile is the name of the file in the root of a git repo that pins
+// the environment and service used by commands run inside the repo
+const WorkspaceFile = ".datica.yml"
+
+// FindWorkspace looks for the workspace file in the root of the git repo
+// containing the given directory. The workspace and the path of the file are
+// returned, or nil if the directory is not in a git repo or the repo does not
+// have a workspace file.
+func FindWorkspace(dir string) (*models.Workspace, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, "", nil
+		}
+		dir = parent
+	}
+	path := filepath.Join(dir, WorkspaceFile)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, path, err
+	}
+	var workspace models.Workspace
+	if err = yaml.Unmarshal(b, &workspace); err != nil {
+		return nil, path, err
+	}
+	return &workspace, path, nil
+}
+
+// ServiceName returns the given service name, or the label of the service
+// pinned by the workspace file if none was given
+func ServiceName(given string, settings *models.Settings) string {
+	if given == "" && settings.ServiceLabel != "" {
+		logrus.Debugf("Using the service %s from the workspace file", settings.ServiceLabel)
+		return settings.ServiceLabel
+	}
+	return given
+}
+
+// RequiredServiceName returns the given service name, or the label of the
+// service pinned by the workspace file if none was given. It fails if neither
+// is set.
+func RequiredServiceName(given string, settings *models.Settings) (string, error) {
+	name := ServiceName(given, settings)
+	if name == "" {
+		return "", fmt.Errorf("No SERVICE_NAME was given and no service is pinned by a workspace file. Give the name of a service or pin one with \"service\" in %s.", WorkspaceFile)
+	}
+	return name, nil
+}
+
+// SaveWorkspace writes the workspace to the workspace file at the given path
+func SaveWorkspace(workspace *models.Workspace, path string) error {
+	b, err := yaml.Marshal(workspace)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
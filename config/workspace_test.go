@@ -0,0 +1,95 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daticahealth/cli/models"
+)
+
+var findWorkspaceTests = []struct {
+	gitRepo     bool
+	contents    string
+	expectEnv   string
+	expectFound bool
+	expectErr   bool
+}{
+	{true, "environment: production\nservice: app01\n", "production", true, false},
+	{true, "", "", false, false},
+	{false, "environment: production\n", "", false, false},
+	{true, "environment: [production\n", "", false, true},
+}
+
+func TestFindWorkspace(t *testing.T) {
+	for _, data := range findWorkspaceTests {
+		t.Logf("Data: %+v", data)
+		dir, err := ioutil.TempDir("", "workspace")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.gitRepo {
+			os.Mkdir(filepath.Join(dir, ".git"), 0755)
+		}
+		if data.contents != "" {
+			ioutil.WriteFile(filepath.Join(dir, WorkspaceFile), []byte(data.contents), 0644)
+		}
+		nested := filepath.Join(dir, "src", "app")
+		os.MkdirAll(nested, 0755)
+
+		// test
+		workspace, _, err := FindWorkspace(nested)
+		os.RemoveAll(dir)
+
+		// assert
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if (workspace != nil) != data.expectFound {
+			t.Errorf("Expected a workspace to be found: %t, but got %+v", data.expectFound, workspace)
+			continue
+		}
+		if workspace != nil && workspace.Environment != data.expectEnv {
+			t.Errorf("Expected the environment %s but got %s", data.expectEnv, workspace.Environment)
+		}
+	}
+}
+
+var requiredServiceNameTests = []struct {
+	given     string
+	pinned    string
+	expected  string
+	expectErr bool
+}{
+	{"app01", "", "app01", false},
+	{"app01", "app02", "app01", false},
+	{"", "app02", "app02", false},
+	{"", "", "", true},
+}
+
+func TestRequiredServiceName(t *testing.T) {
+	for _, data := range requiredServiceNameTests {
+		t.Logf("Data: %+v", data)
+		actual, err := RequiredServiceName(data.given, &models.Settings{ServiceLabel: data.pinned})
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if actual != data.expected {
+			t.Errorf("Expected %s but got %s", data.expected, actual)
+		}
+	}
+}
+
+func TestCheckRequiredAssociationWorkspace(t *testing.T) {
+	settings := &models.Settings{EnvironmentID: "env", ServiceID: "svc"}
+	settings.WorkspaceErr = os.ErrNotExist
+	if err := CheckRequiredAssociation(true, false, settings); err != os.ErrNotExist {
+		t.Errorf("Expected the workspace error but got %v", err)
+	}
+	if err := CheckRequiredAssociation(false, false, settings); err != nil {
+		t.Errorf("Expected commands without an association to run but got %s", err)
+	}
+}
@@ -1,62 +1,105 @@
 package datica
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"runtime"
 	"strconv"
 	"time"
 
+	"github.com/daticahealth/cli/commands/access"
+	"github.com/daticahealth/cli/commands/admin"
+	"github.com/daticahealth/cli/commands/alerts"
 	"github.com/daticahealth/cli/commands/associate"
 	"github.com/daticahealth/cli/commands/associated"
+	"github.com/daticahealth/cli/commands/audit"
+	"github.com/daticahealth/cli/commands/breakglass"
+	"github.com/daticahealth/cli/commands/builds"
+	"github.com/daticahealth/cli/commands/cache"
+	"github.com/daticahealth/cli/commands/capabilities"
 	"github.com/daticahealth/cli/commands/certs"
 	"github.com/daticahealth/cli/commands/clear"
 	"github.com/daticahealth/cli/commands/console"
+	"github.com/daticahealth/cli/commands/cron"
 	"github.com/daticahealth/cli/commands/dashboard"
 	"github.com/daticahealth/cli/commands/db"
 	"github.com/daticahealth/cli/commands/default"
+	"github.com/daticahealth/cli/commands/defaults"
+	"github.com/daticahealth/cli/commands/deploy"
 	"github.com/daticahealth/cli/commands/deploykeys"
 	"github.com/daticahealth/cli/commands/disassociate"
+	"github.com/daticahealth/cli/commands/doctor"
 	"github.com/daticahealth/cli/commands/domain"
+	"github.com/daticahealth/cli/commands/dr"
 	"github.com/daticahealth/cli/commands/environments"
+	"github.com/daticahealth/cli/commands/es"
 	"github.com/daticahealth/cli/commands/files"
+	"github.com/daticahealth/cli/commands/flags"
 	"github.com/daticahealth/cli/commands/git"
+	"github.com/daticahealth/cli/commands/githooks"
+	"github.com/daticahealth/cli/commands/hosts"
+	"github.com/daticahealth/cli/commands/inbox"
 	"github.com/daticahealth/cli/commands/invites"
+	"github.com/daticahealth/cli/commands/jobs"
 	"github.com/daticahealth/cli/commands/keys"
 	"github.com/daticahealth/cli/commands/logout"
 	"github.com/daticahealth/cli/commands/logs"
 	"github.com/daticahealth/cli/commands/maintenance"
 	"github.com/daticahealth/cli/commands/metrics"
+	"github.com/daticahealth/cli/commands/mfa"
 	"github.com/daticahealth/cli/commands/rake"
 	"github.com/daticahealth/cli/commands/redeploy"
 	"github.com/daticahealth/cli/commands/releases"
+	"github.com/daticahealth/cli/commands/reports"
+	"github.com/daticahealth/cli/commands/resume"
+	"github.com/daticahealth/cli/commands/roles"
 	"github.com/daticahealth/cli/commands/rollback"
+	"github.com/daticahealth/cli/commands/run"
+	"github.com/daticahealth/cli/commands/runbook"
+	"github.com/daticahealth/cli/commands/runtime"
+	"github.com/daticahealth/cli/commands/saved"
+	"github.com/daticahealth/cli/commands/schema"
+	"github.com/daticahealth/cli/commands/serviceaccounts"
 	"github.com/daticahealth/cli/commands/services"
 	"github.com/daticahealth/cli/commands/sites"
 	"github.com/daticahealth/cli/commands/ssl"
 	"github.com/daticahealth/cli/commands/status"
+	"github.com/daticahealth/cli/commands/strict"
 	"github.com/daticahealth/cli/commands/supportids"
+	"github.com/daticahealth/cli/commands/timezone"
+	"github.com/daticahealth/cli/commands/trash"
 	"github.com/daticahealth/cli/commands/update"
 	"github.com/daticahealth/cli/commands/users"
 	"github.com/daticahealth/cli/commands/vars"
 	"github.com/daticahealth/cli/commands/version"
+	"github.com/daticahealth/cli/commands/volumes"
 	"github.com/daticahealth/cli/commands/whoami"
 	"github.com/daticahealth/cli/commands/worker"
 
 	"github.com/daticahealth/cli/config"
 	"github.com/daticahealth/cli/models"
 
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/lib/httpclient"
 	"github.com/daticahealth/cli/lib/pods"
+	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/lib/updater"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/jault3/mow.cli"
 )
 
-type simpleLogger struct{}
+type simpleLogger struct {
+	// deferWarnings leaves warnings out of the log so that they are only
+	// printed in the summary once the command is done
+	deferWarnings bool
+}
 
 func (s *simpleLogger) Format(entry *logrus.Entry) ([]byte, error) {
+	if s.deferWarnings && entry.Level == logrus.WarnLevel {
+		return []byte{}, nil
+	}
 	levelString := fmt.Sprintf("[%s] ", entry.Level)
 	levelPrefix := ""
 	levelSuffix := ""
@@ -79,6 +122,14 @@ func (s *simpleLogger) Format(entry *logrus.Entry) ([]byte, error) {
 	return []byte(l), nil
 }
 
+// deprecatedEnvVar reports use of a deprecated environment variable, exiting
+// in strict mode
+func deprecatedEnvVar(deprecated, replacement string) {
+	if err := config.Deprecated(fmt.Sprintf("You are using a deprecated environment variable %s. Please use %s instead. Support for %s will be removed soon.", deprecated, replacement, deprecated)); err != nil {
+		logrus.Fatal(err.Error())
+	}
+}
+
 // Run runs the Datica CLI
 func Run() {
 	InitLogrus()
@@ -89,27 +140,68 @@ func Run() {
 		}
 	}
 
+	flagDefaults := config.FlagDefaults()
+	cli.OptionDefaults = func(path []string) map[string]string {
+		return defaults.Resolve(flagDefaults, path[1:])
+	}
+
 	var app = cli.App("datica", fmt.Sprintf("Datica CLI. Version %s", config.VERSION))
 	settings := &models.Settings{}
 	InitGlobalOpts(app, settings)
 	InitCLI(app, settings)
 
+	for _, arg := range os.Args[1:] {
+		if arg == "--web" {
+			if err := help.Open(app.Cmd, os.Args[1:]); err != nil {
+				logrus.Fatal(err.Error())
+			}
+			return
+		}
+	}
+	// JSON output is only known once the command line has been parsed, since
+	// --json may also be set with "datica defaults"
+	jsonOutput := false
+	var output *bytes.Buffer
+	before := app.Before
+	app.Before = func() {
+		jsonOutput = config.JSONOutput()
+		if jsonOutput {
+			// warnings printed among the JSON output would keep it from being
+			// parsed, so they are only printed with the summary
+			logrus.SetFormatter(&simpleLogger{deferWarnings: true})
+			if !config.StreamOutput() {
+				// the output is held back so the warnings can be added to it
+				output = &bytes.Buffer{}
+				logrus.SetOutput(output)
+			}
+		}
+		before()
+	}
+	config.CollectWarnings(func() {
+		printWarnings(jsonOutput, output)
+	})
 	app.Run(os.Args)
+	printWarnings(jsonOutput, output)
 }
 
-func InitGlobalOpts(app *cli.Cli, settings *models.Settings) {
-	accountsHost := os.Getenv(config.AccountsHostEnvVar)
-	if accountsHost == "" {
-		accountsHost = config.AccountsHost
-	}
-	authHost := os.Getenv(config.AuthHostEnvVar)
-	if authHost == "" {
-		authHost = config.AuthHost
-	}
-	paasHost := os.Getenv(config.PaasHostEnvVar)
-	if paasHost == "" {
-		paasHost = config.PaasHost
+// printWarnings prints the summary of the warnings logged while the command
+// ran. The summary goes to stderr so that the output of the command can still
+// be parsed from stdout. JSON output held back in the given buffer is printed
+// with the warnings added to it when it is a JSON object.
+func printWarnings(jsonOutput bool, output *bytes.Buffer) {
+	if output != nil {
+		logrus.SetOutput(os.Stdout)
+		b, added := config.AddWarnings(output.Bytes())
+		output.Reset()
+		os.Stdout.Write(b)
+		if added {
+			return
+		}
 	}
+	config.PrintWarnings(os.Stderr, jsonOutput)
+}
+
+func InitGlobalOpts(app *cli.Cli, settings *models.Settings) {
 	username := app.String(cli.StringOpt{
 		Name:      "U username",
 		Desc:      "Datica Username",
@@ -122,12 +214,93 @@ func InitGlobalOpts(app *cli.Cli, settings *models.Settings) {
 		EnvVar:    config.DaticaPasswordEnvVar,
 		HideValue: true,
 	})
+	mfaCode := app.String(cli.StringOpt{
+		Name:      "mfa-code",
+		Desc:      "The one-time password of your second factor, used if signing in requires one",
+		EnvVar:    config.DaticaMFACodeEnvVar,
+		HideValue: true,
+	})
 	givenEnvName := app.String(cli.StringOpt{
 		Name:      "E env",
 		Desc:      "The local alias of the environment in which this command will be run",
 		EnvVar:    config.DaticaEnvironmentEnvVar,
 		HideValue: true,
 	})
+	givenTimezone := app.String(cli.StringOpt{
+		Name:   "timezone",
+		Desc:   "The timezone to print timestamps in, such as \"UTC\" or \"America/Chicago\". Defaults to the timezone set with the \"datica timezone\" command or your local timezone",
+		EnvVar: config.TimezoneEnvVar,
+	})
+	utc := app.BoolOpt("utc", false, "Print timestamps in UTC. This is the same as --timezone UTC")
+	raw := app.Bool(cli.BoolOpt{
+		Name:   "raw",
+		Desc:   "Print exact sizes in bytes, durations in seconds, and RFC3339 timestamps instead of human readable values",
+		EnvVar: config.RawOutputEnvVar,
+	})
+	accountsHost := app.String(cli.StringOpt{
+		Name:      "accounts-host",
+		Desc:      "The accounts host of a self-hosted platform install. Overrides the hosts set with the \"datica hosts\" command",
+		EnvVar:    config.AccountsHostEnvVar,
+		HideValue: true,
+	})
+	authHost := app.String(cli.StringOpt{
+		Name:      "auth-host",
+		Desc:      "The auth host of a self-hosted platform install. Overrides the hosts set with the \"datica hosts\" command",
+		EnvVar:    config.AuthHostEnvVar,
+		HideValue: true,
+	})
+	paasHost := app.String(cli.StringOpt{
+		Name:      "paas-host",
+		Desc:      "The PaaS host of a self-hosted platform install. Overrides the hosts set with the \"datica hosts\" command",
+		EnvVar:    config.PaasHostEnvVar,
+		HideValue: true,
+	})
+	clientCert := app.String(cli.StringOpt{
+		Name:      "client-cert",
+		Desc:      "The path to a client certificate to present to hosts that require mutual TLS. Overrides the certificate set with the \"datica hosts\" command",
+		EnvVar:    config.ClientCertEnvVar,
+		HideValue: true,
+	})
+	clientKey := app.String(cli.StringOpt{
+		Name:      "client-key",
+		Desc:      "The path to the private key of the client certificate",
+		EnvVar:    config.ClientKeyEnvVar,
+		HideValue: true,
+	})
+	caCert := app.String(cli.StringOpt{
+		Name:      "ca-cert",
+		Desc:      "The path to PEM encoded certificate authorities to trust in addition to the system's, such as the certificate of a proxy that inspects TLS traffic. Overrides the certificate set with the \"datica hosts\" command",
+		EnvVar:    config.CACertEnvVar,
+		HideValue: true,
+	})
+	nonInteractive := app.Bool(cli.BoolOpt{
+		Name:   "non-interactive",
+		Desc:   "Fail instead of prompting for input, such as confirmations or credentials, so that scripts never wait for input",
+		EnvVar: config.NonInteractiveEnvVar,
+	})
+	assumeYes := app.Bool(cli.BoolOpt{
+		Name:   "y yes",
+		Desc:   "Answer yes to every confirmation, including the PHI warning, so that commands can be automated",
+		EnvVar: config.AssumeYesEnvVar,
+	})
+	strictMode := app.Bool(cli.BoolOpt{
+		Name:   "strict",
+		Desc:   "Fail instead of warning when a deprecated command, flag, output format, or environment variable is used, so that deprecated usage can be removed from scripts before support for it ends. Defaults to the setting of the \"datica strict\" command",
+		EnvVar: config.StrictEnvVar,
+	})
+	retries := app.Int(cli.IntOpt{
+		Name:   "retries",
+		Value:  httpclient.DefaultRetries,
+		Desc:   "The number of attempts made for each API request. Requests that fail with a network error, are rate limited, or hit a server error are retried with an exponential backoff",
+		EnvVar: config.RetriesEnvVar,
+	})
+	traceFile := app.String(cli.StringOpt{
+		Name:      "trace",
+		Desc:      "The path of a file to append the method, URL, status, duration, request ID, and headers of every API request to, with credentials redacted. Attach this file to support tickets",
+		EnvVar:    config.TraceEnvVar,
+		HideValue: true,
+	})
+	app.BoolOpt("web", false, "Open the documentation for the given command in your default browser instead of running it")
 	if loggingLevel := os.Getenv(config.LogLevelEnvVar); loggingLevel != "" {
 		if lvl, err := logrus.ParseLevel(loggingLevel); err == nil {
 			logrus.SetLevel(lvl)
@@ -136,35 +309,86 @@ func InitGlobalOpts(app *cli.Cli, settings *models.Settings) {
 		if lvl, err := logrus.ParseLevel(loggingLevel); err == nil {
 			logrus.SetLevel(lvl)
 		}
-		logrus.Warnf("You are using a deprecated environment variable %s. Please use %s instead. Support for %s will be removed soon.", config.LogLevelEnvVarDeprecated, config.LogLevelEnvVar, config.LogLevelEnvVarDeprecated)
 	}
 
 	app.Before = func() {
+		config.SetStrict(*strictMode || config.StrictSetting())
+		if os.Getenv(config.LogLevelEnvVar) == "" && os.Getenv(config.LogLevelEnvVarDeprecated) != "" {
+			deprecatedEnvVar(config.LogLevelEnvVarDeprecated, config.LogLevelEnvVar)
+		}
 		if *username == "" {
 			*username = os.Getenv(config.DaticaUsernameEnvVarDeprecated)
 			if *username != "" {
-				logrus.Warnf("You are using a deprecated environment variable %s. Please use %s instead. Support for %s will be removed soon.", config.DaticaUsernameEnvVarDeprecated, config.DaticaUsernameEnvVar, config.DaticaUsernameEnvVarDeprecated)
+				deprecatedEnvVar(config.DaticaUsernameEnvVarDeprecated, config.DaticaUsernameEnvVar)
 			}
 		}
 		if *password == "" {
 			*password = os.Getenv(config.DaticaPasswordEnvVarDeprecated)
 			if *password != "" {
-				logrus.Warnf("You are using a deprecated environment variable %s. Please use %s instead. Support for %s will be removed soon.", config.DaticaPasswordEnvVarDeprecated, config.DaticaPasswordEnvVar, config.DaticaPasswordEnvVarDeprecated)
+				deprecatedEnvVar(config.DaticaPasswordEnvVarDeprecated, config.DaticaPasswordEnvVar)
 			}
 		}
 		if *givenEnvName == "" {
 			*givenEnvName = os.Getenv(config.DaticaEnvironmentEnvVarDeprecated)
 			if *givenEnvName != "" {
-				logrus.Warnf("You are using a deprecated environment variable %s. Please use %s instead. Support for %s will be removed soon.", config.DaticaEnvironmentEnvVarDeprecated, config.DaticaEnvironmentEnvVar, config.DaticaEnvironmentEnvVarDeprecated)
+				deprecatedEnvVar(config.DaticaEnvironmentEnvVarDeprecated, config.DaticaEnvironmentEnvVar)
 			}
 		}
 		if config.Beta {
 			logrus.Println("This is a BETA release. Please contact Datica Support at https://datica.com/support with any issues.")
 		}
 		r := config.FileSettingsRetriever{}
-		*settings = *r.GetSettings(*givenEnvName, "", accountsHost, authHost, "", paasHost, "", *username, *password)
+		*settings = *r.GetSettings(*givenEnvName, "", *accountsHost, *authHost, "", *paasHost, "", *username, *password)
+		settings.MFACode = *mfaCode
+		settings.AccountToken = os.Getenv(config.DaticaTokenEnvVar)
+		tz := settings.Timezone
+		if *givenTimezone != "" {
+			tz = *givenTimezone
+		}
+		if *utc {
+			tz = "UTC"
+		}
+		if err := config.SetTimezone(tz); err != nil {
+			logrus.Fatal(err.Error())
+		}
+		config.SetRawOutput(*raw)
+		prompts.SetNonInteractive(*nonInteractive)
+		prompts.SetAssumeYes(*assumeYes)
+		httpclient.SetRetries(*retries)
+		if config.BreakGlassActive(settings) {
+			httpclient.SetBreakGlassSession(settings.BreakGlass.ID)
+			fmt.Fprintf(os.Stderr, "Break-glass session %s is active until %s. Run \"datica breakglass end\" when the emergency is over.\n", settings.BreakGlass.ID, config.FormatTimestampString(settings.BreakGlass.ExpiresAt))
+		}
+		httpclient.SetRunbookStep(os.Getenv(config.RunbookEnvVar))
+		if err := httpclient.SetTraceFile(*traceFile); err != nil {
+			logrus.Fatalf("Could not open the trace file %s: %s", *traceFile, err)
+		}
 		skip, _ := strconv.ParseBool(os.Getenv(config.SkipVerifyEnvVar))
-		settings.HTTPManager = httpclient.NewTLSHTTPManager(skip)
+		override := config.HostOverride(settings, settings.Pod)
+		caFile := *caCert
+		if caFile == "" {
+			caFile = override.CACert
+		}
+		if err := httpclient.TrustCACert(caFile); err != nil {
+			logrus.Fatal(err.Error())
+		}
+		certFile, keyFile := *clientCert, *clientKey
+		if certFile == "" && keyFile == "" {
+			certFile, keyFile = override.ClientCert, override.ClientKey
+		}
+		if certFile != "" || keyFile != "" {
+			if certFile == "" || keyFile == "" {
+				logrus.Fatal("You must specify both --client-cert and --client-key to use a client certificate")
+			}
+			manager, err := httpclient.NewClientCertHTTPManager(skip, certFile, keyFile)
+			if err != nil {
+				logrus.Fatal(err.Error())
+			}
+			settings.HTTPManager = manager
+			settings.ClientCert = certFile
+		} else {
+			settings.HTTPManager = httpclient.NewTLSHTTPManager(skip)
+		}
 		logrus.Debugf("%+v", settings)
 
 		if settings.Pods == nil || len(*settings.Pods) == 0 || settings.PodCheck < time.Now().Unix() {
@@ -179,6 +403,15 @@ func InitGlobalOpts(app *cli.Cli, settings *models.Settings) {
 				logrus.Debugf("Error listing pods: %s", err.Error())
 			}
 		}
+
+		if !settings.InboxQuiet && settings.SessionToken != "" && !prompts.NonInteractive() && settings.InboxCheck < time.Now().Unix() {
+			settings.InboxCheck = time.Now().Unix() + 86400
+			if count, err := inbox.UnreadCount(inbox.New(settings)); err != nil {
+				logrus.Debugf("Error checking the inbox: %s", err.Error())
+			} else if count > 0 {
+				fmt.Fprintf(os.Stderr, "You have %d unread notifications. Run \"datica inbox list\" to read them.\n", count)
+			}
+		}
 	}
 	app.After = func() {
 		config.SaveSettings(settings)
@@ -200,43 +433,113 @@ func InitLogrus() {
 	logrus.SetLevel(config.LogLevel)
 }
 
-// InitCLI adds arguments and commands to the given cli instance
+// InitCLI adds arguments and commands to the given cli instance. The top level
+// help lists the commands grouped by their category.
 func InitCLI(app *cli.Cli, settings *models.Settings) {
-	app.CommandLong(associate.Cmd.Name, associate.Cmd.ShortHelp, associate.Cmd.LongHelp, associate.Cmd.CmdFunc(settings))
-	app.CommandLong(associated.Cmd.Name, associated.Cmd.ShortHelp, associated.Cmd.LongHelp, associated.Cmd.CmdFunc(settings))
-	app.CommandLong(certs.Cmd.Name, certs.Cmd.ShortHelp, certs.Cmd.LongHelp, certs.Cmd.CmdFunc(settings))
-	app.CommandLong(clear.Cmd.Name, clear.Cmd.ShortHelp, clear.Cmd.LongHelp, clear.Cmd.CmdFunc(settings))
-	app.CommandLong(console.Cmd.Name, console.Cmd.ShortHelp, console.Cmd.LongHelp, console.Cmd.CmdFunc(settings))
-	app.CommandLong(dashboard.Cmd.Name, dashboard.Cmd.ShortHelp, dashboard.Cmd.LongHelp, dashboard.Cmd.CmdFunc(settings))
-	app.CommandLong(db.Cmd.Name, db.Cmd.ShortHelp, db.Cmd.LongHelp, db.Cmd.CmdFunc(settings))
-	app.CommandLong(defaultcmd.Cmd.Name, defaultcmd.Cmd.ShortHelp, defaultcmd.Cmd.LongHelp, defaultcmd.Cmd.CmdFunc(settings))
-	app.CommandLong(deploykeys.Cmd.Name, deploykeys.Cmd.ShortHelp, deploykeys.Cmd.LongHelp, deploykeys.Cmd.CmdFunc(settings))
-	app.CommandLong(disassociate.Cmd.Name, disassociate.Cmd.ShortHelp, disassociate.Cmd.LongHelp, disassociate.Cmd.CmdFunc(settings))
-	app.CommandLong(domain.Cmd.Name, domain.Cmd.ShortHelp, domain.Cmd.LongHelp, domain.Cmd.CmdFunc(settings))
-	app.CommandLong(environments.Cmd.Name, environments.Cmd.ShortHelp, environments.Cmd.LongHelp, environments.Cmd.CmdFunc(settings))
-	app.CommandLong(files.Cmd.Name, files.Cmd.ShortHelp, files.Cmd.LongHelp, files.Cmd.CmdFunc(settings))
-	app.CommandLong(git.Cmd.Name, git.Cmd.ShortHelp, git.Cmd.LongHelp, git.Cmd.CmdFunc(settings))
-	app.CommandLong(invites.Cmd.Name, invites.Cmd.ShortHelp, invites.Cmd.LongHelp, invites.Cmd.CmdFunc(settings))
-	app.CommandLong(keys.Cmd.Name, keys.Cmd.ShortHelp, keys.Cmd.LongHelp, keys.Cmd.CmdFunc(settings))
-	app.CommandLong(logout.Cmd.Name, logout.Cmd.ShortHelp, logout.Cmd.LongHelp, logout.Cmd.CmdFunc(settings))
-	app.CommandLong(logs.Cmd.Name, logs.Cmd.ShortHelp, logs.Cmd.LongHelp, logs.Cmd.CmdFunc(settings))
-	app.CommandLong(maintenance.Cmd.Name, maintenance.Cmd.ShortHelp, maintenance.Cmd.LongHelp, maintenance.Cmd.CmdFunc(settings))
-	app.CommandLong(metrics.Cmd.Name, metrics.Cmd.ShortHelp, metrics.Cmd.LongHelp, metrics.Cmd.CmdFunc(settings))
-	app.CommandLong(rake.Cmd.Name, rake.Cmd.ShortHelp, rake.Cmd.LongHelp, rake.Cmd.CmdFunc(settings))
-	app.CommandLong(redeploy.Cmd.Name, redeploy.Cmd.ShortHelp, redeploy.Cmd.LongHelp, redeploy.Cmd.CmdFunc(settings))
-	app.CommandLong(releases.Cmd.Name, releases.Cmd.ShortHelp, releases.Cmd.LongHelp, releases.Cmd.CmdFunc(settings))
-	app.CommandLong(rollback.Cmd.Name, rollback.Cmd.ShortHelp, rollback.Cmd.LongHelp, rollback.Cmd.CmdFunc(settings))
-	app.CommandLong(services.Cmd.Name, services.Cmd.ShortHelp, services.Cmd.LongHelp, services.Cmd.CmdFunc(settings))
-	app.CommandLong(sites.Cmd.Name, sites.Cmd.ShortHelp, sites.Cmd.LongHelp, sites.Cmd.CmdFunc(settings))
-	app.CommandLong(ssl.Cmd.Name, ssl.Cmd.ShortHelp, ssl.Cmd.LongHelp, ssl.Cmd.CmdFunc(settings))
-	app.CommandLong(status.Cmd.Name, status.Cmd.ShortHelp, status.Cmd.LongHelp, status.Cmd.CmdFunc(settings))
-	app.CommandLong(supportids.Cmd.Name, supportids.Cmd.ShortHelp, supportids.Cmd.LongHelp, supportids.Cmd.CmdFunc(settings))
-	if !config.Beta {
-		app.CommandLong(update.Cmd.Name, update.Cmd.ShortHelp, update.Cmd.LongHelp, update.Cmd.CmdFunc(settings))
+	commands := []models.Command{
+		access.Cmd,
+		admin.Cmd,
+		alerts.Cmd,
+		associate.Cmd,
+		associated.Cmd,
+		audit.Cmd,
+		breakglass.Cmd,
+		builds.Cmd,
+		cache.Cmd,
+		capabilities.Cmd,
+		certs.Cmd,
+		clear.Cmd,
+		console.Cmd,
+		cron.Cmd,
+		dashboard.Cmd,
+		db.Cmd,
+		defaultcmd.Cmd,
+		defaults.Cmd,
+		deploy.Cmd,
+		deploykeys.Cmd,
+		disassociate.Cmd,
+		doctor.Cmd,
+		domain.Cmd,
+		dr.Cmd,
+		environments.Cmd,
+		es.Cmd,
+		files.Cmd,
+		flags.Cmd,
+		git.Cmd,
+		githooks.Cmd,
+		hosts.Cmd,
+		inbox.Cmd,
+		invites.Cmd,
+		jobs.Cmd,
+		keys.Cmd,
+		logout.Cmd,
+		logs.Cmd,
+		maintenance.Cmd,
+		metrics.Cmd,
+		mfa.Cmd,
+		rake.Cmd,
+		redeploy.Cmd,
+		releases.Cmd,
+		reports.Cmd,
+		resume.Cmd,
+		roles.Cmd,
+		rollback.Cmd,
+		run.Cmd,
+		runbook.Cmd,
+		runtimecmd.Cmd,
+		saved.Cmd,
+		schema.Cmd,
+		serviceaccounts.Cmd,
+		services.Cmd,
+		sites.Cmd,
+		ssl.Cmd,
+		status.Cmd,
+		strict.Cmd,
+		supportids.Cmd,
+		timezone.Cmd,
+		trash.Cmd,
+		update.Cmd,
+		users.Cmd,
+		vars.Cmd,
+		version.Cmd,
+		volumes.Cmd,
+		whoami.Cmd,
+		worker.Cmd,
+	}
+	registered := []models.Command{}
+	for _, c := range commands {
+		if c.Name == update.Cmd.Name && config.Beta {
+			continue
+		}
+		app.CommandLong(c.Name, c.ShortHelp, help.Render(c.LongHelp), c.CmdFunc(settings))
+		registered = append(registered, c)
+	}
+	app.CommandsHelp = help.TOC(registered)
+	capabilities.SetRoot(app.Cmd)
+	defaults.SetRoot(app.Cmd)
+	saved.SetRunner(func(args []string) error {
+		return runSaved(args, settings)
+	})
+	invites.SetAssociator(func(orgID string) error {
+		return associate.CmdAssociateOrg(orgID, associate.New(settings), environments.New(settings), services.New(settings), prompts.New())
+	})
+}
+
+// runSaved runs a saved command as if it had been given on the command line.
+// It is run by its own app so that its global options are parsed again, and
+// the settings it leaves behind replace the given settings so that they are
+// not saved over afterwards. The global options given to "saved run" come
+// first, since the app resets the state they set, so that the saved command
+// is run with them unless it gives its own.
+func runSaved(args []string, settings *models.Settings) error {
+	app := cli.App("datica", fmt.Sprintf("Datica CLI. Version %s", config.VERSION))
+	inner := &models.Settings{}
+	InitGlobalOpts(app, inner)
+	InitCLI(app, inner)
+	global, _ := config.SplitGlobalArgs(os.Args[1:])
+	err := app.Run(append(append([]string{"datica"}, global...), args...))
+	if inner.HTTPManager != nil {
+		*settings = *inner
 	}
-	app.CommandLong(users.Cmd.Name, users.Cmd.ShortHelp, users.Cmd.LongHelp, users.Cmd.CmdFunc(settings))
-	app.CommandLong(vars.Cmd.Name, vars.Cmd.ShortHelp, vars.Cmd.LongHelp, vars.Cmd.CmdFunc(settings))
-	app.CommandLong(version.Cmd.Name, version.Cmd.ShortHelp, version.Cmd.LongHelp, version.Cmd.CmdFunc(settings))
-	app.CommandLong(whoami.Cmd.Name, whoami.Cmd.ShortHelp, whoami.Cmd.LongHelp, whoami.Cmd.CmdFunc(settings))
-	app.CommandLong(worker.Cmd.Name, worker.Cmd.ShortHelp, worker.Cmd.LongHelp, worker.Cmd.CmdFunc(settings))
+	return err
 }
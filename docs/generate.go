@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/daticahealth/cli/datica"
+	"github.com/daticahealth/cli/lib/help"
 	"github.com/daticahealth/cli/models"
 	"github.com/jault3/mow.cli"
 )
@@ -15,6 +16,7 @@ const binaryName = "datica"
 func main() {
 	app := cli.App(binaryName, "")
 	settings := &models.Settings{}
+	help.Markdown = true
 	datica.InitLogrus()
 	datica.InitGlobalOpts(app, settings)
 	datica.InitCLI(app, settings)
@@ -0,0 +1,45 @@
+package alerts
+
+import "github.com/daticahealth/cli/models"
+
+const (
+	// EventOOM is the event of a job being killed for exceeding its memory
+	// limit
+	EventOOM = "oom"
+	// EventScaleMismatch is the event of a worker target running fewer jobs
+	// than it is scaled to
+	EventScaleMismatch = "scale_mismatch"
+)
+
+// Events are the events an alert rule can be created for
+var Events = []string{EventOOM, EventScaleMismatch}
+
+// Metrics are the metrics an alert rule can watch. Thresholds are a
+// percentage of the CPU, memory, or storage of the service.
+var Metrics = []string{"cpu", "memory", "disk"}
+
+const (
+	// TargetEmail is an alert target that sends an email
+	TargetEmail = "email"
+	// TargetWebhook is an alert target that posts to a URL
+	TargetWebhook = "webhook"
+)
+
+// IAlerts
+type IAlerts interface {
+	CreateRule(rule *models.AlertRule) (*models.AlertRule, error)
+	DeleteRule(ruleID string) error
+	ListRules() (*[]models.AlertRule, error)
+}
+
+// SAlerts is a concrete implementation of IAlerts
+type SAlerts struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IAlerts
+func New(settings *models.Settings) IAlerts {
+	return &SAlerts{
+		Settings: settings,
+	}
+}
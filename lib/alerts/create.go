@@ -0,0 +1,26 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/daticahealth/cli/models"
+)
+
+func (a *SAlerts) CreateRule(rule *models.AlertRule) (*models.AlertRule, error) {
+	b, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/environments/%s/alerts", a.Settings.PaasHost, a.Settings.PaasHostVersion, a.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var created models.AlertRule
+	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
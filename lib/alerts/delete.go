@@ -0,0 +1,12 @@
+package alerts
+
+import "fmt"
+
+func (a *SAlerts) DeleteRule(ruleID string) error {
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Delete(nil, fmt.Sprintf("%s%s/environments/%s/alerts/%s", a.Settings.PaasHost, a.Settings.PaasHostVersion, a.Settings.EnvironmentID, ruleID), headers)
+	if err != nil {
+		return err
+	}
+	return a.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+}
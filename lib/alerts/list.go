@@ -0,0 +1,21 @@
+package alerts
+
+import (
+	"fmt"
+
+	"github.com/daticahealth/cli/models"
+)
+
+func (a *SAlerts) ListRules() (*[]models.AlertRule, error) {
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/alerts", a.Settings.PaasHost, a.Settings.PaasHostVersion, a.Settings.EnvironmentID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var rules []models.AlertRule
+	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, &rules)
+	if err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
@@ -10,45 +10,192 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"regexp"
+	"strings"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/prompts"
 	"github.com/daticahealth/cli/models"
 )
 
+// MFAModeAuthenticator is the second factor mode of one-time passwords from
+// an authenticator app
+const MFAModeAuthenticator = "authenticator"
+
+var totpRegex = regexp.MustCompile(`^[0-9]{6}$`)
+
 // Signin signs in a user and returns the representative user model. If an
 // error occurs, nil is returned for the user and the error field is populated.
 func (a *SAuth) Signin() (*models.User, error) {
+	if a.Settings.AccountToken != "" {
+		return a.signinWithToken()
+	}
 	// if we're already signed in with a valid session, don't sign in again
 	if user, err := a.Verify(); err == nil {
 		return user, nil
 	}
-	f := a.signInWithKey
-	if a.Settings.PrivateKeyPath == "" {
-		f = a.signInWithCredentials
+	user, err := a.resumeSession()
+	if err != nil || user != nil {
+		return user, err
 	}
-	signinResp, err := f()
+	// signing in may prompt for credentials, so the settings lock is only
+	// taken again once the new session is ready to be saved
+	user, err = a.signin()
 	if err != nil {
 		return nil, err
 	}
+	unlock, err := config.LockSettings()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	a.saveSession(user)
+	return user, nil
+}
 
-	var user *models.User
-
-	if signinResp.MFAID != "" {
-		user, err = a.mfaSignin(signinResp.MFAID, signinResp.MFAPreferredMode)
-		if err != nil {
-			return nil, err
+// resumeSession reuses the session another process signed in to or refreshes
+// the expired session while holding the settings lock, so that only one
+// process sharing these settings uses the refresh token at a time. No user is
+// returned when signing in again is required.
+func (a *SAuth) resumeSession() (*models.User, error) {
+	unlock, err := config.LockSettings()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	// another process may have signed in while we were waiting for the lock,
+	// in which case its session can be reused
+	if token, usersID, err := config.PersistedSession(); err == nil && token != "" && token != a.Settings.SessionToken {
+		a.Settings.SessionToken = token
+		a.Settings.UsersID = usersID
+		if user, err := a.Verify(); err == nil {
+			logrus.Debugln("Reusing the session created by another datica process")
+			return user, nil
 		}
-	} else {
-		user = signinResp.toUser()
 	}
+	// an expired session is refreshed without prompting whenever the refresh
+	// token is still valid. When the auth host could not be reached, the
+	// refresh token is kept and signing in is left for the next command.
+	user, err := a.refresh()
+	if err != nil {
+		if err != errNoRefreshToken && !refreshRejected(err) {
+			return nil, fmt.Errorf("Unable to refresh your session: %s", err)
+		}
+		logrus.Debugf("Unable to refresh the session, signing in again: %s", err)
+		return nil, nil
+	}
+	a.saveSession(user)
+	return user, nil
+}
 
+// saveSession stores the session of the given user in the settings. The
+// settings lock must be held.
+func (a *SAuth) saveSession(user *models.User) {
 	a.Settings.UsersID = user.UsersID
 	a.Settings.Username = user.Username
 	a.Settings.SessionToken = user.SessionToken
+	a.Settings.RefreshToken = user.RefreshToken
 
 	config.SaveSettings(a.Settings)
+}
 
+// signinWithToken signs in as the service account the token belongs to. A
+// rejected token is never followed by a prompt for credentials, since service
+// accounts are used where no one can answer it.
+func (a *SAuth) signinWithToken() (*models.User, error) {
+	a.Settings.SessionToken = a.Settings.AccountToken
+	a.Settings.RefreshToken = ""
+	user, err := a.Verify()
+	if err != nil {
+		return nil, fmt.Errorf("The service account token given by %s was rejected: %s", config.DaticaTokenEnvVar, err)
+	}
+	user.SessionToken = a.Settings.AccountToken
+	return user, nil
+}
+
+// signin signs in with the private key or credentials of the user and their
+// second factor if they have one
+func (a *SAuth) signin() (*models.User, error) {
+	f := a.signInWithKey
+	if a.Settings.PrivateKeyPath == "" {
+		f = a.signInWithCredentials
+	}
+	signinResp, err := f()
+	if err != nil {
+		return nil, err
+	}
+	if signinResp.MFAID != "" {
+		return a.mfaSignin(signinResp.MFAID, signinResp.MFAPreferredMode)
+	}
+	return signinResp.toUser(), nil
+}
+
+// errNoRefreshToken is returned by refresh when there is no refresh token to
+// exchange for a new session
+var errNoRefreshToken = errors.New("No refresh token has been stored")
+
+// refreshRejected reports whether the auth host rejected the refresh token as
+// invalid or expired, as opposed to failing to handle the request
+func refreshRejected(err error) bool {
+	apiErr, ok := err.(*httpclient.APIError)
+	return ok && (apiErr.StatusCode == 400 || apiErr.StatusCode == 401)
+}
+
+// refresh exchanges the refresh token for a new session. A refresh token that
+// is rejected is cleared so that it is not tried again, while one that could
+// not be exchanged because of a network or server error is kept.
+func (a *SAuth) refresh() (*models.User, error) {
+	// the refresh token is cleared when signing in again is required, such
+	// as after setting a new private key
+	if a.Settings.RefreshToken == "" {
+		return nil, errNoRefreshToken
+	}
+	// another process may have used the refresh token and stored the one
+	// that replaced it
+	refreshToken, err := config.PersistedRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if refreshToken == "" {
+		return nil, errNoRefreshToken
+	}
+	b, err := json.Marshal(struct {
+		RefreshToken string `json:"refreshToken"`
+	}{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, err
+	}
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/auth/refresh", a.Settings.AuthHost, a.Settings.AuthHostVersion), headers)
+	if err != nil {
+		return nil, err
+	}
+	signinResp := &signinResponse{}
+	if err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, signinResp); err != nil {
+		if refreshRejected(err) {
+			a.Settings.RefreshToken = ""
+			config.SaveSettings(a.Settings)
+		}
+		return nil, err
+	}
+	if signinResp.SessionToken == "" {
+		return nil, errors.New("No session token was returned for the refresh token")
+	}
+	// refresh tokens are replaced on every refresh unless the auth host does
+	// not rotate them
+	if signinResp.RefreshToken == "" {
+		signinResp.RefreshToken = refreshToken
+	}
+	a.Settings.SessionToken = signinResp.SessionToken
+	user, err := a.Verify()
+	if err != nil {
+		return nil, err
+	}
+	user.SessionToken = signinResp.SessionToken
+	user.RefreshToken = signinResp.RefreshToken
+	logrus.Debugln("Refreshed the expired session")
 	return user, nil
 }
 
@@ -57,6 +204,7 @@ type signinResponse struct {
 	Name             string `json:"name"`
 	Email            string `json:"email"`
 	SessionToken     string `json:"sessionToken"`
+	RefreshToken     string `json:"refreshToken"`
 	MFAID            string `json:"mfaID"`
 	MFAPreferredMode string `json:"mfaPreferredType"`
 }
@@ -67,6 +215,7 @@ func (sr *signinResponse) toUser() *models.User {
 		Username:     sr.Name,
 		Email:        sr.Email,
 		SessionToken: sr.SessionToken,
+		RefreshToken: sr.RefreshToken,
 	}
 }
 
@@ -105,7 +254,7 @@ func (a *SAuth) signInWithKey() (*signinResponse, error) {
 		Signature string `json:"signature"`
 	}{}
 
-	bytes, err := ioutil.ReadFile(a.Settings.PrivateKeyPath)
+	bytes, publicKey, err := config.PrivateKey(a.Settings.PrivateKeyPath)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +264,10 @@ func (a *SAuth) signInWithKey() (*signinResponse, error) {
 	}
 	bytes = block.Bytes
 	if x509.IsEncryptedPEMBlock(block) {
-		passphrase := a.Prompts.KeyPassphrase(a.Settings.PrivateKeyPath)
+		passphrase, err := a.Prompts.KeyPassphrase(a.Settings.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
 		bytes, err = x509.DecryptPEMBlock(block, []byte(passphrase))
 		if err != nil {
 			return nil, err
@@ -125,26 +277,22 @@ func (a *SAuth) signInWithKey() (*signinResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	publicKey, err := ioutil.ReadFile(a.Settings.PrivateKeyPath + ".pub")
-	if err != nil {
-		return nil, err
-	}
 	body.PublicKey = string(publicKey)
 
-	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
-	message := fmt.Sprintf("%s&%s", headers["X-Request-Nonce"][0], headers["X-Request-Timestamp"][0])
-	hashedMessage := sha256.Sum256([]byte(message))
-	signature, err := privateKey.Sign(rand.Reader, hashedMessage[:], crypto.SHA256)
-	if err != nil {
-		return nil, err
-	}
-	body.Signature = base64.StdEncoding.EncodeToString(signature)
-
-	b, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+	// the nonce and timestamp are signed again for every retry since each
+	// retry is sent with new ones
+	sign := func(headers map[string][]string) ([]byte, error) {
+		message := fmt.Sprintf("%s&%s", headers["X-Request-Nonce"][0], headers["X-Request-Timestamp"][0])
+		hashedMessage := sha256.Sum256([]byte(message))
+		signature, err := privateKey.Sign(rand.Reader, hashedMessage[:], crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		body.Signature = base64.StdEncoding.EncodeToString(signature)
+		return json.Marshal(body)
 	}
-	resp, statusCode, err := a.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/auth/signin/key", a.Settings.AuthHost, a.Settings.AuthHostVersion), headers)
+	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
+	resp, statusCode, err := a.Settings.HTTPManager.PostSigned(sign, fmt.Sprintf("%s%s/auth/signin/key", a.Settings.AuthHost, a.Settings.AuthHostVersion), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +301,23 @@ func (a *SAuth) signInWithKey() (*signinResponse, error) {
 }
 
 func (a *SAuth) mfaSignin(mfaID string, preferredMode string) (*models.User, error) {
-	token := a.Prompts.OTP(preferredMode)
+	token := a.Settings.MFACode
+	if token == "" {
+		if prompts.NonInteractive() {
+			return nil, fmt.Errorf("Unable to prompt for a one-time password because the CLI is running non-interactively. Give it with --mfa-code or the %s environment variable instead", config.DaticaMFACodeEnvVar)
+		}
+		logrus.Println("This account has two-factor authentication enabled.")
+		var err error
+		if token, err = a.Prompts.OTP(preferredMode); err != nil {
+			return nil, err
+		}
+	}
+	token = strings.Replace(token, " ", "", -1)
+	if preferredMode == MFAModeAuthenticator {
+		if err := CheckTOTP(token); err != nil {
+			return nil, err
+		}
+	}
 	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
 	b, err := json.Marshal(struct {
 		OTP string `json:"otp"`
@@ -162,6 +326,9 @@ func (a *SAuth) mfaSignin(mfaID string, preferredMode string) (*models.User, err
 		return nil, err
 	}
 	resp, statusCode, err := a.Settings.HTTPManager.Post(b, fmt.Sprintf("%s%s/auth/signin/mfa/%s", a.Settings.AuthHost, a.Settings.AuthHostVersion, mfaID), headers)
+	if err != nil {
+		return nil, err
+	}
 	user := &models.User{}
 	err = a.Settings.HTTPManager.ConvertResp(resp, statusCode, user)
 	if err != nil {
@@ -170,6 +337,15 @@ func (a *SAuth) mfaSignin(mfaID string, preferredMode string) (*models.User, err
 	return user, err
 }
 
+// CheckTOTP returns an error if the given code is not a one-time password
+// from an authenticator app, which are always 6 digits
+func CheckTOTP(code string) error {
+	if !totpRegex.MatchString(code) {
+		return fmt.Errorf("\"%s\" is not a one-time password from your authenticator app, which are 6 digits", code)
+	}
+	return nil
+}
+
 // Signout signs out a user by their session token.
 func (a *SAuth) Signout() error {
 	headers := a.Settings.HTTPManager.GetHeaders(a.Settings.SessionToken, a.Settings.Version, a.Settings.Pod, a.Settings.UsersID)
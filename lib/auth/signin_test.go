@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/keyring"
+	"github.com/daticahealth/cli/lib/prompts"
+	"github.com/daticahealth/cli/models"
+)
+
+// refreshTokenKey is the keyring key the refresh token is stored under
+const refreshTokenKey = "refresh_token"
+
+var refreshTests = []struct {
+	persisted     string
+	statusCode    int
+	rotated       string
+	expectSent    string
+	expectRefresh string
+	expectErr     bool
+	expectKept    bool
+}{
+	{"refresh1", 200, "refresh2", "refresh1", "refresh2", false, true},
+	{"refresh1", 200, "", "refresh1", "refresh1", false, true},
+	{"refresh-other", 200, "refresh3", "refresh-other", "refresh3", false, true},
+	{"refresh1", 401, "", "refresh1", "", true, false},
+	{"refresh1", 400, "", "refresh1", "", true, false},
+	{"refresh1", 503, "", "refresh1", "", true, true},
+	{"refresh1", 500, "", "refresh1", "", true, true},
+	{"refresh1", 429, "", "refresh1", "", true, true},
+}
+
+func setupKeyring(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	home, backend := os.Getenv("HOME"), os.Getenv(keyring.BackendEnvVar)
+	os.Setenv("HOME", dir)
+	os.Setenv(keyring.BackendEnvVar, keyring.FileBackend)
+	return func() {
+		os.Setenv("HOME", home)
+		os.Setenv(keyring.BackendEnvVar, backend)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	teardown := setupKeyring(t)
+	defer teardown()
+	httpclient.SetRetries(1)
+	defer httpclient.SetRetries(httpclient.DefaultRetries)
+
+	for _, data := range refreshTests {
+		t.Logf("Data: %+v", data)
+		sent := ""
+		mux := http.NewServeMux()
+		mux.HandleFunc("/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				RefreshToken string `json:"refreshToken"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			sent = body.RefreshToken
+			w.WriteHeader(data.statusCode)
+			if data.statusCode == 200 {
+				fmt.Fprintf(w, `{"sessionToken":"session2","refreshToken":"%s"}`, data.rotated)
+			} else {
+				fmt.Fprint(w, `{"code":1,"title":"Error","description":"Refresh failed"}`)
+			}
+		})
+		mux.HandleFunc("/auth/verify", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"usersId":"user1"}`)
+		})
+		server := httptest.NewServer(mux)
+		if err := keyring.New().Set(refreshTokenKey, data.persisted); err != nil {
+			t.Fatal(err)
+		}
+		settings := &models.Settings{
+			AuthHost:     server.URL,
+			HTTPManager:  httpclient.NewTLSHTTPManager(false),
+			SessionToken: "session1",
+			RefreshToken: "refresh1",
+		}
+		a := &SAuth{Settings: settings}
+
+		// test
+		user, err := a.refresh()
+		server.Close()
+
+		// assert
+		if sent != data.expectSent {
+			t.Errorf("Expected the refresh token %s to be sent but got %s", data.expectSent, sent)
+		}
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if kept := settings.RefreshToken != ""; kept != data.expectKept {
+			t.Errorf("Expected the refresh token to be kept: %t, but it was kept: %t", data.expectKept, kept)
+		}
+		if err != nil {
+			if rejected := refreshRejected(err); rejected == data.expectKept {
+				t.Errorf("Expected the error to be a rejection: %t, but got %s", !data.expectKept, err)
+			}
+			continue
+		}
+		if user.SessionToken != "session2" || user.RefreshToken != data.expectRefresh {
+			t.Errorf("Expected the session session2 and refresh token %s but got %s and %s", data.expectRefresh, user.SessionToken, user.RefreshToken)
+		}
+	}
+}
+
+func TestRefreshWithoutToken(t *testing.T) {
+	teardown := setupKeyring(t)
+	defer teardown()
+
+	a := &SAuth{Settings: &models.Settings{RefreshToken: ""}}
+	if _, err := a.refresh(); err != errNoRefreshToken {
+		t.Errorf("Expected no refresh token to be found but got %v", err)
+	}
+	a.Settings.RefreshToken = "refresh1"
+	if _, err := a.refresh(); err != errNoRefreshToken {
+		t.Errorf("Expected no refresh token to be found in the keyring but got %v", err)
+	}
+}
+
+var mfaSigninTests = []struct {
+	mfaCode    string
+	mode       string
+	expectSent string
+	expectErr  bool
+}{
+	{"123456", MFAModeAuthenticator, "123456", false},
+	{"123 456", MFAModeAuthenticator, "123456", false},
+	{"12345", MFAModeAuthenticator, "", true},
+	{"abcdef", MFAModeAuthenticator, "", true},
+	{"abc123", "sms", "abc123", false},
+	{"", MFAModeAuthenticator, "", true},
+}
+
+func TestMFASignin(t *testing.T) {
+	prompts.SetNonInteractive(true)
+	defer prompts.SetNonInteractive(false)
+
+	for _, data := range mfaSigninTests {
+		t.Logf("Data: %+v", data)
+		sent := ""
+		mux := http.NewServeMux()
+		mux.HandleFunc("/auth/signin/mfa/mfa1", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				OTP string `json:"otp"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			sent = body.OTP
+			fmt.Fprint(w, `{"usersId":"user1","sessionToken":"session2"}`)
+		})
+		server := httptest.NewServer(mux)
+		a := &SAuth{Settings: &models.Settings{
+			AuthHost:    server.URL,
+			HTTPManager: httpclient.NewTLSHTTPManager(false),
+			MFACode:     data.mfaCode,
+		}}
+
+		// test
+		user, err := a.mfaSignin("mfa1", data.mode)
+		server.Close()
+
+		// assert
+		if sent != data.expectSent {
+			t.Errorf("Expected the one-time password %s to be sent but got %s", data.expectSent, sent)
+		}
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if err == nil && user.SessionToken != "session2" {
+			t.Errorf("Expected the session session2 but got %s", user.SessionToken)
+		}
+	}
+}
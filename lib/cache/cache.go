@@ -0,0 +1,215 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	objectsDir = "objects"
+	refsDir    = "refs"
+)
+
+func cacheDir() string {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		logrus.Debugf("Error finding the home directory for the cache: %s", err)
+		return config.CacheDir
+	}
+	return filepath.Join(homeDir, config.CacheDir)
+}
+
+func (c *SCache) objectPath(digest string) string {
+	return filepath.Join(c.Dir, objectsDir, digest)
+}
+
+// Get returns the path of the cached artifact with the given digest, if it is
+// in the cache. The artifact is marked as used so it is pruned last.
+func (c *SCache) Get(digest string) (string, bool) {
+	if !validDigest(digest) {
+		return "", false
+	}
+	path := c.objectPath(digest)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return path, true
+}
+
+// Put copies the contents of r into the cache and returns their digest. If
+// an artifact with the same digest is already cached it is kept as is.
+func (c *SCache) Put(r io.Reader) (string, error) {
+	dir := filepath.Join(c.Dir, objectsDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	tmp, err := ioutil.TempFile(dir, ".put")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), r)
+	tmp.Close()
+	if err != nil {
+		return "", err
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if _, ok := c.Get(digest); ok {
+		return digest, nil
+	}
+	return digest, os.Rename(tmp.Name(), c.objectPath(digest))
+}
+
+// Resolve returns the digest of the artifact with the given ref, if it is
+// still in the cache
+func (c *SCache) Resolve(ref string) (string, bool) {
+	b, err := ioutil.ReadFile(filepath.Join(c.Dir, refsDir, refName(ref)))
+	if err != nil {
+		return "", false
+	}
+	digest := strings.TrimSpace(string(b))
+	if _, ok := c.Get(digest); !ok {
+		return "", false
+	}
+	return digest, true
+}
+
+// Link gives the cached artifact with the given digest a ref
+func (c *SCache) Link(ref, digest string) error {
+	dir := filepath.Join(c.Dir, refsDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, refName(ref)), []byte(digest), 0600)
+}
+
+// List returns every cached artifact, most recently used first
+func (c *SCache) List() (*[]models.CacheEntry, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return nil, err
+	}
+	sorted := []models.CacheEntry{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		sorted = append(sorted, entries[i].CacheEntry)
+	}
+	return &sorted, nil
+}
+
+// Prune removes the least recently used artifacts until the cache holds at
+// most maxSize bytes, and returns the number of artifacts removed and the
+// number of bytes freed
+func (c *SCache) Prune(maxSize int64) (int, int64, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return 0, 0, err
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	removed := 0
+	var freed int64
+	for _, e := range entries {
+		if total <= maxSize {
+			break
+		}
+		if err = os.Remove(c.objectPath(e.Digest)); err != nil {
+			return removed, freed, err
+		}
+		for _, ref := range e.Refs {
+			os.Remove(filepath.Join(c.Dir, refsDir, refName(ref)))
+		}
+		total -= e.Size
+		freed += e.Size
+		removed++
+	}
+	return removed, freed, nil
+}
+
+type entry struct {
+	models.CacheEntry
+	lastUsed time.Time
+}
+
+// entries returns every cached artifact, least recently used first
+func (c *SCache) entries() ([]entry, error) {
+	files, err := ioutil.ReadDir(filepath.Join(c.Dir, objectsDir))
+	if os.IsNotExist(err) {
+		return []entry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	refs := map[string][]string{}
+	if refFiles, err := ioutil.ReadDir(filepath.Join(c.Dir, refsDir)); err == nil {
+		for _, f := range refFiles {
+			b, err := ioutil.ReadFile(filepath.Join(c.Dir, refsDir, f.Name()))
+			if err != nil {
+				continue
+			}
+			digest := strings.TrimSpace(string(b))
+			refs[digest] = append(refs[digest], f.Name())
+		}
+	}
+	entries := []entry{}
+	for _, f := range files {
+		if f.IsDir() || !validDigest(f.Name()) {
+			continue
+		}
+		entries = append(entries, entry{
+			CacheEntry: models.CacheEntry{
+				Digest:   f.Name(),
+				Size:     f.Size(),
+				LastUsed: f.ModTime().UTC().Format(time.RFC3339),
+				Refs:     refs[f.Name()],
+			},
+			lastUsed: f.ModTime(),
+		})
+	}
+	sort.Sort(byLastUsed(entries))
+	return entries, nil
+}
+
+// byLastUsed is a wrapper for entry array in order to sort them by the time
+// they were last used
+type byLastUsed []entry
+
+func (e byLastUsed) Len() int {
+	return len(e)
+}
+
+func (e byLastUsed) Swap(i, j int) {
+	e[i], e[j] = e[j], e[i]
+}
+
+func (e byLastUsed) Less(i, j int) bool {
+	return e[i].lastUsed.Before(e[j].lastUsed)
+}
+
+// refName makes a ref safe to use as a file name
+func refName(ref string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(ref)
+}
+
+func validDigest(digest string) bool {
+	if len(digest) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(digest)
+	return err == nil
+}
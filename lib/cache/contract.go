@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"io"
+
+	"github.com/daticahealth/cli/models"
+)
+
+// ICache stores downloaded artifacts on the local machine by the SHA-256
+// digest of their contents so that they do not have to be downloaded again.
+// Artifacts can also be given a ref, such as the ID of the backup they were
+// downloaded from, when their digest is not known ahead of time.
+type ICache interface {
+	Get(digest string) (string, bool)
+	Put(r io.Reader) (string, error)
+	Resolve(ref string) (string, bool)
+	Link(ref, digest string) error
+	List() (*[]models.CacheEntry, error)
+	Prune(maxSize int64) (int, int64, error)
+}
+
+// SCache is a concrete implementation of ICache that stores each artifact as
+// a file named by its digest in Dir.
+type SCache struct {
+	Dir string
+}
+
+// New returns an instance of ICache
+func New() ICache {
+	return &SCache{
+		Dir: cacheDir(),
+	}
+}
@@ -0,0 +1,147 @@
+package help
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/daticahealth/cli/models"
+	"github.com/jault3/mow.cli"
+	"github.com/skratchdot/open-golang/open"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// DocsURL is the location of the CLI reference documentation
+const DocsURL = "https://resources.datica.com/compliant-cloud/cli-reference/"
+
+const (
+	bold  = "\033[1m"
+	reset = "\033[0m"
+)
+
+// Markdown disables rendering so help is left as markdown. This is used when
+// generating the CLI reference documentation.
+var Markdown = false
+
+var (
+	boldRegex = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	codeRegex = regexp.MustCompile("`([^`]+)`")
+	linkRegex = regexp.MustCompile(`\[([^\]]+)\]\([^)]+\)`)
+)
+
+// Render converts the markdown in a command's long help into text suitable
+// for a terminal. Link targets are stripped, code blocks are indented, and
+// bold text and inline code are highlighted when help is printed to a
+// terminal that supports it.
+func Render(markdown string) string {
+	if Markdown {
+		return markdown
+	}
+	start, end := "", ""
+	if runtime.GOOS != "windows" && terminal.IsTerminal(int(os.Stderr.Fd())) {
+		start, end = bold, reset
+	}
+	lines := []string{}
+	inCode := false
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			if line != "" {
+				line = "    " + line
+			}
+			lines = append(lines, line)
+			continue
+		}
+		line = linkRegex.ReplaceAllString(line, "$1")
+		line = boldRegex.ReplaceAllString(line, start+"$1"+end)
+		line = codeRegex.ReplaceAllString(line, start+"$1"+end)
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Open opens the documentation for the command given in args in the default
+// browser. The command is found by matching args against the names of the
+// commands registered on root. Any args that do not name a command, such as
+// flags and their values, are skipped.
+func Open(root *cli.Cmd, args []string) error {
+	path := []string{}
+	cmd := root
+	for _, arg := range args {
+		var next *cli.Cmd
+		for _, sub := range cmd.Commands {
+			if sub.Name == arg {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			continue
+		}
+		if err := next.DoInit(); err != nil {
+			return err
+		}
+		path = append(path, next.Name)
+		cmd = next
+	}
+	url := DocsURL
+	if len(path) > 0 {
+		url = fmt.Sprintf("%s#%s", DocsURL, strings.Join(path, "-"))
+	}
+	return open.Run(url)
+}
+
+// categories is the order commands are grouped in by TOC
+var categories = []string{
+	models.CategoryEnvironment,
+	models.CategoryData,
+	models.CategoryDeploy,
+	models.CategoryAccess,
+	models.CategoryObservability,
+}
+
+// TOC builds the list of commands shown in the top level help message. The
+// commands are grouped by category and each is described by the first
+// sentence of its short help. Commands without a category are listed last.
+func TOC(commands []models.Command) string {
+	groups := map[string][]models.Command{}
+	width := 15
+	for _, c := range commands {
+		groups[c.Category] = append(groups[c.Category], c)
+		if len(c.Name)+3 > width {
+			width = len(c.Name) + 3
+		}
+	}
+	var b bytes.Buffer
+	for _, category := range append(categories, "") {
+		group := groups[category]
+		if len(group) == 0 {
+			continue
+		}
+		if category == "" {
+			category = "Other"
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s Commands:\n", category)
+		for _, c := range group {
+			fmt.Fprintf(&b, "  %-*s%s\n", width, c.Name, summary(c.ShortHelp))
+		}
+	}
+	return b.String()
+}
+
+// summary shortens help to its first sentence
+func summary(help string) string {
+	if i := strings.Index(help, ". "); i >= 0 {
+		return help[:i]
+	}
+	return strings.TrimSuffix(help, ".")
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// rootCAs are the certificate authorities trusted by every connection the CLI
+// makes. nil trusts the system's certificate authorities.
+var rootCAs *x509.CertPool
+
+// LoadCACert returns the system's certificate authorities along with the PEM
+// encoded certificates in the given file.
+func LoadCACert(file string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("No PEM encoded certificates were found in %s", file)
+	}
+	return pool, nil
+}
+
+// TrustCACert trusts the certificates in the given file in addition to the
+// system's certificate authorities, such as the certificate of a proxy that
+// inspects TLS traffic. It applies to every HTTPManager created afterwards and
+// to downloads made with the default http client. An empty file is ignored.
+func TrustCACert(file string) error {
+	if file == "" {
+		return nil
+	}
+	pool, err := LoadCACert(file)
+	if err != nil {
+		return fmt.Errorf("Could not load the CA certificate %s: %s", file, err)
+	}
+	rootCAs = pool
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return nil
+}
+
+// RootCAs returns the certificate authorities connections should trust, or nil
+// for the system's certificate authorities.
+func RootCAs() *x509.CertPool {
+	return rootCAs
+}
@@ -6,9 +6,7 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -28,24 +26,59 @@ type TLSHTTPManager struct {
 }
 
 // NewTLSHTTPManager constructs and returns a new instance of HTTPManager
-// with TLSv1.2 and redirect support.
+// with TLSv1.2 and redirect support. Requests go through the proxy set in the
+// HTTPS_PROXY environment variable unless the host is listed in NO_PROXY.
 func NewTLSHTTPManager(skipVerify bool) models.HTTPManager {
-	var tr = &http.Transport{
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		},
-	}
-	if skipVerify {
-		tr.TLSClientConfig.InsecureSkipVerify = true
+	return newTLSHTTPManager(&tls.Config{
+		MinVersion:         MinTLSVersion,
+		InsecureSkipVerify: skipVerify,
+	})
+}
+
+// NewClientCertHTTPManager constructs and returns a new instance of
+// HTTPManager like NewTLSHTTPManager that also presents the given client
+// certificate. This is used for self-hosted installations that require mutual
+// TLS.
+func NewClientCertHTTPManager(skipVerify bool, certFile, keyFile string) (models.HTTPManager, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load the client certificate %s with the key %s: %s", certFile, keyFile, err)
 	}
+	return newTLSHTTPManager(&tls.Config{
+		MinVersion:         MinTLSVersion,
+		InsecureSkipVerify: skipVerify,
+		Certificates:       []tls.Certificate{cert},
+	}), nil
+}
+
+func newTLSHTTPManager(tlsConfig *tls.Config) models.HTTPManager {
+	tlsConfig.RootCAs = rootCAs
 	return &TLSHTTPManager{
 		client: &http.Client{
-			Transport:     tr,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
 			CheckRedirect: redirectPolicyFunc,
 		},
 	}
 }
 
+// TLSConfig returns a copy of the TLS configuration of the given HTTPManager,
+// so that connections made without it, such as websockets, present the same
+// client certificate and verify the host the same way
+func TLSConfig(m models.HTTPManager) *tls.Config {
+	if t, ok := m.(*TLSHTTPManager); ok {
+		if transport, ok := t.client.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+			return transport.TLSClientConfig.Clone()
+		}
+	}
+	return &tls.Config{
+		MinVersion: MinTLSVersion,
+		RootCAs:    rootCAs,
+	}
+}
+
 func redirectPolicyFunc(req *http.Request, via []*http.Request) error {
 	if len(via) == 0 {
 		// No redirects
@@ -63,22 +96,69 @@ func redirectPolicyFunc(req *http.Request, via []*http.Request) error {
 	return nil
 }
 
+// breakGlassSession is the ID of the active break-glass session, if any
+var breakGlassSession string
+
+// SetBreakGlassSession tags every following request with the given
+// break-glass session so that the changes made during it can be told apart in
+// the audit trail. An empty ID stops tagging requests.
+func SetBreakGlassSession(id string) {
+	breakGlassSession = id
+}
+
+// runbookStep is the runbook step this process was started for, if any
+var runbookStep string
+
+// SetRunbookStep tags every following request with the given runbook step so
+// that the changes made by a runbook can be told apart in the audit trail. An
+// empty step stops tagging requests.
+func SetRunbookStep(step string) {
+	runbookStep = step
+}
+
 // GetHeaders builds a map of headers for a new request.
 func (m *TLSHTTPManager) GetHeaders(sessionToken, version, pod, userID string) map[string][]string {
-	b := make([]byte, 32)
-	rand.Read(b)
-	nonce := base64.StdEncoding.EncodeToString(b)
-	timestamp := time.Now().Unix()
-	return map[string][]string{
+	nonce, timestamp := newNonce()
+	headers := map[string][]string{
 		"Accept":              {"application/json"},
 		"Content-Type":        {"application/json"},
 		"Authorization":       {fmt.Sprintf("Bearer %s", sessionToken)},
 		"X-CLI-Version":       {version},
 		"X-Pod-ID":            {pod},
 		"X-Request-Nonce":     {nonce},
-		"X-Request-Timestamp": {fmt.Sprintf("%d", timestamp)},
+		"X-Request-Timestamp": {timestamp},
 		"User-Agent":          {fmt.Sprintf("datica-cli-%s %s %s %s", version, runtime.GOOS, config.ArchString(), userID)},
 	}
+	if breakGlassSession != "" {
+		headers["X-Break-Glass-Session"] = []string{breakGlassSession}
+	}
+	if runbookStep != "" {
+		headers["X-Runbook-Step"] = []string{runbookStep}
+	}
+	return headers
+}
+
+// newNonce returns a random nonce and the current timestamp, which the API
+// uses to reject replayed requests
+func newNonce() (string, string) {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b), fmt.Sprintf("%d", time.Now().Unix())
+}
+
+// renewNonce returns a copy of the given headers with a new nonce and
+// timestamp, so that a retried request is not rejected as a replay
+func renewNonce(headers map[string][]string) map[string][]string {
+	renewed := map[string][]string{}
+	for k, v := range headers {
+		renewed[k] = v
+	}
+	if _, ok := headers["X-Request-Nonce"]; ok {
+		nonce, timestamp := newNonce()
+		renewed["X-Request-Nonce"] = []string{nonce}
+		renewed["X-Request-Timestamp"] = []string{timestamp}
+	}
+	return renewed
 }
 
 // ConvertResp takes in a resp from one of the httpclient methods and
@@ -105,34 +185,70 @@ func (m *TLSHTTPManager) isError(statusCode int) bool {
 // convertError attempts to convert a response into a usable error object.
 func (m *TLSHTTPManager) convertError(b []byte, statusCode int) error {
 	msg := fmt.Sprintf("(%d)", statusCode)
+	reported := false
 	if b != nil && len(b) > 0 {
 		var errs models.Error
 		unmarshalErr := json.Unmarshal(b, &errs)
 		if unmarshalErr == nil && errs.Title != "" && errs.Description != "" {
 			msg = fmt.Sprintf("(%d) %s: %s", errs.Code, errs.Title, errs.Description)
+			reported = true
 		} else {
 			var reportedErr models.ReportedError
 			unmarshalErr = json.Unmarshal(b, &reportedErr)
 			if unmarshalErr == nil && reportedErr.Message != "" {
 				msg = fmt.Sprintf("(%d) %s", reportedErr.Code, reportedErr.Message)
+				reported = true
 			} else {
 				msg = fmt.Sprintf("(%d) %s", statusCode, string(b))
 			}
 		}
 	}
-	return errors.New(msg)
+	return &APIError{
+		StatusCode: statusCode,
+		Message:    msg,
+		Reported:   reported,
+	}
+}
+
+// APIError is returned by ConvertResp for responses with an error status code
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Reported is whether the body was an error returned by the API itself,
+	// rather than by a proxy in front of it
+	Reported bool
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// IsMissingEndpoint returns whether the given error was returned for a
+// request to an endpoint the host does not serve, such as an endpoint added
+// after the pod was last updated. A 404 the API reported itself means the
+// requested resource does not exist, not the endpoint.
+func IsMissingEndpoint(err error) bool {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	case http.StatusNotFound:
+		return !apiErr.Reported
+	}
+	return false
 }
 
 // Get performs a GET request
 func (m *TLSHTTPManager) Get(body []byte, url string, headers map[string][]string) ([]byte, int, error) {
-	reader := bytes.NewReader(body)
-	return m.makeRequest("GET", url, reader, headers)
+	return m.makeRequest("GET", url, body, headers)
 }
 
 // Post performs a POST request
 func (m *TLSHTTPManager) Post(body []byte, url string, headers map[string][]string) ([]byte, int, error) {
-	reader := bytes.NewReader(body)
-	return m.makeRequest("POST", url, reader, headers)
+	return m.makeRequest("POST", url, body, headers)
 }
 
 // PostFile uploads a file with a POST
@@ -158,7 +274,7 @@ func (m *TLSHTTPManager) uploadFile(method, filepath, url string, headers map[st
 	req, _ := http.NewRequest(method, url, file)
 	req.ContentLength = info.Size()
 
-	resp, err := m.client.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -167,37 +283,79 @@ func (m *TLSHTTPManager) uploadFile(method, filepath, url string, headers map[st
 	return respBody, resp.StatusCode, nil
 }
 
+// do sends the given request, records it in the trace file, and checks the
+// TLS connection it was sent over
+func (m *TLSHTTPManager) do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := m.client.Do(req)
+	trace(req, resp, err, start)
+	checkTLS(resp)
+	return resp, err
+}
+
+// PostSigned performs a POST request whose body signs the nonce and timestamp
+// of the headers. The body is built again for every attempt, since each
+// attempt is sent with a new nonce.
+func (m *TLSHTTPManager) PostSigned(sign func(headers map[string][]string) ([]byte, error), url string, headers map[string][]string) ([]byte, int, error) {
+	return m.makeSignedRequest("POST", url, sign, headers)
+}
+
 // Put performs a PUT request
 func (m *TLSHTTPManager) Put(body []byte, url string, headers map[string][]string) ([]byte, int, error) {
-	reader := bytes.NewReader(body)
-	return m.makeRequest("PUT", url, reader, headers)
+	return m.makeRequest("PUT", url, body, headers)
 }
 
 // Delete performs a DELETE request
 func (m *TLSHTTPManager) Delete(body []byte, url string, headers map[string][]string) ([]byte, int, error) {
-	reader := bytes.NewReader(body)
-	return m.makeRequest("DELETE", url, reader, headers)
+	return m.makeRequest("DELETE", url, body, headers)
 }
 
 // MakeRequest is a generic HTTP runner that performs a request and returns
 // the result body as a byte array. It's up to the caller to transform them
-// into an object.
-func (m *TLSHTTPManager) makeRequest(method string, url string, body io.Reader, headers map[string][]string) ([]byte, int, error) {
+// into an object. Failed requests are retried with an exponential backoff when
+// it is safe to send them again, see shouldRetry.
+func (m *TLSHTTPManager) makeRequest(method string, url string, body []byte, headers map[string][]string) ([]byte, int, error) {
+	logrus.Debugf("%s", body)
+	return m.makeSignedRequest(method, url, func(map[string][]string) ([]byte, error) {
+		return body, nil
+	}, headers)
+}
+
+// makeSignedRequest is makeRequest with a body built from the headers of each
+// attempt. Every attempt after the first is sent with a new nonce and
+// timestamp.
+func (m *TLSHTTPManager) makeSignedRequest(method string, url string, sign func(headers map[string][]string) ([]byte, error), headers map[string][]string) ([]byte, int, error) {
 	logrus.Debugf("%s %s", method, url)
 	logrus.Debugf("%+v", headers)
-	logrus.Debugf("%s", body)
-	req, _ := http.NewRequest(method, url, body)
-	req.Header = headers
+	first := true
+	a := withRetries(method, url, func() attempt {
+		attemptHeaders := headers
+		if !first {
+			attemptHeaders = renewNonce(headers)
+		}
+		first = false
+		body, err := sign(attemptHeaders)
+		if err != nil {
+			return attempt{err: err}
+		}
+		req, _ := http.NewRequest(method, url, bytes.NewReader(body))
+		req.Header = attemptHeaders
 
-	resp, err := m.client.Do(req)
-	if err != nil {
-		return nil, 0, err
+		resp, err := m.do(req)
+		if err != nil {
+			return attempt{err: err}
+		}
+		defer resp.Body.Close()
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return attempt{body: respBody, statusCode: resp.StatusCode, header: resp.Header}
+	})
+	if a.err != nil {
+		return nil, 0, a.err
 	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode == 412 {
+	respBody, statusCode := a.body, a.statusCode
+	if statusCode == 412 {
 		updater.AutoUpdater.ForcedUpgrade()
 		return nil, 0, fmt.Errorf("A required update has been applied. Please re-run this command.")
 	}
-	return respBody, resp.StatusCode, nil
+	return respBody, statusCode, nil
 }
@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"errors"
+	"testing"
+)
+
+var isMissingEndpointTests = []struct {
+	statusCode int
+	body       string
+	expected   bool
+}{
+	{404, "", true},
+	{404, "404 page not found", true},
+	{404, `{"code":1,"title":"Not Found","description":"Service not found"}`, false},
+	{404, `{"code":1,"message":"Environment not found"}`, false},
+	{405, `{"code":1,"title":"Method Not Allowed","description":"Method not allowed"}`, true},
+	{501, "", true},
+	{403, "", false},
+	{500, "", false},
+}
+
+func TestIsMissingEndpoint(t *testing.T) {
+	m := NewTLSHTTPManager(false).(*TLSHTTPManager)
+	for _, data := range isMissingEndpointTests {
+		t.Logf("Data: %+v", data)
+		err := m.ConvertResp([]byte(data.body), data.statusCode, nil)
+		if actual := IsMissingEndpoint(err); actual != data.expected {
+			t.Errorf("Expected %t but got %t for %s", data.expected, actual, err)
+		}
+	}
+	if IsMissingEndpoint(errors.New("connection refused")) {
+		t.Errorf("Expected a network error not to be a missing endpoint")
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	m := NewTLSHTTPManager(true)
+	config := TLSConfig(m)
+	if !config.InsecureSkipVerify || config.MinVersion != MinTLSVersion {
+		t.Errorf("Expected the TLS configuration of the manager but got %+v", config)
+	}
+	config.InsecureSkipVerify = false
+	if !TLSConfig(m).InsecureSkipVerify {
+		t.Errorf("Expected a copy of the TLS configuration of the manager")
+	}
+	if config = TLSConfig(nil); config.InsecureSkipVerify || config.MinVersion != MinTLSVersion {
+		t.Errorf("Expected the default TLS configuration but got %+v", config)
+	}
+}
+
+func TestGetHeadersTags(t *testing.T) {
+	defer SetBreakGlassSession("")
+	defer SetRunbookStep("")
+	m := NewTLSHTTPManager(false)
+	headers := m.GetHeaders("token", "dev", "pod01", "user1")
+	if _, ok := headers["X-Break-Glass-Session"]; ok {
+		t.Errorf("Expected no break-glass session header but got %v", headers)
+	}
+	if _, ok := headers["X-Runbook-Step"]; ok {
+		t.Errorf("Expected no runbook step header but got %v", headers)
+	}
+	SetBreakGlassSession("bg1")
+	SetRunbookStep("scale up/2")
+	headers = m.GetHeaders("token", "dev", "pod01", "user1")
+	if session := headers["X-Break-Glass-Session"]; len(session) != 1 || session[0] != "bg1" {
+		t.Errorf("Expected the break-glass session header but got %v", session)
+	}
+	if step := headers["X-Runbook-Step"]; len(step) != 1 || step[0] != "scale up/2" {
+		t.Errorf("Expected the runbook step header but got %v", step)
+	}
+}
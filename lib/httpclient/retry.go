@@ -0,0 +1,121 @@
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// DefaultRetries is the number of attempts made for a request before its
+// failure is returned to the caller.
+const DefaultRetries = 3
+
+var retries = DefaultRetries
+
+// retryBackoff is the wait before the second attempt of a request. Each
+// following attempt waits twice as long as the one before it.
+var retryBackoff = time.Second
+
+// maxRetryAfter is the longest wait a Retry-After header is honored for
+var maxRetryAfter = time.Minute
+
+// SetRetries sets the number of attempts made for every API request. Anything
+// less than 1 makes a single attempt.
+func SetRetries(attempts int) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	retries = attempts
+}
+
+// attempt is the outcome of sending a request once
+type attempt struct {
+	body       []byte
+	statusCode int
+	header     http.Header
+	err        error
+}
+
+// shouldRetry reports whether a request that ended with the given outcome is
+// worth trying again. Idempotent requests are retried on network errors, rate
+// limiting, and server errors. Other requests may already have been acted on,
+// so they are only retried when the server asked for it with a 429 or 503, or
+// when the connection failed before the request was sent.
+func shouldRetry(method string, a attempt) bool {
+	if a.err != nil {
+		return isIdempotent(method) || notSent(a.err)
+	}
+	if a.statusCode == 429 || a.statusCode == 503 {
+		return true
+	}
+	// a 501 means the host does not serve the endpoint, which no retry fixes
+	return isIdempotent(method) && a.statusCode >= 500 && a.statusCode != 501
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// notSent reports whether the error happened while connecting, before any of
+// the request was sent
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryAfter returns the wait requested by the Retry-After header of a
+// response, given either in seconds or as a date. Waits longer than
+// maxRetryAfter are capped.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = at.Sub(now)
+	} else {
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
+
+// withRetries runs do until it succeeds, fails with something that is not
+// retryable, or runs out of attempts. The result of the last attempt is
+// returned.
+func withRetries(method, url string, do func() attempt) attempt {
+	wait := retryBackoff
+	for n := 1; ; n++ {
+		a := do()
+		if n >= retries || !shouldRetry(method, a) {
+			return a
+		}
+		sleep := wait
+		if after, ok := retryAfter(a.header, time.Now()); ok {
+			sleep = after
+		}
+		if a.err != nil {
+			logrus.Debugf("%s %s failed: %s. Retrying in %s", method, url, a.err, sleep)
+		} else {
+			logrus.Debugf("%s %s returned %d. Retrying in %s", method, url, a.statusCode, sleep)
+		}
+		time.Sleep(sleep)
+		wait *= 2
+	}
+}
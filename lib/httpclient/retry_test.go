@@ -0,0 +1,160 @@
+package httpclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var shouldRetryTests = []struct {
+	method     string
+	statusCode int
+	err        error
+	expected   bool
+}{
+	{"GET", 500, nil, true},
+	{"PUT", 502, nil, true},
+	{"DELETE", 429, nil, true},
+	{"GET", 404, nil, false},
+	{"GET", 501, nil, false},
+	{"GET", 200, nil, false},
+	{"GET", 0, errors.New("connection reset by peer"), true},
+	{"POST", 500, nil, false},
+	{"POST", 502, nil, false},
+	{"POST", 503, nil, true},
+	{"POST", 429, nil, true},
+	{"POST", 0, errors.New("connection reset by peer"), false},
+	{"POST", 0, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+	{"POST", 0, &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, false},
+}
+
+func TestShouldRetry(t *testing.T) {
+	for _, data := range shouldRetryTests {
+		t.Logf("Data: %+v", data)
+		actual := shouldRetry(data.method, attempt{statusCode: data.statusCode, err: data.err})
+		if actual != data.expected {
+			t.Errorf("Expected %t but got %t", data.expected, actual)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"3600", maxRetryAfter, true},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, data := range tests {
+		t.Logf("Data: %+v", data)
+		header := http.Header{}
+		if data.value != "" {
+			header.Set("Retry-After", data.value)
+		}
+		actual, ok := retryAfter(header, now)
+		if actual != data.expected || ok != data.ok {
+			t.Errorf("Expected %s, %t but got %s, %t", data.expected, data.ok, actual, ok)
+		}
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	backoff := retryBackoff
+	retryBackoff = time.Millisecond
+	defer func() { retryBackoff = backoff }()
+	SetRetries(3)
+
+	tests := []struct {
+		method     string
+		statusCode int
+		retryAfter string
+		expected   int
+	}{
+		{"GET", 500, "", 3},
+		{"POST", 500, "", 1},
+		{"POST", 503, "0", 3},
+		{"POST", 201, "", 1},
+	}
+	for _, data := range tests {
+		t.Logf("Data: %+v", data)
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if data.retryAfter != "" {
+				w.Header().Set("Retry-After", data.retryAfter)
+			}
+			w.WriteHeader(data.statusCode)
+		}))
+		m := NewTLSHTTPManager(false).(*TLSHTTPManager)
+		_, statusCode, err := m.makeRequest(data.method, server.URL, nil, map[string][]string{})
+		server.Close()
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if statusCode != data.statusCode {
+			t.Errorf("Expected the status %d but got %d", data.statusCode, statusCode)
+		}
+		if requests != data.expected {
+			t.Errorf("Expected %d requests but got %d", data.expected, requests)
+		}
+	}
+}
+
+func TestRetryRenewsNonce(t *testing.T) {
+	backoff := retryBackoff
+	retryBackoff = time.Millisecond
+	defer func() { retryBackoff = backoff }()
+	SetRetries(3)
+	defer SetRetries(DefaultRetries)
+
+	nonces := []string{}
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, r.Header.Get("X-Request-Nonce"))
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+	m := NewTLSHTTPManager(false).(*TLSHTTPManager)
+	headers := m.GetHeaders("token", "dev", "pod", "user")
+	first := headers["X-Request-Nonce"][0]
+	sign := func(headers map[string][]string) ([]byte, error) {
+		return []byte(headers["X-Request-Nonce"][0]), nil
+	}
+	if _, _, err := m.PostSigned(sign, server.URL, headers); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(nonces) != 3 {
+		t.Fatalf("Expected 3 requests but got %d", len(nonces))
+	}
+	if nonces[0] != first {
+		t.Errorf("Expected the first attempt to be sent with the nonce %s but got %s", first, nonces[0])
+	}
+	seen := map[string]bool{}
+	for i, nonce := range nonces {
+		if seen[nonce] {
+			t.Errorf("Expected every attempt to have a new nonce but %s was sent again", nonce)
+		}
+		seen[nonce] = true
+		if bodies[i] != nonce {
+			t.Errorf("Expected the body to be signed with the nonce %s of its attempt but got %s", nonce, bodies[i])
+		}
+	}
+	if headers["X-Request-Nonce"][0] != first {
+		t.Errorf("Expected the headers given to be left unchanged")
+	}
+}
@@ -0,0 +1,107 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// TLSDeprecationHeader is the header the platform adds to responses when the
+// TLS connections the CLI makes will stop being accepted, describing the
+// upcoming change
+const TLSDeprecationHeader = "X-TLS-Deprecation"
+
+const (
+	// MinTLSVersion is the lowest TLS version the CLI negotiates
+	MinTLSVersion = tls.VersionTLS12
+	// MaxTLSVersion is the highest TLS version the CLI negotiates
+	MaxTLSVersion = tls.VersionTLS13
+)
+
+var (
+	tlsLock sync.Mutex
+	// negotiated is the TLS connection state last negotiated with each host
+	negotiated = map[string]tls.ConnectionState{}
+	// tlsWarned holds the TLS warnings already printed by this process
+	tlsWarned = map[string]bool{}
+)
+
+// TLSVersions are the TLS versions by the name the platform uses for them
+var TLSVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion returns the TLS version with the given name, such as "1.2"
+func ParseTLSVersion(name string) (uint16, error) {
+	v, ok := TLSVersions[strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "TLS ")]
+	if !ok {
+		return 0, fmt.Errorf("\"%s\" is not a TLS version", name)
+	}
+	return v, nil
+}
+
+// Negotiated returns the TLS connection state last negotiated with the given
+// host, such as "api.datica.com", if a request has been made to it
+func Negotiated(host string) (tls.ConnectionState, bool) {
+	tlsLock.Lock()
+	defer tlsLock.Unlock()
+	state, ok := negotiated[host]
+	return state, ok
+}
+
+// WeakConnection returns why the given TLS connection is weak, or an empty
+// string if it is not. Connections using a cipher suite with known weaknesses
+// or without forward secrecy are weak. Versions older than MinTLSVersion are
+// never negotiated, so they fail the handshake instead.
+func WeakConnection(state tls.ConnectionState) string {
+	suite := tls.CipherSuiteName(state.CipherSuite)
+	for _, s := range tls.InsecureCipherSuites() {
+		if s.ID == state.CipherSuite {
+			return fmt.Sprintf("the cipher suite %s is insecure", suite)
+		}
+	}
+	// every TLS 1.3 cipher suite has forward secrecy
+	if state.Version < tls.VersionTLS13 && !strings.HasPrefix(suite, "TLS_ECDHE_") {
+		return fmt.Sprintf("the cipher suite %s does not have forward secrecy", suite)
+	}
+	return ""
+}
+
+// checkTLS remembers the TLS connection of the given response and warns once
+// per host when the connection is weak or the platform signals that it will
+// stop being accepted
+func checkTLS(resp *http.Response) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+	host := resp.Request.URL.Host
+	if msg := resp.Header.Get(TLSDeprecationHeader); msg != "" {
+		warnTLS(host+msg, fmt.Sprintf("%s will stop accepting the TLS connections this CLI makes: %s. Run \"datica doctor --tls\" for details.", host, msg))
+	}
+	if resp.TLS == nil {
+		return
+	}
+	tlsLock.Lock()
+	negotiated[host] = *resp.TLS
+	tlsLock.Unlock()
+	if weak := WeakConnection(*resp.TLS); weak != "" {
+		warnTLS(host+weak, fmt.Sprintf("The connection to %s is weak since %s. This is usually caused by a proxy that inspects TLS traffic. Run \"datica doctor --tls\" for details.", host, weak))
+	}
+}
+
+func warnTLS(key, msg string) {
+	tlsLock.Lock()
+	defer tlsLock.Unlock()
+	if tlsWarned[key] {
+		return
+	}
+	tlsWarned[key] = true
+	logrus.Warnln(msg)
+}
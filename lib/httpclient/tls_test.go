@@ -0,0 +1,85 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+)
+
+var weakConnectionTests = []struct {
+	version     uint16
+	cipherSuite uint16
+	expectWeak  bool
+}{
+	{tls.VersionTLS13, tls.TLS_AES_128_GCM_SHA256, false},
+	{tls.VersionTLS12, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, false},
+	{tls.VersionTLS12, tls.TLS_RSA_WITH_AES_128_GCM_SHA256, true},
+	{tls.VersionTLS12, tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA, true},
+	{tls.VersionTLS12, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA, true},
+}
+
+func TestWeakConnection(t *testing.T) {
+	for _, data := range weakConnectionTests {
+		t.Logf("Data: %+v", data)
+		weak := WeakConnection(tls.ConnectionState{Version: data.version, CipherSuite: data.cipherSuite})
+		if (weak != "") != data.expectWeak {
+			t.Errorf("Expected weak: %t, but got \"%s\"", data.expectWeak, weak)
+		}
+	}
+}
+
+// checkTLSWarnings returns what checkTLS warns about the given responses
+func checkTLSWarnings(responses ...*http.Response) string {
+	tlsLock.Lock()
+	tlsWarned = map[string]bool{}
+	tlsLock.Unlock()
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	defer logrus.SetOutput(os.Stderr)
+	for _, resp := range responses {
+		checkTLS(resp)
+	}
+	return buf.String()
+}
+
+func tlsResponse(host string, state *tls.ConnectionState, header http.Header) *http.Response {
+	return &http.Response{
+		Request: &http.Request{URL: &url.URL{Scheme: "https", Host: host}},
+		Header:  header,
+		TLS:     state,
+	}
+}
+
+func TestCheckTLS(t *testing.T) {
+	strong := &tls.ConnectionState{Version: tls.VersionTLS12, CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+	weak := &tls.ConnectionState{Version: tls.VersionTLS12, CipherSuite: tls.TLS_RSA_WITH_AES_128_GCM_SHA256}
+	deprecation := http.Header{}
+	deprecation.Set(TLSDeprecationHeader, "TLS 1.2 is no longer accepted after 2027-01-01")
+
+	if output := checkTLSWarnings(tlsResponse("strong.example.com", strong, http.Header{})); output != "" {
+		t.Errorf("Expected no warning for a strong connection but got %s", output)
+	}
+	if state, ok := Negotiated("strong.example.com"); !ok || state.CipherSuite != strong.CipherSuite {
+		t.Errorf("Expected the negotiated connection to be remembered but got %+v", state)
+	}
+
+	output := checkTLSWarnings(tlsResponse("weak.example.com", weak, http.Header{}), tlsResponse("weak.example.com", weak, http.Header{}))
+	if strings.Count(output, "weak.example.com is weak since") != 1 {
+		t.Errorf("Expected a single weak connection warning but got %s", output)
+	}
+
+	output = checkTLSWarnings(tlsResponse("old.example.com", strong, deprecation), tlsResponse("old.example.com", nil, deprecation))
+	if strings.Count(output, "no longer accepted after 2027-01-01") != 1 {
+		t.Errorf("Expected a single deprecation warning but got %s", output)
+	}
+
+	if output = checkTLSWarnings(nil, &http.Response{}); output != "" {
+		t.Errorf("Expected no warning without a request but got %s", output)
+	}
+}
@@ -0,0 +1,145 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces the value of every header and query parameter that could
+// hold a credential
+const redacted = "[REDACTED]"
+
+var (
+	traceFile *os.File
+	tracePath string
+	traceLock sync.Mutex
+)
+
+// sensitive are the parts of header and query parameter names that mark them
+// as credentials. "credential" and "sig" cover the parameters of presigned
+// URLs, such as X-Amz-Credential and the sig of an Azure SAS.
+var sensitive = []string{"authorization", "cookie", "token", "password", "secret", "key", "signature", "otp", "credential", "sig"}
+
+// traceEntry is a single line of the trace file
+type traceEntry struct {
+	Time            string              `json:"time"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	Status          int                 `json:"status,omitempty"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+	RequestID       string              `json:"request_id,omitempty"`
+	Duration        float64             `json:"duration_ms"`
+	Error           string              `json:"error,omitempty"`
+}
+
+// SetTraceFile appends the metadata of every request made by an HTTPManager,
+// with credentials redacted, to the given file as one JSON object per line.
+// Request and response bodies are never written. An empty path turns tracing
+// off.
+func SetTraceFile(path string) error {
+	traceLock.Lock()
+	defer traceLock.Unlock()
+	if traceFile != nil {
+		traceFile.Close()
+		traceFile = nil
+	}
+	tracePath = ""
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	traceFile = f
+	tracePath = path
+	return nil
+}
+
+// TraceFile returns the path of the file requests are traced to, or an empty
+// string if tracing is off
+func TraceFile() string {
+	traceLock.Lock()
+	defer traceLock.Unlock()
+	return tracePath
+}
+
+// trace records a finished request in the trace file if tracing is on
+func trace(req *http.Request, resp *http.Response, err error, start time.Time) {
+	traceLock.Lock()
+	defer traceLock.Unlock()
+	if traceFile == nil {
+		return
+	}
+	entry := traceEntry{
+		Time:           start.UTC().Format(time.RFC3339Nano),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+		RequestID:      req.Header.Get("X-Request-Nonce"),
+		Duration:       float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if err != nil {
+		entry.Error = redactError(err, req.URL)
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.ResponseHeaders = redactHeaders(resp.Header)
+		if id := resp.Header.Get("X-Request-Id"); id != "" {
+			entry.RequestID = id
+		}
+	}
+	b, _ := json.Marshal(entry)
+	traceFile.Write(append(b, '\n'))
+}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitive {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeaders(headers http.Header) map[string][]string {
+	redactedHeaders := map[string][]string{}
+	for k, v := range headers {
+		if isSensitive(k) {
+			v = []string{redacted}
+		}
+		redactedHeaders[k] = v
+	}
+	return redactedHeaders
+}
+
+// redactError formats err with the URL of a *url.Error redacted, since it
+// holds the full URL of the request
+func redactError(err error, u *url.URL) string {
+	if urlErr, ok := err.(*url.Error); ok {
+		redactedErr := *urlErr
+		redactedErr.URL = redactURL(u)
+		return redactedErr.Error()
+	}
+	return err.Error()
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.User = nil
+	query := redactedURL.Query()
+	for k := range query {
+		if isSensitive(k) {
+			query.Set(k, redacted)
+		}
+	}
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
@@ -5,14 +5,16 @@ import "github.com/daticahealth/cli/models"
 // IJobs
 type IJobs interface {
 	Delete(jobID, svcID string) error
-	Deploy(redeploy bool, releaseName, target, svcID string) error
-	DeployRelease(releaseName, svcID string) error
-	DeployTarget(target, svcID string) error
-	Redeploy(svcID string) error
+	Deploy(redeploy bool, releaseName, target, strategy, svcID string) (*models.Job, error)
+	DeployRelease(releaseName, svcID string) (*models.Job, error)
+	DeployTarget(target, svcID string) (*models.Job, error)
+	Redeploy(svcID, strategy string) (*models.Job, error)
 	Retrieve(jobID, svcID string, includeSpec bool) (*models.Job, error)
 	RetrieveByStatus(svcID, status string) (*[]models.Job, error)
 	RetrieveByType(svcID, jobType string, page, pageSize int) (*[]models.Job, error)
-	RetrieveByTarget(svcID, target string, page, pageSize int) (*[]models.Job, error)
+	RetrieveAllByType(svcID, jobType string) (*[]models.Job, error)
+	RetrieveByTarget(svcID, target string) (*[]models.Job, error)
+	Retry(jobID, svcID string) (*models.Job, error)
 	PollForStatus(statuses []string, jobID, svcID string) (string, error)
 	PollTillFinished(jobID, svcID string) (string, error)
 	List(svcID string, page, pageSize int) (*[]models.Job, error)
@@ -3,21 +3,46 @@ package jobs
 import (
 	"fmt"
 	"strings"
+
+	"github.com/daticahealth/cli/models"
 )
 
-func (j *SJobs) DeployRelease(releaseName, svcID string) error {
-	return j.Deploy(true, releaseName, "", svcID)
+// DeployStrategies are the ways a deploy can replace the running jobs of a
+// service. Recreate stops every running job before the new ones start, which
+// is what the API does when no strategy is given, while rolling replaces the
+// jobs one at a time so that the service keeps serving during the deploy.
+var DeployStrategies = []string{"rolling", "recreate"}
+
+// ValidateStrategy returns an error if the strategy is not one of
+// DeployStrategies. An empty strategy is valid and leaves the choice to the
+// API.
+func ValidateStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+	for _, s := range DeployStrategies {
+		if s == strategy {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid strategy \"%s\". The strategy must be one of %s", strategy, strings.Join(DeployStrategies, ", "))
 }
 
-func (j *SJobs) DeployTarget(target, svcID string) error {
-	return j.Deploy(false, "", target, svcID)
+func (j *SJobs) DeployRelease(releaseName, svcID string) (*models.Job, error) {
+	return j.Deploy(true, releaseName, "", "", svcID)
 }
 
-func (j *SJobs) Redeploy(svcID string) error {
-	return j.Deploy(true, "", "", svcID)
+func (j *SJobs) DeployTarget(target, svcID string) (*models.Job, error) {
+	return j.Deploy(false, "", target, "", svcID)
 }
 
-func (j *SJobs) Deploy(redeploy bool, releaseName, target, svcID string) error {
+func (j *SJobs) Redeploy(svcID, strategy string) (*models.Job, error) {
+	return j.Deploy(true, "", "", strategy, svcID)
+}
+
+// Deploy starts a new deploy job for a service and returns the created job.
+// The returned job may have an empty ID if the API did not report one.
+func (j *SJobs) Deploy(redeploy bool, releaseName, target, strategy, svcID string) (*models.Job, error) {
 	var params = []string{}
 	if releaseName != "" {
 		params = append(params, fmt.Sprintf("release=%s", releaseName))
@@ -28,10 +53,18 @@ func (j *SJobs) Deploy(redeploy bool, releaseName, target, svcID string) error {
 	if target != "" {
 		params = append(params, fmt.Sprintf("target=%s", target))
 	}
+	if strategy != "" {
+		params = append(params, fmt.Sprintf("strategy=%s", strategy))
+	}
 	headers := j.Settings.HTTPManager.GetHeaders(j.Settings.SessionToken, j.Settings.Version, j.Settings.Pod, j.Settings.UsersID)
 	resp, statusCode, err := j.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/deploy?%s", j.Settings.PaasHost, j.Settings.PaasHostVersion, j.Settings.EnvironmentID, svcID, strings.Join(params, "&")), headers)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var job models.Job
+	err = j.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
 	}
-	return j.Settings.HTTPManager.ConvertResp(resp, statusCode, nil)
+	return &job, nil
 }
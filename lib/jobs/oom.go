@@ -0,0 +1,25 @@
+package jobs
+
+import "github.com/daticahealth/cli/models"
+
+// StatusOOMKilled is shown in place of the status of a job that was killed
+// for exceeding the memory limit of its service
+const StatusOOMKilled = "oom_killed"
+
+// oomExitCode is the exit code of a process killed by the kernel OOM killer
+const oomExitCode = 137
+
+// OOMKilled reports whether the job was killed for exceeding the memory limit
+// of its service. The platform restarts these jobs like any other failed job,
+// so the status alone does not tell them apart.
+func OOMKilled(job *models.Job) bool {
+	return job.Termination != nil && (job.Termination.Reason == "OOMKilled" || job.Termination.ExitCode == oomExitCode)
+}
+
+// DisplayStatus returns the status of the job to show to the user
+func DisplayStatus(job *models.Job) string {
+	if OOMKilled(job) {
+		return StatusOOMKilled
+	}
+	return job.Status
+}
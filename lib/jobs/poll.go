@@ -1,14 +1,23 @@
 package jobs
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/stream"
 	"github.com/daticahealth/cli/models"
 )
 
+// pendingStatuses are the statuses of a job that is still being processed
+var pendingStatuses = []string{"scheduled", "queued", "started", "running", "stopped", "waiting"}
+
+// errStreamDone stops the stream of a job's events once it is done
+var errStreamDone = errors.New("The job is done")
+
 func contains(v string, a []string) bool {
 	for _, i := range a {
 		if i == v {
@@ -18,6 +27,16 @@ func contains(v string, a []string) bool {
 	return false
 }
 
+// TerminalStatuses returns the statuses that indicate a job of the given type
+// is done being processed. Deploy and worker jobs are long running and are
+// considered done once they are running while all other jobs must finish.
+func TerminalStatuses(jobType string) []string {
+	if jobType == "deploy" || jobType == "worker" {
+		return []string{"running"}
+	}
+	return []string{"finished"}
+}
+
 func (j *SJobs) PollTillFinished(jobID, svcID string) (string, error) {
 	return j.PollForStatus([]string{"finished"}, jobID, svcID)
 }
@@ -37,6 +56,9 @@ func (j *SJobs) WaitToAppear(jobID, svcID string) error {
 }
 
 func (j *SJobs) PollForStatus(statuses []string, jobID, svcID string) (string, error) {
+	if status, ok, err := j.streamForStatus(statuses, jobID, svcID); ok {
+		return status, err
+	}
 	var job models.Job
 	failedAttempts := 0
 poll:
@@ -58,7 +80,7 @@ poll:
 		switch {
 		case contains(s, statuses):
 			break poll
-		case contains(s, []string{"scheduled", "queued", "started", "running", "stopped", "waiting"}):
+		case contains(s, pendingStatuses):
 			if failedAttempts >= 3 {
 				return "", fmt.Errorf("Error - ended in status '%s'.", job.Status)
 			}
@@ -74,3 +96,29 @@ poll:
 	}
 	return job.Status, nil
 }
+
+// streamForStatus waits for the job to reach one of the given statuses by
+// following the stream of its events, which the pod sends as soon as the job
+// changes. ok is false if the pod does not support streaming or the stream
+// fails, in which case the job must be polled instead.
+func (j *SJobs) streamForStatus(statuses []string, jobID, svcID string) (string, bool, error) {
+	var job models.Job
+	err := stream.New(j.Settings).Subscribe(fmt.Sprintf("/environments/%s/services/%s/jobs/%s/stream", j.Settings.EnvironmentID, svcID, jobID), func(msg []byte) error {
+		if err := json.Unmarshal(msg, &job); err != nil {
+			return err
+		}
+		if contains(job.Status, statuses) || !contains(job.Status, pendingStatuses) {
+			return errStreamDone
+		}
+		logrus.StandardLogger().Out.Write([]byte("."))
+		return nil
+	})
+	if err != errStreamDone {
+		logrus.Debugf("Falling back to polling for the status of job %s: %s", jobID, err)
+		return "", false, nil
+	}
+	if !contains(job.Status, statuses) {
+		return "", true, fmt.Errorf("Error - ended in status '%s'.", job.Status)
+	}
+	return job.Status, true, nil
+}
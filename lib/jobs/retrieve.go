@@ -2,10 +2,19 @@ package jobs
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/daticahealth/cli/models"
 )
 
+const (
+	// allJobsPageSize is the number of jobs requested per page when retrieving
+	// every job of a type
+	allJobsPageSize = 500
+	// maxConcurrentPages bounds the number of pages of jobs fetched at once
+	maxConcurrentPages = 4
+)
+
 func (j *SJobs) Retrieve(jobID, svcID string, includeSpec bool) (*models.Job, error) {
 	headers := j.Settings.HTTPManager.GetHeaders(j.Settings.SessionToken, j.Settings.Version, j.Settings.Pod, j.Settings.UsersID)
 	resp, statusCode, err := j.Settings.HTTPManager.Get(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs/%s?spec=true", j.Settings.PaasHost, j.Settings.PaasHostVersion, j.Settings.EnvironmentID, svcID, jobID), headers)
@@ -48,9 +57,46 @@ func (j *SJobs) RetrieveByType(svcID, jobType string, page, pageSize int) (*[]mo
 	return &jobs, nil
 }
 
-func (j *SJobs) RetrieveByTarget(svcID, target string, page, pageSize int) (*[]models.Job, error) {
+// RetrieveAllByType retrieves every job of the given type. After the first
+// page, up to maxConcurrentPages pages are fetched at once until a page comes
+// back with fewer than allJobsPageSize jobs.
+func (j *SJobs) RetrieveAllByType(svcID, jobType string) (*[]models.Job, error) {
+	first, err := j.RetrieveByType(svcID, jobType, 1, allJobsPageSize)
+	if err != nil {
+		return nil, err
+	}
+	all := *first
+	if len(*first) < allJobsPageSize {
+		return &all, nil
+	}
+	for next := 2; ; next += maxConcurrentPages {
+		pages := make([]*[]models.Job, maxConcurrentPages)
+		errs := make([]error, maxConcurrentPages)
+		var wg sync.WaitGroup
+		for i := range pages {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				pages[i], errs[i] = j.RetrieveByType(svcID, jobType, next+i, allJobsPageSize)
+			}(i)
+		}
+		wg.Wait()
+		for i, page := range pages {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+			all = append(all, *page...)
+			if len(*page) < allJobsPageSize {
+				return &all, nil
+			}
+		}
+	}
+}
+
+// RetrieveByTarget retrieves every worker job with the given target
+func (j *SJobs) RetrieveByTarget(svcID, target string) (*[]models.Job, error) {
 	var res []models.Job
-	jobs, err := j.RetrieveByType(svcID, "worker", page, pageSize)
+	jobs, err := j.RetrieveAllByType(svcID, "worker")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/daticahealth/cli/models"
+)
+
+// Retry starts a new job with the same spec as the given job and returns the
+// new job
+func (j *SJobs) Retry(jobID, svcID string) (*models.Job, error) {
+	headers := j.Settings.HTTPManager.GetHeaders(j.Settings.SessionToken, j.Settings.Version, j.Settings.Pod, j.Settings.UsersID)
+	resp, statusCode, err := j.Settings.HTTPManager.Post(nil, fmt.Sprintf("%s%s/environments/%s/services/%s/jobs/%s/retry", j.Settings.PaasHost, j.Settings.PaasHostVersion, j.Settings.EnvironmentID, svcID, jobID), headers)
+	if err != nil {
+		return nil, err
+	}
+	var job models.Job
+	err = j.Settings.HTTPManager.ConvertResp(resp, statusCode, &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
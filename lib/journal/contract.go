@@ -0,0 +1,29 @@
+package journal
+
+import "github.com/daticahealth/cli/models"
+
+// IJournal records the progress of multi-step commands on the local machine
+// so that an interrupted run can be resumed without repeating steps that
+// already completed.
+type IJournal interface {
+	Start(command string, steps []string, options map[string]string) (*models.Operation, error)
+	Complete(op *models.Operation, step int) error
+	Finish(op *models.Operation) error
+	Retrieve(opID string) (*models.Operation, error)
+	List() (*[]models.Operation, error)
+}
+
+// SJournal is a concrete implementation of IJournal that stores each
+// operation as a JSON file in Dir.
+type SJournal struct {
+	Settings *models.Settings
+	Dir      string
+}
+
+// New returns an instance of IJournal
+func New(settings *models.Settings) IJournal {
+	return &SJournal{
+		Settings: settings,
+		Dir:      journalDir(),
+	}
+}
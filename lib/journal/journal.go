@@ -0,0 +1,146 @@
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/models"
+	"github.com/mitchellh/go-homedir"
+)
+
+func journalDir() string {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		logrus.Debugf("Error finding the home directory for the operation journal: %s", err)
+		return config.JournalDir
+	}
+	return filepath.Join(homeDir, config.JournalDir)
+}
+
+// Start records a new operation made up of the given steps, none of which
+// have completed yet. The current environment and the given options are saved
+// with the operation so it can be resumed the same way later.
+func (j *SJournal) Start(command string, steps []string, options map[string]string) (*models.Operation, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	op := &models.Operation{
+		ID:              hex.EncodeToString(b),
+		Command:         command,
+		EnvironmentID:   j.Settings.EnvironmentID,
+		EnvironmentName: j.Settings.EnvironmentName,
+		ServiceID:       j.Settings.ServiceID,
+		Pod:             j.Settings.Pod,
+		OrgID:           j.Settings.OrgID,
+		Options:         options,
+		CreatedAt:       time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, s := range steps {
+		op.Steps = append(op.Steps, models.OperationStep{Name: s})
+	}
+	return op, j.save(op)
+}
+
+// Complete marks the step at the given index as done and persists the
+// operation immediately so progress survives an interruption.
+func (j *SJournal) Complete(op *models.Operation, step int) error {
+	if step < 0 || step >= len(op.Steps) {
+		return fmt.Errorf("Invalid step %d for operation %s", step, op.ID)
+	}
+	op.Steps[step].Done = true
+	return j.save(op)
+}
+
+// Finish removes a completed operation from the journal.
+func (j *SJournal) Finish(op *models.Operation) error {
+	err := os.Remove(j.path(op.ID))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Retrieve loads a single operation by its ID.
+func (j *SJournal) Retrieve(opID string) (*models.Operation, error) {
+	if strings.ContainsAny(opID, "/\\.") {
+		return nil, fmt.Errorf("Invalid operation ID \"%s\"", opID)
+	}
+	b, err := ioutil.ReadFile(j.path(opID))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("Could not find an operation with the ID \"%s\". You can list interrupted operations with the \"datica resume\" command.", opID)
+	} else if err != nil {
+		return nil, err
+	}
+	var op models.Operation
+	err = json.Unmarshal(b, &op)
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// List returns all operations that have not finished, oldest first.
+func (j *SJournal) List() (*[]models.Operation, error) {
+	ops := []models.Operation{}
+	files, err := ioutil.ReadDir(j.Dir)
+	if os.IsNotExist(err) {
+		return &ops, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		op, err := j.Retrieve(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			logrus.Debugf("Skipping unreadable operation %s: %s", f.Name(), err)
+			continue
+		}
+		ops = append(ops, *op)
+	}
+	sort.Sort(SortedOperations(ops))
+	return &ops, nil
+}
+
+func (j *SJournal) save(op *models.Operation) error {
+	if err := os.MkdirAll(j.Dir, 0700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(j.path(op.ID), b, 0600)
+}
+
+func (j *SJournal) path(opID string) string {
+	return filepath.Join(j.Dir, opID+".json")
+}
+
+// SortedOperations is a wrapper for Operation arrays in order to sort them
+// by CreatedAt
+type SortedOperations []models.Operation
+
+func (ops SortedOperations) Len() int {
+	return len(ops)
+}
+
+func (ops SortedOperations) Swap(i, j int) {
+	ops[i], ops[j] = ops[j], ops[i]
+}
+
+func (ops SortedOperations) Less(i, j int) bool {
+	return ops[i].CreatedAt < ops[j].CreatedAt
+}
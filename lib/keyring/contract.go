@@ -0,0 +1,48 @@
+package keyring
+
+import (
+	"errors"
+	"os"
+)
+
+const (
+	// Service is the name secrets are stored under in the keychain of the OS
+	Service = "datica"
+	// BackendEnvVar is the env variable used to choose where secrets are
+	// stored. Set it to "file" to store secrets in CredentialsFile even when
+	// the OS has a keychain.
+	BackendEnvVar = "DATICA_KEYRING"
+	// CredentialsFile is the location of the file secrets are stored in when
+	// the OS keychain can't be used, such as on headless systems.
+	CredentialsFile = ".datica_credentials"
+	// FileBackend is the name of the backend storing secrets in CredentialsFile
+	FileBackend = "file"
+)
+
+// ErrNotFound is returned when no secret is stored under the given key
+var ErrNotFound = errors.New("No secret is stored under the given key")
+
+// IKeyring stores secrets, such as the session token, outside of the
+// settings file. Deleting a key that has no secret stored is not an error.
+type IKeyring interface {
+	Get(key string) (string, error)
+	Set(key, secret string) error
+	Delete(key string) error
+	Name() string
+}
+
+// New returns the keychain of the OS, falling back to CredentialsFile when
+// the OS has no keychain or it can't be used.
+func New() IKeyring {
+	file := NewFile(credentialsPath())
+	if os.Getenv(BackendEnvVar) == FileBackend {
+		return file
+	}
+	if k := platform(); k != nil {
+		return &fallbackKeyring{
+			primary:   k,
+			secondary: file,
+		}
+	}
+	return file
+}
@@ -0,0 +1,46 @@
+package keyring
+
+import "github.com/Sirupsen/logrus"
+
+// fallbackKeyring stores secrets in the primary keyring and only uses the
+// secondary keyring when the primary one fails. Secrets stored in the
+// secondary keyring are moved into the primary keyring the next time they
+// are set.
+type fallbackKeyring struct {
+	primary   IKeyring
+	secondary IKeyring
+}
+
+func (f *fallbackKeyring) Get(key string) (string, error) {
+	secret, err := f.primary.Get(key)
+	if err == nil {
+		return secret, nil
+	}
+	if err != ErrNotFound {
+		logrus.Debugf("Unable to read %s from the %s keyring, trying the %s keyring: %s", key, f.primary.Name(), f.secondary.Name(), err)
+	}
+	return f.secondary.Get(key)
+}
+
+func (f *fallbackKeyring) Set(key, secret string) error {
+	if err := f.primary.Set(key, secret); err != nil {
+		logrus.Debugf("Unable to store %s in the %s keyring, using the %s keyring instead: %s", key, f.primary.Name(), f.secondary.Name(), err)
+		return f.secondary.Set(key, secret)
+	}
+	if err := f.secondary.Delete(key); err != nil {
+		logrus.Debugf("Unable to remove %s from the %s keyring: %s", key, f.secondary.Name(), err)
+	}
+	return nil
+}
+
+func (f *fallbackKeyring) Delete(key string) error {
+	err := f.primary.Delete(key)
+	if secondaryErr := f.secondary.Delete(key); err == nil {
+		err = secondaryErr
+	}
+	return err
+}
+
+func (f *fallbackKeyring) Name() string {
+	return f.primary.Name()
+}
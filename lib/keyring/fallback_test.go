@@ -0,0 +1,96 @@
+package keyring
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// brokenKeyring is a keyring whose every operation fails, like a keychain
+// that is locked or not reachable
+type brokenKeyring struct{}
+
+func (b *brokenKeyring) Get(key string) (string, error) { return "", errors.New("locked") }
+func (b *brokenKeyring) Set(key, secret string) error   { return errors.New("locked") }
+func (b *brokenKeyring) Delete(key string) error        { return errors.New("locked") }
+func (b *brokenKeyring) Name() string                   { return "broken" }
+
+func tempFile(t *testing.T) (IKeyring, func()) {
+	dir, err := ioutil.TempDir("", "keyring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewFile(filepath.Join(dir, CredentialsFile)), func() { os.RemoveAll(dir) }
+}
+
+func TestFile(t *testing.T) {
+	file, teardown := tempFile(t)
+	defer teardown()
+
+	if _, err := file.Get("token"); err != ErrNotFound {
+		t.Errorf("Expected the secret not to be found but got %v", err)
+	}
+	if err := file.Set("token", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(file.(*SFile).Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the credentials file to only be readable by the user but got %s", info.Mode())
+	}
+	if secret, err := file.Get("token"); err != nil || secret != "abc" {
+		t.Errorf("Expected the secret abc but got %s, %v", secret, err)
+	}
+	if err = file.Delete("token"); err != nil {
+		t.Fatal(err)
+	}
+	if err = file.Delete("token"); err != nil {
+		t.Errorf("Expected deleting a missing secret to succeed but got %s", err)
+	}
+	if _, err = os.Stat(file.(*SFile).Path); !os.IsNotExist(err) {
+		t.Errorf("Expected the empty credentials file to be removed")
+	}
+}
+
+func TestFallback(t *testing.T) {
+	primary, teardownPrimary := tempFile(t)
+	defer teardownPrimary()
+	secondary, teardownSecondary := tempFile(t)
+	defer teardownSecondary()
+
+	// a secret stored while the primary keyring failed is moved into it the
+	// next time it is set
+	broken := &fallbackKeyring{primary: &brokenKeyring{}, secondary: secondary}
+	if err := broken.Set("token", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if secret, err := broken.Get("token"); err != nil || secret != "abc" {
+		t.Errorf("Expected the secret from the secondary keyring but got %s, %v", secret, err)
+	}
+	f := &fallbackKeyring{primary: primary, secondary: secondary}
+	if secret, err := f.Get("token"); err != nil || secret != "abc" {
+		t.Errorf("Expected the secret from the secondary keyring but got %s, %v", secret, err)
+	}
+	if err := f.Set("token", "def"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secondary.Get("token"); err != ErrNotFound {
+		t.Errorf("Expected the secret to be moved out of the secondary keyring but got %v", err)
+	}
+	if secret, err := primary.Get("token"); err != nil || secret != "def" {
+		t.Errorf("Expected the secret in the primary keyring but got %s, %v", secret, err)
+	}
+
+	// deleting removes the secret from both keyrings
+	secondary.Set("token", "abc")
+	if err := f.Delete("token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Get("token"); err != ErrNotFound {
+		t.Errorf("Expected the secret to be deleted but got %v", err)
+	}
+}
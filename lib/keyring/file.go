@@ -0,0 +1,96 @@
+package keyring
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/mitchellh/go-homedir"
+)
+
+func credentialsPath() string {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		logrus.Debugf("Error finding the home directory for the credentials file: %s", err)
+		return CredentialsFile
+	}
+	return filepath.Join(homeDir, CredentialsFile)
+}
+
+// SFile is an implementation of IKeyring that stores secrets in a JSON file
+// only readable by the current user.
+type SFile struct {
+	Path string
+}
+
+// NewFile returns an instance of IKeyring storing secrets in the given file
+func NewFile(path string) IKeyring {
+	return &SFile{
+		Path: path,
+	}
+}
+
+func (f *SFile) Get(key string) (string, error) {
+	secrets, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (f *SFile) Set(key, secret string) error {
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = secret
+	return f.write(secrets)
+}
+
+func (f *SFile) Delete(key string) error {
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+	delete(secrets, key)
+	if len(secrets) == 0 {
+		return os.Remove(f.Path)
+	}
+	return f.write(secrets)
+}
+
+func (f *SFile) Name() string {
+	return FileBackend
+}
+
+func (f *SFile) read() (map[string]string, error) {
+	secrets := map[string]string{}
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return secrets, json.Unmarshal(b, &secrets)
+}
+
+func (f *SFile) write(secrets map[string]string) error {
+	b, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(f.Path, b, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of a file that already exists
+	return os.Chmod(f.Path, 0600)
+}
@@ -0,0 +1,99 @@
+// +build darwin
+
+package keyring
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// notFoundExitCode is the exit code of the security tool when no item
+// matches the search
+const notFoundExitCode = 44
+
+// macKeyring stores secrets as generic passwords in the login keychain
+// through the security tool.
+type macKeyring struct{}
+
+func platform() IKeyring {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil
+	}
+	return &macKeyring{}
+}
+
+func (m *macKeyring) Get(key string) (string, error) {
+	out, err := security("find-generic-password", "-s", Service, "-a", key, "-w")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+func (m *macKeyring) Set(key, secret string) error {
+	// the command is read from stdin by the interactive mode of the security
+	// tool so that the secret is never an argument, which every local user
+	// could read from the process list
+	return securityInteractive(addPasswordCommand(key, secret))
+}
+
+func (m *macKeyring) Delete(key string) error {
+	_, err := security("delete-generic-password", "-s", Service, "-a", key)
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func (m *macKeyring) Name() string {
+	return "macOS Keychain"
+}
+
+// addPasswordCommand returns the command of the interactive mode of the
+// security tool that stores the secret under the given key. The secret is hex
+// encoded so that it needs no quoting and can span several lines.
+func addPasswordCommand(key, secret string) string {
+	return fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", quote(Service), quote(key), hex.EncodeToString([]byte(secret)))
+}
+
+// quote quotes an argument of a command of the interactive mode of the
+// security tool
+func quote(arg string) string {
+	arg = strings.Replace(arg, `\`, `\\`, -1)
+	return `"` + strings.Replace(arg, `"`, `\"`, -1) + `"`
+}
+
+// securityInteractive runs the given commands with the interactive mode of
+// the security tool. It exits successfully even when a command fails, so the
+// errors it prints are checked instead.
+func securityInteractive(commands string) error {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(commands)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("security: %s", msg)
+	}
+	return nil
+}
+
+func security(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("security", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == notFoundExitCode {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,24 @@
+// +build darwin
+
+package keyring
+
+import "testing"
+
+var addPasswordCommandTests = []struct {
+	key      string
+	secret   string
+	expected string
+}{
+	{"session_token", "abc", "add-generic-password -U -s \"datica\" -a \"session_token\" -X 616263\n"},
+	{`private_key:/Users/a b/"id"`, "-----BEGIN\nKEY", "add-generic-password -U -s \"datica\" -a \"private_key:/Users/a b/\\\"id\\\"\" -X 2d2d2d2d2d424547494e0a4b4559\n"},
+}
+
+func TestAddPasswordCommand(t *testing.T) {
+	for _, data := range addPasswordCommandTests {
+		t.Logf("Data: %+v", data)
+		actual := addPasswordCommand(data.key, data.secret)
+		if actual != data.expected {
+			t.Errorf("Expected %q but got %q", data.expected, actual)
+		}
+	}
+}
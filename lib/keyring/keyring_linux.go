@@ -0,0 +1,74 @@
+// +build linux
+
+package keyring
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// secretServiceKeyring stores secrets with the Secret Service API, as
+// provided by GNOME Keyring and KWallet, through the secret-tool command.
+type secretServiceKeyring struct{}
+
+func platform() IKeyring {
+	// the Secret Service is only reachable over the session bus, which
+	// headless systems don't have
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil
+	}
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil
+	}
+	return &secretServiceKeyring{}
+}
+
+func (s *secretServiceKeyring) Get(key string) (string, error) {
+	out, err := secretTool("", "lookup", "service", Service, "account", key)
+	if err != nil {
+		return "", err
+	}
+	// secret-tool prints nothing and exits with 1 when nothing matches, which
+	// can't be told apart from other failures by the exit code alone
+	if out == "" {
+		return "", ErrNotFound
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+func (s *secretServiceKeyring) Set(key, secret string) error {
+	_, err := secretTool(secret, "store", "--label", fmt.Sprintf("Datica CLI %s", key), "service", Service, "account", key)
+	return err
+}
+
+func (s *secretServiceKeyring) Delete(key string) error {
+	_, err := secretTool("", "clear", "service", Service, "account", key)
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func (s *secretServiceKeyring) Name() string {
+	return "Secret Service"
+}
+
+// secretTool runs secret-tool with the given input on stdin so that secrets
+// are never passed as arguments
+func secretTool(input string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", args...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stdout.Len() == 0 && stderr.Len() == 0 {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
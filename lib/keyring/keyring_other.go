@@ -0,0 +1,7 @@
+// +build !darwin,!linux,!windows
+
+package keyring
+
+func platform() IKeyring {
+	return nil
+}
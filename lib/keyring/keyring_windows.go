@@ -0,0 +1,178 @@
+// +build windows
+
+package keyring
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	// errorNotFound is returned by CredReadW and CredDeleteW when no
+	// credential has the given target name
+	errorNotFound syscall.Errno = 1168
+	// maxBlobSize is CRED_MAX_CREDENTIAL_BLOB_SIZE, the largest secret a
+	// single credential holds
+	maxBlobSize = 5 * 512
+)
+
+var (
+	advapi32        = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+// credential mirrors the CREDENTIALW struct of wincred.h
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialManager stores secrets as generic credentials in the Windows
+// Credential Manager.
+type credentialManager struct{}
+
+func platform() IKeyring {
+	if err := procCredReadW.Find(); err != nil {
+		return nil
+	}
+	return &credentialManager{}
+}
+
+func (c *credentialManager) Get(key string) (string, error) {
+	secret := []byte{}
+	for part := 0; ; part++ {
+		blob, err := read(partName(key, part))
+		if err == ErrNotFound && part > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		secret = append(secret, blob...)
+		if len(blob) < maxBlobSize {
+			break
+		}
+	}
+	return string(secret), nil
+}
+
+// Set stores the secret in parts of at most maxBlobSize bytes, since that is
+// the largest credential the Credential Manager holds, and removes the parts
+// left over from a longer secret stored before
+func (c *credentialManager) Set(key, secret string) error {
+	blob := []byte(secret)
+	part := 0
+	for ; part == 0 || len(blob) > 0; part++ {
+		n := len(blob)
+		if n > maxBlobSize {
+			n = maxBlobSize
+		}
+		if err := write(partName(key, part), key, blob[:n]); err != nil {
+			return err
+		}
+		blob = blob[n:]
+	}
+	return removeParts(key, part)
+}
+
+func (c *credentialManager) Delete(key string) error {
+	return removeParts(key, 0)
+}
+
+func read(target string) ([]byte, error) {
+	targetPtr, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return nil, err
+	}
+	var cred *credential
+	r, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(targetPtr)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		if err == errorNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	if cred.CredentialBlobSize == 0 {
+		return []byte{}, nil
+	}
+	blob := (*[1 << 20]byte)(unsafe.Pointer(cred.CredentialBlob))[:cred.CredentialBlobSize:cred.CredentialBlobSize]
+	return append([]byte{}, blob...), nil
+}
+
+func write(target, key string, blob []byte) error {
+	targetPtr, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+	userName, err := syscall.UTF16PtrFromString(key)
+	if err != nil {
+		return err
+	}
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         targetPtr,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	r, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// removeParts deletes the parts of the secret stored under key starting with
+// the given part
+func removeParts(key string, from int) error {
+	for part := from; ; part++ {
+		target, err := syscall.UTF16PtrFromString(partName(key, part))
+		if err != nil {
+			return err
+		}
+		r, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+		if r == 0 {
+			if err == errorNotFound {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (c *credentialManager) Name() string {
+	return "Windows Credential Manager"
+}
+
+func targetName(key string) string {
+	return Service + ":" + key
+}
+
+// partName returns the target name of the given part of the secret stored
+// under key. The first part is stored under the target name of the key itself.
+func partName(key string, part int) string {
+	if part == 0 {
+		return targetName(key)
+	}
+	return fmt.Sprintf("%s#%d", targetName(key), part)
+}
@@ -0,0 +1,39 @@
+package probe
+
+import "github.com/daticahealth/cli/models"
+
+// Features served by some pods but not others. Commands made of several API
+// calls leave out what a feature provides when the pod does not serve it.
+const (
+	BuildJobs     = "build jobs"
+	RunningJobs   = "running jobs"
+	WorkerJobs    = "worker jobs"
+	WorkerTargets = "worker targets"
+)
+
+// Expiry is the amount of time in seconds a feature a pod does not serve is
+// remembered before it is probed again. Running `datica clear --pods` forgets
+// them right away.
+const Expiry = 86400
+
+// IProbe keeps track of the features the pod of the current environment does
+// not serve
+type IProbe interface {
+	Available(feature string) bool
+	Check(feature string, err error) error
+	Note()
+}
+
+// SProbe is a concrete implementation of IProbe that remembers the features
+// a pod does not serve in the settings
+type SProbe struct {
+	Settings *models.Settings
+	missing  []string
+}
+
+// New returns an instance of IProbe
+func New(settings *models.Settings) IProbe {
+	return &SProbe{
+		Settings: settings,
+	}
+}
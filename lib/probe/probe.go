@@ -0,0 +1,66 @@
+package probe
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/httpclient"
+)
+
+func (p *SProbe) key(feature string) string {
+	return p.Settings.Pod + "/" + feature
+}
+
+// Available returns whether the feature should be requested from the pod. A
+// feature is unavailable if the pod recently did not serve it.
+func (p *SProbe) Available(feature string) bool {
+	if expires, ok := p.Settings.Unavailable[p.key(feature)]; ok && expires > time.Now().Unix() {
+		p.markMissing(feature)
+		return false
+	}
+	return true
+}
+
+// Check returns the error of a request for the given feature, unless the
+// error is caused by the pod not serving the feature. Such errors are
+// remembered so the feature is not requested again until Expiry passes, and
+// nil is returned so that the caller can go on without the feature.
+func (p *SProbe) Check(feature string, err error) error {
+	if err == nil {
+		delete(p.Settings.Unavailable, p.key(feature))
+		return nil
+	}
+	if !httpclient.IsMissingEndpoint(err) {
+		return err
+	}
+	logrus.Debugf("The %s pod does not serve %s: %s", p.Settings.Pod, feature, err)
+	if p.Settings.Unavailable == nil {
+		p.Settings.Unavailable = map[string]int64{}
+	}
+	p.Settings.Unavailable[p.key(feature)] = time.Now().Unix() + Expiry
+	p.markMissing(feature)
+	return nil
+}
+
+// Note prints the features left out because the pod does not serve them
+func (p *SProbe) Note() {
+	if len(p.missing) == 0 {
+		return
+	}
+	pod := "this pod"
+	if p.Settings.Pod != "" {
+		pod = "the " + p.Settings.Pod + " pod"
+	}
+	logrus.Printf("\nNot shown since %s does not serve them yet: %s", pod, strings.Join(p.missing, ", "))
+	logrus.Printf("Run \"datica clear --pods\" to check for them again once %s is updated", pod)
+}
+
+func (p *SProbe) markMissing(feature string) {
+	for _, f := range p.missing {
+		if f == feature {
+			return
+		}
+	}
+	p.missing = append(p.missing, feature)
+}
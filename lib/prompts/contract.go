@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/daticahealth/cli/config"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
@@ -15,16 +17,53 @@ import (
 // input.
 type IPrompts interface {
 	UsernamePassword() (string, string, error)
-	KeyPassphrase(string) string
-	Password(msg string) string
+	KeyPassphrase(string) (string, error)
+	Password(msg string) (string, error)
 	PHI() error
 	YesNo(msg string) error
-	OTP(string) string
+	OTP(string) (string, error)
+	Select(msg string, options []string) (int, error)
+	ConfirmName(msg, name string) error
 }
 
 // SPrompts is a concrete implementation of IPrompts
 type SPrompts struct{}
 
+// nonInteractive makes every prompt fail instead of waiting for input
+var nonInteractive bool
+
+// assumeYes answers yes to every confirmation without waiting for input
+var assumeYes bool
+
+// SetAssumeYes answers yes to every confirmation, including the PHI warning,
+// without waiting for input. Prompts for credentials are not affected.
+func SetAssumeYes(y bool) {
+	assumeYes = y
+}
+
+// AssumeYes returns whether every confirmation is answered with yes
+func AssumeYes() bool {
+	return assumeYes
+}
+
+// SetNonInteractive makes every prompt fail immediately with an error
+// instead of waiting for input, so scripts never hang
+func SetNonInteractive(n bool) {
+	nonInteractive = n
+}
+
+// NonInteractive returns whether prompts fail instead of waiting for input
+func NonInteractive() bool {
+	return nonInteractive
+}
+
+// nonInteractiveError is returned by a prompt in non-interactive mode. The
+// question is the prompt that would have been shown.
+func nonInteractiveError(question string) error {
+	question = strings.TrimSpace(strings.Replace(question, "(y/n)", "", -1))
+	return fmt.Errorf("Unable to prompt because the CLI is running non-interactively: %s\nRun the command again with --yes to confirm", question)
+}
+
 // New returns a new instance of IPrompts
 func New() IPrompts {
 	return &SPrompts{}
@@ -39,6 +78,9 @@ var validAnswers = map[string]bool{
 
 // UsernamePassword prompts a user to enter their username and password.
 func (p *SPrompts) UsernamePassword() (string, string, error) {
+	if nonInteractive {
+		return "", "", fmt.Errorf("Unable to prompt for your username and password because the CLI is running non-interactively. Set the %s and %s environment variables instead", config.DaticaUsernameEnvVar, config.DaticaPasswordEnvVar)
+	}
 	var username string
 	fmt.Print("Username or Email: ")
 	in := bufio.NewReader(os.Stdin)
@@ -57,16 +99,26 @@ func (p *SPrompts) UsernamePassword() (string, string, error) {
 }
 
 // KeyPassphrase prompts a user to enter a passphrase for a named key.
-func (p *SPrompts) KeyPassphrase(filepath string) string {
+func (p *SPrompts) KeyPassphrase(filepath string) (string, error) {
+	if nonInteractive {
+		return "", fmt.Errorf("Unable to prompt for the passphrase of %s because the CLI is running non-interactively", filepath)
+	}
 	fmt.Printf("Enter passphrase for %s: ", filepath)
 	bytes, _ := terminal.ReadPassword(int(os.Stdin.Fd()))
 	fmt.Println("")
-	return string(bytes)
+	return string(bytes), nil
 }
 
 // PHI prompts a user to accept liability for downloading PHI to their local
-// machine.
+// machine. If confirmations are assumed with SetAssumeYes, nil is returned
+// without prompting.
 func (p *SPrompts) PHI() error {
+	if assumeYes {
+		return nil
+	}
+	if nonInteractive {
+		return nonInteractiveError("This operation might result in PHI data being downloaded and decrypted to your local machine")
+	}
 	var answer string
 	for {
 		fmt.Println("This operation might result in PHI data being downloaded and decrypted to your local machine. By entering \"y\" at the prompt below, you warrant that you have the necessary privileges to view the data, have taken all necessary precautions to secure this data, and absolve Datica of any issues that might arise from its loss.")
@@ -90,11 +142,18 @@ func (p *SPrompts) PHI() error {
 // message SHOULD contain the string "(y/n)" or some other form of y/n
 // indicating that the user needs to type in y or n. This method does not do
 // that for you. The message will not have a new line appended to it. If you
-// require a newline, add this to the given message.
+// require a newline, add this to the given message. If confirmations are
+// assumed with SetAssumeYes, nil is returned without prompting.
 func (p *SPrompts) YesNo(msg string) error {
+	if assumeYes {
+		return nil
+	}
+	if nonInteractive {
+		return nonInteractiveError(msg)
+	}
 	var answer string
 	for {
-		fmt.Printf(msg)
+		fmt.Print(msg)
 		fmt.Scanln(&answer)
 		fmt.Println("")
 		if _, contains := validAnswers[strings.ToLower(answer)]; !contains {
@@ -109,19 +168,41 @@ func (p *SPrompts) YesNo(msg string) error {
 	return nil
 }
 
+// ConfirmName asks the user to type the given name to confirm an action that
+// can't be undone easily. Unlike YesNo, this is not skipped when
+// confirmations are assumed with SetAssumeYes. The message will not have a new
+// line appended to it.
+func (p *SPrompts) ConfirmName(msg, name string) error {
+	if nonInteractive {
+		return fmt.Errorf("Unable to prompt because the CLI is running non-interactively: %s", strings.TrimSpace(msg))
+	}
+	fmt.Print(msg)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	fmt.Println("")
+	if strings.TrimSpace(answer) != name {
+		return fmt.Errorf("\"%s\" does not match \"%s\". Exiting", strings.TrimSpace(answer), name)
+	}
+	return nil
+}
+
 // Password prompts the user for a password displaying the given message.
 // The password will be hidden while typed. A newline is not added to the given
 // message. If a newline is required, it should be part of the passed in string.
-func (p *SPrompts) Password(msg string) string {
+func (p *SPrompts) Password(msg string) (string, error) {
+	if nonInteractive {
+		return "", fmt.Errorf("Unable to prompt because the CLI is running non-interactively: %s", strings.TrimSpace(msg))
+	}
 	fmt.Print(msg)
 	bytes, _ := terminal.ReadPassword(int(os.Stdin.Fd()))
 	fmt.Println("")
-	return string(bytes)
+	return string(bytes), nil
 }
 
 // OTP prompts for a one-time password and returns the value.
-func (p *SPrompts) OTP(preferredMode string) string {
-	fmt.Println("This account has two-factor authentication enabled.")
+func (p *SPrompts) OTP(preferredMode string) (string, error) {
+	if nonInteractive {
+		return "", errors.New("Unable to prompt for a one-time password because the CLI is running non-interactively")
+	}
 	prompt := "Your one-time password: "
 	if preferredMode == "authenticator" {
 		prompt = "Your authenticator one-time password: "
@@ -131,5 +212,80 @@ func (p *SPrompts) OTP(preferredMode string) string {
 	fmt.Print(prompt)
 	var token string
 	fmt.Scanln(&token)
-	return strings.TrimSpace(token)
+	return strings.TrimSpace(token), nil
+}
+
+// maxSelectOptions is the number of options listed at once by Select. More
+// options are narrowed down by typing a filter.
+const maxSelectOptions = 20
+
+// Select lists the given options in a numbered menu and returns the index of
+// the one chosen by the user. Instead of a number, the user can type part of
+// an option to fuzzy filter the menu, and pressing enter picks the only
+// option left.
+func (p *SPrompts) Select(msg string, options []string) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("There is nothing to choose from")
+	}
+	if nonInteractive {
+		return -1, fmt.Errorf("Unable to prompt because the CLI is running non-interactively: %s", msg)
+	}
+	in := bufio.NewReader(os.Stdin)
+	matches := fuzzyFilter("", options)
+	for {
+		fmt.Println(msg)
+		for i, match := range matches {
+			if i == maxSelectOptions {
+				fmt.Printf("  ...and %d more, type to filter\n", len(matches)-maxSelectOptions)
+				break
+			}
+			fmt.Printf("  %d) %s\n", i+1, options[match])
+		}
+		fmt.Print("Enter a number, or type to filter: ")
+		answer, err := in.ReadString('\n')
+		if err != nil {
+			return -1, errors.New("Exiting")
+		}
+		answer = strings.TrimSpace(answer)
+		fmt.Println("")
+		if answer == "" {
+			if len(matches) == 1 {
+				return matches[0], nil
+			}
+			matches = fuzzyFilter("", options)
+			continue
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n > 0 && n <= len(matches) && n <= maxSelectOptions {
+			return matches[n-1], nil
+		}
+		filtered := fuzzyFilter(answer, options)
+		if len(filtered) == 0 {
+			fmt.Printf("Nothing matches \"%s\"\n", answer)
+			continue
+		}
+		matches = filtered
+	}
+}
+
+// fuzzyFilter returns the indexes of the options that contain every character
+// of the pattern in order, ignoring case
+func fuzzyFilter(pattern string, options []string) []int {
+	pattern = strings.ToLower(pattern)
+	matches := []int{}
+	for i, option := range options {
+		rest := strings.ToLower(option)
+		matched := true
+		for _, c := range pattern {
+			idx := strings.IndexRune(rest, c)
+			if idx < 0 {
+				matched = false
+				break
+			}
+			rest = rest[idx+len(string(c)):]
+		}
+		if matched {
+			matches = append(matches, i)
+		}
+	}
+	return matches
 }
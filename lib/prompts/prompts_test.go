@@ -0,0 +1,28 @@
+package prompts
+
+import "testing"
+
+var nonInteractiveTests = []struct {
+	name   string
+	prompt func(p IPrompts) error
+}{
+	{"UsernamePassword", func(p IPrompts) error { _, _, err := p.UsernamePassword(); return err }},
+	{"KeyPassphrase", func(p IPrompts) error { _, err := p.KeyPassphrase("id_rsa"); return err }},
+	{"Password", func(p IPrompts) error { _, err := p.Password("Password: "); return err }},
+	{"OTP", func(p IPrompts) error { _, err := p.OTP("authenticator"); return err }},
+	{"PHI", func(p IPrompts) error { return p.PHI() }},
+	{"YesNo", func(p IPrompts) error { return p.YesNo("Are you sure? (y/n) ") }},
+	{"ConfirmName", func(p IPrompts) error { return p.ConfirmName("Type the name: ", "env") }},
+	{"Select", func(p IPrompts) error { _, err := p.Select("Pick one", []string{"a", "b"}); return err }},
+}
+
+func TestNonInteractive(t *testing.T) {
+	SetNonInteractive(true)
+	defer SetNonInteractive(false)
+	for _, data := range nonInteractiveTests {
+		t.Logf("Data: %s", data.name)
+		if err := data.prompt(New()); err == nil {
+			t.Errorf("Expected %s to fail instead of prompting", data.name)
+		}
+	}
+}
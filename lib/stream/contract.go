@@ -0,0 +1,45 @@
+package stream
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/daticahealth/cli/models"
+)
+
+// ErrUnsupported is returned when the pod of the associated environment does
+// not support streaming. Callers should fall back to polling.
+var ErrUnsupported = errors.New("Streaming is not supported by this pod")
+
+// IStream subscribes to streaming endpoints of the PaaS host and other
+// websockets. A subscription holds a single long-lived connection open
+// instead of polling, and reconnects on its own when the connection drops.
+type IStream interface {
+	Supported() bool
+	Subscribe(path string, handle func(msg []byte) error) error
+	Follow(urlString string, headers func() http.Header, cb Callbacks, handle func(msg []byte) error) error
+}
+
+// Callbacks are called by Follow as the connection of a stream is lost and
+// opened again. Either may be nil.
+type Callbacks struct {
+	// Lost is called when the connection drops with the time until it is
+	// opened again
+	Lost func(wait time.Duration)
+	// Reconnected is called once the connection is opened again
+	Reconnected func()
+}
+
+// SStream is a concrete implementation of IStream that streams over a
+// websocket
+type SStream struct {
+	Settings *models.Settings
+}
+
+// New returns an instance of IStream
+func New(settings *models.Settings) IStream {
+	return &SStream{
+		Settings: settings,
+	}
+}
@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/gorilla/websocket"
+)
+
+const writeTimeout = 10 * time.Second
+
+// reconnectTime is the wait before the first attempt to reopen a dropped
+// stream
+var reconnectTime = config.LogReconnectTime * time.Second
+
+// Supported returns whether the pod of the associated environment advertises
+// support for streaming endpoints
+func (s *SStream) Supported() bool {
+	if s.Settings.Pods == nil {
+		return false
+	}
+	for _, p := range *s.Settings.Pods {
+		if p.Name == s.Settings.Pod {
+			return p.Streaming
+		}
+	}
+	return false
+}
+
+// Subscribe opens a stream to the given path of the PaaS host and calls handle
+// with every message received, as Follow does. ErrUnsupported is returned if
+// the pod does not support streaming.
+func (s *SStream) Subscribe(path string, handle func(msg []byte) error) error {
+	if !s.Supported() {
+		return ErrUnsupported
+	}
+	urlString := s.Settings.PaasHost + s.Settings.PaasHostVersion + path
+	urlString = strings.Replace(strings.Replace(urlString, "https://", "wss://", 1), "http://", "ws://", 1)
+	// the headers are generated for every connection, since the signed
+	// nonce and timestamp in them cannot be used again
+	headers := func() http.Header {
+		headers := http.Header{}
+		for k, v := range s.Settings.HTTPManager.GetHeaders(s.Settings.SessionToken, s.Settings.Version, s.Settings.Pod, s.Settings.UsersID) {
+			headers[k] = v
+		}
+		// the websocket handshake sets its own content headers
+		headers.Del("Content-Type")
+		headers.Del("Accept")
+		return headers
+	}
+	return s.Follow(urlString, headers, Callbacks{}, handle)
+}
+
+// Follow opens a websocket to the given URL with the headers returned by
+// headers and calls handle with every message received. The connection is
+// made with the TLS configuration of the HTTPManager, so a client certificate
+// and SKIP_VERIFY apply to it as well. If the connection drops, it is reopened
+// with an increasing backoff and new headers. Follow returns when handle
+// returns an error, when the websocket cannot be opened in the first place, or
+// when reopening it is refused with a 4xx status code, such as for a session
+// that expired in the meantime.
+func (s *SStream) Follow(urlString string, headers func() http.Header, cb Callbacks, handle func(msg []byte) error) error {
+	dialer := &websocket.Dialer{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: httpclient.TLSConfig(s.Settings.HTTPManager),
+	}
+
+	backoff := reconnectTime
+	connected := false
+	for {
+		c, resp, err := dialer.Dial(urlString, headers())
+		if err != nil && resp != nil && resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			body, _ := ioutil.ReadAll(resp.Body)
+			return s.Settings.HTTPManager.ConvertResp(body, resp.StatusCode, nil)
+		}
+		if err != nil && !connected {
+			return err
+		}
+		if err != nil {
+			logrus.Debugf("Error reconnecting to %s: %s", urlString, err)
+		} else {
+			if connected && cb.Reconnected != nil {
+				cb.Reconnected()
+			}
+			connected = true
+			backoff = reconnectTime
+			err = read(c, handle)
+			c.Close()
+			if err != nil {
+				return err
+			}
+		}
+		logrus.Debugf("Lost connection to %s, reconnecting in %s", urlString, backoff)
+		if cb.Lost != nil {
+			cb.Lost(backoff)
+		}
+		time.Sleep(backoff)
+		backoff = NextBackoff(backoff)
+	}
+}
+
+// NextBackoff returns the wait before the next attempt to reconnect, which is
+// twice the given wait up to LogReconnectMaxTime
+func NextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > config.LogReconnectMaxTime*time.Second {
+		backoff = config.LogReconnectMaxTime * time.Second
+	}
+	return backoff
+}
+
+// read passes messages to handle until the connection drops, which is not an
+// error, or handle returns an error
+func read(c *websocket.Conn, handle func(msg []byte) error) error {
+	c.SetPingHandler(func(string) error {
+		c.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return c.WriteMessage(websocket.PongMessage, []byte{})
+	})
+	for {
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			logrus.Debugf("Error reading from stream: %s", err)
+			return nil
+		}
+		if err = handle(msg); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package stream
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/models"
+	"github.com/gorilla/websocket"
+)
+
+var errStop = errors.New("stop")
+
+// nonces are the request nonces sent by every connection to a stream server
+var nonces []string
+
+// streamServer returns a websocket server that sends the given messages on
+// each connection and then drops it
+func streamServer(t *testing.T, tls bool, connections *int, messages ...string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Expected the session token to be sent but got %v", r.Header)
+		}
+		nonces = append(nonces, r.Header.Get("X-Request-Nonce"))
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			return
+		}
+		*connections++
+		for _, msg := range messages {
+			c.WriteMessage(websocket.TextMessage, []byte(msg))
+		}
+		c.Close()
+	})
+	if tls {
+		return httptest.NewTLSServer(handler)
+	}
+	return httptest.NewServer(handler)
+}
+
+func streamSettings(url string, streaming bool) *models.Settings {
+	return &models.Settings{
+		PaasHost:    url,
+		Pod:         "pod01",
+		Pods:        &[]models.Pod{{Name: "pod01", Streaming: streaming}},
+		HTTPManager: httpclient.NewTLSHTTPManager(true),
+	}
+}
+
+func TestSupported(t *testing.T) {
+	settings := streamSettings("", true)
+	if !New(settings).Supported() {
+		t.Errorf("Expected streaming to be supported")
+	}
+	settings.Pod = "pod02"
+	if New(settings).Supported() {
+		t.Errorf("Expected streaming not to be supported by an unknown pod")
+	}
+	settings.Pods = nil
+	if New(settings).Supported() {
+		t.Errorf("Expected streaming not to be supported without pods")
+	}
+}
+
+func TestSubscribeUnsupported(t *testing.T) {
+	err := New(streamSettings("https://localhost", false)).Subscribe("/stream", func(msg []byte) error {
+		return nil
+	})
+	if err != ErrUnsupported {
+		t.Errorf("Expected %s but got %v", ErrUnsupported, err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	defer func(wait time.Duration) { reconnectTime = wait }(reconnectTime)
+	reconnectTime = time.Millisecond
+	nonces = nil
+	connections := 0
+	server := streamServer(t, true, &connections, "first", "second")
+	defer server.Close()
+	settings := streamSettings(server.URL, true)
+	settings.SessionToken = "token"
+
+	// the server drops the connection after two messages, so the last
+	// message is received after reconnecting
+	received := []string{}
+	err := New(settings).Subscribe("/stream", func(msg []byte) error {
+		received = append(received, string(msg))
+		if len(received) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("Expected the error of the handler but got %v", err)
+	}
+	if strings.Join(received, ",") != "first,second,first" {
+		t.Errorf("Expected the messages of both connections but got %v", received)
+	}
+	if connections != 2 {
+		t.Errorf("Expected 2 connections but got %d", connections)
+	}
+	if len(nonces) != 2 || nonces[0] == "" || nonces[0] == nonces[1] {
+		t.Errorf("Expected a new nonce for every connection but got %v", nonces)
+	}
+}
+
+func TestFollowCallbacks(t *testing.T) {
+	defer func(wait time.Duration) { reconnectTime = wait }(reconnectTime)
+	reconnectTime = time.Millisecond
+	connections := 0
+	server := streamServer(t, false, &connections, "message")
+	defer server.Close()
+	headers := func() http.Header {
+		return http.Header{"Authorization": {"Bearer token"}}
+	}
+
+	lost, reconnected := []time.Duration{}, 0
+	received := 0
+	err := New(streamSettings("", false)).Follow(strings.Replace(server.URL, "http://", "ws://", 1), headers, Callbacks{
+		Lost:        func(wait time.Duration) { lost = append(lost, wait) },
+		Reconnected: func() { reconnected++ },
+	}, func(msg []byte) error {
+		received++
+		if received == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("Expected the error of the handler but got %v", err)
+	}
+	if reconnected != 2 || len(lost) != 2 {
+		t.Errorf("Expected 2 reconnects after 2 lost connections but got %d after %v", reconnected, lost)
+	}
+	for _, wait := range lost {
+		if wait != reconnectTime {
+			t.Errorf("Expected the backoff to be reset after reconnecting but got %s", wait)
+		}
+	}
+}
+
+func TestFollowDialError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	err := New(streamSettings("", false)).Follow(strings.Replace(server.URL, "http://", "ws://", 1), func() http.Header { return http.Header{} }, Callbacks{}, func(msg []byte) error {
+		return nil
+	})
+	if err == nil {
+		t.Errorf("Expected an error when the stream cannot be opened")
+	}
+}
+
+func TestFollowReconnectRejected(t *testing.T) {
+	defer func(wait time.Duration) { reconnectTime = wait }(reconnectTime)
+	reconnectTime = time.Millisecond
+	connections := 0
+	server := streamServer(t, false, &connections, "message")
+	defer server.Close()
+	// the session expires after the first connection
+	headers := func() http.Header {
+		if connections > 0 {
+			return http.Header{}
+		}
+		return http.Header{"Authorization": {"Bearer token"}}
+	}
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			http.Error(w, "expired", http.StatusUnauthorized)
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer rejecting.Close()
+
+	err := New(streamSettings("", false)).Follow(strings.Replace(rejecting.URL, "http://", "ws://", 1), headers, Callbacks{}, func(msg []byte) error {
+		return nil
+	})
+	apiErr, ok := err.(*httpclient.APIError)
+	if !ok || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected the rejected reconnect to be returned but got %v", err)
+	}
+	if connections != 1 {
+		t.Errorf("Expected 1 connection but got %d", connections)
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	max := config.LogReconnectMaxTime * time.Second
+	tests := []struct {
+		backoff  time.Duration
+		expected time.Duration
+	}{
+		{time.Second, 2 * time.Second},
+		{4 * time.Second, 8 * time.Second},
+		{max / 2, max},
+		{max, max},
+	}
+	for _, data := range tests {
+		t.Logf("Data: %+v", data)
+		if actual := NextBackoff(data.backoff); actual != data.expected {
+			t.Errorf("Expected %s but got %s", data.expected, actual)
+		}
+	}
+}
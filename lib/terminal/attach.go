@@ -0,0 +1,201 @@
+package terminal
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/models"
+	"github.com/docker/docker/pkg/term"
+	"golang.org/x/net/websocket"
+)
+
+// ctrlC is the byte a terminal sends for ctrl-C
+const ctrlC = 0x03
+
+// controlMessage is sent to the remote PTY in a binary frame, which keeps it
+// apart from input that is always sent in text frames
+type controlMessage struct {
+	Type string `json:"type"`
+	Cols uint16 `json:"cols"`
+	Rows uint16 `json:"rows"`
+}
+
+// proxy returns the proxy for requests to the given URL, which is read from
+// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+var proxy = http.ProxyFromEnvironment
+
+// Dial opens a websocket connection to the remote PTY at the given ws or wss
+// URL with the given headers. The connection is made through the proxy set in
+// the environment and with the TLS configuration of the HTTPManager, so a
+// client certificate and SKIP_VERIFY apply to it as well.
+func Dial(url string, header http.Header, m models.HTTPManager) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(url, "ws://localhost:9443/")
+	if err != nil {
+		return nil, err
+	}
+	config.TlsConfig = httpclient.TLSConfig(m)
+	for key, values := range header {
+		config.Header[key] = values
+	}
+	conn, err := dial(config)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// dial opens the connection to the host of the websocket, tunneled through
+// the proxy for its URL if there is one
+func dial(config *websocket.Config) (net.Conn, error) {
+	location := *config.Location
+	addr := location.Host
+	switch location.Scheme {
+	case "ws":
+		location.Scheme = "http"
+	case "wss":
+		location.Scheme = "https"
+	default:
+		return nil, websocket.ErrBadScheme
+	}
+	if location.Port() == "" {
+		addr = net.JoinHostPort(location.Hostname(), map[string]string{"http": "80", "https": "443"}[location.Scheme])
+	}
+	proxyURL, err := proxy(&http.Request{URL: &location})
+	if err != nil {
+		return nil, err
+	}
+	var conn net.Conn
+	if proxyURL == nil {
+		conn, err = net.Dial("tcp", addr)
+	} else {
+		conn, err = connect(proxyURL, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if location.Scheme == "http" {
+		return conn, nil
+	}
+	tlsConfig := config.TlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = location.Hostname()
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err = tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// connect opens a tunnel to the given address through the given HTTP proxy
+func connect(proxyURL *url.URL, addr string) (net.Conn, error) {
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "80")
+	}
+	conn, err := net.Dial("tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if proxyURL.User != nil {
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(proxyURL.User.String())))
+	}
+	if err = req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("The proxy %s refused to connect to %s: %s", proxyAddr, addr, resp.Status)
+	}
+	return conn, nil
+}
+
+// Attach connects the terminal to the remote PTY on the other end of the
+// websocket until either side closes the connection. The terminal is in raw
+// mode while attached, so keys such as ctrl-C are handled by the remote PTY
+// rather than the CLI, and the remote PTY is resized along with the terminal.
+func (t *Terminal) Attach(ws *websocket.Conn) error {
+	oldState, err := term.MakeRaw(t.fdIn)
+	if err != nil {
+		return err
+	}
+	defer term.RestoreTerminal(t.fdIn, oldState)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	if size, err := t.Size(); err == nil {
+		resize(ws, size)
+	}
+	go watchResize(t, stop, func(size *Size) {
+		resize(ws, size)
+	})
+
+	// interrupts that do not come from the keyboard, such as kill -INT, are
+	// passed on as ctrl-C as well
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	go func() {
+		for {
+			select {
+			case <-interrupts:
+				ws.Write([]byte{ctrlC})
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(t.out, ws)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(ws, t.in)
+		done <- err
+	}()
+	return <-done
+}
+
+// resize tells the remote PTY the new size of the terminal
+func resize(ws *websocket.Conn, size *Size) {
+	b, err := json.Marshal(controlMessage{Type: "resize", Cols: size.Cols, Rows: size.Rows})
+	if err != nil {
+		return
+	}
+	if err = websocket.Message.Send(ws, b); err != nil {
+		logrus.Debugf("Unable to resize the remote terminal to %dx%d: %s", size.Cols, size.Rows, err)
+	}
+}
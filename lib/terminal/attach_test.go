@@ -0,0 +1,165 @@
+package terminal
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/daticahealth/cli/lib/httpclient"
+	"golang.org/x/net/websocket"
+)
+
+// ptyServer returns a websocket server that checks the console token and
+// echoes every frame back
+func ptyServer(t *testing.T, tls bool) *httptest.Server {
+	handler := websocket.Handler(func(ws *websocket.Conn) {
+		if token := ws.Request().Header.Get("X-Console-Token"); token != "token" {
+			t.Errorf("Expected the console token to be sent but got %s", token)
+		}
+		io.Copy(ws, ws)
+	})
+	if tls {
+		return httptest.NewTLSServer(handler)
+	}
+	return httptest.NewServer(handler)
+}
+
+// proxyServer returns an HTTP proxy that tunnels CONNECT requests and counts
+// them
+func proxyServer(t *testing.T, tunnels *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			t.Errorf("Expected a CONNECT request but got %s", r.Method)
+			http.Error(w, "", http.StatusMethodNotAllowed)
+			return
+		}
+		if auth := r.Header.Get("Proxy-Authorization"); auth != "Basic dXNlcjpwYXNz" {
+			t.Errorf("Expected the proxy credentials to be sent but got %s", auth)
+		}
+		target, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		*tunnels++
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			return
+		}
+		io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+		go io.Copy(target, conn)
+		io.Copy(conn, target)
+		conn.Close()
+		target.Close()
+	}))
+}
+
+func TestDial(t *testing.T) {
+	tunnels := 0
+	proxy := proxyServer(t, &tunnels)
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+	proxyURL.User = url.UserPassword("user", "pass")
+
+	tests := []struct {
+		tls   bool
+		proxy bool
+	}{
+		{false, false},
+		{true, false},
+		{false, true},
+		{true, true},
+	}
+	for _, data := range tests {
+		t.Logf("Data: %+v", data)
+		server := ptyServer(t, data.tls)
+		withProxy(func(*http.Request) (*url.URL, error) {
+			if data.proxy {
+				return proxyURL, nil
+			}
+			return nil, nil
+		}, func() {
+			tunnels = 0
+			url := strings.Replace(server.URL, "http", "ws", 1)
+			// the test server is only trusted when verification is skipped
+			ws, err := Dial(url, http.Header{"X-Console-Token": {"token"}}, httpclient.NewTLSHTTPManager(true))
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+			defer ws.Close()
+			if err = websocket.Message.Send(ws, "hello"); err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			var msg string
+			if err = websocket.Message.Receive(ws, &msg); err != nil || msg != "hello" {
+				t.Errorf("Expected the message to be echoed but got %s, %v", msg, err)
+			}
+			if data.proxy != (tunnels == 1) {
+				t.Errorf("Expected the connection to be made through the proxy: %t, tunnels: %d", data.proxy, tunnels)
+			}
+		})
+		server.Close()
+	}
+}
+
+func TestDialVerifiesTLS(t *testing.T) {
+	server := ptyServer(t, true)
+	defer server.Close()
+	url := strings.Replace(server.URL, "http", "ws", 1)
+	if _, err := Dial(url, http.Header{"X-Console-Token": {"token"}}, httpclient.NewTLSHTTPManager(false)); err == nil {
+		t.Errorf("Expected an untrusted certificate to be rejected")
+	}
+}
+
+func TestDialProxyRefused(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "", http.StatusForbidden)
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+	withProxy(http.ProxyURL(proxyURL), func() {
+		_, err := Dial("wss://console.example.com/", http.Header{}, httpclient.NewTLSHTTPManager(false))
+		if err == nil || !strings.Contains(err.Error(), "403") {
+			t.Errorf("Expected the refusal of the proxy but got %v", err)
+		}
+	})
+}
+
+func TestResize(t *testing.T) {
+	frames := make(chan []byte, 1)
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var b []byte
+		if err := websocket.Message.Receive(ws, &b); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		frames <- b
+	}))
+	defer server.Close()
+	ws, err := websocket.Dial(strings.Replace(server.URL, "http", "ws", 1), "", "http://localhost/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	resize(ws, &Size{Cols: 120, Rows: 40})
+	var msg controlMessage
+	if err = json.Unmarshal(<-frames, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg != (controlMessage{Type: "resize", Cols: 120, Rows: 40}) {
+		t.Errorf("Expected a resize to 120x40 but got %+v", msg)
+	}
+}
+
+func withProxy(p func(*http.Request) (*url.URL, error), f func()) {
+	defer func(p func(*http.Request) (*url.URL, error)) { proxy = p }(proxy)
+	proxy = p
+	f()
+}
@@ -0,0 +1,53 @@
+package terminal
+
+import (
+	"errors"
+	"io"
+	"runtime"
+
+	"github.com/docker/docker/pkg/term"
+)
+
+// Size is the number of columns and rows of a terminal
+type Size struct {
+	Cols uint16 `json:"cols"`
+	Rows uint16 `json:"rows"`
+}
+
+// Terminal is the local terminal a remote PTY is attached to
+type Terminal struct {
+	in    io.ReadCloser
+	out   io.Writer
+	fdIn  uintptr
+	fdOut uintptr
+}
+
+// New returns the terminal of the standard streams. An error is returned if
+// stdin is not a terminal.
+func New() (*Terminal, error) {
+	stdin, stdout, _ := term.StdStreams()
+	fdIn, isTermIn := term.GetFdInfo(stdin)
+	if !isTermIn {
+		return nil, errors.New("StdIn is not a terminal")
+	}
+	fdOut, _ := term.GetFdInfo(stdout)
+	return &Terminal{
+		in:    stdin,
+		out:   stdout,
+		fdIn:  fdIn,
+		fdOut: fdOut,
+	}, nil
+}
+
+// Size returns the current size of the terminal
+func (t *Terminal) Size() (*Size, error) {
+	fd := t.fdIn
+	if runtime.GOOS == "windows" {
+		fd = t.fdOut
+	}
+	size, err := term.GetWinsize(fd)
+	if err != nil {
+		return nil, err
+	}
+	return &Size{Cols: size.Width, Rows: size.Height}, nil
+}
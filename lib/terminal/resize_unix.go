@@ -0,0 +1,27 @@
+// +build !windows
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize calls onResize with the new size of the terminal every time it
+// is resized until stop is closed
+func watchResize(t *Terminal, stop chan struct{}, onResize func(*Size)) {
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+	for {
+		select {
+		case <-winch:
+			if size, err := t.Size(); err == nil {
+				onResize(size)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
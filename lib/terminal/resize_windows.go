@@ -0,0 +1,30 @@
+// +build windows
+
+package terminal
+
+import "time"
+
+// resizePollInterval is how often the size of the console is checked, since
+// Windows does not signal resizes
+const resizePollInterval = 250 * time.Millisecond
+
+// watchResize calls onResize with the new size of the terminal every time it
+// is resized until stop is closed
+func watchResize(t *Terminal, stop chan struct{}, onResize func(*Size)) {
+	last, _ := t.Size()
+	ticker := time.NewTicker(resizePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			size, err := t.Size()
+			if err != nil || (last != nil && *size == *last) {
+				continue
+			}
+			last = size
+			onResize(size)
+		case <-stop:
+			return
+		}
+	}
+}
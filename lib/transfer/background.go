@@ -0,0 +1,52 @@
+package transfer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/daticahealth/cli/config"
+	"github.com/mitchellh/go-homedir"
+)
+
+// startBackground runs the command again in a background process, and is
+// replaced in tests
+var startBackground = startInBackground
+
+// startInBackground runs the command again with the same arguments in a
+// process that outlives this one and waits for the schedule window to open.
+// Nothing can be prompted for in the background, so the process is
+// non-interactive and the confirmations already answered in this process are
+// answered with yes. The output is appended to the schedule log file, and the
+// PID of the process and the path of the log file are returned.
+func startInBackground() (int, string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, "", err
+	}
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return 0, "", err
+	}
+	logPath := filepath.Join(homeDir, config.ScheduleLogFile)
+	log, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, "", err
+	}
+	defer log.Close()
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(),
+		config.ScheduleWaitEnvVar+"=true",
+		config.NonInteractiveEnvVar+"=true",
+		config.AssumeYesEnvVar+"=true",
+	)
+	cmd.Stdout = log
+	cmd.Stderr = log
+	cmd.SysProcAttr = detachedProcAttr()
+	if err = cmd.Start(); err != nil {
+		return 0, "", err
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+	return pid, logPath, nil
+}
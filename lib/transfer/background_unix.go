@@ -0,0 +1,11 @@
+// +build !windows
+
+package transfer
+
+import "syscall"
+
+// detachedProcAttr starts the background process in a new session, so that it
+// is not stopped along with the terminal it was started from
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,15 @@
+// +build windows
+
+package transfer
+
+import "syscall"
+
+// detachedProcess is the DETACHED_PROCESS creation flag, which starts a
+// process without the console of its parent
+const detachedProcess = 0x00000008
+
+// detachedProcAttr starts the background process without a console, so that
+// it is not stopped along with the console it was started from
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcess}
+}
@@ -0,0 +1,102 @@
+package transfer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var rateRegex = regexp.MustCompile(`(?i)^([0-9]+(?:\.[0-9]+)?)\s*([kmg]?)(?:i?b)?(?:/s)?$`)
+
+// limit is the token bucket every transfer draws from, nil when transfers are
+// not rate limited
+var limit struct {
+	sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// ParseRate parses a transfer rate in bytes per second such as "500K", "5M",
+// or "1G". Suffixes are powers of 1024. An empty rate means no limit.
+func ParseRate(rate string) (ByteSize, error) {
+	if rate == "" {
+		return 0, nil
+	}
+	n, ok := parseByteSize(rate)
+	if !ok {
+		return 0, fmt.Errorf("Invalid rate \"%s\". Rates are given in bytes per second with an optional K, M, or G suffix, such as 500K or 5M", rate)
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("Invalid rate \"%s\", the rate must be at least 1 byte per second", rate)
+	}
+	return n, nil
+}
+
+// ParseSize parses a size in bytes such as "500M" or "20G". Suffixes are
+// powers of 1024.
+func ParseSize(size string) (ByteSize, error) {
+	n, ok := parseByteSize(size)
+	if !ok {
+		return 0, fmt.Errorf("Invalid size \"%s\". Sizes are given in bytes with an optional K, M, or G suffix, such as 500M or 20G", size)
+	}
+	return n, nil
+}
+
+func parseByteSize(size string) (ByteSize, bool) {
+	matches := rateRegex.FindStringSubmatch(strings.TrimSpace(size))
+	if matches == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	switch strings.ToUpper(matches[2]) {
+	case "K":
+		n *= float64(KB)
+	case "M":
+		n *= float64(MB)
+	case "G":
+		n *= float64(GB)
+	}
+	return ByteSize(n), true
+}
+
+// SetRateLimit limits every upload and download to the given number of bytes
+// per second, shared between concurrent transfers. A rate of 0 removes the
+// limit.
+func SetRateLimit(rate ByteSize) {
+	limit.Lock()
+	defer limit.Unlock()
+	limit.rate = float64(rate)
+	limit.tokens = float64(rate)
+	limit.last = time.Now()
+}
+
+// throttle blocks until n more bytes may be transferred without exceeding the
+// rate limit. The bucket holds at most one second worth of bytes, so a
+// transfer can burst briefly but never sustains more than the limit.
+func throttle(n int) {
+	limit.Lock()
+	if limit.rate <= 0 {
+		limit.Unlock()
+		return
+	}
+	now := time.Now()
+	limit.tokens += now.Sub(limit.last).Seconds() * limit.rate
+	if limit.tokens > limit.rate {
+		limit.tokens = limit.rate
+	}
+	limit.last = now
+	limit.tokens -= float64(n)
+	var wait time.Duration
+	if limit.tokens < 0 {
+		wait = time.Duration(-limit.tokens / limit.rate * float64(time.Second))
+	}
+	limit.Unlock()
+	time.Sleep(wait)
+}
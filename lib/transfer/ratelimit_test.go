@@ -0,0 +1,63 @@
+package transfer
+
+import (
+	"testing"
+	"time"
+)
+
+var parseRateTests = []struct {
+	rate      string
+	expected  ByteSize
+	expectErr bool
+}{
+	{"", 0, false},
+	{"500", 500, false},
+	{"500K", 500 * KB, false},
+	{"5m", 5 * MB, false},
+	{"1G", GB, false},
+	{"1.5M", ByteSize(1.5 * float64(MB)), false},
+	{"5MB/s", 5 * MB, false},
+	{"5MiB", 5 * MB, false},
+	{" 5M ", 5 * MB, false},
+	{"0", 0, true},
+	{"0.5", 0, true},
+	{"-5M", 0, true},
+	{"5T", 0, true},
+	{"fast", 0, true},
+}
+
+func TestParseRate(t *testing.T) {
+	for _, data := range parseRateTests {
+		t.Logf("Data: %+v", data)
+		rate, err := ParseRate(data.rate)
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if rate != data.expected {
+			t.Errorf("Expected %s but got %s", data.expected, rate)
+		}
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	defer SetRateLimit(0)
+
+	SetRateLimit(0)
+	start := time.Now()
+	throttle(int(GB))
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Expected no wait without a limit but waited %s", elapsed)
+	}
+
+	SetRateLimit(10 * KB)
+	start = time.Now()
+	throttle(int(10 * KB))
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Expected the first second of bytes to burst but waited %s", elapsed)
+	}
+	throttle(int(2 * KB))
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Expected to wait about 200ms once the bucket was empty but waited %s", elapsed)
+	}
+}
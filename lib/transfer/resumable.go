@@ -0,0 +1,239 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// ResumableDownload downloads a file in one or more HTTP range requests,
+// appending to a partial file on disk so that an interrupted download can
+// pick up where it left off instead of starting from zero. The ETag of the
+// file is kept next to the partial file so that a download is only resumed
+// if the file has not changed since it started.
+type ResumableDownload struct {
+	length   uint64
+	written  uint64
+	path     string
+	etag     string
+	truncate bool
+	client   *http.Client
+}
+
+// NewResumableDownload instantiates a ResumableDownload that writes to the
+// partial file at the given path
+func NewResumableDownload(path string) *ResumableDownload {
+	rd := new(ResumableDownload)
+	rd.path = path
+	rd.client = http.DefaultClient
+	return rd
+}
+
+// Open requests the remainder of the file from url, starting at the current
+// size of the partial file. The returned body must be passed to Append. A nil
+// body is returned if the partial file already holds the entire file. If
+// Open or Append fail, Open can be called again, with a fresh url if the
+// previous one expired, to resume the download. If the partial file does not
+// match the file at url, it is discarded and the download starts over.
+func (rd *ResumableDownload) Open(url string) (io.ReadCloser, error) {
+	var offset int64
+	if info, err := os.Stat(rd.path); err == nil {
+		offset = info.Size()
+	}
+	if offset > 0 && rd.etag == "" {
+		if etag, err := ioutil.ReadFile(rd.etagPath()); err == nil {
+			rd.etag = string(etag)
+		}
+	}
+	if offset > 0 && rd.etag == "" {
+		// without an ETag there is no telling whether the partial file
+		// belongs to the same version of the file
+		return rd.restart(url)
+	}
+	atomic.StoreUint64(&rd.written, uint64(offset))
+	rd.truncate = false
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	if offset > 0 {
+		// the whole file is sent instead of the range if it changed
+		req.Header.Set("If-Range", rd.etag)
+	}
+	resp, err := rd.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, total, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if start != offset {
+			resp.Body.Close()
+			if offset == 0 {
+				return nil, fmt.Errorf("Failed to download file - received a range starting at byte %d instead of the beginning of the file", start)
+			}
+			return rd.restart(url)
+		}
+		atomic.StoreUint64(&rd.length, uint64(total))
+	case http.StatusOK:
+		// the server does not support ranges or the file changed so the
+		// whole file is being sent
+		total, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		atomic.StoreUint64(&rd.length, uint64(total))
+		atomic.StoreUint64(&rd.written, 0)
+		rd.truncate = true
+	case http.StatusRequestedRangeNotSatisfiable:
+		resp.Body.Close()
+		_, total, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil || total != offset {
+			// the partial file is larger than the file
+			if offset == 0 {
+				return nil, fmt.Errorf("Failed to download file - received status code %d", resp.StatusCode)
+			}
+			return rd.restart(url)
+		}
+		// the partial file already holds the entire file
+		atomic.StoreUint64(&rd.length, uint64(offset))
+		return nil, nil
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("Failed to download file - received status code %d", resp.StatusCode)
+	}
+	if err = rd.saveETag(resp.Header.Get("ETag")); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// restart discards the partial file and requests the file from the start
+func (rd *ResumableDownload) restart(url string) (io.ReadCloser, error) {
+	logrus.Debugf("Discarding the partial file %s since it does not match the file being downloaded", rd.path)
+	if err := rd.Remove(); err != nil {
+		return nil, err
+	}
+	return rd.Open(url)
+}
+
+// Remove deletes the partial file along with the ETag kept for it
+func (rd *ResumableDownload) Remove() error {
+	rd.etag = ""
+	if err := os.Remove(rd.etagPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(rd.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// saveETag keeps the ETag of the file being downloaded so that a later Open,
+// even in another process, can tell whether the file changed
+func (rd *ResumableDownload) saveETag(etag string) error {
+	if etag == rd.etag {
+		return nil
+	}
+	rd.etag = etag
+	if etag == "" {
+		if err := os.Remove(rd.etagPath()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return ioutil.WriteFile(rd.etagPath(), []byte(etag), 0600)
+}
+
+func (rd *ResumableDownload) etagPath() string {
+	return rd.path + ".etag"
+}
+
+// Append writes a body returned from Open to the end of the partial file and
+// closes it. It returns nil once the entire file has been downloaded.
+func (rd *ResumableDownload) Append(body io.ReadCloser) error {
+	if body == nil {
+		return nil
+	}
+	defer body.Close()
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if rd.truncate {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	file, err := os.OpenFile(rd.path, flags, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, io.TeeReader(body, rd))
+	if err != nil {
+		return err
+	}
+	if rd.Transferred() < rd.Length() {
+		return errors.New("Connection closed before the download finished")
+	}
+	return nil
+}
+
+// Write counts the bytes that have been appended to the partial file
+func (rd *ResumableDownload) Write(p []byte) (int, error) {
+	atomic.AddUint64(&rd.written, uint64(len(p)))
+	throttle(len(p))
+	return len(p), nil
+}
+
+func (rd *ResumableDownload) Transferred() ByteSize {
+	return ByteSize(atomic.LoadUint64(&rd.written))
+}
+
+func (rd *ResumableDownload) Length() ByteSize {
+	return ByteSize(atomic.LoadUint64(&rd.length))
+}
+
+// parseContentRange extracts the first byte and the complete length from a
+// Content-Range header of the form "bytes 100-199/200". The first byte is -1
+// for a header of the form "bytes */200".
+func parseContentRange(contentRange string) (int64, int64, error) {
+	invalid := fmt.Errorf("Invalid Content-Range header \"%s\"", contentRange)
+	if !strings.HasPrefix(contentRange, "bytes ") {
+		return 0, 0, invalid
+	}
+	i := strings.LastIndex(contentRange, "/")
+	if i == -1 || contentRange[i+1:] == "*" {
+		return 0, 0, invalid
+	}
+	total, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	r := contentRange[len("bytes "):i]
+	if r == "*" {
+		return -1, total, nil
+	}
+	j := strings.Index(r, "-")
+	if j == -1 {
+		return 0, 0, invalid
+	}
+	start, err := strconv.ParseInt(r[:j], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	return start, total, nil
+}
@@ -0,0 +1,131 @@
+package transfer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const resumableContent = "hello world"
+
+var resumableTests = []struct {
+	partial       string
+	etag          string
+	wrongStart    bool
+	expectRange   string
+	expectRestart bool
+}{
+	{"", "", false, "bytes=0-", false},
+	{"hello", `"v2"`, false, "bytes=5-", false},
+	{resumableContent, `"v2"`, false, "bytes=11-", false},
+	{"jello", `"v1"`, false, "bytes=5-", false},
+	{"hello", `"v2"`, true, "bytes=5-", true},
+	{resumableContent + " and more", `"v2"`, false, "bytes=20-", true},
+	{"hello", "", false, "bytes=0-", false},
+}
+
+func TestResumableDownload(t *testing.T) {
+	for _, data := range resumableTests {
+		t.Logf("Data: %+v", data)
+		dir, err := ioutil.TempDir("", "resumable")
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "backup.part")
+		if data.partial != "" {
+			ioutil.WriteFile(path, []byte(data.partial), 0600)
+		}
+		if data.etag != "" {
+			ioutil.WriteFile(path+".etag", []byte(data.etag), 0600)
+		}
+		var ranges []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ranges = append(ranges, r.Header.Get("Range"))
+			w.Header().Set("ETag", `"v2"`)
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.Header.Get("Range"), "bytes="), "-"))
+			if ifRange := r.Header.Get("If-Range"); r.Header.Get("Range") == "" || (ifRange != "" && ifRange != `"v2"`) {
+				w.Header().Set("Content-Length", strconv.Itoa(len(resumableContent)))
+				fmt.Fprint(w, resumableContent)
+				return
+			}
+			if start >= len(resumableContent) {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(resumableContent)))
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			if data.wrongStart && start > 0 {
+				start--
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(resumableContent)-1, len(resumableContent)))
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, resumableContent[start:])
+		}))
+
+		// test
+		rd := NewResumableDownload(path)
+		body, err := rd.Open(server.URL)
+		if err == nil {
+			err = rd.Append(body)
+		}
+
+		// assert
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		if len(ranges) == 0 || ranges[0] != data.expectRange {
+			t.Errorf("Expected the first request to be for %s but got %v", data.expectRange, ranges)
+		}
+		if restarted := len(ranges) > 1; restarted != data.expectRestart {
+			t.Errorf("Expected a restart to be %t but requested %v", data.expectRestart, ranges)
+		}
+		if b, _ := ioutil.ReadFile(path); string(b) != resumableContent {
+			t.Errorf("Expected the partial file to hold %q but got %q", resumableContent, string(b))
+		}
+		if data.partial != resumableContent {
+			if b, _ := ioutil.ReadFile(path + ".etag"); string(b) != `"v2"` {
+				t.Errorf("Expected the ETag to be kept but got %q", string(b))
+			}
+		}
+		if err = rd.Remove(); err != nil {
+			t.Errorf("Unexpected error removing the partial file: %s", err)
+		}
+		if _, err = os.Stat(path + ".etag"); !os.IsNotExist(err) {
+			t.Error("Expected the ETag to be removed with the partial file")
+		}
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+var parseContentRangeTests = []struct {
+	contentRange string
+	start        int64
+	total        int64
+	expectErr    bool
+}{
+	{"bytes 100-199/200", 100, 200, false},
+	{"bytes */200", -1, 200, false},
+	{"bytes 0-99/*", 0, 0, true},
+	{"100-199/200", 0, 0, true},
+	{"bytes a-199/200", 0, 0, true},
+}
+
+func TestParseContentRange(t *testing.T) {
+	for _, data := range parseContentRangeTests {
+		t.Logf("Data: %+v", data)
+		start, total, err := parseContentRange(data.contentRange)
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if start != data.start || total != data.total {
+			t.Errorf("Expected %d and %d but got %d and %d", data.start, data.total, start, total)
+		}
+	}
+}
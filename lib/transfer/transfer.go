@@ -1,9 +1,10 @@
 package transfer
 
 import (
-	"fmt"
 	"io"
 	"sync/atomic"
+
+	"github.com/daticahealth/cli/config"
 )
 
 type ByteSize float64
@@ -17,17 +18,7 @@ const (
 )
 
 func (b ByteSize) String() string {
-	switch {
-	case b >= TB:
-		return fmt.Sprintf("%.3fTB", b/TB)
-	case b >= GB:
-		return fmt.Sprintf("%.2fGB", b/GB)
-	case b >= MB:
-		return fmt.Sprintf("%.1fMB", b/MB)
-	case b >= KB:
-		return fmt.Sprintf("%.0fKB", b/KB)
-	}
-	return fmt.Sprintf("%.0fB", b)
+	return config.FormatBytes(float64(b))
 }
 
 type Transfer interface {
@@ -54,6 +45,7 @@ func NewReaderTransfer(reader io.Reader, length int) *ReaderTransfer {
 func (rt *ReaderTransfer) Read(p []byte) (int, error) {
 	n, err := rt.reader.Read(p)
 	atomic.AddUint64(&rt.read, uint64(n))
+	throttle(n)
 	return n, err
 }
 
@@ -84,6 +76,7 @@ func NewWriteCloserTransfer(writeCloser io.WriteCloser, length int) *WriteCloser
 func (wct *WriteCloserTransfer) Write(p []byte) (int, error) {
 	n, err := wct.writeCloser.Write(p)
 	atomic.AddUint64(&wct.written, uint64(n))
+	throttle(n)
 	return n, err
 }
 
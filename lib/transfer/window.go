@@ -0,0 +1,141 @@
+package transfer
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/daticahealth/cli/config"
+)
+
+var windowRegex = regexp.MustCompile(`^([01]?[0-9]|2[0-3]):([0-5][0-9])-([01]?[0-9]|2[0-3]):([0-5][0-9])$`)
+
+// Window is a daily period of time, such as 22:00-06:00, that heavy
+// transfers are allowed to start in. A window whose end is before its start
+// wraps past midnight.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// window is the schedule window every heavy transfer waits for, nil when
+// transfers can start at any time
+var window *Window
+
+// ParseWindow parses a schedule window given as "HH:MM-HH:MM" in the
+// configured timezone. An empty window means transfers can start at any time.
+func ParseWindow(w string) (*Window, error) {
+	if w == "" {
+		return nil, nil
+	}
+	matches := windowRegex.FindStringSubmatch(w)
+	if matches == nil {
+		return nil, fmt.Errorf("Invalid schedule window \"%s\". Windows are given as a start and end time of day, such as 22:00-06:00", w)
+	}
+	parsed := &Window{
+		Start: clock(matches[1], matches[2]),
+		End:   clock(matches[3], matches[4]),
+	}
+	if parsed.Start == parsed.End {
+		return nil, fmt.Errorf("Invalid schedule window \"%s\", the start and end times must be different", w)
+	}
+	return parsed, nil
+}
+
+func clock(hours, minutes string) time.Duration {
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+}
+
+// Contains returns whether the given time falls inside the window
+func (w *Window) Contains(t time.Time) bool {
+	t = t.In(config.Timezone())
+	offset := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// Next returns the time the window next opens at or after the given time. If
+// the window is already open the given time is returned.
+func (w *Window) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	local := t.In(config.Timezone())
+	next := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location()).Add(w.Start)
+	if !next.After(t) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, local.Location()).Add(w.Start)
+	}
+	return next
+}
+
+// SetScheduleWindow defers every heavy transfer to the given window. A nil
+// window lets transfers start at any time.
+func SetScheduleWindow(w *Window) {
+	window = w
+}
+
+// detached is whether the command exits without waiting for its transfers, in
+// which case a transfer deferred by the schedule window is started in the
+// background once the window opens
+var detached bool
+
+// SetDetached sets whether the command exits without waiting for its
+// transfers
+func SetDetached(d bool) {
+	detached = d
+}
+
+// DeferredError is returned for a transfer that was not started because the
+// schedule window is closed, so that the command fails and a cron job running
+// it can tell it apart from a transfer that was done
+type DeferredError struct {
+	Transfer string
+	Opens    time.Time
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("The schedule window is closed, so the %s was not started. The window opens at %s, run this command again then.", e.Transfer, config.FormatTimestamp(e.Opens))
+}
+
+// sleep waits for the given duration, and is replaced in tests
+var sleep = time.Sleep
+
+// Deferred returns whether the given transfer should not be started by this
+// process because the schedule window is closed. A detached command is run
+// again by a background process that waits for the window to open, and a
+// *DeferredError is returned otherwise. Transfers that are already running
+// when the window closes are not interrupted.
+func Deferred(transfer string) (bool, error) {
+	return deferred(transfer, time.Now())
+}
+
+func deferred(transfer string, now time.Time) (bool, error) {
+	if window == nil {
+		return false, nil
+	}
+	next := window.Next(now)
+	if !next.After(now) {
+		return false, nil
+	}
+	if os.Getenv(config.ScheduleWaitEnvVar) != "" {
+		logrus.Printf("Waiting until %s for the schedule window to open before starting the %s", config.FormatTimestamp(next), transfer)
+		sleep(next.Sub(now))
+		return false, nil
+	}
+	if !detached {
+		return true, &DeferredError{Transfer: transfer, Opens: next}
+	}
+	pid, logPath, err := startBackground()
+	if err != nil {
+		return true, fmt.Errorf("The schedule window is closed and the %s could not be left to a background process: %s", transfer, err)
+	}
+	logrus.Printf("The schedule window is closed, so the %s will be started at %s by a background process (PID %d) logging to %s", transfer, config.FormatTimestamp(next), pid, logPath)
+	return true, nil
+}
@@ -0,0 +1,167 @@
+package transfer
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daticahealth/cli/config"
+)
+
+var parseWindowTests = []struct {
+	window    string
+	start     time.Duration
+	end       time.Duration
+	expectNil bool
+	expectErr bool
+}{
+	{"", 0, 0, true, false},
+	{"22:00-06:00", 22 * time.Hour, 6 * time.Hour, false, false},
+	{"9:30-17:45", 9*time.Hour + 30*time.Minute, 17*time.Hour + 45*time.Minute, false, false},
+	{"00:00-23:59", 0, 23*time.Hour + 59*time.Minute, false, false},
+	{"22:00-22:00", 0, 0, false, true},
+	{"24:00-06:00", 0, 0, false, true},
+	{"22:60-06:00", 0, 0, false, true},
+	{"22:00", 0, 0, false, true},
+	{"10pm-6am", 0, 0, false, true},
+}
+
+func TestParseWindow(t *testing.T) {
+	for _, data := range parseWindowTests {
+		t.Logf("Data: %+v", data)
+		w, err := ParseWindow(data.window)
+		if err != nil != data.expectErr {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if data.expectErr {
+			continue
+		}
+		if (w == nil) != data.expectNil {
+			t.Errorf("Expected no window: %t, but got %+v", data.expectNil, w)
+			continue
+		}
+		if w != nil && (w.Start != data.start || w.End != data.end) {
+			t.Errorf("Expected %s-%s but got %s-%s", data.start, data.end, w.Start, w.End)
+		}
+	}
+}
+
+var windowTests = []struct {
+	window   string
+	now      string
+	contains bool
+	next     string
+}{
+	{"22:00-06:00", "2017-06-07T23:00:00Z", true, "2017-06-07T23:00:00Z"},
+	{"22:00-06:00", "2017-06-07T03:00:00Z", true, "2017-06-07T03:00:00Z"},
+	{"22:00-06:00", "2017-06-07T06:00:00Z", false, "2017-06-07T22:00:00Z"},
+	{"22:00-06:00", "2017-06-07T12:00:00Z", false, "2017-06-07T22:00:00Z"},
+	{"09:00-17:00", "2017-06-07T09:00:00Z", true, "2017-06-07T09:00:00Z"},
+	{"09:00-17:00", "2017-06-07T08:59:00Z", false, "2017-06-07T09:00:00Z"},
+	{"09:00-17:00", "2017-06-07T17:00:00Z", false, "2017-06-08T09:00:00Z"},
+}
+
+func TestWindow(t *testing.T) {
+	config.SetTimezone("UTC")
+	defer config.SetTimezone("local")
+	for _, data := range windowTests {
+		t.Logf("Data: %+v", data)
+		w, _ := ParseWindow(data.window)
+		now, _ := time.Parse(time.RFC3339, data.now)
+		if contains := w.Contains(now); contains != data.contains {
+			t.Errorf("Expected contains to be %t but got %t", data.contains, contains)
+		}
+		next, _ := time.Parse(time.RFC3339, data.next)
+		if actual := w.Next(now); !actual.Equal(next) {
+			t.Errorf("Expected the window to open at %s but got %s", next, actual)
+		}
+		SetScheduleWindow(w)
+		if d, _ := deferred("backup", now); d == data.contains {
+			t.Errorf("Expected the transfer to be deferred: %t, but got %t", !data.contains, d)
+		}
+	}
+	SetScheduleWindow(nil)
+	if d, err := deferred("backup", time.Now()); d || err != nil {
+		t.Errorf("Expected transfers not to be deferred without a window")
+	}
+}
+
+func TestWindowTimezone(t *testing.T) {
+	if err := config.SetTimezone("America/Chicago"); err != nil {
+		t.Fatal(err)
+	}
+	defer config.SetTimezone("local")
+	w, _ := ParseWindow("22:00-06:00")
+	// 03:00 UTC is 22:00 in Chicago during daylight saving time
+	now := time.Date(2017, 6, 7, 3, 0, 0, 0, time.UTC)
+	if !w.Contains(now) {
+		t.Errorf("Expected the window to be open at %s", now)
+	}
+	if w.Contains(now.Add(-time.Minute)) {
+		t.Errorf("Expected the window to be closed at %s", now.Add(-time.Minute))
+	}
+	if next := w.Next(now.Add(-time.Hour)); !next.Equal(now) {
+		t.Errorf("Expected the window to open at %s but got %s", now, next)
+	}
+}
+
+func TestDeferred(t *testing.T) {
+	defer func(start func() (int, string, error), s func(time.Duration)) {
+		startBackground, sleep = start, s
+	}(startBackground, sleep)
+	defer SetScheduleWindow(nil)
+	defer SetDetached(false)
+	defer os.Unsetenv(config.ScheduleWaitEnvVar)
+	// the window opens at 22:00 and the transfer is started at 20:00
+	w, _ := ParseWindow("22:00-06:00")
+	now := time.Date(2017, 6, 7, 20, 0, 0, 0, config.Timezone())
+
+	tests := []struct {
+		window        *Window
+		detached      bool
+		waiting       bool
+		expectDefer   bool
+		expectErr     bool
+		expectStarted bool
+		expectSlept   time.Duration
+	}{
+		{nil, false, false, false, false, false, 0},
+		{w, false, false, true, true, false, 0},
+		{w, true, false, true, false, true, 0},
+		{w, true, true, false, false, false, 2 * time.Hour},
+	}
+	for _, data := range tests {
+		t.Logf("Data: %+v", data)
+		started := false
+		startBackground = func() (int, string, error) {
+			started = true
+			return 1, "schedule.log", nil
+		}
+		var slept time.Duration
+		sleep = func(d time.Duration) { slept = d }
+		SetScheduleWindow(data.window)
+		SetDetached(data.detached)
+		os.Unsetenv(config.ScheduleWaitEnvVar)
+		if data.waiting {
+			os.Setenv(config.ScheduleWaitEnvVar, "true")
+		}
+
+		// test
+		d, err := deferred("backup", now)
+
+		// assert
+		if d != data.expectDefer {
+			t.Errorf("Expected deferred to be %t but got %t", data.expectDefer, d)
+		}
+		if _, ok := err.(*DeferredError); ok != data.expectErr {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if started != data.expectStarted {
+			t.Errorf("Expected a background process to be started: %t", data.expectStarted)
+		}
+		if slept != data.expectSlept {
+			t.Errorf("Expected to wait %s but waited %s", data.expectSlept, slept)
+		}
+	}
+}
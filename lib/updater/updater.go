@@ -12,6 +12,11 @@ package updater
 // Changes 6/6/16:
 //     removed all partial binary checking to cut down on release build time
 //     now every update is a full replacement
+// Changes 10/17/26:
+//     verify the detached signature of the full binary, and refuse updates
+//     when built without a signing key
+//     sign the version and platform along with the hash of the binary, so an
+//     older signed binary cannot be offered as a newer version
 
 // Update protocol:
 //
@@ -20,7 +25,8 @@ package updater
 //   200 ok
 //   {
 //       "Version": "2",
-//       "Sha256": "..." // base64
+//       "Sha256": "...", // base64
+//       "Signature": "..." // base64
 //   }
 //
 // then
@@ -42,7 +48,11 @@ package updater
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -81,6 +91,20 @@ var AutoUpdater = &Updater{
 
 // ErrHashMismatch represents a mismatch in the expected hash and the calculated hash
 var ErrHashMismatch = errors.New("new file hash mismatch after patch")
+
+// ErrSignatureMismatch represents a signature that was not made by the
+// signing key over the version, platform, and hash of the new binary
+var ErrSignatureMismatch = errors.New("new file signature does not match the signing key")
+
+// ErrNoSigningKey is returned for updates to a binary that was built without
+// a signing key, which has no way to verify them
+var ErrNoSigningKey = errors.New("this build has no signing key to verify updates with")
+
+// signingKey is the base64 encoded DER public key that release binaries are
+// signed with. It is set at build time by the release script with
+// -ldflags "-X github.com/daticahealth/cli/lib/updater.signingKey=...".
+// Builds without a signing key do not install updates.
+var signingKey string
 var up = update.New()
 
 // Updater is the configuration and runtime data for doing an update.
@@ -108,8 +132,9 @@ type Updater struct {
 	DiffURL        string // Base URL for diff downloads.
 	Dir            string // Directory to store selfupdate state.
 	Info           struct {
-		Version string
-		Sha256  []byte
+		Version   string
+		Sha256    []byte
+		Signature []byte
 	}
 }
 
@@ -178,6 +203,10 @@ func (u *Updater) update() error {
 	if err != nil {
 		if err == ErrHashMismatch {
 			logrus.Warnln("update: hash mismatch from full binary")
+		} else if err == ErrSignatureMismatch {
+			logrus.Warnln("update: signature mismatch from full binary")
+		} else if err == ErrNoSigningKey {
+			logrus.Warnln("update: this build of the CLI was made without a signing key and cannot verify updates")
 		} else {
 			logrus.Warnln("update: error fetching full binary,", err)
 		}
@@ -226,6 +255,9 @@ func (u *Updater) fetchAndVerifyFullBin() ([]byte, error) {
 	if !verified {
 		return nil, ErrHashMismatch
 	}
+	if err = verifySignature(u.Info.Version, plat, u.Info.Sha256, u.Info.Signature, signingKey); err != nil {
+		return nil, err
+	}
 	return bin, nil
 }
 
@@ -279,6 +311,43 @@ func verifySha(bin []byte, sha []byte) bool {
 	return bytes.Equal(h.Sum(nil), sha)
 }
 
+// signedPayload returns what the signature of an update is made over: the
+// version and platform of the update and the hex encoded SHA-256 hash of its
+// binary, separated by spaces. Signing the version keeps a signed binary from
+// being offered again as any other version.
+func signedPayload(version, platform string, sha []byte) []byte {
+	return []byte(fmt.Sprintf("%s %s %x", version, platform, sha))
+}
+
+// verifySignature verifies the RSA PKCS #1 v1.5 signature of the SHA-256 hash
+// of the signed payload of the given update against the given base64 encoded
+// DER public key. The hash of the binary itself is verified separately.
+func verifySignature(version, platform string, sha []byte, signature []byte, key string) error {
+	if key == "" {
+		return ErrNoSigningKey
+	}
+	der, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return err
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return err
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("signing key is not an RSA public key")
+	}
+	if len(signature) == 0 {
+		return ErrSignatureMismatch
+	}
+	h := sha256.Sum256(signedPayload(version, platform, sha))
+	if rsa.VerifyPKCS1v15(rsaPub, crypto.SHA256, h[:], signature) != nil {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
 func writeTime(path string, t time.Time) bool {
 	return ioutil.WriteFile(path, []byte(t.Format(time.RFC3339)), 0644) == nil
 }
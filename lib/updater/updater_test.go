@@ -0,0 +1,67 @@
+package updater
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+)
+
+// testKey returns a new RSA key and its public key encoded like signingKey
+func testKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, base64.StdEncoding.EncodeToString(der)
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, version, platform string, sha []byte) []byte {
+	h := sha256.Sum256(signedPayload(version, platform, sha))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, pub := testKey(t)
+	otherKey, _ := testKey(t)
+	sha := sha256.Sum256([]byte("new binary"))
+	tampered := sha256.Sum256([]byte("tampered binary"))
+	signature := sign(t, key, "3.6.0", "linux-amd64", sha[:])
+	tests := []struct {
+		name      string
+		version   string
+		platform  string
+		sha       []byte
+		signature []byte
+		key       string
+		expected  error
+	}{
+		{"good signature", "3.6.0", "linux-amd64", sha[:], signature, pub, nil},
+		{"tampered binary", "3.6.0", "linux-amd64", tampered[:], signature, pub, ErrSignatureMismatch},
+		{"replayed as a newer version", "3.7.0", "linux-amd64", sha[:], signature, pub, ErrSignatureMismatch},
+		{"replayed for another platform", "3.6.0", "darwin-amd64", sha[:], signature, pub, ErrSignatureMismatch},
+		{"signed by another key", "3.6.0", "linux-amd64", sha[:], sign(t, otherKey, "3.6.0", "linux-amd64", sha[:]), pub, ErrSignatureMismatch},
+		{"missing signature", "3.6.0", "linux-amd64", sha[:], nil, pub, ErrSignatureMismatch},
+		{"missing signing key", "3.6.0", "linux-amd64", sha[:], signature, "", ErrNoSigningKey},
+	}
+	for _, data := range tests {
+		t.Logf("Data: %s", data.name)
+		if err := verifySignature(data.version, data.platform, data.sha, data.signature, data.key); err != data.expected {
+			t.Errorf("Expected %v but got %v", data.expected, err)
+		}
+	}
+	if err := verifySignature("3.6.0", "linux-amd64", sha[:], signature, "not a key"); err == nil {
+		t.Errorf("Expected an invalid signing key to fail verification")
+	}
+}
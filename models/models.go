@@ -2,6 +2,66 @@ package models
 
 import "github.com/jault3/mow.cli"
 
+// ActivityEvent is a single CLI operation run by a user, from an
+// organization's audit log
+type ActivityEvent struct {
+	UsersID   string `json:"usersId"`
+	Email     string `json:"email"`
+	Operation string `json:"operation"`
+	Class     string `json:"class"`
+	Timestamp string `json:"timestamp"`
+}
+
+// AlertRule asks the platform to send a notification to the inbox of the
+// members of an environment whenever an event happens to a service, or a
+// metric of the service crosses a threshold for a period of time. The
+// notification is also sent to every email address and webhook in Notify.
+type AlertRule struct {
+	ID        string        `json:"id,omitempty"`
+	Event     string        `json:"event,omitempty"`
+	Metric    string        `json:"metric,omitempty"`
+	Operator  string        `json:"operator,omitempty"`
+	Threshold float64       `json:"threshold,omitempty"` // a percentage of the size of the service
+	Duration  int           `json:"duration,omitempty"`  // seconds the threshold must be crossed for
+	ServiceID string        `json:"service"`
+	Target    string        `json:"target,omitempty"`
+	Notify    []AlertTarget `json:"notify,omitempty"`
+	CreatedAt string        `json:"created_at,omitempty"`
+}
+
+// AlertTarget is an email address or webhook URL an alert is sent to
+type AlertTarget struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// AccessGrant is a role given to a user of an organization for a limited
+// time, after which the user's previous role is restored
+type AccessGrant struct {
+	ID             string `json:"id,omitempty"`
+	UsersID        string `json:"usersID"`
+	Email          string `json:"email,omitempty"`
+	RoleID         int    `json:"roleID"`
+	PreviousRoleID int    `json:"previousRoleID,omitempty"`
+	TTL            int64  `json:"ttl,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	GrantedBy      string `json:"grantedBy,omitempty"`
+	CreatedAt      string `json:"createdAt,omitempty"`
+	ExpiresAt      string `json:"expiresAt,omitempty"`
+}
+
+// AuditEvent is a single change made in an organization, from an
+// organization's audit trail
+type AuditEvent struct {
+	ID            string `json:"id"`
+	Timestamp     string `json:"timestamp"`
+	ActorEmail    string `json:"actorEmail"`
+	Action        string `json:"action"`
+	Target        string `json:"target"`
+	EnvironmentID string `json:"environmentId,omitempty"`
+	BreakGlassID  string `json:"breakGlassId,omitempty"` // the break-glass session the change was made in, if any
+}
+
 // AssociatedEnv holds information about an associated environment
 type AssociatedEnv struct {
 	EnvironmentID string `json:"environmentId"`
@@ -12,6 +72,19 @@ type AssociatedEnv struct {
 	OrgID         string `json:"organizationId"`
 }
 
+// BreakGlassSession is an emergency session that lifts the production guards
+// of the CLI for an organization until it ends or expires.
+// Every request made during the session is tagged with its ID in the audit
+// trail.
+type BreakGlassSession struct {
+	ID            string `json:"id"`
+	OrgID         string `json:"organizationId"`
+	EnvironmentID string `json:"environmentId,omitempty"`
+	Reason        string `json:"reason"`
+	StartedAt     string `json:"startedAt,omitempty"`
+	ExpiresAt     string `json:"expiresAt"`
+}
+
 type Cert struct {
 	Name    string `json:"name"`
 	PubKey  string `json:"sslCertFile"`
@@ -27,7 +100,59 @@ type Command struct {
 	Name      string
 	ShortHelp string
 	LongHelp  string
-	CmdFunc   func(settings *Settings) func(cmd *cli.Cmd)
+	Category  string
+	// JSONOutput is a value of the type printed by the --json option of the
+	// command, if it has one, used to publish the JSON schema of its output
+	JSONOutput interface{}
+	CmdFunc    func(settings *Settings) func(cmd *cli.Cmd)
+}
+
+// Categories that top level commands are grouped by in the help message
+const (
+	CategoryEnvironment   = "Environment"
+	CategoryData          = "Data"
+	CategoryDeploy        = "Deploy"
+	CategoryAccess        = "Access"
+	CategoryObservability = "Observability"
+)
+
+// BackupSchedule is how often a database service is backed up automatically
+// and how long those backups are kept
+type BackupSchedule struct {
+	Frequency     string `json:"frequency"`
+	RetentionDays int    `json:"retentionDays"`
+}
+
+// RestoreWindow is the range of time a database service can be restored to
+// a point in
+type RestoreWindow struct {
+	Earliest string `json:"earliest"`
+	Latest   string `json:"latest"`
+}
+
+// BackupManifest lists the segments a backup is stored in. Segments that are
+// unchanged since a previous backup have the same hash, so an incremental
+// export only has to download the segments that changed.
+type BackupManifest struct {
+	JobID    string          `json:"jobId"`
+	Segments []BackupSegment `json:"segments"`
+}
+
+// BackupSegment is a single encrypted segment of a backup. The hash and size
+// are of the decrypted segment.
+type BackupSegment struct {
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+	IV   string `json:"iv"`
+	URL  string `json:"url,omitempty"`
+}
+
+// CacheEntry is a single artifact in the local cache
+type CacheEntry struct {
+	Digest   string   `json:"digest"`
+	Size     int64    `json:"size"`
+	LastUsed string   `json:"lastUsed"`
+	Refs     []string `json:"refs,omitempty"`
 }
 
 // ConsoleCredentials hold the keys necessary for connecting to a console service
@@ -49,6 +174,16 @@ type DeployKey struct {
 	Type string `json:"type"`
 }
 
+// DiskUsage is the disk space used by a job and how much it read and wrote
+type DiskUsage struct {
+	JobID   string  `json:"job"`
+	Total   float64 `json:"total"`
+	Used    float64 `json:"used"`
+	ReadKB  float64 `json:"read_kb"`
+	WriteKB float64 `json:"write_kb"`
+	TS      int     `json:"ts"`
+}
+
 // EncryptionStore holds the values for encryption on backup/import jobs
 type EncryptionStore struct {
 	Key             string `json:"key"`
@@ -66,6 +201,17 @@ type Environment struct {
 	OrgID     string `json:"organizationId"`
 }
 
+// OrgPlan is the plan of an organization and how much of it is in use. A
+// limit of 0 is unlimited, and an empty list of pods allows every pod.
+type OrgPlan struct {
+	Name             string   `json:"name"`
+	EnvironmentLimit int      `json:"environmentLimit"`
+	Environments     int      `json:"environments"`
+	RAMLimit         int      `json:"ramLimit"`
+	RAM              int      `json:"ram"`
+	Pods             []string `json:"pods"`
+}
+
 // Error is a wrapper around an array of errors from the API
 type Error struct {
 	Title       string `json:"title"`
@@ -90,6 +236,28 @@ type GroupMember struct {
 	Email string `json:"email"`
 }
 
+// ESHealth is the cluster health of an Elasticsearch service
+type ESHealth struct {
+	ClusterName         string `json:"cluster_name"`
+	Status              string `json:"status"`
+	NumberOfNodes       int    `json:"number_of_nodes"`
+	NumberOfDataNodes   int    `json:"number_of_data_nodes"`
+	ActivePrimaryShards int    `json:"active_primary_shards"`
+	ActiveShards        int    `json:"active_shards"`
+	RelocatingShards    int    `json:"relocating_shards"`
+	InitializingShards  int    `json:"initializing_shards"`
+	UnassignedShards    int    `json:"unassigned_shards"`
+}
+
+// ESIndex is a single index of an Elasticsearch service
+type ESIndex struct {
+	Index     string `json:"index"`
+	Health    string `json:"health"`
+	Status    string `json:"status"`
+	DocsCount string `json:"docs.count"`
+	StoreSize string `json:"store.size"`
+}
+
 // Hits contain arrays of log data
 type Hits struct {
 	Total    int64      `json:"total"`
@@ -102,6 +270,7 @@ type HTTPManager interface {
 	ConvertResp(b []byte, statusCode int, s interface{}) error
 	Get(body []byte, url string, headers map[string][]string) ([]byte, int, error)
 	Post(body []byte, url string, headers map[string][]string) ([]byte, int, error)
+	PostSigned(sign func(headers map[string][]string) ([]byte, error), url string, headers map[string][]string) ([]byte, int, error)
 	PostFile(filepath string, url string, headers map[string][]string) ([]byte, int, error)
 	PutFile(filepath string, url string, headers map[string][]string) ([]byte, int, error)
 	Put(body []byte, url string, headers map[string][]string) ([]byte, int, error)
@@ -161,10 +330,17 @@ type Metrics struct {
 // MetricsData is a container for each type of metrics: network, memory, etc.
 type MetricsData struct {
 	CPUUsage     *[]CPUUsage     `json:"cpu.usage"`
+	DiskUsage    *[]DiskUsage    `json:"disk.usage"`
 	MemoryUsage  *[]MemoryUsage  `json:"memory.usage"`
 	NetworkUsage *[]NetworkUsage `json:"network.usage"`
 }
 
+// MultipartUpload holds the temporary URLs for each part of a large upload
+type MultipartUpload struct {
+	UploadID string   `json:"uploadId"`
+	URLs     []string `json:"urls"`
+}
+
 type NetworkUsage struct {
 	JobID     string  `json:"job"`
 	RXDropped float64 `json:"rx_dropped"`
@@ -178,6 +354,27 @@ type NetworkUsage struct {
 	TS        int     `json:"ts"`
 }
 
+// Operation is a multi-step command recorded in the local journal so that it
+// can be resumed if interrupted
+type Operation struct {
+	ID              string            `json:"id"`
+	Command         string            `json:"command"`
+	EnvironmentID   string            `json:"environmentId"`
+	EnvironmentName string            `json:"environmentName"`
+	ServiceID       string            `json:"serviceId"`
+	Pod             string            `json:"pod"`
+	OrgID           string            `json:"organizationId"`
+	Steps           []OperationStep   `json:"steps"`
+	Options         map[string]string `json:"options,omitempty"` // options the command was run with that apply to every step
+	CreatedAt       string            `json:"createdAt"`
+}
+
+// OperationStep is a single unit of work within an Operation
+type OperationStep struct {
+	Name string `json:"name"`
+	Done bool   `json:"done"`
+}
+
 type Org struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -186,10 +383,12 @@ type Org struct {
 
 // OrgUser users who have access to an org
 type OrgUser struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	RoleID int    `json:"roleID"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	RoleID     int    `json:"roleID"`
+	LastLogin  string `json:"lastLogin"`
+	MFAEnabled bool   `json:"mfaEnabled"`
 }
 
 // Payload is the payload of a job
@@ -202,6 +401,11 @@ type Pod struct {
 	Name                 string `json:"name"`
 	PHISafe              bool   `json:"phiSafe"`
 	ImportRequiresLength bool   `json:"importRequiresLength"`
+	Streaming            bool   `json:"streaming"` // whether the pod serves streaming endpoints
+	// MinTLSVersion is the lowest TLS version the pod accepts, such as "1.2",
+	// from TLSCutover on if it is set
+	MinTLSVersion string `json:"minTlsVersion,omitempty"`
+	TLSCutover    string `json:"tlsCutover,omitempty"`
 }
 
 // Job job
@@ -215,7 +419,25 @@ type Job struct {
 	MetricsData      *[]MetricsData   `json:"metrics"`
 	Spec             *Spec            `json:"spec"`
 	Target           string           `json:"target,omitempty"`
+	Schedule         string           `json:"schedule,omitempty"`
 	IsSnapshotBackup *bool            `json:"isSnapshotBackup,omitempty"`
+	Termination      *JobTermination  `json:"termination,omitempty"`
+	Size             int64            `json:"size,omitempty"`      // the size of a backup in bytes
+	Scheduled        bool             `json:"scheduled,omitempty"` // whether a backup was created by the backup schedule
+}
+
+// BuildOutput is a chunk of the output of a build job. Offset is where the
+// next chunk starts.
+type BuildOutput struct {
+	Lines  []string `json:"lines"`
+	Offset int      `json:"offset"`
+}
+
+// JobTermination describes how the process of a finished job ended
+type JobTermination struct {
+	Reason     string `json:"reason"`
+	ExitCode   int    `json:"exit_code"`
+	FinishedAt string `json:"finished_at"`
 }
 
 // PodWrapper pod wrapper
@@ -230,9 +452,21 @@ type PostInvite struct {
 }
 
 type Release struct {
-	Name      string `json:"release,omitempty"`
-	CreatedAt string `json:"created_at,omitempty"`
-	Notes     string `json:"metadata,omitempty"`
+	Name       string `json:"release,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	Notes      string `json:"metadata,omitempty"`
+	GitSHA     string `json:"git_sha,omitempty"`
+	DeployedBy string `json:"deployed_by,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+// ReportSchedule is a report that is generated and emailed on a recurring
+// schedule
+type ReportSchedule struct {
+	ID     string   `json:"id,omitempty"`
+	Type   string   `json:"type"`
+	Cron   string   `json:"cron"`
+	Emails []string `json:"emails"`
 }
 
 // ReportedError is the standard error model sent back from the API
@@ -242,8 +476,17 @@ type ReportedError struct {
 }
 
 type Role struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions,omitempty"`
+	Custom      bool     `json:"custom,omitempty"`
+}
+
+// SavedCommand is a CLI invocation saved under a short name. The command may
+// hold {param} and {param=default} placeholders filled in when it is run.
+type SavedCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description,omitempty"`
 }
 
 // Service service
@@ -262,6 +505,23 @@ type Service struct {
 	WorkerScale    int               `json:"worker_scale,omitempty"`
 	ReleaseVersion string            `json:"release_version,omitempty"`
 	Redeployable   bool              `json:"redeployable,omitempty"`
+	Runtime        *RuntimePins      `json:"runtime,omitempty"`
+}
+
+// RuntimePins are the build stack and language runtime versions a code
+// service is built with, instead of the versions the buildpack picks
+type RuntimePins struct {
+	Stack    string            `json:"stack,omitempty"`
+	Versions map[string]string `json:"versions,omitempty"` // keyed by runtime, such as "node"
+}
+
+// BuildCache is the cache of dependencies a code service's builds reuse
+type BuildCache struct {
+	ServiceID string `json:"service"`
+	Size      int64  `json:"size"`
+	Buildpack string `json:"buildpack,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	LastUsed  string `json:"last_used,omitempty"`
 }
 
 // ServiceFile is a file associated with a service
@@ -284,6 +544,20 @@ type ServiceSize struct {
 	CPU      int    `json:"cpu"`
 }
 
+// ServiceAccount is a non-human principal of an organization, such as a CI
+// pipeline, that signs in with a token instead of credentials. The token is
+// only returned when the account is created or its token is rotated.
+type ServiceAccount struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name"`
+	RoleID        int    `json:"roleID"`
+	EnvironmentID string `json:"environmentId"`
+	Token         string `json:"token,omitempty"`
+	CreatedBy     string `json:"createdBy,omitempty"`
+	CreatedAt     string `json:"createdAt,omitempty"`
+	LastUsedAt    string `json:"lastUsedAt,omitempty"`
+}
+
 // Settings holds various settings for the current context. All items with
 // `json:"-"` are never persisted to disk but used in memory for the current
 // command.
@@ -294,22 +568,130 @@ type Settings struct {
 	AuthHostVersion string      `json:"-"`
 	PaasHostVersion string      `json:"-"`
 	Version         string      `json:"-"`
+	ClientCert      string      `json:"-"` // the client certificate presented to the hosts, if any
 	HTTPManager     HTTPManager `json:"-"`
 
 	Username        string                   `json:"-"`
 	Password        string                   `json:"-"`
+	MFACode         string                   `json:"-"` // the one-time password of the second factor used when signing in, if given
+	AccountToken    string                   `json:"-"` // the token of the service account to sign in as, if given
 	EnvironmentID   string                   `json:"-"` // the id of the environment used for the current command
 	ServiceID       string                   `json:"-"` // the id of the service used for the current command
 	Pod             string                   `json:"-"` // the pod used for the current command
 	EnvironmentName string                   `json:"-"` // the name of the environment used for the current command
 	OrgID           string                   `json:"-"` // the org ID the chosen environment for this commands belongs to
+	ServiceLabel    string                   `json:"-"` // the label of the service pinned by the workspace file, used when no service is given
+	WorkspaceErr    error                    `json:"-"` // why the workspace file could not be used, returned by commands that need an association
 	PrivateKeyPath  string                   `json:"private_key_path"`
 	SessionToken    string                   `json:"token"`
+	RefreshToken    string                   `json:"-"`
 	UsersID         string                   `json:"user_id"`
 	Environments    map[string]AssociatedEnv `json:"environments"`
 	Default         string                   `json:"default"`
 	Pods            *[]Pod                   `json:"pods"`
 	PodCheck        int64                    `json:"pod_check"`
+	Timezone        string                   `json:"timezone"` // the default timezone timestamps are printed in
+	Hosts           map[string]HostOverride  `json:"hosts"`    // hosts of self-hosted platform installs keyed by pod name
+	InboxCheck      int64                    `json:"inbox_check"`
+	InboxQuiet      bool                     `json:"inbox_quiet"` // whether the unread notification count is hidden on startup
+	Saved           map[string]SavedCommand  `json:"saved"`       // saved invocations keyed by name
+	Defaults        map[string]string        `json:"defaults"`    // default option values keyed by command and option, such as "logs.follow"
+	Strict          bool                     `json:"strict"`      // whether use of deprecated commands, flags, and output formats fails instead of warning
+	Unavailable     map[string]int64         `json:"unavailable"` // features pods do not serve keyed by pod and feature, with the time to probe them again
+	BreakGlass      *BreakGlassSession       `json:"break_glass,omitempty"`
+}
+
+// Workspace pins the environment and service used by commands run inside a
+// git repo, from the workspace file in the root of the repo
+type Workspace struct {
+	Environment string `yaml:"environment"` // the alias of an associated environment
+	Service     string `yaml:"service"`     // the label of a service in that environment
+	// the code services of a monorepo keyed by service label
+	Services map[string]WorkspaceService `yaml:"services"`
+}
+
+// WorkspaceService maps a code service to the directory of a monorepo its code
+// lives in
+type WorkspaceService struct {
+	Path      string   `yaml:"path"`       // relative to the root of the repo
+	DependsOn []string `yaml:"depends_on"` // labels of services deployed first
+}
+
+// DRManifest is the configuration applied to a disaster recovery environment
+// during a drill, read from a manifest file
+type DRManifest struct {
+	// the configuration of code services keyed by service label
+	Services    map[string]DRManifestService `yaml:"services"`
+	SmokeChecks []string                     `yaml:"smoke_checks"` // URLs that must respond successfully
+}
+
+// DRManifestService is the configuration of a single code service of a
+// disaster recovery environment
+type DRManifestService struct {
+	Vars    map[string]string `yaml:"vars"`
+	Workers map[string]int    `yaml:"workers"` // worker scale keyed by target
+}
+
+// Runbook is a sequence of CLI invocations read from a runbook file
+type Runbook struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Params      map[string]string `yaml:"params"` // default values of the placeholders of the steps
+	Steps       []RunbookStep     `yaml:"steps"`
+}
+
+// RunbookStep is a single CLI invocation of a runbook. Run, If, and Confirm
+// can hold {param} placeholders, which are filled in with the params of the
+// runbook and the outputs captured by earlier steps.
+type RunbookStep struct {
+	Name            string `yaml:"name"`
+	Run             string `yaml:"run"`               // the invocation without the leading "datica"
+	If              string `yaml:"if"`                // the step is skipped unless this condition holds
+	Confirm         string `yaml:"confirm"`           // a question the user must agree to before the step is run
+	Capture         string `yaml:"capture"`           // the name the output of the step is captured as
+	ContinueOnError bool   `yaml:"continue_on_error"` // whether later steps are run when this step fails
+}
+
+// Notification is a platform announcement, deprecation notice, or scheduled
+// maintenance sent to a user
+type Notification struct {
+	ID             string   `json:"id"`
+	Type           string   `json:"type"`
+	Title          string   `json:"title"`
+	Body           string   `json:"body,omitempty"`
+	EnvironmentIDs []string `json:"environments,omitempty"` // the environments the notification is about, if any
+	Pod            string   `json:"pod,omitempty"`
+	Roles          []string `json:"roles,omitempty"` // the roles of the organization a notification sent by a user goes to
+	CreatedAt      string   `json:"created_at"`
+	Read           bool     `json:"read"`
+}
+
+// FeatureFlag is a feature flag stored by the platform for an environment and
+// exposed to its code services
+type FeatureFlag struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Enabled     bool          `json:"enabled"`
+	Targeting   FlagTargeting `json:"targeting"`
+	UpdatedAt   string        `json:"updated_at,omitempty"`
+}
+
+// FlagTargeting limits which code services and what share of requests see a
+// feature flag as enabled
+type FlagTargeting struct {
+	Services   []string `json:"services,omitempty"` // service IDs, empty targets every code service
+	Percentage int      `json:"percentage"`
+}
+
+// HostOverride points the CLI at a self-hosted installation of the platform.
+// Empty hosts are left at their defaults.
+type HostOverride struct {
+	AccountsHost string `json:"accounts_host,omitempty"`
+	AuthHost     string `json:"auth_host,omitempty"`
+	PaasHost     string `json:"paas_host,omitempty"`
+	ClientCert   string `json:"client_cert,omitempty"` // path to a client certificate for mutual TLS
+	ClientKey    string `json:"client_key,omitempty"`  // path to the private key of ClientCert
+	CACert       string `json:"ca_cert,omitempty"`     // path to additional trusted certificate authorities
 }
 
 type Site struct {
@@ -332,29 +714,75 @@ type TempURL struct {
 	URL string `json:"url"`
 }
 
+// UploadPart identifies a single uploaded part of a MultipartUpload
+type UploadPart struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+}
+
 // User is an authenticated User
 type User struct {
 	Username     string `json:"name"`
 	Email        string `json:"email"`
 	SessionToken string `json:"sessionToken"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 	UsersID      string `json:"id"`
 }
 
+// MFAStatus is the state of the second factor of the signed in user
+type MFAStatus struct {
+	Enabled       bool   `json:"enabled"`
+	PreferredMode string `json:"preferredMode"`
+}
+
+// MFAEnrollment is an authenticator app being added as the second factor of
+// the signed in user. It is not used until it is verified with a one-time
+// password.
+type MFAEnrollment struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
 // UserKey is a public key belonging to a user
 type UserKey struct {
 	Name string `json:"name"`
 	Key  string `json:"key"`
 }
 
+// TrashItem is a soft deleted environment variable, site, or invite that can
+// still be restored
+type TrashItem struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"` // var, site, or invite
+	Name      string `json:"name"`
+	ServiceID string `json:"service,omitempty"`
+	DeletedBy string `json:"deleted_by,omitempty"`
+	DeletedAt string `json:"deleted_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
 type Volume struct {
 	ID   int    `json:"id"`
 	Type string `json:"type"`
 	Size int    `json:"size"`
 }
 
+// VolumeSnapshot is a point in time copy of the volumes attached to a service
+type VolumeSnapshot struct {
+	ID        string `json:"id"`
+	JobID     string `json:"job"`
+	Status    string `json:"status"`
+	Size      int    `json:"size"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
 type Workers struct {
 	Limit   int            `json:"worker_limit,omitempty"`
 	Workers map[string]int `json:"workers"`
+	// Paused holds the scale each paused target is restored to when resumed
+	Paused map[string]int `json:"paused,omitempty"`
 }
 
 type Maintenance struct {
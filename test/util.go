@@ -1,12 +1,17 @@
 package test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"testing"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/daticahealth/cli/lib/httpclient"
+	"github.com/daticahealth/cli/lib/keyring"
 	"github.com/daticahealth/cli/models"
 )
 
@@ -30,6 +35,20 @@ const (
 	SvcLabelAlt  = "code2"
 )
 
+// init points the home directory of every test package importing this one at
+// an empty temp dir and keeps secrets in the credentials file in it, so that
+// commands saving the settings never touch the settings or the keychain of
+// the user running the tests.
+func init() {
+	home, err := ioutil.TempDir("", "cli-tests")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	os.Setenv("USERPROFILE", home)
+	os.Setenv(keyring.BackendEnvVar, keyring.FileBackend)
+}
+
 func Setup() (*http.ServeMux, *httptest.Server, *url.URL) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
@@ -48,6 +67,30 @@ func AssertEquals(t *testing.T, expected, actual string) {
 	}
 }
 
+// messageFormatter prints only the message of a log entry, the same as the
+// CLI does for info messages
+type messageFormatter struct{}
+
+func (f *messageFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	return []byte(entry.Message + "\n"), nil
+}
+
+// CaptureOutput returns everything the given function prints through logrus,
+// such as rendered tables, so that tests can assert on it
+func CaptureOutput(f func()) string {
+	var buf bytes.Buffer
+	logger := logrus.StandardLogger()
+	out, formatter := logger.Out, logger.Formatter
+	logrus.SetOutput(&buf)
+	logrus.SetFormatter(&messageFormatter{})
+	defer func() {
+		logrus.SetOutput(out)
+		logrus.SetFormatter(formatter)
+	}()
+	f()
+	return buf.String()
+}
+
 func GetSettings(baseURL string) *models.Settings {
 	return &models.Settings{
 		SessionToken:   "token",
@@ -84,11 +127,11 @@ type FakePrompts struct{}
 func (f *FakePrompts) UsernamePassword() (string, string, error) {
 	return "username", "password", nil
 }
-func (f *FakePrompts) KeyPassphrase(string) string {
-	return "passphrase"
+func (f *FakePrompts) KeyPassphrase(string) (string, error) {
+	return "passphrase", nil
 }
-func (f *FakePrompts) Password(msg string) string {
-	return "password"
+func (f *FakePrompts) Password(msg string) (string, error) {
+	return "password", nil
 }
 func (f *FakePrompts) PHI() error {
 	return nil
@@ -96,6 +139,12 @@ func (f *FakePrompts) PHI() error {
 func (f *FakePrompts) YesNo(msg string) error {
 	return nil
 }
-func (f *FakePrompts) OTP(string) string {
-	return "123456"
+func (f *FakePrompts) OTP(string) (string, error) {
+	return "123456", nil
+}
+func (f *FakePrompts) Select(msg string, options []string) (int, error) {
+	return 0, nil
+}
+func (f *FakePrompts) ConfirmName(msg, name string) error {
+	return nil
 }